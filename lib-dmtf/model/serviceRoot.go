@@ -68,7 +68,7 @@ type Product struct{}
 // ProtocolFeaturesSupported redfish structure
 type ProtocolFeaturesSupported struct {
 	ExcerptQuery    bool         `json:"ExcerptQuery"`
-	ExpandQuery     *ExpandQuery `json:"ExpandQuery:omitempty"`
+	ExpandQuery     *ExpandQuery `json:"ExpandQuery,omitempty"`
 	FilterQuery     bool         `json:"FilterQuery"`
 	OnlyMemberQuery bool         `json:"OnlyMemberQuery"`
 	SelectQuery     bool         `json:"SelectQuery"`
@@ -90,6 +90,10 @@ type RedisStreams struct {
 	RedisInMemoryEncryptedPassword string `toml:"RedisInMemoryEncryptedPassword"`
 	RSAPrivateKey                  []byte
 	RedisInMemoryPassword          []byte
+	// PendingEntryIdleTime defines the time in seconds after which a message
+	// left unacknowledged by a consumer is delivered to another consumer.
+	// DEFAULT = 600
+	PendingEntryIdleTime int `toml:"PendingEntryIdleTime"`
 }
 
 // MQ Create both MQF and KafkaPacket Objects. MQF will be used to store
@@ -0,0 +1,344 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package datacommunicator
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// deliveryTimeout is the maximum time the conformance tests wait for
+// the messages to be delivered
+const deliveryTimeout = 10 * time.Second
+
+// testMessage is the message exchanged in the conformance tests
+type testMessage struct {
+	Seq     int               `json:"Seq"`
+	Payload string            `json:"Payload"`
+	Labels  map[string]string `json:"Labels"`
+}
+
+// collector gathers the messages handed over to a MsgProcess callback
+type collector struct {
+	lock     sync.Mutex
+	messages []map[string]interface{}
+	received chan struct{}
+}
+
+func newCollector() *collector {
+	return &collector{received: make(chan struct{}, 1000)}
+}
+
+func (c *collector) process(d interface{}) {
+	c.lock.Lock()
+	c.messages = append(c.messages, d.(map[string]interface{}))
+	c.lock.Unlock()
+	c.received <- struct{}{}
+}
+
+// subscriber gathers the messages and their offsets handed over to a
+// MsgOffsetProcess callback
+type subscriber struct {
+	collector
+	offsets []uint64
+}
+
+func newSubscriber() *subscriber {
+	return &subscriber{collector: *newCollector()}
+}
+
+func (s *subscriber) process(offset uint64, d interface{}) {
+	s.lock.Lock()
+	s.offsets = append(s.offsets, offset)
+	s.lock.Unlock()
+	s.collector.process(d)
+}
+
+func (c *collector) count() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return len(c.messages)
+}
+
+func (c *collector) seqs() []int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	var seqs []int
+	for _, m := range c.messages {
+		seqs = append(seqs, int(m["Seq"].(float64)))
+	}
+	return seqs
+}
+
+// waitForMessages waits till the collectors together received n messages
+func waitForMessages(t *testing.T, n int, collectors ...*collector) {
+	deadline := time.Now().Add(deliveryTimeout)
+	for time.Now().Before(deadline) {
+		total := 0
+		for _, c := range collectors {
+			total += c.count()
+		}
+		if total >= n {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d messages", n)
+}
+
+// subscriptionDelay is the time given to a subscription started in the
+// background to be established, the messages distributed before that are
+// not delivered to a new consumer group
+const subscriptionDelay = 200 * time.Millisecond
+
+// accept starts the subscription in the background and returns the channel
+// on which the result of Accept is reported once it returns
+func accept(bus MQBus, fn MsgProcess) <-chan error {
+	done := make(chan error, 1)
+	go func() {
+		done <- bus.Accept(fn)
+	}()
+	time.Sleep(subscriptionDelay)
+	return done
+}
+
+// subscribe starts the subscription in the background same as accept
+func subscribe(bus MQBus, fn MsgOffsetProcess) <-chan error {
+	done := make(chan error, 1)
+	go func() {
+		done <- bus.Subscribe(fn)
+	}()
+	time.Sleep(subscriptionDelay)
+	return done
+}
+
+func remove(t *testing.T, bus MQBus, done <-chan error) {
+	if err := bus.Remove(); err != nil {
+		t.Fatalf("Remove() failed: %v", err)
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Accept() returned error after Remove(): %v", err)
+		}
+	case <-time.After(deliveryTimeout):
+		t.Fatal("Accept() did not return after Remove()")
+	}
+}
+
+func distribute(t *testing.T, bus MQBus, from, to int) {
+	for i := from; i < to; i++ {
+		msg := testMessage{
+			Seq:     i,
+			Payload: fmt.Sprintf("message %d", i),
+			Labels:  map[string]string{"OriginOfCondition": "/redfish/v1/Systems/1"},
+		}
+		if err := bus.Distribute(msg); err != nil {
+			t.Fatalf("Distribute() failed: %v", err)
+		}
+	}
+}
+
+// testMQBusConformance verifies the behaviour every MQBus implementation
+// must provide. newBus is expected to return a new packet of the platform
+// under test for the given pipe.
+func testMQBusConformance(t *testing.T, newBus func(t *testing.T, pipe string) MQBus) {
+	newPipe := func() string {
+		return "conformance-" + uuid.NewV4().String()
+	}
+
+	t.Run("delivers messages in order with content intact", func(t *testing.T) {
+		pipe := newPipe()
+		consumer := newBus(t, pipe)
+		c := newCollector()
+		done := accept(consumer, c.process)
+		distribute(t, newBus(t, pipe), 0, 20)
+		waitForMessages(t, 20, c)
+		remove(t, consumer, done)
+
+		for i, seq := range c.seqs() {
+			if seq != i {
+				t.Fatalf("message %d delivered at position %d", seq, i)
+			}
+		}
+		first := c.messages[0]
+		if first["Payload"] != "message 0" {
+			t.Errorf("Payload = %v, want %v", first["Payload"], "message 0")
+		}
+		labels, ok := first["Labels"].(map[string]interface{})
+		if !ok || labels["OriginOfCondition"] != "/redfish/v1/Systems/1" {
+			t.Errorf("Labels = %v, nested content not preserved", first["Labels"])
+		}
+	})
+
+	t.Run("shares messages between the consumers of a pipe", func(t *testing.T) {
+		pipe := newPipe()
+		first, second := newBus(t, pipe), newBus(t, pipe)
+		c1, c2 := newCollector(), newCollector()
+		done1 := accept(first, c1.process)
+		done2 := accept(second, c2.process)
+		distribute(t, newBus(t, pipe), 0, 50)
+		waitForMessages(t, 50, c1, c2)
+		// give the platform a chance to deliver duplicates, if any
+		time.Sleep(100 * time.Millisecond)
+		remove(t, first, done1)
+		remove(t, second, done2)
+
+		seen := make(map[int]int)
+		for _, seq := range append(c1.seqs(), c2.seqs()...) {
+			seen[seq]++
+		}
+		for i := 0; i < 50; i++ {
+			if seen[i] != 1 {
+				t.Errorf("message %d delivered %d times, want 1", i, seen[i])
+			}
+		}
+	})
+
+	t.Run("isolates pipes", func(t *testing.T) {
+		pipeA, pipeB := newPipe(), newPipe()
+		consumer := newBus(t, pipeB)
+		c := newCollector()
+		done := accept(consumer, c.process)
+		distribute(t, newBus(t, pipeA), 0, 5)
+		distribute(t, newBus(t, pipeB), 100, 101)
+		waitForMessages(t, 1, c)
+		time.Sleep(100 * time.Millisecond)
+		remove(t, consumer, done)
+		if seqs := c.seqs(); len(seqs) != 1 || seqs[0] != 100 {
+			t.Errorf("consumer of %s received %v, want [100]", pipeB, seqs)
+		}
+	})
+
+	t.Run("stops delivery on remove and retains messages", func(t *testing.T) {
+		pipe := newPipe()
+		consumer := newBus(t, pipe)
+		c := newCollector()
+		done := accept(consumer, c.process)
+		distribute(t, newBus(t, pipe), 0, 3)
+		waitForMessages(t, 3, c)
+		remove(t, consumer, done)
+
+		distribute(t, newBus(t, pipe), 3, 6)
+		time.Sleep(100 * time.Millisecond)
+		if n := c.count(); n != 3 {
+			t.Errorf("removed consumer received %d messages, want 3", n)
+		}
+
+		next := newBus(t, pipe)
+		c2 := newCollector()
+		done2 := accept(next, c2.process)
+		waitForMessages(t, 3, c2)
+		remove(t, next, done2)
+	})
+
+	t.Run("delivers every message to each subscriber", func(t *testing.T) {
+		pipe := newPipe()
+		distribute(t, newBus(t, pipe), 0, 2)
+		first, second := newBus(t, pipe), newBus(t, pipe)
+		s1, s2 := newSubscriber(), newSubscriber()
+		done1 := subscribe(first, s1.process)
+		done2 := subscribe(second, s2.process)
+		distribute(t, newBus(t, pipe), 2, 22)
+		waitForMessages(t, 40, &s1.collector, &s2.collector)
+		time.Sleep(100 * time.Millisecond)
+		remove(t, first, done1)
+		remove(t, second, done2)
+
+		for _, s := range []*subscriber{s1, s2} {
+			for i, seq := range s.seqs() {
+				if seq != i+2 {
+					t.Fatalf("subscriber received %v, want the messages 2 to 21 in order", s.seqs())
+				}
+			}
+			for i := 1; i < len(s.offsets); i++ {
+				if s.offsets[i] <= s.offsets[i-1] {
+					t.Fatalf("offsets %v are not increasing", s.offsets)
+				}
+			}
+		}
+		for i := range s1.offsets {
+			if s1.offsets[i] != s2.offsets[i] {
+				t.Fatalf("subscribers got the offsets %v and %v for the same messages", s1.offsets, s2.offsets)
+			}
+		}
+	})
+
+	t.Run("subscribers do not take the messages of the consumers", func(t *testing.T) {
+		pipe := newPipe()
+		consumer, sub := newBus(t, pipe), newBus(t, pipe)
+		c, s := newCollector(), newSubscriber()
+		done1 := accept(consumer, c.process)
+		done2 := subscribe(sub, s.process)
+		distribute(t, newBus(t, pipe), 0, 10)
+		waitForMessages(t, 10, c)
+		waitForMessages(t, 10, &s.collector)
+		remove(t, consumer, done1)
+		remove(t, sub, done2)
+	})
+
+	t.Run("rejects remove without subscription", func(t *testing.T) {
+		if err := newBus(t, newPipe()).Remove(); err == nil {
+			t.Error("Remove() without Accept() should fail")
+		}
+	})
+}
+
+func TestInMemoryConformance(t *testing.T) {
+	defer ResetInMemoryPipes()
+	testMQBusConformance(t, func(t *testing.T, pipe string) MQBus {
+		bus, err := Communicator(INMEMORY, "", pipe)
+		if err != nil {
+			t.Fatalf("Communicator() failed: %v", err)
+		}
+		return bus
+	})
+}
+
+func TestRedisStreamsConformance(t *testing.T) {
+	mockRedisStreams(t)
+	testMQBusConformance(t, func(t *testing.T, pipe string) MQBus {
+		bus, err := Communicator(REDISSTREAMS, "", pipe)
+		if err != nil {
+			t.Fatalf("Communicator() failed: %v", err)
+		}
+		return bus
+	})
+}
+
+// TestKafkaConformance runs the conformance tests against a Kafka broker,
+// only when MQBUS_KAFKA_CONFIG points to a message bus config file with the
+// KAFKA section.
+func TestKafkaConformance(t *testing.T) {
+	configFile := os.Getenv("MQBUS_KAFKA_CONFIG")
+	if configFile == "" {
+		t.Skip("MQBUS_KAFKA_CONFIG is not set")
+	}
+	if err := SetConfiguration(configFile); err != nil {
+		t.Fatalf("SetConfiguration() failed: %v", err)
+	}
+	testMQBusConformance(t, func(t *testing.T, pipe string) MQBus {
+		bus, err := Communicator(KAFKA, configFile, pipe)
+		if err != nil {
+			t.Fatalf("Communicator() failed: %v", err)
+		}
+		return bus
+	})
+}
@@ -14,8 +14,9 @@
 /*
 
 Package datacommunicator facilitates communication between different Microservice
-components. It allows user to select the underlying communication mediums. For now,
-Kafka is the only communication middlewares supported by MessageBus in this release.
+components. It allows user to select the underlying communication mediums. Kafka and
+Redis Streams are the communication middlewares supported by MessageBus in this release,
+along with an in-process platform (InMemory) to be used in unit tests.
 
 This component provides the basic building block for enabling communication by allowing
 users / clients to send any user defined information over to the remote applications / systems
@@ -21,25 +21,35 @@ package datacommunicator
 import (
 	"encoding/json"
 	"fmt"
+	"log"
+	"runtime/debug"
 )
 
 // BrokerType defines the underline MQ platform to be selected for the
-// messages. KAFKA and RedisStremas platforms are supported.
+// messages. KAFKA, RedisStremas and InMemory platforms are supported.
 const (
 	KAFKA                = "Kafka"        // KAFKA as Messaging Platform, Please use this ID
 	REDISSTREAMS         = "RedisStreams" // REDISSTREAMS as Messaging Platform
+	INMEMORY             = "InMemory"     // INMEMORY as Messaging Platform, limited to a single process
 	EVENTREADERGROUPNAME = "eventreaders_grp"
 )
 
 // MQBus Interface defines the Process interface function (Only function user
 // should call). These functions are implemented as part of Packet struct.
 // Distribute - API to Publish Messages into specified Pipe (Topic / Subject)
-// Accept - Consume the incoming message if subscribed by that component
+// Accept - Consume the incoming message if subscribed by that component. All
+// the packets accepting on the same pipe share the messages, each message is
+// handed over to only one of them. The call blocks till Remove is called.
+// Subscribe - Consume the messages distributed after the call. Unlike Accept,
+// every packet subscribing on the pipe receives all the messages, so each
+// replica of a service sees them. The call blocks till Remove is called.
 // Get - Would initiate blocking call to remote process to get response
+// Remove - Would stop the subscription created by Accept or Subscribe.
 // Close - Would disconnect the connection with Middleware.
 type MQBus interface {
 	Distribute(data interface{}) error
 	Accept(fn MsgProcess) error
+	Subscribe(fn MsgOffsetProcess) error
 	Get(pipe string, d interface{}) interface{}
 	Remove() error
 	Close() error
@@ -51,6 +61,23 @@ type MQBus interface {
 // be sent to MessageBus as callback for handling the incoming messages.
 type MsgProcess func(d interface{})
 
+// MsgOffsetProcess defines the functions for processing the messages received
+// with Subscribe. offset is the position of the message in the pipe, it is the
+// same for all the subscribers and increases with every message distributed.
+type MsgOffsetProcess func(offset uint64, d interface{})
+
+// invoke calls the message handler of the pipe. A panic of the handler is
+// logged along with its stack and the message is treated as processed, as
+// delivering the same message again would only panic again.
+func invoke(pipe string, fn MsgProcess, d interface{}) {
+	defer func() {
+		if err := recover(); err != nil {
+			log.Printf("error: handler of %s panicked while processing the message, dropping it: %v\n%s", pipe, err, debug.Stack())
+		}
+	}()
+	fn(d)
+}
+
 // Packet defines all the message related information that Producer or Consumer
 // should know for message transactions. Both Producer and Consumer use this
 // same structure for message transactions.
@@ -73,6 +100,7 @@ func Communicator(bt string, messageQueueConfigPath, pipe string) (MQBus, error)
 	// storing maintain the connections as a Map (Between Connection and Pipe)
 	var kp *KafkaPacket
 	var rp *RedisStreamsPacket
+	var mp *InMemoryPacket
 	switch bt {
 	case KAFKA:
 		kp = new(KafkaPacket)
@@ -85,6 +113,11 @@ func Communicator(bt string, messageQueueConfigPath, pipe string) (MQBus, error)
 		rp.BrokerType = bt
 		rp.pipe = pipe
 		return rp, nil
+	case INMEMORY:
+		mp = new(InMemoryPacket)
+		mp.BrokerType = bt
+		mp.pipe = pipe
+		return mp, nil
 	default:
 		return nil, fmt.Errorf("Broker: \"Broker Type\" is not supported - %s", bt)
 	}
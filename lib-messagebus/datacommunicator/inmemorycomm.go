@@ -0,0 +1,257 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package datacommunicator
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// inMemoryPipeCapacity is the number of messages a pipe can hold before
+// Distribute starts failing.
+const inMemoryPipeCapacity = 1024
+
+// InMemoryPacket defines the message packet for the in-process message bus.
+// Messages are kept in memory of the current process only, so the platform
+// is meant for unit tests and single process setups where no broker is
+// available. Messages are encoded and decoded the same way as the other
+// platforms, so the consumers receive the same data types.
+type InMemoryPacket struct {
+	Packet
+	pipe string
+
+	// stop is closed to end the subscription started by Accept or Read
+	stop chan struct{}
+	mu   sync.Mutex
+}
+
+// inMemoryPipe holds the messages of a pipe which are yet to be consumed
+// and the channels of the callers waiting for the next message using Get.
+// Same as a consumer group, the pipe retains the messages only once it was
+// subscribed with Accept. Each packet subscribed with Subscribe has its own
+// channel in subscribers, to which every message is copied with its offset.
+type inMemoryPipe struct {
+	messages    chan []byte
+	observers   map[chan []byte]struct{}
+	subscribers map[chan inMemoryMessage]struct{}
+	offset      uint64
+	accepted    bool
+	lock        sync.Mutex
+}
+
+// inMemoryMessage is a message delivered to the packets subscribed with
+// Subscribe
+type inMemoryMessage struct {
+	offset uint64
+	data   []byte
+}
+
+// inMemoryPipes maintains all the pipes created in the process
+var inMemoryPipes = struct {
+	lock  sync.Mutex
+	pipes map[string]*inMemoryPipe
+}{
+	pipes: make(map[string]*inMemoryPipe),
+}
+
+// getInMemoryPipe returns the pipe with the given name, creating it if it
+// does not exist yet.
+func getInMemoryPipe(name string) *inMemoryPipe {
+	inMemoryPipes.lock.Lock()
+	defer inMemoryPipes.lock.Unlock()
+	p, exist := inMemoryPipes.pipes[name]
+	if !exist {
+		p = &inMemoryPipe{
+			messages:    make(chan []byte, inMemoryPipeCapacity),
+			observers:   make(map[chan []byte]struct{}),
+			subscribers: make(map[chan inMemoryMessage]struct{}),
+		}
+		inMemoryPipes.pipes[name] = p
+	}
+	return p
+}
+
+// ResetInMemoryPipes drops all the pipes of the in-process message bus along
+// with the messages which are not consumed yet. Intended to be used between
+// unit tests.
+func ResetInMemoryPipes() {
+	inMemoryPipes.lock.Lock()
+	defer inMemoryPipes.lock.Unlock()
+	inMemoryPipes.pipes = make(map[string]*inMemoryPipe)
+}
+
+// Distribute encodes the message and places it in the pipe. The callers
+// waiting on Get for the pipe are handed over a copy of the message as well.
+// The packets subscribed with Subscribe receive a copy, a subscriber whose
+// channel is full misses the message. The message is not retained if the
+// pipe was never subscribed with Accept.
+func (mp *InMemoryPacket) Distribute(d interface{}) error {
+	b, e := Encode(d)
+	if e != nil {
+		return fmt.Errorf("error: message encoding failed: %s", e.Error())
+	}
+	p := getInMemoryPipe(mp.pipe)
+
+	p.lock.Lock()
+	for observer := range p.observers {
+		select {
+		case observer <- b:
+		default:
+		}
+	}
+	p.offset++
+	for subscriber := range p.subscribers {
+		select {
+		case subscriber <- inMemoryMessage{offset: p.offset, data: b}:
+		default:
+		}
+	}
+	accepted := p.accepted
+	p.lock.Unlock()
+	if !accepted {
+		return nil
+	}
+
+	select {
+	case p.messages <- b:
+		return nil
+	default:
+		return fmt.Errorf("error: write message failed: pipe %s is full", mp.pipe)
+	}
+}
+
+// Accept function defines the Consumer or Subscriber functionality for the
+// in-process message bus. The pipe retains the messages distributed from the
+// first Accept on. The call blocks till the subscription is removed.
+func (mp *InMemoryPacket) Accept(fn MsgProcess) error {
+	p := getInMemoryPipe(mp.pipe)
+	p.lock.Lock()
+	p.accepted = true
+	p.lock.Unlock()
+	return mp.Read(fn)
+}
+
+// Read would hand over the messages of the pipe to the callback one after
+// the other till the subscription is removed.
+func (mp *InMemoryPacket) Read(fn MsgProcess) error {
+	stop, err := mp.start()
+	if err != nil {
+		return err
+	}
+	p := getInMemoryPipe(mp.pipe)
+	for {
+		select {
+		case <-stop:
+			return nil
+		case b := <-p.messages:
+			var d interface{}
+			if e := Decode(b, &d); e != nil {
+				continue
+			}
+			invoke(mp.pipe, fn, d)
+		}
+	}
+}
+
+// Subscribe would hand over every message distributed into the pipe after
+// the call to the callback, till the subscription is removed. The messages
+// are not shared with the other packets subscribed on the pipe.
+func (mp *InMemoryPacket) Subscribe(fn MsgOffsetProcess) error {
+	stop, err := mp.start()
+	if err != nil {
+		return err
+	}
+	p := getInMemoryPipe(mp.pipe)
+	messages := make(chan inMemoryMessage, inMemoryPipeCapacity)
+	p.lock.Lock()
+	p.subscribers[messages] = struct{}{}
+	p.lock.Unlock()
+	defer func() {
+		p.lock.Lock()
+		delete(p.subscribers, messages)
+		p.lock.Unlock()
+	}()
+
+	for {
+		select {
+		case <-stop:
+			return nil
+		case m := <-messages:
+			var d interface{}
+			if e := Decode(m.data, &d); e != nil {
+				continue
+			}
+			invoke(mp.pipe, func(d interface{}) { fn(m.offset, d) }, d)
+		}
+	}
+}
+
+// start marks the packet as subscribed and returns the channel closed on
+// Remove
+func (mp *InMemoryPacket) start() (chan struct{}, error) {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+	if mp.stop != nil {
+		return nil, fmt.Errorf("specified pipe %s is already subscribed", mp.pipe)
+	}
+	mp.stop = make(chan struct{})
+	return mp.stop, nil
+}
+
+// Get would wait for the next message distributed into the specified pipe
+// and decode it into d. The message is still delivered to the subscribers of
+// the pipe. Returns nil if no message arrives with in the timeout.
+func (mp *InMemoryPacket) Get(pipe string, d interface{}) interface{} {
+	p := getInMemoryPipe(pipe)
+	observer := make(chan []byte, 1)
+	p.lock.Lock()
+	p.observers[observer] = struct{}{}
+	p.lock.Unlock()
+	defer func() {
+		p.lock.Lock()
+		delete(p.observers, observer)
+		p.lock.Unlock()
+	}()
+
+	select {
+	case b := <-observer:
+		if e := Decode(b, d); e != nil {
+			return nil
+		}
+		return d
+	case <-time.After(getTimeout):
+		return nil
+	}
+}
+
+// Remove will stop the subscription created with Accept, Read or Subscribe.
+// The messages which are not consumed yet by Accept stay in the pipe.
+func (mp *InMemoryPacket) Remove() error {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+	if mp.stop == nil {
+		return fmt.Errorf("specified pipe is not subscribed yet. please check the pipe name passed")
+	}
+	close(mp.stop)
+	mp.stop = nil
+	return nil
+}
+
+// Close is a no-op for the in-process message bus as there is no connection
+// to be released.
+func (mp *InMemoryPacket) Close() error {
+	return nil
+}
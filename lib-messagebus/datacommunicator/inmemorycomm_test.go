@@ -0,0 +1,103 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package datacommunicator
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestInMemoryPacket_DropsMessageOnPanic(t *testing.T) {
+	defer ResetInMemoryPipes()
+	producer, _ := Communicator(INMEMORY, "", "panic")
+	consumer, _ := Communicator(INMEMORY, "", "panic")
+
+	var attempts int32
+	delivered := make(chan struct{})
+	done := accept(consumer, func(d interface{}) {
+		atomic.AddInt32(&attempts, 1)
+		if d.(map[string]interface{})["Seq"] == float64(1) {
+			panic("failed to process the message")
+		}
+		close(delivered)
+	})
+	distribute(t, producer, 1, 3)
+	select {
+	case <-delivered:
+	case <-time.After(deliveryTimeout):
+		t.Fatal("message after the panicking one was not delivered")
+	}
+	time.Sleep(100 * time.Millisecond)
+	remove(t, consumer, done)
+	if n := atomic.LoadInt32(&attempts); n != 2 {
+		t.Errorf("handler called %d times, want 2 as the panicking message is not delivered again", n)
+	}
+}
+
+func TestInMemoryPacket_DropsMessagesBeforeAccept(t *testing.T) {
+	defer ResetInMemoryPipes()
+	producer, _ := Communicator(INMEMORY, "", "unsubscribed")
+	distribute(t, producer, 0, 2)
+	consumer, _ := Communicator(INMEMORY, "", "unsubscribed")
+	c := newCollector()
+	done := accept(consumer, c.process)
+	distribute(t, producer, 2, 3)
+	waitForMessages(t, 1, c)
+	time.Sleep(100 * time.Millisecond)
+	remove(t, consumer, done)
+	if seqs := c.seqs(); len(seqs) != 1 || seqs[0] != 2 {
+		t.Errorf("consumer received %v, want [2]", seqs)
+	}
+}
+
+func TestInMemoryPacket_Get(t *testing.T) {
+	defer ResetInMemoryPipes()
+	producer, _ := Communicator(INMEMORY, "", "get")
+	result := make(chan interface{})
+	go func() {
+		var msg testMessage
+		result <- producer.Get("get", &msg)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	if err := producer.Distribute(testMessage{Seq: 7}); err != nil {
+		t.Fatalf("Distribute() failed: %v", err)
+	}
+	select {
+	case got := <-result:
+		msg, ok := got.(*testMessage)
+		if !ok || msg.Seq != 7 {
+			t.Errorf("Get() = %v, want message 7", got)
+		}
+	case <-time.After(deliveryTimeout):
+		t.Fatal("Get() did not return")
+	}
+}
+
+func TestInMemoryPacket_PipeFull(t *testing.T) {
+	defer ResetInMemoryPipes()
+	producer, _ := Communicator(INMEMORY, "", "full")
+	consumer, _ := Communicator(INMEMORY, "", "full")
+	// the pipe retains the messages once subscribed, even without any reader
+	remove(t, consumer, accept(consumer, func(d interface{}) {}))
+	for i := 0; i < inMemoryPipeCapacity; i++ {
+		if err := producer.Distribute(testMessage{Seq: i}); err != nil {
+			t.Fatalf("Distribute() failed: %v", err)
+		}
+	}
+	if err := producer.Distribute(testMessage{}); err == nil {
+		t.Error("Distribute() on a full pipe should fail")
+	}
+}
@@ -24,9 +24,11 @@ import (
 	"crypto/x509"
 	"fmt"
 	"io/ioutil"
+	"os"
 	"sync"
 	"time"
 
+	uuid "github.com/satori/go.uuid"
 	"github.com/segmentio/kafka-go"
 )
 
@@ -57,6 +59,11 @@ type KafkaPacket struct {
 
 	// pipe is defined to maintain the object created for which specific pipe
 	pipe string
+
+	// subscriber is the reader of the subscription created with Subscribe,
+	// it is not shared with the other packets of the pipe
+	subscriber *kafka.Reader
+	mu         sync.Mutex
 }
 
 // Following are the map definition of both KAFKA reader and writers with Topic name.
@@ -248,8 +255,9 @@ func (kp *KafkaPacket) Accept(fn MsgProcess) error {
 	return nil
 }
 
-// Read would access the KAFKA messages in a infinite loop. Callback method
-// access is existing only in "goka" library.  Not available in "kafka-go".
+// Read would access the KAFKA messages in a infinite loop till the subscription
+// is removed. Callback method access is existing only in "goka" library.  Not
+// available in "kafka-go".
 func (kp *KafkaPacket) Read(fn MsgProcess) error {
 	// This interface should be defined outside the inner level to make sure
 	// we are making the ToData API to work. Otherwise we would get exception
@@ -267,6 +275,14 @@ func (kp *KafkaPacket) Read(fn MsgProcess) error {
 		// explicitly committing the messages
 		m, e := reader.ReadMessage(c)
 		if e != nil {
+			// reader is closed and dropped from the map when the
+			// subscription is removed, stop reading in that case
+			krw.reader.Lock()
+			current, exist := krw.Readers[kp.pipe]
+			krw.reader.Unlock()
+			if !exist || current != reader {
+				return nil
+			}
 			time.Sleep(10 * time.Second)
 			continue
 		}
@@ -276,10 +292,73 @@ func (kp *KafkaPacket) Read(fn MsgProcess) error {
 			continue
 		}
 		// Callback Function call.
-		fn(d)
+		invoke(kp.pipe, fn, d)
+	}
+}
+
+// Subscribe reads the messages published into the topic after the call till
+// the subscription is removed. The packet reads with the consumer group of the
+// replica, so that the messages are not shared with the other subscribers. A
+// restarted replica joins its group again and continues after the messages
+// read before. The offset handed over to the callback is the offset of the message in its
+// partition, so it increases across the messages only for the topics with a
+// single partition.
+func (kp *KafkaPacket) Subscribe(fn MsgOffsetProcess) error {
+	if e := kafkaConnect(kp); e != nil {
+		return e
+	}
+	kp.mu.Lock()
+	if kp.subscriber != nil {
+		kp.mu.Unlock()
+		return fmt.Errorf("specified pipe %s is already subscribed", kp.pipe)
+	}
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:        kp.ServersInfo,
+		GroupID:        subscriberGroupID(kp.pipe),
+		Topic:          kp.pipe,
+		StartOffset:    kafka.LastOffset,
+		MinBytes:       10e1,
+		MaxBytes:       10e6,
+		CommitInterval: 1 * time.Second,
+		Dialer:         kp.DialerConn,
+	})
+	kp.subscriber = reader
+	kp.mu.Unlock()
+
+	for {
+		m, e := reader.ReadMessage(context.Background())
+		if e != nil {
+			kp.mu.Lock()
+			removed := kp.subscriber != reader
+			kp.mu.Unlock()
+			if removed {
+				return nil
+			}
+			time.Sleep(10 * time.Second)
+			continue
+		}
+		var d interface{}
+		if e = Decode(m.Value, &d); e != nil {
+			continue
+		}
+		offset := uint64(m.Offset)
+		invoke(kp.pipe, func(d interface{}) { fn(offset, d) }, d)
 	}
 }
 
+// subscriberGroupID returns the consumer group of the replica subscribing to
+// the pipe, named after the pod of the replica or its host name
+func subscriberGroupID(pipe string) string {
+	replica := os.Getenv("POD_NAME")
+	if replica == "" {
+		replica, _ = os.Hostname()
+	}
+	if replica == "" {
+		replica = uuid.NewV4().String()
+	}
+	return pipe + "-" + replica
+}
+
 // Get - Not supported for now in Kafka from Message Bus side due to limitations
 // on the quality of the go library implementation. Will be taken-up in future.
 func (kp *KafkaPacket) Get(pipe string, d interface{}) interface{} {
@@ -290,6 +369,15 @@ func (kp *KafkaPacket) Get(pipe string, d interface{}) interface{} {
 // Remove will just remove the existing subscription. This API would check just
 // the Reader map as to Distribute / Publish messages, we don't need subscription
 func (kp *KafkaPacket) Remove() error {
+	kp.mu.Lock()
+	if kp.subscriber != nil {
+		kp.subscriber.Close()
+		kp.subscriber = nil
+		kp.mu.Unlock()
+		return nil
+	}
+	kp.mu.Unlock()
+
 	krw.reader.Lock()
 	defer krw.reader.Unlock()
 	es, ok := krw.Readers[kp.pipe]
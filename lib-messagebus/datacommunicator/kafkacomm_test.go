@@ -15,6 +15,7 @@ package datacommunicator
 
 import (
 	"crypto/tls"
+	"os"
 	"reflect"
 	"testing"
 
@@ -214,3 +215,20 @@ func TestKafkaPacket_Close(t *testing.T) {
 		})
 	}
 }
+
+func TestSubscriberGroupID(t *testing.T) {
+	t.Setenv("POD_NAME", "task-7f9c5d-x2k4p")
+	if got := subscriberGroupID("ODIM-CONTROL-MESSAGES"); got != "ODIM-CONTROL-MESSAGES-task-7f9c5d-x2k4p" {
+		t.Errorf("subscriberGroupID() = %v, want the group of the pod", got)
+	}
+	// the group stays the same across the subscriptions of the replica
+	if subscriberGroupID("ODIM-CONTROL-MESSAGES") != subscriberGroupID("ODIM-CONTROL-MESSAGES") {
+		t.Error("subscriberGroupID() changed between the subscriptions")
+	}
+
+	t.Setenv("POD_NAME", "")
+	hostName, _ := os.Hostname()
+	if got := subscriberGroupID("ODIM-CONTROL-MESSAGES"); hostName != "" && got != "ODIM-CONTROL-MESSAGES-"+hostName {
+		t.Errorf("subscriberGroupID() = %v, want the group of the host", got)
+	}
+}
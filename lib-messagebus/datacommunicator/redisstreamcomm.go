@@ -18,7 +18,9 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-redis/redis/v8"
@@ -27,6 +29,23 @@ import (
 
 const (
 	DefaultTLSMinVersion = tls.VersionTLS12
+
+	// defaultPendingEntryIdleTime is the time after which an unacknowledged
+	// message is reclaimed by any consumer of the group, when no value is
+	// configured for PendingEntryIdleTime
+	defaultPendingEntryIdleTime = 600
+	// maxDeliveryCount is the number of times a message will be delivered
+	// to the consumers before it is acknowledged and dropped
+	maxDeliveryCount = 10
+	// readBatchSize is the maximum number of messages fetched in one read
+	readBatchSize = 10
+	// readBlockTime is the time a read waits for new messages before it
+	// checks whether the subscription was removed
+	readBlockTime = time.Second
+	// readRetryInterval is the time to wait before retrying a failed read
+	readRetryInterval = 5 * time.Second
+	// getTimeout is the maximum time Get waits for a message to arrive
+	getTimeout = 10 * time.Second
 )
 
 // RedisStreamsPacket defines the RedisStreamsPacket Message Packet Object. Apart from Base Packet, it
@@ -34,8 +53,16 @@ const (
 type RedisStreamsPacket struct {
 	Packet
 	pipe string
+
+	// cancel stops the reader started by Accept, Read or Subscribe
+	cancel context.CancelFunc
+	mu     sync.Mutex
 }
 
+// redisConnect is used for creating the connection towards the Redis server,
+// it is defined as a variable so that the connection can be mocked in tests
+var redisConnect = getDBConnection
+
 func getDBConnection() (*redis.Client, error) {
 	var dbConn *redis.Client
 
@@ -66,10 +93,20 @@ func getDBConnection() (*redis.Client, error) {
 	return dbConn, nil
 }
 
+// pendingEntryIdleTime returns the time after which the unacknowledged
+// messages of a consumer are claimed by the other consumers of the group
+func pendingEntryIdleTime() time.Duration {
+	if MQ.RedisStreams != nil && MQ.RedisStreams.PendingEntryIdleTime > 0 {
+		return time.Duration(MQ.RedisStreams.PendingEntryIdleTime) * time.Second
+	}
+	return defaultPendingEntryIdleTime * time.Second
+}
+
 // Distribute defines the Producer / Publisher role and functionality. Writer
 // would be created for each Pipe comes-in for communication. If Writer already
 // exists, that connection would be used for this call. Before publishing the
 // message in the specified Pipe, it will be converted into Byte stream using
+// "Encode" API.
 func (rp *RedisStreamsPacket) Distribute(data interface{}) error {
 	ctx := context.Background()
 	// Encode the message before appending into Redis Message struct
@@ -77,158 +114,325 @@ func (rp *RedisStreamsPacket) Distribute(data interface{}) error {
 	if e != nil {
 		return fmt.Errorf("while trying to encode message: %s", e.Error())
 	}
-	redisClient, err := getDBConnection()
+	redisClient, err := redisConnect()
 	if err != nil {
 		return err
 	}
 	defer redisClient.Close()
-	_, rerr := redisClient.XAdd(ctx, &redis.XAddArgs{
+	args := &redis.XAddArgs{
 		Stream: rp.pipe,
 		Values: map[string]interface{}{"data": b},
-	}).Result()
-
-	if rerr != nil {
-		if strings.Contains(rerr.Error(), " connection timed out") {
-			redisClient, err = getDBConnection()
-			if err != nil {
-				return err
-			}
-			redisClient.XAdd(ctx, &redis.XAddArgs{
-				Stream: rp.pipe,
-				Values: map[string]interface{}{"data": b},
-			}).Result()
+	}
+	_, rerr := redisClient.XAdd(ctx, args).Result()
+	if rerr != nil && strings.Contains(rerr.Error(), " connection timed out") {
+		retryClient, err := redisConnect()
+		if err != nil {
+			return err
 		}
+		defer retryClient.Close()
+		_, rerr = retryClient.XAdd(ctx, args).Result()
+	}
+	if rerr != nil {
 		return fmt.Errorf("unable to publish event to redis, got: %s", rerr.Error())
 	}
-
 	return nil
 }
 
-// Accept implmentation need to be added
+// Accept function defines the Consumer or Subscriber functionality for Redis
+// Streams. The packet joins the EVENTREADERGROUPNAME consumer group of the
+// pipe, creating both the stream and the group when they don't exist yet, and
+// reads the messages through "Read". A new group starts with the messages
+// published after its creation. The call blocks till the subscription is
+// removed using "Remove".
 func (rp *RedisStreamsPacket) Accept(fn MsgProcess) error {
-	redisClient, err := getDBConnection()
+	redisClient, err := redisConnect()
 	if err != nil {
 		return err
 	}
-	var id = uuid.NewV4().String()
+	defer redisClient.Close()
 	rerr := redisClient.XGroupCreateMkStream(context.Background(),
 		rp.pipe, EVENTREADERGROUPNAME, "$").Err()
-	if rerr != nil {
-		if strings.Contains(rerr.Error(), " connection timed out") {
-			redisClient, err = getDBConnection()
-			if err != nil {
-				return err
-			}
-		}
+	if rerr != nil && !strings.HasPrefix(rerr.Error(), "BUSYGROUP") {
+		return fmt.Errorf("unable to create consumer group %s for %s: %s", EVENTREADERGROUPNAME, rp.pipe, rerr.Error())
+	}
+	return rp.Read(fn)
+}
 
+// Read would access the messages of the pipe as a member of the consumer group
+// in a loop till the subscription is removed. Every message is acknowledged
+// once the callback returns, or panics. Messages left unacknowledged because
+// the consumer went down are reclaimed after PendingEntryIdleTime and
+// delivered again.
+func (rp *RedisStreamsPacket) Read(fn MsgProcess) error {
+	ctx, err := rp.start()
+	if err != nil {
+		return err
 	}
 
-	// create a unique consumer id for the  instance
-	errChan := make(chan error)
-	defer close(errChan)
-	go rp.checkUnacknowledgedEvents(fn, id, errChan)
-	err = <-errChan
+	// create a unique consumer id for the instance
+	consumerID := uuid.NewV4().String()
+
+	redisClient, err := redisConnect()
 	if err != nil {
+		rp.stop()
 		return err
 	}
+	defer redisClient.Close()
+
+	reclaimDone := make(chan struct{})
 	go func() {
-		for {
-			events, err := redisClient.XReadGroup(context.Background(),
-				&redis.XReadGroupArgs{
-					Group:    EVENTREADERGROUPNAME,
-					Consumer: id,
-					Count:    1,
-					Streams:  []string{rp.pipe, ">"},
-				}).Result()
-			if err != nil {
-				errChan <- fmt.Errorf("unable to get data from the group %s", err.Error())
-				if strings.Contains(err.Error(), " connection timed out") {
-					redisClient, err = getDBConnection()
-					if err != nil {
-						errChan <- err
-						return
-					}
-				}
-			} else {
-
-				if len(events) > 0 && len(events[0].Messages) > 0 {
-					messageID := events[0].Messages[0].ID
-					evtStr := events[0].Messages[0].Values["data"].(string)
-					var evt interface{}
-					err := Decode([]byte(evtStr), &evt)
-					if err != nil {
-						errChan <- err
-						return
-					}
-					fn(evt)
-					redisClient.XAck(context.Background(), rp.pipe, EVENTREADERGROUPNAME, messageID)
-				}
+		defer close(reclaimDone)
+		rp.reclaimPendingEntries(ctx, redisClient, consumerID, fn)
+	}()
+
+	for ctx.Err() == nil {
+		streams, err := redisClient.XReadGroup(context.Background(),
+			&redis.XReadGroupArgs{
+				Group:    EVENTREADERGROUPNAME,
+				Consumer: consumerID,
+				Count:    readBatchSize,
+				Block:    readBlockTime,
+				Streams:  []string{rp.pipe, ">"},
+			}).Result()
+		if err != nil {
+			if err != redis.Nil {
+				wait(ctx, readRetryInterval)
+			}
+			continue
+		}
+		for _, stream := range streams {
+			for _, message := range stream.Messages {
+				rp.process(redisClient, message, fn)
 			}
 		}
-	}()
-	err = <-errChan
-	if err != nil {
-		return err
 	}
+	<-reclaimDone
+	rp.removeConsumer(redisClient, consumerID)
 	return nil
 }
 
-// Read implmentation need to be added
-func (rp *RedisStreamsPacket) Read(fn MsgProcess) error {
-	return nil
-}
-
-// Get - Not supported for now in RedisStreams from Message Bus side due to limitations
-func (rp *RedisStreamsPacket) Get(pipe string, d interface{}) interface{} {
+// Subscribe would read every message published into the stream after the
+// call, outside of any consumer group, till the subscription is removed. The
+// offset handed over to the callback is derived from the ID of the entry in
+// the stream.
+func (rp *RedisStreamsPacket) Subscribe(fn MsgOffsetProcess) error {
+	ctx, err := rp.start()
+	if err != nil {
+		return err
+	}
+	redisClient, err := redisConnect()
+	if err != nil {
+		rp.stop()
+		return err
+	}
+	defer redisClient.Close()
 
+	// reading from the last entry instead of "$" in each call, so that the
+	// entries added in between two reads are not missed
+	lastID := "0-0"
+	for ctx.Err() == nil {
+		entries, err := redisClient.XRevRangeN(context.Background(), rp.pipe, "+", "-", 1).Result()
+		if err == nil {
+			if len(entries) > 0 {
+				lastID = entries[0].ID
+			}
+			break
+		}
+		wait(ctx, readRetryInterval)
+	}
+	for ctx.Err() == nil {
+		streams, err := redisClient.XRead(context.Background(), &redis.XReadArgs{
+			Streams: []string{rp.pipe, lastID},
+			Count:   readBatchSize,
+			Block:   readBlockTime,
+		}).Result()
+		if err != nil {
+			if err != redis.Nil {
+				wait(ctx, readRetryInterval)
+			}
+			continue
+		}
+		for _, stream := range streams {
+			for _, message := range stream.Messages {
+				lastID = message.ID
+				var evt interface{}
+				evtStr, _ := message.Values["data"].(string)
+				if err := Decode([]byte(evtStr), &evt); err != nil {
+					continue
+				}
+				offset := streamOffset(message.ID)
+				invoke(rp.pipe, func(d interface{}) { fn(offset, d) }, evt)
+			}
+		}
+	}
 	return nil
 }
 
-// Remove implmentation need to be added
-func (rp *RedisStreamsPacket) Remove() error {
-	return nil
+// streamOffset converts the ID of a stream entry, made of the time in
+// milliseconds and a sequence number, to an increasing number
+func streamOffset(id string) uint64 {
+	parts := strings.SplitN(id, "-", 2)
+	ms, _ := strconv.ParseUint(parts[0], 10, 64)
+	var seq uint64
+	if len(parts) == 2 {
+		seq, _ = strconv.ParseUint(parts[1], 10, 64)
+	}
+	return ms<<20 | seq
 }
 
-// Close implmentation need to be added
-func (rp *RedisStreamsPacket) Close() error {
-	return nil
+// start marks the packet as subscribed and returns the context cancelled on
+// Remove
+func (rp *RedisStreamsPacket) start() (context.Context, error) {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+	if rp.cancel != nil {
+		return nil, fmt.Errorf("specified pipe %s is already subscribed", rp.pipe)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	rp.cancel = cancel
+	return ctx, nil
 }
-func (rp *RedisStreamsPacket) checkUnacknowledgedEvents(fn MsgProcess, id string, errChan chan<- error) {
-	redisClient, err := getDBConnection()
-	if err != nil {
-		errChan <- err
+
+// process decodes the message, hands it over to the callback and acknowledges
+// it on success.
+func (rp *RedisStreamsPacket) process(redisClient *redis.Client, message redis.XMessage, fn MsgProcess) {
+	ctx := context.Background()
+	var evt interface{}
+	evtStr, _ := message.Values["data"].(string)
+	if err := Decode([]byte(evtStr), &evt); err != nil {
+		// message can never be processed, acknowledging it to
+		// avoid delivering the same again and again
+		redisClient.XAck(ctx, rp.pipe, EVENTREADERGROUPNAME, message.ID)
 		return
 	}
+	invoke(rp.pipe, fn, evt)
+	redisClient.XAck(ctx, rp.pipe, EVENTREADERGROUPNAME, message.ID)
+}
+
+// reclaimPendingEntries periodically claims the messages which are pending in
+// the group for more than PendingEntryIdleTime and processes them. Messages
+// which are already delivered maxDeliveryCount times are dropped.
+func (rp *RedisStreamsPacket) reclaimPendingEntries(ctx context.Context, redisClient *redis.Client, consumerID string, fn MsgProcess) {
+	idleTime := pendingEntryIdleTime()
 	for {
-		events, _, err := redisClient.XAutoClaim(context.Background(), &redis.XAutoClaimArgs{
-			Stream:   rp.pipe,
-			Group:    EVENTREADERGROUPNAME,
-			Consumer: id,
-			MinIdle:  time.Minute * 10,
-			Count:    100,
-			Start:    "0-0",
+		pending, err := redisClient.XPendingExt(context.Background(), &redis.XPendingExtArgs{
+			Stream: rp.pipe,
+			Group:  EVENTREADERGROUPNAME,
+			Start:  "-",
+			End:    "+",
+			Count:  100,
 		}).Result()
-		if err != nil {
-			if strings.Contains(err.Error(), " connection timed out") {
-				redisClient, err = getDBConnection()
-				if err != nil {
-					errChan <- err
-					return
+		if err == nil {
+			var ids []string
+			for _, entry := range pending {
+				if entry.Idle < idleTime {
+					continue
+				}
+				if entry.RetryCount >= maxDeliveryCount {
+					redisClient.XAck(context.Background(), rp.pipe, EVENTREADERGROUPNAME, entry.ID)
+					continue
 				}
+				ids = append(ids, entry.ID)
 			}
-		}
-		for _, event := range events {
-			messageID := event.ID
-			evtStr := event.Values["data"].(string)
-			var evt interface{}
-			Decode([]byte(evtStr), &evt)
-			if err != nil {
-				errChan <- err
-				return
+			if len(ids) > 0 {
+				messages, _ := redisClient.XClaim(context.Background(), &redis.XClaimArgs{
+					Stream:   rp.pipe,
+					Group:    EVENTREADERGROUPNAME,
+					Consumer: consumerID,
+					MinIdle:  idleTime,
+					Messages: ids,
+				}).Result()
+				for _, message := range messages {
+					rp.process(redisClient, message, fn)
+				}
 			}
-			fn(evt)
-			redisClient.XAck(context.Background(), rp.pipe, EVENTREADERGROUPNAME, messageID)
 		}
-		time.Sleep(time.Minute * 10)
+		if !wait(ctx, idleTime) {
+			return
+		}
+	}
+}
+
+// removeConsumer deletes the consumer from the group when it doesn't hold
+// any pending messages, otherwise the consumer is retained so that the
+// messages can be reclaimed by the other consumers.
+func (rp *RedisStreamsPacket) removeConsumer(redisClient *redis.Client, consumerID string) {
+	ctx := context.Background()
+	pending, err := redisClient.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream:   rp.pipe,
+		Group:    EVENTREADERGROUPNAME,
+		Start:    "-",
+		End:      "+",
+		Count:    1,
+		Consumer: consumerID,
+	}).Result()
+	if err == nil && len(pending) == 0 {
+		redisClient.XGroupDelConsumer(ctx, rp.pipe, EVENTREADERGROUPNAME, consumerID)
+	}
+}
+
+// stop cancels the reader of the packet and reports whether there was one
+func (rp *RedisStreamsPacket) stop() bool {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+	if rp.cancel == nil {
+		return false
+	}
+	rp.cancel()
+	rp.cancel = nil
+	return true
+}
+
+// Get would wait for the next message published into the specified pipe and
+// decode it into d. The message is read outside of any consumer group so the
+// group members still receive it. Returns nil if no message arrives with in
+// the timeout.
+func (rp *RedisStreamsPacket) Get(pipe string, d interface{}) interface{} {
+	redisClient, err := redisConnect()
+	if err != nil {
+		return nil
+	}
+	defer redisClient.Close()
+	streams, err := redisClient.XRead(context.Background(), &redis.XReadArgs{
+		Streams: []string{pipe, "$"},
+		Count:   1,
+		Block:   getTimeout,
+	}).Result()
+	if err != nil || len(streams) == 0 || len(streams[0].Messages) == 0 {
+		return nil
+	}
+	evtStr, _ := streams[0].Messages[0].Values["data"].(string)
+	if err := Decode([]byte(evtStr), d); err != nil {
+		return nil
+	}
+	return d
+}
+
+// Remove will stop the subscription created with Accept, Read or Subscribe.
+// Messages which are not processed yet stay in the stream for the other
+// consumers.
+func (rp *RedisStreamsPacket) Remove() error {
+	if !rp.stop() {
+		return fmt.Errorf("specified pipe is not subscribed yet. please check the pipe name passed")
+	}
+	return nil
+}
+
+// Close is a no-op for Redis Streams, as Distribute does not hold any
+// connection after publishing the message.
+func (rp *RedisStreamsPacket) Close() error {
+	return nil
+}
+
+// wait blocks for the given duration and reports false if the context got
+// cancelled in between.
+func wait(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
 	}
 }
@@ -0,0 +1,168 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package datacommunicator
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+// mockRedisStreams points the Redis Streams platform to an in-process
+// Redis server for the duration of the test
+func mockRedisStreams(t *testing.T) *miniredis.Miniredis {
+	server, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start redis server: %v", err)
+	}
+	origConnect, origConf := redisConnect, MQ.RedisStreams
+	redisConnect = func() (*redis.Client, error) {
+		return redis.NewClient(&redis.Options{Addr: server.Addr()}), nil
+	}
+	MQ.RedisStreams = &RedisStreams{PendingEntryIdleTime: 1}
+	t.Cleanup(func() {
+		redisConnect, MQ.RedisStreams = origConnect, origConf
+		server.Close()
+	})
+	return server
+}
+
+// pendingCount returns the number of messages of the pipe which are
+// delivered to the group and not acknowledged yet
+func pendingCount(t *testing.T, pipe string) int64 {
+	client, _ := redisConnect()
+	defer client.Close()
+	pending, err := client.XPending(context.Background(), pipe, EVENTREADERGROUPNAME).Result()
+	if err != nil {
+		t.Fatalf("XPending() failed: %v", err)
+	}
+	return pending.Count
+}
+
+func TestRedisStreamsPacket_RedeliversUnacknowledged(t *testing.T) {
+	mockRedisStreams(t)
+	producer, _ := Communicator(REDISSTREAMS, "", "redelivery")
+	consumer, _ := Communicator(REDISSTREAMS, "", "redelivery")
+
+	// a consumer of the group reads the message and goes down before
+	// acknowledging it
+	client, _ := redisConnect()
+	defer client.Close()
+	ctx := context.Background()
+	if err := client.XGroupCreateMkStream(ctx, "redelivery", EVENTREADERGROUPNAME, "$").Err(); err != nil {
+		t.Fatalf("XGroupCreateMkStream() failed: %v", err)
+	}
+	if err := producer.Distribute(testMessage{Seq: 1}); err != nil {
+		t.Fatalf("Distribute() failed: %v", err)
+	}
+	if err := client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    EVENTREADERGROUPNAME,
+		Consumer: "crashed",
+		Streams:  []string{"redelivery", ">"},
+		Block:    -1,
+	}).Err(); err != nil {
+		t.Fatalf("XReadGroup() failed: %v", err)
+	}
+
+	delivered := make(chan struct{})
+	done := accept(consumer, func(d interface{}) {
+		close(delivered)
+	})
+	select {
+	case <-delivered:
+	case <-time.After(deliveryTimeout):
+		t.Fatal("unacknowledged message was not delivered again")
+	}
+	remove(t, consumer, done)
+	if n := pendingCount(t, "redelivery"); n != 0 {
+		t.Errorf("%d messages are still pending after processing", n)
+	}
+}
+
+func TestRedisStreamsPacket_AcknowledgesOnPanic(t *testing.T) {
+	mockRedisStreams(t)
+	producer, _ := Communicator(REDISSTREAMS, "", "panic")
+	consumer, _ := Communicator(REDISSTREAMS, "", "panic")
+
+	var attempts int32
+	delivered := make(chan struct{})
+	done := accept(consumer, func(d interface{}) {
+		atomic.AddInt32(&attempts, 1)
+		if d.(map[string]interface{})["Seq"] == float64(1) {
+			panic("failed to process the message")
+		}
+		close(delivered)
+	})
+	distribute(t, producer, 1, 3)
+	select {
+	case <-delivered:
+	case <-time.After(deliveryTimeout):
+		t.Fatal("message after the panicking one was not delivered")
+	}
+	// wait past PendingEntryIdleTime to see the message is not reclaimed
+	time.Sleep(1500 * time.Millisecond)
+	remove(t, consumer, done)
+	if n := atomic.LoadInt32(&attempts); n != 2 {
+		t.Errorf("handler called %d times, want 2 as the panicking message is not delivered again", n)
+	}
+	if n := pendingCount(t, "panic"); n != 0 {
+		t.Errorf("%d messages are still pending after processing", n)
+	}
+}
+
+func TestRedisStreamsPacket_NewGroupStartsAtEnd(t *testing.T) {
+	mockRedisStreams(t)
+	producer, _ := Communicator(REDISSTREAMS, "", "history")
+	distribute(t, producer, 0, 3)
+
+	consumer, _ := Communicator(REDISSTREAMS, "", "history")
+	c := newCollector()
+	done := accept(consumer, c.process)
+	distribute(t, producer, 3, 4)
+	waitForMessages(t, 1, c)
+	time.Sleep(100 * time.Millisecond)
+	remove(t, consumer, done)
+	if seqs := c.seqs(); len(seqs) != 1 || seqs[0] != 3 {
+		t.Errorf("new consumer group received %v, want only [3] published after its creation", seqs)
+	}
+}
+
+func TestRedisStreamsPacket_Get(t *testing.T) {
+	mockRedisStreams(t)
+	producer, _ := Communicator(REDISSTREAMS, "", "get")
+	result := make(chan interface{})
+	go func() {
+		var msg testMessage
+		result <- producer.Get("get", &msg)
+	}()
+	// Get reads only the messages published after the call
+	time.Sleep(200 * time.Millisecond)
+	if err := producer.Distribute(testMessage{Seq: 7}); err != nil {
+		t.Fatalf("Distribute() failed: %v", err)
+	}
+	select {
+	case got := <-result:
+		msg, ok := got.(*testMessage)
+		if !ok || msg.Seq != 7 {
+			t.Errorf("Get() = %v, want message 7", got)
+		}
+	case <-time.After(deliveryTimeout):
+		t.Fatal("Get() did not return")
+	}
+}
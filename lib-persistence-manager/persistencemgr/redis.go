@@ -1553,6 +1553,36 @@ func (p *ConnPool) Decr(table, key string) (int, *errors.Error) {
 	return count, nil
 }
 
+// incrWithExpiryScript increments the count and sets its expiry together
+var incrWithExpiryScript = redis.NewScript(1, `
+local count = redis.call("INCR", KEYS[1])
+redis.call("EXPIRE", KEYS[1], ARGV[1])
+return count`)
+
+// IncrWithExpiry is for incrementing the count which expires when it is not incremented for a while
+/* IncrWithExpiry takes the following keys as input:
+1."table" is a string which is used identify what kind of data we are storing.
+2."key" is a string which acts as a unique ID to increment the count and return same.
+3."expiretime" is of type int, the count is removed after so many seconds unless it is incremented again.
+*/
+func (p *ConnPool) IncrWithExpiry(table, key string, expiretime int) (int, *errors.Error) {
+	writePool := (*redis.Pool)(atomic.LoadPointer((*unsafe.Pointer)(unsafe.Pointer(&p.WritePool))))
+	if writePool == nil {
+		return 0, errors.PackError(errors.UndefinedErrorType, "IncrWithExpiry : WritePool is nil ")
+	}
+	writeConn := writePool.Get()
+	defer writeConn.Close()
+
+	count, err := redis.Int(incrWithExpiryScript.Do(writeConn, table+":"+key, expiretime))
+	if err != nil {
+		if errs, aye := isDbConnectError(err); aye {
+			return 0, errs
+		}
+		return 0, errors.PackError(errors.UndefinedErrorType, "error while trying to increment the count: ", err)
+	}
+	return count, nil
+}
+
 // SetExpire key to hold the string value and set key to timeout after a given number of seconds
 /* SetExpire takes the following keys as input:
 1."table" is a string which is used identify what kind of data we are storing.
@@ -1614,6 +1644,146 @@ func (p *ConnPool) TTL(table, key string) (int, *errors.Error) {
 	return time, nil
 }
 
+// acquireLeaseScript takes the lease when nobody holds it, or renews it when
+// the caller already is the holder
+var acquireLeaseScript = redis.NewScript(1, `
+if redis.call("SET", KEYS[1], ARGV[1], "NX", "EX", ARGV[2]) then
+	return 1
+end
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	redis.call("EXPIRE", KEYS[1], ARGV[2])
+	return 1
+end
+return 0`)
+
+// releaseLeaseScript drops the lease only when the caller still holds it
+var releaseLeaseScript = redis.NewScript(1, `
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+// AcquireLease is for taking an expiring lease on a key, so that only one of
+// the replicas of a service works on the resource it names
+/* AcquireLease takes the following keys as input:
+1."table" is a string which is used identify the kind of the leases.
+2."key" is a string which acts as a unique ID to the lease.
+3."owner" is a string which identifies the replica asking for the lease.
+4."expiretime" is of type int, the lease is released after so many seconds
+unless it is acquired again by the owner.
+The return value is true when the owner holds the lease.
+*/
+func (p *ConnPool) AcquireLease(table, key, owner string, expiretime int) (bool, *errors.Error) {
+	writePool := (*redis.Pool)(atomic.LoadPointer((*unsafe.Pointer)(unsafe.Pointer(&p.WritePool))))
+	if writePool == nil {
+		return false, errors.PackError(errors.UndefinedErrorType, "AcquireLease : WritePool is nil ")
+	}
+	writeConn := writePool.Get()
+	defer writeConn.Close()
+
+	acquired, err := redis.Bool(acquireLeaseScript.Do(writeConn, table+":"+key, owner, expiretime))
+	if err != nil {
+		if errs, aye := isDbConnectError(err); aye {
+			return false, errs
+		}
+		return false, errors.PackError(errors.UndefinedErrorType, "error while trying to acquire the lease: ", err)
+	}
+	return acquired, nil
+}
+
+// ReleaseLease is for giving up the lease on a key taken by AcquireLease,
+// the lease is left untouched if it is held by another owner
+func (p *ConnPool) ReleaseLease(table, key, owner string) *errors.Error {
+	writePool := (*redis.Pool)(atomic.LoadPointer((*unsafe.Pointer)(unsafe.Pointer(&p.WritePool))))
+	if writePool == nil {
+		return errors.PackError(errors.UndefinedErrorType, "ReleaseLease : WritePool is nil ")
+	}
+	writeConn := writePool.Get()
+	defer writeConn.Close()
+
+	if _, err := releaseLeaseScript.Do(writeConn, table+":"+key, owner); err != nil {
+		if errs, aye := isDbConnectError(err); aye {
+			return errs
+		}
+		return errors.PackError(errors.UndefinedErrorType, "error while trying to release the lease: ", err)
+	}
+	return nil
+}
+
+// swapAndCountScript replaces the data when it still is the expected one,
+// and moves it between the counts in the same step. KEYS are the key of the
+// data followed by the counts to decrement and the counts to increment.
+var swapAndCountScript = redis.NewScript(-1, `
+local current = redis.call("GET", KEYS[1])
+if current == false then
+	current = ""
+end
+if current ~= ARGV[1] then
+	return 0
+end
+if ARGV[2] == "" then
+	redis.call("DEL", KEYS[1])
+else
+	redis.call("SET", KEYS[1], ARGV[2])
+end
+local decrements = tonumber(ARGV[3])
+for i = 2, #KEYS do
+	if i <= decrements + 1 then
+		redis.call("DECR", KEYS[i])
+	else
+		redis.call("INCR", KEYS[i])
+	end
+end
+return 1`)
+
+// SwapAndCount is for replacing the data of a key together with the counts
+// kept about it, so that concurrent writers can't leave the counts out of
+// step with the data
+/* SwapAndCount takes the following keys as input:
+1."table" is a string which is used identify what kind of data we are storing.
+2."key" is a string which acts as a unique ID to the data entry.
+3."oldData" is the data expected in the DB, nil when the key is expected to be absent.
+4."newData" is the data to be stored, nil for deleting the key.
+5."countTable" is the table of the counts.
+6."decrKeys" and "incrKeys" are the counts to decrement and to increment.
+The return value is false when the data in the DB is not oldData anymore,
+nothing is changed then.
+*/
+func (p *ConnPool) SwapAndCount(table, key string, oldData, newData interface{}, countTable string, decrKeys, incrKeys []string) (bool, *errors.Error) {
+	var values [2]string
+	for i, data := range []interface{}{oldData, newData} {
+		if data == nil {
+			continue
+		}
+		jsondata, err := json.Marshal(data)
+		if err != nil {
+			return false, errors.PackError(errors.UndefinedErrorType, "Write to DB in json form failed: "+err.Error())
+		}
+		values[i] = string(jsondata)
+	}
+
+	writePool := (*redis.Pool)(atomic.LoadPointer((*unsafe.Pointer)(unsafe.Pointer(&p.WritePool))))
+	if writePool == nil {
+		return false, errors.PackError(errors.UndefinedErrorType, "SwapAndCount : WritePool is nil ")
+	}
+	writeConn := writePool.Get()
+	defer writeConn.Close()
+
+	args := []interface{}{1 + len(decrKeys) + len(incrKeys), table + ":" + key}
+	for _, countKey := range append(append([]string{}, decrKeys...), incrKeys...) {
+		args = append(args, countTable+":"+countKey)
+	}
+	args = append(args, values[0], values[1], len(decrKeys))
+	swapped, err := redis.Bool(swapAndCountScript.Do(writeConn, args...))
+	if err != nil {
+		if errs, aye := isDbConnectError(err); aye {
+			return false, errs
+		}
+		return false, errors.PackError(errors.UndefinedErrorType, "error while trying to swap the data: ", err)
+	}
+	return swapped, nil
+}
+
 // CreateAggregateHostIndex is used to create and save secondary index
 /* CreateAggregateHostIndex take the following keys are input:
 1. index is the name of the index to be created
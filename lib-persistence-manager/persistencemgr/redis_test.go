@@ -1451,6 +1451,131 @@ func TestTTL(t *testing.T) {
 
 }
 
+func TestIncrWithExpiry(t *testing.T) {
+	c, err := MockDBConnection(t)
+	if err != nil {
+		t.Fatal("Error while making mock DB connection:", err)
+	}
+	defer c.Delete("table", "count")
+
+	for want := 1; want <= 3; want++ {
+		got, rerr := c.IncrWithExpiry("table", "count", 30)
+		if rerr != nil {
+			t.Fatalf("Error while incrementing the count: %v\n", rerr.Error())
+		}
+		if got != want {
+			t.Errorf("IncrWithExpiry() = %v, want %v", got, want)
+		}
+	}
+	ttl, rerr := c.TTL("table", "count")
+	if rerr != nil || ttl <= 0 {
+		t.Errorf("TTL() = %v %v, want the count to expire", ttl, rerr)
+	}
+}
+
+func TestAcquireLease(t *testing.T) {
+	c, err := MockDBConnection(t)
+	if err != nil {
+		t.Fatal("Error while making mock DB connection:", err)
+	}
+	defer c.ReleaseLease("table", "lease", "owner1")
+
+	tests := []struct {
+		name  string
+		owner string
+		want  bool
+	}{
+		{"free lease", "owner1", true},
+		{"lease held by another owner", "owner2", false},
+		{"renewal by the holder", "owner1", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, rerr := c.AcquireLease("table", "lease", tt.owner, 30)
+			if rerr != nil {
+				t.Fatalf("Error while acquiring the lease: %v\n", rerr.Error())
+			}
+			if got != tt.want {
+				t.Errorf("AcquireLease() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	ttl, rerr := c.TTL("table", "lease")
+	if rerr != nil || ttl <= 0 {
+		t.Errorf("TTL() = %v %v, want the lease to expire", ttl, rerr)
+	}
+}
+
+func TestReleaseLease(t *testing.T) {
+	c, err := MockDBConnection(t)
+	if err != nil {
+		t.Fatal("Error while making mock DB connection:", err)
+	}
+	if _, rerr := c.AcquireLease("table", "lease", "owner1", 30); rerr != nil {
+		t.Fatalf("Error while acquiring the lease: %v\n", rerr.Error())
+	}
+
+	// only the holder gives up the lease
+	if rerr := c.ReleaseLease("table", "lease", "owner2"); rerr != nil {
+		t.Errorf("Error while releasing the lease: %v\n", rerr.Error())
+	}
+	if got, _ := c.AcquireLease("table", "lease", "owner2", 30); got {
+		t.Errorf("AcquireLease() = %v, want the lease to be held by owner1", got)
+	}
+	if rerr := c.ReleaseLease("table", "lease", "owner1"); rerr != nil {
+		t.Errorf("Error while releasing the lease: %v\n", rerr.Error())
+	}
+	if got, _ := c.AcquireLease("table", "lease", "owner2", 30); !got {
+		t.Errorf("AcquireLease() = %v, want the released lease to be free", got)
+	}
+	c.ReleaseLease("table", "lease", "owner2")
+}
+
+func TestSwapAndCount(t *testing.T) {
+	c, err := MockDBConnection(t)
+	if err != nil {
+		t.Fatal("Error while making mock DB connection:", err)
+	}
+	defer func() {
+		c.Delete("table", "key")
+		c.Delete("counts", "old")
+		c.Delete("counts", "new")
+	}()
+
+	tests := []struct {
+		name     string
+		oldData  interface{}
+		newData  interface{}
+		decrKeys []string
+		incrKeys []string
+		want     bool
+	}{
+		{"absent key", nil, "old", nil, []string{"old"}, true},
+		{"stale data", nil, "new", nil, []string{"new"}, false},
+		{"expected data", "old", "new", []string{"old"}, []string{"new"}, true},
+		{"deletion", "new", nil, []string{"new"}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, rerr := c.SwapAndCount("table", "key", tt.oldData, tt.newData, "counts", tt.decrKeys, tt.incrKeys)
+			if rerr != nil {
+				t.Fatalf("Error while swapping the data: %v\n", rerr.Error())
+			}
+			if got != tt.want {
+				t.Errorf("SwapAndCount() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if _, rerr := c.Read("table", "key"); rerr == nil {
+		t.Errorf("Read() want the deleted key to be absent")
+	}
+	for _, key := range []string{"old", "new"} {
+		if count, _ := c.Read("counts", key); count != "0" {
+			t.Errorf("count %v = %v, want 0", key, count)
+		}
+	}
+}
+
 func TestConnPool_GetWriteConnection(t *testing.T) {
 	c, err := MockDBConnection(t)
 	if err != nil {
@@ -91,5 +91,8 @@ func CreateHeader(req *http.Request, ctx context.Context) *http.Request {
 		req.Header.Set(common.ThreadName, threadName)
 		req.Header.Set(common.ProcessName, processName)
 	}
+	if etag, ok := ctx.Value(common.IfNoneMatch).(string); ok && etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
 	return req
 }
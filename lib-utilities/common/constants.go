@@ -161,6 +161,8 @@ const (
 	ActionID      = "actionid"
 	ProcessName   = "processname"
 	RequestBody   = "requestbody"
+	// IfNoneMatch is the context key of the ETag sent in the If-None-Match header of the plugin request
+	IfNoneMatch = "ifnonematch"
 	// Below fields define Service Name
 	ManagerService     = "svc-managers"
 	AccountService     = "svc-account"
@@ -193,13 +195,21 @@ const (
 	SetBootOrder                           = "SettingBootOrder"
 	CollectAndSetDefaultBootOrder          = "CollectAndSetDefaultBoorOrder"
 	AddAggregationSource                   = "AddingAggregationSource"
+	BulkAddAggregationSources              = "BulkAddingAggregationSources"
+	DiscoverAggregationSources             = "DiscoveringAggregationSources"
+	ApproveDiscoveredAggregationSources    = "ApprovingDiscoveredAggregationSources"
+	RotateAggregationSourceCredentials     = "RotatingAggregationSourceCredentials"
 	DeleteAggregationSource                = "DeleteAggregationSource"
 	SubTaskStatusUpdate                    = "SubTaskStatusUpdate"
 	ResetSystem                            = "ResetSystem"
 	SetDefaultBootOrderElementsOfAggregate = "SetDefaultBootOrderElementsOfAggregate"
+	ApplySettingsElementsOfAggregate       = "ApplySettingsElementsOfAggregate"
+	ApplySystemSettings                    = "ApplySystemSettings"
 	RediscoverSystemInventory              = "RediscoverSystemInventory"
+	RefreshResourceInventory               = "RefreshResourceInventory"
 	CheckPluginStatus                      = "CheckPluginStatus"
 	GetTelemetryResource                   = "GetTelemetryResource"
+	CollectMetricReport                    = "CollectMetricReport"
 	// constants for log
 	SessionToken            = "sessiontoken"
 	SessionUserID           = "sessionuserid"
@@ -313,12 +323,25 @@ var Actions = map[ActionKey]ActionType{
 	// Actions URI
 	{"Systems", "ComputerSystem.Reset", "POST"}:               {"075", "ComputerSystemReset"},
 	{"Systems", "ComputerSystem.SetDefaultBootOrder", "POST"}: {"076", "SetDefaultBootOrder"},
+	{"Systems", "HealthSummary", "GET"}:                       {"238", "GetHealthSummary"},
 	// Aggregation URI
-	{"AggregationService", "AggregationService", "GET"}:                      {"077", "GetAggregationService"},
-	{"AggregationService", "ResetActionInfo", "GET"}:                         {"078", "GetResetActionInfoService"},
-	{"AggregationService", "SetDefaultBootOrderActionInfo", "GET"}:           {"079", "GetSetDefaultBootOrderActionInfo"},
-	{"AggregationService", "AggregationService.Reset", "POST"}:               {"080", "AggregationServiceReset"},
-	{"AggregationService", "AggregationService.SetDefaultBootOrder", "POST"}: {"081", "SetDefaultBootOrder"},
+	{"AggregationService", "AggregationService", "GET"}:                        {"077", "GetAggregationService"},
+	{"AggregationService", "ResetActionInfo", "GET"}:                           {"078", "GetResetActionInfoService"},
+	{"AggregationService", "SetDefaultBootOrderActionInfo", "GET"}:             {"079", "GetSetDefaultBootOrderActionInfo"},
+	{"AggregationService", "AggregationService.Reset", "POST"}:                 {"080", "AggregationServiceReset"},
+	{"AggregationService", "AggregationService.SetDefaultBootOrder", "POST"}:   {"081", "SetDefaultBootOrder"},
+	{"AggregationService", "Odim.BulkAddAggregationSources", "POST"}:           {"226", "BulkAddAggregationSources"},
+	{"AggregationService", "Odim.DiscoverAggregationSources", "POST"}:          {"227", "DiscoverAggregationSources"},
+	{"AggregationService", "Odim.ApproveDiscoveredAggregationSources", "POST"}: {"228", "ApproveDiscoveredAggregationSources"},
+	{"AggregationService", "DiscoveredAggregationSources", "GET"}:              {"229", "GetAllDiscoveredAggregationSources"},
+	{"AggregationService", "DiscoveredAggregationSources/{id}", "GET"}:         {"230", "GetDiscoveredAggregationSource"},
+	{"AggregationService", "Odim.RotateAggregationSourceCredentials", "POST"}:  {"231", "RotateAggregationSourceCredentials"},
+	{"AggregationService", "InventoryHistory/{id}", "GET"}:                     {"234", "GetInventoryHistory"},
+	{"AggregationService", "InventorySync", "GET"}:                             {"235", "GetInventorySync"},
+	{"AggregationService", "InventorySync", "PATCH"}:                           {"236", "UpdateInventorySync"},
+	{"AggregationService", "Odim.ValidateInteropProfile", "POST"}:              {"239", "ValidateInteropProfile"},
+	{"AggregationService", "ConformanceReports", "GET"}:                        {"240", "GetConformanceReports"},
+	{"AggregationService", "ConformanceReports/{id}", "GET"}:                   {"241", "GetConformanceReport"},
 	//AggregationSources URI
 	{"AggregationService", "AggregationSources", "POST"}:        {"082", "AddAggregationSource"},
 	{"AggregationService", "AggregationSources", "GET"}:         {"083", "GetAllAggregationSource"},
@@ -337,6 +360,8 @@ var Actions = map[ActionKey]ActionType{
 	{"AggregationService", "Aggregate.RemoveElements", "POST"}:      {"103", "RemoveElementsFromAggregate"},
 	{"AggregationService", "Aggregate.Reset", "POST"}:               {"104", "ResetAggregateElements"},
 	{"AggregationService", "Aggregate.SetDefaultBootOrder", "POST"}: {"105", "SetDefaultBootOrderAggregateElements"},
+	{"AggregationService", "Aggregate.ApplySettings", "POST"}:       {"242", "ApplySettingsAggregateElements"},
+	{"AggregationService", "SettingsDrift", "GET"}:                  {"243", "GetAggregateSettingsDrift"},
 	// Chassis URI
 	{"Chassis", "Chassis", "GET"}:                     {"106", "GetChassisCollection"},
 	{"Chassis", "Chassis", "POST"}:                    {"107", "CreateChassis"},
@@ -370,12 +395,15 @@ var Actions = map[ActionKey]ActionType{
 	{"Chassis", "#Fans/{id}", "GET"}:                  {"135", "GetChassisFans"},
 	{"Chassis", "#Temperatures/{id}", "GET"}:          {"136", "GetChassisTemperatures"},
 	// EventService URI
-	{"EventService", "EventService", "GET"}:                 {"137", "GetEventService"},
-	{"EventService", "Subscriptions", "GET"}:                {"138", "GetEventSubscriptionsCollection"},
-	{"EventService", "Subscriptions/{id}", "GET"}:           {"139", "GetEventSubscription"},
-	{"EventService", "Subscriptions", "POST"}:               {"140", "CreateEventSubscription"},
-	{"EventService", "EventService.SubmitTestEvent", "GET"}: {"141", "SubmitTestEvent"},
-	{"EventService", "Subscriptions/{id}", "DELETE"}:        {"142", "DeleteEventSubscription"},
+	{"EventService", "EventService", "GET"}:                         {"137", "GetEventService"},
+	{"EventService", "Subscriptions", "GET"}:                        {"138", "GetEventSubscriptionsCollection"},
+	{"EventService", "Subscriptions/{id}", "GET"}:                   {"139", "GetEventSubscription"},
+	{"EventService", "Subscriptions", "POST"}:                       {"140", "CreateEventSubscription"},
+	{"EventService", "EventService.SubmitTestEvent", "GET"}:         {"141", "SubmitTestEvent"},
+	{"EventService", "Subscriptions/{id}", "DELETE"}:                {"142", "DeleteEventSubscription"},
+	{"EventService", "SSE", "GET"}:                                  {"218", "GetEventStream"},
+	{"EventService", "EventDestination.ResumeSubscription", "POST"}: {"219", "ResumeEventSubscription"},
+	{"EventService", "UndeliveredEvents", "GET"}:                    {"220", "GetUndeliveredEventsCollection"},
 	// Fabrics URI
 	{"Fabrics", "Fabrics", "GET"}:              {"143", "GetFabricCollection"},
 	{"Fabrics", "Fabrics/{id}", "GET"}:         {"144", "GetFabric"},
@@ -439,16 +467,21 @@ var Actions = map[ActionKey]ActionType{
 	{"UpdateService", "SoftwareInventory", "GET"}:           {"200", "GetSoftwareInventoryCollection"},
 	{"UpdateService", "SoftwareInventory/{id}", "GET"}:      {"201", "GetSoftwareInventory"},
 	// Telemetry Service URI
-	{"TelemetryService", "TelemetryService", "GET"}:             {"202", "GetTelemetryService"},
-	{"TelemetryService", "MetricDefinitions", "GET"}:            {"203", "GetMetricDefinitionCollection"},
-	{"TelemetryService", "MetricReportDefinitions", "GET"}:      {"204", "GetMetricReportDefinitionCollection"},
-	{"TelemetryService", "MetricReports", "GET"}:                {"205", "GetMetricReportCollection"},
-	{"TelemetryService", "Triggers", "GET"}:                     {"206", "GetTriggerCollection"},
-	{"TelemetryService", "MetricDefinitions/{id}", "GET"}:       {"207", "GetMetricDefinition"},
-	{"TelemetryService", "MetricReportDefinitions/{id}", "GET"}: {"208", "GetMetricReportDefinition"},
-	{"TelemetryService", "MetricReports/{id}", "GET"}:           {"209", "GetMetricReport"},
-	{"TelemetryService", "Triggers/{id}", "GET"}:                {"210", "GetTrigger"},
-	{"TelemetryService", "Triggers/{id}", "PATCH"}:              {"211", "UpdateTrigger"},
+	{"TelemetryService", "TelemetryService", "GET"}:                {"202", "GetTelemetryService"},
+	{"TelemetryService", "MetricDefinitions", "GET"}:               {"203", "GetMetricDefinitionCollection"},
+	{"TelemetryService", "MetricReportDefinitions", "GET"}:         {"204", "GetMetricReportDefinitionCollection"},
+	{"TelemetryService", "MetricReports", "GET"}:                   {"205", "GetMetricReportCollection"},
+	{"TelemetryService", "Triggers", "GET"}:                        {"206", "GetTriggerCollection"},
+	{"TelemetryService", "MetricDefinitions/{id}", "GET"}:          {"207", "GetMetricDefinition"},
+	{"TelemetryService", "MetricReportDefinitions/{id}", "GET"}:    {"208", "GetMetricReportDefinition"},
+	{"TelemetryService", "MetricReports/{id}", "GET"}:              {"209", "GetMetricReport"},
+	{"TelemetryService", "Triggers/{id}", "GET"}:                   {"210", "GetTrigger"},
+	{"TelemetryService", "Triggers/{id}", "PATCH"}:                 {"211", "UpdateTrigger"},
+	{"TelemetryService", "MetricReportDefinitions", "POST"}:        {"221", "CreateMetricReportDefinition"},
+	{"TelemetryService", "MetricReportDefinitions/{id}", "DELETE"}: {"222", "DeleteMetricReportDefinition"},
+	{"TelemetryService", "Triggers", "POST"}:                       {"223", "CreateTrigger"},
+	{"TelemetryService", "Triggers/{id}", "DELETE"}:                {"224", "DeleteTrigger"},
+	{"TelemetryService", "OpenMetrics", "GET"}:                     {"225", "GetOpenMetrics"},
 	//License Service URI
 	{"LicenseService", "LicenseService", "GET"}: {"212", "GetLicenseService"},
 	{"LicenseService", "Licenses", "GET"}:       {"213", "GetLicenseCollection"},
@@ -544,7 +577,7 @@ var ResourceTypes = map[string]string{
 	"LogEntry":               "LogEntry",
 	"LogService":             "LogServices",
 	"Manager":                "Manager",
-	"ManagerAccount":         "ManagerAccount",
+	"ManagerAccount":         "Accounts",
 	"ManagerNetworkProtocol": "ManagerNetworkProtocol",
 	"Memory":                 "Memory",
 	"MemoryChunks":           "MemoryChunks",
@@ -590,6 +623,9 @@ type Events struct {
 	IP        string `json:"ip"`
 	Request   []byte `json:"request"`
 	EventType string `json:"eventType"`
+	// Internal is set for the events published by the services of the aggregator,
+	// which are not received from the BMCs
+	Internal bool `json:"internal,omitempty"`
 }
 
 // MessageData contains information of Events and message details including arguments
@@ -31,9 +31,29 @@ var (
 	MuxLock = &sync.Mutex{}
 )
 
-// DecryptWithPrivateKey is used to decrypt ciphered text to device password
-// with the private key whose path is available in the config file
+// DecryptWithPrivateKey is used to decrypt ciphered text to device password.
+// The ciphered text is decrypted by the secret provider which encrypted it,
+// so the credentials stored before a change of the provider or its key stay readable
 func DecryptWithPrivateKey(ciphertext []byte) ([]byte, error) {
+	provider, err := getSecretProviderOf(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	return provider.Decrypt(ciphertext)
+}
+
+// EncryptWithPublicKey is used to encrypt device password with the secret provider configured in SecretConf
+func EncryptWithPublicKey(password []byte) ([]byte, error) {
+	provider, err := GetSecretProvider()
+	if err != nil {
+		return nil, err
+	}
+	return provider.Encrypt(password)
+}
+
+// rsaDecrypt is used to decrypt ciphered text to device password
+// with the private key whose path is available in the config file
+func rsaDecrypt(ciphertext []byte) ([]byte, error) {
 	MuxLock.Lock()
 	defer MuxLock.Unlock()
 	var err error
@@ -64,8 +84,8 @@ func DecryptWithPrivateKey(ciphertext []byte) ([]byte, error) {
 	return plainText, nil
 }
 
-// EncryptWithPublicKey is used to encrypt device password using odimra public key
-func EncryptWithPublicKey(password []byte) ([]byte, error) {
+// rsaEncrypt is used to encrypt device password using odimra public key
+func rsaEncrypt(password []byte) ([]byte, error) {
 	var err error
 	block, _ := pem.Decode(config.Data.KeyCertConf.RSAPublicKey)
 	enc := x509.IsEncryptedPEMBlock(block)
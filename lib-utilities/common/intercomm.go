@@ -22,6 +22,9 @@ const (
 	// SubscribeEMB is the ControlMessage type to
 	// indicate to subscribe plugin EMB
 	SubscribeEMB ControlMessage = iota
+	// CancelTask is the ControlMessage type to indicate
+	// the cancellation of a task to the service running it
+	CancelTask
 )
 
 // ControlMessageData holds the control message data
@@ -37,6 +40,13 @@ type SubscribeEMBData struct {
 	EMBQueues []string
 }
 
+// CancelTaskData holds the IDs of the task being cancelled
+// and of all its sub tasks
+type CancelTaskData struct {
+	TaskID     string
+	SubTaskIDs []string
+}
+
 // PluginStatusEvent contains details of the plugin status
 type PluginStatusEvent struct {
 	Name         string `json:"Name"`
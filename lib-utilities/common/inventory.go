@@ -0,0 +1,68 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"strings"
+)
+
+// GetInventoryTableNames returns the names of the tables in which the resource
+// with the given @odata.id can be found, among the BMC inventory saved in the
+// in-memory DB during the discovery. Candidates are ordered by likelihood.
+func GetInventoryTableNames(oid string) []string {
+	oid = strings.TrimSuffix(oid, "/")
+	urlData := strings.Split(oid, "/")
+	// a valid resource URI has at least /redfish/v1/{Collection}/{Id}
+	if len(urlData) < 5 {
+		return nil
+	}
+	if len(urlData) == 5 {
+		switch urlData[3] {
+		case "Systems":
+			return []string{"ComputerSystem"}
+		case "Chassis":
+			return []string{"Chassis"}
+		case "Managers":
+			return []string{"Managers"}
+		}
+	}
+	resourceName := urlData[len(urlData)-1]
+	var resourceTables map[string]string
+	switch urlData[3] {
+	case "Systems":
+		resourceTables = SystemResource
+	case "Chassis":
+		resourceTables = ChassisResource
+	case "Managers":
+		resourceTables = ManagersResource
+	}
+	var tables []string
+	add := func(table string) {
+		for _, t := range tables {
+			if t == table {
+				return
+			}
+		}
+		tables = append(tables, table)
+	}
+	if table, exist := resourceTables[resourceName]; exist {
+		add(table)
+	}
+	// members are saved in the table named after the parent collection
+	add(urlData[len(urlData)-2])
+	add(resourceName + "Collection")
+	add(resourceName)
+	return tables
+}
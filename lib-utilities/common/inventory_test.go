@@ -0,0 +1,45 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetInventoryTableNames(t *testing.T) {
+	systemURI := "/redfish/v1/Systems/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1"
+	tests := []struct {
+		oid  string
+		want []string
+	}{
+		{"/redfish/v1/Systems", nil},
+		{systemURI, []string{"ComputerSystem"}},
+		{systemURI + "/", []string{"ComputerSystem"}},
+		{"/redfish/v1/Chassis/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1", []string{"Chassis"}},
+		{"/redfish/v1/Managers/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1", []string{"Managers"}},
+		{systemURI + "/Processors", []string{"ProcessorsCollection", "6d4a0a66-7efa-578e-83cf-44dc68d2874e.1", "Processors"}},
+		{systemURI + "/Processors/1", []string{"Processors", "1Collection", "1"}},
+		{systemURI + "/Bios", []string{"Bios", "6d4a0a66-7efa-578e-83cf-44dc68d2874e.1", "BiosCollection"}},
+		{"/redfish/v1/Managers/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1/EthernetInterfaces", []string{"EthernetInterfacesCollection", "6d4a0a66-7efa-578e-83cf-44dc68d2874e.1", "EthernetInterfaces"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.oid, func(t *testing.T) {
+			if got := GetInventoryTableNames(tt.oid); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetInventoryTableNames() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"os"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	"github.com/google/uuid"
+)
+
+// leaseTable is the InMemory table holding the leases of the replicas
+const leaseTable = "Lease"
+
+// ReplicaID identifies the running replica of the service as the holder of the leases.
+// The pod name keeps the logs readable, the random suffix tells apart the restarts of the pod.
+var ReplicaID = os.Getenv("POD_NAME") + "-" + uuid.NewString()
+
+// AcquireLease takes the lease of the given name for the running replica, so that the
+// work guarded by the lease is done by only one of the replicas of the service.
+// Acquiring a lease already held by the replica renews it. The lease expires after
+// the ttl, so the work is taken over by another replica when the holder goes away.
+func AcquireLease(name string, ttl time.Duration) (bool, *errors.Error) {
+	conn, err := GetDBConnection(InMemory)
+	if err != nil {
+		return false, err
+	}
+	seconds := int(ttl / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+	return conn.AcquireLease(leaseTable, name, ReplicaID, seconds)
+}
+
+// ReleaseLease gives up the lease of the given name if it is held by the running replica
+func ReleaseLease(name string) *errors.Error {
+	conn, err := GetDBConnection(InMemory)
+	if err != nil {
+		return err
+	}
+	return conn.ReleaseLease(leaseTable, name, ReplicaID)
+}
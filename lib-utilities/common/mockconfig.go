@@ -52,6 +52,9 @@ func SetUpMockConfig() error {
 	config.Data.AuthConf = &config.AuthConf{
 		SessionTimeOutInMins:            30,
 		ExpiredSessionCleanUpTimeInMins: 15,
+		AccountLockoutThreshold:         config.DefaultAccountLockoutThreshold,
+		AccountLockoutDuration:          config.DefaultAccountLockoutDuration,
+		AccountLockoutCounterResetAfter: config.DefaultAccountLockoutCounterResetAfter,
 	}
 	config.Data.APIGatewayConf = &config.APIGatewayConf{
 		Port: "9090",
@@ -90,9 +93,10 @@ func SetUpMockConfig() error {
 		},
 	}
 	config.Data.AuthConf.PasswordRules = &config.PasswordRules{
-		MinPasswordLength:       12,
-		MaxPasswordLength:       16,
-		AllowedSpecialCharcters: "~!@#$%^&*-+_|(){}:;<>,.?/",
+		MinPasswordLength:        12,
+		MaxPasswordLength:        16,
+		AllowedSpecialCharcters:  "~!@#$%^&*-+_|(){}:;<>,.?/",
+		RequiredCharacterClasses: config.DefaultRequiredCharacterClasses,
 	}
 	config.Data.RootServiceUUID = "3bd1f589-117a-4cf9-89f2-da44ee8e012b"
 	config.Data.FirmwareVersion = "1.0"
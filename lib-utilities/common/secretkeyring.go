@@ -0,0 +1,186 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+)
+
+// keyringKeyLength is the length of the AES-256 keys of the keyring
+const keyringKeyLength = 32
+
+// Keyring is the content of the keyring file of the Keyring secret provider.
+// A key rotation adds a new key and sets CurrentVersion to it, the older keys
+// are kept until all the stored secrets are re-encrypted with the new key
+type Keyring struct {
+	CurrentVersion int          `json:"CurrentVersion"`
+	Keys           []KeyringKey `json:"Keys"`
+}
+
+// KeyringKey is a versioned AES-256 key of the keyring, Key holds the base64 encoded 32 bytes
+type KeyringKey struct {
+	Version int    `json:"Version"`
+	Key     []byte `json:"Key"`
+}
+
+var (
+	// keyringCache holds the keyring last read from the file, it is read
+	// again when the modification time of the file changes
+	keyringCache = struct {
+		sync.Mutex
+		filePath string
+		modTime  time.Time
+		keyring  *Keyring
+	}{}
+)
+
+// keyringSecretProvider encrypts the secrets with AES-256-GCM using the versioned keys of the keyring file
+type keyringSecretProvider struct {
+	filePath string
+}
+
+func (p keyringSecretProvider) Name() string {
+	return config.SecretProviderKeyring
+}
+
+func (p keyringSecretProvider) Encrypt(plainText []byte) ([]byte, error) {
+	keyring, err := loadKeyring(p.filePath)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := keyring.cipher(keyring.CurrentVersion)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("error while generating the nonce: %v", err)
+	}
+	header := secretHeader(p.Name(), keyring.CurrentVersion)
+	// the header is authenticated to detect a secret moved to another key version
+	secret := append(append([]byte{}, header...), nonce...)
+	return aead.Seal(secret, nonce, plainText, header), nil
+}
+
+func (p keyringSecretProvider) Decrypt(secret []byte) ([]byte, error) {
+	_, version, payload, err := parseSecretHeader(secret)
+	if err != nil {
+		return nil, err
+	}
+	keyring, err := loadKeyring(p.filePath)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := keyring.cipher(version)
+	if err != nil {
+		return nil, err
+	}
+	if len(payload) < aead.NonceSize() {
+		return nil, fmt.Errorf("error: secret is too short")
+	}
+	header := secret[:len(secret)-len(payload)]
+	plainText, err := aead.Open(nil, payload[:aead.NonceSize()], payload[aead.NonceSize():], header)
+	if err != nil {
+		return nil, fmt.Errorf("error while trying to decrypt password: %v", err)
+	}
+	return plainText, nil
+}
+
+func (p keyringSecretProvider) IsCurrent(secret []byte) (bool, error) {
+	_, version, _, err := parseSecretHeader(secret)
+	if err != nil {
+		return false, err
+	}
+	keyring, err := loadKeyring(p.filePath)
+	if err != nil {
+		return false, err
+	}
+	return version == keyring.CurrentVersion, nil
+}
+
+func (p keyringSecretProvider) Delete(secret []byte) error {
+	return nil
+}
+
+// cipher returns the AES-GCM cipher of the key version
+func (k *Keyring) cipher(version int) (cipher.AEAD, error) {
+	for _, key := range k.Keys {
+		if key.Version != version {
+			continue
+		}
+		block, err := aes.NewCipher(key.Key)
+		if err != nil {
+			return nil, fmt.Errorf("error while creating the cipher of key version %d: %v", version, err)
+		}
+		return cipher.NewGCM(block)
+	}
+	return nil, fmt.Errorf("error: key version %d not found in the keyring", version)
+}
+
+// loadKeyring returns the keyring of the file, read again only when the file was modified
+func loadKeyring(filePath string) (*Keyring, error) {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading the keyring file %s: %v", filePath, err)
+	}
+	keyringCache.Lock()
+	defer keyringCache.Unlock()
+	if keyringCache.keyring != nil && keyringCache.filePath == filePath && keyringCache.modTime.Equal(info.ModTime()) {
+		return keyringCache.keyring, nil
+	}
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading the keyring file %s: %v", filePath, err)
+	}
+	var keyring Keyring
+	if err := json.Unmarshal(data, &keyring); err != nil {
+		return nil, fmt.Errorf("error while unmarshaling the keyring file %s: %v", filePath, err)
+	}
+	if err := keyring.validate(); err != nil {
+		return nil, fmt.Errorf("error: invalid keyring file %s: %v", filePath, err)
+	}
+	keyringCache.filePath = filePath
+	keyringCache.modTime = info.ModTime()
+	keyringCache.keyring = &keyring
+	return &keyring, nil
+}
+
+func (k *Keyring) validate() error {
+	versions := make(map[int]bool, len(k.Keys))
+	for _, key := range k.Keys {
+		if versions[key.Version] {
+			return fmt.Errorf("key version %d is duplicated", key.Version)
+		}
+		if len(key.Key) != keyringKeyLength {
+			return fmt.Errorf("key version %d is not %d bytes long", key.Version, keyringKeyLength)
+		}
+		versions[key.Version] = true
+	}
+	if !versions[k.CurrentVersion] {
+		return fmt.Errorf("current key version %d not found", k.CurrentVersion)
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+)
+
+// secretHeaderPrefix is the prefix of the header of the secrets created by the
+// Keyring and Vault providers. The header has the form odim:<provider>:<version>:
+// and the secrets without it are the RSA ciphered texts stored before the providers existed
+const secretHeaderPrefix = "odim:"
+
+// SecretProvider is the interface implemented by the backends which protect
+// the device and plugin credentials stored in DB
+type SecretProvider interface {
+	// Name returns the name of the provider as configured in SecretConf
+	Name() string
+	// Encrypt returns the secret to be stored in DB for the plain text
+	Encrypt(plainText []byte) ([]byte, error)
+	// Decrypt returns the plain text of a secret created by the provider
+	Decrypt(secret []byte) ([]byte, error)
+	// IsCurrent tells whether a secret created by the provider is protected with its current key
+	IsCurrent(secret []byte) (bool, error)
+	// Delete removes a secret created by the provider once it is no longer stored in DB,
+	// nothing is done by the providers which keep the whole secret in DB
+	Delete(secret []byte) error
+}
+
+// GetSecretProvider returns the secret provider configured in SecretConf
+func GetSecretProvider() (SecretProvider, error) {
+	if config.Data.SecretConf == nil {
+		return rsaSecretProvider{}, nil
+	}
+	return getSecretProvider(config.Data.SecretConf.Provider)
+}
+
+func getSecretProvider(name string) (SecretProvider, error) {
+	switch name {
+	case "", config.SecretProviderRSA:
+		return rsaSecretProvider{}, nil
+	case config.SecretProviderKeyring:
+		return keyringSecretProvider{filePath: config.Data.SecretConf.KeyringFilePath}, nil
+	case config.SecretProviderVault:
+		if config.Data.SecretConf.VaultConf == nil {
+			return nil, fmt.Errorf("error: VaultConf is not provided")
+		}
+		return vaultSecretProvider{conf: *config.Data.SecretConf.VaultConf}, nil
+	}
+	return nil, fmt.Errorf("error: unknown secret provider %s", name)
+}
+
+// getSecretProviderOf returns the secret provider which created the secret
+func getSecretProviderOf(secret []byte) (SecretProvider, error) {
+	name, _, _, err := parseSecretHeader(secret)
+	if err != nil {
+		return nil, err
+	}
+	if name == config.SecretProviderRSA {
+		return rsaSecretProvider{}, nil
+	}
+	if config.Data.SecretConf == nil {
+		return nil, fmt.Errorf("error: SecretConf is not provided to decrypt the %s secret", name)
+	}
+	switch name {
+	case config.SecretProviderKeyring:
+		// the keyring file keeps the older key versions, so it decrypts the secrets
+		// even after the provider was changed, as long as the file is configured
+		if config.Data.SecretConf.KeyringFilePath == "" {
+			return nil, fmt.Errorf("error: KeyringFilePath is not provided to decrypt the %s secret", name)
+		}
+	case config.SecretProviderVault:
+		if config.Data.SecretConf.VaultConf == nil {
+			return nil, fmt.Errorf("error: VaultConf is not provided to decrypt the %s secret", name)
+		}
+	}
+	return getSecretProvider(name)
+}
+
+// ReencryptSecret returns the secret protected with the current key of the configured secret provider.
+// The boolean return value is false when the secret is already protected with it and nothing was done
+func ReencryptSecret(secret []byte) ([]byte, bool, error) {
+	current, err := GetSecretProvider()
+	if err != nil {
+		return nil, false, err
+	}
+	name, _, _, err := parseSecretHeader(secret)
+	if err != nil {
+		return nil, false, err
+	}
+	if name == current.Name() {
+		isCurrent, err := current.IsCurrent(secret)
+		if err != nil || isCurrent {
+			return nil, false, err
+		}
+	}
+	plainText, err := DecryptWithPrivateKey(secret)
+	if err != nil {
+		return nil, false, err
+	}
+	reencrypted, err := current.Encrypt(plainText)
+	if err != nil {
+		return nil, false, err
+	}
+	return reencrypted, true, nil
+}
+
+// DeleteSecret removes the secret from the provider which created it. It is called once the secret
+// is replaced or removed in DB, so that the secrets held outside of DB are not left behind
+func DeleteSecret(secret []byte) error {
+	provider, err := getSecretProviderOf(secret)
+	if err != nil {
+		return err
+	}
+	return provider.Delete(secret)
+}
+
+// secretHeader returns the header of the secrets created by the provider with the key version
+func secretHeader(provider string, version int) []byte {
+	return []byte(secretHeaderPrefix + provider + ":" + strconv.Itoa(version) + ":")
+}
+
+// parseSecretHeader splits a secret into the provider name, the key version and the payload
+func parseSecretHeader(secret []byte) (string, int, []byte, error) {
+	if !bytes.HasPrefix(secret, []byte(secretHeaderPrefix)) {
+		return config.SecretProviderRSA, 0, secret, nil
+	}
+	parts := bytes.SplitN(secret[len(secretHeaderPrefix):], []byte(":"), 3)
+	if len(parts) != 3 {
+		return "", 0, nil, fmt.Errorf("error: invalid secret header")
+	}
+	version, err := strconv.Atoi(string(parts[1]))
+	if err != nil {
+		return "", 0, nil, fmt.Errorf("error: invalid key version in the secret header: %v", err)
+	}
+	return string(parts[0]), version, parts[2], nil
+}
+
+// rsaSecretProvider encrypts the secrets with the RSA key pair of KeyCertConf.
+// The ciphered texts have no header to stay compatible with the ones stored earlier
+type rsaSecretProvider struct{}
+
+func (rsaSecretProvider) Name() string {
+	return config.SecretProviderRSA
+}
+
+func (rsaSecretProvider) Encrypt(plainText []byte) ([]byte, error) {
+	return rsaEncrypt(plainText)
+}
+
+func (rsaSecretProvider) Decrypt(secret []byte) ([]byte, error) {
+	return rsaDecrypt(secret)
+}
+
+// IsCurrent always returns true since the RSA key pair can not be versioned,
+// a replaced key pair makes the stored secrets unreadable
+func (rsaSecretProvider) IsCurrent(secret []byte) (bool, error) {
+	return true, nil
+}
+
+func (rsaSecretProvider) Delete(secret []byte) error {
+	return nil
+}
@@ -0,0 +1,267 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+)
+
+func setSecretConf(t *testing.T, conf *config.SecretConf) {
+	keyCertConf, secretConf := config.Data.KeyCertConf, config.Data.SecretConf
+	t.Cleanup(func() {
+		config.Data.KeyCertConf, config.Data.SecretConf = keyCertConf, secretConf
+	})
+	config.Data.KeyCertConf = &config.KeyCertConf{
+		RSAPublicKey:  []byte(publicKey),
+		RSAPrivateKey: []byte(privateKey),
+	}
+	config.Data.SecretConf = conf
+}
+
+func writeKeyring(t *testing.T, filePath string, keyring Keyring, modTime time.Time) {
+	data, err := json.Marshal(keyring)
+	if err != nil {
+		t.Fatalf("failed to marshal the keyring: %v", err)
+	}
+	if err := ioutil.WriteFile(filePath, data, 0600); err != nil {
+		t.Fatalf("failed to write the keyring: %v", err)
+	}
+	// the keyring is read again only when the modification time changes
+	if err := os.Chtimes(filePath, modTime, modTime); err != nil {
+		t.Fatalf("failed to set the keyring modification time: %v", err)
+	}
+}
+
+func TestKeyringSecretProvider(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "keyring.json")
+	key1, key2 := bytes.Repeat([]byte{1}, keyringKeyLength), bytes.Repeat([]byte{2}, keyringKeyLength)
+	now := time.Now()
+	writeKeyring(t, filePath, Keyring{CurrentVersion: 1, Keys: []KeyringKey{{Version: 1, Key: key1}}}, now)
+	setSecretConf(t, &config.SecretConf{Provider: config.SecretProviderKeyring, KeyringFilePath: filePath})
+
+	legacy, err := rsaEncrypt([]byte("legacyPassword"))
+	if err != nil {
+		t.Fatalf("rsaEncrypt failed with %v", err)
+	}
+	secretV1, err := EncryptWithPublicKey([]byte("testPassword"))
+	if err != nil {
+		t.Fatalf("EncryptWithPublicKey failed with %v", err)
+	}
+	if !strings.HasPrefix(string(secretV1), "odim:Keyring:1:") {
+		t.Errorf("EncryptWithPublicKey returned secret without keyring header: %q", secretV1)
+	}
+	if _, reencrypted, err := ReencryptSecret(secretV1); err != nil || reencrypted {
+		t.Errorf("ReencryptSecret() of a current secret = %v, %v, want false, nil", reencrypted, err)
+	}
+
+	// rotate the key, the secrets of the older key must stay readable
+	writeKeyring(t, filePath, Keyring{CurrentVersion: 2, Keys: []KeyringKey{{Version: 1, Key: key1}, {Version: 2, Key: key2}}}, now.Add(time.Minute))
+	for _, tt := range []struct {
+		name   string
+		secret []byte
+		want   string
+	}{
+		{name: "older keyring key", secret: secretV1, want: "testPassword"},
+		{name: "legacy RSA secret", secret: legacy, want: "legacyPassword"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			plainText, err := DecryptWithPrivateKey(tt.secret)
+			if err != nil || string(plainText) != tt.want {
+				t.Fatalf("DecryptWithPrivateKey() = %q, %v, want %q", plainText, err, tt.want)
+			}
+			secretV2, reencrypted, err := ReencryptSecret(tt.secret)
+			if err != nil || !reencrypted {
+				t.Fatalf("ReencryptSecret() = %v, %v, want true, nil", reencrypted, err)
+			}
+			if !strings.HasPrefix(string(secretV2), "odim:Keyring:2:") {
+				t.Errorf("ReencryptSecret() returned secret without current key version: %q", secretV2)
+			}
+			plainText, err = DecryptWithPrivateKey(secretV2)
+			if err != nil || string(plainText) != tt.want {
+				t.Errorf("DecryptWithPrivateKey() of re-encrypted secret = %q, %v, want %q", plainText, err, tt.want)
+			}
+		})
+	}
+
+	// a secret with a tampered key version fails the authentication
+	tampered := append([]byte("odim:Keyring:2:"), secretV1[len("odim:Keyring:1:"):]...)
+	if _, err := DecryptWithPrivateKey(tampered); err == nil {
+		t.Errorf("DecryptWithPrivateKey() of a tampered secret succeeded")
+	}
+}
+
+func TestLoadKeyringInvalid(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		keyring Keyring
+	}{
+		{name: "current version missing", keyring: Keyring{CurrentVersion: 2, Keys: []KeyringKey{{Version: 1, Key: make([]byte, keyringKeyLength)}}}},
+		{name: "short key", keyring: Keyring{CurrentVersion: 1, Keys: []KeyringKey{{Version: 1, Key: make([]byte, 16)}}}},
+		{name: "duplicate version", keyring: Keyring{CurrentVersion: 1, Keys: []KeyringKey{{Version: 1, Key: make([]byte, keyringKeyLength)}, {Version: 1, Key: make([]byte, keyringKeyLength)}}}},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(dir, strconv.Itoa(i)+".json")
+			writeKeyring(t, filePath, tt.keyring, time.Now())
+			if _, err := loadKeyring(filePath); err == nil {
+				t.Errorf("loadKeyring() succeeded for an invalid keyring")
+			}
+		})
+	}
+}
+
+// fakeVault is a minimal KV version 2 secrets engine
+type fakeVault struct {
+	sync.Mutex
+	token   string
+	secrets map[string][]json.RawMessage
+}
+
+func (v *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	v.Lock()
+	defer v.Unlock()
+	if r.Header.Get("X-Vault-Token") != v.token {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	path := strings.TrimPrefix(r.URL.Path, "/v1/")
+	switch r.Method {
+	case http.MethodPost:
+		var body struct {
+			Data json.RawMessage `json:"data"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		v.secrets[path] = append(v.secrets[path], body.Data)
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"version": len(v.secrets[path])}})
+	case http.MethodGet:
+		version, _ := strconv.Atoi(r.URL.Query().Get("version"))
+		if version < 1 || version > len(v.secrets[path]) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"data": v.secrets[path][version-1]}})
+	case http.MethodDelete:
+		if !strings.Contains(path, "/metadata/") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		delete(v.secrets, strings.Replace(path, "/metadata/", "/data/", 1))
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestVaultSecretProvider(t *testing.T) {
+	vault := &fakeVault{token: "s.testToken", secrets: map[string][]json.RawMessage{}}
+	server := httptest.NewServer(vault)
+	defer server.Close()
+	httpClient := vaultHTTPClient
+	defer func() { vaultHTTPClient = httpClient }()
+	vaultHTTPClient = func() (*http.Client, error) {
+		return server.Client(), nil
+	}
+	tokenFilePath := filepath.Join(t.TempDir(), "token")
+	if err := ioutil.WriteFile(tokenFilePath, []byte(vault.token+"\n"), 0600); err != nil {
+		t.Fatalf("failed to write the token: %v", err)
+	}
+	vaultConf := &config.VaultConf{
+		Address:       server.URL,
+		TokenFilePath: tokenFilePath,
+		MountPath:     config.DefaultVaultMountPath,
+		SecretPath:    config.DefaultVaultSecretPath,
+	}
+	setSecretConf(t, &config.SecretConf{Provider: config.SecretProviderVault, VaultConf: vaultConf})
+
+	secret, err := EncryptWithPublicKey([]byte("testPassword"))
+	if err != nil {
+		t.Fatalf("EncryptWithPublicKey failed with %v", err)
+	}
+	if !strings.HasPrefix(string(secret), "odim:Vault:1:secret/data/odimra/") {
+		t.Errorf("EncryptWithPublicKey returned invalid vault reference: %q", secret)
+	}
+	if bytes.Contains(secret, []byte("testPassword")) {
+		t.Errorf("EncryptWithPublicKey stored the password in the vault reference")
+	}
+	plainText, err := DecryptWithPrivateKey(secret)
+	if err != nil || string(plainText) != "testPassword" {
+		t.Errorf("DecryptWithPrivateKey() = %q, %v, want testPassword", plainText, err)
+	}
+	if _, reencrypted, err := ReencryptSecret(secret); err != nil || reencrypted {
+		t.Errorf("ReencryptSecret() of a current secret = %v, %v, want false, nil", reencrypted, err)
+	}
+
+	// moving the secrets to another path re-encrypts them
+	vaultConf.SecretPath = "odimra-new"
+	moved, reencrypted, err := ReencryptSecret(secret)
+	if err != nil || !reencrypted || !strings.HasPrefix(string(moved), "odim:Vault:1:secret/data/odimra-new/") {
+		t.Errorf("ReencryptSecret() = %q, %v, %v, want secret under the new path", moved, reencrypted, err)
+	}
+
+	// the replaced secret is removed from vault
+	if err := DeleteSecret(secret); err != nil {
+		t.Fatalf("DeleteSecret() failed with %v", err)
+	}
+	if _, err := DecryptWithPrivateKey(secret); err == nil {
+		t.Errorf("DecryptWithPrivateKey() of a deleted secret succeeded")
+	}
+	if plainText, err := DecryptWithPrivateKey(moved); err != nil || string(plainText) != "testPassword" {
+		t.Errorf("DecryptWithPrivateKey() of the moved secret = %q, %v, want testPassword", plainText, err)
+	}
+
+	vault.token = "s.revokedToken"
+	if _, err := DecryptWithPrivateKey(moved); err == nil {
+		t.Errorf("DecryptWithPrivateKey() succeeded with a revoked token")
+	}
+}
+
+func TestGetSecretProviderOf(t *testing.T) {
+	setSecretConf(t, &config.SecretConf{Provider: config.SecretProviderRSA})
+	tests := []struct {
+		name    string
+		secret  []byte
+		want    string
+		wantErr bool
+	}{
+		{name: "legacy RSA secret", secret: []byte{0x12, 0x34}, want: config.SecretProviderRSA},
+		{name: "keyring secret without KeyringFilePath", secret: []byte("odim:Keyring:1:data"), wantErr: true},
+		{name: "vault secret without VaultConf", secret: []byte("odim:Vault:1:secret/data/odimra/id"), wantErr: true},
+		{name: "invalid key version", secret: []byte("odim:Keyring:one:data"), wantErr: true},
+		{name: "unknown provider", secret: []byte("odim:Unknown:1:data"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := getSecretProviderOf(tt.secret)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getSecretProviderOf() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && provider.Name() != tt.want {
+				t.Errorf("getSecretProviderOf() = %v, want %v", provider.Name(), tt.want)
+			}
+		})
+	}
+}
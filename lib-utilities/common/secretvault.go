@@ -0,0 +1,155 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/google/uuid"
+)
+
+// vaultHTTPClient returns the client for contacting the Vault server,
+// which is validated with the root CA certificate of KeyCertConf
+var vaultHTTPClient = func() (*http.Client, error) {
+	httpConf := &config.HTTPConfig{
+		CACertificate: &config.Data.KeyCertConf.RootCACertificate,
+	}
+	return httpConf.GetHTTPClientObj()
+}
+
+// vaultSecret is the data of a KV version 2 secret holding a credential
+type vaultSecret struct {
+	Password []byte `json:"password"`
+}
+
+// vaultSecretProvider stores the secrets in a Vault compatible KV version 2 secrets engine.
+// The secret stored in DB is a reference holding the path and the version of the KV secret
+type vaultSecretProvider struct {
+	conf config.VaultConf
+}
+
+func (p vaultSecretProvider) Name() string {
+	return config.SecretProviderVault
+}
+
+func (p vaultSecretProvider) Encrypt(plainText []byte) ([]byte, error) {
+	path := p.secretPathPrefix() + uuid.New().String()
+	reqBody, err := json.Marshal(map[string]interface{}{
+		"data": vaultSecret{Password: plainText},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error while marshaling the vault secret: %v", err)
+	}
+	respBody, err := p.do(http.MethodPost, path, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Data struct {
+			Version int `json:"version"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, fmt.Errorf("error while unmarshaling the vault response: %v", err)
+	}
+	return append(secretHeader(p.Name(), resp.Data.Version), path...), nil
+}
+
+func (p vaultSecretProvider) Decrypt(secret []byte) ([]byte, error) {
+	_, version, path, err := parseSecretHeader(secret)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := p.do(http.MethodGet, string(path)+"?version="+strconv.Itoa(version), nil)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Data struct {
+			Data vaultSecret `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, fmt.Errorf("error while unmarshaling the vault response: %v", err)
+	}
+	return resp.Data.Data.Password, nil
+}
+
+// IsCurrent tells whether the secret is stored under the configured mount and secret path,
+// the keys protecting the secrets engine are rotated by Vault itself
+func (p vaultSecretProvider) IsCurrent(secret []byte) (bool, error) {
+	_, _, path, err := parseSecretHeader(secret)
+	if err != nil {
+		return false, err
+	}
+	return strings.HasPrefix(string(path), p.secretPathPrefix()), nil
+}
+
+// Delete removes all the versions and the metadata of the KV secret, each secret has its own path
+func (p vaultSecretProvider) Delete(secret []byte) error {
+	_, _, path, err := parseSecretHeader(secret)
+	if err != nil {
+		return err
+	}
+	// the secret is read from <mount>/data/<path> and its versions are kept under <mount>/metadata/<path>
+	_, err = p.do(http.MethodDelete, strings.Replace(string(path), "/data/", "/metadata/", 1), nil)
+	return err
+}
+
+// secretPathPrefix returns the API path under which the secrets are stored, like secret/data/odimra/
+func (p vaultSecretProvider) secretPathPrefix() string {
+	return strings.Trim(p.conf.MountPath, "/") + "/data/" + strings.Trim(p.conf.SecretPath, "/") + "/"
+}
+
+// do sends the request to the Vault server with the token read from TokenFilePath,
+// the file is read for every request so that a renewed token is used right away
+func (p vaultSecretProvider) do(method, path string, body []byte) ([]byte, error) {
+	token, err := ioutil.ReadFile(p.conf.TokenFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading the vault token file %s: %v", p.conf.TokenFilePath, err)
+	}
+	req, err := http.NewRequest(method, strings.TrimRight(p.conf.Address, "/")+"/v1/"+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error while creating the vault request: %v", err)
+	}
+	req.Header.Set("X-Vault-Token", strings.TrimSpace(string(token)))
+	req.Header.Set("Content-Type", "application/json")
+	httpClient, err := vaultHTTPClient()
+	if err != nil {
+		return nil, fmt.Errorf("error while creating the vault client: %v", err)
+	}
+	config.TLSConfMutex.RLock()
+	resp, err := httpClient.Do(req)
+	config.TLSConfMutex.RUnlock()
+	if err != nil {
+		return nil, fmt.Errorf("error while trying to make the request to vault: %v", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error while trying to read the vault response: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return nil, fmt.Errorf("error: vault responded with %v: %s", resp.StatusCode, string(respBody))
+	}
+	return respBody, nil
+}
@@ -0,0 +1,146 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+)
+
+// TaskCancellationServices are the services which run the long running operations of the
+// tasks, the CancelTask control message is delivered to each of them
+var TaskCancellationServices = []string{UpdateService, AggregationService}
+
+// trackedTask holds the cancel function of the task running in the service
+// and the operations of the task completed so far
+type trackedTask struct {
+	cancel    context.CancelFunc
+	completed []string
+}
+
+var runningTasks = struct {
+	lock  sync.Mutex
+	tasks map[string]*trackedTask
+}{tasks: make(map[string]*trackedTask)}
+
+// TaskControlMessageQueue returns the name of the control message queue of the service
+func TaskControlMessageQueue(service string) string {
+	return config.Data.MessageBusConf.OdimControlMessageQueue + "-" + service
+}
+
+// TrackTask registers the task as running in the service. The returned context is done when
+// the task is cancelled, the tasks tracked with a context derived from it are cancelled with it.
+// The returned function must be called once the task has finished.
+func TrackTask(ctx context.Context, taskID string) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	runningTasks.lock.Lock()
+	runningTasks.tasks[taskID] = &trackedTask{cancel: cancel}
+	runningTasks.lock.Unlock()
+	return ctx, func() {
+		runningTasks.lock.Lock()
+		delete(runningTasks.tasks, taskID)
+		runningTasks.lock.Unlock()
+		cancel()
+	}
+}
+
+// CancelTrackedTask cancels the context of the task if it's running in the service
+func CancelTrackedTask(taskID string) bool {
+	runningTasks.lock.Lock()
+	defer runningTasks.lock.Unlock()
+	task, ok := runningTasks.tasks[taskID]
+	if ok {
+		task.cancel()
+	}
+	return ok
+}
+
+// AddCompletedOperation records the operation of the task which has completed
+func AddCompletedOperation(taskID, operation string) {
+	runningTasks.lock.Lock()
+	defer runningTasks.lock.Unlock()
+	if task, ok := runningTasks.tasks[taskID]; ok {
+		task.completed = append(task.completed, operation)
+	}
+}
+
+// CompletedOperations returns the operations of the task which have completed
+func CompletedOperations(taskID string) []string {
+	runningTasks.lock.Lock()
+	defer runningTasks.lock.Unlock()
+	if task, ok := runningTasks.tasks[taskID]; ok {
+		return append([]string{}, task.completed...)
+	}
+	return nil
+}
+
+// ProcessCancelTaskMsg cancels the tasks of the CancelTask control message which are running
+// in the service, the messages for the tasks of the other services are ignored
+func ProcessCancelTaskMsg(data interface{}) bool {
+	event, ok := data.(ControlMessageData)
+	if !ok || event.MessageType != CancelTask {
+		return false
+	}
+	msg, _ := json.Marshal(event.Data)
+	var cancelTask CancelTaskData
+	if err := json.Unmarshal(msg, &cancelTask); err != nil {
+		// the data is marshalled to bytes by the publisher and is received as a base64 string
+		var raw []byte
+		if json.Unmarshal(msg, &raw) != nil || json.Unmarshal(raw, &cancelTask) != nil {
+			return false
+		}
+	}
+	for _, subTaskID := range cancelTask.SubTaskIDs {
+		CancelTrackedTask(subTaskID)
+	}
+	CancelTrackedTask(cancelTask.TaskID)
+	return true
+}
+
+// CancelledTaskResponse returns the response recorded for the cancelled task, the message
+// lists the operations of the task which had completed before the cancellation
+func CancelledTaskResponse(taskID string, completed []string) response.RPC {
+	message := fmt.Sprintf("Work on the task with Id %v has been halted prior to completion due to an explicit request.", taskID)
+	if len(completed) == 0 {
+		message += " No operations had completed."
+	} else {
+		message += " Operations completed before the cancellation: " + strings.Join(completed, ", ") + "."
+	}
+	return response.RPC{
+		StatusCode:    http.StatusOK,
+		StatusMessage: Cancelled,
+		Body: response.CommonError{
+			Error: response.ErrorClass{
+				Code:    TaskEventType + ".TaskCancelled",
+				Message: message,
+				MessageExtendedInfo: []response.Msg{{
+					OdataType:   response.ErrorMessageOdataType,
+					MessageID:   TaskEventType + ".TaskCancelled",
+					Message:     message,
+					Severity:    Warning,
+					MessageArgs: []interface{}{taskID},
+					Resolution:  "None",
+				}},
+			},
+		},
+	}
+}
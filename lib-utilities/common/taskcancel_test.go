@@ -0,0 +1,106 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+)
+
+func TestTrackTask(t *testing.T) {
+	ctx, done := TrackTask(context.Background(), "task1")
+	defer done()
+	subCtx, subDone := TrackTask(ctx, "task2")
+	defer subDone()
+
+	AddCompletedOperation("task1", "op1")
+	AddCompletedOperation("task1", "op2")
+	if got := CompletedOperations("task1"); !reflect.DeepEqual(got, []string{"op1", "op2"}) {
+		t.Errorf("CompletedOperations() = %v", got)
+	}
+	if CancelTrackedTask("unknown") {
+		t.Error("CancelTrackedTask() cancelled a task not running in the service")
+	}
+	if !CancelTrackedTask("task1") {
+		t.Error("CancelTrackedTask() didn't find the running task")
+	}
+	if ctx.Err() == nil || subCtx.Err() == nil {
+		t.Error("cancellation of the task wasn't propagated to the sub task")
+	}
+	done()
+	if CompletedOperations("task1") != nil {
+		t.Error("finished task is still tracked")
+	}
+}
+
+func TestProcessCancelTaskMsg(t *testing.T) {
+	data, _ := json.Marshal(CancelTaskData{TaskID: "task3", SubTaskIDs: []string{"task4"}})
+	tests := []struct {
+		name string
+		data interface{}
+		want bool
+	}{
+		{
+			name: "CancelTask message",
+			data: ControlMessageData{MessageType: CancelTask, Data: CancelTaskData{TaskID: "task3", SubTaskIDs: []string{"task4"}}},
+			want: true,
+		},
+		{
+			name: "CancelTask message with marshalled data",
+			data: ControlMessageData{MessageType: CancelTask, Data: data},
+			want: true,
+		},
+		{
+			name: "other control message",
+			data: ControlMessageData{MessageType: SubscribeEMB},
+			want: false,
+		},
+		{
+			name: "invalid data",
+			data: "invalid",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, done := TrackTask(context.Background(), "task4")
+			defer done()
+			if got := ProcessCancelTaskMsg(tt.data); got != tt.want {
+				t.Errorf("ProcessCancelTaskMsg() = %v, want %v", got, tt.want)
+			}
+			if (ctx.Err() != nil) != tt.want {
+				t.Errorf("sub task cancelled = %v, want %v", ctx.Err() != nil, tt.want)
+			}
+		})
+	}
+}
+
+func TestCancelledTaskResponse(t *testing.T) {
+	resp := CancelledTaskResponse("task5", []string{"/redfish/v1/Systems/uuid.1"})
+	body := resp.Body.(response.CommonError)
+	if !strings.HasSuffix(body.Error.Message, "Operations completed before the cancellation: /redfish/v1/Systems/uuid.1.") {
+		t.Errorf("CancelledTaskResponse() message = %v", body.Error.Message)
+	}
+	resp = CancelledTaskResponse("task5", nil)
+	body = resp.Body.(response.CommonError)
+	if !strings.HasSuffix(body.Error.Message, "No operations had completed.") {
+		t.Errorf("CancelledTaskResponse() message = %v", body.Error.Message)
+	}
+}
@@ -46,6 +46,7 @@ var Data configModel
 type configModel struct {
 	SouthBoundRequestTimeoutInSecs int                      `json:"SouthBoundRequestTimeoutInSecs"` // holds the value of south bound call request time out
 	ServerRediscoveryBatchSize     int                      `json:"ServerRediscoveryBatchSize"`
+	InventoryHistoryLimit          int                      `json:"InventoryHistoryLimit"` // holds the number of inventory changes retained for a system
 	FirmwareVersion                string                   `json:"FirmwareVersion"`
 	RootServiceUUID                string                   `json:"RootServiceUUID"` //static uuid used for root service
 	SearchAndFilterSchemaPath      string                   `json:"SearchAndFilterSchemaPath"`
@@ -55,6 +56,7 @@ type configModel struct {
 	MessageBusConf                 *MessageBusConf          `json:"MessageBusConf"`
 	DBConf                         *DBConf                  `json:"DBConf"`
 	KeyCertConf                    *KeyCertConf             `json:"KeyCertConf"`
+	SecretConf                     *SecretConf              `json:"SecretConf"`
 	AuthConf                       *AuthConf                `json:"AuthConf"`
 	APIGatewayConf                 *APIGatewayConf          `json:"APIGatewayConf"`
 	AddComputeSkipResources        *AddComputeSkipResources `json:"AddComputeSkipResources"`
@@ -66,6 +68,8 @@ type configModel struct {
 	SupportedPluginTypes           []string                 `json:"SupportedPluginTypes"`
 	ConnectionMethodConf           []ConnectionMethodConf   `json:"ConnectionMethodConf"`
 	EventConf                      *EventConf               `json:"EventConf"`
+	CredentialRotationConf         *CredentialRotationConf  `json:"CredentialRotationConf"`
+	InteropProfileConf             *InteropProfileConf      `json:"InteropProfileConf"`
 	ResourceRateLimit              []string                 `json:"ResourceRateLimit"`
 	RequestLimitCountPerSession    int                      `json:"RequestLimitCountPerSession"`
 	SessionLimitCountPerUser       int                      `json:"SessionLimitCountPerUser"`
@@ -101,6 +105,8 @@ type MessageBusConf struct {
 	MessageBusConfigFilePath string `json:"MessageBusConfigFilePath"`
 	MessageBusType           string `json:"MessageBusType"`
 	OdimControlMessageQueue  string `json:"OdimControlMessageQueue"`
+	OdimSSEEventQueue        string `json:"OdimSSEEventQueue"`
+	OdimHealthEventQueue     string `json:"OdimHealthEventQueue"`
 }
 
 // KeyCertConf is for holding all security oriented configuration
@@ -117,18 +123,87 @@ type KeyCertConf struct {
 	RSAPrivateKey         []byte
 }
 
+// SecretConf holds the configuration of the secret provider encrypting the device and plugin credentials stored in DB
+type SecretConf struct {
+	Provider        string     `json:"Provider"`        // holds the secret provider, one of RSA, Keyring and Vault
+	KeyringFilePath string     `json:"KeyringFilePath"` // holds the location of the keyring file used by the Keyring provider
+	VaultConf       *VaultConf `json:"VaultConf"`       // holds the configuration of the Vault provider
+}
+
+// VaultConf holds the configuration for storing the credentials in a Vault compatible KV version 2 secrets engine
+type VaultConf struct {
+	Address       string `json:"Address"`       // holds the address of the Vault server, like https://vault.odim.com:8200
+	TokenFilePath string `json:"TokenFilePath"` // holds the location of the file with the Vault token
+	MountPath     string `json:"MountPath"`     // holds the mount path of the KV secrets engine
+	SecretPath    string `json:"SecretPath"`    // holds the path under which the credentials are stored
+}
+
 // AuthConf holds all authentication related configurations
 type AuthConf struct {
-	SessionTimeOutInMins            float64        `json:"SessionTimeOutInMins"`
-	ExpiredSessionCleanUpTimeInMins float64        `json:"ExpiredSessionCleanUpTimeInMins"`
-	PasswordRules                   *PasswordRules `json:"PasswordRules"`
+	SessionTimeOutInMins            float64                  `json:"SessionTimeOutInMins"`
+	ExpiredSessionCleanUpTimeInMins float64                  `json:"ExpiredSessionCleanUpTimeInMins"`
+	PasswordRules                   *PasswordRules           `json:"PasswordRules"`
+	AccountLockoutThreshold         int                      `json:"AccountLockoutThreshold"`
+	AccountLockoutDuration          int                      `json:"AccountLockoutDuration"`
+	AccountLockoutCounterResetAfter int                      `json:"AccountLockoutCounterResetAfter"`
+	LDAP                            *ExternalAccountProvider `json:"LDAP"`
+	ActiveDirectory                 *ExternalAccountProvider `json:"ActiveDirectory"`
+	OAuth2                          *OAuth2                  `json:"OAuth2"`
+	ClientCertificate               *ClientCertificate       `json:"ClientCertificate"`
+}
+
+// ExternalAccountProvider holds the configuration of a directory service
+// against which the users not present in ODIM are authenticated
+type ExternalAccountProvider struct {
+	ServiceEnabled         bool                `json:"ServiceEnabled"`         // holds whether the users are authenticated against the directory service
+	ServiceAddresses       []string            `json:"ServiceAddresses"`       // holds the ldap:// or ldaps:// URLs of the directory servers, tried in the given order
+	Username               string              `json:"Username"`               // holds the distinguished name used for searching the users, the search is anonymous if empty
+	PasswordFilePath       string              `json:"PasswordFilePath"`       // holds the path of the RSA-OAEP encrypted password of Username
+	Password               []byte              `json:"-"`                      // holds the decrypted password of Username
+	BaseDistinguishedNames []string            `json:"BaseDistinguishedNames"` // holds the distinguished names under which the users are searched
+	UsernameAttribute      string              `json:"UsernameAttribute"`      // holds the attribute which holds the user name
+	GroupsAttribute        string              `json:"GroupsAttribute"`        // holds the attribute which lists the groups of the user
+	TimeoutInSeconds       int                 `json:"TimeoutInSeconds"`       // holds the timeout of each request to the directory service
+	RemoteRoleMapping      []RemoteRoleMapping `json:"RemoteRoleMapping"`      // holds the mapping of the directory groups to the ODIM roles
+}
+
+// OAuth2 holds the configuration of the OAuth 2.0 authorization server
+// whose bearer tokens are accepted by the northbound API
+type OAuth2 struct {
+	ServiceEnabled          bool                `json:"ServiceEnabled"`          // holds whether the bearer tokens are accepted
+	Mode                    string              `json:"Mode"`                    // holds Discovery to fetch the signing keys from the issuer, or Offline to use OAuthServiceSigningKeys
+	Issuer                  string              `json:"Issuer"`                  // holds the issuer of the tokens, matched against the iss claim
+	Audience                []string            `json:"Audience"`                // holds the accepted values of the aud claim
+	OAuthServiceSigningKeys string              `json:"OAuthServiceSigningKeys"` // holds the Base64 encoded JSON Web Key Set used in the Offline mode
+	UsernameClaim           string              `json:"UsernameClaim"`           // holds the claim which identifies the user
+	RolesClaim              string              `json:"RolesClaim"`              // holds the claim which lists the groups or roles of the user
+	RemoteRoleMapping       []RemoteRoleMapping `json:"RemoteRoleMapping"`       // holds the mapping of the RolesClaim values to the ODIM roles
+}
+
+// ClientCertificate holds the configuration of the authentication of the northbound users
+// by the TLS client certificates, which are mapped to the ODIM accounts
+type ClientCertificate struct {
+	Enabled                         bool   `json:"Enabled"`                         // holds whether the client certificates are accepted
+	CACertificatePath               string `json:"CACertificatePath"`               // holds the path of the CA bundle against which the client certificates are validated
+	CertificateMappingAttribute     string `json:"CertificateMappingAttribute"`     // holds CommonName or UserPrincipalName, the attribute of the certificate which is the account name
+	RespondToUnauthenticatedClients *bool  `json:"RespondToUnauthenticatedClients"` // holds whether the clients without a certificate can use the other authentication methods
+}
+
+// RemoteRoleMapping maps a group of the external account provider to an ODIM role
+type RemoteRoleMapping struct {
+	RemoteGroup string `json:"RemoteGroup"` // holds the name or the distinguished name of the group
+	LocalRole   string `json:"LocalRole"`   // holds the ID of the ODIM role
 }
 
 // PasswordRules defines rules for password complexity
 type PasswordRules struct {
-	MinPasswordLength       int    `json:"MinPasswordLength"`       // holds the value  of min password length
-	MaxPasswordLength       int    `json:"MaxPasswordLength"`       // holds the value of max password length
-	AllowedSpecialCharcters string `json:"AllowedSpecialCharcters"` // holds all value of  all sppecial charcters
+	MinPasswordLength               int      `json:"MinPasswordLength"`               // holds the value  of min password length
+	MaxPasswordLength               int      `json:"MaxPasswordLength"`               // holds the value of max password length
+	AllowedSpecialCharcters         string   `json:"AllowedSpecialCharcters"`         // holds all value of  all sppecial charcters
+	RequiredCharacterClasses        []string `json:"RequiredCharacterClasses"`        // holds the classes of characters every password must have
+	PasswordHistoryCount            int      `json:"PasswordHistoryCount"`            // holds the number of last passwords which can not be reused
+	MaxPasswordAgeInDays            int      `json:"MaxPasswordAgeInDays"`            // holds the number of days after which the password expires
+	ForcePasswordChangeOnFirstLogin bool     `json:"ForcePasswordChangeOnFirstLogin"` // holds whether the password of a new account must be changed on the first login
 }
 
 // APIGatewayConf holds API gateway related configurations
@@ -188,14 +263,31 @@ type TaskQueueConf struct {
 
 // ConnectionMethodConf is for connection method type and variant
 type ConnectionMethodConf struct {
-	ConnectionMethodType    string `json:"ConnectionMethodType"`
-	ConnectionMethodVariant string `json:"ConnectionMethodVariant"`
+	ConnectionMethodType    string   `json:"ConnectionMethodType"`
+	ConnectionMethodVariant string   `json:"ConnectionMethodVariant"`
+	Vendors                 []string `json:"Vendors,omitempty"` // vendors of the BMCs matched to the connection method by the network discovery, "*" matches any vendor
 }
 
 // EventConf stores all inforamtion related to event delivery configurations
 type EventConf struct {
 	DeliveryRetryAttempts        int `json:"DeliveryRetryAttempts"`        // holds value of retrying event posting to destination
 	DeliveryRetryIntervalSeconds int `json:"DeliveryRetryIntervalSeconds"` // holds value of retrying events posting in interval
+	SSEEventBufferSize           int `json:"SSEEventBufferSize"`           // holds number of recent events retained for resuming the SSE streams
+	SSEKeepAliveIntervalSeconds  int `json:"SSEKeepAliveIntervalSeconds"`  // holds interval of sending keep-alive comments on idle SSE streams
+	UndeliveredEventsLimit       int `json:"UndeliveredEventsLimit"`       // holds maximum number of undelivered events retained for a subscription
+}
+
+// CredentialRotationConf holds the configuration for rotating the passwords of the BMCs added as aggregation sources
+type CredentialRotationConf struct {
+	RotationIntervalInDays   int    `json:"RotationIntervalInDays"`   // holds the number of days after which the BMC passwords are rotated, 0 disables the scheduled rotation
+	PasswordLength           int    `json:"PasswordLength"`           // holds the length of the generated BMC passwords
+	AllowedSpecialCharacters string `json:"AllowedSpecialCharacters"` // holds the special characters used in the generated BMC passwords
+}
+
+// InteropProfileConf holds the configuration for validating the managed servers against the Redfish Interop profiles
+type InteropProfileConf struct {
+	ProfilesPath   string `json:"ProfilesPath"`   // holds the path of the directory with the Redfish Interop profiles
+	DefaultProfile string `json:"DefaultProfile"` // holds the profile the servers are validated against once added, empty disables the validation on add
 }
 
 // SetConfiguration will extract the config data from file
@@ -234,6 +326,9 @@ func ValidateConfiguration() (WarningList, error) {
 	if err = checkKeyCertConf(); err != nil {
 		return *warningList, err
 	}
+	if err = checkSecretConf(warningList); err != nil {
+		return *warningList, err
+	}
 	if err = checkAPIGatewayConf(); err != nil {
 		return *warningList, err
 	}
@@ -257,6 +352,8 @@ func ValidateConfiguration() (WarningList, error) {
 	checkURLTranslation(warningList)
 	checkPluginStatusPolling(warningList)
 	checkExecPriorityDelayConf(warningList)
+	checkCredentialRotationConf(warningList)
+	checkInteropProfileConf(warningList)
 
 	return *warningList, nil
 }
@@ -275,6 +372,10 @@ func checkMiscellaneousConf(wl *WarningList) error {
 	if Data.LocalhostFQDN == "" {
 		return fmt.Errorf("error: no value set for localhostFQDN")
 	}
+	if Data.InventoryHistoryLimit <= 0 {
+		wl.add("No value set for InventoryHistoryLimit, setting default value")
+		Data.InventoryHistoryLimit = DefaultInventoryHistoryLimit
+	}
 	if _, err := os.Stat(Data.SearchAndFilterSchemaPath); err != nil {
 		return fmt.Errorf("error: value check failed for SearchAndFilterSchemaPath:%s with %v", Data.SearchAndFilterSchemaPath, err)
 	}
@@ -391,6 +492,14 @@ func checkMessageBusConf(wl *WarningList) error {
 			Data.MessageBusConf.OdimControlMessageQueue = "ODIM-CONTROL-MESSAGES"
 		}
 	}
+	if len(Data.MessageBusConf.OdimSSEEventQueue) <= 0 {
+		wl.add("No value set for OdimSSEEventQueue, setting default value")
+		Data.MessageBusConf.OdimSSEEventQueue = DefaultSSEEventQueue
+	}
+	if len(Data.MessageBusConf.OdimHealthEventQueue) <= 0 {
+		wl.add("No value set for OdimHealthEventQueue, setting default value")
+		Data.MessageBusConf.OdimHealthEventQueue = DefaultHealthEventQueue
+	}
 	if !AllowedMessageBusTypes[Data.MessageBusConf.MessageBusType] {
 		return fmt.Errorf("error: invalid value configured for MessageBusType")
 	}
@@ -436,16 +545,64 @@ func checkKeyCertConf() error {
 	return nil
 }
 
+func checkSecretConf(wl *WarningList) error {
+	if Data.SecretConf == nil {
+		wl.add("SecretConf not provided, setting default value")
+		Data.SecretConf = &SecretConf{Provider: SecretProviderRSA}
+		return nil
+	}
+	switch Data.SecretConf.Provider {
+	case "":
+		wl.add("No value set for Provider in SecretConf, setting default value")
+		Data.SecretConf.Provider = SecretProviderRSA
+	case SecretProviderRSA:
+	case SecretProviderKeyring:
+		if Data.SecretConf.KeyringFilePath == "" {
+			return fmt.Errorf("error: no value set for KeyringFilePath, required by the %s secret provider", SecretProviderKeyring)
+		}
+	case SecretProviderVault:
+		return checkVaultConf(wl)
+	default:
+		return fmt.Errorf("error: invalid value set for Provider in SecretConf: %s", Data.SecretConf.Provider)
+	}
+	return nil
+}
+
+func checkVaultConf(wl *WarningList) error {
+	if Data.SecretConf.VaultConf == nil {
+		return fmt.Errorf("error: VaultConf is not provided, required by the %s secret provider", SecretProviderVault)
+	}
+	if Data.SecretConf.VaultConf.Address == "" {
+		return fmt.Errorf("error: no value set for Address in VaultConf")
+	}
+	if Data.SecretConf.VaultConf.TokenFilePath == "" {
+		return fmt.Errorf("error: no value set for TokenFilePath in VaultConf")
+	}
+	if Data.SecretConf.VaultConf.MountPath == "" {
+		wl.add("No value set for MountPath in VaultConf, setting default value")
+		Data.SecretConf.VaultConf.MountPath = DefaultVaultMountPath
+	}
+	if Data.SecretConf.VaultConf.SecretPath == "" {
+		wl.add("No value set for SecretPath in VaultConf, setting default value")
+		Data.SecretConf.VaultConf.SecretPath = DefaultVaultSecretPath
+	}
+	return nil
+}
+
 func checkAuthConf(wl *WarningList) {
 	if Data.AuthConf == nil {
 		wl.add("No value found for AuthConf, setting default value")
 		Data.AuthConf = &AuthConf{
 			SessionTimeOutInMins:            DefaultSessionTimeOutInMins,
 			ExpiredSessionCleanUpTimeInMins: DefaultExpiredSessionCleanUpTimeInMins,
+			AccountLockoutThreshold:         DefaultAccountLockoutThreshold,
+			AccountLockoutDuration:          DefaultAccountLockoutDuration,
+			AccountLockoutCounterResetAfter: DefaultAccountLockoutCounterResetAfter,
 			PasswordRules: &PasswordRules{
-				MinPasswordLength:       DefaultMinPasswordLength,
-				MaxPasswordLength:       DefaultMaxPasswordLength,
-				AllowedSpecialCharcters: DefaultAllowedSpecialCharcters,
+				MinPasswordLength:        DefaultMinPasswordLength,
+				MaxPasswordLength:        DefaultMaxPasswordLength,
+				AllowedSpecialCharcters:  DefaultAllowedSpecialCharcters,
+				RequiredCharacterClasses: DefaultRequiredCharacterClasses,
 			},
 		}
 		return
@@ -458,16 +615,136 @@ func checkAuthConf(wl *WarningList) {
 		wl.add("No value set for ExpiredSessionCleanUpTimeInMins, setting default value")
 		Data.AuthConf.ExpiredSessionCleanUpTimeInMins = DefaultExpiredSessionCleanUpTimeInMins
 	}
+	checkAccountLockoutConf(wl)
 	checkPasswordRulesConf(wl)
+	checkExternalAccountProviderConf(wl, "LDAP", Data.AuthConf.LDAP, DefaultLDAPUsernameAttribute)
+	checkExternalAccountProviderConf(wl, "ActiveDirectory", Data.AuthConf.ActiveDirectory, DefaultActiveDirectoryUsernameAttribute)
+	checkOAuth2Conf(wl)
+	checkClientCertificateConf(wl)
+}
+
+func checkAccountLockoutConf(wl *WarningList) {
+	// zero disables the account lockout, as in the Redfish AccountService
+	if Data.AuthConf.AccountLockoutThreshold < 0 {
+		wl.add("Invalid value set for AccountLockoutThreshold, setting default value")
+		Data.AuthConf.AccountLockoutThreshold = DefaultAccountLockoutThreshold
+	}
+	if Data.AuthConf.AccountLockoutDuration <= 0 {
+		wl.add("No value set for AccountLockoutDuration, setting default value")
+		Data.AuthConf.AccountLockoutDuration = DefaultAccountLockoutDuration
+	}
+	if Data.AuthConf.AccountLockoutCounterResetAfter <= 0 {
+		wl.add("No value set for AccountLockoutCounterResetAfter, setting default value")
+		Data.AuthConf.AccountLockoutCounterResetAfter = DefaultAccountLockoutCounterResetAfter
+	}
+	// the failed attempts counter must not be reset while the account is locked
+	if Data.AuthConf.AccountLockoutDuration < Data.AuthConf.AccountLockoutCounterResetAfter {
+		wl.add("AccountLockoutDuration is less than AccountLockoutCounterResetAfter, setting AccountLockoutDuration to AccountLockoutCounterResetAfter")
+		Data.AuthConf.AccountLockoutDuration = Data.AuthConf.AccountLockoutCounterResetAfter
+	}
+}
+
+func checkOAuth2Conf(wl *WarningList) {
+	oauth2 := Data.AuthConf.OAuth2
+	if oauth2 == nil || !oauth2.ServiceEnabled {
+		return
+	}
+	if oauth2.Issuer == "" || len(oauth2.Audience) == 0 {
+		wl.add("No value set for Issuer or Audience of OAuth2, disabling the bearer token authentication")
+		oauth2.ServiceEnabled = false
+		return
+	}
+	switch oauth2.Mode {
+	case OAuth2ModeDiscovery:
+	case OAuth2ModeOffline:
+		if oauth2.OAuthServiceSigningKeys == "" {
+			wl.add("No value set for OAuthServiceSigningKeys of OAuth2 in Offline mode, disabling the bearer token authentication")
+			oauth2.ServiceEnabled = false
+			return
+		}
+	default:
+		wl.add("Invalid value set for Mode of OAuth2, setting default value")
+		oauth2.Mode = OAuth2ModeDiscovery
+	}
+	if oauth2.UsernameClaim == "" {
+		wl.add("No value set for UsernameClaim of OAuth2, setting default value")
+		oauth2.UsernameClaim = DefaultOAuth2UsernameClaim
+	}
+	if oauth2.RolesClaim == "" {
+		wl.add("No value set for RolesClaim of OAuth2, setting default value")
+		oauth2.RolesClaim = DefaultOAuth2RolesClaim
+	}
+	if len(oauth2.RemoteRoleMapping) == 0 {
+		wl.add("No value set for RemoteRoleMapping of OAuth2, the bearer tokens will not be accepted")
+	}
+}
+
+func checkClientCertificateConf(wl *WarningList) {
+	clientCertificate := Data.AuthConf.ClientCertificate
+	if clientCertificate == nil || !clientCertificate.Enabled {
+		return
+	}
+	if clientCertificate.CACertificatePath == "" {
+		wl.add("No value set for CACertificatePath of ClientCertificate, disabling the client certificate authentication")
+		clientCertificate.Enabled = false
+		return
+	}
+	switch clientCertificate.CertificateMappingAttribute {
+	case CertificateMappingCommonName, CertificateMappingUserPrincipalName:
+	default:
+		wl.add("Invalid value set for CertificateMappingAttribute of ClientCertificate, setting default value")
+		clientCertificate.CertificateMappingAttribute = CertificateMappingCommonName
+	}
+	if clientCertificate.RespondToUnauthenticatedClients == nil {
+		wl.add("No value set for RespondToUnauthenticatedClients of ClientCertificate, setting default value")
+		respond := DefaultRespondToUnauthenticatedClients
+		clientCertificate.RespondToUnauthenticatedClients = &respond
+	}
+}
+
+func checkExternalAccountProviderConf(wl *WarningList, name string, provider *ExternalAccountProvider, defaultUsernameAttribute string) {
+	if provider == nil || !provider.ServiceEnabled {
+		return
+	}
+	if len(provider.ServiceAddresses) == 0 || len(provider.BaseDistinguishedNames) == 0 {
+		wl.add("No value set for ServiceAddresses or BaseDistinguishedNames of " + name + ", disabling the account provider")
+		provider.ServiceEnabled = false
+		return
+	}
+	if provider.PasswordFilePath != "" {
+		password, err := decryptRSAOAEPEncryptedPasswords(provider.PasswordFilePath)
+		if err != nil {
+			wl.add("Unable to read the password of " + name + ", disabling the account provider: " + err.Error())
+			provider.ServiceEnabled = false
+			return
+		}
+		provider.Password = password
+	}
+	if provider.UsernameAttribute == "" {
+		wl.add("No value set for UsernameAttribute of " + name + ", setting default value")
+		provider.UsernameAttribute = defaultUsernameAttribute
+	}
+	if provider.GroupsAttribute == "" {
+		wl.add("No value set for GroupsAttribute of " + name + ", setting default value")
+		provider.GroupsAttribute = DefaultGroupsAttribute
+	}
+	if provider.TimeoutInSeconds <= 0 {
+		wl.add("No value set for TimeoutInSeconds of " + name + ", setting default value")
+		provider.TimeoutInSeconds = DefaultExternalAccountProviderTimeout
+	}
+	if len(provider.RemoteRoleMapping) == 0 {
+		wl.add("No value set for RemoteRoleMapping of " + name + ", the directory users will not be able to log in")
+	}
 }
 
 func checkPasswordRulesConf(wl *WarningList) {
 	if Data.AuthConf.PasswordRules == nil {
 		wl.add("PasswordRules configuration is found empty, setting default value")
 		Data.AuthConf.PasswordRules = &PasswordRules{
-			MinPasswordLength:       DefaultMinPasswordLength,
-			MaxPasswordLength:       DefaultMaxPasswordLength,
-			AllowedSpecialCharcters: DefaultAllowedSpecialCharcters,
+			MinPasswordLength:        DefaultMinPasswordLength,
+			MaxPasswordLength:        DefaultMaxPasswordLength,
+			AllowedSpecialCharcters:  DefaultAllowedSpecialCharcters,
+			RequiredCharacterClasses: DefaultRequiredCharacterClasses,
 		}
 		return
 	}
@@ -483,6 +760,33 @@ func checkPasswordRulesConf(wl *WarningList) {
 		wl.add("No value set for AllowedSpecialCharcters, setting default value")
 		Data.AuthConf.PasswordRules.AllowedSpecialCharcters = DefaultAllowedSpecialCharcters
 	}
+	// an empty list is a valid value, which does not require any class of characters
+	if Data.AuthConf.PasswordRules.RequiredCharacterClasses == nil {
+		wl.add("No value set for RequiredCharacterClasses, setting default value")
+		Data.AuthConf.PasswordRules.RequiredCharacterClasses = DefaultRequiredCharacterClasses
+	}
+	var classes []string
+	for _, class := range Data.AuthConf.PasswordRules.RequiredCharacterClasses {
+		switch class {
+		case PasswordCharacterClassUpperCase, PasswordCharacterClassLowerCase,
+			PasswordCharacterClassDigit, PasswordCharacterClassSpecialCharacter:
+			classes = append(classes, class)
+		default:
+			wl.add("Invalid value " + class + " set for RequiredCharacterClasses, ignoring it")
+		}
+	}
+	if classes == nil {
+		classes = []string{}
+	}
+	Data.AuthConf.PasswordRules.RequiredCharacterClasses = classes
+	if Data.AuthConf.PasswordRules.PasswordHistoryCount < 0 {
+		wl.add("Invalid value set for PasswordHistoryCount, the password history will not be checked")
+		Data.AuthConf.PasswordRules.PasswordHistoryCount = 0
+	}
+	if Data.AuthConf.PasswordRules.MaxPasswordAgeInDays < 0 {
+		wl.add("Invalid value set for MaxPasswordAgeInDays, the passwords will not expire")
+		Data.AuthConf.PasswordRules.MaxPasswordAgeInDays = 0
+	}
 }
 
 func checkAPIGatewayConf() error {
@@ -662,6 +966,9 @@ func checkEventConf(wl *WarningList) error {
 		Data.EventConf = &EventConf{
 			DeliveryRetryAttempts:        DefaultDeliveryRetryAttempts,
 			DeliveryRetryIntervalSeconds: DefaultDeliveryRetryIntervalSeconds,
+			SSEEventBufferSize:           DefaultSSEEventBufferSize,
+			SSEKeepAliveIntervalSeconds:  DefaultSSEKeepAliveIntervalSeconds,
+			UndeliveredEventsLimit:       DefaultUndeliveredEventsLimit,
 		}
 		return nil
 	}
@@ -673,9 +980,56 @@ func checkEventConf(wl *WarningList) error {
 		wl.add("No value found for DeliveryRetryIntervalSeconds, setting default value")
 		Data.EventConf.DeliveryRetryIntervalSeconds = DefaultDeliveryRetryIntervalSeconds
 	}
+	if Data.EventConf.SSEEventBufferSize <= 0 {
+		wl.add("No value found for SSEEventBufferSize, setting default value")
+		Data.EventConf.SSEEventBufferSize = DefaultSSEEventBufferSize
+	}
+	if Data.EventConf.SSEKeepAliveIntervalSeconds <= 0 {
+		wl.add("No value found for SSEKeepAliveIntervalSeconds, setting default value")
+		Data.EventConf.SSEKeepAliveIntervalSeconds = DefaultSSEKeepAliveIntervalSeconds
+	}
+	if Data.EventConf.UndeliveredEventsLimit <= 0 {
+		wl.add("No value found for UndeliveredEventsLimit, setting default value")
+		Data.EventConf.UndeliveredEventsLimit = DefaultUndeliveredEventsLimit
+	}
 	return nil
 }
 
+func checkCredentialRotationConf(wl *WarningList) {
+	if Data.CredentialRotationConf == nil {
+		wl.add("CredentialRotationConf not provided, setting default value")
+		Data.CredentialRotationConf = &CredentialRotationConf{
+			PasswordLength:           DefaultBMCPasswordLength,
+			AllowedSpecialCharacters: DefaultBMCPasswordSpecialCharacters,
+		}
+		return
+	}
+	if Data.CredentialRotationConf.RotationIntervalInDays < 0 {
+		wl.add("Invalid value set for RotationIntervalInDays, the BMC passwords will not be rotated on schedule")
+		Data.CredentialRotationConf.RotationIntervalInDays = 0
+	}
+	// the generated password has at least one upper case letter, lower case letter, digit and special character
+	if Data.CredentialRotationConf.PasswordLength < MinBMCPasswordLength || Data.CredentialRotationConf.PasswordLength > MaxBMCPasswordLength {
+		wl.add("No valid value set for PasswordLength, setting default value")
+		Data.CredentialRotationConf.PasswordLength = DefaultBMCPasswordLength
+	}
+	if Data.CredentialRotationConf.AllowedSpecialCharacters == "" {
+		wl.add("No value set for AllowedSpecialCharacters, setting default value")
+		Data.CredentialRotationConf.AllowedSpecialCharacters = DefaultBMCPasswordSpecialCharacters
+	}
+}
+
+func checkInteropProfileConf(wl *WarningList) {
+	if Data.InteropProfileConf == nil {
+		wl.add("InteropProfileConf not provided, setting default value")
+		Data.InteropProfileConf = &InteropProfileConf{}
+	}
+	if Data.InteropProfileConf.ProfilesPath == "" {
+		wl.add("No value set for ProfilesPath, setting default value")
+		Data.InteropProfileConf.ProfilesPath = DefaultInteropProfilesPath
+	}
+}
+
 func checkResourceRateLimit() error {
 	for _, val := range Data.ResourceRateLimit {
 		resourceLimit := strings.Split(val, ":")
@@ -19,6 +19,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 )
 
@@ -621,3 +622,416 @@ func TestValidateConfigurationForEventConf(t *testing.T) {
 	}
 	os.Remove(sampleFileForTest)
 }
+
+func TestCheckExternalAccountProviderConf(t *testing.T) {
+	tests := []struct {
+		name        string
+		provider    *ExternalAccountProvider
+		wantEnabled bool
+	}{
+		{
+			name:     "Account provider not configured",
+			provider: nil,
+		},
+		{
+			name: "ServiceAddresses not configured, disabling the provider",
+			provider: &ExternalAccountProvider{
+				ServiceEnabled:         true,
+				BaseDistinguishedNames: []string{"ou=people,dc=odim,dc=local"},
+			},
+			wantEnabled: false,
+		},
+		{
+			name: "Zero value configured, setting to default",
+			provider: &ExternalAccountProvider{
+				ServiceEnabled:         true,
+				ServiceAddresses:       []string{"ldap://localhost:389"},
+				BaseDistinguishedNames: []string{"ou=people,dc=odim,dc=local"},
+			},
+			wantEnabled: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkExternalAccountProviderConf(&WarningList{}, "LDAP", tt.provider, DefaultLDAPUsernameAttribute)
+			if tt.provider == nil {
+				return
+			}
+			if tt.provider.ServiceEnabled != tt.wantEnabled {
+				t.Errorf("checkExternalAccountProviderConf() ServiceEnabled = %v, want %v", tt.provider.ServiceEnabled, tt.wantEnabled)
+			}
+			if tt.wantEnabled && (tt.provider.UsernameAttribute != DefaultLDAPUsernameAttribute ||
+				tt.provider.GroupsAttribute != DefaultGroupsAttribute ||
+				tt.provider.TimeoutInSeconds != DefaultExternalAccountProviderTimeout) {
+				t.Errorf("checkExternalAccountProviderConf() defaults not set: %+v", tt.provider)
+			}
+		})
+	}
+}
+
+func TestCheckOAuth2Conf(t *testing.T) {
+	tests := []struct {
+		name        string
+		oauth2      *OAuth2
+		wantEnabled bool
+		wantMode    string
+	}{
+		{
+			name: "Issuer not configured, disabling the bearer token authentication",
+			oauth2: &OAuth2{
+				ServiceEnabled: true,
+				Audience:       []string{"odimra"},
+			},
+			wantEnabled: false,
+		},
+		{
+			name: "Signing keys not configured in Offline mode",
+			oauth2: &OAuth2{
+				ServiceEnabled: true,
+				Mode:           OAuth2ModeOffline,
+				Issuer:         "https://idp.odim.local",
+				Audience:       []string{"odimra"},
+			},
+			wantEnabled: false,
+			wantMode:    OAuth2ModeOffline,
+		},
+		{
+			name: "Zero value configured, setting to default",
+			oauth2: &OAuth2{
+				ServiceEnabled: true,
+				Issuer:         "https://idp.odim.local",
+				Audience:       []string{"odimra"},
+			},
+			wantEnabled: true,
+			wantMode:    OAuth2ModeDiscovery,
+		},
+	}
+	authConf := Data.AuthConf
+	Data.AuthConf = &AuthConf{}
+	defer func() {
+		Data.AuthConf = authConf
+	}()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Data.AuthConf.OAuth2 = tt.oauth2
+			checkOAuth2Conf(&WarningList{})
+			if tt.oauth2.ServiceEnabled != tt.wantEnabled {
+				t.Errorf("checkOAuth2Conf() ServiceEnabled = %v, want %v", tt.oauth2.ServiceEnabled, tt.wantEnabled)
+			}
+			if tt.oauth2.Mode != tt.wantMode {
+				t.Errorf("checkOAuth2Conf() Mode = %v, want %v", tt.oauth2.Mode, tt.wantMode)
+			}
+			if tt.wantEnabled && (tt.oauth2.UsernameClaim != DefaultOAuth2UsernameClaim || tt.oauth2.RolesClaim != DefaultOAuth2RolesClaim) {
+				t.Errorf("checkOAuth2Conf() defaults not set: %+v", tt.oauth2)
+			}
+		})
+	}
+}
+
+func TestCheckAccountLockoutConf(t *testing.T) {
+	tests := []struct {
+		name             string
+		authConf         AuthConf
+		wantThreshold    int
+		wantDuration     int
+		wantCounterReset int
+	}{
+		{
+			name:             "Zero value configured, setting to default",
+			authConf:         AuthConf{},
+			wantThreshold:    0,
+			wantDuration:     DefaultAccountLockoutDuration,
+			wantCounterReset: DefaultAccountLockoutCounterResetAfter,
+		},
+		{
+			name: "Negative AccountLockoutThreshold configured, setting to default",
+			authConf: AuthConf{
+				AccountLockoutThreshold:         -1,
+				AccountLockoutDuration:          600,
+				AccountLockoutCounterResetAfter: 300,
+			},
+			wantThreshold:    DefaultAccountLockoutThreshold,
+			wantDuration:     600,
+			wantCounterReset: 300,
+		},
+		{
+			name: "AccountLockoutDuration less than AccountLockoutCounterResetAfter",
+			authConf: AuthConf{
+				AccountLockoutThreshold:         3,
+				AccountLockoutDuration:          60,
+				AccountLockoutCounterResetAfter: 300,
+			},
+			wantThreshold:    3,
+			wantDuration:     300,
+			wantCounterReset: 300,
+		},
+		{
+			name: "Valid values configured",
+			authConf: AuthConf{
+				AccountLockoutThreshold:         3,
+				AccountLockoutDuration:          600,
+				AccountLockoutCounterResetAfter: 300,
+			},
+			wantThreshold:    3,
+			wantDuration:     600,
+			wantCounterReset: 300,
+		},
+	}
+	authConf := Data.AuthConf
+	defer func() {
+		Data.AuthConf = authConf
+	}()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Data.AuthConf = &tt.authConf
+			checkAccountLockoutConf(&WarningList{})
+			if Data.AuthConf.AccountLockoutThreshold != tt.wantThreshold {
+				t.Errorf("checkAccountLockoutConf() AccountLockoutThreshold = %v, want %v", Data.AuthConf.AccountLockoutThreshold, tt.wantThreshold)
+			}
+			if Data.AuthConf.AccountLockoutDuration != tt.wantDuration {
+				t.Errorf("checkAccountLockoutConf() AccountLockoutDuration = %v, want %v", Data.AuthConf.AccountLockoutDuration, tt.wantDuration)
+			}
+			if Data.AuthConf.AccountLockoutCounterResetAfter != tt.wantCounterReset {
+				t.Errorf("checkAccountLockoutConf() AccountLockoutCounterResetAfter = %v, want %v", Data.AuthConf.AccountLockoutCounterResetAfter, tt.wantCounterReset)
+			}
+		})
+	}
+}
+
+func TestCheckPasswordRulesConf(t *testing.T) {
+	tests := []struct {
+		name           string
+		passwordRules  *PasswordRules
+		wantClasses    []string
+		wantHistory    int
+		wantMaxAgeDays int
+	}{
+		{
+			name:          "PasswordRules not configured, setting to default",
+			passwordRules: nil,
+			wantClasses:   DefaultRequiredCharacterClasses,
+		},
+		{
+			name:          "RequiredCharacterClasses not configured, setting to default",
+			passwordRules: &PasswordRules{PasswordHistoryCount: 3, MaxPasswordAgeInDays: 90},
+			wantClasses:   DefaultRequiredCharacterClasses,
+			wantHistory:   3, wantMaxAgeDays: 90,
+		},
+		{
+			name:          "no character class required",
+			passwordRules: &PasswordRules{RequiredCharacterClasses: []string{}},
+			wantClasses:   []string{},
+		},
+		{
+			name: "invalid values configured",
+			passwordRules: &PasswordRules{
+				RequiredCharacterClasses: []string{"Digit", "Emoji"},
+				PasswordHistoryCount:     -1,
+				MaxPasswordAgeInDays:     -1,
+			},
+			wantClasses: []string{"Digit"},
+		},
+	}
+	authConf := Data.AuthConf
+	defer func() {
+		Data.AuthConf = authConf
+	}()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Data.AuthConf = &AuthConf{PasswordRules: tt.passwordRules}
+			checkPasswordRulesConf(&WarningList{})
+			rules := Data.AuthConf.PasswordRules
+			if !reflect.DeepEqual(rules.RequiredCharacterClasses, tt.wantClasses) {
+				t.Errorf("checkPasswordRulesConf() RequiredCharacterClasses = %v, want %v", rules.RequiredCharacterClasses, tt.wantClasses)
+			}
+			if rules.PasswordHistoryCount != tt.wantHistory {
+				t.Errorf("checkPasswordRulesConf() PasswordHistoryCount = %v, want %v", rules.PasswordHistoryCount, tt.wantHistory)
+			}
+			if rules.MaxPasswordAgeInDays != tt.wantMaxAgeDays {
+				t.Errorf("checkPasswordRulesConf() MaxPasswordAgeInDays = %v, want %v", rules.MaxPasswordAgeInDays, tt.wantMaxAgeDays)
+			}
+		})
+	}
+}
+
+func TestCheckCredentialRotationConf(t *testing.T) {
+	tests := []struct {
+		name            string
+		rotationConf    *CredentialRotationConf
+		wantInterval    int
+		wantLength      int
+		wantSpecialChar string
+	}{
+		{
+			name:            "CredentialRotationConf not configured, setting to default",
+			rotationConf:    nil,
+			wantLength:      DefaultBMCPasswordLength,
+			wantSpecialChar: DefaultBMCPasswordSpecialCharacters,
+		},
+		{
+			name:            "invalid values configured",
+			rotationConf:    &CredentialRotationConf{RotationIntervalInDays: -1, PasswordLength: 4},
+			wantLength:      DefaultBMCPasswordLength,
+			wantSpecialChar: DefaultBMCPasswordSpecialCharacters,
+		},
+		{
+			name:            "valid values configured",
+			rotationConf:    &CredentialRotationConf{RotationIntervalInDays: 30, PasswordLength: 20, AllowedSpecialCharacters: "!@"},
+			wantInterval:    30,
+			wantLength:      20,
+			wantSpecialChar: "!@",
+		},
+	}
+	rotationConf := Data.CredentialRotationConf
+	defer func() {
+		Data.CredentialRotationConf = rotationConf
+	}()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Data.CredentialRotationConf = tt.rotationConf
+			checkCredentialRotationConf(&WarningList{})
+			conf := Data.CredentialRotationConf
+			if conf.RotationIntervalInDays != tt.wantInterval {
+				t.Errorf("checkCredentialRotationConf() RotationIntervalInDays = %v, want %v", conf.RotationIntervalInDays, tt.wantInterval)
+			}
+			if conf.PasswordLength != tt.wantLength {
+				t.Errorf("checkCredentialRotationConf() PasswordLength = %v, want %v", conf.PasswordLength, tt.wantLength)
+			}
+			if conf.AllowedSpecialCharacters != tt.wantSpecialChar {
+				t.Errorf("checkCredentialRotationConf() AllowedSpecialCharacters = %v, want %v", conf.AllowedSpecialCharacters, tt.wantSpecialChar)
+			}
+		})
+	}
+}
+
+func TestCheckSecretConf(t *testing.T) {
+	tests := []struct {
+		name           string
+		secretConf     *SecretConf
+		wantProvider   string
+		wantMountPath  string
+		wantSecretPath string
+		wantErr        bool
+	}{
+		{
+			name:         "SecretConf not configured, setting to default",
+			secretConf:   nil,
+			wantProvider: SecretProviderRSA,
+		},
+		{
+			name:         "Provider not configured, setting to default",
+			secretConf:   &SecretConf{},
+			wantProvider: SecretProviderRSA,
+		},
+		{
+			name:       "invalid Provider",
+			secretConf: &SecretConf{Provider: "Unknown"},
+			wantErr:    true,
+		},
+		{
+			name:       "Keyring without KeyringFilePath",
+			secretConf: &SecretConf{Provider: SecretProviderKeyring},
+			wantErr:    true,
+		},
+		{
+			name:         "valid Keyring",
+			secretConf:   &SecretConf{Provider: SecretProviderKeyring, KeyringFilePath: "/etc/odimra_keyring/keyring.json"},
+			wantProvider: SecretProviderKeyring,
+		},
+		{
+			name:       "Vault without VaultConf",
+			secretConf: &SecretConf{Provider: SecretProviderVault},
+			wantErr:    true,
+		},
+		{
+			name:       "Vault without TokenFilePath",
+			secretConf: &SecretConf{Provider: SecretProviderVault, VaultConf: &VaultConf{Address: "https://vault:8200"}},
+			wantErr:    true,
+		},
+		{
+			name: "Vault with default paths",
+			secretConf: &SecretConf{
+				Provider:  SecretProviderVault,
+				VaultConf: &VaultConf{Address: "https://vault:8200", TokenFilePath: "/etc/odimra_vault/token"},
+			},
+			wantProvider:   SecretProviderVault,
+			wantMountPath:  DefaultVaultMountPath,
+			wantSecretPath: DefaultVaultSecretPath,
+		},
+	}
+	secretConf := Data.SecretConf
+	defer func() {
+		Data.SecretConf = secretConf
+	}()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Data.SecretConf = tt.secretConf
+			err := checkSecretConf(&WarningList{})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkSecretConf() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if Data.SecretConf.Provider != tt.wantProvider {
+				t.Errorf("checkSecretConf() Provider = %v, want %v", Data.SecretConf.Provider, tt.wantProvider)
+			}
+			if tt.wantMountPath != "" && Data.SecretConf.VaultConf.MountPath != tt.wantMountPath {
+				t.Errorf("checkSecretConf() MountPath = %v, want %v", Data.SecretConf.VaultConf.MountPath, tt.wantMountPath)
+			}
+			if tt.wantSecretPath != "" && Data.SecretConf.VaultConf.SecretPath != tt.wantSecretPath {
+				t.Errorf("checkSecretConf() SecretPath = %v, want %v", Data.SecretConf.VaultConf.SecretPath, tt.wantSecretPath)
+			}
+		})
+	}
+}
+
+func TestCheckClientCertificateConf(t *testing.T) {
+	tests := []struct {
+		name          string
+		conf          ClientCertificate
+		wantEnabled   bool
+		wantAttribute string
+	}{
+		{
+			name:        "CA bundle not configured",
+			conf:        ClientCertificate{Enabled: true},
+			wantEnabled: false,
+		},
+		{
+			name:          "mapping attribute not configured",
+			conf:          ClientCertificate{Enabled: true, CACertificatePath: "/etc/odimra_certs/clientca.crt"},
+			wantEnabled:   true,
+			wantAttribute: CertificateMappingCommonName,
+		},
+		{
+			name: "valid values configured",
+			conf: ClientCertificate{Enabled: true, CACertificatePath: "/etc/odimra_certs/clientca.crt",
+				CertificateMappingAttribute: CertificateMappingUserPrincipalName},
+			wantEnabled:   true,
+			wantAttribute: CertificateMappingUserPrincipalName,
+		},
+	}
+	authConf := Data.AuthConf
+	defer func() {
+		Data.AuthConf = authConf
+	}()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Data.AuthConf = &AuthConf{ClientCertificate: &tt.conf}
+			checkClientCertificateConf(&WarningList{})
+			if tt.conf.Enabled != tt.wantEnabled {
+				t.Errorf("checkClientCertificateConf() Enabled = %v, want %v", tt.conf.Enabled, tt.wantEnabled)
+			}
+			if !tt.wantEnabled {
+				return
+			}
+			if tt.conf.CertificateMappingAttribute != tt.wantAttribute {
+				t.Errorf("checkClientCertificateConf() CertificateMappingAttribute = %v, want %v", tt.conf.CertificateMappingAttribute, tt.wantAttribute)
+			}
+			if tt.conf.RespondToUnauthenticatedClients == nil || !*tt.conf.RespondToUnauthenticatedClients {
+				t.Errorf("checkClientCertificateConf() RespondToUnauthenticatedClients should default to true")
+			}
+		})
+	}
+}
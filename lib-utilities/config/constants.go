@@ -46,9 +46,9 @@ const (
 	DefaultAuthFailureLoggingThreshold = 3
 	// DefaultAccountLockoutThreshold - default AccountLockoutThreshold value
 	DefaultAccountLockoutThreshold = 5
-	// DefaultAccountLockoutDuration - default AccountLockoutDuration value
+	// DefaultAccountLockoutDuration - default AccountLockoutDuration value in seconds
 	DefaultAccountLockoutDuration = 30
-	// DefaultAccountLockoutCounterResetAfter - default AccountLockoutCounterResetAfter value
+	// DefaultAccountLockoutCounterResetAfter - default AccountLockoutCounterResetAfter value in seconds
 	DefaultAccountLockoutCounterResetAfter = 30
 	// DefaultMinPasswordLength - default MinPasswordLengt value
 	DefaultMinPasswordLength = 12
@@ -94,6 +94,69 @@ const (
 	DefaultDeliveryRetryAttempts = 3
 	// DefaultDeliveryRetryIntervalSeconds - default DeliveryRetryIntervalSeconds value
 	DefaultDeliveryRetryIntervalSeconds = 60
+	// DefaultSSEEventBufferSize - default SSEEventBufferSize value
+	DefaultSSEEventBufferSize = 1000
+	// DefaultSSEKeepAliveIntervalSeconds - default SSEKeepAliveIntervalSeconds value
+	DefaultSSEKeepAliveIntervalSeconds = 15
+	// DefaultSSEEventQueue - default message bus topic on which the events are published for the SSE streams
+	DefaultSSEEventQueue = "ODIM-SSE-EVENTS"
+	// DefaultHealthEventQueue - default message bus topic on which the events are published for the health rollup
+	DefaultHealthEventQueue = "ODIM-HEALTH-EVENTS"
+	// DefaultUndeliveredEventsLimit - default UndeliveredEventsLimit value
+	DefaultUndeliveredEventsLimit = 1000
+	// DefaultBMCPasswordLength - default PasswordLength value of CredentialRotationConf
+	DefaultBMCPasswordLength = 16
+	// MinBMCPasswordLength - minimum PasswordLength value of CredentialRotationConf
+	MinBMCPasswordLength = 8
+	// MaxBMCPasswordLength - maximum PasswordLength value of CredentialRotationConf
+	MaxBMCPasswordLength = 64
+	// DefaultBMCPasswordSpecialCharacters - default AllowedSpecialCharacters value of CredentialRotationConf
+	DefaultBMCPasswordSpecialCharacters = "!#$%*+-=?@^_"
+	// DefaultInteropProfilesPath - default ProfilesPath value of InteropProfileConf
+	DefaultInteropProfilesPath = "/etc/odimra_profiles"
+	// DefaultInventoryHistoryLimit - default InventoryHistoryLimit value
+	DefaultInventoryHistoryLimit = 20
+	// SecretProviderRSA - secret provider encrypting the credentials with the RSA key pair of KeyCertConf
+	SecretProviderRSA = "RSA"
+	// SecretProviderKeyring - secret provider encrypting the credentials with the versioned keys of a keyring file
+	SecretProviderKeyring = "Keyring"
+	// SecretProviderVault - secret provider storing the credentials in a Vault compatible KV secrets engine
+	SecretProviderVault = "Vault"
+	// DefaultVaultMountPath - default MountPath value of VaultConf
+	DefaultVaultMountPath = "secret"
+	// DefaultVaultSecretPath - default SecretPath value of VaultConf
+	DefaultVaultSecretPath = "odimra"
+	// DefaultLDAPUsernameAttribute - default UsernameAttribute value of the LDAP account provider
+	DefaultLDAPUsernameAttribute = "uid"
+	// DefaultActiveDirectoryUsernameAttribute - default UsernameAttribute value of the ActiveDirectory account provider
+	DefaultActiveDirectoryUsernameAttribute = "sAMAccountName"
+	// DefaultGroupsAttribute - default GroupsAttribute value of the external account providers
+	DefaultGroupsAttribute = "memberOf"
+	// DefaultExternalAccountProviderTimeout - default TimeoutInSeconds value of the external account providers
+	DefaultExternalAccountProviderTimeout = 10
+	// DefaultOAuth2UsernameClaim - default UsernameClaim value of OAuth2
+	DefaultOAuth2UsernameClaim = "sub"
+	// DefaultOAuth2RolesClaim - default RolesClaim value of OAuth2
+	DefaultOAuth2RolesClaim = "groups"
+	// OAuth2ModeDiscovery - the OAuth2 signing keys are fetched from the issuer
+	OAuth2ModeDiscovery = "Discovery"
+	// OAuth2ModeOffline - the OAuth2 signing keys are configured in OAuthServiceSigningKeys
+	OAuth2ModeOffline = "Offline"
+	// CertificateMappingCommonName - the account of the client certificate is the common name of the subject
+	CertificateMappingCommonName = "CommonName"
+	// CertificateMappingUserPrincipalName - the account of the client certificate is the user principal name
+	// in the subject alternative name
+	CertificateMappingUserPrincipalName = "UserPrincipalName"
+	// DefaultRespondToUnauthenticatedClients - default RespondToUnauthenticatedClients value of ClientCertificate
+	DefaultRespondToUnauthenticatedClients = true
+	// PasswordCharacterClassUpperCase - the password must have an upper case letter
+	PasswordCharacterClassUpperCase = "UpperCase"
+	// PasswordCharacterClassLowerCase - the password must have a lower case letter
+	PasswordCharacterClassLowerCase = "LowerCase"
+	// PasswordCharacterClassDigit - the password must have a digit
+	PasswordCharacterClassDigit = "Digit"
+	// PasswordCharacterClassSpecialCharacter - the password must have one of the AllowedSpecialCharcters
+	PasswordCharacterClassSpecialCharacter = "SpecialCharacter"
 )
 
 var (
@@ -105,6 +168,13 @@ var (
 	DefaultSkipListUnderChassis = []string{"Managers", "Systems", "Devices"}
 	// DefaultSkipListUnderOthers - holds the default list of resources which needs to be ignored for storing in DB under any other resource
 	DefaultSkipListUnderOthers = []string{"Power", "Thermal", "SmartStorage"}
+	// DefaultRequiredCharacterClasses - default RequiredCharacterClasses value
+	DefaultRequiredCharacterClasses = []string{
+		PasswordCharacterClassUpperCase,
+		PasswordCharacterClassLowerCase,
+		PasswordCharacterClassDigit,
+		PasswordCharacterClassSpecialCharacter,
+	}
 	// DefaultCipherSuiteList - default cipher suite list
 	DefaultCipherSuiteList = []uint16{
 		tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
@@ -19,6 +19,7 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"sync"
@@ -121,6 +122,25 @@ func (config *HTTPConfig) LoadCertificates(tlsConfig *tls.Config) error {
 	return nil
 }
 
+// SetTLSConfig is for requesting the client certificates during the TLS handshake,
+// the certificates are validated against the CA bundle in CACertificatePath
+func (clientCertificate *ClientCertificate) SetTLSConfig(tlsConfig *tls.Config) error {
+	caBundle, err := ioutil.ReadFile(clientCertificate.CACertificatePath)
+	if err != nil {
+		return fmt.Errorf("error: value check failed for CACertificatePath:%s with %v", clientCertificate.CACertificatePath, err)
+	}
+	capool := x509.NewCertPool()
+	if !capool.AppendCertsFromPEM(caBundle) {
+		return fmt.Errorf("error: failed to load CA certificate of the client certificates")
+	}
+	tlsConfig.ClientCAs = capool
+	tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	if clientCertificate.RespondToUnauthenticatedClients != nil && !*clientCertificate.RespondToUnauthenticatedClients {
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return nil
+}
+
 // SetTLSConfig is for setting updating common fields of tls.Config
 func (host Host) SetTLSConfig(tlsConfig *tls.Config) {
 	tlsConfig.MinVersion = configuredTLSMinVersion
@@ -17,7 +17,9 @@ package config
 
 import (
 	"crypto/tls"
+	"io/ioutil"
 	"net/http"
+	"path/filepath"
 	"reflect"
 	"testing"
 )
@@ -455,3 +457,49 @@ func TestSetPreferredCipherSuites(t *testing.T) {
 		})
 	}
 }
+
+func TestClientCertificateSetTLSConfig(t *testing.T) {
+	SetUpMockConfig(t)
+	caBundlePath := filepath.Join(t.TempDir(), "clientca.crt")
+	if err := ioutil.WriteFile(caBundlePath, hostCA, 0600); err != nil {
+		t.Fatalf("unable to write the CA bundle: %v", err)
+	}
+	respond, reject := true, false
+	tests := []struct {
+		name           string
+		conf           ClientCertificate
+		wantClientAuth tls.ClientAuthType
+		wanterr        bool
+	}{
+		{
+			name:           "certificate optional",
+			conf:           ClientCertificate{CACertificatePath: caBundlePath, RespondToUnauthenticatedClients: &respond},
+			wantClientAuth: tls.VerifyClientCertIfGiven,
+		},
+		{
+			name:           "certificate required",
+			conf:           ClientCertificate{CACertificatePath: caBundlePath, RespondToUnauthenticatedClients: &reject},
+			wantClientAuth: tls.RequireAndVerifyClientCert,
+		},
+		{
+			name:    "CA bundle not found",
+			conf:    ClientCertificate{CACertificatePath: caBundlePath + ".missing"},
+			wanterr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tlsConfig := &tls.Config{}
+			err := tt.conf.SetTLSConfig(tlsConfig)
+			if (err != nil) != tt.wanterr {
+				t.Fatalf("SetTLSConfig() err = %v, wanterr %v", err, tt.wanterr)
+			}
+			if tt.wanterr {
+				return
+			}
+			if tlsConfig.ClientAuth != tt.wantClientAuth || tlsConfig.ClientCAs == nil {
+				t.Errorf("SetTLSConfig() ClientAuth = %v, want %v", tlsConfig.ClientAuth, tt.wantClientAuth)
+			}
+		})
+	}
+}
@@ -222,6 +222,7 @@ func SetUpMockConfig(t *testing.T) error {
 	Data.FirmwareVersion = "1.0"
 	Data.SouthBoundRequestTimeoutInSecs = 10
 	Data.ServerRediscoveryBatchSize = 10
+	Data.InventoryHistoryLimit = 5
 	path := strings.SplitAfter(workingDir, "ODIM")
 	var basePath string
 	if len(path) > 2 {
@@ -248,6 +249,8 @@ func SetUpMockConfig(t *testing.T) error {
 	Data.MessageBusConf = &MessageBusConf{
 		MessageBusType:          "Kafka",
 		OdimControlMessageQueue: "odim-control-messages",
+		OdimSSEEventQueue:       "odim-sse-events",
+		OdimHealthEventQueue:    "odim-health-events",
 	}
 	Data.KeyCertConf = &KeyCertConf{
 		RootCACertificate: hostCA,
@@ -256,13 +259,20 @@ func SetUpMockConfig(t *testing.T) error {
 		RSAPublicKey:      hostPubKey,
 		RSAPrivateKey:     hostRSAPrivKey,
 	}
+	Data.SecretConf = &SecretConf{
+		Provider: SecretProviderRSA,
+	}
 	Data.AuthConf = &AuthConf{
 		SessionTimeOutInMins:            30,
 		ExpiredSessionCleanUpTimeInMins: 15,
+		AccountLockoutThreshold:         DefaultAccountLockoutThreshold,
+		AccountLockoutDuration:          DefaultAccountLockoutDuration,
+		AccountLockoutCounterResetAfter: DefaultAccountLockoutCounterResetAfter,
 		PasswordRules: &PasswordRules{
-			MinPasswordLength:       12,
-			MaxPasswordLength:       16,
-			AllowedSpecialCharcters: "~!@#$%^&*-+_|(){}:;<>,.?/",
+			MinPasswordLength:        12,
+			MaxPasswordLength:        16,
+			AllowedSpecialCharcters:  "~!@#$%^&*-+_|(){}:;<>,.?/",
+			RequiredCharacterClasses: DefaultRequiredCharacterClasses,
 		},
 	}
 	Data.APIGatewayConf = &APIGatewayConf{
@@ -340,6 +350,16 @@ func SetUpMockConfig(t *testing.T) error {
 	Data.EventConf = &EventConf{
 		DeliveryRetryAttempts:        1,
 		DeliveryRetryIntervalSeconds: 1,
+		SSEEventBufferSize:           10,
+		SSEKeepAliveIntervalSeconds:  1,
+		UndeliveredEventsLimit:       10,
+	}
+	Data.CredentialRotationConf = &CredentialRotationConf{
+		PasswordLength:           DefaultBMCPasswordLength,
+		AllowedSpecialCharacters: DefaultBMCPasswordSpecialCharacters,
+	}
+	Data.InteropProfileConf = &InteropProfileConf{
+		ProfilesPath: basePath + "/odim-profiles",
 	}
 	Data.TaskQueueConf = &TaskQueueConf{
 		QueueSize:        1000,
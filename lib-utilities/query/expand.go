@@ -0,0 +1,256 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package query
+
+import (
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+)
+
+// $expand related query parameters
+const (
+	ExpandParam = "$expand"
+	Levels      = "$levels"
+)
+
+// types of hyperlinks to be expanded
+const (
+	// ExpandAll expands all the hyperlinks
+	ExpandAll = "*"
+	// ExpandSubordinate expands the hyperlinks which are not in the Links property
+	ExpandSubordinate = "."
+	// ExpandLinks expands only the hyperlinks in the Links property
+	ExpandLinks = "~"
+)
+
+// MaxExpandLevels is the maximum value supported for $levels
+const MaxExpandLevels = 6
+
+// MaxExpandedResources is the maximum number of resources expanded in a
+// single response. The hyperlinks left after reaching the limit are not
+// expanded, so that large servers can't make the response grow unbounded.
+const MaxExpandedResources = 256
+
+var expandPattern = regexp.MustCompile(`^([*.~])(?:\(\$levels=([^)]*)\))?$`)
+
+// Expand is the parsed $expand query parameter
+type Expand struct {
+	Type   string
+	Levels int
+}
+
+// ResourceFetcher returns the resource with the given @odata.id
+type ResourceFetcher func(odataID string) (map[string]interface{}, error)
+
+// ParseExpand returns the $expand requested in the query parameters, nil if
+// the expansion is not requested. The levels can be passed either as
+// $expand=.($levels=2) or as a separate $levels parameter.
+func ParseExpand(values url.Values) (*Expand, *Error) {
+	value, exist := values[ExpandParam]
+	levelsValue, levelsExist := values[Levels]
+	if !exist {
+		if levelsExist {
+			return nil, &Error{
+				StatusMessage: response.QueryNotSupported,
+				ErrorMessage:  "error: " + Levels + " is supported only along with " + ExpandParam,
+			}
+		}
+		return nil, nil
+	}
+	match := expandPattern.FindStringSubmatch(value[0])
+	if match == nil {
+		return nil, &Error{
+			StatusMessage: response.QueryParameterValueFormatError,
+			ErrorMessage:  "error: " + ExpandParam + " must be one of *, . or ~ optionally followed by ($levels=n)",
+			MessageArgs:   []interface{}{value[0], ExpandParam},
+		}
+	}
+	expand := &Expand{Type: match[1], Levels: 1}
+	levels := match[2]
+	if levelsExist {
+		if levels != "" {
+			return nil, &Error{
+				StatusMessage: response.QueryCombinationInvalid,
+				ErrorMessage:  "error: " + Levels + " is given both in " + ExpandParam + " and as a separate parameter",
+			}
+		}
+		levels = levelsValue[0]
+	}
+	if levels != "" || strings.Contains(value[0], "(") {
+		n, err := strconv.Atoi(levels)
+		if err != nil {
+			return nil, &Error{
+				StatusMessage: response.QueryParameterValueFormatError,
+				ErrorMessage:  "error: " + Levels + " must be an integer",
+				MessageArgs:   []interface{}{levels, Levels},
+			}
+		}
+		if n < 1 || n > MaxExpandLevels {
+			return nil, &Error{
+				StatusMessage: response.QueryParameterOutOfRange,
+				ErrorMessage:  "error: unsupported value for " + Levels,
+				MessageArgs:   []interface{}{levels, Levels, "1-" + strconv.Itoa(MaxExpandLevels)},
+			}
+		}
+		expand.Levels = n
+	}
+	return expand, nil
+}
+
+// SplitExpand separates $expand and $levels from the request URI. It returns
+// the URI without them, keeping the other query parameters as they are, along
+// with the parsed $expand which is nil when the expansion is not requested.
+func SplitExpand(uri string) (string, *Expand, *Error) {
+	parts := strings.SplitN(uri, "?", 2)
+	if len(parts) == 1 {
+		return uri, nil, nil
+	}
+	var remaining []string
+	values := url.Values{}
+	for _, param := range strings.Split(parts[1], "&") {
+		if param == "" {
+			continue
+		}
+		kv := strings.SplitN(param, "=", 2)
+		key, err := url.QueryUnescape(kv[0])
+		if err != nil || (key != ExpandParam && key != Levels) {
+			remaining = append(remaining, param)
+			continue
+		}
+		var value string
+		if len(kv) == 2 {
+			if value, err = url.QueryUnescape(kv[1]); err != nil {
+				return "", nil, &Error{
+					StatusMessage: response.QueryParameterValueFormatError,
+					ErrorMessage:  "error: invalid encoding of " + key,
+					MessageArgs:   []interface{}{kv[1], key},
+				}
+			}
+		}
+		values.Add(key, value)
+	}
+	expand, e := ParseExpand(values)
+	if e != nil {
+		return "", nil, e
+	}
+	if len(remaining) == 0 {
+		return parts[0], expand, nil
+	}
+	return parts[0] + "?" + strings.Join(remaining, "&"), expand, nil
+}
+
+// Apply replaces the hyperlinks in the resource with the resources they
+// refer to, upto the requested levels. At most budget resources are fetched,
+// the hyperlinks which couldn't be fetched or are beyond the budget are left
+// as they are. The number of resources expanded is returned along with a
+// flag reporting whether the budget was exhausted.
+func (e *Expand) Apply(resource map[string]interface{}, fetch ResourceFetcher, budget int) (int, bool) {
+	x := &expander{
+		expand:  e,
+		fetch:   fetch,
+		budget:  budget,
+		visited: make(map[string]bool),
+	}
+	if id, ok := resource["@odata.id"].(string); ok {
+		x.visited[strings.TrimSuffix(id, "/")] = true
+	}
+	x.walk(resource, e.Levels, false)
+	return x.expanded, x.exhausted
+}
+
+type expander struct {
+	expand    *Expand
+	fetch     ResourceFetcher
+	budget    int
+	expanded  int
+	exhausted bool
+	// visited holds the resources in the current expansion path, which are
+	// not expanded again to avoid cycles
+	visited map[string]bool
+}
+
+// walk expands the hyperlinks found in the value. inLinks tells whether the
+// value is within a Links property.
+func (x *expander) walk(value interface{}, levels int, inLinks bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		// sorted, so that the same hyperlinks are expanded when the budget
+		// runs out
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			child := v[key]
+			if key == "Oem" || strings.HasPrefix(key, "@odata.") {
+				continue
+			}
+			childInLinks := inLinks || key == "Links"
+			if expanded, ok := x.resolve(child, levels, childInLinks); ok {
+				v[key] = expanded
+				continue
+			}
+			x.walk(child, levels, childInLinks)
+		}
+	case []interface{}:
+		for i, child := range v {
+			if expanded, ok := x.resolve(child, levels, inLinks); ok {
+				v[i] = expanded
+				continue
+			}
+			x.walk(child, levels, inLinks)
+		}
+	}
+}
+
+// resolve fetches the resource if the value is a hyperlink to be expanded
+func (x *expander) resolve(value interface{}, levels int, inLinks bool) (map[string]interface{}, bool) {
+	link, ok := value.(map[string]interface{})
+	if !ok || len(link) != 1 {
+		return nil, false
+	}
+	id, ok := link["@odata.id"].(string)
+	if !ok || strings.Contains(id, "#") {
+		return nil, false
+	}
+	if (inLinks && x.expand.Type == ExpandSubordinate) || (!inLinks && x.expand.Type == ExpandLinks) {
+		return nil, false
+	}
+	id = strings.TrimSuffix(id, "/")
+	if x.visited[id] {
+		return nil, false
+	}
+	if x.expanded >= x.budget {
+		x.exhausted = true
+		return nil, false
+	}
+	resource, err := x.fetch(id)
+	if err != nil || resource == nil {
+		return nil, false
+	}
+	x.expanded++
+	if levels > 1 {
+		x.visited[id] = true
+		x.walk(resource, levels-1, false)
+		delete(x.visited, id)
+	}
+	return resource, true
+}
@@ -0,0 +1,204 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+)
+
+func TestParseExpand(t *testing.T) {
+	tests := []struct {
+		query         string
+		want          *Expand
+		wantStatusMsg string
+	}{
+		{query: "", want: nil},
+		{query: "$expand=.", want: &Expand{Type: ExpandSubordinate, Levels: 1}},
+		{query: "$expand=*", want: &Expand{Type: ExpandAll, Levels: 1}},
+		{query: "$expand=~(" + url.QueryEscape("$levels=3") + ")", want: &Expand{Type: ExpandLinks, Levels: 3}},
+		{query: "$expand=.&$levels=2", want: &Expand{Type: ExpandSubordinate, Levels: 2}},
+		{query: "$expand=Processors", wantStatusMsg: response.QueryParameterValueFormatError},
+		{query: "$expand=.($levels=two)", wantStatusMsg: response.QueryParameterValueFormatError},
+		{query: "$expand=.($levels=7)", wantStatusMsg: response.QueryParameterOutOfRange},
+		{query: "$expand=.&$levels=0", wantStatusMsg: response.QueryParameterOutOfRange},
+		{query: "$expand=.($levels=2)&$levels=2", wantStatusMsg: response.QueryCombinationInvalid},
+		{query: "$levels=2", wantStatusMsg: response.QueryNotSupported},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			values, _ := url.ParseQuery(tt.query)
+			got, err := ParseExpand(values)
+			if tt.wantStatusMsg != "" {
+				if err == nil || err.StatusMessage != tt.wantStatusMsg {
+					t.Fatalf("ParseExpand() error = %v, want %v", err, tt.wantStatusMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseExpand() error = %v", err)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("ParseExpand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitExpand(t *testing.T) {
+	tests := []struct {
+		uri        string
+		wantURI    string
+		wantExpand bool
+	}{
+		{"/redfish/v1/Systems/1", "/redfish/v1/Systems/1", false},
+		{"/redfish/v1/Systems/1?$expand=.", "/redfish/v1/Systems/1", true},
+		{"/redfish/v1/Systems?$filter=MemorySummary/TotalSystemMemoryGiB%20eq%20384&$expand=*", "/redfish/v1/Systems?$filter=MemorySummary/TotalSystemMemoryGiB%20eq%20384", true},
+		{"/redfish/v1/Systems?%24expand=.%28%24levels%3D2%29&$top=1", "/redfish/v1/Systems?$top=1", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.uri, func(t *testing.T) {
+			uri, expand, err := SplitExpand(tt.uri)
+			if err != nil {
+				t.Fatalf("SplitExpand() error = %v", err)
+			}
+			if uri != tt.wantURI || (expand != nil) != tt.wantExpand {
+				t.Errorf("SplitExpand() = %v, %v, want %v, %v", uri, expand, tt.wantURI, tt.wantExpand)
+			}
+		})
+	}
+	if _, _, err := SplitExpand("/redfish/v1/Systems/1?$expand=+"); err == nil {
+		t.Error("SplitExpand() expected error for invalid $expand")
+	}
+}
+
+var testInventory = map[string]string{
+	"/redfish/v1/Systems/1": `{
+		"@odata.id": "/redfish/v1/Systems/1",
+		"Processors": {"@odata.id": "/redfish/v1/Systems/1/Processors"},
+		"Memory": {"@odata.id": "/redfish/v1/Systems/1/Memory/"},
+		"Bios": {"@odata.id": "/redfish/v1/Systems/1/Bios"},
+		"Links": {"Chassis": [{"@odata.id": "/redfish/v1/Chassis/1"}], "ManagedBy": [{"@odata.id": "/redfish/v1/Managers/1"}]},
+		"Oem": {"Vendor": {"@odata.id": "/redfish/v1/Systems/1/Oem"}},
+		"Status": {"Health": "OK"}
+	}`,
+	"/redfish/v1/Systems/1/Processors": `{
+		"@odata.id": "/redfish/v1/Systems/1/Processors",
+		"Members": [{"@odata.id": "/redfish/v1/Systems/1/Processors/1"}, {"@odata.id": "/redfish/v1/Systems/1/Processors/2"}]
+	}`,
+	"/redfish/v1/Systems/1/Processors/1": `{"@odata.id": "/redfish/v1/Systems/1/Processors/1", "Links": {"Chassis": {"@odata.id": "/redfish/v1/Chassis/1"}}}`,
+	"/redfish/v1/Systems/1/Processors/2": `{"@odata.id": "/redfish/v1/Systems/1/Processors/2", "Id": "2"}`,
+	"/redfish/v1/Systems/1/Memory":       `{"@odata.id": "/redfish/v1/Systems/1/Memory", "Members": []}`,
+	"/redfish/v1/Chassis/1":              `{"@odata.id": "/redfish/v1/Chassis/1", "Links": {"ComputerSystems": [{"@odata.id": "/redfish/v1/Systems/1"}]}}`,
+	"/redfish/v1/Systems/1/Oem":          `{"@odata.id": "/redfish/v1/Systems/1/Oem"}`,
+}
+
+func fetchTestInventory(fetched *[]string) ResourceFetcher {
+	return func(odataID string) (map[string]interface{}, error) {
+		data, exist := testInventory[odataID]
+		if !exist {
+			return nil, fmt.Errorf("%s not found", odataID)
+		}
+		*fetched = append(*fetched, odataID)
+		var resource map[string]interface{}
+		err := json.Unmarshal([]byte(data), &resource)
+		return resource, err
+	}
+}
+
+func getSystem(t *testing.T) map[string]interface{} {
+	var system map[string]interface{}
+	if err := json.Unmarshal([]byte(testInventory["/redfish/v1/Systems/1"]), &system); err != nil {
+		t.Fatal(err)
+	}
+	return system
+}
+
+func isExpanded(value interface{}) bool {
+	m, ok := value.(map[string]interface{})
+	return ok && len(m) > 1
+}
+
+func TestExpandApply(t *testing.T) {
+	t.Run("subordinate", func(t *testing.T) {
+		var fetched []string
+		system := getSystem(t)
+		n, exhausted := (&Expand{Type: ExpandSubordinate, Levels: 1}).Apply(system, fetchTestInventory(&fetched), MaxExpandedResources)
+		if n != 2 || exhausted {
+			t.Errorf("Apply() = %v, %v, want 2, false", n, exhausted)
+		}
+		if !isExpanded(system["Processors"]) || !isExpanded(system["Memory"]) {
+			t.Errorf("Processors and Memory are not expanded: %v", system)
+		}
+		// hyperlinks which can't be fetched are retained
+		if system["Bios"].(map[string]interface{})["@odata.id"] != "/redfish/v1/Systems/1/Bios" {
+			t.Errorf("Bios = %v, want the hyperlink", system["Bios"])
+		}
+		if isExpanded(system["Links"].(map[string]interface{})["Chassis"].([]interface{})[0]) {
+			t.Error("Links are expanded with $expand=.")
+		}
+		if isExpanded(system["Oem"].(map[string]interface{})["Vendor"]) {
+			t.Error("Oem is expanded")
+		}
+		members := system["Processors"].(map[string]interface{})["Members"].([]interface{})
+		if isExpanded(members[0]) {
+			t.Error("second level is expanded with $levels=1")
+		}
+	})
+
+	t.Run("links", func(t *testing.T) {
+		var fetched []string
+		system := getSystem(t)
+		(&Expand{Type: ExpandLinks, Levels: 1}).Apply(system, fetchTestInventory(&fetched), MaxExpandedResources)
+		if isExpanded(system["Processors"]) {
+			t.Error("subordinate resources are expanded with $expand=~")
+		}
+		if !isExpanded(system["Links"].(map[string]interface{})["Chassis"].([]interface{})[0]) {
+			t.Error("Links/Chassis is not expanded with $expand=~")
+		}
+	})
+
+	t.Run("levels and cycles", func(t *testing.T) {
+		var fetched []string
+		system := getSystem(t)
+		(&Expand{Type: ExpandAll, Levels: 3}).Apply(system, fetchTestInventory(&fetched), MaxExpandedResources)
+		members := system["Processors"].(map[string]interface{})["Members"].([]interface{})
+		if !isExpanded(members[0]) || !isExpanded(members[1]) {
+			t.Errorf("processors are not expanded with $levels=3: %v", members)
+		}
+		chassis := members[0].(map[string]interface{})["Links"].(map[string]interface{})["Chassis"]
+		if !isExpanded(chassis) {
+			t.Errorf("third level is not expanded: %v", chassis)
+		}
+		// the system is not expanded again inside the chassis
+		back := chassis.(map[string]interface{})["Links"].(map[string]interface{})["ComputerSystems"].([]interface{})[0]
+		if isExpanded(back) {
+			t.Error("the resource being expanded is expanded again")
+		}
+	})
+
+	t.Run("budget", func(t *testing.T) {
+		var fetched []string
+		system := getSystem(t)
+		n, exhausted := (&Expand{Type: ExpandAll, Levels: 2}).Apply(system, fetchTestInventory(&fetched), 2)
+		if n != 2 || !exhausted || len(fetched) != 2 {
+			t.Errorf("Apply() = %v, %v with %v fetched, want 2, true", n, exhausted, fetched)
+		}
+	})
+}
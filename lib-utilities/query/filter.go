@@ -0,0 +1,428 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Expression is a parsed $filter expression which can be evaluated against
+// a resource
+type Expression interface {
+	// Evaluate reports whether the resource satisfies the expression
+	Evaluate(resource map[string]interface{}) bool
+}
+
+// comparison operators supported in $filter
+const (
+	opEq = "eq"
+	opNe = "ne"
+	opGt = "gt"
+	opGe = "ge"
+	opLt = "lt"
+	opLe = "le"
+)
+
+var comparisonOperators = map[string]bool{
+	opEq: true, opNe: true, opGt: true, opGe: true, opLt: true, opLe: true,
+}
+
+// comparison is the expression "<property> <operator> <literal>"
+type comparison struct {
+	path     []string
+	operator string
+	value    interface{}
+}
+
+// logical is the expression "<expression> and|or <expression>"
+type logical struct {
+	operator    string
+	left, right Expression
+}
+
+// negation is the expression "not <expression>"
+type negation struct {
+	expr Expression
+}
+
+func (l logical) Evaluate(resource map[string]interface{}) bool {
+	if l.operator == "and" {
+		return l.left.Evaluate(resource) && l.right.Evaluate(resource)
+	}
+	return l.left.Evaluate(resource) || l.right.Evaluate(resource)
+}
+
+func (n negation) Evaluate(resource map[string]interface{}) bool {
+	return !n.expr.Evaluate(resource)
+}
+
+func (c comparison) Evaluate(resource map[string]interface{}) bool {
+	values := lookup(resource, c.path)
+	if c.operator == opNe {
+		return !c.matches(values, opEq)
+	}
+	return c.matches(values, c.operator)
+}
+
+// matches reports whether any of the property values satisfies the operator
+func (c comparison) matches(values []interface{}, operator string) bool {
+	if len(values) == 0 {
+		// missing property is treated as null
+		return c.value == nil && operator == opEq
+	}
+	for _, v := range values {
+		if compare(v, c.value, operator) {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterProperties returns the properties referred in the expression, the
+// names of nested properties are joined with '/'
+func FilterProperties(expr Expression) []string {
+	switch e := expr.(type) {
+	case comparison:
+		return []string{strings.Join(e.path, "/")}
+	case logical:
+		return append(FilterProperties(e.left), FilterProperties(e.right)...)
+	case negation:
+		return FilterProperties(e.expr)
+	}
+	return nil
+}
+
+// lookup returns the values found at the property path. Arrays on the path
+// are expanded, so a path can resolve to more than one value.
+func lookup(resource interface{}, path []string) []interface{} {
+	if len(path) == 0 {
+		if list, ok := resource.([]interface{}); ok {
+			return list
+		}
+		return []interface{}{resource}
+	}
+	switch r := resource.(type) {
+	case map[string]interface{}:
+		v, exist := r[path[0]]
+		if !exist {
+			return nil
+		}
+		return lookup(v, path[1:])
+	case []interface{}:
+		var values []interface{}
+		for _, item := range r {
+			values = append(values, lookup(item, path)...)
+		}
+		return values
+	}
+	return nil
+}
+
+// compare evaluates the operator on the property value and the literal.
+// Values of different types never match each other.
+func compare(property, literal interface{}, operator string) bool {
+	switch lv := literal.(type) {
+	case nil:
+		return property == nil && operator == opEq
+	case bool:
+		pv, ok := property.(bool)
+		return ok && operator == opEq && pv == lv
+	case float64:
+		pv, ok := toFloat(property)
+		if !ok {
+			return false
+		}
+		return compareOrdered(pv < lv, pv == lv, operator)
+	case string:
+		pv, ok := property.(string)
+		if !ok {
+			return false
+		}
+		return compareOrdered(pv < lv, pv == lv, operator)
+	}
+	return false
+}
+
+func compareOrdered(less, equal bool, operator string) bool {
+	switch operator {
+	case opEq:
+		return equal
+	case opGt:
+		return !less && !equal
+	case opGe:
+		return !less
+	case opLt:
+		return less
+	case opLe:
+		return less || equal
+	}
+	return false
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+// token kinds of the $filter expression
+const (
+	tokenIdentifier = iota
+	tokenString
+	tokenNumber
+	tokenOpenParen
+	tokenCloseParen
+)
+
+type token struct {
+	kind  int
+	value string
+}
+
+// tokenize splits the $filter expression into tokens
+func tokenize(expr string) ([]token, error) {
+	var tokens []token
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenOpenParen, value: "("})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenCloseParen, value: ")"})
+			i++
+		case r == '\'':
+			// string literal, a quote inside the literal is escaped by doubling it
+			var sb strings.Builder
+			i++
+			closed := false
+			for i < len(runes) {
+				if runes[i] == '\'' {
+					if i+1 < len(runes) && runes[i+1] == '\'' {
+						sb.WriteRune('\'')
+						i += 2
+						continue
+					}
+					closed = true
+					i++
+					break
+				}
+				sb.WriteRune(runes[i])
+				i++
+			}
+			if !closed {
+				return nil, fmt.Errorf("unterminated string literal")
+			}
+			tokens = append(tokens, token{kind: tokenString, value: sb.String()})
+		case r == '-' || unicode.IsDigit(r):
+			start := i
+			i++
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || strings.ContainsRune(".eE+-", runes[i])) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, value: string(runes[start:i])})
+		case isIdentifierRune(r):
+			start := i
+			for i < len(runes) && isIdentifierRune(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdentifier, value: string(runes[start:i])})
+		default:
+			return nil, fmt.Errorf("unexpected character %q", r)
+		}
+	}
+	return tokens, nil
+}
+
+func isIdentifierRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_@.#/", r)
+}
+
+// parser is a recursive descent parser for the $filter grammar
+//
+//	expression := term ( "or" term )*
+//	term       := factor ( "and" factor )*
+//	factor     := "not" factor | "(" expression ")" | property operator literal
+//	literal    := 'string' | number | true | false | null
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// ParseFilter parses the $filter expression
+func ParseFilter(expr string) (Expression, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty filter expression")
+	}
+	p := &parser{tokens: tokens}
+	e, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q at position %d", p.tokens[p.pos].value, p.pos+1)
+	}
+	return e, nil
+}
+
+func (p *parser) peek() *token {
+	if p.pos < len(p.tokens) {
+		return &p.tokens[p.pos]
+	}
+	return nil
+}
+
+func (p *parser) next() *token {
+	t := p.peek()
+	if t != nil {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) keyword(word string) bool {
+	t := p.peek()
+	if t != nil && t.kind == tokenIdentifier && t.value == word {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expression() (Expression, error) {
+	left, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or") {
+		right, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		left = logical{operator: "or", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) term() (Expression, error) {
+	left, err := p.factor()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("and") {
+		right, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		left = logical{operator: "and", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) factor() (Expression, error) {
+	if p.keyword("not") {
+		e, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		return negation{expr: e}, nil
+	}
+	t := p.next()
+	if t == nil {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	if t.kind == tokenOpenParen {
+		e, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		if c := p.next(); c == nil || c.kind != tokenCloseParen {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		return e, nil
+	}
+	if t.kind != tokenIdentifier || isReserved(t.value) {
+		return nil, fmt.Errorf("expected property name, found %q", t.value)
+	}
+	property := t.value
+	op := p.next()
+	if op == nil || op.kind != tokenIdentifier || !comparisonOperators[op.value] {
+		return nil, fmt.Errorf("expected comparison operator after %s", property)
+	}
+	value, err := p.literal()
+	if err != nil {
+		return nil, err
+	}
+	return comparison{
+		path:     strings.Split(property, "/"),
+		operator: op.value,
+		value:    value,
+	}, nil
+}
+
+func (p *parser) literal() (interface{}, error) {
+	t := p.next()
+	if t == nil {
+		return nil, fmt.Errorf("unexpected end of expression, expected a value")
+	}
+	switch t.kind {
+	case tokenString:
+		return t.value, nil
+	case tokenNumber:
+		n, err := strconv.ParseFloat(t.value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %s", t.value)
+		}
+		return n, nil
+	case tokenIdentifier:
+		switch t.value {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid value %q, string values must be enclosed in single quotes", t.value)
+}
+
+func isReserved(word string) bool {
+	switch word {
+	case "and", "or", "not", "true", "false", "null":
+		return true
+	}
+	return comparisonOperators[word]
+}
@@ -0,0 +1,120 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package query
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+const testResource = `{
+	"Id": "1",
+	"Name": "O'Brien server",
+	"PowerState": "On",
+	"MemorySummary": {"TotalSystemMemoryGiB": 384},
+	"ProcessorSummary": {"Count": 2, "Model": "Intel Xeon"},
+	"Status": {"Health": "OK", "State": "Enabled"},
+	"IndicatorLED": null,
+	"Enabled": true,
+	"Links": {"Chassis": [{"@odata.id": "/redfish/v1/Chassis/1"}, {"@odata.id": "/redfish/v1/Chassis/2"}]},
+	"Tags": ["rack1", "prod"]
+}`
+
+func TestParseFilterEvaluate(t *testing.T) {
+	var resource map[string]interface{}
+	if err := json.Unmarshal([]byte(testResource), &resource); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{"PowerState eq 'On'", true},
+		{"PowerState ne 'On'", false},
+		{"MemorySummary/TotalSystemMemoryGiB gt 256", true},
+		{"MemorySummary/TotalSystemMemoryGiB ge 384", true},
+		{"MemorySummary/TotalSystemMemoryGiB lt 384", false},
+		{"MemorySummary/TotalSystemMemoryGiB le 384.0", true},
+		{"ProcessorSummary/Count eq 2 and Status/Health eq 'OK'", true},
+		{"ProcessorSummary/Count eq 4 or Status/Health eq 'OK'", true},
+		{"not (ProcessorSummary/Count eq 4 or Status/Health eq 'Critical')", true},
+		{"(PowerState eq 'Off' or PowerState eq 'On') and not Enabled eq false", true},
+		{"PowerState eq 'On' and (Status/Health eq 'Warning' or Status/State eq 'Disabled')", false},
+		{"Name eq 'O''Brien server'", true},
+		{"IndicatorLED eq null", true},
+		{"AssetTag eq null", true},
+		{"AssetTag ne null", false},
+		{"Enabled eq true", true},
+		// values of different types never match
+		{"ProcessorSummary/Count eq '2'", false},
+		{"PowerState gt 1", false},
+		// arrays match when any of the elements match
+		{"Tags eq 'prod'", true},
+		{"Tags ne 'prod'", false},
+		{"Links/Chassis/@odata.id eq '/redfish/v1/Chassis/2'", true},
+		{"Links/Chassis/@odata.id eq '/redfish/v1/Chassis/3'", false},
+		// string comparison is lexical
+		{"ProcessorSummary/Model ge 'Intel'", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			expr, err := ParseFilter(tt.filter)
+			if err != nil {
+				t.Fatalf("ParseFilter() error = %v", err)
+			}
+			if got := expr.Evaluate(resource); got != tt.want {
+				t.Errorf("Evaluate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFilterInvalid(t *testing.T) {
+	tests := []string{
+		"",
+		"   ",
+		"PowerState",
+		"PowerState eq",
+		"PowerState eq On",
+		"PowerState equals 'On'",
+		"PowerState eq 'On",
+		"(PowerState eq 'On'",
+		"PowerState eq 'On')",
+		"PowerState eq 'On' and",
+		"PowerState eq 'On' PowerState eq 'Off'",
+		"and eq 'On'",
+		"Count eq 1.2.3",
+		"PowerState eq 'On' | Id eq '1'",
+	}
+	for _, filter := range tests {
+		t.Run(filter, func(t *testing.T) {
+			if _, err := ParseFilter(filter); err == nil {
+				t.Errorf("ParseFilter(%q) expected error", filter)
+			}
+		})
+	}
+}
+
+func TestFilterProperties(t *testing.T) {
+	expr, err := ParseFilter("EventType eq 'Alert' and not (Status/Health eq 'OK' or MessageId eq 'Base.1.0.Success')")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"EventType", "Status/Health", "MessageId"}
+	if got := FilterProperties(expr); !reflect.DeepEqual(got, want) {
+		t.Errorf("FilterProperties() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,300 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package query implements the Redfish query parameters $filter, $top, $skip
+// and $select which can be applied on any resource collection, and $expand
+// which expands the hyperlinks in a resource
+package query
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+)
+
+// query parameters supported by the package
+const (
+	Filter = "$filter"
+	Top    = "$top"
+	Skip   = "$skip"
+	Select = "$select"
+)
+
+// maxConcurrentFetch is the maximum number of members fetched in parallel
+// while evaluating $filter
+const maxConcurrentFetch = 10
+
+// Params holds the parsed query parameters of a request
+type Params struct {
+	Filter Expression
+	// Top is the maximum number of members to be returned, -1 when
+	// $top is not present in the request
+	Top    int
+	Skip   int
+	Select []string
+	// Expand is nil when the expansion is not requested
+	Expand *Expand
+
+	values url.Values
+}
+
+// Error is returned when the query parameters of the request are invalid
+type Error struct {
+	StatusMessage string
+	ErrorMessage  string
+	MessageArgs   []interface{}
+}
+
+func (e *Error) Error() string {
+	return e.ErrorMessage
+}
+
+// Response returns the Redfish error response for the invalid query
+func (e *Error) Response() response.RPC {
+	return common.GeneralError(http.StatusBadRequest, e.StatusMessage, e.ErrorMessage, e.MessageArgs, nil)
+}
+
+// MemberFetcher returns the resource of the collection member with the given
+// @odata.id
+type MemberFetcher func(odataID string) (map[string]interface{}, error)
+
+// Parse validates the query parameters of the request and returns the
+// parsed parameters. Query parameters starting with $ other than the
+// supported ones are reported as not supported.
+func Parse(values url.Values) (*Params, *Error) {
+	p := &Params{Top: -1, values: values}
+	for key, val := range values {
+		if !strings.HasPrefix(key, "$") {
+			continue
+		}
+		value := val[0]
+		switch key {
+		case Filter:
+			expr, err := ParseFilter(value)
+			if err != nil {
+				return nil, &Error{
+					StatusMessage: response.QueryParameterValueFormatError,
+					ErrorMessage:  "error: invalid $filter expression: " + err.Error(),
+					MessageArgs:   []interface{}{value, key},
+				}
+			}
+			p.Filter = expr
+		case Top, Skip:
+			n, e := parseNonNegative(key, value)
+			if e != nil {
+				return nil, e
+			}
+			if key == Top {
+				p.Top = n
+			} else {
+				p.Skip = n
+			}
+		case Select:
+			for _, property := range strings.Split(value, ",") {
+				property = strings.TrimSpace(property)
+				if property == "" {
+					return nil, &Error{
+						StatusMessage: response.QueryParameterValueFormatError,
+						ErrorMessage:  "error: $select contains an empty property name",
+						MessageArgs:   []interface{}{value, key},
+					}
+				}
+				p.Select = append(p.Select, property)
+			}
+		case ExpandParam, Levels:
+			// parsed together below
+		default:
+			return nil, &Error{
+				StatusMessage: response.QueryNotSupported,
+				ErrorMessage:  "error: query parameter " + key + " is not supported",
+			}
+		}
+	}
+	expand, e := ParseExpand(values)
+	if e != nil {
+		return nil, e
+	}
+	p.Expand = expand
+	return p, nil
+}
+
+func parseNonNegative(key, value string) (int, *Error) {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, &Error{
+			StatusMessage: response.QueryParameterValueFormatError,
+			ErrorMessage:  "error: " + key + " must be an integer",
+			MessageArgs:   []interface{}{value, key},
+		}
+	}
+	if n < 0 {
+		return 0, &Error{
+			StatusMessage: response.QueryParameterOutOfRange,
+			ErrorMessage:  "error: " + key + " must not be negative",
+			MessageArgs:   []interface{}{value, key, "0 or more"},
+		}
+	}
+	return n, nil
+}
+
+// IsEmpty reports whether none of the query parameters applied on the
+// collection by ApplyToCollection are present
+func (p *Params) IsEmpty() bool {
+	return p.Filter == nil && p.Top < 0 && p.Skip == 0 && len(p.Select) == 0
+}
+
+// ApplyToCollection applies the query parameters on the collection resource.
+// fetch is used to get the members of the collection when $filter is present.
+// path is the request path without the query, used to build the
+// Members@odata.nextLink when only a page of the members is returned.
+func (p *Params) ApplyToCollection(collection map[string]interface{}, path string, fetch MemberFetcher) map[string]interface{} {
+	members, _ := collection["Members"].([]interface{})
+	if p.Filter != nil {
+		members = p.filterMembers(members, fetch)
+	}
+	total := len(members)
+
+	delete(collection, "Members@odata.nextLink")
+	start := p.Skip
+	if start > total {
+		start = total
+	}
+	end := total
+	if p.Top >= 0 && start+p.Top < total {
+		end = start + p.Top
+	}
+	if end < total {
+		collection["Members@odata.nextLink"] = p.nextLink(path, end)
+	}
+	page := make([]interface{}, 0, end-start)
+	page = append(page, members[start:end]...)
+	collection["Members"] = page
+	collection["Members@odata.count"] = total
+
+	if len(p.Select) > 0 {
+		collection = SelectProperties(collection, p.Select, "Members", "Members@odata.count", "Members@odata.nextLink")
+	}
+	return collection
+}
+
+// filterMembers fetches the members concurrently and returns the ones which
+// satisfy $filter, preserving the order of the collection. Members which
+// can't be fetched are left out.
+func (p *Params) filterMembers(members []interface{}, fetch MemberFetcher) []interface{} {
+	matched := make([]bool, len(members))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrentFetch)
+	for i, member := range members {
+		link, _ := member.(map[string]interface{})
+		odataID, _ := link["@odata.id"].(string)
+		if odataID == "" {
+			continue
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, odataID string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			resource, err := fetch(odataID)
+			if err != nil || resource == nil {
+				return
+			}
+			matched[i] = p.Filter.Evaluate(resource)
+		}(i, odataID)
+	}
+	wg.Wait()
+
+	var result []interface{}
+	for i, member := range members {
+		if matched[i] {
+			result = append(result, member)
+		}
+	}
+	return result
+}
+
+// nextLink builds the link to the next page of the collection, retaining
+// all the other query parameters of the request
+func (p *Params) nextLink(path string, skip int) string {
+	values := url.Values{}
+	for key, val := range p.values {
+		values[key] = val
+	}
+	values.Set(Skip, strconv.Itoa(skip))
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var params []string
+	for _, key := range keys {
+		for _, val := range values[key] {
+			params = append(params, key+"="+url.QueryEscape(val))
+		}
+	}
+	return fmt.Sprintf("%s?%s", path, strings.Join(params, "&"))
+}
+
+// SelectProperties returns the resource with only the selected properties.
+// The @odata annotations and the properties passed in keep are always
+// retained. Nested properties are selected using '/', eg: Status/Health.
+func SelectProperties(resource map[string]interface{}, properties []string, keep ...string) map[string]interface{} {
+	result := make(map[string]interface{})
+	for key, val := range resource {
+		if strings.HasPrefix(key, "@odata.") {
+			result[key] = val
+		}
+	}
+	for _, key := range keep {
+		if val, exist := resource[key]; exist {
+			result[key] = val
+		}
+	}
+	for _, property := range properties {
+		selectPath(resource, result, strings.Split(property, "/"))
+	}
+	return result
+}
+
+// selectPath copies the property at the path from src to dst, creating the
+// parent objects in dst as required
+func selectPath(src, dst map[string]interface{}, path []string) {
+	val, exist := src[path[0]]
+	if !exist {
+		return
+	}
+	if len(path) == 1 {
+		dst[path[0]] = val
+		return
+	}
+	child, ok := val.(map[string]interface{})
+	if !ok {
+		return
+	}
+	dstChild, ok := dst[path[0]].(map[string]interface{})
+	if !ok {
+		dstChild = make(map[string]interface{})
+		dst[path[0]] = dstChild
+	}
+	selectPath(child, dstChild, path[1:])
+}
@@ -0,0 +1,228 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package query
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		wantStatusMsg string
+		wantTop       int
+		wantSkip      int
+		wantSelect    []string
+	}{
+		{name: "no query", query: "", wantTop: -1},
+		{name: "top and skip", query: "$top=5&$skip=10", wantTop: 5, wantSkip: 10},
+		{name: "select", query: "$select=Name,%20Status/Health", wantTop: -1, wantSelect: []string{"Name", "Status/Health"}},
+		{name: "non odata parameters are ignored", query: "only=true", wantTop: -1},
+		{name: "invalid top", query: "$top=ten", wantStatusMsg: response.QueryParameterValueFormatError},
+		{name: "negative skip", query: "$skip=-1", wantStatusMsg: response.QueryParameterOutOfRange},
+		{name: "invalid filter", query: "$filter=" + url.QueryEscape("Name eq"), wantStatusMsg: response.QueryParameterValueFormatError},
+		{name: "empty select", query: "$select=Name,,Id", wantStatusMsg: response.QueryParameterValueFormatError},
+		{name: "unsupported parameter", query: "$levels=2", wantStatusMsg: response.QueryNotSupported},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, _ := url.ParseQuery(tt.query)
+			p, err := Parse(values)
+			if tt.wantStatusMsg != "" {
+				if err == nil {
+					t.Fatalf("Parse() expected error %v", tt.wantStatusMsg)
+				}
+				if err.StatusMessage != tt.wantStatusMsg {
+					t.Errorf("Parse() StatusMessage = %v, want %v", err.StatusMessage, tt.wantStatusMsg)
+				}
+				if resp := err.Response(); resp.StatusCode != http.StatusBadRequest {
+					t.Errorf("Response() StatusCode = %v, want %v", resp.StatusCode, http.StatusBadRequest)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if p.Top != tt.wantTop || p.Skip != tt.wantSkip || !reflect.DeepEqual(p.Select, tt.wantSelect) {
+				t.Errorf("Parse() = top %v skip %v select %v, want top %v skip %v select %v",
+					p.Top, p.Skip, p.Select, tt.wantTop, tt.wantSkip, tt.wantSelect)
+			}
+			if p.IsEmpty() != (tt.query == "" || tt.query == "only=true") {
+				t.Errorf("IsEmpty() = %v for %q", p.IsEmpty(), tt.query)
+			}
+		})
+	}
+}
+
+// testCollection returns a collection of n members, the member i has
+// Index i and Status/Health OK for even i and Warning for odd i
+func testCollection(n int) (map[string]interface{}, MemberFetcher) {
+	members := []interface{}{}
+	resources := map[string]map[string]interface{}{}
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("/redfish/v1/Chassis/%d", i)
+		members = append(members, map[string]interface{}{"@odata.id": id})
+		health := "OK"
+		if i%2 == 1 {
+			health = "Warning"
+		}
+		resources[id] = map[string]interface{}{
+			"@odata.id": id,
+			"Index":     float64(i),
+			"Status":    map[string]interface{}{"Health": health},
+		}
+	}
+	collection := map[string]interface{}{
+		"@odata.id":           "/redfish/v1/Chassis",
+		"@odata.type":         "#ChassisCollection.ChassisCollection",
+		"Name":                "Chassis Collection",
+		"Description":         "Chassis Collection",
+		"Members":             members,
+		"Members@odata.count": n,
+	}
+	fetch := func(odataID string) (map[string]interface{}, error) {
+		if odataID == "/redfish/v1/Chassis/3" {
+			return nil, fmt.Errorf("not found")
+		}
+		return resources[odataID], nil
+	}
+	return collection, fetch
+}
+
+func memberIDs(collection map[string]interface{}) []string {
+	ids := []string{}
+	for _, m := range collection["Members"].([]interface{}) {
+		ids = append(ids, m.(map[string]interface{})["@odata.id"].(string))
+	}
+	return ids
+}
+
+func TestApplyToCollection(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		wantMembers  []string
+		wantCount    int
+		wantNextLink string
+	}{
+		{
+			name:        "no query",
+			query:       "",
+			wantMembers: []string{"/redfish/v1/Chassis/0", "/redfish/v1/Chassis/1", "/redfish/v1/Chassis/2", "/redfish/v1/Chassis/3", "/redfish/v1/Chassis/4", "/redfish/v1/Chassis/5"},
+			wantCount:   6,
+		},
+		{
+			name:         "top",
+			query:        "$top=2",
+			wantMembers:  []string{"/redfish/v1/Chassis/0", "/redfish/v1/Chassis/1"},
+			wantCount:    6,
+			wantNextLink: "/redfish/v1/Chassis?$skip=2&$top=2",
+		},
+		{
+			name:         "skip and top",
+			query:        "$skip=2&$top=2",
+			wantMembers:  []string{"/redfish/v1/Chassis/2", "/redfish/v1/Chassis/3"},
+			wantCount:    6,
+			wantNextLink: "/redfish/v1/Chassis?$skip=4&$top=2",
+		},
+		{
+			name:        "last page",
+			query:       "$skip=4&$top=2",
+			wantMembers: []string{"/redfish/v1/Chassis/4", "/redfish/v1/Chassis/5"},
+			wantCount:   6,
+		},
+		{
+			name:        "skip beyond members",
+			query:       "$skip=10",
+			wantMembers: []string{},
+			wantCount:   6,
+		},
+		{
+			name:        "filter skips members which can't be fetched",
+			query:       "$filter=" + url.QueryEscape("Status/Health eq 'Warning'"),
+			wantMembers: []string{"/redfish/v1/Chassis/1", "/redfish/v1/Chassis/5"},
+			wantCount:   2,
+		},
+		{
+			name:         "filter with pagination",
+			query:        "$filter=" + url.QueryEscape("Index ge 1") + "&$top=2",
+			wantMembers:  []string{"/redfish/v1/Chassis/1", "/redfish/v1/Chassis/2"},
+			wantCount:    4,
+			wantNextLink: "/redfish/v1/Chassis?$filter=Index+ge+1&$skip=2&$top=2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, _ := url.ParseQuery(tt.query)
+			p, err := Parse(values)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			collection, fetch := testCollection(6)
+			got := p.ApplyToCollection(collection, "/redfish/v1/Chassis", fetch)
+			if ids := memberIDs(got); !reflect.DeepEqual(ids, tt.wantMembers) {
+				t.Errorf("Members = %v, want %v", ids, tt.wantMembers)
+			}
+			if got["Members@odata.count"] != tt.wantCount {
+				t.Errorf("Members@odata.count = %v, want %v", got["Members@odata.count"], tt.wantCount)
+			}
+			nextLink, _ := got["Members@odata.nextLink"].(string)
+			if nextLink != tt.wantNextLink {
+				t.Errorf("Members@odata.nextLink = %v, want %v", nextLink, tt.wantNextLink)
+			}
+		})
+	}
+}
+
+func TestApplyToCollectionSelect(t *testing.T) {
+	values, _ := url.ParseQuery("$select=Name&$top=1")
+	p, _ := Parse(values)
+	collection, fetch := testCollection(3)
+	got := p.ApplyToCollection(collection, "/redfish/v1/Chassis", fetch)
+	for _, key := range []string{"@odata.id", "@odata.type", "Name", "Members", "Members@odata.count", "Members@odata.nextLink"} {
+		if _, exist := got[key]; !exist {
+			t.Errorf("property %v is missing in %v", key, got)
+		}
+	}
+	if _, exist := got["Description"]; exist {
+		t.Errorf("property Description is not selected, but present in %v", got)
+	}
+}
+
+func TestSelectProperties(t *testing.T) {
+	resource := map[string]interface{}{
+		"@odata.id": "/redfish/v1/Systems/1",
+		"Id":        "1",
+		"Name":      "System",
+		"Status":    map[string]interface{}{"Health": "OK", "State": "Enabled"},
+		"Boot":      map[string]interface{}{"BootSourceOverrideTarget": "Pxe"},
+	}
+	got := SelectProperties(resource, []string{"Name", "Status/Health", "Unknown", "Id/Value"})
+	want := map[string]interface{}{
+		"@odata.id": "/redfish/v1/Systems/1",
+		"Name":      "System",
+		"Status":    map[string]interface{}{"Health": "OK"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SelectProperties() = %v, want %v", got, want)
+	}
+}
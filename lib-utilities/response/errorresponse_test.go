@@ -110,6 +110,34 @@ func TestCreateErrorResponse(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: PasswordChangeRequired,
+			args: Args{
+				Code:    PasswordChangeRequired,
+				Message: PasswordChangeRequired,
+				ErrorArgs: []ErrArgs{
+					ErrArgs{
+						StatusMessage: PasswordChangeRequired,
+						ErrorMessage:  errMsg,
+					},
+				},
+			},
+			want: CommonError{
+				Error: ErrorClass{
+					Code:    PasswordChangeRequired,
+					Message: PasswordChangeRequired,
+					MessageExtendedInfo: []Msg{
+						Msg{
+							OdataType:  ErrorMessageOdataType,
+							MessageID:  PasswordChangeRequired,
+							Message:    "The password provided for this account must be changed before access is granted." + errMsg,
+							Severity:   "Critical",
+							Resolution: "Change the password for this account using a PATCH to the Password property of the account.",
+						},
+					},
+				},
+			},
+		},
 		{
 			name: InternalError,
 			args: Args{
@@ -490,6 +518,66 @@ func TestCreateErrorResponse(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: QueryParameterValueFormatError,
+			args: Args{
+				Code:    QueryParameterValueFormatError,
+				Message: QueryParameterValueFormatError,
+				ErrorArgs: []ErrArgs{
+					ErrArgs{
+						StatusMessage: QueryParameterValueFormatError,
+						ErrorMessage:  errMsg,
+						MessageArgs:   []interface{}{"abc", "$top"},
+					},
+				},
+			},
+			want: CommonError{
+				Error: ErrorClass{
+					Code:    QueryParameterValueFormatError,
+					Message: QueryParameterValueFormatError,
+					MessageExtendedInfo: []Msg{
+						Msg{
+							OdataType:   ErrorMessageOdataType,
+							MessageID:   QueryParameterValueFormatError,
+							Message:     "The value abc for the parameter $top is of a different format than the parameter can accept. " + errMsg,
+							Severity:    "Warning",
+							MessageArgs: []interface{}{"abc", "$top"},
+							Resolution:  "Correct the value for the query parameter in the request and resubmit the request if the operation failed.",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: QueryParameterOutOfRange,
+			args: Args{
+				Code:    QueryParameterOutOfRange,
+				Message: QueryParameterOutOfRange,
+				ErrorArgs: []ErrArgs{
+					ErrArgs{
+						StatusMessage: QueryParameterOutOfRange,
+						ErrorMessage:  errMsg,
+						MessageArgs:   []interface{}{"-1", "$skip", "0 or greater"},
+					},
+				},
+			},
+			want: CommonError{
+				Error: ErrorClass{
+					Code:    QueryParameterOutOfRange,
+					Message: QueryParameterOutOfRange,
+					MessageExtendedInfo: []Msg{
+						Msg{
+							OdataType:   ErrorMessageOdataType,
+							MessageID:   QueryParameterOutOfRange,
+							Message:     "The value -1 for the query parameter $skip is out of range 0 or greater. " + errMsg,
+							Severity:    "Warning",
+							MessageArgs: []interface{}{"-1", "$skip", "0 or greater"},
+							Resolution:  "Reduce the value for the query parameter to a value that is within range, such as a start or count value that is within bounds of the number of resources in a collection or a page that is within the range of valid pages.",
+						},
+					},
+				},
+			},
+		},
 		{
 			name: ActionParameterNotSupported,
 			args: Args{
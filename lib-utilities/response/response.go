@@ -63,6 +63,10 @@ const (
 	QueryCombinationInvalid = BaseVersion + "QueryCombinationInvalid"
 	// QueryNotSupported defines the status message at the time of not supported query
 	QueryNotSupported = BaseVersion + "QueryNotSupported"
+	// QueryParameterValueFormatError defines the status message at the time of query parameter value having a invalid format
+	QueryParameterValueFormatError = BaseVersion + "QueryParameterValueFormatError"
+	// QueryParameterOutOfRange defines the status message at the time of query parameter value being out of the supported range
+	QueryParameterOutOfRange = BaseVersion + "QueryParameterOutOfRange"
 	// ResourceRemoved is the message for successful removal of resource
 	ResourceRemoved = "ResourceEvent.1.2.1.ResourceRemoved"
 	// ResourceCreated is the message for successful creation of resource
@@ -85,6 +89,8 @@ const (
 	RateLimitExceeded = "RateLimitExceeded"
 	// SessionLimitExceeded Indicates that a session establishment has been requested but the operation failed due to the number of simultaneous sessions exceeding the limit of the implementation.
 	SessionLimitExceeded = BaseVersion + "SessionLimitExceeded"
+	// PasswordChangeRequired indicates that the password of the account must be changed before accessing the service
+	PasswordChangeRequired = BaseVersion + "PasswordChangeRequired"
 	// InvalidURL defines the status message at the time of URL Not Found
 	InvalidURI = BaseVersion + "InvalidURI"
 )
@@ -40,5 +40,10 @@ func (r *Response) CreateGenericResponse(statusMessage string) {
 	case TaskStarted:
 		r.NumberOfArgs = len(r.MessageArgs)
 		r.Message = fmt.Sprintf("The task with id %v has started.", r.MessageArgs[0])
+	case PasswordChangeRequired:
+		r.NumberOfArgs = len(r.MessageArgs)
+		r.Severity = "Critical"
+		r.Message = fmt.Sprintf("The password provided for this account must be changed before access is granted.  PATCH the Password property for this account located at the target URI '%v' to complete this process.", r.MessageArgs[0])
+		r.Resolution = "Change the password for this account using a PATCH to the Password property at the URI provided."
 	}
 }
@@ -75,6 +75,13 @@ func TestCreateGenericResponse(t *testing.T) {
 			messageargs: []string{"1234"},
 			noargs:      1,
 		},
+		{
+			name:        PasswordChangeRequired,
+			code:        PasswordChangeRequired,
+			message:     "The password provided for this account must be changed before access is granted.  PATCH the Password property for this account located at the target URI '/redfish/v1/AccountService/Accounts/admin' to complete this process.",
+			messageargs: []string{"/redfish/v1/AccountService/Accounts/admin"},
+			noargs:      1,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -60,9 +60,10 @@ func GetResource(ctx iris.Context) {
 	}
 
 	device := &rfputilities.RedfishDevice{
-		Host:     deviceDetails.Host,
-		Username: deviceDetails.Username,
-		Password: string(deviceDetails.Password),
+		Host:        deviceDetails.Host,
+		Username:    deviceDetails.Username,
+		Password:    string(deviceDetails.Password),
+		IfNoneMatch: ctx.GetHeader("If-None-Match"),
 	}
 	//plainText, err := rfputilities.GetPlainText(device.Password)
 	//device.Password = plainText
@@ -102,6 +103,11 @@ func GetResource(ctx iris.Context) {
 		ctx.WriteString("Authtication with the device failed")
 		return
 	}
+	if resp.StatusCode == http.StatusNotModified {
+		// the resource is unchanged since the ETag given in If-None-Match
+		ctx.StatusCode(http.StatusNotModified)
+		return
+	}
 	if resp.StatusCode >= 300 {
 		log.Error("Could not retrieve generic resource for" + device.Host + ": \n" + string(body) + ":\n" + uri)
 
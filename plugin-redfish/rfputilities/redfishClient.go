@@ -46,6 +46,8 @@ type RedfishDevice struct {
 	ComputerSystems []*Identifier
 	PostBody        []byte `json:"PostBody,omitempty"`
 	Location        string `json:"Location"`
+	// IfNoneMatch is the ETag sent in the If-None-Match header of the GET of the resource
+	IfNoneMatch string `json:"-"`
 }
 
 //Identifier struct definition
@@ -212,6 +214,9 @@ func (client *RedfishClient) GetWithBasicAuth(device *RedfishDevice, requestURI
 	Basicauth := "Basic " + base64.StdEncoding.EncodeToString([]byte(auth))
 	req.Header.Add("Authorization", Basicauth)
 	req.Header.Add("Content-Type", "application/json")
+	if device.IfNoneMatch != "" {
+		req.Header.Set("If-None-Match", device.IfNoneMatch)
+	}
 	req.Close = true
 
 	lutilconf.TLSConfMutex.RLock()
@@ -20,6 +20,7 @@ import (
 	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
 	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+	"github.com/ODIM-Project/ODIM/svc-account-session/auth"
 )
 
 const (
@@ -37,6 +38,8 @@ type ExternalInterface struct {
 	GetUserDetails     func(string) (asmodel.User, *errors.Error)
 	GetRoleDetailsByID func(string) (asmodel.Role, *errors.Error)
 	UpdateUserDetails  func(asmodel.User, asmodel.User) *errors.Error
+	IsAccountLocked    func(string) (bool, *errors.Error)
+	UnlockAccount      func(string) *errors.Error
 }
 
 // GetExternalInterface retrieves all the external connections account package functions uses
@@ -46,6 +49,8 @@ func GetExternalInterface() *ExternalInterface {
 		GetUserDetails:     asmodel.GetUserDetails,
 		GetRoleDetailsByID: asmodel.GetRoleDetailsByID,
 		UpdateUserDetails:  asmodel.UpdateUserDetails,
+		IsAccountLocked:    auth.IsAccountLocked,
+		UnlockAccount:      asmodel.DeleteAccountLockout,
 	}
 }
 func TrackConfigFileChanges(errChan chan error) {
@@ -47,9 +47,22 @@ func getMockExternalInterface() *ExternalInterface {
 		GetUserDetails:     mockGetUserDetails,
 		GetRoleDetailsByID: mockGetRoleDetailsByID,
 		UpdateUserDetails:  mockUpdateUserDetails,
+		IsAccountLocked:    mockIsAccountLocked,
+		UnlockAccount:      mockUnlockAccount,
 	}
 }
 
+func mockIsAccountLocked(userName string) (bool, *errors.Error) {
+	return userName == "lockedUser", nil
+}
+
+func mockUnlockAccount(userName string) *errors.Error {
+	if userName != "lockedUser" {
+		return errors.PackError(errors.DBKeyNotFound, "error while trying to delete the account lockout details: no data with the with key ", userName, " found")
+	}
+	return nil
+}
+
 func mockCreateUser(user asmodel.User) *errors.Error {
 	if user.UserName == "existingUser" {
 		return errors.PackError(errors.DBKeyAlreadyExist, "error: data with key existingUser already exists")
@@ -72,7 +85,7 @@ func mockGetUserDetails(userName string) (asmodel.User, *errors.Error) {
 		user.RoleID = common.RoleAdmin
 	} else if userName == "testUser3" {
 		user.RoleID = "PrivilegeLogin"
-	} else if userName == "operatorUser" {
+	} else if userName == "operatorUser" || userName == "lockedUser" {
 		user.RoleID = common.RoleMonitor
 	} else {
 		return user, errors.PackError(errors.DBKeyNotFound, "error while trying to get user: ", fmt.Sprintf("no data with the with key %v found", userName))
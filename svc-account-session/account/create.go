@@ -20,14 +20,12 @@ package account
 // ---------------------------------------------------------------------------------------
 import (
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"regexp"
 	"strings"
-
-	"golang.org/x/crypto/sha3"
+	"time"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
@@ -153,12 +151,10 @@ func (e *ExternalInterface) Create(ctx context.Context, req *accountproto.Create
 		return resp, err
 
 	}
-	hash := sha3.New512()
-	hash.Write([]byte(user.Password))
-	hashSum := hash.Sum(nil)
-	hashedPassword := base64.URLEncoding.EncodeToString(hashSum)
-	user.Password = hashedPassword
+	user.Password = auth.HashPassword(user.Password)
 	user.AccountTypes = []string{"Redfish"}
+	user.PasswordChangedTime = time.Now()
+	user.PasswordChangeRequired = config.Data.AuthConf.PasswordRules.ForcePasswordChangeOnFirstLogin
 	if cerr := e.CreateUser(user); cerr != nil {
 		errorMessage := errorLogPrefix + cerr.Error()
 		if errors.DBKeyAlreadyExist == cerr.ErrNo() {
@@ -193,10 +189,12 @@ func (e *ExternalInterface) Create(ctx context.Context, req *accountproto.Create
 
 	commonResponse.CreateGenericResponse(resp.StatusMessage)
 	resp.Body = asresponse.Account{
-		Response:     commonResponse,
-		UserName:     user.UserName,
-		RoleID:       user.RoleID,
-		AccountTypes: user.AccountTypes,
+		Response:               commonResponse,
+		UserName:               user.UserName,
+		RoleID:                 user.RoleID,
+		AccountTypes:           user.AccountTypes,
+		PasswordChangeRequired: auth.IsPasswordChangeRequired(&user, time.Now()),
+		PasswordExpiration:     getPasswordExpiration(&user),
 		Links: asresponse.Links{
 			Role: asresponse.Role{
 				OdataID: "/redfish/v1/AccountService/Roles/" + user.RoleID,
@@ -233,21 +231,44 @@ func validatePassword(userName, password string) error {
 	if len(password) > config.Data.AuthConf.PasswordRules.MaxPasswordLength {
 		return fmt.Errorf("error: invalid password, password length is greater than the maximum length")
 	}
-	matched, _ := regexp.Match("[A-Z]+", []byte(password))
-	if !matched {
-		return fmt.Errorf("error: invalid password, password should contain minimum One Upper case, One Lower case, One Number and One Special character")
+	classes := config.Data.AuthConf.PasswordRules.RequiredCharacterClasses
+	for _, class := range classes {
+		var pattern string
+		switch class {
+		case config.PasswordCharacterClassUpperCase:
+			pattern = "[A-Z]+"
+		case config.PasswordCharacterClassLowerCase:
+			pattern = "[a-z]+"
+		case config.PasswordCharacterClassDigit:
+			pattern = "[0-9]+"
+		case config.PasswordCharacterClassSpecialCharacter:
+			pattern = "[" + config.Data.AuthConf.PasswordRules.AllowedSpecialCharcters + "]+"
+		default:
+			continue
+		}
+		matched, _ := regexp.Match(pattern, []byte(password))
+		if !matched {
+			return fmt.Errorf("error: invalid password, password should contain minimum %s", describeCharacterClasses(classes))
+		}
 	}
-	matched, _ = regexp.Match("[a-z]+", []byte(password))
-	if !matched {
-		return fmt.Errorf("error: invalid password, password should contain minimum One Upper case, One Lower case, One Number and One Special character")
+	return nil
+}
+
+// describeCharacterClasses returns the description of the required classes of characters, like
+// "One Upper case, One Lower case, One Number and One Special character"
+func describeCharacterClasses(classes []string) string {
+	descriptions := map[string]string{
+		config.PasswordCharacterClassUpperCase:        "One Upper case",
+		config.PasswordCharacterClassLowerCase:        "One Lower case",
+		config.PasswordCharacterClassDigit:            "One Number",
+		config.PasswordCharacterClassSpecialCharacter: "One Special character",
 	}
-	matched, _ = regexp.Match("[0-9]+", []byte(password))
-	if !matched {
-		return fmt.Errorf("error: invalid password, password should contain minimum One Upper case, One Lower case, One Number and One Special character")
+	var required []string
+	for _, class := range classes {
+		required = append(required, descriptions[class])
 	}
-	matched, _ = regexp.Match("["+config.Data.AuthConf.PasswordRules.AllowedSpecialCharcters+"]+", []byte(password))
-	if !matched {
-		return fmt.Errorf("error: invalid password, password should contain minimum One Upper case, One Lower case, One Number and One Special character")
+	if len(required) <= 1 {
+		return strings.Join(required, "")
 	}
-	return nil
+	return strings.Join(required[:len(required)-1], ", ") + " and " + required[len(required)-1]
 }
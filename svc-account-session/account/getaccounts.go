@@ -22,6 +22,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
@@ -178,6 +179,13 @@ func GetAccount(ctx context.Context, session *asmodel.Session, accountID string)
 		l.LogWithFields(ctx).Error(errorMessage)
 		return resp
 	}
+	locked, err := auth.IsAccountLocked(accountID)
+	if err != nil {
+		errorMessage := errLogPrefix + err.Error()
+		resp.CreateInternalErrorResponse(errorMessage)
+		l.LogWithFields(ctx).Error(errorMessage)
+		return resp
+	}
 
 	resp.StatusCode = http.StatusOK
 	resp.StatusMessage = response.Success
@@ -191,10 +199,13 @@ func GetAccount(ctx context.Context, session *asmodel.Session, accountID string)
 	commonResponse.MessageID = ""
 	commonResponse.Severity = ""
 	resp.Body = asresponse.Account{
-		Response:     commonResponse,
-		UserName:     user.UserName,
-		RoleID:       user.RoleID,
-		AccountTypes: user.AccountTypes,
+		Response:               commonResponse,
+		UserName:               user.UserName,
+		RoleID:                 user.RoleID,
+		AccountTypes:           user.AccountTypes,
+		Locked:                 locked,
+		PasswordChangeRequired: auth.IsPasswordChangeRequired(&user, time.Now()),
+		PasswordExpiration:     getPasswordExpiration(&user),
 		Links: asresponse.Links{
 			Role: asresponse.Role{
 				OdataID: "/redfish/v1/AccountService/Roles/" + user.RoleID,
@@ -242,23 +253,122 @@ func GetAccountService(ctx context.Context) response.RPC {
 	commonResponse.Message = ""
 	commonResponse.MessageID = ""
 	commonResponse.Severity = ""
-	resp.Body = asresponse.AccountService{
+	accountService := asresponse.AccountService{
 		Response: commonResponse,
 		//TODO: Yet to implement AccountService state and health
 		Status: asresponse.Status{
 			State:  serviceState,
 			Health: "OK",
 		},
-		ServiceEnabled:    isServiceEnabled,
-		MinPasswordLength: config.Data.AuthConf.PasswordRules.MinPasswordLength,
+		ServiceEnabled:                    isServiceEnabled,
+		MinPasswordLength:                 config.Data.AuthConf.PasswordRules.MinPasswordLength,
+		MaxPasswordLength:                 config.Data.AuthConf.PasswordRules.MaxPasswordLength,
+		PasswordExpirationDays:            config.Data.AuthConf.PasswordRules.MaxPasswordAgeInDays,
+		AccountLockoutThreshold:           config.Data.AuthConf.AccountLockoutThreshold,
+		AccountLockoutDuration:            config.Data.AuthConf.AccountLockoutDuration,
+		AccountLockoutCounterResetAfter:   config.Data.AuthConf.AccountLockoutCounterResetAfter,
+		AccountLockoutCounterResetEnabled: true,
 		Accounts: asresponse.Accounts{
 			OdataID: "/redfish/v1/AccountService/Accounts",
 		},
 		Roles: asresponse.Accounts{
 			OdataID: "/redfish/v1/AccountService/Roles",
 		},
+		Oem: &asresponse.AccountServiceOem{
+			Odim: getPasswordPolicy(config.Data.AuthConf.PasswordRules),
+		},
+	}
+	if conf := config.Data.AuthConf.LDAP; conf != nil {
+		accountService.LDAP = &asresponse.LDAP{
+			ExternalAccountProvider: getExternalAccountProvider(auth.LDAPService, conf),
+		}
+	}
+	if conf := config.Data.AuthConf.ActiveDirectory; conf != nil {
+		accountService.ActiveDirectory = &asresponse.ActiveDirectory{
+			ExternalAccountProvider: getExternalAccountProvider(auth.ActiveDirectoryService, conf),
+		}
+	}
+	if conf := config.Data.AuthConf.OAuth2; conf != nil {
+		accountService.OAuth2 = &asresponse.OAuth2{
+			ExternalAccountProvider: getOAuth2Provider(conf),
+		}
+	}
+	if conf := config.Data.AuthConf.ClientCertificate; conf != nil {
+		accountService.MultiFactorAuth = &asresponse.MultiFactorAuth{
+			ClientCertificate: &asresponse.ClientCertificate{
+				Enabled:                         conf.Enabled,
+				RespondToUnauthenticatedClients: conf.RespondToUnauthenticatedClients == nil || *conf.RespondToUnauthenticatedClients,
+				CertificateMappingAttribute:     conf.CertificateMappingAttribute,
+			},
+		}
+	}
+	if len(auth.GetAccountProviders()) > 0 {
+		// the local accounts are checked before the external account providers
+		accountService.LocalAccountAuth = "LocalFirst"
 	}
+	resp.Body = accountService
 
 	return resp
 
 }
+
+// getPasswordPolicy returns the password rules which are not reported through the standard AccountService properties
+func getPasswordPolicy(rules *config.PasswordRules) *asresponse.PasswordPolicy {
+	return &asresponse.PasswordPolicy{
+		RequiredCharacterClasses:        rules.RequiredCharacterClasses,
+		PasswordHistoryCount:            rules.PasswordHistoryCount,
+		ForcePasswordChangeOnFirstLogin: rules.ForcePasswordChangeOnFirstLogin,
+	}
+}
+
+// getExternalAccountProvider returns the configuration of the external account provider, without the password
+func getExternalAccountProvider(accountProviderType string, conf *config.ExternalAccountProvider) asresponse.ExternalAccountProvider {
+	provider := asresponse.ExternalAccountProvider{
+		AccountProviderType: accountProviderType,
+		ServiceEnabled:      conf.ServiceEnabled,
+		ServiceAddresses:    conf.ServiceAddresses,
+		Authentication: &asresponse.Authentication{
+			AuthenticationType: "UsernameAndPassword",
+			Username:           conf.Username,
+		},
+		LDAPService: &asresponse.LDAPService{
+			SearchSettings: asresponse.LDAPSearchSettings{
+				BaseDistinguishedNames: conf.BaseDistinguishedNames,
+				UsernameAttribute:      conf.UsernameAttribute,
+				GroupsAttribute:        conf.GroupsAttribute,
+			},
+		},
+	}
+	provider.RemoteRoleMapping = getRemoteRoleMapping(conf.RemoteRoleMapping)
+	return provider
+}
+
+// getOAuth2Provider returns the configuration of the OAuth 2.0 authorization server issuing the bearer tokens
+func getOAuth2Provider(conf *config.OAuth2) asresponse.ExternalAccountProvider {
+	provider := asresponse.ExternalAccountProvider{
+		AccountProviderType: "OAuth2",
+		ServiceEnabled:      conf.ServiceEnabled,
+		OAuth2Service: &asresponse.OAuth2Service{
+			Mode:     conf.Mode,
+			Issuer:   conf.Issuer,
+			Audience: conf.Audience,
+		},
+		RemoteRoleMapping: getRemoteRoleMapping(conf.RemoteRoleMapping),
+	}
+	// the signing keys are public keys, they are shown as configured in the Offline mode
+	if conf.Mode == config.OAuth2ModeOffline {
+		provider.OAuth2Service.OAuthServiceSigningKeys = conf.OAuthServiceSigningKeys
+	}
+	return provider
+}
+
+func getRemoteRoleMapping(mapping []config.RemoteRoleMapping) []asresponse.RemoteRoleMapping {
+	var remoteRoleMapping []asresponse.RemoteRoleMapping
+	for _, roleMapping := range mapping {
+		remoteRoleMapping = append(remoteRoleMapping, asresponse.RemoteRoleMapping{
+			LocalRole:   roleMapping.LocalRole,
+			RemoteGroup: roleMapping.RemoteGroup,
+		})
+	}
+	return remoteRoleMapping
+}
@@ -332,14 +332,24 @@ func TestGetAccountService(t *testing.T) {
 						State:  "Enabled",
 						Health: "OK",
 					},
-					ServiceEnabled:    true,
-					MinPasswordLength: config.Data.AuthConf.PasswordRules.MinPasswordLength,
+					ServiceEnabled:                    true,
+					MinPasswordLength:                 config.Data.AuthConf.PasswordRules.MinPasswordLength,
+					MaxPasswordLength:                 config.Data.AuthConf.PasswordRules.MaxPasswordLength,
+					AccountLockoutThreshold:           config.Data.AuthConf.AccountLockoutThreshold,
+					AccountLockoutDuration:            config.Data.AuthConf.AccountLockoutDuration,
+					AccountLockoutCounterResetAfter:   config.Data.AuthConf.AccountLockoutCounterResetAfter,
+					AccountLockoutCounterResetEnabled: true,
 					Accounts: asresponse.Accounts{
 						OdataID: "/redfish/v1/AccountService/Accounts",
 					},
 					Roles: asresponse.Accounts{
 						OdataID: "/redfish/v1/AccountService/Roles",
 					},
+					Oem: &asresponse.AccountServiceOem{
+						Odim: &asresponse.PasswordPolicy{
+							RequiredCharacterClasses: config.DefaultRequiredCharacterClasses,
+						},
+					},
 				},
 			},
 		},
@@ -357,14 +367,24 @@ func TestGetAccountService(t *testing.T) {
 						State:  "Disabled",
 						Health: "OK",
 					},
-					ServiceEnabled:    false,
-					MinPasswordLength: config.Data.AuthConf.PasswordRules.MinPasswordLength,
+					ServiceEnabled:                    false,
+					MinPasswordLength:                 config.Data.AuthConf.PasswordRules.MinPasswordLength,
+					MaxPasswordLength:                 config.Data.AuthConf.PasswordRules.MaxPasswordLength,
+					AccountLockoutThreshold:           config.Data.AuthConf.AccountLockoutThreshold,
+					AccountLockoutDuration:            config.Data.AuthConf.AccountLockoutDuration,
+					AccountLockoutCounterResetAfter:   config.Data.AuthConf.AccountLockoutCounterResetAfter,
+					AccountLockoutCounterResetEnabled: true,
 					Accounts: asresponse.Accounts{
 						OdataID: "/redfish/v1/AccountService/Accounts",
 					},
 					Roles: asresponse.Accounts{
 						OdataID: "/redfish/v1/AccountService/Roles",
 					},
+					Oem: &asresponse.AccountServiceOem{
+						Odim: &asresponse.PasswordPolicy{
+							RequiredCharacterClasses: config.DefaultRequiredCharacterClasses,
+						},
+					},
 				},
 			},
 		},
@@ -380,3 +400,86 @@ func TestGetAccountService(t *testing.T) {
 		config.Data.EnabledServices = []string{"XXXX"}
 	}
 }
+
+func TestGetAccountServiceWithLDAP(t *testing.T) {
+	common.SetUpMockConfig()
+	config.Data.AuthConf.LDAP = &config.ExternalAccountProvider{
+		ServiceEnabled:         true,
+		ServiceAddresses:       []string{"ldaps://ldap.odim.local:636"},
+		Username:               "cn=odim,ou=services,dc=odim,dc=local",
+		Password:               []byte("odimpassword"),
+		BaseDistinguishedNames: []string{"ou=people,dc=odim,dc=local"},
+		UsernameAttribute:      "uid",
+		GroupsAttribute:        "memberOf",
+		RemoteRoleMapping: []config.RemoteRoleMapping{
+			{RemoteGroup: "odim-admins", LocalRole: common.RoleAdmin},
+		},
+	}
+	defer func() {
+		config.Data.AuthConf.LDAP = nil
+	}()
+	want := &asresponse.LDAP{
+		ExternalAccountProvider: asresponse.ExternalAccountProvider{
+			AccountProviderType: "LDAPService",
+			ServiceEnabled:      true,
+			ServiceAddresses:    []string{"ldaps://ldap.odim.local:636"},
+			Authentication: &asresponse.Authentication{
+				AuthenticationType: "UsernameAndPassword",
+				Username:           "cn=odim,ou=services,dc=odim,dc=local",
+			},
+			LDAPService: &asresponse.LDAPService{
+				SearchSettings: asresponse.LDAPSearchSettings{
+					BaseDistinguishedNames: []string{"ou=people,dc=odim,dc=local"},
+					UsernameAttribute:      "uid",
+					GroupsAttribute:        "memberOf",
+				},
+			},
+			RemoteRoleMapping: []asresponse.RemoteRoleMapping{
+				{LocalRole: common.RoleAdmin, RemoteGroup: "odim-admins"},
+			},
+		},
+	}
+	got := GetAccountService(context.TODO()).Body.(asresponse.AccountService)
+	if !reflect.DeepEqual(got.LDAP, want) {
+		t.Errorf("GetAccountService() LDAP = %v, want %v", got.LDAP, want)
+	}
+	if got.LocalAccountAuth != "LocalFirst" {
+		t.Errorf("GetAccountService() LocalAccountAuth = %v, want LocalFirst", got.LocalAccountAuth)
+	}
+}
+
+func TestGetAccountServiceWithOAuth2(t *testing.T) {
+	common.SetUpMockConfig()
+	config.Data.AuthConf.OAuth2 = &config.OAuth2{
+		ServiceEnabled:          true,
+		Mode:                    config.OAuth2ModeOffline,
+		Issuer:                  "https://idp.odim.local",
+		Audience:                []string{"odimra"},
+		OAuthServiceSigningKeys: "eyJrZXlzIjpbXX0=",
+		RemoteRoleMapping: []config.RemoteRoleMapping{
+			{RemoteGroup: "odim-admins", LocalRole: common.RoleAdmin},
+		},
+	}
+	defer func() {
+		config.Data.AuthConf.OAuth2 = nil
+	}()
+	want := &asresponse.OAuth2{
+		ExternalAccountProvider: asresponse.ExternalAccountProvider{
+			AccountProviderType: "OAuth2",
+			ServiceEnabled:      true,
+			OAuth2Service: &asresponse.OAuth2Service{
+				Mode:                    config.OAuth2ModeOffline,
+				Issuer:                  "https://idp.odim.local",
+				Audience:                []string{"odimra"},
+				OAuthServiceSigningKeys: "eyJrZXlzIjpbXX0=",
+			},
+			RemoteRoleMapping: []asresponse.RemoteRoleMapping{
+				{LocalRole: common.RoleAdmin, RemoteGroup: "odim-admins"},
+			},
+		},
+	}
+	got := GetAccountService(context.TODO()).Body.(asresponse.AccountService)
+	if !reflect.DeepEqual(got.OAuth2, want) {
+		t.Errorf("GetAccountService() OAuth2 = %v, want %v", got.OAuth2, want)
+	}
+}
@@ -0,0 +1,67 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package account
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+	"github.com/ODIM-Project/ODIM/svc-account-session/auth"
+)
+
+// checkPasswordHistory checks that the hashed password is not one of the last PasswordHistoryCount
+// passwords of the user, which are the current password and the retained previous passwords
+func checkPasswordHistory(user asmodel.User, hashedPassword string) error {
+	count := config.Data.AuthConf.PasswordRules.PasswordHistoryCount
+	if count <= 0 {
+		return nil
+	}
+	history := append([]string{user.Password}, user.PasswordHistory...)
+	if len(history) > count {
+		history = history[:count]
+	}
+	for _, password := range history {
+		if password == hashedPassword {
+			return fmt.Errorf("error: invalid password, password is one of the last %d passwords of the user", count)
+		}
+	}
+	return nil
+}
+
+// updatePasswordHistory returns the previous passwords to be retained when the password of the user is changed,
+// the new password and the retained passwords together are the last PasswordHistoryCount passwords
+func updatePasswordHistory(user asmodel.User) []string {
+	count := config.Data.AuthConf.PasswordRules.PasswordHistoryCount - 1
+	if count <= 0 {
+		return nil
+	}
+	history := append([]string{user.Password}, user.PasswordHistory...)
+	if len(history) > count {
+		history = history[:count]
+	}
+	return history
+}
+
+// getPasswordExpiration returns the expiration time of the password of the user in the
+// format of the PasswordExpiration property, or an empty string if the password does not expire
+func getPasswordExpiration(user *asmodel.User) string {
+	expiration := auth.PasswordExpiration(user)
+	if expiration.IsZero() {
+		return ""
+	}
+	return expiration.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,127 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package account
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+	"github.com/ODIM-Project/ODIM/svc-account-session/auth"
+)
+
+func TestPasswordHistory(t *testing.T) {
+	config.SetUpMockConfig(t)
+	user := asmodel.User{
+		UserName:        "testUser1",
+		Password:        auth.HashPassword("Password@123"),
+		PasswordHistory: []string{auth.HashPassword("Password@122"), auth.HashPassword("Password@121")},
+	}
+	tests := []struct {
+		name         string
+		historyCount int
+		password     string
+		wantErr      bool
+		wantHistory  []string
+	}{
+		{
+			name:         "password history is not checked",
+			historyCount: 0,
+			password:     "Password@123",
+		},
+		{
+			name:         "current password is reused",
+			historyCount: 1,
+			password:     "Password@123",
+			wantErr:      true,
+		},
+		{
+			name:         "previous password is reused",
+			historyCount: 3,
+			password:     "Password@121",
+			wantErr:      true,
+			wantHistory:  []string{user.Password, user.PasswordHistory[0]},
+		},
+		{
+			name:         "password older than the history is reused",
+			historyCount: 2,
+			password:     "Password@121",
+			wantHistory:  []string{user.Password},
+		},
+		{
+			name:         "new password",
+			historyCount: 5,
+			password:     "Password@124",
+			wantHistory:  []string{user.Password, user.PasswordHistory[0], user.PasswordHistory[1]},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.Data.AuthConf.PasswordRules.PasswordHistoryCount = tt.historyCount
+			if err := checkPasswordHistory(user, auth.HashPassword(tt.password)); (err != nil) != tt.wantErr {
+				t.Errorf("checkPasswordHistory() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := updatePasswordHistory(user); !reflect.DeepEqual(got, tt.wantHistory) {
+				t.Errorf("updatePasswordHistory() = %v, want %v", got, tt.wantHistory)
+			}
+		})
+	}
+}
+
+func TestValidatePasswordCharacterClasses(t *testing.T) {
+	config.SetUpMockConfig(t)
+	tests := []struct {
+		name     string
+		classes  []string
+		password string
+		wantErr  string
+	}{
+		{
+			name:     "all classes required",
+			classes:  config.DefaultRequiredCharacterClasses,
+			password: "password@1234",
+			wantErr:  "error: invalid password, password should contain minimum One Upper case, One Lower case, One Number and One Special character",
+		},
+		{
+			name:     "upper case and digit required",
+			classes:  []string{config.PasswordCharacterClassUpperCase, config.PasswordCharacterClassDigit},
+			password: "password@1234",
+			wantErr:  "error: invalid password, password should contain minimum One Upper case and One Number",
+		},
+		{
+			name:     "required classes present",
+			classes:  []string{config.PasswordCharacterClassLowerCase, config.PasswordCharacterClassDigit},
+			password: "password1234",
+		},
+		{
+			name:     "no class required",
+			classes:  []string{},
+			password: "passwordpassword",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.Data.AuthConf.PasswordRules.RequiredCharacterClasses = tt.classes
+			err := validatePassword("testUser1", tt.password)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("validatePassword() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("validatePassword() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -20,12 +20,13 @@ package account
 // ---------------------------------------------------------------------------------------
 import (
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
 	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
 	accountproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/account"
@@ -33,7 +34,6 @@ import (
 	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
 	"github.com/ODIM-Project/ODIM/svc-account-session/asresponse"
 	"github.com/ODIM-Project/ODIM/svc-account-session/auth"
-	"golang.org/x/crypto/sha3"
 )
 
 // Update defines the updation of the account details. Every account details can be
@@ -212,6 +212,48 @@ func (e *ExternalInterface) Update(ctx context.Context, req *accountproto.Update
 		}
 	}
 
+	if updateAccount.Locked != nil {
+		// the accounts are locked only after the failed login attempts, the administrators can unlock them
+		if *updateAccount.Locked {
+			errorMessage := errorLogPrefix + "Locked can only be set to false to unlock the account"
+			resp.StatusCode = http.StatusBadRequest
+			resp.StatusMessage = response.PropertyValueNotInList
+			args := response.Args{
+				Code:    response.GeneralError,
+				Message: "",
+				ErrorArgs: []response.ErrArgs{
+					response.ErrArgs{
+						StatusMessage: resp.StatusMessage,
+						ErrorMessage:  errorMessage,
+						MessageArgs:   []interface{}{"true", "Locked"},
+					},
+				},
+			}
+			resp.Body = args.CreateGenericErrorResponse()
+			l.LogWithFields(ctx).Error(errorMessage)
+			return resp
+		}
+		if !session.Privileges[common.PrivilegeConfigureUsers] {
+			errorMessage := errorLogPrefix + "User does not have the privilege of unlocking any account, including his own account"
+			resp.StatusCode = http.StatusForbidden
+			resp.StatusMessage = response.InsufficientPrivilege
+			args := response.Args{
+				Code:    response.GeneralError,
+				Message: "",
+				ErrorArgs: []response.ErrArgs{
+					response.ErrArgs{
+						StatusMessage: resp.StatusMessage,
+						ErrorMessage:  errorMessage,
+						MessageArgs:   []interface{}{},
+					},
+				},
+			}
+			resp.Body = args.CreateGenericErrorResponse()
+			auth.CustomAuthLog(ctx, session.Token, errorMessage, resp.StatusCode)
+			return resp
+		}
+	}
+
 	if requestUser.Password != "" {
 		// Password modification not allowed, if user doesn't have ConfigureSelf or ConfigureUsers privilege
 		if !session.Privileges[common.PrivilegeConfigureSelf] && !session.Privileges[common.PrivilegeConfigureUsers] {
@@ -235,7 +277,11 @@ func (e *ExternalInterface) Update(ctx context.Context, req *accountproto.Update
 		}
 
 		//TODO: handle all the combination of patch roles(admin,non-admin,default admin, non-default admin)
-		if err = validatePassword(user.UserName, requestUser.Password); err != nil {
+		err = validatePassword(user.UserName, requestUser.Password)
+		if err == nil {
+			err = checkPasswordHistory(user, auth.HashPassword(requestUser.Password))
+		}
+		if err != nil {
 			errorMessage := err.Error()
 			resp.StatusCode = http.StatusBadRequest
 			resp.StatusMessage = response.PropertyValueFormatError
@@ -254,11 +300,12 @@ func (e *ExternalInterface) Update(ctx context.Context, req *accountproto.Update
 			l.LogWithFields(ctx).Error(errorMessage)
 			return resp
 		}
-		hash := sha3.New512()
-		hash.Write([]byte(requestUser.Password))
-		hashSum := hash.Sum(nil)
-		hashedPassword := base64.URLEncoding.EncodeToString(hashSum)
-		requestUser.Password = hashedPassword
+		requestUser.Password = auth.HashPassword(requestUser.Password)
+		requestUser.PasswordHistory = updatePasswordHistory(user)
+		requestUser.PasswordChangedTime = time.Now()
+		// the password set by an administrator for another user must be changed by the user on the next login
+		requestUser.PasswordChangeRequired = user.UserName != session.UserName &&
+			config.Data.AuthConf.PasswordRules.ForcePasswordChangeOnFirstLogin
 	}
 
 	l.LogWithFields(ctx).Infof("Updating the account %s", id)
@@ -268,6 +315,21 @@ func (e *ExternalInterface) Update(ctx context.Context, req *accountproto.Update
 		l.LogWithFields(ctx).Error(errorMessage)
 		return resp
 	}
+	var locked bool
+	if updateAccount.Locked != nil {
+		l.LogWithFields(ctx).Infof("Unlocking the account %s", id)
+		if uerr := e.UnlockAccount(id); uerr != nil && uerr.ErrNo() != errors.DBKeyNotFound {
+			errorMessage := errorLogPrefix + "Unable to unlock user: " + uerr.Error()
+			resp.CreateInternalErrorResponse(errorMessage)
+			l.LogWithFields(ctx).Error(errorMessage)
+			return resp
+		}
+	} else if locked, gerr = e.IsAccountLocked(id); gerr != nil {
+		errorMessage := errorLogPrefix + gerr.Error()
+		resp.CreateInternalErrorResponse(errorMessage)
+		l.LogWithFields(ctx).Error(errorMessage)
+		return resp
+	}
 
 	resp.StatusCode = http.StatusOK
 	resp.StatusMessage = response.AccountModified
@@ -279,12 +341,19 @@ func (e *ExternalInterface) Update(ctx context.Context, req *accountproto.Update
 	if requestUser.RoleID != "" {
 		user.RoleID = requestUser.RoleID
 	}
+	if requestUser.Password != "" {
+		user.PasswordChangedTime = requestUser.PasswordChangedTime
+		user.PasswordChangeRequired = requestUser.PasswordChangeRequired
+	}
 	commonResponse.CreateGenericResponse(resp.StatusMessage)
 	resp.Body = asresponse.Account{
-		Response:     commonResponse,
-		UserName:     user.UserName,
-		RoleID:       user.RoleID,
-		AccountTypes: user.AccountTypes,
+		Response:               commonResponse,
+		UserName:               user.UserName,
+		RoleID:                 user.RoleID,
+		AccountTypes:           user.AccountTypes,
+		Locked:                 locked,
+		PasswordChangeRequired: auth.IsPasswordChangeRequired(&user, time.Now()),
+		PasswordExpiration:     getPasswordExpiration(&user),
 		Links: asresponse.Links{
 			Role: asresponse.Role{
 				OdataID: "/redfish/v1/AccountService/Roles/" + user.RoleID,
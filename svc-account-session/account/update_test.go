@@ -182,6 +182,39 @@ func TestUpdate(t *testing.T) {
 	})
 
 	emptyPayload, _ := json.Marshal(map[string]interface{}{})
+	reqBodyUnlock, _ := json.Marshal(map[string]interface{}{"Locked": false})
+	reqBodyLock, _ := json.Marshal(map[string]interface{}{"Locked": true})
+
+	unlockSuccessResponse := response.Response{
+		OdataType:    common.ManagerAccountType,
+		OdataID:      "/redfish/v1/AccountService/Accounts/lockedUser",
+		OdataContext: "/redfish/v1/$metadata#ManagerAccount.ManagerAccount",
+		ID:           "lockedUser",
+		Name:         "Account Service",
+	}
+	unlockSuccessResponse.CreateGenericResponse(response.AccountModified)
+	errArgLock := response.Args{
+		Code:    response.GeneralError,
+		Message: "",
+		ErrorArgs: []response.ErrArgs{
+			response.ErrArgs{
+				StatusMessage: response.PropertyValueNotInList,
+				ErrorMessage:  "failed to update the account lockedUser: Locked can only be set to false to unlock the account",
+				MessageArgs:   []interface{}{"true", "Locked"},
+			},
+		},
+	}
+	errArgUnlock := response.Args{
+		Code:    response.GeneralError,
+		Message: "",
+		ErrorArgs: []response.ErrArgs{
+			response.ErrArgs{
+				StatusMessage: response.InsufficientPrivilege,
+				ErrorMessage:  "failed to update the account lockedUser: User does not have the privilege of unlocking any account, including his own account",
+				MessageArgs:   []interface{}{},
+			},
+		},
+	}
 
 	tests := []struct {
 		name string
@@ -577,6 +610,80 @@ func TestUpdate(t *testing.T) {
 				Body:          errArg5.CreateGenericErrorResponse(),
 			},
 		},
+		{
+			name: "unlock the account as admin",
+			args: args{
+				req: &accountproto.UpdateAccountRequest{
+					RequestBody: reqBodyUnlock,
+					AccountID:   "lockedUser",
+				},
+				session: &asmodel.Session{
+					UserName: "testUser1",
+					Privileges: map[string]bool{
+						common.PrivilegeConfigureUsers: true,
+					},
+				},
+			},
+			want: response.RPC{
+				StatusCode:    http.StatusOK,
+				StatusMessage: response.AccountModified,
+				Header: map[string]string{
+					"Link":     "</redfish/v1/AccountService/Accounts/lockedUser/>; rel=describedby",
+					"Location": "/redfish/v1/AccountService/Accounts/lockedUser",
+				},
+				Body: asresponse.Account{
+					Response: unlockSuccessResponse,
+					UserName: "lockedUser",
+					RoleID:   common.RoleMonitor,
+					Locked:   false,
+					Links: asresponse.Links{
+						Role: asresponse.Role{
+							OdataID: "/redfish/v1/AccountService/Roles/" + common.RoleMonitor,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "lock the account",
+			args: args{
+				req: &accountproto.UpdateAccountRequest{
+					RequestBody: reqBodyLock,
+					AccountID:   "lockedUser",
+				},
+				session: &asmodel.Session{
+					UserName: "testUser1",
+					Privileges: map[string]bool{
+						common.PrivilegeConfigureUsers: true,
+					},
+				},
+			},
+			want: response.RPC{
+				StatusCode:    http.StatusBadRequest,
+				StatusMessage: response.PropertyValueNotInList,
+				Body:          errArgLock.CreateGenericErrorResponse(),
+			},
+		},
+		{
+			name: "unlock own account without privilege",
+			args: args{
+				req: &accountproto.UpdateAccountRequest{
+					RequestBody: reqBodyUnlock,
+					AccountID:   "lockedUser",
+				},
+				session: &asmodel.Session{
+					UserName: "lockedUser",
+					Privileges: map[string]bool{
+						common.PrivilegeConfigureSelf: true,
+					},
+				},
+			},
+			want: response.RPC{
+				StatusCode:    http.StatusForbidden,
+				StatusMessage: response.InsufficientPrivilege,
+				Body:          errArgUnlock.CreateGenericErrorResponse(),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,76 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package asmessagebus ...
+package asmessagebus
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	dc "github.com/ODIM-Project/ODIM/lib-messagebus/datacommunicator"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	uuid "github.com/satori/go.uuid"
+)
+
+// AccountsCollection is the event source of the events of the user accounts, the events are
+// delivered to the subscriptions with /redfish/v1/AccountService/Accounts origin resource
+const AccountsCollection = "AccountsCollection"
+
+// PublishAccountLocked publishes the security alert raised when the account is locked
+// after AccountLockoutThreshold failed login attempts
+func PublishAccountLocked(ctx context.Context, userName string, threshold int) {
+	topicName := config.Data.MessageBusConf.OdimControlMessageQueue
+	k, err := dc.Communicator(config.Data.MessageBusConf.MessageBusType, config.Data.MessageBusConf.MessageBusConfigFilePath, topicName)
+	if err != nil {
+		l.LogWithFields(ctx).Error("Unable to connect to " + config.Data.MessageBusConf.MessageBusType + " " + err.Error())
+		return
+	}
+
+	var eventID = uuid.NewV4().String()
+	var event = common.Event{
+		EventID:        eventID,
+		MessageID:      "ResourceEvent.1.2.1.ResourceWarningThresholdExceeded",
+		EventTimestamp: time.Now().Format(time.RFC3339),
+		EventType:      "Alert",
+		Message:        "The account " + userName + " is locked after " + strconv.Itoa(threshold) + " failed login attempts.",
+		MessageArgs:    []string{"AccountLockoutThreshold", strconv.Itoa(threshold)},
+		OriginOfCondition: &common.Link{
+			Oid: "/redfish/v1/AccountService/Accounts/" + userName,
+		},
+		Severity: "Warning",
+	}
+	var events = []common.Event{event}
+	var messageData = common.MessageData{
+		Name:      "Security Event",
+		Context:   "/redfish/v1/$metadata#Event.Event",
+		OdataType: common.EventType,
+		Events:    events,
+	}
+	data, _ := json.Marshal(messageData)
+	var mbevent = common.Events{
+		IP:       AccountsCollection,
+		Request:  data,
+		Internal: true,
+	}
+
+	if err := k.Distribute(mbevent); err != nil {
+		l.LogWithFields(ctx).Error("UserName:" + userName + ", EventID:" + eventID + " : unable to publish the account lockout event to message bus: " + err.Error())
+		return
+	}
+}
@@ -17,6 +17,7 @@ package asmodel
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
@@ -27,14 +28,18 @@ type Account struct {
 	UserName string `json:"UserName"`
 	Password string `json:"Password"`
 	RoleID   string `json:"RoleId"`
+	Locked   *bool  `json:"Locked"`
 }
 
 // User is the model for User Account
 type User struct {
-	UserName     string   `json:"UserName"`
-	Password     string   `json:"Password"`
-	RoleID       string   `json:"RoleId"`
-	AccountTypes []string `json:"AccountTypes"`
+	UserName               string    `json:"UserName"`
+	Password               string    `json:"Password"`
+	RoleID                 string    `json:"RoleId"`
+	AccountTypes           []string  `json:"AccountTypes"`
+	PasswordHistory        []string  `json:"PasswordHistory,omitempty"`
+	PasswordChangedTime    time.Time `json:"PasswordChangedTime"`
+	PasswordChangeRequired bool      `json:"PasswordChangeRequired"`
 }
 
 var (
@@ -123,8 +128,12 @@ func UpdateUserDetails(user, newData User) *errors.Error {
 	const table string = "User"
 	//Save data into Database
 
+	// the password history and age are updated along with the password
 	if newData.Password != "" {
 		user.Password = newData.Password
+		user.PasswordHistory = newData.PasswordHistory
+		user.PasswordChangedTime = newData.PasswordChangedTime
+		user.PasswordChangeRequired = newData.PasswordChangeRequired
 	}
 	if newData.RoleID != "" {
 		user.RoleID = newData.RoleID
@@ -0,0 +1,117 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package asmodel
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+)
+
+const (
+	accountLockoutTable = "AccountLockout"
+	failedLoginsTable   = "FailedLogins"
+)
+
+// AccountLockout holds the time till which a user account is locked
+type AccountLockout struct {
+	LockedUntil time.Time
+}
+
+// IsLocked checks whether the account is locked at the given time
+func (a *AccountLockout) IsLocked(now time.Time) bool {
+	return now.Before(a.LockedUntil)
+}
+
+// GetAccountLockout will get the lock of the account of the user from the in-memory DB
+func GetAccountLockout(userName string) (AccountLockout, *errors.Error) {
+	var lockout AccountLockout
+	connPool, err := GetDBConnectionFunc(common.InMemory)
+	if err != nil {
+		return lockout, errors.PackError(err.ErrNo(), "error while trying to connecting to DB: ", err.Error())
+	}
+	data, err := connPool.Read(accountLockoutTable, userName)
+	if err != nil {
+		return lockout, errors.PackError(err.ErrNo(), "error while trying to get the account lockout details: ", err.Error())
+	}
+	if jerr := json.Unmarshal([]byte(data), &lockout); jerr != nil {
+		return lockout, errors.PackError(errors.UndefinedErrorType, "error while trying to unmarshal account lockout data: ", jerr)
+	}
+	return lockout, nil
+}
+
+// CountFailedLogin will increment the failed login attempts of the user in the in-memory DB and return them,
+// the attempts are removed when no login fails for resetAfter seconds
+func CountFailedLogin(userName string, resetAfter int) (int, *errors.Error) {
+	connPool, err := GetDBConnectionFunc(common.InMemory)
+	if err != nil {
+		return 0, errors.PackError(err.ErrNo(), "error while trying to connecting to DB: ", err.Error())
+	}
+	attempts, err := connPool.IncrWithExpiry(failedLoginsTable, userName, resetAfter)
+	if err != nil {
+		return 0, errors.PackError(err.ErrNo(), "error while trying to count the failed login attempt: ", err.Error())
+	}
+	return attempts, nil
+}
+
+// ResetFailedLogins will delete the failed login attempts of the user from the in-memory DB
+func ResetFailedLogins(userName string) *errors.Error {
+	connPool, err := GetDBConnectionFunc(common.InMemory)
+	if err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to connecting to DB: ", err.Error())
+	}
+	if err = connPool.Delete(failedLoginsTable, userName); err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to delete the failed login attempts: ", err.Error())
+	}
+	return nil
+}
+
+// LockAccount will lock the account of the user in the in-memory DB for the given seconds,
+// DBKeyAlreadyExist is returned if the account is already locked
+func LockAccount(userName string, lockout AccountLockout, duration int) *errors.Error {
+	connPool, err := GetDBConnectionFunc(common.InMemory)
+	if err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to connecting to DB: ", err.Error())
+	}
+	if err = connPool.SetExpire(accountLockoutTable, userName, lockout, duration); err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to save the account lockout details: ", err.Error())
+	}
+	return nil
+}
+
+// DeleteAccountLockout will delete the lock of the account and the failed login attempts
+// of the user from the in-memory DB, which unlocks the account
+func DeleteAccountLockout(userName string) *errors.Error {
+	connPool, err := GetDBConnectionFunc(common.InMemory)
+	if err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to connecting to DB: ", err.Error())
+	}
+	var found bool
+	for _, table := range []string{accountLockoutTable, failedLoginsTable} {
+		if err = connPool.Delete(table, userName); err == nil {
+			found = true
+			continue
+		}
+		if err.ErrNo() != errors.DBKeyNotFound {
+			return errors.PackError(err.ErrNo(), "error while trying to delete the account lockout details: ", err.Error())
+		}
+	}
+	if !found {
+		return errors.PackError(errors.DBKeyNotFound, "error: no account lockout details of the user ", userName, " found")
+	}
+	return nil
+}
@@ -35,6 +35,8 @@ type Session struct {
 	Origin       string
 	CreatedTime  time.Time
 	LastUsedTime time.Time
+	// PasswordChangeRequired is set for the sessions which can only be used to change the password of the user
+	PasswordChangeRequired bool
 }
 
 //CreateSession will hold input request for creating a session
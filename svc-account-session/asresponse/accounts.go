@@ -23,12 +23,15 @@ import (
 // Account struct is used to ommit password for display purposes
 type Account struct {
 	response.Response
-	UserName     string   `json:"UserName"`
-	RoleID       string   `json:"RoleId"`
-	AccountTypes []string `json:"AccountTypes"`
-	Password     *string  `json:"Password"`
-	Links        Links    `json:"Links"`
-	OEM          *OEM     `json:"Oem,omitempty"`
+	UserName               string   `json:"UserName"`
+	RoleID                 string   `json:"RoleId"`
+	AccountTypes           []string `json:"AccountTypes"`
+	Password               *string  `json:"Password"`
+	Locked                 bool     `json:"Locked"`
+	PasswordChangeRequired bool     `json:"PasswordChangeRequired"`
+	PasswordExpiration     string   `json:"PasswordExpiration,omitempty"`
+	Links                  Links    `json:"Links"`
+	OEM                    *OEM     `json:"Oem,omitempty"`
 }
 
 //OEM struct definition
@@ -48,31 +51,44 @@ type Role struct {
 //AccountService struct definition
 type AccountService struct {
 	response.Response
-	Status                             Status           `json:"Status,omitempty"`
-	ServiceEnabled                     bool             `json:"ServiceEnabled,omitempty"`
-	AuthFailureLoggingThreshold        int              `json:"AuthFailureLoggingThreshold,omitempty"`
-	MinPasswordLength                  int              `json:"MinPasswordLength,omitempty"`
-	AccountLockoutThreshold            int              `json:"AccountLockoutThreshold,omitempty"`
-	AccountLockoutDuration             int              `json:"AccountLockoutDuration,omitempty"`
-	AccountLockoutCounterResetAfter    int              `json:"AccountLockoutCounterResetAfter,omitempty"`
-	Accounts                           Accounts         `json:"Accounts,omitempty"`
-	Roles                              Accounts         `json:"Roles,omitempty"`
-	AccountLockoutCounterResetEnabled  bool             `json:"AccountLockoutCounterResetEnabled,omitempty"`
-	Actions                            *dmtf.OemActions `json:"Actions,omitempty"`
-	ActiveDirectory                    *ActiveDirectory `json:"ActiveDirectory,omitempty"`
-	AdditionalExternalAccountProviders *dmtf.Link       `json:"AdditionalExternalAccountProviders,omitempty"`
-	LDAP                               *LDAP            `json:"LDAP,omitempty"`
-	LocalAccountAuth                   string           `json:"LocalAccountAuth,omitempty"`
-	MaxPasswordLength                  int              `json:"MaxPasswordLength,omitempty"`
-	OAuth2                             *OAuth2          `json:"OAuth2,omitempty"`
-	Oem                                *OEM             `json:"Oem,omitempty"`
-	PasswordExpirationDays             int              `json:"PasswordExpirationDays,omitempty"`
-	PrivilegeMap                       *dmtf.Link       `json:"PrivilegeMap,omitempty"`
-	RestrictedOemPrivileges            []string         `json:"RestrictedOemPrivileges,omitempty"`
-	RestrictedPrivileges               []string         `json:"RestrictedPrivileges,omitempty"`
-	SupportedAccountTypes              []string         `json:"SupportedAccountTypes,omitempty"`
-	SupportedOEMAccountTypes           []string         `json:"SupportedOEMAccountTypes,omitempty"`
-	TACACSplus                         *TACACSplus      `json:"TACACSplus,omitempty"`
+	Status                             Status             `json:"Status,omitempty"`
+	ServiceEnabled                     bool               `json:"ServiceEnabled,omitempty"`
+	AuthFailureLoggingThreshold        int                `json:"AuthFailureLoggingThreshold,omitempty"`
+	MinPasswordLength                  int                `json:"MinPasswordLength,omitempty"`
+	AccountLockoutThreshold            int                `json:"AccountLockoutThreshold"`
+	AccountLockoutDuration             int                `json:"AccountLockoutDuration,omitempty"`
+	AccountLockoutCounterResetAfter    int                `json:"AccountLockoutCounterResetAfter,omitempty"`
+	Accounts                           Accounts           `json:"Accounts,omitempty"`
+	Roles                              Accounts           `json:"Roles,omitempty"`
+	AccountLockoutCounterResetEnabled  bool               `json:"AccountLockoutCounterResetEnabled,omitempty"`
+	Actions                            *dmtf.OemActions   `json:"Actions,omitempty"`
+	ActiveDirectory                    *ActiveDirectory   `json:"ActiveDirectory,omitempty"`
+	AdditionalExternalAccountProviders *dmtf.Link         `json:"AdditionalExternalAccountProviders,omitempty"`
+	LDAP                               *LDAP              `json:"LDAP,omitempty"`
+	LocalAccountAuth                   string             `json:"LocalAccountAuth,omitempty"`
+	MaxPasswordLength                  int                `json:"MaxPasswordLength,omitempty"`
+	MultiFactorAuth                    *MultiFactorAuth   `json:"MultiFactorAuth,omitempty"`
+	OAuth2                             *OAuth2            `json:"OAuth2,omitempty"`
+	Oem                                *AccountServiceOem `json:"Oem,omitempty"`
+	PasswordExpirationDays             int                `json:"PasswordExpirationDays,omitempty"`
+	PrivilegeMap                       *dmtf.Link         `json:"PrivilegeMap,omitempty"`
+	RestrictedOemPrivileges            []string           `json:"RestrictedOemPrivileges,omitempty"`
+	RestrictedPrivileges               []string           `json:"RestrictedPrivileges,omitempty"`
+	SupportedAccountTypes              []string           `json:"SupportedAccountTypes,omitempty"`
+	SupportedOEMAccountTypes           []string           `json:"SupportedOEMAccountTypes,omitempty"`
+	TACACSplus                         *TACACSplus        `json:"TACACSplus,omitempty"`
+}
+
+// AccountServiceOem struct definition
+type AccountServiceOem struct {
+	Odim *PasswordPolicy `json:"Odim,omitempty"`
+}
+
+// PasswordPolicy struct definition, it holds the password rules not covered by the AccountService schema
+type PasswordPolicy struct {
+	RequiredCharacterClasses        []string `json:"RequiredCharacterClasses"`
+	PasswordHistoryCount            int      `json:"PasswordHistoryCount"`
+	ForcePasswordChangeOnFirstLogin bool     `json:"ForcePasswordChangeOnFirstLogin"`
 }
 
 //Accounts struct definition
@@ -82,14 +98,72 @@ type Accounts struct {
 
 // OAuth2 struct definition
 type OAuth2 struct {
+	ExternalAccountProvider
 }
 
 // ActiveDirectory struct definition
 type ActiveDirectory struct {
+	ExternalAccountProvider
 }
 
 // LDAP struct definition
 type LDAP struct {
+	ExternalAccountProvider
+}
+
+// ExternalAccountProvider struct definition
+type ExternalAccountProvider struct {
+	AccountProviderType string              `json:"AccountProviderType,omitempty"`
+	ServiceEnabled      bool                `json:"ServiceEnabled"`
+	ServiceAddresses    []string            `json:"ServiceAddresses,omitempty"`
+	Authentication      *Authentication     `json:"Authentication,omitempty"`
+	LDAPService         *LDAPService        `json:"LDAPService,omitempty"`
+	OAuth2Service       *OAuth2Service      `json:"OAuth2Service,omitempty"`
+	RemoteRoleMapping   []RemoteRoleMapping `json:"RemoteRoleMapping,omitempty"`
+}
+
+// Authentication struct definition
+type Authentication struct {
+	AuthenticationType string `json:"AuthenticationType"`
+	Username           string `json:"Username,omitempty"`
+}
+
+// LDAPService struct definition
+type LDAPService struct {
+	SearchSettings LDAPSearchSettings `json:"SearchSettings"`
+}
+
+// LDAPSearchSettings struct definition
+type LDAPSearchSettings struct {
+	BaseDistinguishedNames []string `json:"BaseDistinguishedNames,omitempty"`
+	UsernameAttribute      string   `json:"UsernameAttribute,omitempty"`
+	GroupsAttribute        string   `json:"GroupsAttribute,omitempty"`
+}
+
+// OAuth2Service struct definition
+type OAuth2Service struct {
+	Mode                    string   `json:"Mode"`
+	Issuer                  string   `json:"Issuer,omitempty"`
+	Audience                []string `json:"Audience,omitempty"`
+	OAuthServiceSigningKeys string   `json:"OAuthServiceSigningKeys,omitempty"`
+}
+
+// RemoteRoleMapping struct definition
+type RemoteRoleMapping struct {
+	LocalRole   string `json:"LocalRole"`
+	RemoteGroup string `json:"RemoteGroup"`
+}
+
+// MultiFactorAuth struct definition
+type MultiFactorAuth struct {
+	ClientCertificate *ClientCertificate `json:"ClientCertificate,omitempty"`
+}
+
+// ClientCertificate struct definition
+type ClientCertificate struct {
+	Enabled                         bool   `json:"Enabled"`
+	RespondToUnauthenticatedClients bool   `json:"RespondToUnauthenticatedClients"`
+	CertificateMappingAttribute     string `json:"CertificateMappingAttribute,omitempty"`
 }
 
 // TACACSplus struct definition
@@ -63,6 +63,13 @@ func Auth(ctx context.Context, req *authproto.AuthRequest) (int32, string) {
 		return err.GetAuthStatusCodeAndMessage()
 	}
 
+	// the session of a user who must change the password is used only for changing the password,
+	// which is authorized by the account service itself
+	if session.PasswordChangeRequired {
+		CustomAuthLog(ctx, req.SessionToken, "The password of the user must be changed", http.StatusForbidden)
+		return http.StatusForbidden, response.PasswordChangeRequired
+	}
+
 	// if the service has all the privileges then return success
 	// if any of the privilege isn't assigned to service then return failure
 	for _, privilege := range req.Privileges {
@@ -0,0 +1,109 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package auth
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/asn1"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+)
+
+var (
+	oidSubjectAltName    = asn1.ObjectIdentifier{2, 5, 29, 17}
+	oidUserPrincipalName = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 311, 20, 2, 3}
+)
+
+// otherName is the otherName form of a subject alternative name, RFC 5280.
+// Value is the [0] EXPLICIT tagged value of the name.
+type otherName struct {
+	TypeID asn1.ObjectIdentifier
+	Value  asn1.RawValue
+}
+
+// CheckClientCertificate maps the client certificate verified by the API service to the ODIM
+// account named by the CertificateMappingAttribute of the certificate.
+// The returned user carries only the role of the account, the password policies
+// do not apply to the certificate based logins.
+func CheckClientCertificate(ctx context.Context, certificate []byte) (*asmodel.User, *errors.Error) {
+	conf := config.Data.AuthConf.ClientCertificate
+	if conf == nil || !conf.Enabled {
+		return nil, errors.PackError(errors.UndefinedErrorType, "error: client certificate authentication is not enabled")
+	}
+	cert, err := x509.ParseCertificate(certificate)
+	if err != nil {
+		return nil, errors.PackError(errors.UndefinedErrorType, "error while parsing the client certificate: ", err)
+	}
+	userName := getCertificateUserName(cert, conf.CertificateMappingAttribute)
+	if userName == "" {
+		return nil, errors.PackError(errors.UndefinedErrorType, "error: client certificate has no ", conf.CertificateMappingAttribute)
+	}
+	user, gerr := asmodel.GetUserDetails(userName)
+	if gerr != nil {
+		return nil, errors.PackError(gerr.ErrNo(), "error: no account is mapped to the client certificate of ", userName, ": ", gerr.Error())
+	}
+	lockout, gerr := getAccountLockout(userName)
+	if gerr != nil {
+		return nil, errors.PackError(gerr.ErrNo(), "error while checking the client certificate: unable to get the failed login attempts: ", gerr.Error())
+	}
+	if lockout != nil && lockout.IsLocked(time.Now()) {
+		return nil, errors.PackError(errors.UndefinedErrorType, "error while checking the client certificate: account is locked till ", lockout.LockedUntil.Format(time.RFC3339))
+	}
+	return &asmodel.User{
+		UserName:     user.UserName,
+		RoleID:       user.RoleID,
+		AccountTypes: user.AccountTypes,
+	}, nil
+}
+
+// getCertificateUserName returns the value of the certificate mapping attribute of the certificate
+func getCertificateUserName(cert *x509.Certificate, attribute string) string {
+	if attribute == config.CertificateMappingUserPrincipalName {
+		return getUserPrincipalName(cert)
+	}
+	return cert.Subject.CommonName
+}
+
+// getUserPrincipalName returns the user principal name of the subject alternative names of the certificate
+func getUserPrincipalName(cert *x509.Certificate) string {
+	for _, extension := range cert.Extensions {
+		if !extension.Id.Equal(oidSubjectAltName) {
+			continue
+		}
+		var names []asn1.RawValue
+		if _, err := asn1.Unmarshal(extension.Value, &names); err != nil {
+			return ""
+		}
+		for _, name := range names {
+			if name.Class != asn1.ClassContextSpecific || name.Tag != 0 {
+				continue
+			}
+			var other otherName
+			if _, err := asn1.UnmarshalWithParams(name.FullBytes, &other, "tag:0"); err != nil ||
+				!other.TypeID.Equal(oidUserPrincipalName) || other.Value.Tag != 0 {
+				continue
+			}
+			var upn string
+			if _, err := asn1.UnmarshalWithParams(other.Value.Bytes, &upn, "utf8"); err == nil {
+				return upn
+			}
+		}
+	}
+	return ""
+}
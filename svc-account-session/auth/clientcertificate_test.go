@@ -0,0 +1,122 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+)
+
+func mockClientCertificate(t *testing.T, commonName, userPrincipalName string) *x509.Certificate {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	if userPrincipalName != "" {
+		upn, err := asn1.MarshalWithParams(userPrincipalName, "utf8")
+		if err != nil {
+			t.Fatalf("error while marshalling the user principal name: %v", err)
+		}
+		name, err := asn1.MarshalWithParams(otherName{
+			TypeID: oidUserPrincipalName,
+			Value:  asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: upn},
+		}, "tag:0")
+		if err != nil {
+			t.Fatalf("error while marshalling the other name: %v", err)
+		}
+		names, err := asn1.Marshal([]asn1.RawValue{{FullBytes: name}})
+		if err != nil {
+			t.Fatalf("error while marshalling the subject alternative names: %v", err)
+		}
+		template.ExtraExtensions = []pkix.Extension{{Id: oidSubjectAltName, Value: names}}
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("error while generating the key: %v", err)
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("error while creating the certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("error while parsing the certificate: %v", err)
+	}
+	return cert
+}
+
+func TestGetCertificateUserName(t *testing.T) {
+	tests := []struct {
+		name      string
+		cert      *x509.Certificate
+		attribute string
+		want      string
+	}{
+		{
+			name:      "common name",
+			cert:      mockClientCertificate(t, "automation", "automation@odim.local"),
+			attribute: config.CertificateMappingCommonName,
+			want:      "automation",
+		},
+		{
+			name:      "user principal name",
+			cert:      mockClientCertificate(t, "automation", "automation@odim.local"),
+			attribute: config.CertificateMappingUserPrincipalName,
+			want:      "automation@odim.local",
+		},
+		{
+			name:      "certificate without user principal name",
+			cert:      mockClientCertificate(t, "automation", ""),
+			attribute: config.CertificateMappingUserPrincipalName,
+			want:      "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getCertificateUserName(tt.cert, tt.attribute); got != tt.want {
+				t.Errorf("getCertificateUserName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckClientCertificateDisabled(t *testing.T) {
+	config.SetUpMockConfig(t)
+	cert := mockClientCertificate(t, "automation", "")
+	if _, err := CheckClientCertificate(context.Background(), cert.Raw); err == nil {
+		t.Errorf("CheckClientCertificate() expected an error when client certificate authentication is disabled")
+	}
+	config.Data.AuthConf.ClientCertificate = &config.ClientCertificate{
+		Enabled:                     true,
+		CertificateMappingAttribute: config.CertificateMappingCommonName,
+	}
+	defer func() { config.Data.AuthConf.ClientCertificate = nil }()
+	if _, err := CheckClientCertificate(context.Background(), []byte("invalid")); err == nil {
+		t.Errorf("CheckClientCertificate() expected an error for an invalid certificate")
+	}
+}
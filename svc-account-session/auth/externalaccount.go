@@ -0,0 +1,131 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package auth
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+)
+
+// Account provider types of the external account providers
+const (
+	LDAPService            = "LDAPService"
+	ActiveDirectoryService = "ActiveDirectoryService"
+)
+
+// AccountProvider is an external account service against which the users not present in ODIM are authenticated
+type AccountProvider interface {
+	// Authenticate checks the credentials of the user and returns the groups the user belongs to
+	Authenticate(ctx context.Context, userName, password string) ([]string, error)
+	// RemoteRoleMapping returns the mapping of the groups to the ODIM roles
+	RemoteRoleMapping() []config.RemoteRoleMapping
+}
+
+// GetAccountProviders returns the enabled external account providers in the order they are tried
+var GetAccountProviders = getAccountProviders
+
+// externalAuthorization is the role of an external user cached for the session lifetime
+type externalAuthorization struct {
+	hashedPassword string
+	roleID         string
+	expiry         time.Time
+}
+
+var (
+	externalAuthorizations     = make(map[string]externalAuthorization)
+	externalAuthorizationsLock sync.Mutex
+)
+
+func getAccountProviders() []AccountProvider {
+	var providers []AccountProvider
+	if conf := config.Data.AuthConf.LDAP; conf != nil && conf.ServiceEnabled {
+		providers = append(providers, &ldapProvider{accountProviderType: LDAPService, conf: conf})
+	}
+	if conf := config.Data.AuthConf.ActiveDirectory; conf != nil && conf.ServiceEnabled {
+		providers = append(providers, &ldapProvider{accountProviderType: ActiveDirectoryService, conf: conf})
+	}
+	return providers
+}
+
+// authenticateExternalUser authenticates the user against the external account providers
+// and maps the groups of the user to an ODIM role through the RemoteRoleMapping.
+// The role is cached for the session lifetime, so that the basic auth requests
+// of the user are not authenticated against the directory service every time.
+func authenticateExternalUser(ctx context.Context, userName, password string) (*asmodel.User, *errors.Error) {
+	hashedPassword := HashPassword(password)
+	externalAuthorizationsLock.Lock()
+	authorization, exist := externalAuthorizations[userName]
+	if exist && time.Now().After(authorization.expiry) {
+		delete(externalAuthorizations, userName)
+		exist = false
+	}
+	externalAuthorizationsLock.Unlock()
+	if exist && authorization.hashedPassword == hashedPassword {
+		return newExternalUser(userName, authorization.roleID), nil
+	}
+
+	providers := GetAccountProviders()
+	if len(providers) == 0 {
+		return nil, errors.PackError(errors.UndefinedErrorType, "error: no external account provider is enabled")
+	}
+	for _, provider := range providers {
+		groups, err := provider.Authenticate(ctx, userName, password)
+		if err != nil {
+			l.LogWithFields(ctx).Warn("unable to authenticate the user " + userName + " against the external account provider: " + err.Error())
+			continue
+		}
+		roleID := mapRemoteGroups(groups, provider.RemoteRoleMapping())
+		if roleID == "" {
+			return nil, errors.PackError(errors.UndefinedErrorType, "error: none of the groups of the user "+userName+" is mapped to an ODIM role")
+		}
+		externalAuthorizationsLock.Lock()
+		externalAuthorizations[userName] = externalAuthorization{
+			hashedPassword: hashedPassword,
+			roleID:         roleID,
+			expiry:         time.Now().Add(time.Duration(config.Data.AuthConf.SessionTimeOutInMins * float64(time.Minute))),
+		}
+		externalAuthorizationsLock.Unlock()
+		return newExternalUser(userName, roleID), nil
+	}
+	return nil, errors.PackError(errors.UndefinedErrorType, "error: unable to authenticate the user "+userName+" against the external account providers")
+}
+
+func newExternalUser(userName, roleID string) *asmodel.User {
+	return &asmodel.User{
+		UserName:     userName,
+		RoleID:       roleID,
+		AccountTypes: []string{"Redfish"},
+	}
+}
+
+// mapRemoteGroups returns the role of the first RemoteRoleMapping entry which matches any of the groups.
+// A group matches when the RemoteGroup is either the distinguished name or the name of the group.
+func mapRemoteGroups(groups []string, mapping []config.RemoteRoleMapping) string {
+	for _, roleMapping := range mapping {
+		for _, group := range groups {
+			if strings.EqualFold(roleMapping.RemoteGroup, group) || strings.EqualFold(roleMapping.RemoteGroup, groupName(group)) {
+				return roleMapping.LocalRole
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,156 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/go-ldap/ldap/v3"
+)
+
+// userPrincipalNameAttribute is the Active Directory attribute used when the user logs in with user@domain
+const userPrincipalNameAttribute = "userPrincipalName"
+
+// ldapProvider authenticates the users against an LDAP or Active Directory service
+type ldapProvider struct {
+	accountProviderType string
+	conf                *config.ExternalAccountProvider
+}
+
+// Authenticate searches the user in the directory, binds as the user to verify the password
+// and returns the values of the GroupsAttribute of the user entry
+func (p *ldapProvider) Authenticate(ctx context.Context, userName, password string) ([]string, error) {
+	conn, err := p.dial()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	conn.SetTimeout(time.Duration(p.conf.TimeoutInSeconds) * time.Second)
+
+	if p.conf.Username != "" {
+		if err = conn.Bind(p.conf.Username, string(p.conf.Password)); err != nil {
+			return nil, fmt.Errorf("unable to bind as %s: %v", p.conf.Username, err)
+		}
+	}
+	entry, err := p.searchUser(conn, userName)
+	if err != nil {
+		return nil, err
+	}
+	if err = conn.Bind(entry.DN, password); err != nil {
+		return nil, fmt.Errorf("invalid credentials for %s: %v", entry.DN, err)
+	}
+	return entry.GetAttributeValues(p.conf.GroupsAttribute), nil
+}
+
+// RemoteRoleMapping returns the mapping of the directory groups to the ODIM roles
+func (p *ldapProvider) RemoteRoleMapping() []config.RemoteRoleMapping {
+	return p.conf.RemoteRoleMapping
+}
+
+// dial connects to the first reachable directory server
+func (p *ldapProvider) dial() (*ldap.Conn, error) {
+	var errs []string
+	for _, address := range p.conf.ServiceAddresses {
+		opts := []ldap.DialOpt{
+			ldap.DialWithDialer(&net.Dialer{Timeout: time.Duration(p.conf.TimeoutInSeconds) * time.Second}),
+		}
+		if strings.HasPrefix(strings.ToLower(address), "ldaps://") {
+			tlsConfig, err := getLDAPTLSConfig(address)
+			if err != nil {
+				errs = append(errs, err.Error())
+				continue
+			}
+			opts = append(opts, ldap.DialWithTLSConfig(tlsConfig))
+		}
+		conn, err := ldap.DialURL(address, opts...)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		return conn, nil
+	}
+	return nil, fmt.Errorf("unable to connect to the %s: %s", p.accountProviderType, strings.Join(errs, "; "))
+}
+
+// searchUser returns the entry of the user, which must be unique under the BaseDistinguishedNames
+func (p *ldapProvider) searchUser(conn *ldap.Conn, userName string) (*ldap.Entry, error) {
+	attribute := p.conf.UsernameAttribute
+	if p.accountProviderType == ActiveDirectoryService && strings.Contains(userName, "@") {
+		attribute = userPrincipalNameAttribute
+	}
+	filter := fmt.Sprintf("(%s=%s)", attribute, ldap.EscapeFilter(userName))
+	for _, baseDN := range p.conf.BaseDistinguishedNames {
+		searchRequest := ldap.NewSearchRequest(baseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 2,
+			p.conf.TimeoutInSeconds, false, filter, []string{p.conf.GroupsAttribute}, nil)
+		result, err := conn.Search(searchRequest)
+		if err != nil {
+			if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+				continue
+			}
+			if ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+				return nil, fmt.Errorf("user %s is not unique under %s", userName, baseDN)
+			}
+			return nil, fmt.Errorf("unable to search the user %s under %s: %v", userName, baseDN, err)
+		}
+		switch len(result.Entries) {
+		case 0:
+			continue
+		case 1:
+			return result.Entries[0], nil
+		default:
+			return nil, fmt.Errorf("user %s is not unique under %s", userName, baseDN)
+		}
+	}
+	return nil, fmt.Errorf("user %s not found", userName)
+}
+
+// getLDAPTLSConfig returns the TLS configuration for the ldaps:// address,
+// the directory server certificate is validated against the ODIM root CA certificate
+func getLDAPTLSConfig(address string) (*tls.Config, error) {
+	serviceURL, err := url.Parse(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid service address %s: %v", address, err)
+	}
+	tlsConfig := &tls.Config{
+		ServerName: serviceURL.Hostname(),
+	}
+	httpConf := &config.HTTPConfig{
+		CACertificate: &config.Data.KeyCertConf.RootCACertificate,
+	}
+	if err = httpConf.LoadCertificates(tlsConfig); err != nil {
+		return nil, err
+	}
+	config.TLSConfMutex.RLock()
+	config.Client.SetTLSConfig(tlsConfig)
+	config.TLSConfMutex.RUnlock()
+	return tlsConfig, nil
+}
+
+// groupName returns the value of the first attribute of the group distinguished name,
+// e.g. admins for cn=admins,ou=groups,dc=odim,dc=local
+func groupName(group string) string {
+	dn, err := ldap.ParseDN(group)
+	if err != nil || len(dn.RDNs) == 0 || len(dn.RDNs[0].Attributes) == 0 {
+		return group
+	}
+	return dn.RDNs[0].Attributes[0].Value
+}
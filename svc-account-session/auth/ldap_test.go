@@ -0,0 +1,338 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package auth
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+)
+
+// ldapStandIn is an in-process LDAP server supporting the simple bind and the equality search
+type ldapStandIn struct {
+	listener net.Listener
+	entries  []ldapStandInEntry
+}
+
+type ldapStandInEntry struct {
+	dn         string
+	password   string
+	attributes map[string][]string
+}
+
+func newLDAPStandIn(t *testing.T, entries []ldapStandInEntry) *ldapStandIn {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("error while starting the LDAP stand-in: %v", err)
+	}
+	s := &ldapStandIn{listener: listener, entries: entries}
+	go s.serve()
+	return s
+}
+
+func (s *ldapStandIn) address() string {
+	return "ldap://" + s.listener.Addr().String()
+}
+
+func (s *ldapStandIn) close() {
+	s.listener.Close()
+}
+
+func (s *ldapStandIn) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *ldapStandIn) handle(conn net.Conn) {
+	defer conn.Close()
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil || len(packet.Children) < 2 {
+			return
+		}
+		messageID := packet.Children[0].Value.(int64)
+		op := packet.Children[1]
+		switch op.Tag {
+		case ldap.ApplicationBindRequest:
+			code := ldap.LDAPResultInvalidCredentials
+			if s.bind(op.Children[1].Data.String(), op.Children[2].Data.String()) {
+				code = ldap.LDAPResultSuccess
+			}
+			conn.Write(ldapResponse(messageID, ldap.ApplicationBindResponse, ldapResult(code)...).Bytes())
+		case ldap.ApplicationSearchRequest:
+			filter, err := ldap.DecompileFilter(op.Children[6])
+			if err != nil {
+				conn.Write(ldapResponse(messageID, ldap.ApplicationSearchResultDone, ldapResult(ldap.LDAPResultOperationsError)...).Bytes())
+				continue
+			}
+			for _, entry := range s.search(op.Children[0].Data.String(), filter) {
+				conn.Write(ldapResponse(messageID, ldap.ApplicationSearchResultEntry, entry.encode()...).Bytes())
+			}
+			conn.Write(ldapResponse(messageID, ldap.ApplicationSearchResultDone, ldapResult(ldap.LDAPResultSuccess)...).Bytes())
+		default:
+			return
+		}
+	}
+}
+
+func (s *ldapStandIn) bind(dn, password string) bool {
+	for _, entry := range s.entries {
+		if strings.EqualFold(entry.dn, dn) && entry.password == password && password != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *ldapStandIn) search(baseDN, filter string) []ldapStandInEntry {
+	var entries []ldapStandInEntry
+	for _, entry := range s.entries {
+		if !strings.HasSuffix(strings.ToLower(entry.dn), strings.ToLower(baseDN)) {
+			continue
+		}
+		for name, values := range entry.attributes {
+			for _, value := range values {
+				if strings.EqualFold(filter, fmt.Sprintf("(%s=%s)", name, value)) {
+					entries = append(entries, entry)
+				}
+			}
+		}
+	}
+	return entries
+}
+
+func (e ldapStandInEntry) encode() []*ber.Packet {
+	attributes := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attributes")
+	for name, values := range e.attributes {
+		attribute := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attribute")
+		attribute.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, "Type"))
+		set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "Values")
+		for _, value := range values {
+			set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, value, "Value"))
+		}
+		attribute.AppendChild(set)
+		attributes.AppendChild(attribute)
+	}
+	return []*ber.Packet{
+		ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, e.dn, "ObjectName"),
+		attributes,
+	}
+}
+
+func ldapResponse(messageID int64, tag ber.Tag, children ...*ber.Packet) *ber.Packet {
+	packet := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageID, "MessageID"))
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Response")
+	for _, child := range children {
+		op.AppendChild(child)
+	}
+	packet.AppendChild(op)
+	return packet
+}
+
+func ldapResult(code int) []*ber.Packet {
+	return []*ber.Packet{
+		ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(code), "ResultCode"),
+		ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "MatchedDN"),
+		ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "DiagnosticMessage"),
+	}
+}
+
+func mockDirectoryEntries() []ldapStandInEntry {
+	return []ldapStandInEntry{
+		{
+			dn:       "cn=odim,ou=services,dc=odim,dc=local",
+			password: "odimpassword",
+		},
+		{
+			dn:       "uid=alice,ou=people,dc=odim,dc=local",
+			password: "alicepassword",
+			attributes: map[string][]string{
+				"uid":               {"alice"},
+				"sAMAccountName":    {"alice"},
+				"userPrincipalName": {"alice@odim.local"},
+				"memberOf":          {"cn=odim-admins,ou=groups,dc=odim,dc=local"},
+			},
+		},
+		{
+			dn:       "uid=bob,ou=people,dc=odim,dc=local",
+			password: "bobpassword",
+			attributes: map[string][]string{
+				"uid":      {"bob"},
+				"memberOf": {"cn=guests,ou=groups,dc=odim,dc=local"},
+			},
+		},
+	}
+}
+
+func mockAccountProviderConf(address string) *config.ExternalAccountProvider {
+	return &config.ExternalAccountProvider{
+		ServiceEnabled:         true,
+		ServiceAddresses:       []string{address},
+		Username:               "cn=odim,ou=services,dc=odim,dc=local",
+		Password:               []byte("odimpassword"),
+		BaseDistinguishedNames: []string{"ou=people,dc=odim,dc=local"},
+		UsernameAttribute:      config.DefaultLDAPUsernameAttribute,
+		GroupsAttribute:        config.DefaultGroupsAttribute,
+		TimeoutInSeconds:       5,
+		RemoteRoleMapping: []config.RemoteRoleMapping{
+			{RemoteGroup: "odim-admins", LocalRole: "Administrator"},
+		},
+	}
+}
+
+func TestLDAPProviderAuthenticate(t *testing.T) {
+	standIn := newLDAPStandIn(t, mockDirectoryEntries())
+	defer standIn.close()
+	ldapConf := mockAccountProviderConf(standIn.address())
+	adConf := mockAccountProviderConf(standIn.address())
+	adConf.UsernameAttribute = config.DefaultActiveDirectoryUsernameAttribute
+	unreachableConf := mockAccountProviderConf("ldap://127.0.0.1:1")
+	tests := []struct {
+		name     string
+		provider *ldapProvider
+		userName string
+		password string
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name:     "successful authentication",
+			provider: &ldapProvider{accountProviderType: LDAPService, conf: ldapConf},
+			userName: "alice",
+			password: "alicepassword",
+			want:     []string{"cn=odim-admins,ou=groups,dc=odim,dc=local"},
+		},
+		{
+			name:     "invalid password",
+			provider: &ldapProvider{accountProviderType: LDAPService, conf: ldapConf},
+			userName: "alice",
+			password: "wrongpassword",
+			wantErr:  true,
+		},
+		{
+			name:     "user not present in the directory",
+			provider: &ldapProvider{accountProviderType: LDAPService, conf: ldapConf},
+			userName: "carol",
+			password: "carolpassword",
+			wantErr:  true,
+		},
+		{
+			name:     "active directory user principal name",
+			provider: &ldapProvider{accountProviderType: ActiveDirectoryService, conf: adConf},
+			userName: "alice@odim.local",
+			password: "alicepassword",
+			want:     []string{"cn=odim-admins,ou=groups,dc=odim,dc=local"},
+		},
+		{
+			name:     "directory service not reachable",
+			provider: &ldapProvider{accountProviderType: LDAPService, conf: unreachableConf},
+			userName: "alice",
+			password: "alicepassword",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.provider.Authenticate(mockContext(), tt.userName, tt.password)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Authenticate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Authenticate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthenticateExternalUser(t *testing.T) {
+	config.SetUpMockConfig(t)
+	standIn := newLDAPStandIn(t, mockDirectoryEntries())
+	conf := mockAccountProviderConf(standIn.address())
+	GetAccountProviders = func() []AccountProvider {
+		return []AccountProvider{&ldapProvider{accountProviderType: LDAPService, conf: conf}}
+	}
+	defer func() {
+		GetAccountProviders = getAccountProviders
+		externalAuthorizations = make(map[string]externalAuthorization)
+	}()
+
+	user, err := authenticateExternalUser(mockContext(), "alice", "alicepassword")
+	if err != nil {
+		t.Fatalf("authenticateExternalUser() error = %v", err)
+	}
+	if want := newExternalUser("alice", "Administrator"); !reflect.DeepEqual(user, want) {
+		t.Errorf("authenticateExternalUser() = %v, want %v", user, want)
+	}
+	if _, err = authenticateExternalUser(mockContext(), "bob", "bobpassword"); err == nil {
+		t.Errorf("authenticateExternalUser() expected error for the user without a mapped group")
+	}
+
+	// the authorization is cached for the session lifetime
+	standIn.close()
+	if _, err = authenticateExternalUser(mockContext(), "alice", "alicepassword"); err != nil {
+		t.Errorf("authenticateExternalUser() error = %v, expected the cached authorization", err)
+	}
+	if _, err = authenticateExternalUser(mockContext(), "alice", "wrongpassword"); err == nil {
+		t.Errorf("authenticateExternalUser() expected error for the invalid password")
+	}
+}
+
+func TestMapRemoteGroups(t *testing.T) {
+	mapping := []config.RemoteRoleMapping{
+		{RemoteGroup: "cn=operators,ou=groups,dc=odim,dc=local", LocalRole: "Operator"},
+		{RemoteGroup: "odim-admins", LocalRole: "Administrator"},
+	}
+	tests := []struct {
+		name   string
+		groups []string
+		want   string
+	}{
+		{
+			name:   "mapped by the group name",
+			groups: []string{"CN=odim-admins,OU=groups,DC=odim,DC=local"},
+			want:   "Administrator",
+		},
+		{
+			name:   "mapped by the group distinguished name, first mapping wins",
+			groups: []string{"cn=odim-admins,ou=groups,dc=odim,dc=local", "cn=operators,ou=groups,dc=odim,dc=local"},
+			want:   "Operator",
+		},
+		{
+			name:   "no mapping",
+			groups: []string{"cn=guests,ou=groups,dc=odim,dc=local"},
+			want:   "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mapRemoteGroups(tt.groups, mapping); got != tt.want {
+				t.Errorf("mapRemoteGroups() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmessagebus"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+)
+
+// PublishAccountLocked publishes the security alert raised when an account is locked
+var PublishAccountLocked = asmessagebus.PublishAccountLocked
+
+var (
+	countFailedLogin = asmodel.CountFailedLogin
+	lockAccount      = asmodel.LockAccount
+)
+
+// getAccountLockout returns the lock of the account of the user, or nil if it is not locked
+func getAccountLockout(userName string) (*asmodel.AccountLockout, *errors.Error) {
+	lockout, err := asmodel.GetAccountLockout(userName)
+	if err != nil {
+		if err.ErrNo() == errors.DBKeyNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &lockout, nil
+}
+
+// IsAccountLocked checks whether the account is locked after AccountLockoutThreshold failed login attempts
+func IsAccountLocked(userName string) (bool, *errors.Error) {
+	lockout, err := getAccountLockout(userName)
+	if err != nil || lockout == nil {
+		return false, err
+	}
+	return lockout.IsLocked(time.Now()), nil
+}
+
+// recordFailedLogin counts the failed login attempt of the user and locks the account when
+// AccountLockoutThreshold attempts fail without AccountLockoutCounterResetAfter passing in between.
+// The attempts are counted atomically in the in-memory DB, so the replicas of the service share them.
+// Nothing is counted when AccountLockoutThreshold is 0, the accounts are never locked then.
+func recordFailedLogin(ctx context.Context, userName string) {
+	conf := config.Data.AuthConf
+	if conf.AccountLockoutThreshold == 0 {
+		return
+	}
+	attempts, err := countFailedLogin(userName, conf.AccountLockoutCounterResetAfter)
+	if err != nil {
+		l.LogWithFields(ctx).Error("unable to count the failed login attempt of the user " + userName + ": " + err.Error())
+		return
+	}
+	if attempts < conf.AccountLockoutThreshold {
+		return
+	}
+	lockout := asmodel.AccountLockout{
+		LockedUntil: time.Now().Add(time.Duration(conf.AccountLockoutDuration) * time.Second),
+	}
+	if err = lockAccount(userName, lockout, conf.AccountLockoutDuration); err != nil {
+		// the account is already locked by a concurrent failed login
+		if err.ErrNo() == errors.DBKeyAlreadyExist {
+			return
+		}
+		l.LogWithFields(ctx).Error("unable to lock the account of the user " + userName + ": " + err.Error())
+		return
+	}
+	l.LogWithFields(ctx).Warnf("account %s is locked till %s after %d failed login attempts",
+		userName, lockout.LockedUntil.Format(time.RFC3339), attempts)
+	go PublishAccountLocked(ctx, userName, conf.AccountLockoutThreshold)
+}
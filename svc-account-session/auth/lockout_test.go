@@ -0,0 +1,88 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmessagebus"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+)
+
+func TestRecordFailedLogin(t *testing.T) {
+	config.SetUpMockConfig(t)
+	config.Data.AuthConf.AccountLockoutThreshold = 3
+	config.Data.AuthConf.AccountLockoutDuration = 600
+	config.Data.AuthConf.AccountLockoutCounterResetAfter = 60
+	defer func() {
+		countFailedLogin = asmodel.CountFailedLogin
+		lockAccount = asmodel.LockAccount
+		PublishAccountLocked = asmessagebus.PublishAccountLocked
+	}()
+
+	var attempts, locks int
+	var locked bool
+	countFailedLogin = func(userName string, resetAfter int) (int, *errors.Error) {
+		if resetAfter != 60 {
+			t.Errorf("countFailedLogin() resetAfter = %d, want 60", resetAfter)
+		}
+		attempts++
+		return attempts, nil
+	}
+	lockAccount = func(userName string, lockout asmodel.AccountLockout, duration int) *errors.Error {
+		if duration != 600 {
+			t.Errorf("lockAccount() duration = %d, want 600", duration)
+		}
+		locks++
+		if locked {
+			return errors.PackError(errors.DBKeyAlreadyExist, "account is already locked")
+		}
+		locked = true
+		return nil
+	}
+	publishedCh := make(chan string, 10)
+	PublishAccountLocked = func(ctx context.Context, userName string, threshold int) {
+		publishedCh <- userName
+	}
+
+	// the account is locked and the alert is published only once on reaching the threshold
+	for i := 0; i < 4; i++ {
+		recordFailedLogin(context.Background(), "admin")
+	}
+	if locks != 2 {
+		t.Errorf("recordFailedLogin() locked the account %d times, want 2", locks)
+	}
+	select {
+	case <-publishedCh:
+	case <-time.After(time.Second):
+		t.Fatal("recordFailedLogin() did not publish the alert on locking the account")
+	}
+	time.Sleep(100 * time.Millisecond)
+	if len(publishedCh) != 0 {
+		t.Error("recordFailedLogin() published the alert for the account already locked")
+	}
+
+	// the failed attempts are not counted when the lockout is disabled
+	config.Data.AuthConf.AccountLockoutThreshold = 0
+	attempts = 0
+	recordFailedLogin(context.Background(), "admin")
+	if attempts != 0 {
+		t.Errorf("recordFailedLogin() counted %d attempts with the lockout disabled, want 0", attempts)
+	}
+}
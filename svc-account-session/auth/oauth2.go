@@ -0,0 +1,288 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// signingKeysRefreshInterval is the minimum interval between fetching the signing keys from the issuer
+const signingKeysRefreshInterval = time.Minute
+
+// bearerTokenSigningMethods are the accepted signing algorithms of the bearer tokens
+var bearerTokenSigningMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+// GetHTTPClient returns the client used for fetching the signing keys from the issuer
+var GetHTTPClient = getHTTPClient
+
+var (
+	discoveredSigningKeys     map[string]crypto.PublicKey
+	discoveredSigningKeysTime time.Time
+	discoveredSigningKeysLock sync.Mutex
+)
+
+// jsonWebKey is an RSA or EC public key of a JSON Web Key Set, RFC 7517
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// CheckBearerToken validates the bearer token issued by the configured OAuth 2.0 authorization server
+// and maps the RolesClaim of the token to an ODIM role.
+func CheckBearerToken(ctx context.Context, bearerToken string) (*asmodel.User, *errors.Error) {
+	conf := config.Data.AuthConf.OAuth2
+	if conf == nil || !conf.ServiceEnabled {
+		return nil, errors.PackError(errors.UndefinedErrorType, "error: bearer token authentication is not enabled")
+	}
+	claims := jwt.MapClaims{}
+	parser := jwt.NewParser(jwt.WithValidMethods(bearerTokenSigningMethods))
+	_, err := parser.ParseWithClaims(bearerToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return getSigningKey(ctx, conf, kid)
+	})
+	if err != nil {
+		return nil, errors.PackError(errors.UndefinedErrorType, "error: invalid bearer token: ", err.Error())
+	}
+	if !claims.VerifyIssuer(conf.Issuer, true) {
+		return nil, errors.PackError(errors.UndefinedErrorType, "error: bearer token is not issued by ", conf.Issuer)
+	}
+	if !verifyAudience(claims, conf.Audience) {
+		return nil, errors.PackError(errors.UndefinedErrorType, "error: bearer token is not issued for the audience ", strings.Join(conf.Audience, ", "))
+	}
+	if _, ok := claims["exp"]; !ok {
+		return nil, errors.PackError(errors.UndefinedErrorType, "error: bearer token has no expiry")
+	}
+	userName, _ := claims[conf.UsernameClaim].(string)
+	if userName == "" {
+		return nil, errors.PackError(errors.UndefinedErrorType, "error: bearer token has no ", conf.UsernameClaim, " claim")
+	}
+	roleID := mapRemoteGroups(getClaimValues(claims, conf.RolesClaim), conf.RemoteRoleMapping)
+	if roleID == "" {
+		return nil, errors.PackError(errors.UndefinedErrorType, "error: none of the ", conf.RolesClaim, " of the user ", userName, " is mapped to an ODIM role")
+	}
+	return newExternalUser(userName, roleID), nil
+}
+
+// verifyAudience checks whether the aud claim has any of the configured audiences
+func verifyAudience(claims jwt.MapClaims, audience []string) bool {
+	for _, aud := range audience {
+		if claims.VerifyAudience(aud, true) {
+			return true
+		}
+	}
+	return false
+}
+
+// getClaimValues returns the values of the claim, which is either a string or a list of strings
+func getClaimValues(claims jwt.MapClaims, name string) []string {
+	switch value := claims[name].(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		var values []string
+		for _, v := range value {
+			if s, ok := v.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// getSigningKey returns the key with the kid among the OAuthServiceSigningKeys in the Offline mode,
+// or among the keys published by the issuer in the Discovery mode
+func getSigningKey(ctx context.Context, conf *config.OAuth2, kid string) (crypto.PublicKey, error) {
+	if conf.Mode == config.OAuth2ModeOffline {
+		keySet, err := base64.StdEncoding.DecodeString(conf.OAuthServiceSigningKeys)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode OAuthServiceSigningKeys: %v", err)
+		}
+		keys, err := parseJSONWebKeySet(keySet)
+		if err != nil {
+			return nil, err
+		}
+		return selectSigningKey(keys, kid)
+	}
+
+	discoveredSigningKeysLock.Lock()
+	defer discoveredSigningKeysLock.Unlock()
+	if key, err := selectSigningKey(discoveredSigningKeys, kid); err == nil {
+		return key, nil
+	}
+	// the keys are fetched again when the issuer has rotated them
+	if time.Since(discoveredSigningKeysTime) < signingKeysRefreshInterval {
+		return nil, fmt.Errorf("signing key %s not found", kid)
+	}
+	keys, err := discoverSigningKeys(conf.Issuer)
+	if err != nil {
+		return nil, err
+	}
+	discoveredSigningKeys = keys
+	discoveredSigningKeysTime = time.Now()
+	return selectSigningKey(keys, kid)
+}
+
+// selectSigningKey returns the key with the kid, or the only key when the token has no kid
+func selectSigningKey(keys map[string]crypto.PublicKey, kid string) (crypto.PublicKey, error) {
+	if key, exist := keys[kid]; exist {
+		return key, nil
+	}
+	if kid == "" && len(keys) == 1 {
+		for _, key := range keys {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("signing key %s not found", kid)
+}
+
+// discoverSigningKeys fetches the JSON Web Key Set from the jwks_uri of the OpenID Provider Configuration of the issuer
+func discoverSigningKeys(issuer string) (map[string]crypto.PublicKey, error) {
+	client, err := GetHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	var providerConfiguration struct {
+		JWKSURI string `json:"jwks_uri"`
+	}
+	body, err := httpGet(client, strings.TrimSuffix(issuer, "/")+"/.well-known/openid-configuration")
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(body, &providerConfiguration); err != nil || providerConfiguration.JWKSURI == "" {
+		return nil, fmt.Errorf("unable to find jwks_uri in the OpenID Provider Configuration of %s", issuer)
+	}
+	if body, err = httpGet(client, providerConfiguration.JWKSURI); err != nil {
+		return nil, err
+	}
+	return parseJSONWebKeySet(body)
+}
+
+func httpGet(client *http.Client, uri string) ([]byte, error) {
+	resp, err := client.Get(uri)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch %s: %v", uri, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to fetch %s: %s", uri, resp.Status)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+func getHTTPClient() (*http.Client, error) {
+	httpConf := &config.HTTPConfig{
+		CACertificate: &config.Data.KeyCertConf.RootCACertificate,
+	}
+	return httpConf.GetHTTPClientObj()
+}
+
+// parseJSONWebKeySet returns the signing keys of the key set by their kid, the keys of other types are skipped
+func parseJSONWebKeySet(data []byte) (map[string]crypto.PublicKey, error) {
+	var keySet jsonWebKeySet
+	if err := json.Unmarshal(data, &keySet); err != nil {
+		return nil, fmt.Errorf("unable to parse the JSON Web Key Set: %v", err)
+	}
+	keys := make(map[string]crypto.PublicKey)
+	for _, key := range keySet.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+		publicKey, err := key.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("invalid signing key %s: %v", key.Kid, err)
+		}
+		if publicKey != nil {
+			keys[key.Kid] = publicKey
+		}
+	}
+	return keys, nil
+}
+
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %s", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, fmt.Errorf("point is not on the curve %s", k.Crv)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	}
+	return nil, nil
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	if err != nil || len(data) == 0 {
+		return nil, fmt.Errorf("invalid key parameter %q", value)
+	}
+	return new(big.Int).SetBytes(data), nil
+}
@@ -0,0 +1,264 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const mockIssuer = "https://idp.odim.local"
+
+func mockOAuth2Conf(mode, signingKeys string) *config.OAuth2 {
+	return &config.OAuth2{
+		ServiceEnabled:          true,
+		Mode:                    mode,
+		Issuer:                  mockIssuer,
+		Audience:                []string{"odimra"},
+		OAuthServiceSigningKeys: signingKeys,
+		UsernameClaim:           config.DefaultOAuth2UsernameClaim,
+		RolesClaim:              config.DefaultOAuth2RolesClaim,
+		RemoteRoleMapping: []config.RemoteRoleMapping{
+			{RemoteGroup: "odim-admins", LocalRole: "Administrator"},
+			{RemoteGroup: "odim-readers", LocalRole: "ReadOnly"},
+		},
+	}
+}
+
+func encodeBigInt(value *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(value.Bytes())
+}
+
+func mockJSONWebKeySet(t *testing.T, keys map[string]crypto.PublicKey) []byte {
+	var keySet jsonWebKeySet
+	for kid, key := range keys {
+		switch key := key.(type) {
+		case *rsa.PublicKey:
+			keySet.Keys = append(keySet.Keys, jsonWebKey{Kid: kid, Kty: "RSA", Use: "sig",
+				N: encodeBigInt(key.N), E: encodeBigInt(big.NewInt(int64(key.E)))})
+		case *ecdsa.PublicKey:
+			keySet.Keys = append(keySet.Keys, jsonWebKey{Kid: kid, Kty: "EC",
+				Crv: key.Curve.Params().Name, X: encodeBigInt(key.X), Y: encodeBigInt(key.Y)})
+		}
+	}
+	// keys of other types are skipped
+	keySet.Keys = append(keySet.Keys, jsonWebKey{Kid: "symmetric", Kty: "oct"})
+	data, err := json.Marshal(keySet)
+	if err != nil {
+		t.Fatalf("unable to marshal the key set: %v", err)
+	}
+	return data
+}
+
+func mockBearerToken(t *testing.T, method jwt.SigningMethod, kid string, key crypto.PrivateKey, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("unable to sign the token: %v", err)
+	}
+	return signed
+}
+
+func mockClaims(overrides map[string]interface{}) jwt.MapClaims {
+	claims := jwt.MapClaims{
+		"iss":    mockIssuer,
+		"aud":    []string{"odimra", "other"},
+		"exp":    time.Now().Add(time.Hour).Unix(),
+		"sub":    "carol",
+		"groups": []string{"odim-users", "odim-admins"},
+	}
+	for name, value := range overrides {
+		if value == nil {
+			delete(claims, name)
+			continue
+		}
+		claims[name] = value
+	}
+	return claims
+}
+
+func TestCheckBearerTokenOffline(t *testing.T) {
+	config.SetUpMockConfig(t)
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	otherKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	keySet := mockJSONWebKeySet(t, map[string]crypto.PublicKey{"rsa": &rsaKey.PublicKey, "ec": &ecKey.PublicKey})
+	config.Data.AuthConf.OAuth2 = mockOAuth2Conf(config.OAuth2ModeOffline, base64.StdEncoding.EncodeToString(keySet))
+	defer func() {
+		config.Data.AuthConf.OAuth2 = nil
+	}()
+
+	tests := []struct {
+		name     string
+		token    string
+		wantUser string
+		wantRole string
+		wantErr  bool
+	}{
+		{
+			name:     "RSA signed token",
+			token:    mockBearerToken(t, jwt.SigningMethodRS256, "rsa", rsaKey, mockClaims(nil)),
+			wantUser: "carol",
+			wantRole: "Administrator",
+		},
+		{
+			name:     "EC signed token with the roles claim as a string",
+			token:    mockBearerToken(t, jwt.SigningMethodES256, "ec", ecKey, mockClaims(map[string]interface{}{"groups": "odim-readers", "aud": "odimra"})),
+			wantUser: "carol",
+			wantRole: "ReadOnly",
+		},
+		{
+			name:    "token signed by an unknown key",
+			token:   mockBearerToken(t, jwt.SigningMethodRS256, "rsa", otherKey, mockClaims(nil)),
+			wantErr: true,
+		},
+		{
+			name:    "token with an unknown kid",
+			token:   mockBearerToken(t, jwt.SigningMethodRS256, "unknown", rsaKey, mockClaims(nil)),
+			wantErr: true,
+		},
+		{
+			name:    "symmetric signing method",
+			token:   mockBearerToken(t, jwt.SigningMethodHS256, "symmetric", []byte("secret"), mockClaims(nil)),
+			wantErr: true,
+		},
+		{
+			name:    "token of another issuer",
+			token:   mockBearerToken(t, jwt.SigningMethodRS256, "rsa", rsaKey, mockClaims(map[string]interface{}{"iss": "https://other.odim.local"})),
+			wantErr: true,
+		},
+		{
+			name:    "token for another audience",
+			token:   mockBearerToken(t, jwt.SigningMethodRS256, "rsa", rsaKey, mockClaims(map[string]interface{}{"aud": "other"})),
+			wantErr: true,
+		},
+		{
+			name:    "expired token",
+			token:   mockBearerToken(t, jwt.SigningMethodRS256, "rsa", rsaKey, mockClaims(map[string]interface{}{"exp": time.Now().Add(-time.Minute).Unix()})),
+			wantErr: true,
+		},
+		{
+			name:    "token without expiry",
+			token:   mockBearerToken(t, jwt.SigningMethodRS256, "rsa", rsaKey, mockClaims(map[string]interface{}{"exp": nil})),
+			wantErr: true,
+		},
+		{
+			name:    "token without the username claim",
+			token:   mockBearerToken(t, jwt.SigningMethodRS256, "rsa", rsaKey, mockClaims(map[string]interface{}{"sub": nil})),
+			wantErr: true,
+		},
+		{
+			name:    "token without a mapped role",
+			token:   mockBearerToken(t, jwt.SigningMethodRS256, "rsa", rsaKey, mockClaims(map[string]interface{}{"groups": []string{"odim-users"}})),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := CheckBearerToken(mockContext(), tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckBearerToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if want := newExternalUser(tt.wantUser, tt.wantRole); !reflect.DeepEqual(user, want) {
+				t.Errorf("CheckBearerToken() = %v, want %v", user, want)
+			}
+		})
+	}
+
+	config.Data.AuthConf.OAuth2.ServiceEnabled = false
+	if _, err := CheckBearerToken(mockContext(), tests[0].token); err == nil {
+		t.Errorf("CheckBearerToken() expected error when the bearer token authentication is disabled")
+	}
+}
+
+func TestCheckBearerTokenDiscovery(t *testing.T) {
+	config.SetUpMockConfig(t)
+	oldKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	newKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	keys := map[string]crypto.PublicKey{"old": &oldKey.PublicKey}
+	var keySetRequests int
+	server := httptest.NewTLSServer(nil)
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			json.NewEncoder(w).Encode(map[string]string{"issuer": server.URL, "jwks_uri": server.URL + "/keys"})
+		case "/keys":
+			keySetRequests++
+			w.Write(mockJSONWebKeySet(t, keys))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer server.Close()
+
+	conf := mockOAuth2Conf(config.OAuth2ModeDiscovery, "")
+	config.Data.AuthConf.OAuth2 = conf
+	GetHTTPClient = func() (*http.Client, error) {
+		return server.Client(), nil
+	}
+	defer func() {
+		config.Data.AuthConf.OAuth2 = nil
+		GetHTTPClient = getHTTPClient
+		discoveredSigningKeys = nil
+		discoveredSigningKeysTime = time.Time{}
+	}()
+	// the keys are discovered from the OpenID Provider Configuration of the issuer stand-in
+	conf.Issuer = server.URL
+	claims := mockClaims(map[string]interface{}{"iss": server.URL})
+
+	if _, err := CheckBearerToken(mockContext(), mockBearerToken(t, jwt.SigningMethodRS256, "old", oldKey, claims)); err != nil {
+		t.Fatalf("CheckBearerToken() error = %v", err)
+	}
+	if _, err := CheckBearerToken(mockContext(), mockBearerToken(t, jwt.SigningMethodRS256, "old", oldKey, claims)); err != nil {
+		t.Fatalf("CheckBearerToken() error = %v", err)
+	}
+	if keySetRequests != 1 {
+		t.Errorf("CheckBearerToken() fetched the key set %d times, want the cached key set", keySetRequests)
+	}
+
+	// the key set is fetched again for an unknown kid, but not more often than the refresh interval
+	keys["new"] = &newKey.PublicKey
+	newToken := mockBearerToken(t, jwt.SigningMethodRS256, "new", newKey, claims)
+	if _, err := CheckBearerToken(mockContext(), newToken); err == nil {
+		t.Errorf("CheckBearerToken() expected error within the refresh interval")
+	}
+	discoveredSigningKeysTime = time.Now().Add(-signingKeysRefreshInterval)
+	if _, err := CheckBearerToken(mockContext(), newToken); err != nil {
+		t.Errorf("CheckBearerToken() error = %v, expected the rotated key to be discovered", err)
+	}
+	if keySetRequests != 2 {
+		t.Errorf("CheckBearerToken() fetched the key set %d times, want 2", keySetRequests)
+	}
+}
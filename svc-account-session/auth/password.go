@@ -0,0 +1,43 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package auth
+
+import (
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+)
+
+// PasswordExpiration returns the time at which the password of the user expires after MaxPasswordAgeInDays,
+// or the zero time if the password does not expire
+func PasswordExpiration(user *asmodel.User) time.Time {
+	maxAge := config.Data.AuthConf.PasswordRules.MaxPasswordAgeInDays
+	// the accounts created before the password age was tracked expire only after their next password change
+	if maxAge <= 0 || user.PasswordChangedTime.IsZero() {
+		return time.Time{}
+	}
+	return user.PasswordChangedTime.AddDate(0, 0, maxAge)
+}
+
+// IsPasswordChangeRequired checks whether the user must change the password before accessing the service,
+// either because the password is set by an administrator or because the password is expired
+func IsPasswordChangeRequired(user *asmodel.User, now time.Time) bool {
+	if user.PasswordChangeRequired {
+		return true
+	}
+	expiration := PasswordExpiration(user)
+	return !expiration.IsZero() && !now.Before(expiration)
+}
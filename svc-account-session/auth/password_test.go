@@ -0,0 +1,70 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+)
+
+func TestIsPasswordChangeRequired(t *testing.T) {
+	config.SetUpMockConfig(t)
+	now := time.Now()
+	tests := []struct {
+		name       string
+		maxAgeDays int
+		user       asmodel.User
+		want       bool
+	}{
+		{
+			name:       "password set by an administrator",
+			maxAgeDays: 0,
+			user:       asmodel.User{PasswordChangeRequired: true, PasswordChangedTime: now},
+			want:       true,
+		},
+		{
+			name:       "password does not expire",
+			maxAgeDays: 0,
+			user:       asmodel.User{PasswordChangedTime: now.AddDate(-1, 0, 0)},
+		},
+		{
+			name:       "password expired",
+			maxAgeDays: 90,
+			user:       asmodel.User{PasswordChangedTime: now.AddDate(0, 0, -91)},
+			want:       true,
+		},
+		{
+			name:       "password not expired",
+			maxAgeDays: 90,
+			user:       asmodel.User{PasswordChangedTime: now.AddDate(0, 0, -89)},
+		},
+		{
+			name:       "password age not tracked",
+			maxAgeDays: 90,
+			user:       asmodel.User{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.Data.AuthConf.PasswordRules.MaxPasswordAgeInDays = tt.maxAgeDays
+			if got := IsPasswordChangeRequired(&tt.user, now); got != tt.want {
+				t.Errorf("IsPasswordChangeRequired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -47,18 +47,38 @@ func CheckSessionCreationCredentials(ctx context.Context, userName, password str
 	}
 	user, err := asmodel.GetUserDetails(userName)
 	if err != nil {
+		// the users not present in ODIM are authenticated against the external account providers
+		if err.ErrNo() == errors.DBKeyNotFound && len(GetAccountProviders()) > 0 {
+			return authenticateExternalUser(ctx, userName, password)
+		}
 		return nil, errors.PackError(err.ErrNo(), "error: Invalid username or password :", err.Error())
 	}
-	hash := sha3.New512()
-	hash.Write([]byte(password))
-	hashSum := hash.Sum(nil)
-	hashedPassword := base64.URLEncoding.EncodeToString(hashSum)
-	if user.Password != hashedPassword {
+	lockout, err := getAccountLockout(userName)
+	if err != nil {
+		return nil, errors.PackError(err.ErrNo(), "error while checking session credentials: unable to get the failed login attempts: ", err.Error())
+	}
+	if lockout != nil && lockout.IsLocked(time.Now()) {
+		return nil, errors.PackError(errors.UndefinedErrorType, "error while checking session credentials: account is locked till ", lockout.LockedUntil.Format(time.RFC3339))
+	}
+	if user.Password != HashPassword(password) {
+		recordFailedLogin(ctx, userName)
 		return nil, errors.PackError(errors.UndefinedErrorType, "error while checking session credentials: input password is not matching user password")
 	}
+	// the failed attempts are not counted any more after a successful login
+	if err = asmodel.ResetFailedLogins(userName); err != nil && err.ErrNo() != errors.DBKeyNotFound {
+		l.LogWithFields(ctx).Error("unable to reset the failed login attempts of the user " + userName + ": " + err.Error())
+	}
 	return &user, nil
 }
 
+// HashPassword returns the password hash in the form stored in the User table
+func HashPassword(password string) string {
+	hash := sha3.New512()
+	hash.Write([]byte(password))
+	hashSum := hash.Sum(nil)
+	return base64.URLEncoding.EncodeToString(hashSum)
+}
+
 // CheckSessionTimeOut defines the session validity check
 func CheckSessionTimeOut(ctx context.Context, sessionToken string) (*asmodel.Session, *errors.Error) {
 	var threadID int = 1
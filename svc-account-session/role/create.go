@@ -255,6 +255,11 @@ func Create(ctx context.Context, req *roleproto.RoleRequest, session *asmodel.Se
 	resp.StatusCode = http.StatusCreated
 	resp.StatusMessage = response.ResourceCreated
 
+	resp.Header = map[string]string{
+		"Link":     "</redfish/v1/AccountService/Roles/" + role.ID + "/>; rel=describedby",
+		"Location": "/redfish/v1/AccountService/Roles/" + role.ID,
+	}
+
 	commonResponse.CreateGenericResponse(resp.StatusMessage)
 	commonResponse.ID = createRoleReq.ID
 
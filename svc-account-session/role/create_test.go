@@ -197,6 +197,10 @@ func TestCreate(t *testing.T) {
 			want: response.RPC{
 				StatusCode:    http.StatusCreated,
 				StatusMessage: response.ResourceCreated,
+				Header: map[string]string{
+					"Link":     "</redfish/v1/AccountService/Roles/testRole/>; rel=describedby",
+					"Location": "/redfish/v1/AccountService/Roles/testRole",
+				},
 				Body: asresponse.UserRole{
 					IsPredefined:       false,
 					AssignedPrivileges: []string{common.PrivilegeLogin},
@@ -42,14 +42,15 @@ import (
 // check privileges. and then add the session details in DB
 // respond RPC response and error if there is.
 func CreateNewSession(ctx context.Context, req *sessionproto.SessionCreateRequest) (response.RPC, string) {
-	commonResponse := response.Response{
-		OdataType: common.SessionServiceType,
-		OdataID:   "/redfish/v1/SessionService/Sessions",
-		ID:        "Sessions",
-		Name:      "Session Service",
-	}
 	var resp response.RPC
 
+	if req.BearerToken != "" {
+		return createBearerTokenSession(ctx, req.BearerToken)
+	}
+	if len(req.ClientCertificate) > 0 {
+		return createClientCertificateSession(ctx, req.ClientCertificate)
+	}
+
 	// parsing the CreateSession
 	var createSession asmodel.CreateSession
 	genErr := json.Unmarshal(req.RequestBody, &createSession)
@@ -89,7 +90,65 @@ func CreateNewSession(ctx context.Context, req *sessionproto.SessionCreateReques
 		}
 		return resp, ""
 	}
+	return persistSession(ctx, user, errLogPrefix)
+}
+
+// createBearerTokenSession creates the session for the user identified by the bearer token.
+// Like the sessions of the basic auth requests, the session is deleted by the API service
+// once the request is served.
+func createBearerTokenSession(ctx context.Context, bearerToken string) (response.RPC, string) {
+	user, err := auth.CheckBearerToken(ctx, bearerToken)
+	if err != nil {
+		errMsg := "failed to create session for the bearer token: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		ctx = context.WithValue(ctx, common.StatusCode, int32(http.StatusUnauthorized))
+		customLogs.AuthLog(ctx).Error("Invalid bearer token")
+		return common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errMsg, nil, nil), ""
+	}
+
+	errLogPrefix := fmt.Sprintf("failed to create session for user %s: ", user.UserName)
+	// the bearer token must not grant the session of a local user
+	if _, err = asmodel.GetUserDetails(user.UserName); err == nil {
+		errMsg := errLogPrefix + "the user of the bearer token conflicts with a local user account"
+		l.LogWithFields(ctx).Error(errMsg)
+		ctx = context.WithValue(ctx, common.SessionUserID, user.UserName)
+		ctx = context.WithValue(ctx, common.StatusCode, int32(http.StatusUnauthorized))
+		customLogs.AuthLog(ctx).Error("Invalid bearer token")
+		return common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errMsg, nil, nil), ""
+	} else if err.ErrNo() != errors.DBKeyNotFound {
+		errMsg := errLogPrefix + "Unable to check the local user accounts: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		if err.ErrNo() == errors.DBConnFailed {
+			msgArgs := []interface{}{fmt.Sprintf("%v:%v", config.Data.DBConf.OnDiskHost, config.Data.DBConf.OnDiskPort)}
+			return common.GeneralError(http.StatusServiceUnavailable, response.CouldNotEstablishConnection, errMsg, msgArgs, nil), ""
+		}
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil), ""
+	}
+	return persistSession(ctx, user, errLogPrefix)
+}
+
+// createClientCertificateSession creates the session for the account mapped to the client certificate
+// verified by the API service. Like the sessions of the basic auth requests, the session is deleted
+// by the API service once the request is served.
+func createClientCertificateSession(ctx context.Context, certificate []byte) (response.RPC, string) {
+	user, err := auth.CheckClientCertificate(ctx, certificate)
+	if err != nil {
+		errMsg := "failed to create session for the client certificate: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		if err.ErrNo() == errors.DBConnFailed {
+			msgArgs := []interface{}{fmt.Sprintf("%v:%v", config.Data.DBConf.OnDiskHost, config.Data.DBConf.OnDiskPort)}
+			return common.GeneralError(http.StatusServiceUnavailable, response.CouldNotEstablishConnection, errMsg, msgArgs, nil), ""
+		}
+		ctx = context.WithValue(ctx, common.StatusCode, int32(http.StatusUnauthorized))
+		customLogs.AuthLog(ctx).Error("Invalid client certificate")
+		return common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errMsg, nil, nil), ""
+	}
+	return persistSession(ctx, user, fmt.Sprintf("failed to create session for user %s: ", user.UserName))
+}
 
+// persistSession creates the session for the authenticated user if the role of the user has the Login privilege
+func persistSession(ctx context.Context, user *asmodel.User, errLogPrefix string) (response.RPC, string) {
+	var resp response.RPC
 	role, err := asmodel.GetRoleDetailsByID(user.RoleID)
 	if err != nil {
 		errorMessage := errLogPrefix + "Unable to get role privileges for session creation: " + err.Error()
@@ -104,7 +163,7 @@ func CreateNewSession(ctx context.Context, req *sessionproto.SessionCreateReques
 	//User requires Login privelege to create a session
 	if _, exist := rolePrivilege[common.PrivilegeLogin]; !exist {
 		errorMessage := errLogPrefix + "User doesn't have required privilege to create a session"
-		ctx = context.WithValue(ctx, common.SessionUserID, createSession.UserName)
+		ctx = context.WithValue(ctx, common.SessionUserID, user.UserName)
 		ctx = context.WithValue(ctx, common.SessionRoleID, role.ID)
 		ctx = context.WithValue(ctx, common.StatusCode, int32(http.StatusForbidden))
 		customLogs.AuthLog(ctx).Error(errorMessage)
@@ -112,16 +171,23 @@ func CreateNewSession(ctx context.Context, req *sessionproto.SessionCreateReques
 	}
 
 	currentTime := time.Now()
+	passwordChangeRequired := auth.IsPasswordChangeRequired(user, currentTime)
+	if passwordChangeRequired {
+		// the session can only be used by the user to change the own password
+		l.LogWithFields(ctx).Warnf("the password of the user %s must be changed, the session is restricted to the password change", user.UserName)
+		rolePrivilege = map[string]bool{common.PrivilegeConfigureSelf: true}
+	}
 	sess := asmodel.Session{
-		ID:           uuid.NewV4().String(),
-		Token:        uuid.NewV4().String(),
-		UserName:     user.UserName,
-		RoleID:       user.RoleID,
-		Privileges:   rolePrivilege,
-		CreatedTime:  currentTime,
-		LastUsedTime: currentTime,
-	}
-	l.LogWithFields(ctx).Infof("Creating session for the user %s", createSession.UserName)
+		ID:                     uuid.NewV4().String(),
+		Token:                  uuid.NewV4().String(),
+		UserName:               user.UserName,
+		RoleID:                 user.RoleID,
+		Privileges:             rolePrivilege,
+		CreatedTime:            currentTime,
+		LastUsedTime:           currentTime,
+		PasswordChangeRequired: passwordChangeRequired,
+	}
+	l.LogWithFields(ctx).Infof("Creating session for the user %s", user.UserName)
 	auth.Lock.Lock()
 	defer auth.Lock.Unlock()
 	if err = sess.Persist(); err != nil {
@@ -136,23 +202,36 @@ func CreateNewSession(ctx context.Context, req *sessionproto.SessionCreateReques
 		return resp, ""
 	}
 
-	resp.StatusCode = http.StatusCreated
-	resp.StatusMessage = response.Created
-
-	resp.StatusCode = http.StatusCreated
-	resp.StatusMessage = response.Created
-	resp.Header = map[string]string{
-		"Link":         "</redfish/v1/SessionService/Sessions/" + sess.ID + "/>; rel=self",
-		"X-Auth-Token": sess.Token,
+	resp = sessionCreatedResponse(sess.ID, sess.Token, user.UserName)
+	if passwordChangeRequired {
+		body := resp.Body.(asresponse.Session)
+		body.MessageArgs = []string{"/redfish/v1/AccountService/Accounts/" + user.UserName}
+		body.CreateGenericResponse(response.PasswordChangeRequired)
+		resp.Body = body
 	}
+	return resp, sess.ID
+}
 
-	commonResponse.ID = sess.ID
-	commonResponse.OdataID = "/redfish/v1/SessionService/Sessions/" + commonResponse.ID
+// sessionCreatedResponse returns the response of the session creation with the token of the session
+func sessionCreatedResponse(sessionID, sessionToken, userName string) response.RPC {
+	resp := response.RPC{
+		StatusCode:    http.StatusCreated,
+		StatusMessage: response.Created,
+		Header: map[string]string{
+			"Link":         "</redfish/v1/SessionService/Sessions/" + sessionID + "/>; rel=self",
+			"X-Auth-Token": sessionToken,
+		},
+	}
+	commonResponse := response.Response{
+		OdataType: common.SessionServiceType,
+		OdataID:   "/redfish/v1/SessionService/Sessions/" + sessionID,
+		ID:        sessionID,
+		Name:      "Session Service",
+	}
 	commonResponse.CreateGenericResponse(resp.StatusMessage)
 	resp.Body = asresponse.Session{
 		Response: commonResponse,
-		UserName: createSession.UserName,
+		UserName: userName,
 	}
-
-	return resp, commonResponse.ID
+	return resp
 }
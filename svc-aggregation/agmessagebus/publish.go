@@ -64,8 +64,9 @@ func Publish(ctx context.Context, systemID, eventType, collectionType string) {
 	}
 	data, _ := json.Marshal(messageData)
 	var mbevent = common.Events{
-		IP:      collectionType,
-		Request: data,
+		IP:       collectionType,
+		Request:  data,
+		Internal: true,
 	}
 
 	if err := k.Distribute(mbevent); err != nil {
@@ -76,6 +77,33 @@ func Publish(ctx context.Context, systemID, eventType, collectionType string) {
 
 }
 
+// PublishEvents publishes the events of the resources of the collectionType to the message bus in a single message
+func PublishEvents(ctx context.Context, collectionType string, events []common.Event) {
+	topicName := config.Data.MessageBusConf.OdimControlMessageQueue
+	k, err := dc.Communicator(config.Data.MessageBusConf.MessageBusType, config.Data.MessageBusConf.MessageBusConfigFilePath, topicName)
+	if err != nil {
+		l.LogWithFields(ctx).Error("Unable to connect to " + config.Data.MessageBusConf.MessageBusType + " " + err.Error())
+		return
+	}
+	var messageData = common.MessageData{
+		Name:      "Resource Event",
+		Context:   "/redfish/v1/$metadata#Event.Event",
+		OdataType: common.EventType,
+		Events:    events,
+	}
+	data, _ := json.Marshal(messageData)
+	var mbevent = common.Events{
+		IP:       collectionType,
+		Request:  data,
+		Internal: true,
+	}
+	if err := k.Distribute(mbevent); err != nil {
+		l.LogWithFields(ctx).Error("Unable Publish events to kafka" + err.Error())
+		return
+	}
+	l.LogWithFields(ctx).Info("Events Published")
+}
+
 // PublishCtrlMsg publishes ODIM control messages to the message bus
 func PublishCtrlMsg(msgType common.ControlMessage, msg interface{}) error {
 	topicName := config.Data.MessageBusConf.OdimControlMessageQueue
@@ -0,0 +1,65 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package agmessagebus
+
+import (
+	"encoding/json"
+
+	dc "github.com/ODIM-Project/ODIM/lib-messagebus/datacommunicator"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+)
+
+// SubscribeCtrlMsgQueue subscribes to the control message queue of the aggregation service,
+// the tasks cancelled through the task service are stopped on receiving the CancelTask message.
+// Every replica of the service receives all the control messages, as the task is tracked only
+// by the replica running it.
+func SubscribeCtrlMsgQueue(topicName string) {
+	subscribeCtrlMsgQueue(topicName, consumeCtrlMsg)
+}
+
+// subscribeCtrlMsgQueue hands over each control message of the queue to the consumer
+func subscribeCtrlMsgQueue(topicName string, consume func(event interface{})) {
+	config.TLSConfMutex.RLock()
+	messageBusConfigFilePath := config.Data.MessageBusConf.MessageBusConfigFilePath
+	messageBusType := config.Data.MessageBusConf.MessageBusType
+	config.TLSConfMutex.RUnlock()
+	k, err := dc.Communicator(messageBusType, messageBusConfigFilePath, topicName)
+	if err != nil {
+		l.Log.Error("Unable to connect to " + messageBusType + " " + err.Error())
+		return
+	}
+	err = k.Subscribe(func(offset uint64, event interface{}) {
+		consume(event)
+	})
+	if err != nil {
+		l.Log.Error(err.Error())
+		return
+	}
+}
+
+// consumeCtrlMsg consumes the control messages
+func consumeCtrlMsg(event interface{}) {
+	var ctrlMessage common.ControlMessageData
+	data, _ := json.Marshal(&event)
+	if err := json.Unmarshal(data, &ctrlMessage); err != nil {
+		l.Log.Error("error while unmarshaling the control message: " + err.Error())
+		return
+	}
+	if !common.ProcessCancelTaskMsg(ctrlMessage) {
+		l.Log.Warn("unable to process the control message of type ", ctrlMessage.MessageType)
+	}
+}
@@ -0,0 +1,77 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package agmessagebus
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	dc "github.com/ODIM-Project/ODIM/lib-messagebus/datacommunicator"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+)
+
+func TestSubscribeCtrlMsgQueue(t *testing.T) {
+	config.SetUpMockConfig(t)
+	config.Data.MessageBusConf.MessageBusType = dc.INMEMORY
+	topicName := common.TaskControlMessageQueue(common.AggregationService)
+
+	// the first replica does not run the task, the second one tracks it
+	received := make(chan interface{}, 10)
+	go subscribeCtrlMsgQueue(topicName, func(event interface{}) {
+		received <- event
+	})
+	go subscribeCtrlMsgQueue(topicName, consumeCtrlMsg)
+	time.Sleep(200 * time.Millisecond)
+
+	const tasks = 5
+	var contexts []context.Context
+	for i := 0; i < tasks; i++ {
+		taskID := "task" + strconv.Itoa(i)
+		ctx, done := common.TrackTask(context.Background(), taskID)
+		defer done()
+		contexts = append(contexts, ctx)
+
+		k, err := dc.Communicator(dc.INMEMORY, "", topicName)
+		if err != nil {
+			t.Fatalf("error: %v", err)
+		}
+		ctrlMsg := common.ControlMessageData{
+			MessageType: common.CancelTask,
+			Data:        common.CancelTaskData{TaskID: taskID},
+		}
+		if err := k.Distribute(ctrlMsg); err != nil {
+			t.Fatalf("error: %v", err)
+		}
+	}
+
+	timeout := time.After(5 * time.Second)
+	for i, ctx := range contexts {
+		select {
+		case <-ctx.Done():
+		case <-timeout:
+			t.Fatalf("task%d is not cancelled by the replica running it", i)
+		}
+	}
+	for i := 0; i < tasks; i++ {
+		select {
+		case <-received:
+		case <-timeout:
+			t.Fatalf("the other replica received %d of %d control messages", i, tasks)
+		}
+	}
+}
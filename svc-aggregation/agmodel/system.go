@@ -16,12 +16,14 @@
 package agmodel
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"strings"
+	"time"
 
 	dmtfmodel "github.com/ODIM-Project/ODIM/lib-dmtf/model"
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
@@ -111,7 +113,19 @@ type SNMP struct {
 
 // Aggregate payload is used for perform the operations on Aggregate
 type Aggregate struct {
-	Elements []OdataID `json:"Elements"`
+	Elements []OdataID          `json:"Elements"`
+	Settings *AggregateSettings `json:"Settings,omitempty"`
+}
+
+// AggregateSettings is the desired BIOS and boot settings baseline of the elements of an aggregate
+type AggregateSettings struct {
+	Attributes map[string]interface{} `json:"Attributes,omitempty"`
+	Boot       *AggregateBootSettings `json:"Boot,omitempty"`
+}
+
+// AggregateBootSettings is the desired boot settings of the elements of an aggregate
+type AggregateBootSettings struct {
+	BootOrder []string `json:"BootOrder"`
 }
 
 // ConnectionMethod payload is used for perform the operations on connection method
@@ -121,6 +135,151 @@ type ConnectionMethod struct {
 	Links                   Links  `json:"Links"`
 }
 
+// DiscoveredAggregationSource holds a Redfish service found by the network discovery,
+// which is not yet added as an aggregation source
+type DiscoveredAggregationSource struct {
+	HostName         string   `json:"HostName"`
+	ServiceRootUUID  string   `json:"ServiceRootUUID,omitempty"`
+	RedfishVersion   string   `json:"RedfishVersion,omitempty"`
+	Vendor           string   `json:"Vendor,omitempty"`
+	Model            string   `json:"Model,omitempty"`
+	FirmwareVersion  string   `json:"FirmwareVersion,omitempty"`
+	ConnectionMethod *OdataID `json:"ConnectionMethod,omitempty"`
+	DiscoveryTask    string   `json:"DiscoveryTask"`
+	DiscoveredTime   string   `json:"DiscoveredTime"`
+}
+
+// CredentialRotation is the state of the password rotation of a BMC aggregation source
+type CredentialRotation struct {
+	Status          string `json:"Status"`
+	Message         string `json:"Message,omitempty"`
+	StartTime       string `json:"StartTime"`
+	LastRotatedTime string `json:"LastRotatedTime,omitempty"`
+}
+
+// InventorySync holds the policies of the scheduled inventory synchronization of the servers
+type InventorySync struct {
+	MaxConcurrency    int                   `json:"MaxConcurrency"`
+	MaintenanceWindow *MaintenanceWindow    `json:"MaintenanceWindow,omitempty"`
+	Policies          []InventorySyncPolicy `json:"Policies"`
+}
+
+// MaintenanceWindow is the daily window in which the scheduled inventory synchronizations are started
+type MaintenanceWindow struct {
+	MaintenanceWindowStartTime         string `json:"MaintenanceWindowStartTime"`
+	MaintenanceWindowDurationInSeconds int    `json:"MaintenanceWindowDurationInSeconds"`
+}
+
+// InventorySyncPolicy synchronizes the servers of an aggregate or of a connection method on a cron schedule
+type InventorySyncPolicy struct {
+	Name     string  `json:"Name"`
+	Schedule string  `json:"Schedule"`
+	Scope    OdataID `json:"Scope"`
+}
+
+// InventorySyncStatus is the state of the scheduled inventory synchronization of a system
+type InventorySyncStatus struct {
+	Policy                 string `json:"Policy"`
+	LastSyncTime           string `json:"LastSyncTime"`
+	LastSuccessfulSyncTime string `json:"LastSuccessfulSyncTime,omitempty"`
+	LastSyncError          string `json:"LastSyncError,omitempty"`
+}
+
+// InventorySyncSchedule is the progress of the scheduled inventory synchronization, shared by the replicas of the service
+type InventorySyncSchedule struct {
+	// LastRunTimes holds the time each policy last ran, keyed by the policy name
+	LastRunTimes map[string]time.Time `json:"LastRunTimes"`
+	// Pending holds the BMCs which are due for synchronization, in the order they became due
+	Pending []PendingInventorySync `json:"Pending"`
+}
+
+// PendingInventorySync is a BMC due for synchronization by the policy
+type PendingInventorySync struct {
+	DeviceUUID string `json:"DeviceUUID"`
+	Policy     string `json:"Policy"`
+}
+
+// InventoryHistory holds the inventory changes of a system found by its rediscoveries, oldest first.
+// The inventory of the system can be rebuilt for any time from StartTime by reverting the later changes
+type InventoryHistory struct {
+	StartTime string                  `json:"StartTime"`
+	Entries   []InventoryHistoryEntry `json:"Entries"`
+}
+
+// ConformanceReport holds the result of validating a system against a Redfish Interop profile and the profiles
+// it requires. Result is the worst result of the requirements, Results holds the requirements which are not met
+// and Operations the support of the actions and property writes the profiles require
+type ConformanceReport struct {
+	Profiles       []InteropProfile    `json:"Profiles"`
+	ValidationTime string              `json:"ValidationTime"`
+	Result         string              `json:"Result"`
+	Counts         ConformanceCounts   `json:"Counts"`
+	Operations     []OperationSupport  `json:"Operations"`
+	Results        []RequirementResult `json:"Results"`
+}
+
+// InteropProfile is the name and the version of a Redfish Interop profile
+type InteropProfile struct {
+	Name    string `json:"Name"`
+	Version string `json:"Version"`
+}
+
+// ConformanceCounts holds the number of the evaluated requirements for each result
+type ConformanceCounts struct {
+	Pass      int `json:"Pass"`
+	Warning   int `json:"Warning"`
+	Fail      int `json:"Fail"`
+	NotTested int `json:"NotTested"`
+}
+
+// OperationSupport is the support of an action or a property write the profile requires, Operation is the action
+// name or PATCH for a property write, and Support is one of Supported, NotSupported and Unknown.
+// AllowableValues holds the values the BMC allows for the action parameters
+type OperationSupport struct {
+	Operation       string              `json:"Operation"`
+	Resource        string              `json:"Resource"`
+	Property        string              `json:"Property,omitempty"`
+	Support         string              `json:"Support"`
+	AllowableValues map[string][]string `json:"AllowableValues,omitempty"`
+	Message         string              `json:"Message,omitempty"`
+}
+
+// RequirementResult is the result of a requirement of the profile for a resource, Resource is the resource type
+// when no resource of the type is found. Result is one of Pass, Warning, Fail and NotTested
+type RequirementResult struct {
+	Profile     string `json:"Profile"`
+	Resource    string `json:"Resource"`
+	Property    string `json:"Property,omitempty"`
+	Requirement string `json:"Requirement"`
+	Result      string `json:"Result"`
+	Message     string `json:"Message,omitempty"`
+}
+
+// InventoryHistoryEntry holds the changes found by a rediscovery of the system
+type InventoryHistoryEntry struct {
+	Timestamp string            `json:"Timestamp"`
+	Changes   []InventoryChange `json:"Changes"`
+}
+
+// InventoryChange is the change of a resource of the inventory. ChangeType is one of Added, Removed and Modified,
+// OldResource holds a removed resource and Properties the changed properties of a modified resource
+type InventoryChange struct {
+	Resource    string           `json:"Resource"`
+	ChangeType  string           `json:"ChangeType"`
+	OldResource interface{}      `json:"OldResource,omitempty"`
+	Properties  []PropertyChange `json:"Properties,omitempty"`
+}
+
+// PropertyChange is the change of a property of a modified resource, Path is the JSON pointer of the property.
+// ChangeType is one of Added, Removed and Modified, OldValue is not set for an added property
+// and NewValue is not set for a removed property
+type PropertyChange struct {
+	Path       string      `json:"Path"`
+	ChangeType string      `json:"ChangeType"`
+	OldValue   interface{} `json:"OldValue,omitempty"`
+	NewValue   interface{} `json:"NewValue,omitempty"`
+}
+
 // Links is payload of aggregation resources
 type Links struct {
 	AggregationSources []OdataID `json:"AggregationSources"`
@@ -920,9 +1079,9 @@ func AddElementsToAggregate(aggregate Aggregate, aggregateURL string) *errors.Er
 	if err != nil {
 		return err
 	}
-	aggregate.Elements = append(aggregate.Elements, agg.Elements...)
+	agg.Elements = append(aggregate.Elements, agg.Elements...)
 	const table string = "Aggregate"
-	if _, err := conn.Update(table, aggregateURL, aggregate); err != nil {
+	if _, err := conn.Update(table, aggregateURL, agg); err != nil {
 		return err
 	}
 	return nil
@@ -938,10 +1097,28 @@ func RemoveElementsFromAggregate(aggregate Aggregate, aggregateURL string) *erro
 	if err != nil {
 		return err
 	}
-	aggregate.Elements = removeElements(aggregate.Elements, agg.Elements)
+	agg.Elements = removeElements(aggregate.Elements, agg.Elements)
+
+	const table string = "Aggregate"
+	if _, err := conn.Update(table, aggregateURL, agg); err != nil {
+		return err
+	}
+	return nil
+}
 
+// UpdateAggregateSettings replaces the settings baseline of the aggregate
+func UpdateAggregateSettings(settings AggregateSettings, aggregateURL string) *errors.Error {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return err
+	}
+	agg, err := GetAggregate(aggregateURL)
+	if err != nil {
+		return err
+	}
+	agg.Settings = &settings
 	const table string = "Aggregate"
-	if _, err := conn.Update(table, aggregateURL, aggregate); err != nil {
+	if _, err := conn.Update(table, aggregateURL, agg); err != nil {
 		return err
 	}
 	return nil
@@ -1258,3 +1435,320 @@ func SaveBMCInventory(data map[string]interface{}) error {
 	}
 	return nil
 }
+
+// SaveDiscoveredAggregationSource saves the discovered aggregation source, replacing the one already saved with the same URI
+func SaveDiscoveredAggregationSource(discoveredSource DiscoveredAggregationSource, discoveredSourceURI string) *errors.Error {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return err
+	}
+	if err = conn.AddResourceData("DiscoveredAggregationSource", discoveredSourceURI, discoveredSource); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetDiscoveredAggregationSource fetches the discovered aggregation source for the given discoveredSourceURI
+func GetDiscoveredAggregationSource(discoveredSourceURI string) (DiscoveredAggregationSource, *errors.Error) {
+	var discoveredSource DiscoveredAggregationSource
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return discoveredSource, err
+	}
+	data, err := conn.Read("DiscoveredAggregationSource", discoveredSourceURI)
+	if err != nil {
+		return discoveredSource, errors.PackError(err.ErrNo(), "error: while trying to fetch discovered aggregation source data: ", err.Error())
+	}
+	if err := json.Unmarshal([]byte(data), &discoveredSource); err != nil {
+		return discoveredSource, errors.PackError(errors.JSONUnmarshalFailed, err)
+	}
+	return discoveredSource, nil
+}
+
+// SaveCredentialRotation saves the state of the password rotation of the aggregation source with the given aggregationSourceURI
+func SaveCredentialRotation(rotation CredentialRotation, aggregationSourceURI string) *errors.Error {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return err
+	}
+	if err = conn.AddResourceData("CredentialRotation", aggregationSourceURI, rotation); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetCredentialRotation fetches the state of the password rotation of the aggregation source with the given aggregationSourceURI
+func GetCredentialRotation(aggregationSourceURI string) (CredentialRotation, *errors.Error) {
+	var rotation CredentialRotation
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return rotation, err
+	}
+	data, err := conn.Read("CredentialRotation", aggregationSourceURI)
+	if err != nil {
+		return rotation, errors.PackError(err.ErrNo(), "error: while trying to fetch credential rotation data: ", err.Error())
+	}
+	if err := json.Unmarshal([]byte(data), &rotation); err != nil {
+		return rotation, errors.PackError(errors.JSONUnmarshalFailed, err)
+	}
+	return rotation, nil
+}
+
+// GetStoredPassword fetches the encrypted password of the entry with the given key in the table,
+// the password is nil when the entry has no password
+func GetStoredPassword(table, key string) ([]byte, *errors.Error) {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return nil, err
+	}
+	data, err := conn.Read(table, key)
+	if err != nil {
+		return nil, errors.PackError(err.ErrNo(), "error: while trying to fetch the password: ", err.Error())
+	}
+	var entry struct {
+		Password []byte `json:"Password"`
+	}
+	if err := json.Unmarshal([]byte(data), &entry); err != nil {
+		return nil, errors.PackError(errors.JSONUnmarshalFailed, err)
+	}
+	return entry.Password, nil
+}
+
+// UpdateStoredPassword replaces the encrypted password of the entry with the given key in the table.
+// The password is replaced only when it is still oldPassword, so that a password changed
+// in the meantime by updating the entry is not overwritten
+func UpdateStoredPassword(table, key string, oldPassword, newPassword []byte) *errors.Error {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return err
+	}
+	data, err := conn.Read(table, key)
+	if err != nil {
+		return errors.PackError(err.ErrNo(), "error: while trying to fetch the password: ", err.Error())
+	}
+	// the entry is updated as raw JSON to keep the fields which are not part of the model
+	var entry map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(data), &entry); err != nil {
+		return errors.PackError(errors.JSONUnmarshalFailed, err)
+	}
+	var storedPassword []byte
+	if err := json.Unmarshal(entry["Password"], &storedPassword); err != nil || !bytes.Equal(storedPassword, oldPassword) {
+		return errors.PackError(errors.DBUpdateFailed, "error: password of ", table, ":", key, " is changed")
+	}
+	password, jerr := json.Marshal(newPassword)
+	if jerr != nil {
+		return errors.PackError(errors.UndefinedErrorType, jerr)
+	}
+	entry["Password"] = password
+	if _, err := conn.Update(table, key, entry); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetDeviceInventory fetches the resources of the BMC with the given deviceUUID, keyed by their URI
+func GetDeviceInventory(deviceUUID string) (map[string]string, *errors.Error) {
+	conn, err := common.GetDBConnection(common.InMemory)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := conn.GetAllMatchingDetails("*", deviceUUID)
+	if err != nil {
+		return nil, err
+	}
+	inventory := make(map[string]string, len(keys))
+	for _, key := range keys {
+		resourceDetails := strings.SplitN(key, ":", 2)
+		if len(resourceDetails) != 2 || !strings.HasPrefix(resourceDetails[1], "/redfish/v1/") {
+			// the entries like SystemReset and SystemOperation are not resources
+			continue
+		}
+		switch resourceDetails[0] {
+		case "SystemReset", "SystemOperation":
+			continue
+		}
+		resource, err := GetResource(resourceDetails[0], resourceDetails[1])
+		if err != nil {
+			if err.ErrNo() == errors.DBKeyNotFound {
+				continue
+			}
+			return nil, err
+		}
+		inventory[resourceDetails[1]] = resource
+	}
+	return inventory, nil
+}
+
+// SaveInventoryHistory saves the inventory history of the system with the given systemID
+func SaveInventoryHistory(history InventoryHistory, systemID string) *errors.Error {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return err
+	}
+	if err = conn.AddResourceData("InventoryHistory", systemID, history); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetInventoryHistory fetches the inventory history of the system with the given systemID
+func GetInventoryHistory(systemID string) (InventoryHistory, *errors.Error) {
+	var history InventoryHistory
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return history, err
+	}
+	data, err := conn.Read("InventoryHistory", systemID)
+	if err != nil {
+		return history, errors.PackError(err.ErrNo(), "error: while trying to fetch inventory history: ", err.Error())
+	}
+	if err := json.Unmarshal([]byte(data), &history); err != nil {
+		return history, errors.PackError(errors.JSONUnmarshalFailed, err)
+	}
+	return history, nil
+}
+
+// GetResourceETags fetches the ETags of the stored resources of the BMC with the given deviceUUID, keyed by their URI
+func GetResourceETags(deviceUUID string) (map[string]string, *errors.Error) {
+	etags := make(map[string]string)
+	conn, err := common.GetDBConnection(common.InMemory)
+	if err != nil {
+		return etags, err
+	}
+	data, err := conn.Read("ResourceETags", deviceUUID)
+	if err != nil {
+		return etags, errors.PackError(err.ErrNo(), "error: while trying to fetch resource ETags: ", err.Error())
+	}
+	if err := json.Unmarshal([]byte(data), &etags); err != nil {
+		return etags, errors.PackError(errors.JSONUnmarshalFailed, err)
+	}
+	return etags, nil
+}
+
+// SaveResourceETags saves the ETags of the stored resources of the BMC with the given deviceUUID
+func SaveResourceETags(etags map[string]string, deviceUUID string) *errors.Error {
+	conn, err := common.GetDBConnection(common.InMemory)
+	if err != nil {
+		return err
+	}
+	if err = conn.AddResourceData("ResourceETags", deviceUUID, etags); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetInventorySync fetches the policies of the scheduled inventory synchronization
+func GetInventorySync() (InventorySync, *errors.Error) {
+	var inventorySync InventorySync
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return inventorySync, err
+	}
+	data, err := conn.Read("InventorySync", "InventorySync")
+	if err != nil {
+		return inventorySync, errors.PackError(err.ErrNo(), "error: while trying to fetch inventory synchronization policies: ", err.Error())
+	}
+	if err := json.Unmarshal([]byte(data), &inventorySync); err != nil {
+		return inventorySync, errors.PackError(errors.JSONUnmarshalFailed, err)
+	}
+	return inventorySync, nil
+}
+
+// SaveInventorySync saves the policies of the scheduled inventory synchronization
+func SaveInventorySync(inventorySync InventorySync) *errors.Error {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return err
+	}
+	if err = conn.AddResourceData("InventorySync", "InventorySync", inventorySync); err != nil {
+		return err
+	}
+	return nil
+}
+
+// SaveInventorySyncStatus saves the state of the scheduled inventory synchronization of the system with the given systemID
+func SaveInventorySyncStatus(status InventorySyncStatus, systemID string) *errors.Error {
+	conn, err := common.GetDBConnection(common.InMemory)
+	if err != nil {
+		return err
+	}
+	if err = conn.AddResourceData("InventorySyncStatus", systemID, status); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetInventorySyncStatus fetches the state of the scheduled inventory synchronization of the system with the given systemID
+func GetInventorySyncStatus(systemID string) (InventorySyncStatus, *errors.Error) {
+	var status InventorySyncStatus
+	conn, err := common.GetDBConnection(common.InMemory)
+	if err != nil {
+		return status, err
+	}
+	data, err := conn.Read("InventorySyncStatus", systemID)
+	if err != nil {
+		return status, errors.PackError(err.ErrNo(), "error: while trying to fetch inventory synchronization status: ", err.Error())
+	}
+	if err := json.Unmarshal([]byte(data), &status); err != nil {
+		return status, errors.PackError(errors.JSONUnmarshalFailed, err)
+	}
+	return status, nil
+}
+
+// GetInventorySyncSchedule fetches the progress of the scheduled inventory synchronization
+func GetInventorySyncSchedule() (InventorySyncSchedule, *errors.Error) {
+	var schedule InventorySyncSchedule
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return schedule, err
+	}
+	data, err := conn.Read("InventorySyncSchedule", "InventorySyncSchedule")
+	if err != nil {
+		return schedule, errors.PackError(err.ErrNo(), "error: while trying to fetch inventory synchronization schedule: ", err.Error())
+	}
+	if err := json.Unmarshal([]byte(data), &schedule); err != nil {
+		return schedule, errors.PackError(errors.JSONUnmarshalFailed, err)
+	}
+	return schedule, nil
+}
+
+// SaveInventorySyncSchedule saves the progress of the scheduled inventory synchronization
+func SaveInventorySyncSchedule(schedule InventorySyncSchedule) *errors.Error {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return err
+	}
+	if err = conn.AddResourceData("InventorySyncSchedule", "InventorySyncSchedule", schedule); err != nil {
+		return err
+	}
+	return nil
+}
+
+// SaveConformanceReport saves the conformance report of the system with the given systemID
+func SaveConformanceReport(report ConformanceReport, systemID string) *errors.Error {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return err
+	}
+	if err = conn.AddResourceData("ConformanceReport", systemID, report); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetConformanceReport fetches the conformance report of the system with the given systemID
+func GetConformanceReport(systemID string) (ConformanceReport, *errors.Error) {
+	var report ConformanceReport
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return report, err
+	}
+	data, err := conn.Read("ConformanceReport", systemID)
+	if err != nil {
+		return report, errors.PackError(err.ErrNo(), "error: while trying to fetch conformance report: ", err.Error())
+	}
+	if err := json.Unmarshal([]byte(data), &report); err != nil {
+		return report, errors.PackError(errors.JSONUnmarshalFailed, err)
+	}
+	return report, nil
+}
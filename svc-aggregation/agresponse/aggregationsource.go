@@ -54,6 +54,42 @@ type AggregateGetResponse struct {
 	ElementsCount int               `json:"ElementsCount,omitempty"`
 	Elements      []agmodel.OdataID `json:"Elements"`
 	Actions       AggregateActions  `json:"Actions,omitempty"`
+	Oem           *AggregateOem     `json:"Oem,omitempty"`
+}
+
+// AggregateOem defines the Oem properties of an aggregate
+type AggregateOem struct {
+	Odim AggregateOdim `json:"Odim"`
+}
+
+// AggregateOdim defines the settings baseline of an aggregate and the link to the drift of its elements
+type AggregateOdim struct {
+	SettingsBaseline agmodel.AggregateSettings `json:"SettingsBaseline"`
+	SettingsDrift    OdataID                   `json:"SettingsDrift"`
+}
+
+// AggregateSettingsDriftResponse defines the response for the drift of the elements of an aggregate from its
+// settings baseline
+type AggregateSettingsDriftResponse struct {
+	response.Response
+	Baseline       agmodel.AggregateSettings `json:"Baseline"`
+	MembersInDrift int                       `json:"MembersInDrift"`
+	Members        []SettingsDriftMember     `json:"Members"`
+}
+
+// SettingsDriftMember defines the drift of an element of an aggregate, State is one of Compliant, Drifted and Unknown
+type SettingsDriftMember struct {
+	System  OdataID        `json:"System"`
+	State   string         `json:"State"`
+	Drift   []SettingDrift `json:"Drift"`
+	Message string         `json:"Message,omitempty"`
+}
+
+// SettingDrift defines the desired and the current value of a setting which drifted from the baseline
+type SettingDrift struct {
+	Property string      `json:"Property"`
+	Desired  interface{} `json:"Desired"`
+	Current  interface{} `json:"Current"`
 }
 
 // AggregateActions defines the links to the actions available under the service
@@ -62,4 +98,5 @@ type AggregateActions struct {
 	AggregateSetDefaultBootOrder Action `json:"#Aggregate.SetDefaultBootOrder"`
 	AggregateAddElements         Action `json:"#Aggregate.AddElements"`
 	AggregateRemoveElements      Action `json:"#Aggregate.RemoveElements"`
+	AggregateApplySettings       Action `json:"#Aggregate.ApplySettings"`
 }
@@ -18,6 +18,7 @@ package agresponse
 import (
 	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
 )
 
 // ResetResponse ...
@@ -47,8 +48,18 @@ type AggregationServiceResponse struct {
 
 //Actions struct definition
 type Actions struct {
-	Reset               Action `json:"#AggregationService.Reset"`
-	SetDefaultBootOrder Action `json:"#AggregationService.SetDefaultBootOrder"`
+	Reset               Action      `json:"#AggregationService.Reset"`
+	SetDefaultBootOrder Action      `json:"#AggregationService.SetDefaultBootOrder"`
+	Oem                 *OemActions `json:"Oem,omitempty"`
+}
+
+// OemActions struct definition
+type OemActions struct {
+	BulkAddAggregationSources           Action `json:"#Odim.BulkAddAggregationSources"`
+	DiscoverAggregationSources          Action `json:"#Odim.DiscoverAggregationSources"`
+	ApproveDiscoveredAggregationSources Action `json:"#Odim.ApproveDiscoveredAggregationSources"`
+	RotateAggregationSourceCredentials  Action `json:"#Odim.RotateAggregationSourceCredentials"`
+	ValidateInteropProfile              Action `json:"#Odim.ValidateInteropProfile"`
 }
 
 //Status struct definition
@@ -64,6 +75,104 @@ type Action struct {
 	ActionInfo string `json:"@Redfish.ActionInfo,omitempty"`
 }
 
+// BulkAddAggregationSourcesResponse is the report of the bulk add of the aggregation sources
+type BulkAddAggregationSourcesResponse struct {
+	Message   string              `json:"Message"`
+	Succeeded []BulkAddHostResult `json:"Succeeded"`
+	Failed    []BulkAddHostResult `json:"Failed"`
+}
+
+// BulkAddHostResult is the result of adding a host of the bulk add request
+type BulkAddHostResult struct {
+	HostName          string   `json:"HostName"`
+	SubTask           *OdataID `json:"SubTask,omitempty"`
+	AggregationSource *OdataID `json:"AggregationSource,omitempty"`
+	StatusCode        int32    `json:"StatusCode"`
+	MessageID         string   `json:"MessageId,omitempty"`
+	Message           string   `json:"Message,omitempty"`
+}
+
+// DiscoverAggregationSourcesResponse is the report of the network discovery of the aggregation sources
+type DiscoverAggregationSourcesResponse struct {
+	Message                      string    `json:"Message"`
+	DiscoveredAggregationSources []OdataID `json:"DiscoveredAggregationSources"`
+}
+
+// DiscoveredAggregationSourceResponse defines the response for a discovered aggregation source
+type DiscoveredAggregationSourceResponse struct {
+	response.Response
+	HostName        string                           `json:"HostName"`
+	ServiceRootUUID string                           `json:"ServiceRootUUID,omitempty"`
+	RedfishVersion  string                           `json:"RedfishVersion,omitempty"`
+	Vendor          string                           `json:"Vendor,omitempty"`
+	Model           string                           `json:"Model,omitempty"`
+	FirmwareVersion string                           `json:"FirmwareVersion,omitempty"`
+	DiscoveredTime  string                           `json:"DiscoveredTime"`
+	Links           DiscoveredAggregationSourceLinks `json:"Links"`
+}
+
+// InventoryHistoryResponse defines the response for the inventory history of a system. It holds the recorded
+// changes, or with the AsOf query the inventory at the time, or with the From and To query the changes in the period
+type InventoryHistoryResponse struct {
+	response.Response
+	StartTime string                          `json:"StartTime"`
+	Entries   []agmodel.InventoryHistoryEntry `json:"Entries,omitempty"`
+	AsOf      string                          `json:"AsOf,omitempty"`
+	Inventory map[string]interface{}          `json:"Inventory,omitempty"`
+	From      string                          `json:"From,omitempty"`
+	To        string                          `json:"To,omitempty"`
+	Changes   []agmodel.InventoryChange       `json:"Changes,omitempty"`
+}
+
+// InventorySyncResponse defines the response for the policies of the scheduled inventory synchronization
+type InventorySyncResponse struct {
+	response.Response
+	MaxConcurrency    int                           `json:"MaxConcurrency"`
+	MaintenanceWindow *agmodel.MaintenanceWindow    `json:"MaintenanceWindow"`
+	Policies          []agmodel.InventorySyncPolicy `json:"Policies"`
+}
+
+// ConformanceReportResponse defines the response for the conformance report of a system
+type ConformanceReportResponse struct {
+	response.Response
+	System OdataID `json:"System"`
+	agmodel.ConformanceReport
+}
+
+// ValidateInteropProfileResponse is the report of the validation of the systems against a Redfish Interop profile
+type ValidateInteropProfileResponse struct {
+	Message            string                    `json:"Message"`
+	Profile            string                    `json:"Profile"`
+	ConformanceReports []ConformanceReportResult `json:"ConformanceReports"`
+}
+
+// ConformanceReportResult is the result of validating a system against a Redfish Interop profile
+type ConformanceReportResult struct {
+	ConformanceReport OdataID `json:"ConformanceReport"`
+	System            OdataID `json:"System"`
+	Result            string  `json:"Result"`
+}
+
+// DiscoveredAggregationSourceLinks defines the links of a discovered aggregation source
+type DiscoveredAggregationSourceLinks struct {
+	ConnectionMethod *agmodel.OdataID `json:"ConnectionMethod,omitempty"`
+	DiscoveryTask    agmodel.OdataID  `json:"DiscoveryTask"`
+}
+
+// RotateAggregationSourceCredentialsResponse is the report of the password rotation of the aggregation sources
+type RotateAggregationSourceCredentialsResponse struct {
+	Message   string                     `json:"Message"`
+	Succeeded []CredentialRotationResult `json:"Succeeded"`
+	Failed    []CredentialRotationResult `json:"Failed"`
+}
+
+// CredentialRotationResult is the result of rotating the password of an aggregation source
+type CredentialRotationResult struct {
+	AggregationSource OdataID `json:"AggregationSource"`
+	Status            string  `json:"Status"`
+	Message           string  `json:"Message,omitempty"`
+}
+
 //OdataID struct definition for @odata.id
 type OdataID struct {
 	OdataID string `json:"@odata.id"`
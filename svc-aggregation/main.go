@@ -99,13 +99,41 @@ func main() {
 	// Rediscover the Resources by looking in OnDisk DB, populate the resources in InMemory DB
 	//This happens only if the InMemory DB lost it contents due to DB reboot or host VM reboot.
 	p := system.ExternalInterface{
-		ContactClient:   pmbhandle.ContactPlugin,
-		Auth:            services.IsAuthorized,
-		PublishEventMB:  agmessagebus.Publish,
-		GetPluginStatus: agcommon.GetPluginStatus,
-		SubscribeToEMB:  services.SubscribeToEMB,
-		DecryptPassword: common.DecryptWithPrivateKey,
-		UpdateTask:      system.UpdateTaskData,
+		ContactClient:               pmbhandle.ContactPlugin,
+		Auth:                        services.IsAuthorized,
+		PublishEventMB:              agmessagebus.Publish,
+		GetPluginStatus:             agcommon.GetPluginStatus,
+		SubscribeToEMB:              services.SubscribeToEMB,
+		DecryptPassword:             common.DecryptWithPrivateKey,
+		UpdateTask:                  system.UpdateTaskData,
+		EncryptPassword:             common.EncryptWithPublicKey,
+		DeleteSecret:                common.DeleteSecret,
+		GetAllKeysFromTable:         agmodel.GetAllKeysFromTable,
+		GetAggregationSourceInfo:    agmodel.GetAggregationSourceInfo,
+		GetPluginMgrAddr:            agmodel.GetPluginData,
+		GetTarget:                   agmodel.GetTarget,
+		UpdateSystemData:            agmodel.UpdateSystemData,
+		UpdateAggregationSourceInfo: agmodel.UpdateAggregtionSource,
+		SaveCredentialRotation:      agmodel.SaveCredentialRotation,
+		GetCredentialRotation:       agmodel.GetCredentialRotation,
+		ReencryptPassword:           common.ReencryptSecret,
+		GetStoredPassword:           agmodel.GetStoredPassword,
+		UpdateStoredPassword:        agmodel.UpdateStoredPassword,
+		GetDeviceInventory:          agmodel.GetDeviceInventory,
+		GetInventoryHistoryInfo:     agmodel.GetInventoryHistory,
+		SaveInventoryHistory:        agmodel.SaveInventoryHistory,
+		PublishEvents:               agmessagebus.PublishEvents,
+		GetResourceETags:            agmodel.GetResourceETags,
+		SaveResourceETags:           agmodel.SaveResourceETags,
+		GetInventorySyncInfo:        agmodel.GetInventorySync,
+		SaveInventorySync:           agmodel.SaveInventorySync,
+		GetInventorySyncStatus:      agmodel.GetInventorySyncStatus,
+		SaveInventorySyncStatus:     agmodel.SaveInventorySyncStatus,
+		GetInventorySyncSchedule:    agmodel.GetInventorySyncSchedule,
+		SaveInventorySyncSchedule:   agmodel.SaveInventorySyncSchedule,
+		GetAggregateInfo:            agmodel.GetAggregate,
+		AcquireLease:                common.AcquireLease,
+		ReleaseLease:                common.ReleaseLease,
 	}
 
 	go p.RediscoverResources()
@@ -118,6 +146,18 @@ func main() {
 
 	go system.PerformPluginHealthCheck()
 
+	// Rotate the passwords of the BMC aggregation sources over the configured interval
+	go p.PerformCredentialRotation()
+
+	// Resynchronize the inventory of the servers on the schedules of the inventory synchronization policies
+	go p.PerformInventorySync()
+
+	// Re-encrypt the stored passwords which are not protected with the current key of the secret provider
+	go p.PerformSecretReencryption()
+
+	// Subscribe to the control messages, the tasks cancelled through the task service are stopped on receiving them
+	go agmessagebus.SubscribeCtrlMsgQueue(common.TaskControlMessageQueue(common.AggregationService))
+
 	if err := services.ODIMService.Run(); err != nil {
 		log.Fatal("failed to run a service: " + err.Error())
 	}
@@ -25,6 +25,7 @@ import (
 	"strings"
 	"time"
 
+	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
@@ -72,6 +73,19 @@ func (a *Aggregator) GetAggregationService(ctx context.Context, req *aggregatorp
 
 	}
 	// Construct the response below
+	var oem dmtf.Oem = map[string]interface{}{
+		"Odim": map[string]interface{}{
+			"DiscoveredAggregationSources": agresponse.OdataID{
+				OdataID: system.DiscoveredAggregationSourcesURI,
+			},
+			"InventorySync": agresponse.OdataID{
+				OdataID: system.InventorySyncURI,
+			},
+			"ConformanceReports": agresponse.OdataID{
+				OdataID: system.ConformanceReportsURI,
+			},
+		},
+	}
 
 	aggregationServiceResponse, _ := json.Marshal(agresponse.AggregationServiceResponse{
 		OdataType:    common.AggregationServiceType,
@@ -89,6 +103,23 @@ func (a *Aggregator) GetAggregationService(ctx context.Context, req *aggregatorp
 				Target:     "/redfish/v1/AggregationService/Actions/AggregationService.SetDefaultBootOrder/",
 				ActionInfo: "/redfish/v1/AggregationService/SetDefaultBootOrderActionInfo",
 			},
+			Oem: &agresponse.OemActions{
+				BulkAddAggregationSources: agresponse.Action{
+					Target: system.BulkAddAggregationSourcesURI,
+				},
+				DiscoverAggregationSources: agresponse.Action{
+					Target: system.DiscoverAggregationSourcesURI,
+				},
+				ApproveDiscoveredAggregationSources: agresponse.Action{
+					Target: system.ApproveDiscoveredAggregationSourcesURI,
+				},
+				RotateAggregationSourceCredentials: agresponse.Action{
+					Target: system.RotateAggregationSourceCredentialsURI,
+				},
+				ValidateInteropProfile: agresponse.Action{
+					Target: system.ValidateInteropProfileURI,
+				},
+			},
 		},
 		Aggregates: agresponse.OdataID{
 			OdataID: "/redfish/v1/AggregationService/Aggregates",
@@ -100,6 +131,7 @@ func (a *Aggregator) GetAggregationService(ctx context.Context, req *aggregatorp
 			OdataID: "/redfish/v1/AggregationService/ConnectionMethods",
 		},
 		ServiceEnabled: isServiceEnabled,
+		Oem:            &oem,
 		Status: agresponse.Status{
 			State:        serviceState,
 			HealthRollup: "OK",
@@ -302,6 +334,21 @@ func (a *Aggregator) RediscoverSystemInventory(ctx context.Context, req *aggrega
 
 }
 
+// RefreshResourceInventory defines the operations which handles the RPC request response
+// for the RefreshResourceInventory service of aggregator micro service.
+// The functionality retrives the request and return backs the response to
+// RPC according to the protoc file defined in the lib-utilities package.
+func (a *Aggregator) RefreshResourceInventory(ctx context.Context, req *aggregatorproto.RefreshResourceInventoryRequest) (
+	*aggregatorproto.RefreshResourceInventoryResponse, error) {
+	resp := &aggregatorproto.RefreshResourceInventoryResponse{}
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.AggregationService, podName)
+	ctx = context.WithValue(ctx, common.ThreadID, 1)
+	ctx = context.WithValue(ctx, common.ThreadName, common.RefreshResourceInventory)
+	go a.connector.RefreshResourceInventory(ctx, req.DeviceUUID, req.ResourceURI)
+	return resp, nil
+}
+
 // UpdateSystemState defines the operations which handles the RPC request response
 // for the UpdateSystemState call to aggregator micro service.
 // The functionality retrives the request and return backs the response to
@@ -314,12 +361,726 @@ func (a *Aggregator) UpdateSystemState(ctx context.Context, req *aggregatorproto
 	return resp, a.connector.UpdateSystemState(ctx, req)
 }
 
-// AddAggregationSource function is for handling the RPC communication for AddAggregationSource
-func (a *Aggregator) AddAggregationSource(ctx context.Context, req *aggregatorproto.AggregatorRequest) (
+// AddAggregationSource function is for handling the RPC communication for AddAggregationSource
+func (a *Aggregator) AddAggregationSource(ctx context.Context, req *aggregatorproto.AggregatorRequest) (
+	*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.AggregationService, podName)
+	var taskID string
+	var oemprivileges []string
+	privileges := []string{common.PrivilegeConfigureComponents}
+	authResp, err := a.connector.Auth(req.SessionToken, privileges, oemprivileges)
+	resp := &aggregatorproto.AggregatorResponse{}
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
+		}
+		generateResponse(authResp, resp)
+		return resp, nil
+	}
+	sessionUserName, err := a.connector.GetSessionUserName(req.SessionToken)
+	if err != nil {
+		errMsg := "Unable to get session username: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errMsg, nil, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	}
+
+	// parsing the AggregationSourceRequest
+	var addRequest system.AggregationSource
+	err = json.Unmarshal(req.RequestBody, &addRequest)
+	if err != nil {
+		errMsg := "Unable to parse the add request" + err.Error()
+		generateResponse(common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	}
+
+	//validating the AggregationSourceRequest
+	invalidParam := validateAggregationSourceRequest(addRequest)
+	if invalidParam != "" {
+		errMsg := "Mandatory field " + invalidParam + " Missing"
+		generateResponse(common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{invalidParam}, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	}
+	managerAddress := addRequest.HostName
+	err = validateManagerAddress(managerAddress)
+	if err != nil {
+		generateResponse(common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, err.Error(), []interface{}{managerAddress, "ManagerAddress"}, nil), resp)
+		l.LogWithFields(ctx).Error(err.Error())
+		return resp, nil
+	}
+
+	// Task Service using RPC and get the taskID
+	taskURI, err := a.connector.CreateTask(ctx, sessionUserName)
+	if err != nil {
+		errMsg := "Unable to create the task: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	}
+	strArray := strings.Split(taskURI, "/")
+	if strings.HasSuffix(taskURI, "/") {
+		taskID = strArray[len(strArray)-2]
+	} else {
+		taskID = strArray[len(strArray)-1]
+	}
+	// spawn the thread here to process the action asynchronously
+	threadID := 1
+	ctxt := context.WithValue(ctx, common.ThreadName, common.AddAggregationSource)
+	ctxt = context.WithValue(ctxt, common.ThreadID, strconv.Itoa(threadID))
+	go a.connector.AddAggregationSource(ctxt, taskID, sessionUserName, req)
+	threadID++
+
+	// return 202 Accepted
+	var rpcResp = response.RPC{
+		StatusCode:    http.StatusAccepted,
+		StatusMessage: response.TaskStarted,
+		Header: map[string]string{
+			"Location": "/taskmon/" + taskID,
+		},
+	}
+	generateTaskRespone(taskID, taskURI, &rpcResp)
+	generateResponse(rpcResp, resp)
+	return resp, nil
+}
+
+// BulkAddAggregationSources function is for handling the RPC communication for the OEM action
+// adding the aggregation sources in bulk
+func (a *Aggregator) BulkAddAggregationSources(ctx context.Context, req *aggregatorproto.AggregatorRequest) (
+	*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.AggregationService, podName)
+	var taskID string
+	var oemprivileges []string
+	privileges := []string{common.PrivilegeConfigureComponents}
+	authResp, err := a.connector.Auth(req.SessionToken, privileges, oemprivileges)
+	resp := &aggregatorproto.AggregatorResponse{}
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
+		}
+		generateResponse(authResp, resp)
+		return resp, nil
+	}
+	sessionUserName, err := a.connector.GetSessionUserName(req.SessionToken)
+	if err != nil {
+		errMsg := "Unable to get session username: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errMsg, nil, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	}
+
+	var bulkRequest system.BulkAggregationSources
+	err = json.Unmarshal(req.RequestBody, &bulkRequest)
+	if err != nil {
+		errMsg := "Unable to parse the bulk add request: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errMsg, nil, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	}
+	invalidProperties, err := common.RequestParamsCaseValidator(req.RequestBody, bulkRequest)
+	if err != nil {
+		errMsg := "Unable to validate request parameters: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	} else if invalidProperties != "" {
+		errMsg := "One or more properties given in the request body are not valid, ensure properties are listed in uppercamelcase "
+		generateResponse(common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errMsg, []interface{}{invalidProperties}, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	}
+	if rpcResp := validateBulkAggregationSourcesRequest(bulkRequest); rpcResp != nil {
+		generateResponse(*rpcResp, resp)
+		return resp, nil
+	}
+
+	// Task Service using RPC and get the taskID
+	taskURI, err := a.connector.CreateTask(ctx, sessionUserName)
+	if err != nil {
+		errMsg := "Unable to create the task: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	}
+	strArray := strings.Split(taskURI, "/")
+	if strings.HasSuffix(taskURI, "/") {
+		taskID = strArray[len(strArray)-2]
+	} else {
+		taskID = strArray[len(strArray)-1]
+	}
+	// spawn the thread here to process the action asynchronously
+	threadID := 1
+	ctxt := context.WithValue(ctx, common.ThreadName, common.BulkAddAggregationSources)
+	ctxt = context.WithValue(ctxt, common.ThreadID, strconv.Itoa(threadID))
+	go a.connector.BulkAddAggregationSources(ctxt, taskID, sessionUserName, req)
+
+	// return 202 Accepted
+	var rpcResp = response.RPC{
+		StatusCode:    http.StatusAccepted,
+		StatusMessage: response.TaskStarted,
+		Header: map[string]string{
+			"Location": "/taskmon/" + taskID,
+		},
+	}
+	generateTaskRespone(taskID, taskURI, &rpcResp)
+	generateResponse(rpcResp, resp)
+	return resp, nil
+}
+
+// validateBulkAggregationSourcesRequest validates the hosts of the bulk add request
+// and returns the error response if the request is not valid
+func validateBulkAggregationSourcesRequest(req system.BulkAggregationSources) *response.RPC {
+	var errResp response.RPC
+	if len(req.Hosts) == 0 {
+		errResp = common.GeneralError(http.StatusBadRequest, response.PropertyMissing, "Mandatory field Hosts Missing", []interface{}{"Hosts"}, nil)
+		return &errResp
+	}
+	if invalidParam := validateLinks(req.Links); invalidParam != "" || req.Links.ConnectionMethod == nil {
+		if invalidParam == "" {
+			invalidParam = "ConnectionMethod"
+		}
+		errResp = common.GeneralError(http.StatusBadRequest, response.PropertyMissing, "Mandatory field "+invalidParam+" Missing", []interface{}{invalidParam}, nil)
+		return &errResp
+	}
+	if req.MaxConcurrency < 0 {
+		errResp = common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, "MaxConcurrency must not be negative",
+			[]interface{}{fmt.Sprintf("%v", req.MaxConcurrency), "MaxConcurrency"}, nil)
+		return &errResp
+	}
+	hostNames := make(map[string]bool, len(req.Hosts))
+	for _, host := range req.Hosts {
+		invalidParam := validateAggregationSourceRequest(system.AggregationSource{
+			HostName: host.HostName,
+			UserName: host.UserName,
+			Password: host.Password,
+			Links:    req.Links,
+		})
+		if invalidParam != "" {
+			errResp = common.GeneralError(http.StatusBadRequest, response.PropertyMissing, "Mandatory field "+invalidParam+" Missing in Hosts", []interface{}{invalidParam}, nil)
+			return &errResp
+		}
+		if err := validateManagerAddress(host.HostName); err != nil {
+			errResp = common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, err.Error(), []interface{}{host.HostName, "HostName"}, nil)
+			return &errResp
+		}
+		if hostNames[host.HostName] {
+			errResp = common.GeneralError(http.StatusBadRequest, response.PropertyValueConflict, "HostName "+host.HostName+" is repeated in Hosts",
+				[]interface{}{"HostName", "HostName"}, nil)
+			return &errResp
+		}
+		hostNames[host.HostName] = true
+	}
+	return nil
+}
+
+// DiscoverAggregationSources function is for handling the RPC communication for the OEM action
+// scanning an address range for the Redfish services to be added as aggregation sources
+func (a *Aggregator) DiscoverAggregationSources(ctx context.Context, req *aggregatorproto.AggregatorRequest) (
+	*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.AggregationService, podName)
+	var taskID string
+	var oemprivileges []string
+	privileges := []string{common.PrivilegeConfigureComponents}
+	authResp, err := a.connector.Auth(req.SessionToken, privileges, oemprivileges)
+	resp := &aggregatorproto.AggregatorResponse{}
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
+		}
+		generateResponse(authResp, resp)
+		return resp, nil
+	}
+	sessionUserName, err := a.connector.GetSessionUserName(req.SessionToken)
+	if err != nil {
+		errMsg := "Unable to get session username: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errMsg, nil, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	}
+
+	var request system.DiscoverAggregationSources
+	err = json.Unmarshal(req.RequestBody, &request)
+	if err != nil {
+		errMsg := "Unable to parse the request: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errMsg, nil, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	}
+	invalidProperties, err := common.RequestParamsCaseValidator(req.RequestBody, request)
+	if err != nil {
+		errMsg := "Unable to validate request parameters: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	} else if invalidProperties != "" {
+		errMsg := "One or more properties given in the request body are not valid, ensure properties are listed in uppercamelcase "
+		generateResponse(common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errMsg, []interface{}{invalidProperties}, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	}
+	if rpcResp := validateDiscoverAggregationSourcesRequest(request); rpcResp != nil {
+		generateResponse(*rpcResp, resp)
+		return resp, nil
+	}
+
+	// Task Service using RPC and get the taskID
+	taskURI, err := a.connector.CreateTask(ctx, sessionUserName)
+	if err != nil {
+		errMsg := "Unable to create the task: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	}
+	strArray := strings.Split(taskURI, "/")
+	if strings.HasSuffix(taskURI, "/") {
+		taskID = strArray[len(strArray)-2]
+	} else {
+		taskID = strArray[len(strArray)-1]
+	}
+	// spawn the thread here to process the action asynchronously
+	threadID := 1
+	ctxt := context.WithValue(ctx, common.ThreadName, common.DiscoverAggregationSources)
+	ctxt = context.WithValue(ctxt, common.ThreadID, strconv.Itoa(threadID))
+	go a.connector.DiscoverAggregationSources(ctxt, taskID, sessionUserName, req)
+
+	// return 202 Accepted
+	var rpcResp = response.RPC{
+		StatusCode:    http.StatusAccepted,
+		StatusMessage: response.TaskStarted,
+		Header: map[string]string{
+			"Location": "/taskmon/" + taskID,
+		},
+	}
+	generateTaskRespone(taskID, taskURI, &rpcResp)
+	generateResponse(rpcResp, resp)
+	return resp, nil
+}
+
+// validateDiscoverAggregationSourcesRequest validates the address range and the credentials of the discovery request
+// and returns the error response if the request is not valid
+func validateDiscoverAggregationSourcesRequest(req system.DiscoverAggregationSources) *response.RPC {
+	var errResp response.RPC
+	if req.AddressRange == "" {
+		errResp = common.GeneralError(http.StatusBadRequest, response.PropertyMissing, "Mandatory field AddressRange Missing", []interface{}{"AddressRange"}, nil)
+		return &errResp
+	}
+	if _, err := system.ParseAddressRange(req.AddressRange); err != nil {
+		errResp = common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, err.Error(), []interface{}{req.AddressRange, "AddressRange"}, nil)
+		return &errResp
+	}
+	if req.Port < 0 || req.Port > 65535 {
+		errResp = common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, "Port must be between 1 and 65535",
+			[]interface{}{fmt.Sprintf("%v", req.Port), "Port"}, nil)
+		return &errResp
+	}
+	if req.MaxConcurrency < 0 {
+		errResp = common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, "MaxConcurrency must not be negative",
+			[]interface{}{fmt.Sprintf("%v", req.MaxConcurrency), "MaxConcurrency"}, nil)
+		return &errResp
+	}
+	if req.UserName != "" && req.Password == "" {
+		errResp = common.GeneralError(http.StatusBadRequest, response.PropertyMissing, "Mandatory field Password Missing", []interface{}{"Password"}, nil)
+		return &errResp
+	}
+	if req.UserName == "" && req.Password != "" {
+		errResp = common.GeneralError(http.StatusBadRequest, response.PropertyMissing, "Mandatory field UserName Missing", []interface{}{"UserName"}, nil)
+		return &errResp
+	}
+	return nil
+}
+
+// ApproveDiscoveredAggregationSources function is for handling the RPC communication for the OEM action
+// adding the discovered aggregation sources as aggregation sources
+func (a *Aggregator) ApproveDiscoveredAggregationSources(ctx context.Context, req *aggregatorproto.AggregatorRequest) (
+	*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.AggregationService, podName)
+	var taskID string
+	var oemprivileges []string
+	privileges := []string{common.PrivilegeConfigureComponents}
+	authResp, err := a.connector.Auth(req.SessionToken, privileges, oemprivileges)
+	resp := &aggregatorproto.AggregatorResponse{}
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
+		}
+		generateResponse(authResp, resp)
+		return resp, nil
+	}
+	sessionUserName, err := a.connector.GetSessionUserName(req.SessionToken)
+	if err != nil {
+		errMsg := "Unable to get session username: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errMsg, nil, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	}
+
+	var request system.ApproveDiscoveredAggregationSources
+	err = json.Unmarshal(req.RequestBody, &request)
+	if err != nil {
+		errMsg := "Unable to parse the request: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errMsg, nil, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	}
+	invalidProperties, err := common.RequestParamsCaseValidator(req.RequestBody, request)
+	if err != nil {
+		errMsg := "Unable to validate request parameters: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	} else if invalidProperties != "" {
+		errMsg := "One or more properties given in the request body are not valid, ensure properties are listed in uppercamelcase "
+		generateResponse(common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errMsg, []interface{}{invalidProperties}, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	}
+	if rpcResp := validateApproveDiscoveredAggregationSourcesRequest(request); rpcResp != nil {
+		generateResponse(*rpcResp, resp)
+		return resp, nil
+	}
+
+	// Task Service using RPC and get the taskID
+	taskURI, err := a.connector.CreateTask(ctx, sessionUserName)
+	if err != nil {
+		errMsg := "Unable to create the task: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	}
+	strArray := strings.Split(taskURI, "/")
+	if strings.HasSuffix(taskURI, "/") {
+		taskID = strArray[len(strArray)-2]
+	} else {
+		taskID = strArray[len(strArray)-1]
+	}
+	// spawn the thread here to process the action asynchronously
+	threadID := 1
+	ctxt := context.WithValue(ctx, common.ThreadName, common.ApproveDiscoveredAggregationSources)
+	ctxt = context.WithValue(ctxt, common.ThreadID, strconv.Itoa(threadID))
+	go a.connector.ApproveDiscoveredAggregationSources(ctxt, taskID, sessionUserName, req)
+
+	// return 202 Accepted
+	var rpcResp = response.RPC{
+		StatusCode:    http.StatusAccepted,
+		StatusMessage: response.TaskStarted,
+		Header: map[string]string{
+			"Location": "/taskmon/" + taskID,
+		},
+	}
+	generateTaskRespone(taskID, taskURI, &rpcResp)
+	generateResponse(rpcResp, resp)
+	return resp, nil
+}
+
+// validateApproveDiscoveredAggregationSourcesRequest validates the discovered aggregation sources and the credentials
+// of the approve request and returns the error response if the request is not valid
+func validateApproveDiscoveredAggregationSourcesRequest(req system.ApproveDiscoveredAggregationSources) *response.RPC {
+	var errResp response.RPC
+	if len(req.DiscoveredAggregationSources) == 0 {
+		errResp = common.GeneralError(http.StatusBadRequest, response.PropertyMissing, "Mandatory field DiscoveredAggregationSources Missing", []interface{}{"DiscoveredAggregationSources"}, nil)
+		return &errResp
+	}
+	invalidParam := ""
+	if req.Password == "" {
+		invalidParam = "Password "
+	}
+	if req.UserName == "" {
+		invalidParam = invalidParam + "UserName "
+	}
+	if req.Links != nil {
+		invalidParam = invalidParam + validateLinks(req.Links)
+	}
+	if invalidParam != "" {
+		errResp = common.GeneralError(http.StatusBadRequest, response.PropertyMissing, "Mandatory field "+invalidParam+" Missing", []interface{}{invalidParam}, nil)
+		return &errResp
+	}
+	if req.MaxConcurrency < 0 {
+		errResp = common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, "MaxConcurrency must not be negative",
+			[]interface{}{fmt.Sprintf("%v", req.MaxConcurrency), "MaxConcurrency"}, nil)
+		return &errResp
+	}
+	discoveredSources := make(map[string]bool, len(req.DiscoveredAggregationSources))
+	for _, discoveredSource := range req.DiscoveredAggregationSources {
+		if !strings.HasPrefix(discoveredSource.OdataID, system.DiscoveredAggregationSourcesURI+"/") {
+			errResp = common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, "DiscoveredAggregationSources must list the members of "+system.DiscoveredAggregationSourcesURI,
+				[]interface{}{discoveredSource.OdataID, "DiscoveredAggregationSources"}, nil)
+			return &errResp
+		}
+		if discoveredSources[discoveredSource.OdataID] {
+			errResp = common.GeneralError(http.StatusBadRequest, response.PropertyValueConflict, discoveredSource.OdataID+" is repeated in DiscoveredAggregationSources",
+				[]interface{}{"DiscoveredAggregationSources", "DiscoveredAggregationSources"}, nil)
+			return &errResp
+		}
+		discoveredSources[discoveredSource.OdataID] = true
+	}
+	return nil
+}
+
+// RotateAggregationSourceCredentials function is for handling the RPC communication for the OEM action
+// rotating the passwords of the BMC aggregation sources
+func (a *Aggregator) RotateAggregationSourceCredentials(ctx context.Context, req *aggregatorproto.AggregatorRequest) (
+	*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.AggregationService, podName)
+	var taskID string
+	var oemprivileges []string
+	privileges := []string{common.PrivilegeConfigureComponents}
+	authResp, err := a.connector.Auth(req.SessionToken, privileges, oemprivileges)
+	resp := &aggregatorproto.AggregatorResponse{}
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
+		}
+		generateResponse(authResp, resp)
+		return resp, nil
+	}
+	sessionUserName, err := a.connector.GetSessionUserName(req.SessionToken)
+	if err != nil {
+		errMsg := "Unable to get session username: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errMsg, nil, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	}
+
+	var request system.RotateAggregationSourceCredentials
+	err = json.Unmarshal(req.RequestBody, &request)
+	if err != nil {
+		errMsg := "Unable to parse the request: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errMsg, nil, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	}
+	invalidProperties, err := common.RequestParamsCaseValidator(req.RequestBody, request)
+	if err != nil {
+		errMsg := "Unable to validate request parameters: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	} else if invalidProperties != "" {
+		errMsg := "One or more properties given in the request body are not valid, ensure properties are listed in uppercamelcase "
+		generateResponse(common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errMsg, []interface{}{invalidProperties}, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	}
+	if rpcResp := validateRotateAggregationSourceCredentialsRequest(request); rpcResp != nil {
+		generateResponse(*rpcResp, resp)
+		return resp, nil
+	}
+
+	// Task Service using RPC and get the taskID
+	taskURI, err := a.connector.CreateTask(ctx, sessionUserName)
+	if err != nil {
+		errMsg := "Unable to create the task: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	}
+	strArray := strings.Split(taskURI, "/")
+	if strings.HasSuffix(taskURI, "/") {
+		taskID = strArray[len(strArray)-2]
+	} else {
+		taskID = strArray[len(strArray)-1]
+	}
+	// spawn the thread here to process the action asynchronously
+	threadID := 1
+	ctxt := context.WithValue(ctx, common.ThreadName, common.RotateAggregationSourceCredentials)
+	ctxt = context.WithValue(ctxt, common.ThreadID, strconv.Itoa(threadID))
+	go a.connector.RotateAggregationSourceCredentials(ctxt, taskID, sessionUserName, req)
+
+	// return 202 Accepted
+	var rpcResp = response.RPC{
+		StatusCode:    http.StatusAccepted,
+		StatusMessage: response.TaskStarted,
+		Header: map[string]string{
+			"Location": "/taskmon/" + taskID,
+		},
+	}
+	generateTaskRespone(taskID, taskURI, &rpcResp)
+	generateResponse(rpcResp, resp)
+	return resp, nil
+}
+
+// validateRotateAggregationSourceCredentialsRequest validates the aggregation sources of the credential rotation request
+// and returns the error response if the request is not valid
+func validateRotateAggregationSourceCredentialsRequest(req system.RotateAggregationSourceCredentials) *response.RPC {
+	var errResp response.RPC
+	if len(req.AggregationSources) == 0 {
+		errResp = common.GeneralError(http.StatusBadRequest, response.PropertyMissing, "Mandatory field AggregationSources Missing", []interface{}{"AggregationSources"}, nil)
+		return &errResp
+	}
+	if req.MaxConcurrency < 0 {
+		errResp = common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, "MaxConcurrency must not be negative",
+			[]interface{}{fmt.Sprintf("%v", req.MaxConcurrency), "MaxConcurrency"}, nil)
+		return &errResp
+	}
+	aggregationSources := make(map[string]bool, len(req.AggregationSources))
+	for _, aggregationSource := range req.AggregationSources {
+		aggregationSourceURI := strings.TrimSuffix(aggregationSource.OdataID, "/")
+		if !strings.HasPrefix(aggregationSourceURI, "/redfish/v1/AggregationService/AggregationSources/") {
+			errResp = common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, "AggregationSources must list the members of /redfish/v1/AggregationService/AggregationSources",
+				[]interface{}{aggregationSource.OdataID, "AggregationSources"}, nil)
+			return &errResp
+		}
+		if aggregationSources[aggregationSourceURI] {
+			errResp = common.GeneralError(http.StatusBadRequest, response.PropertyValueConflict, aggregationSource.OdataID+" is repeated in AggregationSources",
+				[]interface{}{"AggregationSources", "AggregationSources"}, nil)
+			return &errResp
+		}
+		aggregationSources[aggregationSourceURI] = true
+	}
+	return nil
+}
+
+func validateAggregationSourceRequest(req system.AggregationSource) string {
+	param := ""
+	if req.HostName == "" {
+		param = "HostName "
+	}
+	if req.Password == "" {
+		param = param + "Password "
+	}
+	if req.UserName == "" {
+		param = param + "UserName "
+	}
+	return param + validateLinks(req.Links)
+}
+
+func validateLinks(req *system.Links) string {
+	var param = ""
+	if req != nil {
+		if req.ConnectionMethod != nil {
+			if req.ConnectionMethod.OdataID == "" {
+				param = param + "ConnectionMethod @odata.id"
+			}
+		}
+	} else {
+		param = "Links"
+	}
+	return param
+}
+
+// GetAllAggregationSource defines the operations which handles the RPC request response
+// for the GetAllAggregationSource  service of aggregation micro service.
+// The functionality retrives the request and return backs the response to
+// RPC according to the protoc file defined in the util-lib package.
+// The function also checks for the session time out of the token
+// which is present in the request.
+func (a *Aggregator) GetAllAggregationSource(ctx context.Context, req *aggregatorproto.AggregatorRequest) (
+	*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.AggregationService, podName)
+	var oemprivileges []string
+	privileges := []string{common.PrivilegeConfigureComponents}
+	authResp, err := a.connector.Auth(req.SessionToken, privileges, oemprivileges)
+	resp := &aggregatorproto.AggregatorResponse{}
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
+		}
+		generateResponse(authResp, resp)
+		return resp, nil
+	}
+	data := a.connector.GetAggregationSourceCollection(ctx)
+	resp.StatusCode = data.StatusCode
+	resp.StatusMessage = data.StatusMessage
+	resp.Header = data.Header
+	generateResponse(data, resp)
+	return resp, nil
+}
+
+// GetAggregationSource defines the operations which handles the RPC request response
+// for the GetAggregationSource  service of aggregation micro service.
+// The functionality retrives the request and return backs the response to
+// RPC according to the protoc file defined in the util-lib package.
+// The function also checks for the session time out of the token
+// which is present in the request.
+func (a *Aggregator) GetAggregationSource(ctx context.Context, req *aggregatorproto.AggregatorRequest) (
+	*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.AggregationService, podName)
+	var oemprivileges []string
+	privileges := []string{common.PrivilegeConfigureComponents}
+	authResp, err := a.connector.Auth(req.SessionToken, privileges, oemprivileges)
+	resp := &aggregatorproto.AggregatorResponse{}
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
+		}
+		generateResponse(authResp, resp)
+		return resp, nil
+	}
+	data := a.connector.GetAggregationSource(ctx, req.URL)
+	resp.StatusCode = data.StatusCode
+	resp.StatusMessage = data.StatusMessage
+	resp.Header = data.Header
+	generateResponse(data, resp)
+	return resp, nil
+}
+
+// GetAllDiscoveredAggregationSources defines the operations which handles the RPC request response
+// for the GetAllDiscoveredAggregationSources service of aggregation micro service.
+// It returns the collection of the Redfish services found by the network discovery.
+func (a *Aggregator) GetAllDiscoveredAggregationSources(ctx context.Context, req *aggregatorproto.AggregatorRequest) (
+	*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.AggregationService, podName)
+	var oemprivileges []string
+	privileges := []string{common.PrivilegeConfigureComponents}
+	authResp, err := a.connector.Auth(req.SessionToken, privileges, oemprivileges)
+	resp := &aggregatorproto.AggregatorResponse{}
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
+		}
+		generateResponse(authResp, resp)
+		return resp, nil
+	}
+	data := a.connector.GetDiscoveredAggregationSourceCollection(ctx)
+	resp.StatusCode = data.StatusCode
+	resp.StatusMessage = data.StatusMessage
+	resp.Header = data.Header
+	generateResponse(data, resp)
+	return resp, nil
+}
+
+// GetDiscoveredAggregationSource defines the operations which handles the RPC request response
+// for the GetDiscoveredAggregationSource service of aggregation micro service.
+// It returns the Redfish service found by the network discovery with the requested URI.
+func (a *Aggregator) GetDiscoveredAggregationSource(ctx context.Context, req *aggregatorproto.AggregatorRequest) (
+	*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.AggregationService, podName)
+	var oemprivileges []string
+	privileges := []string{common.PrivilegeConfigureComponents}
+	authResp, err := a.connector.Auth(req.SessionToken, privileges, oemprivileges)
+	resp := &aggregatorproto.AggregatorResponse{}
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
+		}
+		generateResponse(authResp, resp)
+		return resp, nil
+	}
+	data := a.connector.GetDiscoveredAggregationSource(ctx, req.URL)
+	resp.StatusCode = data.StatusCode
+	resp.StatusMessage = data.StatusMessage
+	resp.Header = data.Header
+	generateResponse(data, resp)
+	return resp, nil
+}
+
+// GetInventoryHistory defines the operations which handles the RPC request response
+// for the GetInventoryHistory service of aggregation micro service.
+// It returns the inventory history of the system, or the inventory at a time or the changes in a period.
+func (a *Aggregator) GetInventoryHistory(ctx context.Context, req *aggregatorproto.AggregatorRequest) (
 	*aggregatorproto.AggregatorResponse, error) {
 	ctx = common.GetContextData(ctx)
 	ctx = common.ModifyContext(ctx, common.AggregationService, podName)
-	var taskID string
 	var oemprivileges []string
 	privileges := []string{common.PrivilegeConfigureComponents}
 	authResp, err := a.connector.Auth(req.SessionToken, privileges, oemprivileges)
@@ -331,109 +1092,96 @@ func (a *Aggregator) AddAggregationSource(ctx context.Context, req *aggregatorpr
 		generateResponse(authResp, resp)
 		return resp, nil
 	}
-	sessionUserName, err := a.connector.GetSessionUserName(req.SessionToken)
-	if err != nil {
-		errMsg := "Unable to get session username: " + err.Error()
-		generateResponse(common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errMsg, nil, nil), resp)
-		l.LogWithFields(ctx).Error(errMsg)
-		return resp, nil
-	}
-
-	// parsing the AggregationSourceRequest
-	var addRequest system.AggregationSource
-	err = json.Unmarshal(req.RequestBody, &addRequest)
-	if err != nil {
-		errMsg := "Unable to parse the add request" + err.Error()
-		generateResponse(common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil), resp)
-		l.LogWithFields(ctx).Error(errMsg)
-		return resp, nil
-	}
-
-	//validating the AggregationSourceRequest
-	invalidParam := validateAggregationSourceRequest(addRequest)
-	if invalidParam != "" {
-		errMsg := "Mandatory field " + invalidParam + " Missing"
-		generateResponse(common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{invalidParam}, nil), resp)
-		l.LogWithFields(ctx).Error(errMsg)
-		return resp, nil
-	}
-	managerAddress := addRequest.HostName
-	err = validateManagerAddress(managerAddress)
-	if err != nil {
-		generateResponse(common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, err.Error(), []interface{}{managerAddress, "ManagerAddress"}, nil), resp)
-		l.LogWithFields(ctx).Error(err.Error())
-		return resp, nil
-	}
+	data := a.connector.GetInventoryHistory(ctx, req.URL)
+	resp.StatusCode = data.StatusCode
+	resp.StatusMessage = data.StatusMessage
+	resp.Header = data.Header
+	generateResponse(data, resp)
+	return resp, nil
+}
 
-	// Task Service using RPC and get the taskID
-	taskURI, err := a.connector.CreateTask(ctx, sessionUserName)
-	if err != nil {
-		errMsg := "Unable to create the task: " + err.Error()
-		generateResponse(common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil), resp)
-		l.LogWithFields(ctx).Error(errMsg)
+// GetInventorySync defines the operations which handles the RPC request response
+// for the GetInventorySync service of aggregation micro service.
+// It returns the policies of the scheduled inventory synchronization.
+func (a *Aggregator) GetInventorySync(ctx context.Context, req *aggregatorproto.AggregatorRequest) (
+	*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.AggregationService, podName)
+	var oemprivileges []string
+	privileges := []string{common.PrivilegeConfigureComponents}
+	authResp, err := a.connector.Auth(req.SessionToken, privileges, oemprivileges)
+	resp := &aggregatorproto.AggregatorResponse{}
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
+		}
+		generateResponse(authResp, resp)
 		return resp, nil
 	}
-	strArray := strings.Split(taskURI, "/")
-	if strings.HasSuffix(taskURI, "/") {
-		taskID = strArray[len(strArray)-2]
-	} else {
-		taskID = strArray[len(strArray)-1]
-	}
-	// spawn the thread here to process the action asynchronously
-	threadID := 1
-	ctxt := context.WithValue(ctx, common.ThreadName, common.AddAggregationSource)
-	ctxt = context.WithValue(ctxt, common.ThreadID, strconv.Itoa(threadID))
-	go a.connector.AddAggregationSource(ctxt, taskID, sessionUserName, req)
-	threadID++
-
-	// return 202 Accepted
-	var rpcResp = response.RPC{
-		StatusCode:    http.StatusAccepted,
-		StatusMessage: response.TaskStarted,
-		Header: map[string]string{
-			"Location": "/taskmon/" + taskID,
-		},
-	}
-	generateTaskRespone(taskID, taskURI, &rpcResp)
-	generateResponse(rpcResp, resp)
+	data := a.connector.GetInventorySync(ctx)
+	resp.StatusCode = data.StatusCode
+	resp.StatusMessage = data.StatusMessage
+	resp.Header = data.Header
+	generateResponse(data, resp)
 	return resp, nil
 }
 
-func validateAggregationSourceRequest(req system.AggregationSource) string {
-	param := ""
-	if req.HostName == "" {
-		param = "HostName "
-	}
-	if req.Password == "" {
-		param = param + "Password "
-	}
-	if req.UserName == "" {
-		param = param + "UserName "
+// UpdateInventorySync defines the operations which handles the RPC request response
+// for the UpdateInventorySync service of aggregation micro service.
+// It updates the policies of the scheduled inventory synchronization.
+func (a *Aggregator) UpdateInventorySync(ctx context.Context, req *aggregatorproto.AggregatorRequest) (
+	*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.AggregationService, podName)
+	var oemprivileges []string
+	privileges := []string{common.PrivilegeConfigureComponents}
+	authResp, err := a.connector.Auth(req.SessionToken, privileges, oemprivileges)
+	resp := &aggregatorproto.AggregatorResponse{}
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
+		}
+		generateResponse(authResp, resp)
+		return resp, nil
 	}
-	return param + validateLinks(req.Links)
+	data := a.connector.UpdateInventorySync(ctx, req)
+	resp.StatusCode = data.StatusCode
+	resp.StatusMessage = data.StatusMessage
+	resp.Header = data.Header
+	generateResponse(data, resp)
+	return resp, nil
 }
 
-func validateLinks(req *system.Links) string {
-	var param = ""
-	if req != nil {
-		if req.ConnectionMethod != nil {
-			if req.ConnectionMethod.OdataID == "" {
-				param = param + "ConnectionMethod @odata.id"
-			}
+// ValidateInteropProfile defines the operations which handles the RPC request response
+// for the ValidateInteropProfile service of aggregation micro service.
+// It validates the systems against a Redfish Interop profile and saves their conformance reports.
+func (a *Aggregator) ValidateInteropProfile(ctx context.Context, req *aggregatorproto.AggregatorRequest) (
+	*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.AggregationService, podName)
+	var oemprivileges []string
+	privileges := []string{common.PrivilegeConfigureComponents}
+	authResp, err := a.connector.Auth(req.SessionToken, privileges, oemprivileges)
+	resp := &aggregatorproto.AggregatorResponse{}
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
 		}
-	} else {
-		param = "Links"
+		generateResponse(authResp, resp)
+		return resp, nil
 	}
-	return param
+	data := a.connector.ValidateInteropProfile(ctx, req)
+	resp.StatusCode = data.StatusCode
+	resp.StatusMessage = data.StatusMessage
+	resp.Header = data.Header
+	generateResponse(data, resp)
+	return resp, nil
 }
 
-// GetAllAggregationSource defines the operations which handles the RPC request response
-// for the GetAllAggregationSource  service of aggregation micro service.
-// The functionality retrives the request and return backs the response to
-// RPC according to the protoc file defined in the util-lib package.
-// The function also checks for the session time out of the token
-// which is present in the request.
-func (a *Aggregator) GetAllAggregationSource(ctx context.Context, req *aggregatorproto.AggregatorRequest) (
+// GetConformanceReports defines the operations which handles the RPC request response
+// for the GetConformanceReports service of aggregation micro service.
+// It returns the collection of the conformance reports of the systems.
+func (a *Aggregator) GetConformanceReports(ctx context.Context, req *aggregatorproto.AggregatorRequest) (
 	*aggregatorproto.AggregatorResponse, error) {
 	ctx = common.GetContextData(ctx)
 	ctx = common.ModifyContext(ctx, common.AggregationService, podName)
@@ -448,7 +1196,7 @@ func (a *Aggregator) GetAllAggregationSource(ctx context.Context, req *aggregato
 		generateResponse(authResp, resp)
 		return resp, nil
 	}
-	data := a.connector.GetAggregationSourceCollection(ctx)
+	data := a.connector.GetConformanceReports(ctx)
 	resp.StatusCode = data.StatusCode
 	resp.StatusMessage = data.StatusMessage
 	resp.Header = data.Header
@@ -456,13 +1204,10 @@ func (a *Aggregator) GetAllAggregationSource(ctx context.Context, req *aggregato
 	return resp, nil
 }
 
-// GetAggregationSource defines the operations which handles the RPC request response
-// for the GetAggregationSource  service of aggregation micro service.
-// The functionality retrives the request and return backs the response to
-// RPC according to the protoc file defined in the util-lib package.
-// The function also checks for the session time out of the token
-// which is present in the request.
-func (a *Aggregator) GetAggregationSource(ctx context.Context, req *aggregatorproto.AggregatorRequest) (
+// GetConformanceReport defines the operations which handles the RPC request response
+// for the GetConformanceReport service of aggregation micro service.
+// It returns the conformance report of a system.
+func (a *Aggregator) GetConformanceReport(ctx context.Context, req *aggregatorproto.AggregatorRequest) (
 	*aggregatorproto.AggregatorResponse, error) {
 	ctx = common.GetContextData(ctx)
 	ctx = common.ModifyContext(ctx, common.AggregationService, podName)
@@ -477,7 +1222,7 @@ func (a *Aggregator) GetAggregationSource(ctx context.Context, req *aggregatorpr
 		generateResponse(authResp, resp)
 		return resp, nil
 	}
-	data := a.connector.GetAggregationSource(ctx, req.URL)
+	data := a.connector.GetConformanceReport(ctx, req.URL)
 	resp.StatusCode = data.StatusCode
 	resp.StatusMessage = data.StatusMessage
 	resp.Header = data.Header
@@ -892,6 +1637,106 @@ func (a *Aggregator) SetDefaultBootOrderElementsOfAggregate(ctx context.Context,
 	return resp, nil
 }
 
+// ApplySettingsElementsOfAggregate defines the operations which handles the RPC request response
+// for the ApplySettingsElementsOfAggregate service of aggregation micro service.
+// The functionality retrives the request and return backs the response to
+// RPC according to the protoc file defined in the util-lib package.
+// The function also checks for the session time out of the token
+// which is present in the request.
+func (a *Aggregator) ApplySettingsElementsOfAggregate(ctx context.Context, req *aggregatorproto.AggregatorRequest) (
+	*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.AggregationService, podName)
+	var oemprivileges []string
+	privileges := []string{common.PrivilegeConfigureComponents}
+	authResp, err := a.connector.Auth(req.SessionToken, privileges, oemprivileges)
+	resp := &aggregatorproto.AggregatorResponse{}
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
+		}
+		generateResponse(authResp, resp)
+		return resp, nil
+	}
+	sessionUserName, err := a.connector.GetSessionUserName(req.SessionToken)
+	if err != nil {
+		errMsg := "Unable to get session username: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errMsg, nil, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	}
+	taskURI, err := a.connector.CreateTask(ctx, sessionUserName)
+	if err != nil {
+		errMsg := "Unable to create task: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	}
+	strArray := strings.Split(taskURI, "/")
+	var taskID string
+	if strings.HasSuffix(taskURI, "/") {
+		taskID = strArray[len(strArray)-2]
+	} else {
+		taskID = strArray[len(strArray)-1]
+	}
+	err = a.connector.UpdateTask(ctx, common.TaskData{
+		TaskID:          taskID,
+		TargetURI:       taskURI,
+		TaskState:       common.Running,
+		TaskStatus:      common.OK,
+		PercentComplete: 0,
+		HTTPMethod:      http.MethodPost,
+	})
+	if err != nil {
+		// print error as we are unable to communicate with svc-task and then return
+		l.LogWithFields(ctx).Error("Unable to contact task-service with UpdateTask RPC : " + err.Error())
+	}
+
+	threadID := 1
+	ctxt := context.WithValue(ctx, common.ThreadName, common.ApplySettingsElementsOfAggregate)
+	ctxt = context.WithValue(ctxt, common.ThreadID, strconv.Itoa(threadID))
+	go a.connector.ApplySettingsElementsOfAggregate(ctxt, taskID, sessionUserName, req)
+	threadID++
+	// return 202 Accepted
+	var rpcResp = response.RPC{
+		StatusCode:    http.StatusAccepted,
+		StatusMessage: response.TaskStarted,
+		Header: map[string]string{
+			"Location": "/taskmon/" + taskID,
+		},
+	}
+	generateTaskRespone(taskID, taskURI, &rpcResp)
+	generateResponse(rpcResp, resp)
+
+	return resp, nil
+}
+
+// GetAggregateSettingsDrift defines the operations which handles the RPC request response
+// for the GetAggregateSettingsDrift service of aggregation micro service.
+// The functionality retrives the request and return backs the response to
+// RPC according to the protoc file defined in the util-lib package.
+// The function also checks for the session time out of the token
+// which is present in the request.
+func (a *Aggregator) GetAggregateSettingsDrift(ctx context.Context, req *aggregatorproto.AggregatorRequest) (
+	*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.AggregationService, podName)
+	var oemprivileges []string
+	privileges := []string{common.PrivilegeConfigureComponents}
+	authResp, err := a.connector.Auth(req.SessionToken, privileges, oemprivileges)
+	resp := &aggregatorproto.AggregatorResponse{}
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
+		}
+		generateResponse(authResp, resp)
+		return resp, nil
+	}
+	rpcResponce := a.connector.GetAggregateSettingsDrift(ctx, req)
+	generateResponse(rpcResponce, resp)
+	return resp, nil
+}
+
 // GetAllConnectionMethods defines the operations which handles the RPC request response
 // for the GetAllConnectionMethods service of systems micro service.
 // The functionality retrives the request and return backs the response to
@@ -471,6 +471,207 @@ func TestAggregator_AddAggreagationSource(t *testing.T) {
 	}
 }
 
+func TestAggregator_BulkAddAggregationSources(t *testing.T) {
+	config.SetUpMockConfig(t)
+	links := &system.Links{
+		ConnectionMethod: &system.ConnectionMethod{
+			OdataID: "/redfish/v1/AggregationService/ConnectionMethods/c41cbd97-937d-1b73-c41c-1b7385d3906",
+		},
+	}
+	host := system.BulkAggregationSourceHost{HostName: "100.0.0.1:50000", UserName: "admin", Password: "password"}
+	successReq, _ := json.Marshal(system.BulkAggregationSources{Hosts: []system.BulkAggregationSourceHost{host}, Links: links})
+	noHostsReq, _ := json.Marshal(system.BulkAggregationSources{Links: links})
+	noLinksReq, _ := json.Marshal(system.BulkAggregationSources{Hosts: []system.BulkAggregationSourceHost{host}})
+	missingPasswordReq, _ := json.Marshal(system.BulkAggregationSources{
+		Hosts: []system.BulkAggregationSourceHost{{HostName: "100.0.0.2:50000", UserName: "admin"}},
+		Links: links,
+	})
+	invalidHostReq, _ := json.Marshal(system.BulkAggregationSources{
+		Hosts: []system.BulkAggregationSourceHost{{HostName: ":50000", UserName: "admin", Password: "password"}},
+		Links: links,
+	})
+	repeatedHostReq, _ := json.Marshal(system.BulkAggregationSources{Hosts: []system.BulkAggregationSourceHost{host, host}, Links: links})
+	negativeConcurrencyReq, _ := json.Marshal(system.BulkAggregationSources{
+		Hosts:          []system.BulkAggregationSourceHost{host},
+		Links:          links,
+		MaxConcurrency: -1,
+	})
+	tests := []struct {
+		name         string
+		sessionToken string
+		reqBody      []byte
+		want         int32
+	}{
+		{"positive case", "validToken", successReq, http.StatusAccepted},
+		{"auth fail", "invalidToken", successReq, http.StatusUnauthorized},
+		{"unable to create task", "noTaskToken", successReq, http.StatusInternalServerError},
+		{"malformed request", "validToken", []byte("someData"), http.StatusBadRequest},
+		{"invalid property", "validToken", []byte(`{"hosts":[]}`), http.StatusBadRequest},
+		{"no hosts", "validToken", noHostsReq, http.StatusBadRequest},
+		{"no connection method", "validToken", noLinksReq, http.StatusBadRequest},
+		{"missing password", "validToken", missingPasswordReq, http.StatusBadRequest},
+		{"invalid host name", "validToken", invalidHostReq, http.StatusBadRequest},
+		{"repeated host name", "validToken", repeatedHostReq, http.StatusBadRequest},
+		{"negative concurrency", "validToken", negativeConcurrencyReq, http.StatusBadRequest},
+	}
+	a := &Aggregator{connector: connector}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := a.BulkAddAggregationSources(mockContext(), &aggregatorproto.AggregatorRequest{SessionToken: tt.sessionToken, RequestBody: tt.reqBody})
+			if err != nil {
+				t.Fatalf("Aggregator.BulkAddAggregationSources() error = %v", err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Errorf("Aggregator.BulkAddAggregationSources() StatusCode = %v, want %v", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
+
+func TestAggregator_DiscoverAggregationSources(t *testing.T) {
+	config.SetUpMockConfig(t)
+	successReq, _ := json.Marshal(system.DiscoverAggregationSources{AddressRange: "100.0.0.0/30", Port: 50000, UserName: "admin", Password: "password"})
+	noAddressRangeReq, _ := json.Marshal(system.DiscoverAggregationSources{UserName: "admin", Password: "password"})
+	invalidAddressRangeReq, _ := json.Marshal(system.DiscoverAggregationSources{AddressRange: "100.0.0.0/8"})
+	invalidPortReq, _ := json.Marshal(system.DiscoverAggregationSources{AddressRange: "100.0.0.1", Port: 70000})
+	missingPasswordReq, _ := json.Marshal(system.DiscoverAggregationSources{AddressRange: "100.0.0.1", UserName: "admin"})
+	missingUserNameReq, _ := json.Marshal(system.DiscoverAggregationSources{AddressRange: "100.0.0.1", Password: "password"})
+	negativeConcurrencyReq, _ := json.Marshal(system.DiscoverAggregationSources{AddressRange: "100.0.0.1", MaxConcurrency: -1})
+	tests := []struct {
+		name         string
+		sessionToken string
+		reqBody      []byte
+		want         int32
+	}{
+		{"positive case", "validToken", successReq, http.StatusAccepted},
+		{"auth fail", "invalidToken", successReq, http.StatusUnauthorized},
+		{"unable to create task", "noTaskToken", successReq, http.StatusInternalServerError},
+		{"malformed request", "validToken", []byte("someData"), http.StatusBadRequest},
+		{"invalid property", "validToken", []byte(`{"addressRange":"100.0.0.1"}`), http.StatusBadRequest},
+		{"no address range", "validToken", noAddressRangeReq, http.StatusBadRequest},
+		{"invalid address range", "validToken", invalidAddressRangeReq, http.StatusBadRequest},
+		{"invalid port", "validToken", invalidPortReq, http.StatusBadRequest},
+		{"missing password", "validToken", missingPasswordReq, http.StatusBadRequest},
+		{"missing user name", "validToken", missingUserNameReq, http.StatusBadRequest},
+		{"negative concurrency", "validToken", negativeConcurrencyReq, http.StatusBadRequest},
+	}
+	a := &Aggregator{connector: connector}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := a.DiscoverAggregationSources(mockContext(), &aggregatorproto.AggregatorRequest{SessionToken: tt.sessionToken, RequestBody: tt.reqBody})
+			if err != nil {
+				t.Fatalf("Aggregator.DiscoverAggregationSources() error = %v", err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Errorf("Aggregator.DiscoverAggregationSources() StatusCode = %v, want %v", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
+
+func TestAggregator_ApproveDiscoveredAggregationSources(t *testing.T) {
+	config.SetUpMockConfig(t)
+	discoveredSource := agmodel.OdataID{OdataID: system.DiscoveredAggregationSourcesURI + "/a1b6d3b4-5c4f-5b1e-9d4b-8f1f2c8e0b11"}
+	successReq, _ := json.Marshal(system.ApproveDiscoveredAggregationSources{
+		DiscoveredAggregationSources: []agmodel.OdataID{discoveredSource},
+		UserName:                     "admin",
+		Password:                     "password",
+	})
+	noSourcesReq, _ := json.Marshal(system.ApproveDiscoveredAggregationSources{UserName: "admin", Password: "password"})
+	missingPasswordReq, _ := json.Marshal(system.ApproveDiscoveredAggregationSources{
+		DiscoveredAggregationSources: []agmodel.OdataID{discoveredSource},
+		UserName:                     "admin",
+	})
+	invalidLinksReq, _ := json.Marshal(system.ApproveDiscoveredAggregationSources{
+		DiscoveredAggregationSources: []agmodel.OdataID{discoveredSource},
+		UserName:                     "admin",
+		Password:                     "password",
+		Links:                        &system.Links{ConnectionMethod: &system.ConnectionMethod{}},
+	})
+	invalidSourceReq, _ := json.Marshal(system.ApproveDiscoveredAggregationSources{
+		DiscoveredAggregationSources: []agmodel.OdataID{{OdataID: "/redfish/v1/AggregationService/AggregationSources/1"}},
+		UserName:                     "admin",
+		Password:                     "password",
+	})
+	repeatedSourceReq, _ := json.Marshal(system.ApproveDiscoveredAggregationSources{
+		DiscoveredAggregationSources: []agmodel.OdataID{discoveredSource, discoveredSource},
+		UserName:                     "admin",
+		Password:                     "password",
+	})
+	tests := []struct {
+		name         string
+		sessionToken string
+		reqBody      []byte
+		want         int32
+	}{
+		{"positive case", "validToken", successReq, http.StatusAccepted},
+		{"auth fail", "invalidToken", successReq, http.StatusUnauthorized},
+		{"unable to create task", "noTaskToken", successReq, http.StatusInternalServerError},
+		{"malformed request", "validToken", []byte("someData"), http.StatusBadRequest},
+		{"no discovered aggregation sources", "validToken", noSourcesReq, http.StatusBadRequest},
+		{"missing password", "validToken", missingPasswordReq, http.StatusBadRequest},
+		{"invalid connection method", "validToken", invalidLinksReq, http.StatusBadRequest},
+		{"not a discovered aggregation source", "validToken", invalidSourceReq, http.StatusBadRequest},
+		{"repeated discovered aggregation source", "validToken", repeatedSourceReq, http.StatusBadRequest},
+	}
+	a := &Aggregator{connector: connector}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := a.ApproveDiscoveredAggregationSources(mockContext(), &aggregatorproto.AggregatorRequest{SessionToken: tt.sessionToken, RequestBody: tt.reqBody})
+			if err != nil {
+				t.Fatalf("Aggregator.ApproveDiscoveredAggregationSources() error = %v", err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Errorf("Aggregator.ApproveDiscoveredAggregationSources() StatusCode = %v, want %v", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
+
+func TestAggregator_RotateAggregationSourceCredentials(t *testing.T) {
+	config.SetUpMockConfig(t)
+	aggregationSource := agmodel.OdataID{OdataID: "/redfish/v1/AggregationService/AggregationSources/7a2c6100-67da-5fd6-ab82-6870d29c7279.1"}
+	successReq, _ := json.Marshal(system.RotateAggregationSourceCredentials{AggregationSources: []agmodel.OdataID{aggregationSource}})
+	noSourcesReq, _ := json.Marshal(system.RotateAggregationSourceCredentials{})
+	invalidSourceReq, _ := json.Marshal(system.RotateAggregationSourceCredentials{
+		AggregationSources: []agmodel.OdataID{{OdataID: system.DiscoveredAggregationSourcesURI + "/1"}},
+	})
+	repeatedSourceReq, _ := json.Marshal(system.RotateAggregationSourceCredentials{
+		AggregationSources: []agmodel.OdataID{aggregationSource, {OdataID: aggregationSource.OdataID + "/"}},
+	})
+	negativeConcurrencyReq, _ := json.Marshal(system.RotateAggregationSourceCredentials{
+		AggregationSources: []agmodel.OdataID{aggregationSource},
+		MaxConcurrency:     -1,
+	})
+	tests := []struct {
+		name         string
+		sessionToken string
+		reqBody      []byte
+		want         int32
+	}{
+		{"positive case", "validToken", successReq, http.StatusAccepted},
+		{"auth fail", "invalidToken", successReq, http.StatusUnauthorized},
+		{"unable to create task", "noTaskToken", successReq, http.StatusInternalServerError},
+		{"malformed request", "validToken", []byte("someData"), http.StatusBadRequest},
+		{"no aggregation sources", "validToken", noSourcesReq, http.StatusBadRequest},
+		{"not an aggregation source", "validToken", invalidSourceReq, http.StatusBadRequest},
+		{"repeated aggregation source", "validToken", repeatedSourceReq, http.StatusBadRequest},
+		{"negative MaxConcurrency", "validToken", negativeConcurrencyReq, http.StatusBadRequest},
+	}
+	a := &Aggregator{connector: connector}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := a.RotateAggregationSourceCredentials(mockContext(), &aggregatorproto.AggregatorRequest{SessionToken: tt.sessionToken, RequestBody: tt.reqBody})
+			if err != nil {
+				t.Fatalf("Aggregator.RotateAggregationSourceCredentials() error = %v", err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Errorf("Aggregator.RotateAggregationSourceCredentials() StatusCode = %v, want %v", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
+
 func TestAggregator_GetAllAggregationSource(t *testing.T) {
 	defer func() {
 		common.TruncateDB(common.OnDisk)
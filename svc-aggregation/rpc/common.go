@@ -37,35 +37,62 @@ type Aggregator struct {
 func GetAggregator() *Aggregator {
 	return &Aggregator{
 		connector: &system.ExternalInterface{
-			ContactClient:            pmbhandle.ContactPlugin,
-			Auth:                     services.IsAuthorized,
-			GetSessionUserName:       services.GetSessionUserName,
-			CreateTask:               services.CreateTask,
-			CreateChildTask:          services.CreateChildTask,
-			UpdateTask:               system.UpdateTaskData,
-			CreateSubcription:        system.CreateDefaultEventSubscription,
-			PublishEvent:             system.PublishEvent,
-			GetPluginStatus:          agcommon.GetPluginStatus,
-			SubscribeToEMB:           services.SubscribeToEMB,
-			EncryptPassword:          common.EncryptWithPublicKey,
-			DecryptPassword:          common.DecryptWithPrivateKey,
-			DeleteComputeSystem:      agmodel.DeleteComputeSystem,
-			DeleteSystem:             agmodel.DeleteSystem,
-			DeleteEventSubscription:  services.DeleteSubscription,
-			EventNotification:        agmessagebus.Publish,
-			GetAllKeysFromTable:      agmodel.GetAllKeysFromTable,
-			GetConnectionMethod:      agmodel.GetConnectionMethod,
-			UpdateConnectionMethod:   agmodel.UpdateConnectionMethod,
-			GetPluginMgrAddr:         agmodel.GetPluginData,
-			GetAggregationSourceInfo: agmodel.GetAggregationSourceInfo,
-			GenericSave:              agmodel.GenericSave,
-			CheckActiveRequest:       agmodel.CheckActiveRequest,
-			DeleteActiveRequest:      agmodel.DeleteActiveRequest,
-			GetAllMatchingDetails:    agmodel.GetAllMatchingDetails,
-			CheckMetricRequest:       agmodel.CheckMetricRequest,
-			DeleteMetricRequest:      agmodel.DeleteMetricRequest,
-			GetResource:              agmodel.GetResource,
-			Delete:                   agmodel.Delete,
+			ContactClient:                      pmbhandle.ContactPlugin,
+			Auth:                               services.IsAuthorized,
+			GetSessionUserName:                 services.GetSessionUserName,
+			CreateTask:                         services.CreateTask,
+			CreateChildTask:                    services.CreateChildTask,
+			UpdateTask:                         system.UpdateTaskData,
+			CreateSubcription:                  system.CreateDefaultEventSubscription,
+			PublishEvent:                       system.PublishEvent,
+			GetPluginStatus:                    agcommon.GetPluginStatus,
+			SubscribeToEMB:                     services.SubscribeToEMB,
+			EncryptPassword:                    common.EncryptWithPublicKey,
+			DecryptPassword:                    common.DecryptWithPrivateKey,
+			DeleteSecret:                       common.DeleteSecret,
+			DeleteComputeSystem:                agmodel.DeleteComputeSystem,
+			DeleteSystem:                       agmodel.DeleteSystem,
+			DeleteEventSubscription:            services.DeleteSubscription,
+			EventNotification:                  agmessagebus.Publish,
+			GetAllKeysFromTable:                agmodel.GetAllKeysFromTable,
+			GetConnectionMethod:                agmodel.GetConnectionMethod,
+			UpdateConnectionMethod:             agmodel.UpdateConnectionMethod,
+			GetPluginMgrAddr:                   agmodel.GetPluginData,
+			GetAggregationSourceInfo:           agmodel.GetAggregationSourceInfo,
+			GenericSave:                        agmodel.GenericSave,
+			CheckActiveRequest:                 agmodel.CheckActiveRequest,
+			DeleteActiveRequest:                agmodel.DeleteActiveRequest,
+			GetAllMatchingDetails:              agmodel.GetAllMatchingDetails,
+			CheckMetricRequest:                 agmodel.CheckMetricRequest,
+			DeleteMetricRequest:                agmodel.DeleteMetricRequest,
+			GetResource:                        agmodel.GetResource,
+			Delete:                             agmodel.Delete,
+			GetString:                          agmodel.GetString,
+			DoDiscoveryRequest:                 system.NewDiscoveryClient().Do,
+			SaveDiscoveredAggregationSource:    agmodel.SaveDiscoveredAggregationSource,
+			GetDiscoveredAggregationSourceInfo: agmodel.GetDiscoveredAggregationSource,
+			GetTarget:                          agmodel.GetTarget,
+			UpdateSystemData:                   agmodel.UpdateSystemData,
+			UpdateAggregationSourceInfo:        agmodel.UpdateAggregtionSource,
+			SaveCredentialRotation:             agmodel.SaveCredentialRotation,
+			GetCredentialRotation:              agmodel.GetCredentialRotation,
+			GetDeviceInventory:                 agmodel.GetDeviceInventory,
+			GetInventoryHistoryInfo:            agmodel.GetInventoryHistory,
+			SaveInventoryHistory:               agmodel.SaveInventoryHistory,
+			PublishEvents:                      agmessagebus.PublishEvents,
+			GetResourceETags:                   agmodel.GetResourceETags,
+			SaveResourceETags:                  agmodel.SaveResourceETags,
+			GetInventorySyncInfo:               agmodel.GetInventorySync,
+			SaveInventorySync:                  agmodel.SaveInventorySync,
+			GetInventorySyncStatus:             agmodel.GetInventorySyncStatus,
+			SaveInventorySyncStatus:            agmodel.SaveInventorySyncStatus,
+			GetInventorySyncSchedule:           agmodel.GetInventorySyncSchedule,
+			SaveInventorySyncSchedule:          agmodel.SaveInventorySyncSchedule,
+			GetAggregateInfo:                   agmodel.GetAggregate,
+			GetConformanceReportInfo:           agmodel.GetConformanceReport,
+			SaveConformanceReport:              agmodel.SaveConformanceReport,
+			AcquireLease:                       common.AcquireLease,
+			ReleaseLease:                       common.ReleaseLease,
 		},
 	}
 }
@@ -23,6 +23,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
@@ -34,29 +35,75 @@ import (
 )
 
 var connector = &system.ExternalInterface{
-	ContactClient:            mockContactClient,
-	Auth:                     mockIsAuthorized,
-	CreateTask:               createTaskForTesting,
-	CreateChildTask:          mockCreateChildTask,
-	UpdateTask:               mockUpdateTask,
-	DecryptPassword:          stubDevicePassword,
-	GetPluginStatus:          GetPluginStatusForTesting,
-	CreateSubcription:        EventFunctionsForTesting,
-	PublishEvent:             PostEventFunctionForTesting,
-	EncryptPassword:          stubDevicePassword,
-	DeleteComputeSystem:      deleteComputeforTest,
-	DeleteSystem:             deleteSystemforTest,
-	DeleteEventSubscription:  mockDeleteSubscription,
-	EventNotification:        mockEventNotification,
-	SubscribeToEMB:           mockSubscribeEMB,
-	GetSessionUserName:       getSessionUserNameForTesting,
-	GetAllKeysFromTable:      mockGetAllKeysFromTable,
-	GetConnectionMethod:      mockGetConnectionMethod,
-	UpdateConnectionMethod:   mockUpdateConnectionMethod,
-	GetAggregationSourceInfo: mockGetAggregationSourceInfo,
-	GenericSave:              mockGenericSave,
-	CheckActiveRequest:       mockCheckActiveRequest,
-	DeleteActiveRequest:      mockDeleteActiveRequest,
+	ContactClient:                      mockContactClient,
+	Auth:                               mockIsAuthorized,
+	CreateTask:                         createTaskForTesting,
+	CreateChildTask:                    mockCreateChildTask,
+	UpdateTask:                         mockUpdateTask,
+	DecryptPassword:                    stubDevicePassword,
+	GetPluginStatus:                    GetPluginStatusForTesting,
+	CreateSubcription:                  EventFunctionsForTesting,
+	PublishEvent:                       PostEventFunctionForTesting,
+	EncryptPassword:                    stubDevicePassword,
+	DeleteSecret:                       mockDeleteSecret,
+	DeleteComputeSystem:                deleteComputeforTest,
+	DeleteSystem:                       deleteSystemforTest,
+	DeleteEventSubscription:            mockDeleteSubscription,
+	EventNotification:                  mockEventNotification,
+	SubscribeToEMB:                     mockSubscribeEMB,
+	GetSessionUserName:                 getSessionUserNameForTesting,
+	GetAllKeysFromTable:                mockGetAllKeysFromTable,
+	GetConnectionMethod:                mockGetConnectionMethod,
+	UpdateConnectionMethod:             mockUpdateConnectionMethod,
+	GetAggregationSourceInfo:           mockGetAggregationSourceInfo,
+	GenericSave:                        mockGenericSave,
+	CheckActiveRequest:                 mockCheckActiveRequest,
+	DeleteActiveRequest:                mockDeleteActiveRequest,
+	GetString:                          mockGetString,
+	DoDiscoveryRequest:                 mockDoDiscoveryRequest,
+	SaveDiscoveredAggregationSource:    mockSaveDiscoveredAggregationSource,
+	GetDiscoveredAggregationSourceInfo: mockGetDiscoveredAggregationSourceInfo,
+	GetCredentialRotation:              mockGetCredentialRotation,
+	AcquireLease:                       mockAcquireLease,
+	ReleaseLease:                       mockReleaseLease,
+}
+
+func mockGetString(index, match string) ([]string, error) {
+	return nil, nil
+}
+
+func mockDoDiscoveryRequest(req *http.Request) (*http.Response, error) {
+	return nil, fmt.Errorf("no Redfish service at %v", req.URL.Host)
+}
+
+func mockSaveDiscoveredAggregationSource(discoveredSource agmodel.DiscoveredAggregationSource, discoveredSourceURI string) *errors.Error {
+	return nil
+}
+
+func mockGetDiscoveredAggregationSourceInfo(discoveredSourceURI string) (agmodel.DiscoveredAggregationSource, *errors.Error) {
+	if discoveredSourceURI == system.DiscoveredAggregationSourcesURI+"/a1b6d3b4-5c4f-5b1e-9d4b-8f1f2c8e0b11" {
+		return agmodel.DiscoveredAggregationSource{
+			HostName:         "100.0.0.1:50000",
+			ConnectionMethod: &agmodel.OdataID{OdataID: "/redfish/v1/AggregationService/ConnectionMethods/c41cbd97-937d-1b73-c41c-1b7385d3906"},
+		}, nil
+	}
+	return agmodel.DiscoveredAggregationSource{}, errors.PackError(errors.DBKeyNotFound, "no data with the with key "+discoveredSourceURI+" found")
+}
+
+func mockGetCredentialRotation(aggregationSourceURI string) (agmodel.CredentialRotation, *errors.Error) {
+	return agmodel.CredentialRotation{}, errors.PackError(errors.DBKeyNotFound, "no data with the with key "+aggregationSourceURI+" found")
+}
+
+func mockDeleteSecret(secret []byte) error {
+	return nil
+}
+
+func mockAcquireLease(name string, ttl time.Duration) (bool, *errors.Error) {
+	return true, nil
+}
+
+func mockReleaseLease(name string) *errors.Error {
+	return nil
 }
 
 func mockGetAggregationSourceInfo(reqURI string) (agmodel.AggregationSource, *errors.Error) {
@@ -932,34 +932,126 @@ func mockDelete(table, key string, dbtype common.DbType) *errors.Error {
 	return nil
 }
 
+func mockGetDeviceInventory(deviceUUID string) (map[string]string, *errors.Error) {
+	return map[string]string{}, nil
+}
+
+func mockGetInventoryHistory(systemID string) (agmodel.InventoryHistory, *errors.Error) {
+	return agmodel.InventoryHistory{}, errors.PackError(errors.DBKeyNotFound, "error: data with key ", systemID, " does not exist")
+}
+
+func mockSaveInventoryHistory(history agmodel.InventoryHistory, systemID string) *errors.Error {
+	return nil
+}
+
+func mockGetConformanceReport(systemID string) (agmodel.ConformanceReport, *errors.Error) {
+	return agmodel.ConformanceReport{}, errors.PackError(errors.DBKeyNotFound, "error: data with key ", systemID, " does not exist")
+}
+
+func mockSaveConformanceReport(report agmodel.ConformanceReport, systemID string) *errors.Error {
+	return nil
+}
+
+func mockPublishEvents(ctx context.Context, collectionType string, events []common.Event) {
+}
+
+func mockGetResourceETags(deviceUUID string) (map[string]string, *errors.Error) {
+	return map[string]string{}, nil
+}
+
+func mockSaveResourceETags(etags map[string]string, deviceUUID string) *errors.Error {
+	return nil
+}
+
+func mockGetInventorySync() (agmodel.InventorySync, *errors.Error) {
+	return agmodel.InventorySync{}, errors.PackError(errors.DBKeyNotFound, "error: data with key InventorySync does not exist")
+}
+
+func mockSaveInventorySync(inventorySync agmodel.InventorySync) *errors.Error {
+	return nil
+}
+
+func mockGetInventorySyncStatus(systemID string) (agmodel.InventorySyncStatus, *errors.Error) {
+	return agmodel.InventorySyncStatus{}, errors.PackError(errors.DBKeyNotFound, "error: data with key ", systemID, " does not exist")
+}
+
+func mockSaveInventorySyncStatus(status agmodel.InventorySyncStatus, systemID string) *errors.Error {
+	return nil
+}
+
+func mockGetInventorySyncSchedule() (agmodel.InventorySyncSchedule, *errors.Error) {
+	return agmodel.InventorySyncSchedule{}, errors.PackError(errors.DBKeyNotFound, "error: data with key InventorySyncSchedule does not exist")
+}
+
+func mockSaveInventorySyncSchedule(schedule agmodel.InventorySyncSchedule) *errors.Error {
+	return nil
+}
+
+func mockDeleteSecret(secret []byte) error {
+	return nil
+}
+
+func mockAcquireLease(name string, ttl time.Duration) (bool, *errors.Error) {
+	return true, nil
+}
+
+func mockReleaseLease(name string) *errors.Error {
+	return nil
+}
+
+func mockGetAggregate(aggregateURI string) (agmodel.Aggregate, *errors.Error) {
+	if aggregateURI == "/redfish/v1/AggregationService/Aggregates/c14d91b5-3333-48bb-a7b7-75f74a137d48" {
+		return agmodel.Aggregate{Elements: []agmodel.OdataID{{OdataID: "/redfish/v1/Systems/7a2c6100-67da-5fd6-ab82-6870d29c7279.1"}}}, nil
+	}
+	return agmodel.Aggregate{}, errors.PackError(errors.DBKeyNotFound, "error: data with key ", aggregateURI, " does not exist")
+}
+
 func getMockExternalInterface() *ExternalInterface {
 	return &ExternalInterface{
-		ContactClient:           mockContactClient,
-		Auth:                    mockIsAuthorized,
-		CreateChildTask:         mockCreateChildTask,
-		UpdateTask:              mockUpdateTask,
-		CreateSubcription:       EventFunctionsForTesting,
-		PublishEvent:            PostEventFunctionForTesting,
-		GetPluginStatus:         GetPluginStatusForTesting,
-		PublishEventMB:          mockPublishEventMB,
-		SubscribeToEMB:          mockSubscribeEMB,
-		EncryptPassword:         stubDevicePassword,
-		DecryptPassword:         stubDevicePassword,
-		GetConnectionMethod:     mockGetConnectionMethod,
-		UpdateConnectionMethod:  mockUpdateConnectionMethod,
-		GetAllKeysFromTable:     mockGetAllKeysFromTable,
-		GetPluginMgrAddr:        stubPluginMgrAddrData,
-		GenericSave:             mockGenericSave,
-		CheckActiveRequest:      mockCheckActiveRequest,
-		DeleteActiveRequest:     mockDeleteActiveRequest,
-		DeleteComputeSystem:     deleteComputeforTest,
-		DeleteSystem:            deleteSystemforTest,
-		DeleteEventSubscription: mockDeleteSubscription,
-		EventNotification:       mockEventNotification,
-		GetAllMatchingDetails:   mockGetAllMatchingDetails,
-		CheckMetricRequest:      mockCheckMetricRequest,
-		DeleteMetricRequest:     mockDeleteMetricRequest,
-		GetResource:             mockGetResource,
-		Delete:                  mockDelete,
+		ContactClient:             mockContactClient,
+		Auth:                      mockIsAuthorized,
+		CreateChildTask:           mockCreateChildTask,
+		UpdateTask:                mockUpdateTask,
+		CreateSubcription:         EventFunctionsForTesting,
+		PublishEvent:              PostEventFunctionForTesting,
+		GetPluginStatus:           GetPluginStatusForTesting,
+		PublishEventMB:            mockPublishEventMB,
+		SubscribeToEMB:            mockSubscribeEMB,
+		EncryptPassword:           stubDevicePassword,
+		DecryptPassword:           stubDevicePassword,
+		DeleteSecret:              mockDeleteSecret,
+		GetConnectionMethod:       mockGetConnectionMethod,
+		UpdateConnectionMethod:    mockUpdateConnectionMethod,
+		GetAllKeysFromTable:       mockGetAllKeysFromTable,
+		GetPluginMgrAddr:          stubPluginMgrAddrData,
+		GenericSave:               mockGenericSave,
+		CheckActiveRequest:        mockCheckActiveRequest,
+		DeleteActiveRequest:       mockDeleteActiveRequest,
+		DeleteComputeSystem:       deleteComputeforTest,
+		DeleteSystem:              deleteSystemforTest,
+		DeleteEventSubscription:   mockDeleteSubscription,
+		EventNotification:         mockEventNotification,
+		GetAllMatchingDetails:     mockGetAllMatchingDetails,
+		CheckMetricRequest:        mockCheckMetricRequest,
+		DeleteMetricRequest:       mockDeleteMetricRequest,
+		GetResource:               mockGetResource,
+		Delete:                    mockDelete,
+		GetDeviceInventory:        mockGetDeviceInventory,
+		GetInventoryHistoryInfo:   mockGetInventoryHistory,
+		SaveInventoryHistory:      mockSaveInventoryHistory,
+		PublishEvents:             mockPublishEvents,
+		GetResourceETags:          mockGetResourceETags,
+		SaveResourceETags:         mockSaveResourceETags,
+		GetInventorySyncInfo:      mockGetInventorySync,
+		SaveInventorySync:         mockSaveInventorySync,
+		GetInventorySyncStatus:    mockGetInventorySyncStatus,
+		SaveInventorySyncStatus:   mockSaveInventorySyncStatus,
+		GetInventorySyncSchedule:  mockGetInventorySyncSchedule,
+		SaveInventorySyncSchedule: mockSaveInventorySyncSchedule,
+		GetAggregateInfo:          mockGetAggregate,
+		GetConformanceReportInfo:  mockGetConformanceReport,
+		SaveConformanceReport:     mockSaveConformanceReport,
+		AcquireLease:              mockAcquireLease,
+		ReleaseLease:              mockReleaseLease,
 	}
 }
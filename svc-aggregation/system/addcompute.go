@@ -128,6 +128,11 @@ func (e *ExternalInterface) addCompute(ctx context.Context, taskID, targetURI, p
 	progress := percentComplete
 	systemsEstimatedWork := int32(60)
 	var computeSystemID, resourceURI string
+	// discovered records the completed discovery stage and checks whether the task got cancelled meanwhile
+	discovered := func(stage string) bool {
+		common.AddCompletedOperation(taskID, stage)
+		return ctx.Err() != nil
+	}
 	if computeSystemID, resourceURI, progress, err = h.getAllSystemInfo(ctx, taskID, progress, systemsEstimatedWork, pluginContactRequest); err != nil {
 		errMsg := "error while trying to add compute: " + err.Error()
 		l.LogWithFields(ctx).Error(errMsg)
@@ -151,6 +156,10 @@ func (e *ExternalInterface) addCompute(ctx context.Context, taskID, targetURI, p
 	percentComplete = progress
 	task = fillTaskData(taskID, targetURI, pluginContactRequest.TaskRequest, resp, common.Running, common.OK, percentComplete, http.MethodPost)
 	e.UpdateTask(ctx, task)
+	if discovered("Systems discovery") {
+		go e.rollbackInMemory(resourceURI)
+		return e.cancelTask(ctx, taskID, targetURI, pluginContactRequest.TaskRequest, percentComplete), "", nil
+	}
 	h.InventoryData = make(map[string]interface{})
 
 	// Populate the resource Firmware inventory for update service
@@ -213,6 +222,10 @@ func (e *ExternalInterface) addCompute(ctx context.Context, taskID, targetURI, p
 		go e.rollbackInMemory(resourceURI)
 		return resp, "", nil
 	}
+	if discovered("Registries discovery") {
+		go e.rollbackInMemory(resourceURI)
+		return e.cancelTask(ctx, taskID, targetURI, pluginContactRequest.TaskRequest, percentComplete), "", nil
+	}
 
 	// End of Registry files Discovery
 
@@ -239,6 +252,10 @@ func (e *ExternalInterface) addCompute(ctx context.Context, taskID, targetURI, p
 		go e.rollbackInMemory(resourceURI)
 		return resp, "", nil
 	}
+	if discovered("Chassis discovery") {
+		go e.rollbackInMemory(resourceURI)
+		return e.cancelTask(ctx, taskID, targetURI, pluginContactRequest.TaskRequest, percentComplete), "", nil
+	}
 
 	//Logic for getting the manager information
 	// Logic for getting manager information and saving it into the database
@@ -264,6 +281,10 @@ func (e *ExternalInterface) addCompute(ctx context.Context, taskID, targetURI, p
 		go e.rollbackInMemory(resourceURI)
 		return resp, "", nil
 	}
+	if discovered("Managers discovery") {
+		go e.rollbackInMemory(resourceURI)
+		return e.cancelTask(ctx, taskID, targetURI, pluginContactRequest.TaskRequest, percentComplete), "", nil
+	}
 	if h.ErrorMessage != "" && h.StatusCode != http.StatusServiceUnavailable && h.StatusCode != http.StatusNotFound && h.StatusCode != http.StatusInternalServerError && h.StatusCode != http.StatusBadRequest {
 		go e.rollbackInMemory(resourceURI)
 		l.LogWithFields(ctx).Error(h.ErrorMessage)
@@ -304,6 +325,7 @@ func (e *ExternalInterface) addCompute(ctx context.Context, taskID, targetURI, p
 	// get all managers and chassis info
 	pluginContactRequest.PublishEvent(ctx, chassisList, "ChassisCollection")
 	pluginContactRequest.PublishEvent(ctx, managersList, "ManagerCollection")
+	go e.validateConformanceOnAdd(ctx, h.SystemURL)
 
 	h.PluginResponse = strings.Replace(h.PluginResponse, `/redfish/v1/Systems/`, `/redfish/v1/Systems/`+saveSystem.DeviceUUID+`.`, -1)
 	var list agresponse.List
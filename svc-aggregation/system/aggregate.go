@@ -69,6 +69,8 @@ func (e *ExternalInterface) CreateAggregate(ctx context.Context, req *aggregator
 		l.LogWithFields(ctx).Error(errMsg)
 		return common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errMsg, nil, nil)
 	}
+	// the settings baseline is only set by the ApplySettings action
+	createRequest.Settings = nil
 	//empty request check
 	if reflect.DeepEqual(agmodel.Aggregate{}, createRequest) {
 		errMsg := "empty request can not be processed"
@@ -206,7 +208,7 @@ func (e *ExternalInterface) GetAggregate(ctx context.Context, req *aggregatorpro
 		StatusMessage: response.Success,
 	}
 
-	resp.Body = agresponse.AggregateGetResponse{
+	aggregateResponse := agresponse.AggregateGetResponse{
 		Response:      commonResponse,
 		ElementsCount: len(aggregate.Elements),
 		Elements:      aggregate.Elements,
@@ -223,8 +225,20 @@ func (e *ExternalInterface) GetAggregate(ctx context.Context, req *aggregatorpro
 			AggregateRemoveElements: agresponse.Action{
 				Target: "/redfish/v1/AggregationService/Aggregates/" + ID + "/Actions/Aggregate.RemoveElements",
 			},
+			AggregateApplySettings: agresponse.Action{
+				Target: "/redfish/v1/AggregationService/Aggregates/" + ID + "/Actions/Aggregate.ApplySettings",
+			},
 		},
 	}
+	if aggregate.Settings != nil {
+		aggregateResponse.Oem = &agresponse.AggregateOem{
+			Odim: agresponse.AggregateOdim{
+				SettingsBaseline: *aggregate.Settings,
+				SettingsDrift:    agresponse.OdataID{OdataID: req.URL + "/Oem/Odim/SettingsDrift"},
+			},
+		}
+	}
+	resp.Body = aggregateResponse
 	return resp
 }
 
@@ -614,6 +628,8 @@ func (e *ExternalInterface) resetSystem(ctx context.Context, taskID, reqBody str
 	} else {
 		subTaskID = strArray[len(strArray)-1]
 	}
+	ctx, done := common.TrackTask(ctx, subTaskID)
+	defer done()
 	systemID := element[strings.LastIndexAny(element, "/")+1:]
 	var targetURI = element
 	taskInfo := &common.TaskUpdateInfo{Context: ctx, TaskID: subTaskID, TargetURI: targetURI, UpdateTask: e.UpdateTask, TaskRequest: reqBody}
@@ -654,6 +670,12 @@ func (e *ExternalInterface) resetSystem(ctx context.Context, taskID, reqBody str
 		common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"plugin", target.PluginID}, taskInfo)
 		return
 	}
+	if ctx.Err() != nil {
+		subTaskChan <- http.StatusInternalServerError
+		l.LogWithFields(ctx).Warn("reset of the target " + element + " is cancelled")
+		e.cancelTask(ctx, subTaskID, targetURI, reqBody, percentComplete)
+		return
+	}
 	var pluginContactRequest getResourceRequest
 	pluginContactRequest.ContactClient = e.ContactClient
 	pluginContactRequest.GetPluginStatus = e.GetPluginStatus
@@ -685,6 +707,12 @@ func (e *ExternalInterface) resetSystem(ctx context.Context, taskID, reqBody str
 		}
 
 	}
+	if ctx.Err() != nil {
+		subTaskChan <- http.StatusInternalServerError
+		l.LogWithFields(ctx).Warn("reset of the target " + element + " is cancelled")
+		e.cancelTask(ctx, subTaskID, targetURI, reqBody, percentComplete)
+		return
+	}
 	// Adding system state entry to db
 	postRequest := make(map[string]interface{})
 	postRequest["ResetType"] = resetType
@@ -730,6 +758,7 @@ func (e *ExternalInterface) resetSystem(ctx context.Context, taskID, reqBody str
 	}
 	resp.StatusCode = getResponse.StatusCode
 	percentComplete = 100
+	common.AddCompletedOperation(taskID, "Reset of "+element)
 	subTaskChan <- int32(getResponse.StatusCode)
 	var task = fillTaskData(subTaskID, targetURI, reqBody, resp, common.Completed, common.OK, percentComplete, http.MethodPost)
 	err = e.UpdateTask(ctx, task)
@@ -270,6 +270,9 @@ func TestExternalInterface_GetAggregate(t *testing.T) {
 						AggregateRemoveElements: agresponse.Action{
 							Target: "/redfish/v1/AggregationService/Aggregates/7ff3bd97-c41c-5de0-937d-85d390691b73/Actions/Aggregate.RemoveElements",
 						},
+						AggregateApplySettings: agresponse.Action{
+							Target: "/redfish/v1/AggregationService/Aggregates/7ff3bd97-c41c-5de0-937d-85d390691b73/Actions/Aggregate.ApplySettings",
+						},
 					},
 				},
 			},
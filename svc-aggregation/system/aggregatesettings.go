@@ -0,0 +1,490 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package system ...
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agresponse"
+)
+
+const (
+	driftStateCompliant = "Compliant"
+	driftStateDrifted   = "Drifted"
+	driftStateUnknown   = "Unknown"
+)
+
+// ApplySettingsElementsOfAggregate is the handler for applying the BIOS attributes and the boot order to the elements
+// of an aggregate. The settings are saved as the baseline of the aggregate and applied to each element in a sub task
+func (e *ExternalInterface) ApplySettingsElementsOfAggregate(ctx context.Context, taskID string, sessionUserName string, req *aggregatorproto.AggregatorRequest) response.RPC {
+	var resp response.RPC
+	var percentComplete int32
+	targetURI := req.URL
+
+	taskInfo := &common.TaskUpdateInfo{Context: ctx, TaskID: taskID, TargetURI: targetURI, UpdateTask: e.UpdateTask, TaskRequest: string(req.RequestBody)}
+
+	var settings agmodel.AggregateSettings
+	if err := json.Unmarshal(req.RequestBody, &settings); err != nil {
+		errMsg := "error while trying to validate request fields: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errMsg, nil, taskInfo)
+	}
+
+	// Validating the request JSON properties for case sensitive
+	invalidProperties, err := common.RequestParamsCaseValidator(req.RequestBody, settings)
+	if err != nil {
+		errMsg := "error while validating request parameters: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+	} else if invalidProperties != "" {
+		errorMessage := "error: one or more properties given in the request body are not valid, ensure properties are listed in uppercamelcase "
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errorMessage, []interface{}{invalidProperties}, taskInfo)
+	}
+
+	if statusMessage, errArgs, err := validateAggregateSettings(settings); err != nil {
+		errMsg := "error while trying to validate request fields: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, statusMessage, errMsg, errArgs, taskInfo)
+	}
+
+	aggregateURL := aggregatesURI + getAggregateID(req.URL)
+	aggregate, aggErr := agmodel.GetAggregate(aggregateURL)
+	if aggErr != nil {
+		errorMessage := aggErr.Error()
+		l.LogWithFields(ctx).Error("error getting aggregate : " + errorMessage)
+		if errors.DBKeyNotFound == aggErr.ErrNo() {
+			return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errorMessage, []interface{}{"Aggregate", aggregateURL}, taskInfo)
+		}
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, taskInfo)
+	}
+	if aggErr = agmodel.UpdateAggregateSettings(settings, aggregateURL); aggErr != nil {
+		errorMessage := "error while saving the settings baseline of the aggregate: " + aggErr.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, taskInfo)
+	}
+
+	// subTaskChan is a buffered channel with buffer size equal to total number of elements,
+	// so the spanned goroutines can exit even when the task is cancelled and the status codes are not read.
+	subTaskChan := make(chan int32, len(aggregate.Elements))
+	resp.StatusCode = http.StatusOK
+	var wg sync.WaitGroup
+	threadID := 1
+	for _, element := range aggregate.Elements {
+		wg.Add(1)
+		ctxt := context.WithValue(ctx, common.ThreadName, common.ApplySystemSettings)
+		ctxt = context.WithValue(ctxt, common.ThreadID, strconv.Itoa(threadID))
+		go e.applySystemSettings(ctxt, taskID, string(req.RequestBody), subTaskChan, sessionUserName, element.OdataID, settings, &wg)
+		threadID++
+	}
+
+	partialResultFlag := false
+	for i := 0; i < len(aggregate.Elements); i++ {
+		statusCode := <-subTaskChan
+		if statusCode != http.StatusOK {
+			partialResultFlag = true
+			if resp.StatusCode < statusCode {
+				resp.StatusCode = statusCode
+			}
+		}
+		if i < len(aggregate.Elements)-1 {
+			percentComplete = int32((i + 1) * 100 / len(aggregate.Elements))
+			var task = fillTaskData(taskID, targetURI, string(req.RequestBody), resp, common.Running, common.OK, percentComplete, http.MethodPost)
+			err := e.UpdateTask(ctx, task)
+			if err != nil && err.Error() == common.Cancelling {
+				task = fillTaskData(taskID, targetURI, string(req.RequestBody), resp, common.Cancelled, common.OK, percentComplete, http.MethodPost)
+				e.UpdateTask(ctx, task)
+				runtime.Goexit()
+			}
+		}
+	}
+	wg.Wait()
+
+	taskStatus := common.OK
+	if partialResultFlag {
+		taskStatus = common.Warning
+	}
+	percentComplete = 100
+	if resp.StatusCode != http.StatusOK {
+		errMsg := "one or more of the ApplySettings requests failed. for more information please check SubTasks in URI: /redfish/v1/TaskService/Tasks/" + taskID
+		l.LogWithFields(ctx).Error(errMsg)
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			return common.GeneralError(http.StatusUnauthorized, response.ResourceAtURIUnauthorized, errMsg, []interface{}{fmt.Sprintf("%v", aggregate.Elements)}, taskInfo)
+		case http.StatusNotFound:
+			return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"option", "ApplySettings"}, taskInfo)
+		default:
+			return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+		}
+	}
+
+	l.LogWithFields(ctx).Info("all ApplySettings requests successfully completed. for more information please check SubTasks in URI: /redfish/v1/TaskService/Tasks/" + taskID)
+	resp.StatusMessage = response.Success
+	resp.StatusCode = http.StatusOK
+	args := response.Args{
+		Code:    resp.StatusMessage,
+		Message: "Request completed successfully",
+	}
+	resp.Body = args.CreateGenericErrorResponse()
+
+	var task = fillTaskData(taskID, targetURI, string(req.RequestBody), resp, common.Completed, taskStatus, percentComplete, http.MethodPost)
+	err = e.UpdateTask(ctx, task)
+	if err != nil && err.Error() == common.Cancelling {
+		task = fillTaskData(taskID, targetURI, string(req.RequestBody), resp, common.Cancelled, common.Critical, percentComplete, http.MethodPost)
+		e.UpdateTask(ctx, task)
+		runtime.Goexit()
+	}
+	return resp
+}
+
+// validateAggregateSettings checks that the request has settings to apply, that the BIOS attribute values are
+// strings, numbers or booleans, and that the boot order has no empty entries
+func validateAggregateSettings(settings agmodel.AggregateSettings) (string, []interface{}, error) {
+	if len(settings.Attributes) == 0 && settings.Boot == nil {
+		return response.PropertyMissing, []interface{}{"Attributes"}, fmt.Errorf("no Attributes or Boot given in the request")
+	}
+	for name, value := range settings.Attributes {
+		switch value.(type) {
+		case string, float64, bool:
+		default:
+			return response.PropertyValueTypeError, []interface{}{fmt.Sprintf("%v", value), "Attributes/" + name},
+				fmt.Errorf("the value of the attribute %s is not a string, a number or a boolean", name)
+		}
+	}
+	if settings.Boot != nil {
+		if len(settings.Boot.BootOrder) == 0 {
+			return response.PropertyMissing, []interface{}{"Boot/BootOrder"}, fmt.Errorf("property BootOrder missing in Boot")
+		}
+		for _, bootReference := range settings.Boot.BootOrder {
+			if bootReference == "" {
+				return response.PropertyValueFormatError, []interface{}{fmt.Sprintf("%v", settings.Boot.BootOrder), "Boot/BootOrder"},
+					fmt.Errorf("the boot order has an empty boot reference")
+			}
+		}
+	}
+	return "", nil, nil
+}
+
+// applySystemSettings patches the pending BIOS settings and then the boot order of the system, in the sub task
+// of the element
+func (e *ExternalInterface) applySystemSettings(ctx context.Context, taskID, reqBody string, subTaskChan chan<- int32, sessionUserName, element string, settings agmodel.AggregateSettings, wg *sync.WaitGroup) {
+	defer wg.Done()
+	l.LogWithFields(ctx).Info("applying the settings of the aggregate to the target " + element + " has been started.")
+	var resp response.RPC
+	var percentComplete int32
+	//Create the child Task
+	subTaskURI, err := e.CreateChildTask(ctx, sessionUserName, taskID)
+	if err != nil {
+		subTaskChan <- http.StatusInternalServerError
+		l.LogWithFields(ctx).Error("error while trying to create sub task")
+		return
+	}
+	var subTaskID string
+	strArray := strings.Split(subTaskURI, "/")
+	if strings.HasSuffix(subTaskURI, "/") {
+		subTaskID = strArray[len(strArray)-2]
+	} else {
+		subTaskID = strArray[len(strArray)-1]
+	}
+	ctx, done := common.TrackTask(ctx, subTaskID)
+	defer done()
+	targetURI := element
+	taskInfo := &common.TaskUpdateInfo{Context: ctx, TaskID: subTaskID, TargetURI: targetURI, UpdateTask: e.UpdateTask, TaskRequest: reqBody}
+
+	uuid, sysID, err := getIDsFromURI(element)
+	if err != nil {
+		subTaskChan <- http.StatusNotFound
+		errMsg := "error while trying to get system ID from " + element + ": " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"SystemID", element}, taskInfo)
+		return
+	}
+	// Get target device Credentials from using device UUID
+	target, err := agmodel.GetTarget(uuid)
+	if err != nil {
+		subTaskChan <- http.StatusNotFound
+		errMsg := err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"target", uuid}, taskInfo)
+		return
+	}
+	decryptedPasswordByte, err := e.DecryptPassword(target.Password)
+	if err != nil {
+		subTaskChan <- http.StatusInternalServerError
+		errMsg := "error while trying to decrypt device password: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+		return
+	}
+	target.Password = decryptedPasswordByte
+	// Get the Plugin info
+	plugin, errs := agmodel.GetPluginData(target.PluginID)
+	if errs != nil {
+		subTaskChan <- http.StatusNotFound
+		errMsg := errs.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"plugin", target.PluginID}, taskInfo)
+		return
+	}
+
+	var pluginContactRequest getResourceRequest
+	pluginContactRequest.ContactClient = e.ContactClient
+	pluginContactRequest.GetPluginStatus = e.GetPluginStatus
+	pluginContactRequest.Plugin = plugin
+	pluginContactRequest.StatusPoll = true
+	pluginContactRequest.TaskRequest = reqBody
+
+	if strings.EqualFold(plugin.PreferredAuthType, "XAuthToken") {
+		pluginContactRequest.HTTPMethodType = http.MethodPost
+		pluginContactRequest.DeviceInfo = map[string]interface{}{
+			"UserName": plugin.Username,
+			"Password": string(plugin.Password),
+		}
+		pluginContactRequest.OID = "/ODIM/v1/Sessions"
+		_, token, getResponse, err := contactPlugin(ctx, pluginContactRequest, "error while logging in to plugin: ")
+		if err != nil {
+			subTaskChan <- getResponse.StatusCode
+			errMsg := err.Error()
+			l.LogWithFields(ctx).Error(errMsg)
+			common.GeneralError(getResponse.StatusCode, getResponse.StatusMessage, errMsg, getResponse.MsgArgs, taskInfo)
+			return
+		}
+		pluginContactRequest.Token = token
+	} else {
+		pluginContactRequest.LoginCredentials = map[string]string{
+			"UserName": plugin.Username,
+			"Password": string(plugin.Password),
+		}
+	}
+
+	monitorRequest := &monitorTaskRequest{
+		subTaskID:         subTaskID,
+		serverURI:         targetURI,
+		updateRequestBody: reqBody,
+		taskInfo:          taskInfo,
+		resp:              resp,
+	}
+	var getResponse responseStatus
+	if len(settings.Attributes) != 0 {
+		if ctx.Err() != nil {
+			subTaskChan <- http.StatusInternalServerError
+			l.LogWithFields(ctx).Warn("applying the settings to the target " + element + " is cancelled")
+			e.cancelTask(ctx, subTaskID, targetURI, reqBody, percentComplete)
+			return
+		}
+		postBody, _ := json.Marshal(map[string]interface{}{"Attributes": settings.Attributes})
+		target.PostBody = postBody
+		pluginContactRequest.DeviceInfo = target
+		pluginContactRequest.OID = "/ODIM/v1/Systems/" + sysID + "/Bios/Settings"
+		if getResponse, err = e.patchSystemSettings(ctx, subTaskChan, pluginContactRequest, monitorRequest, "error while changing the bios settings: "); err != nil {
+			return
+		}
+		agmodel.AddSystemResetInfo(element+"/Bios/Settings", "None")
+		common.AddCompletedOperation(subTaskID, "BIOS settings of "+element)
+		percentComplete = 50
+	}
+	if settings.Boot != nil {
+		if ctx.Err() != nil {
+			subTaskChan <- http.StatusInternalServerError
+			l.LogWithFields(ctx).Warn("applying the settings to the target " + element + " is cancelled")
+			e.cancelTask(ctx, subTaskID, targetURI, reqBody, percentComplete)
+			return
+		}
+		postBody, _ := json.Marshal(map[string]interface{}{"Boot": map[string]interface{}{"BootOrder": settings.Boot.BootOrder}})
+		target.PostBody = postBody
+		pluginContactRequest.DeviceInfo = target
+		pluginContactRequest.OID = "/ODIM/v1/Systems/" + sysID
+		if getResponse, err = e.patchSystemSettings(ctx, subTaskChan, pluginContactRequest, monitorRequest, "error while changing the boot order settings: "); err != nil {
+			return
+		}
+		agmodel.AddSystemResetInfo(element, "On")
+		common.AddCompletedOperation(subTaskID, "Boot order of "+element)
+	}
+
+	resp.StatusMessage = response.Success
+	resp.Body = response.ErrorClass{
+		Code:    resp.StatusMessage,
+		Message: "Request completed successfully.",
+	}
+	resp.Header = map[string]string{
+		"Location": element,
+	}
+	resp.StatusCode = getResponse.StatusCode
+	percentComplete = 100
+	common.AddCompletedOperation(taskID, "ApplySettings of "+element)
+	subTaskChan <- getResponse.StatusCode
+	var task = fillTaskData(subTaskID, targetURI, reqBody, resp, common.Completed, common.OK, percentComplete, http.MethodPost)
+	err = e.UpdateTask(ctx, task)
+	if err != nil && err.Error() == common.Cancelling {
+		var task = fillTaskData(subTaskID, targetURI, reqBody, resp, common.Cancelled, common.Critical, percentComplete, http.MethodPost)
+		e.UpdateTask(ctx, task)
+	}
+}
+
+// patchSystemSettings sends the PATCH request to the plugin and waits for the plugin task when the request
+// is accepted. On failure the status is sent to subTaskChan and the sub task is updated
+func (e *ExternalInterface) patchSystemSettings(ctx context.Context, subTaskChan chan<- int32, pluginContactRequest getResourceRequest,
+	monitorRequest *monitorTaskRequest, errorMessage string) (responseStatus, error) {
+	pluginContactRequest.HTTPMethodType = http.MethodPatch
+	respBody, location, getResponse, err := contactPlugin(ctx, pluginContactRequest, errorMessage)
+	if err != nil {
+		subTaskChan <- getResponse.StatusCode
+		errMsg := err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		common.GeneralError(getResponse.StatusCode, getResponse.StatusMessage, errMsg, getResponse.MsgArgs, monitorRequest.taskInfo)
+		return getResponse, err
+	}
+	if getResponse.StatusCode == http.StatusAccepted {
+		monitorRequest.respBody = respBody
+		monitorRequest.getResponse = getResponse
+		monitorRequest.location = location
+		monitorRequest.pluginRequest = pluginContactRequest
+		return e.monitorPluginTask(ctx, subTaskChan, monitorRequest)
+	}
+	return getResponse, nil
+}
+
+// GetAggregateSettingsDrift is the handler for getting the drift of the BIOS attributes and the boot order of the
+// elements of an aggregate from the settings baseline of the aggregate. The current settings are read from the
+// inventory of the systems
+func (e *ExternalInterface) GetAggregateSettingsDrift(ctx context.Context, req *aggregatorproto.AggregatorRequest) response.RPC {
+	aggregateID := getAggregateID(req.URL)
+	aggregateURL := aggregatesURI + aggregateID
+	aggregate, err := agmodel.GetAggregate(aggregateURL)
+	if err != nil {
+		errorMessage := err.Error()
+		l.LogWithFields(ctx).Error("error getting aggregate : " + errorMessage)
+		if errors.DBKeyNotFound == err.ErrNo() {
+			return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errorMessage, []interface{}{"Aggregate", aggregateURL}, nil)
+		}
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	if aggregate.Settings == nil {
+		errorMessage := "no settings baseline is applied to the aggregate " + aggregateURL
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errorMessage, []interface{}{"SettingsDrift", aggregateURL}, nil)
+	}
+
+	driftResponse := agresponse.AggregateSettingsDriftResponse{
+		Baseline: *aggregate.Settings,
+		Members:  []agresponse.SettingsDriftMember{},
+	}
+	for _, element := range aggregate.Elements {
+		member := e.getSettingsDrift(ctx, element.OdataID, *aggregate.Settings)
+		if member.State == driftStateDrifted {
+			driftResponse.MembersInDrift++
+		}
+		driftResponse.Members = append(driftResponse.Members, member)
+	}
+	driftResponse.Response = response.Response{
+		OdataType:    "#OdimAggregateSettingsDrift.v1_0_0.OdimAggregateSettingsDrift",
+		OdataID:      aggregateURL + "/Oem/Odim/SettingsDrift",
+		OdataContext: "/redfish/v1/$metadata#OdimAggregateSettingsDrift.OdimAggregateSettingsDrift",
+		ID:           "SettingsDrift",
+		Name:         "Aggregate Settings Drift",
+	}
+	return response.RPC{
+		StatusCode:    http.StatusOK,
+		StatusMessage: response.Success,
+		Body:          driftResponse,
+	}
+}
+
+// getSettingsDrift compares the BIOS attributes and the boot order of the system in the inventory with the baseline
+func (e *ExternalInterface) getSettingsDrift(ctx context.Context, systemURI string, baseline agmodel.AggregateSettings) agresponse.SettingsDriftMember {
+	member := agresponse.SettingsDriftMember{
+		System: agresponse.OdataID{OdataID: systemURI},
+		State:  driftStateCompliant,
+		Drift:  []agresponse.SettingDrift{},
+	}
+	if len(baseline.Attributes) != 0 {
+		var bios struct {
+			Attributes map[string]interface{} `json:"Attributes"`
+		}
+		data, err := e.GetResource("Bios", systemURI+"/Bios")
+		if err == nil && data != "" {
+			if jsonErr := json.Unmarshal([]byte(data), &bios); jsonErr != nil {
+				l.LogWithFields(ctx).Error("error while trying to unmarshal the BIOS of " + systemURI + ": " + jsonErr.Error())
+			}
+		}
+		if bios.Attributes == nil {
+			member.State = driftStateUnknown
+			member.Message = "the BIOS attributes of the system are not found in the inventory"
+			return member
+		}
+		names := make([]string, 0, len(baseline.Attributes))
+		for name := range baseline.Attributes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			current, ok := bios.Attributes[name]
+			if !ok || !reflect.DeepEqual(current, baseline.Attributes[name]) {
+				member.Drift = append(member.Drift, agresponse.SettingDrift{
+					Property: "Attributes/" + name,
+					Desired:  baseline.Attributes[name],
+					Current:  current,
+				})
+			}
+		}
+	}
+	if baseline.Boot != nil {
+		var system struct {
+			Boot struct {
+				BootOrder []string `json:"BootOrder"`
+			} `json:"Boot"`
+		}
+		data, err := e.GetResource("ComputerSystem", systemURI)
+		if err != nil || data == "" {
+			member.State = driftStateUnknown
+			member.Message = "the system is not found in the inventory"
+			return member
+		}
+		if jsonErr := json.Unmarshal([]byte(data), &system); jsonErr != nil {
+			l.LogWithFields(ctx).Error("error while trying to unmarshal the system " + systemURI + ": " + jsonErr.Error())
+		}
+		if !reflect.DeepEqual(system.Boot.BootOrder, baseline.Boot.BootOrder) {
+			var current interface{}
+			if system.Boot.BootOrder != nil {
+				current = system.Boot.BootOrder
+			}
+			member.Drift = append(member.Drift, agresponse.SettingDrift{
+				Property: "Boot/BootOrder",
+				Desired:  baseline.Boot.BootOrder,
+				Current:  current,
+			})
+		}
+	}
+	if len(member.Drift) != 0 {
+		member.State = driftStateDrifted
+	}
+	return member
+}
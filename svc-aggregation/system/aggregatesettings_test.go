@@ -0,0 +1,189 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agresponse"
+)
+
+const mockSettingsAggregateURI = "/redfish/v1/AggregationService/Aggregates/7ff3bd97-c41c-5de0-937d-85d390691b73"
+
+func TestExternalInterface_ApplySettingsElementsOfAggregate(t *testing.T) {
+	common.MuxLock.Lock()
+	config.SetUpMockConfig(t)
+	common.MuxLock.Unlock()
+	defer func() {
+		common.TruncateDB(common.OnDisk)
+		common.TruncateDB(common.InMemory)
+	}()
+	device1 := agmodel.Target{
+		ManagerAddress: "100.0.0.1",
+		Password:       []byte("imKp3Q6Cx989b6JSPHnRhritEcXWtaB3zqVBkSwhCenJYfgAYBf9FlAocE"),
+		UserName:       "admin",
+		DeviceUUID:     "6d4a0a66-7efa-578e-83cf-44dc68d2874e",
+		PluginID:       "GRF",
+	}
+	device2 := agmodel.Target{
+		ManagerAddress: "100.0.0.2",
+		Password:       []byte("imKp3Q6Cx989b6JSPHnRhritEcXWtaB3zqVBkSwhCenJYfgAYBf9FlAocE"),
+		UserName:       "admin",
+		DeviceUUID:     "c14d91b5-3333-48bb-a7b7-75f74a137d48",
+		PluginID:       "GRF",
+	}
+	mockPluginData(t, "GRF")
+	mockDeviceData("6d4a0a66-7efa-578e-83cf-44dc68d2874e", device1)
+	mockDeviceData("c14d91b5-3333-48bb-a7b7-75f74a137d48", device2)
+	aggregate := agmodel.Aggregate{
+		Elements: []agmodel.OdataID{
+			{OdataID: "/redfish/v1/Systems/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1"},
+			{OdataID: "/redfish/v1/Systems/c14d91b5-3333-48bb-a7b7-75f74a137d48.1"},
+		},
+	}
+	if err := agmodel.CreateAggregate(aggregate, mockSettingsAggregateURI); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	p := getMockExternalInterface()
+	ctx := mockContext()
+	actionURI := mockSettingsAggregateURI + "/Actions/Aggregate.ApplySettings"
+	tests := []struct {
+		name           string
+		taskID         string
+		url            string
+		requestBody    string
+		wantStatusCode int32
+	}{
+		{"attributes and boot order", "someID", actionURI, `{"Attributes": {"ProcTurboMode": "Enabled", "NumaGroupSize": 2}, "Boot": {"BootOrder": ["Pxe", "Hdd"]}}`, http.StatusOK},
+		{"attributes only", "someID", actionURI, `{"Attributes": {"ProcTurboMode": "Disabled"}}`, http.StatusOK},
+		{"subtask creation failure", "taskWithoutChild", actionURI, `{"Boot": {"BootOrder": ["Hdd"]}}`, http.StatusInternalServerError},
+		{"no settings", "someID", actionURI, `{}`, http.StatusBadRequest},
+		{"attribute value is an object", "someID", actionURI, `{"Attributes": {"ProcTurboMode": {"Value": "Enabled"}}}`, http.StatusBadRequest},
+		{"empty boot order", "someID", actionURI, `{"Boot": {"BootOrder": []}}`, http.StatusBadRequest},
+		{"empty boot reference", "someID", actionURI, `{"Boot": {"BootOrder": ["Pxe", ""]}}`, http.StatusBadRequest},
+		{"invalid property", "someID", actionURI, `{"attributes": {"ProcTurboMode": "Enabled"}}`, http.StatusBadRequest},
+		{"malformed request", "someID", actionURI, `{"Attributes":`, http.StatusBadRequest},
+		{"invalid aggregate id", "someID", "/redfish/v1/AggregationService/Aggregates/12345/Actions/Aggregate.ApplySettings", `{"Boot": {"BootOrder": ["Hdd"]}}`, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &aggregatorproto.AggregatorRequest{SessionToken: "validToken", URL: tt.url, RequestBody: []byte(tt.requestBody)}
+			if got := p.ApplySettingsElementsOfAggregate(ctx, tt.taskID, "someUser", req); got.StatusCode != tt.wantStatusCode {
+				t.Errorf("ExternalInterface.ApplySettingsElementsOfAggregate() = %v, want %v", got.StatusCode, tt.wantStatusCode)
+			}
+		})
+	}
+
+	// the baseline is replaced by the last valid request and kept when the elements change
+	want := agmodel.AggregateSettings{Boot: &agmodel.AggregateBootSettings{BootOrder: []string{"Hdd"}}}
+	if err := agmodel.RemoveElementsFromAggregate(agmodel.Aggregate{Elements: aggregate.Elements[1:]}, mockSettingsAggregateURI); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	got, err := agmodel.GetAggregate(mockSettingsAggregateURI)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if got.Settings == nil || !reflect.DeepEqual(*got.Settings, want) || len(got.Elements) != 1 {
+		t.Errorf("aggregate = %+v, want the baseline %+v", got, want)
+	}
+}
+
+func TestExternalInterface_GetAggregateSettingsDrift(t *testing.T) {
+	common.MuxLock.Lock()
+	config.SetUpMockConfig(t)
+	common.MuxLock.Unlock()
+	defer func() {
+		common.TruncateDB(common.OnDisk)
+		common.TruncateDB(common.InMemory)
+	}()
+	compliantSystem := "/redfish/v1/Systems/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1"
+	driftedSystem := "/redfish/v1/Systems/c14d91b5-3333-48bb-a7b7-75f74a137d48.1"
+	unknownSystem := "/redfish/v1/Systems/a9e1e8a1-bf5e-4e83-a7b6-3b5e3e0a1f2c.1"
+	aggregate := agmodel.Aggregate{
+		Elements: []agmodel.OdataID{{OdataID: compliantSystem}, {OdataID: driftedSystem}, {OdataID: unknownSystem}},
+	}
+	if err := agmodel.CreateAggregate(aggregate, mockSettingsAggregateURI); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	p := getMockExternalInterface()
+	p.GetResource = func(table, key string) (string, *errors.Error) {
+		inventory := map[string]string{
+			"Bios:" + compliantSystem + "/Bios": `{"Attributes": {"ProcTurboMode": "Enabled", "NumaGroupSize": 2, "BootMode": "Uefi"}}`,
+			"ComputerSystem:" + compliantSystem: `{"Boot": {"BootOrder": ["Pxe", "Hdd"]}}`,
+			"Bios:" + driftedSystem + "/Bios":   `{"Attributes": {"ProcTurboMode": "Disabled", "NumaGroupSize": 2}}`,
+			"ComputerSystem:" + driftedSystem:   `{"Boot": {"BootOrder": ["Hdd", "Pxe"]}}`,
+		}
+		data, ok := inventory[table+":"+key]
+		if !ok {
+			return "", errors.PackError(errors.DBKeyNotFound, "error: data with key ", key, " does not exist")
+		}
+		return data, nil
+	}
+	ctx := mockContext()
+	driftURI := mockSettingsAggregateURI + "/Oem/Odim/SettingsDrift"
+
+	resp := p.GetAggregateSettingsDrift(ctx, &aggregatorproto.AggregatorRequest{URL: driftURI})
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("GetAggregateSettingsDrift() without baseline = %v, want %v", resp.StatusCode, http.StatusNotFound)
+	}
+
+	baseline := agmodel.AggregateSettings{
+		Attributes: map[string]interface{}{"ProcTurboMode": "Enabled", "NumaGroupSize": float64(2)},
+		Boot:       &agmodel.AggregateBootSettings{BootOrder: []string{"Pxe", "Hdd"}},
+	}
+	if err := agmodel.UpdateAggregateSettings(baseline, mockSettingsAggregateURI); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	resp = p.GetAggregateSettingsDrift(ctx, &aggregatorproto.AggregatorRequest{URL: driftURI})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GetAggregateSettingsDrift() = %v, want %v", resp.StatusCode, http.StatusOK)
+	}
+	body := resp.Body.(agresponse.AggregateSettingsDriftResponse)
+	want := []agresponse.SettingsDriftMember{
+		{System: agresponse.OdataID{OdataID: compliantSystem}, State: driftStateCompliant, Drift: []agresponse.SettingDrift{}},
+		{System: agresponse.OdataID{OdataID: driftedSystem}, State: driftStateDrifted, Drift: []agresponse.SettingDrift{
+			{Property: "Attributes/ProcTurboMode", Desired: "Enabled", Current: "Disabled"},
+			{Property: "Boot/BootOrder", Desired: []string{"Pxe", "Hdd"}, Current: []string{"Hdd", "Pxe"}},
+		}},
+		{System: agresponse.OdataID{OdataID: unknownSystem}, State: driftStateUnknown, Drift: []agresponse.SettingDrift{},
+			Message: "the BIOS attributes of the system are not found in the inventory"},
+	}
+	if body.MembersInDrift != 1 || !reflect.DeepEqual(body.Members, want) {
+		t.Errorf("GetAggregateSettingsDrift() = %+v, want %+v", body.Members, want)
+	}
+	if body.OdataID != driftURI || !reflect.DeepEqual(body.Baseline, baseline) {
+		t.Errorf("GetAggregateSettingsDrift() = %v %+v, want the drift of the baseline", body.OdataID, body.Baseline)
+	}
+
+	resp = p.GetAggregate(ctx, &aggregatorproto.AggregatorRequest{URL: mockSettingsAggregateURI})
+	aggregateBody := resp.Body.(agresponse.AggregateGetResponse)
+	if aggregateBody.Oem == nil || aggregateBody.Oem.Odim.SettingsDrift.OdataID != driftURI {
+		t.Errorf("GetAggregate() Oem = %+v, want the link to the settings drift", aggregateBody.Oem)
+	}
+
+	resp = p.GetAggregateSettingsDrift(ctx, &aggregatorproto.AggregatorRequest{URL: "/redfish/v1/AggregationService/Aggregates/12345/Oem/Odim/SettingsDrift"})
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("GetAggregateSettingsDrift() of an unknown aggregate = %v, want %v", resp.StatusCode, http.StatusNotFound)
+	}
+}
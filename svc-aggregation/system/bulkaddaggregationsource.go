@@ -0,0 +1,249 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agresponse"
+)
+
+const (
+	// BulkAddAggregationSourcesURI is the target of the OEM action adding the aggregation sources in bulk
+	BulkAddAggregationSourcesURI = "/redfish/v1/AggregationService/Actions/Oem/Odim.BulkAddAggregationSources/"
+	// DefaultBulkAddConcurrency is the number of hosts added in parallel when the request has no MaxConcurrency
+	DefaultBulkAddConcurrency = 10
+)
+
+// BulkAddAggregationSources adds the hosts of the request as aggregation sources, each host in a sub task of the task.
+// At most MaxConcurrency hosts are added in parallel. Once all the hosts are processed,
+// the task completes with the report of the succeeded and the failed hosts.
+func (e *ExternalInterface) BulkAddAggregationSources(ctx context.Context, taskID string, sessionUserName string, req *aggregatorproto.AggregatorRequest) response.RPC {
+	targetURI := BulkAddAggregationSourcesURI
+	var resp response.RPC
+	var percentComplete int32
+	taskRequest := maskBulkAddRequest(req.RequestBody)
+	taskInfo := &common.TaskUpdateInfo{Context: ctx, TaskID: taskID, TargetURI: targetURI, UpdateTask: e.UpdateTask, TaskRequest: taskRequest}
+	err := e.UpdateTask(ctx, fillTaskData(taskID, targetURI, taskRequest, resp, common.Running, common.OK, percentComplete, http.MethodPost))
+	if err != nil {
+		errMsg := "error while starting the task: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+	ctx, done := common.TrackTask(ctx, taskID)
+	defer done()
+
+	var bulkRequest BulkAggregationSources
+	if err = json.Unmarshal(req.RequestBody, &bulkRequest); err != nil {
+		errMsg := "unable to parse the bulk add request: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+	}
+	hosts := make([]bulkAddHost, 0, len(bulkRequest.Hosts))
+	for _, host := range bulkRequest.Hosts {
+		hosts = append(hosts, bulkAddHost{BulkAggregationSourceHost: host, links: bulkRequest.Links})
+	}
+	return e.addAggregationSourcesInBulk(ctx, taskID, targetURI, taskRequest, sessionUserName, hosts, bulkRequest.MaxConcurrency)
+}
+
+// addAggregationSourcesInBulk adds the hosts as aggregation sources, each host in a sub task of the task,
+// and completes the task with the report of the succeeded and the failed hosts
+func (e *ExternalInterface) addAggregationSourcesInBulk(ctx context.Context, taskID, targetURI, taskRequest, sessionUserName string, hosts []bulkAddHost, concurrency int) response.RPC {
+	var resp response.RPC
+	var percentComplete int32
+	if concurrency <= 0 {
+		concurrency = DefaultBulkAddConcurrency
+	}
+
+	// results is a buffered channel with buffer size equal to total number of hosts,
+	// so the sub tasks can finish even if the task stops collecting the results.
+	results := make(chan bulkAddResult, len(hosts))
+	go func() {
+		// semaphore limits the number of hosts added in parallel
+		semaphore := make(chan struct{}, concurrency)
+		var wg sync.WaitGroup
+		for index, host := range hosts {
+			select {
+			case semaphore <- struct{}{}:
+			case <-ctx.Done():
+			}
+			if ctx.Err() != nil {
+				// the task is cancelled, the remaining hosts are not added
+				break
+			}
+			wg.Add(1)
+			go func(index int, host bulkAddHost) {
+				defer wg.Done()
+				defer func() { <-semaphore }()
+				results <- bulkAddResult{
+					index:  index,
+					result: e.addBulkAggregationSource(ctx, taskID, sessionUserName, host),
+				}
+			}(index, host)
+		}
+		wg.Wait()
+		close(results)
+	}()
+
+	hostResults := make([]*agresponse.BulkAddHostResult, len(hosts))
+	var processed int
+	for result := range results {
+		hostResult := result.result
+		hostResults[result.index] = &hostResult
+		processed++
+		if processed < len(hosts) {
+			percentComplete = int32(processed * 100 / len(hosts))
+			e.UpdateTask(ctx, fillTaskData(taskID, targetURI, taskRequest, resp, common.Running, common.OK, percentComplete, http.MethodPost))
+		}
+	}
+	if ctx.Err() != nil {
+		l.LogWithFields(ctx).Warn("bulk add task " + taskID + " is cancelled")
+		return e.cancelTask(ctx, taskID, targetURI, taskRequest, percentComplete)
+	}
+
+	report := getBulkAddReport(hostResults)
+	taskStatus := common.OK
+	if len(report.Failed) > 0 {
+		taskStatus = common.Warning
+		l.LogWithFields(ctx).Warnf("failed to add %d of %d hosts, for more information please check SubTasks in URI: /redfish/v1/TaskService/Tasks/%s",
+			len(report.Failed), len(hosts), taskID)
+		if len(report.Succeeded) == 0 {
+			taskStatus = common.Critical
+		}
+	}
+	resp = response.RPC{
+		StatusCode:    http.StatusOK,
+		StatusMessage: response.Success,
+		Body:          report,
+	}
+	percentComplete = 100
+	e.UpdateTask(ctx, fillTaskData(taskID, targetURI, taskRequest, resp, common.Completed, taskStatus, percentComplete, http.MethodPost))
+	return resp
+}
+
+// bulkAddHost is a host added by addAggregationSourcesInBulk along with the connection method of the host,
+// discoveredSourceURI is set when the host is approved from the discovered aggregation sources
+type bulkAddHost struct {
+	BulkAggregationSourceHost
+	links               *Links
+	discoveredSourceURI string
+}
+
+// bulkAddResult is the result of adding the host at index of the bulk add request
+type bulkAddResult struct {
+	index  int
+	result agresponse.BulkAddHostResult
+}
+
+// addBulkAggregationSource adds a host of the bulk add request in a sub task of the task
+func (e *ExternalInterface) addBulkAggregationSource(ctx context.Context, taskID, sessionUserName string, host bulkAddHost) agresponse.BulkAddHostResult {
+	result := agresponse.BulkAddHostResult{HostName: host.HostName}
+	subTaskURI, err := e.CreateChildTask(ctx, sessionUserName, taskID)
+	if err != nil {
+		errMsg := "error while trying to create sub task for adding " + host.HostName + ": " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		result.StatusCode = http.StatusInternalServerError
+		result.MessageID = response.InternalError
+		result.Message = errMsg
+		return result
+	}
+	var subTaskID string
+	strArray := strings.Split(subTaskURI, "/")
+	if strings.HasSuffix(subTaskURI, "/") {
+		subTaskID = strArray[len(strArray)-2]
+	} else {
+		subTaskID = strArray[len(strArray)-1]
+	}
+	result.SubTask = &agresponse.OdataID{OdataID: subTaskURI}
+	ctx, done := common.TrackTask(ctx, subTaskID)
+	defer done()
+
+	targetURI := "/redfish/v1/AggregationService/AggregationSources"
+	aggregationSourceRequest := AggregationSource{
+		HostName: host.HostName,
+		UserName: host.UserName,
+		Password: host.Password,
+		Links:    host.links,
+	}
+	reqBody, _ := json.Marshal(aggregationSourceRequest)
+	taskRequest := maskBulkAddRequest(reqBody)
+	taskInfo := &common.TaskUpdateInfo{Context: ctx, TaskID: subTaskID, TargetURI: targetURI, UpdateTask: e.UpdateTask, TaskRequest: taskRequest}
+	var percentComplete int32
+	e.UpdateTask(ctx, fillTaskData(subTaskID, targetURI, taskRequest, response.RPC{}, common.Running, common.OK, percentComplete, http.MethodPost))
+	resp := e.addAggregationSource(ctx, subTaskID, targetURI, taskRequest, percentComplete, aggregationSourceRequest, taskInfo)
+	result.StatusCode = resp.StatusCode
+	if resp.StatusCode == http.StatusCreated {
+		result.AggregationSource = &agresponse.OdataID{OdataID: resp.Header["Location"]}
+		common.AddCompletedOperation(taskID, "Add of "+host.HostName)
+		if host.discoveredSourceURI != "" {
+			if err := e.Delete(discoveredAggregationSourceTable, host.discoveredSourceURI, common.OnDisk); err != nil {
+				l.LogWithFields(ctx).Error("unable to delete the discovered aggregation source " + host.discoveredSourceURI + ": " + err.Error())
+			}
+		}
+		return result
+	}
+	if body, ok := resp.Body.(response.CommonError); ok && len(body.Error.MessageExtendedInfo) > 0 {
+		result.MessageID = body.Error.MessageExtendedInfo[0].MessageID
+		result.Message = body.Error.MessageExtendedInfo[0].Message
+	}
+	return result
+}
+
+// getBulkAddReport splits the results of the hosts into the succeeded and the failed hosts,
+// the hosts which are not processed are skipped
+func getBulkAddReport(hostResults []*agresponse.BulkAddHostResult) agresponse.BulkAddAggregationSourcesResponse {
+	report := agresponse.BulkAddAggregationSourcesResponse{
+		Succeeded: []agresponse.BulkAddHostResult{},
+		Failed:    []agresponse.BulkAddHostResult{},
+	}
+	for _, hostResult := range hostResults {
+		if hostResult == nil {
+			continue
+		}
+		if hostResult.StatusCode == http.StatusCreated {
+			report.Succeeded = append(report.Succeeded, *hostResult)
+		} else {
+			report.Failed = append(report.Failed, *hostResult)
+		}
+	}
+	report.Message = fmt.Sprintf("%d of %d hosts are added as aggregation sources", len(report.Succeeded), len(report.Succeeded)+len(report.Failed))
+	return report
+}
+
+// maskBulkAddRequest returns the bulk add request, or the add request of one of its hosts,
+// without the passwords, to be stored in the tasks
+func maskBulkAddRequest(requestBody []byte) string {
+	var request map[string]interface{}
+	if err := json.Unmarshal(requestBody, &request); err != nil {
+		return ""
+	}
+	if hosts, ok := request["Hosts"].([]interface{}); ok {
+		for _, host := range hosts {
+			if hostMap, ok := host.(map[string]interface{}); ok && hostMap["Password"] != nil {
+				hostMap["Password"] = "null"
+			}
+		}
+	}
+	return l.MaskRequestBody(request)
+}
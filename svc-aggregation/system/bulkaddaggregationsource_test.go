@@ -0,0 +1,145 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agresponse"
+)
+
+func TestMaskBulkAddRequest(t *testing.T) {
+	reqBody, _ := json.Marshal(BulkAggregationSources{
+		Hosts: []BulkAggregationSourceHost{
+			{HostName: "10.0.0.1", UserName: "admin", Password: "secret1"},
+			{HostName: "10.0.0.2", UserName: "admin", Password: "secret2"},
+		},
+	})
+	var masked BulkAggregationSources
+	if err := json.Unmarshal([]byte(maskBulkAddRequest(reqBody)), &masked); err != nil {
+		t.Fatalf("maskBulkAddRequest() returned invalid JSON: %v", err)
+	}
+	for _, host := range masked.Hosts {
+		if host.Password != "null" {
+			t.Errorf("maskBulkAddRequest() Password of %s = %v, want null", host.HostName, host.Password)
+		}
+	}
+	if len(masked.Hosts) != 2 || masked.Hosts[0].HostName != "10.0.0.1" || masked.Hosts[1].UserName != "admin" {
+		t.Errorf("maskBulkAddRequest() Hosts = %v, want the hosts of the request", masked.Hosts)
+	}
+}
+
+func TestGetBulkAddReport(t *testing.T) {
+	succeeded := agresponse.BulkAddHostResult{
+		HostName:          "10.0.0.1",
+		StatusCode:        http.StatusCreated,
+		AggregationSource: &agresponse.OdataID{OdataID: "/redfish/v1/AggregationService/AggregationSources/1"},
+	}
+	failed := agresponse.BulkAddHostResult{HostName: "10.0.0.2", StatusCode: http.StatusConflict}
+	report := getBulkAddReport([]*agresponse.BulkAddHostResult{&succeeded, nil, &failed})
+	want := agresponse.BulkAddAggregationSourcesResponse{
+		Message:   "1 of 2 hosts are added as aggregation sources",
+		Succeeded: []agresponse.BulkAddHostResult{succeeded},
+		Failed:    []agresponse.BulkAddHostResult{failed},
+	}
+	if !reflect.DeepEqual(report, want) {
+		t.Errorf("getBulkAddReport() = %v, want %v", report, want)
+	}
+}
+
+func TestExternalInterface_BulkAddAggregationSources(t *testing.T) {
+	config.SetUpMockConfig(t)
+	reqBody, _ := json.Marshal(BulkAggregationSources{
+		Hosts: []BulkAggregationSourceHost{
+			{HostName: "10.0.0.1", UserName: "admin", Password: "password"},
+			{HostName: "10.0.0.2", UserName: "admin", Password: "password"},
+			{HostName: "10.0.0.3", UserName: "admin", Password: "password"},
+		},
+		Links: &Links{
+			ConnectionMethod: &ConnectionMethod{OdataID: "/redfish/v1/AggregationService/ConnectionMethods/7ff3bd97-c41c-5de0-937d-85d390691b73"},
+		},
+		MaxConcurrency: 2,
+	})
+	e := getMockExternalInterface()
+
+	// the sub tasks of the hosts can not be created, every host is reported as failed
+	resp := e.BulkAddAggregationSources(mockContext(), "taskWithoutChild", "admin", &aggregatorproto.AggregatorRequest{RequestBody: reqBody})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("BulkAddAggregationSources() StatusCode = %v, want %v", resp.StatusCode, http.StatusOK)
+	}
+	report, ok := resp.Body.(agresponse.BulkAddAggregationSourcesResponse)
+	if !ok {
+		t.Fatalf("BulkAddAggregationSources() Body = %T, want the bulk add report", resp.Body)
+	}
+	if len(report.Succeeded) != 0 || len(report.Failed) != 3 {
+		t.Fatalf("BulkAddAggregationSources() report = %v, want 3 failed hosts", report)
+	}
+	for i, host := range []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"} {
+		if report.Failed[i].HostName != host || report.Failed[i].StatusCode != http.StatusInternalServerError {
+			t.Errorf("BulkAddAggregationSources() Failed[%d] = %v, want %s failed with %v", i, report.Failed[i], host, http.StatusInternalServerError)
+		}
+	}
+
+	resp = e.BulkAddAggregationSources(mockContext(), "someTaskID", "admin", &aggregatorproto.AggregatorRequest{RequestBody: []byte("invalid")})
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("BulkAddAggregationSources() StatusCode = %v, want %v for an invalid request", resp.StatusCode, http.StatusInternalServerError)
+	}
+}
+
+func TestExternalInterface_BulkAddAggregationSources_maskedSubTasks(t *testing.T) {
+	config.SetUpMockConfig(t)
+	reqBody, _ := json.Marshal(BulkAggregationSources{
+		Hosts: []BulkAggregationSourceHost{
+			{HostName: "10.0.0.1", UserName: "admin", Password: "bulkSecret1"},
+			{HostName: "10.0.0.2", UserName: "admin", Password: "bulkSecret2"},
+		},
+		Links: &Links{
+			ConnectionMethod: &ConnectionMethod{OdataID: "/redfish/v1/AggregationService/ConnectionMethods/unknown"},
+		},
+		MaxConcurrency: 2,
+	})
+	e := getMockExternalInterface()
+	var lock sync.Mutex
+	var tasks []common.TaskData
+	e.UpdateTask = func(ctx context.Context, task common.TaskData) error {
+		lock.Lock()
+		defer lock.Unlock()
+		tasks = append(tasks, task)
+		return nil
+	}
+
+	e.BulkAddAggregationSources(mockContext(), "someTaskID", "admin", &aggregatorproto.AggregatorRequest{RequestBody: reqBody})
+	var subTasks int
+	for _, task := range tasks {
+		if task.TaskID == "someSubTaskID" {
+			subTasks++
+		}
+		if strings.Contains(task.TaskRequest, "bulkSecret") {
+			t.Errorf("BulkAddAggregationSources() stored the password in the task %s: %s", task.TaskID, task.TaskRequest)
+		}
+	}
+	if subTasks == 0 {
+		t.Errorf("BulkAddAggregationSources() did not update the sub tasks of the hosts")
+	}
+}
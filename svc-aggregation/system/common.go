@@ -75,36 +75,66 @@ type Device struct {
 
 // ExternalInterface struct holds the function pointers all outboud services
 type ExternalInterface struct {
-	ContactClient            func(context.Context, string, string, string, string, interface{}, map[string]string) (*http.Response, error)
-	Auth                     func(string, []string, []string) (response.RPC, error)
-	GetSessionUserName       func(string) (string, error)
-	CreateChildTask          func(context.Context, string, string) (string, error)
-	CreateTask               func(context.Context, string) (string, error)
-	UpdateTask               func(context.Context, common.TaskData) error
-	CreateSubcription        func(context.Context, []string)
-	PublishEvent             func(context.Context, []string, string)
-	PublishEventMB           func(context.Context, string, string, string)
-	GetPluginStatus          func(context.Context, agmodel.Plugin) bool
-	SubscribeToEMB           func(string, []string) error
-	EncryptPassword          func([]byte) ([]byte, error)
-	DecryptPassword          func([]byte) ([]byte, error)
-	DeleteComputeSystem      func(int, string) *errors.Error
-	DeleteSystem             func(string) *errors.Error
-	DeleteEventSubscription  func(string) (*eventsproto.EventSubResponse, error)
-	EventNotification        func(context.Context, string, string, string)
-	GetAllKeysFromTable      func(string) ([]string, error)
-	GetConnectionMethod      func(string) (agmodel.ConnectionMethod, *errors.Error)
-	UpdateConnectionMethod   func(agmodel.ConnectionMethod, string) *errors.Error
-	GetPluginMgrAddr         func(string) (agmodel.Plugin, *errors.Error)
-	GetAggregationSourceInfo func(string) (agmodel.AggregationSource, *errors.Error)
-	GenericSave              func([]byte, string, string) error
-	CheckActiveRequest       func(string) (bool, *errors.Error)
-	DeleteActiveRequest      func(string) *errors.Error
-	GetAllMatchingDetails    func(string, string, common.DbType) ([]string, *errors.Error)
-	CheckMetricRequest       func(string) (bool, *errors.Error)
-	DeleteMetricRequest      func(string) *errors.Error
-	GetResource              func(string, string) (string, *errors.Error)
-	Delete                   func(string, string, common.DbType) *errors.Error
+	ContactClient                      func(context.Context, string, string, string, string, interface{}, map[string]string) (*http.Response, error)
+	Auth                               func(string, []string, []string) (response.RPC, error)
+	GetSessionUserName                 func(string) (string, error)
+	CreateChildTask                    func(context.Context, string, string) (string, error)
+	CreateTask                         func(context.Context, string) (string, error)
+	UpdateTask                         func(context.Context, common.TaskData) error
+	CreateSubcription                  func(context.Context, []string)
+	PublishEvent                       func(context.Context, []string, string)
+	PublishEventMB                     func(context.Context, string, string, string)
+	GetPluginStatus                    func(context.Context, agmodel.Plugin) bool
+	SubscribeToEMB                     func(string, []string) error
+	EncryptPassword                    func([]byte) ([]byte, error)
+	DecryptPassword                    func([]byte) ([]byte, error)
+	DeleteComputeSystem                func(int, string) *errors.Error
+	DeleteSystem                       func(string) *errors.Error
+	DeleteEventSubscription            func(string) (*eventsproto.EventSubResponse, error)
+	EventNotification                  func(context.Context, string, string, string)
+	GetAllKeysFromTable                func(string) ([]string, error)
+	GetConnectionMethod                func(string) (agmodel.ConnectionMethod, *errors.Error)
+	UpdateConnectionMethod             func(agmodel.ConnectionMethod, string) *errors.Error
+	GetPluginMgrAddr                   func(string) (agmodel.Plugin, *errors.Error)
+	GetAggregationSourceInfo           func(string) (agmodel.AggregationSource, *errors.Error)
+	GenericSave                        func([]byte, string, string) error
+	CheckActiveRequest                 func(string) (bool, *errors.Error)
+	DeleteActiveRequest                func(string) *errors.Error
+	GetAllMatchingDetails              func(string, string, common.DbType) ([]string, *errors.Error)
+	CheckMetricRequest                 func(string) (bool, *errors.Error)
+	DeleteMetricRequest                func(string) *errors.Error
+	GetResource                        func(string, string) (string, *errors.Error)
+	Delete                             func(string, string, common.DbType) *errors.Error
+	GetString                          func(string, string) ([]string, error)
+	DoDiscoveryRequest                 func(*http.Request) (*http.Response, error)
+	SaveDiscoveredAggregationSource    func(agmodel.DiscoveredAggregationSource, string) *errors.Error
+	GetDiscoveredAggregationSourceInfo func(string) (agmodel.DiscoveredAggregationSource, *errors.Error)
+	GetTarget                          func(string) (*agmodel.Target, error)
+	UpdateSystemData                   func(agmodel.SaveSystem, string) *errors.Error
+	UpdateAggregationSourceInfo        func(agmodel.AggregationSource, string) *errors.Error
+	SaveCredentialRotation             func(agmodel.CredentialRotation, string) *errors.Error
+	GetCredentialRotation              func(string) (agmodel.CredentialRotation, *errors.Error)
+	ReencryptPassword                  func([]byte) ([]byte, bool, error)
+	GetStoredPassword                  func(string, string) ([]byte, *errors.Error)
+	UpdateStoredPassword               func(string, string, []byte, []byte) *errors.Error
+	GetDeviceInventory                 func(string) (map[string]string, *errors.Error)
+	GetInventoryHistoryInfo            func(string) (agmodel.InventoryHistory, *errors.Error)
+	SaveInventoryHistory               func(agmodel.InventoryHistory, string) *errors.Error
+	PublishEvents                      func(context.Context, string, []common.Event)
+	GetResourceETags                   func(string) (map[string]string, *errors.Error)
+	SaveResourceETags                  func(map[string]string, string) *errors.Error
+	GetInventorySyncInfo               func() (agmodel.InventorySync, *errors.Error)
+	SaveInventorySync                  func(agmodel.InventorySync) *errors.Error
+	GetInventorySyncStatus             func(string) (agmodel.InventorySyncStatus, *errors.Error)
+	SaveInventorySyncStatus            func(agmodel.InventorySyncStatus, string) *errors.Error
+	GetInventorySyncSchedule           func() (agmodel.InventorySyncSchedule, *errors.Error)
+	SaveInventorySyncSchedule          func(agmodel.InventorySyncSchedule) *errors.Error
+	GetAggregateInfo                   func(string) (agmodel.Aggregate, *errors.Error)
+	GetConformanceReportInfo           func(string) (agmodel.ConformanceReport, *errors.Error)
+	SaveConformanceReport              func(agmodel.ConformanceReport, string) *errors.Error
+	DeleteSecret                       func([]byte) error
+	AcquireLease                       func(string, time.Duration) (bool, *errors.Error)
+	ReleaseLease                       func(string) *errors.Error
 }
 
 type responseStatus struct {
@@ -194,6 +224,47 @@ type AggregationSource struct {
 	Links    *Links `json:"Links,omitempty"`
 }
 
+// BulkAggregationSources holds the hosts of the bulk add request,
+// all the hosts are added with the connection method in Links
+type BulkAggregationSources struct {
+	Hosts          []BulkAggregationSourceHost `json:"Hosts"`
+	Links          *Links                      `json:"Links,omitempty"`
+	MaxConcurrency int                         `json:"MaxConcurrency,omitempty"`
+}
+
+// BulkAggregationSourceHost holds the address and the credentials of a host of the bulk add request
+type BulkAggregationSourceHost struct {
+	HostName string `json:"HostName"`
+	UserName string `json:"UserName"`
+	Password string `json:"Password"`
+}
+
+// DiscoverAggregationSources holds the address range scanned for the Redfish services,
+// the managers of the services are read only if the credentials are given
+type DiscoverAggregationSources struct {
+	AddressRange   string `json:"AddressRange"`
+	Port           int    `json:"Port,omitempty"`
+	UserName       string `json:"UserName,omitempty"`
+	Password       string `json:"Password,omitempty"`
+	MaxConcurrency int    `json:"MaxConcurrency,omitempty"`
+}
+
+// ApproveDiscoveredAggregationSources holds the discovered aggregation sources to be added with the given credentials,
+// the connection method in Links is used for the sources which are not matched to a connection method
+type ApproveDiscoveredAggregationSources struct {
+	DiscoveredAggregationSources []agmodel.OdataID `json:"DiscoveredAggregationSources"`
+	UserName                     string            `json:"UserName"`
+	Password                     string            `json:"Password"`
+	Links                        *Links            `json:"Links,omitempty"`
+	MaxConcurrency               int               `json:"MaxConcurrency,omitempty"`
+}
+
+// RotateAggregationSourceCredentials holds the BMC aggregation sources whose passwords are rotated
+type RotateAggregationSourceCredentials struct {
+	AggregationSources []agmodel.OdataID `json:"AggregationSources"`
+	MaxConcurrency     int               `json:"MaxConcurrency,omitempty"`
+}
+
 // Links holds information of Oem
 type Links struct {
 	ConnectionMethod *ConnectionMethod `json:"ConnectionMethod,omitempty"`
@@ -238,6 +309,16 @@ func getKeyFromManagerAddress(managerAddress string) string {
 	return ipAddr
 }
 
+// cancelTask records the task as Cancelled along with the operations of the task completed before the cancellation
+func (e *ExternalInterface) cancelTask(ctx context.Context, taskID, targetURI, request string, percentComplete int32) response.RPC {
+	resp := common.CancelledTaskResponse(taskID, common.CompletedOperations(taskID))
+	task := fillTaskData(taskID, targetURI, request, resp, common.Cancelled, common.Warning, percentComplete, http.MethodPost)
+	if err := e.UpdateTask(ctx, task); err != nil {
+		l.LogWithFields(ctx).Warn("unable to update the cancelled task " + taskID + ": " + err.Error())
+	}
+	return resp
+}
+
 func fillTaskData(taskID, targetURI, request string, resp response.RPC, taskState string, taskStatus string, percentComplete int32, httpMethod string) common.TaskData {
 	return common.TaskData{
 		TaskID:          taskID,
@@ -971,23 +1052,7 @@ func (h *respHolder) getResourceDetails(ctx context.Context, taskID string, prog
 	}
 	resourceName := getResourceName(req.OID, memberFlag)
 	if memberFlag && strings.Contains(resourceName, "VolumesCollection") {
-		CollectionCapabilities := dmtf.CollectionCapabilities{
-			OdataType: "#CollectionCapabilities.v1_4_0.CollectionCapabilities",
-			Capabilities: []*dmtf.Capabilities{
-				&dmtf.Capabilities{
-					CapabilitiesObject: &dmtf.Link{
-						Oid: req.OID + "/Capabilities",
-					},
-					Links: dmtf.CapLinks{
-						TargetCollection: &dmtf.Link{
-							Oid: req.OID,
-						},
-					},
-					UseCase: "VolumeCreation",
-				},
-			},
-		}
-		resourceData["@Redfish.CollectionCapabilities"] = CollectionCapabilities
+		addVolumeCollectionCapabilities(req.OID, resourceData)
 		body, _ = json.Marshal(resourceData)
 
 	}
@@ -1014,6 +1079,28 @@ func (h *respHolder) getResourceDetails(ctx context.Context, taskID string, prog
 	progress = progress + alottedWork
 	return progress
 }
+
+// addVolumeCollectionCapabilities adds the capability of creating volumes to the volume collection
+func addVolumeCollectionCapabilities(oid string, resourceData map[string]interface{}) {
+	CollectionCapabilities := dmtf.CollectionCapabilities{
+		OdataType: "#CollectionCapabilities.v1_4_0.CollectionCapabilities",
+		Capabilities: []*dmtf.Capabilities{
+			&dmtf.Capabilities{
+				CapabilitiesObject: &dmtf.Link{
+					Oid: oid + "/Capabilities",
+				},
+				Links: dmtf.CapLinks{
+					TargetCollection: &dmtf.Link{
+						Oid: oid,
+					},
+				},
+				UseCase: "VolumeCreation",
+			},
+		},
+	}
+	resourceData["@Redfish.CollectionCapabilities"] = CollectionCapabilities
+}
+
 func getResourceName(oDataID string, memberFlag bool) string {
 	str := strings.Split(oDataID, "/")
 	if memberFlag {
@@ -1685,7 +1772,13 @@ func (e *ExternalInterface) monitorPluginTask(ctx context.Context, subTaskChanne
 			e.UpdateTask(ctx, updatetask)
 			return monitorTaskData.getResponse, err
 		}
-		time.Sleep(time.Second * 5)
+		select {
+		case <-ctx.Done():
+			subTaskChannel <- http.StatusInternalServerError
+			e.cancelTask(ctx, monitorTaskData.subTaskID, monitorTaskData.serverURI, monitorTaskData.updateRequestBody, task.PercentComplete)
+			return monitorTaskData.getResponse, ctx.Err()
+		case <-time.After(time.Second * 5):
+		}
 		monitorTaskData.pluginRequest.OID = monitorTaskData.location
 		monitorTaskData.pluginRequest.HTTPMethodType = http.MethodGet
 		monitorTaskData.respBody, _, monitorTaskData.getResponse, err = contactPlugin(ctx, monitorTaskData.pluginRequest, "error while performing simple update action: ")
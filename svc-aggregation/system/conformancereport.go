@@ -0,0 +1,284 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agresponse"
+)
+
+const (
+	// ConformanceReportsURI is the URI of the collection of the conformance reports of the systems
+	ConformanceReportsURI = "/redfish/v1/AggregationService/Oem/Odim/ConformanceReports"
+	// ValidateInteropProfileURI is the target of the OEM action validating the systems against a Redfish Interop profile
+	ValidateInteropProfileURI = "/redfish/v1/AggregationService/Actions/Oem/Odim.ValidateInteropProfile/"
+
+	conformanceReportTable = "ConformanceReport"
+	systemsURI             = "/redfish/v1/Systems/"
+)
+
+// ValidateInteropProfileRequest is the request for validating the systems against a Redfish Interop profile.
+// The DefaultProfile of the configuration is used when Profile is not given, and all the systems
+// are validated when Systems is not given
+type ValidateInteropProfileRequest struct {
+	Profile string            `json:"Profile,omitempty"`
+	Systems []agmodel.OdataID `json:"Systems,omitempty"`
+}
+
+// ValidateInteropProfile validates the stored inventory of the systems against the Redfish Interop profile
+// of the request and the profiles it requires, and saves the conformance report of each system
+func (e *ExternalInterface) ValidateInteropProfile(ctx context.Context, req *aggregatorproto.AggregatorRequest) response.RPC {
+	var validateRequest ValidateInteropProfileRequest
+	if len(req.RequestBody) > 0 {
+		if err := json.Unmarshal(req.RequestBody, &validateRequest); err != nil {
+			errorMessage := "Unable to parse the profile validation request: " + err.Error()
+			l.LogWithFields(ctx).Error(errorMessage)
+			return common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errorMessage, nil, nil)
+		}
+		invalidProperties, err := common.RequestParamsCaseValidator(req.RequestBody, validateRequest)
+		if err != nil {
+			errorMessage := "error while validating request parameters: " + err.Error()
+			l.LogWithFields(ctx).Error(errorMessage)
+			return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+		} else if invalidProperties != "" {
+			errorMessage := "error: one or more properties given in the request body are not valid, ensure properties are listed in uppercamelcase "
+			l.LogWithFields(ctx).Error(errorMessage)
+			return common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errorMessage, []interface{}{invalidProperties}, nil)
+		}
+	}
+	config.TLSConfMutex.RLock()
+	profileConf := *config.Data.InteropProfileConf
+	config.TLSConfMutex.RUnlock()
+	profileName := validateRequest.Profile
+	if profileName == "" {
+		profileName = profileConf.DefaultProfile
+	}
+	if profileName == "" {
+		errorMessage := "error: Profile must be given when no DefaultProfile is configured"
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errorMessage, []interface{}{"Profile"}, nil)
+	}
+	profiles, err := loadInteropProfiles(profileConf.ProfilesPath, profileName)
+	if err != nil {
+		errorMessage := "Unable to load the profile " + profileName + ": " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		if _, ok := err.(*profileNotFoundError); ok {
+			return common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, errorMessage, []interface{}{profileName, "Profile"}, nil)
+		}
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+
+	var systemURIs []string
+	if len(validateRequest.Systems) == 0 {
+		var dbErr *errors.Error
+		if systemURIs, dbErr = e.getAllSystemURIs(); dbErr != nil {
+			errorMessage := "Unable to get the systems: " + dbErr.Error()
+			l.LogWithFields(ctx).Error(errorMessage)
+			return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+		}
+	}
+	for _, system := range validateRequest.Systems {
+		systemURI := strings.TrimSuffix(system.OdataID, "/")
+		if _, dbErr := e.GetResource("ComputerSystem", systemURI); dbErr != nil {
+			errorMessage := "Unable to get the system " + systemURI + ": " + dbErr.Error()
+			l.LogWithFields(ctx).Error(errorMessage)
+			if dbErr.ErrNo() == errors.DBKeyNotFound {
+				return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errorMessage, []interface{}{"System", systemURI}, nil)
+			}
+			return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+		}
+		systemURIs = append(systemURIs, systemURI)
+	}
+
+	validateResponse := agresponse.ValidateInteropProfileResponse{
+		Profile:            profileName,
+		ConformanceReports: []agresponse.ConformanceReportResult{},
+	}
+	for _, systemURI := range systemURIs {
+		report, dbErr := e.validateSystemConformance(ctx, systemURI, profiles)
+		if dbErr != nil {
+			errorMessage := "Unable to validate the system " + systemURI + ": " + dbErr.Error()
+			l.LogWithFields(ctx).Error(errorMessage)
+			return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+		}
+		validateResponse.ConformanceReports = append(validateResponse.ConformanceReports, agresponse.ConformanceReportResult{
+			ConformanceReport: agresponse.OdataID{OdataID: ConformanceReportsURI + "/" + getSystemID(systemURI)},
+			System:            agresponse.OdataID{OdataID: systemURI},
+			Result:            report.Result,
+		})
+	}
+	validateResponse.Message = fmt.Sprintf("Validated %d systems against the profile %s", len(systemURIs), profileName)
+	return response.RPC{
+		StatusCode:    http.StatusOK,
+		StatusMessage: response.Success,
+		Body:          validateResponse,
+	}
+}
+
+// validateConformanceOnAdd validates the added systems against the DefaultProfile of the configuration,
+// the systems are not validated when no DefaultProfile is configured
+func (e *ExternalInterface) validateConformanceOnAdd(ctx context.Context, systemURIs []string) {
+	config.TLSConfMutex.RLock()
+	profileConf := *config.Data.InteropProfileConf
+	config.TLSConfMutex.RUnlock()
+	if profileConf.DefaultProfile == "" {
+		return
+	}
+	profiles, err := loadInteropProfiles(profileConf.ProfilesPath, profileConf.DefaultProfile)
+	if err != nil {
+		l.LogWithFields(ctx).Error("unable to load the profile " + profileConf.DefaultProfile + " for validating the added systems: " + err.Error())
+		return
+	}
+	for _, systemURI := range systemURIs {
+		report, dbErr := e.validateSystemConformance(ctx, systemURI, profiles)
+		if dbErr != nil {
+			l.LogWithFields(ctx).Error("unable to validate the system " + systemURI + ": " + dbErr.Error())
+			continue
+		}
+		l.LogWithFields(ctx).Infof("validated the system %s against the profile %s: %s", systemURI, profileConf.DefaultProfile, report.Result)
+	}
+}
+
+// validateSystemConformance validates the stored inventory of the system against the profiles and saves the
+// conformance report. The chassis and the managers of the BMC are validated with the system
+func (e *ExternalInterface) validateSystemConformance(ctx context.Context, systemURI string, profiles []*interopProfile) (
+	agmodel.ConformanceReport, *errors.Error) {
+	systemID := getSystemID(systemURI)
+	deviceUUID := strings.SplitN(systemID, ".", 2)[0]
+	inventory, err := e.GetDeviceInventory(deviceUUID)
+	if err != nil {
+		return agmodel.ConformanceReport{}, err
+	}
+	resources := make(map[string]map[string]interface{}, len(inventory))
+	for uri, resource := range decodeInventory(ctx, inventory, "") {
+		// the other systems of the BMC have their own reports
+		if strings.HasPrefix(uri, systemsURI) && uri != systemURI && !strings.HasPrefix(uri, systemURI+"/") {
+			continue
+		}
+		if object, ok := resource.(map[string]interface{}); ok {
+			resources[uri] = object
+		}
+	}
+	report := newConformanceValidator(resources).validate(profiles)
+	report.ValidationTime = time.Now().UTC().Format(time.RFC3339)
+	if err := e.SaveConformanceReport(report, systemID); err != nil {
+		return report, err
+	}
+	return report, nil
+}
+
+// getAllSystemURIs returns the URIs of all the systems, sorted
+func (e *ExternalInterface) getAllSystemURIs() ([]string, *errors.Error) {
+	keys, err := e.GetAllMatchingDetails("ComputerSystem", "", common.InMemory)
+	if err != nil {
+		return nil, err
+	}
+	var systemURIs []string
+	for _, key := range keys {
+		if strings.HasPrefix(key, systemsURI) && !strings.Contains(strings.TrimPrefix(key, systemsURI), "/") {
+			systemURIs = append(systemURIs, key)
+		}
+	}
+	sort.Strings(systemURIs)
+	return systemURIs, nil
+}
+
+// GetConformanceReports returns the collection of the conformance reports of the systems
+func (e *ExternalInterface) GetConformanceReports(ctx context.Context) response.RPC {
+	systemIDs, err := e.GetAllKeysFromTable(conformanceReportTable)
+	if err != nil {
+		errorMessage := "Unable to get conformance reports: " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	sort.Strings(systemIDs)
+	members := make([]agresponse.ListMember, 0, len(systemIDs))
+	for _, systemID := range systemIDs {
+		members = append(members, agresponse.ListMember{OdataID: ConformanceReportsURI + "/" + systemID})
+	}
+	commonResponse := response.Response{
+		OdataType:    "#OdimConformanceReportCollection.OdimConformanceReportCollection",
+		OdataID:      ConformanceReportsURI,
+		OdataContext: "/redfish/v1/$metadata#OdimConformanceReportCollection.OdimConformanceReportCollection",
+		Name:         "Conformance Reports",
+	}
+	commonResponse.CreateGenericResponse(response.Success)
+	commonResponse.Message = ""
+	commonResponse.ID = ""
+	commonResponse.MessageID = ""
+	commonResponse.Severity = ""
+	return response.RPC{
+		StatusCode:    http.StatusOK,
+		StatusMessage: response.Success,
+		Body: agresponse.List{
+			Response:     commonResponse,
+			MembersCount: len(members),
+			Members:      members,
+		},
+	}
+}
+
+// GetConformanceReport returns the conformance report of the system with the given URI
+func (e *ExternalInterface) GetConformanceReport(ctx context.Context, reqURI string) response.RPC {
+	uri := strings.TrimSuffix(reqURI, "/")
+	systemID := strings.TrimPrefix(uri, ConformanceReportsURI+"/")
+	report, err := e.GetConformanceReportInfo(systemID)
+	if err != nil {
+		errorMessage := err.Error()
+		l.LogWithFields(ctx).Error("Unable to get conformance report : " + errorMessage)
+		if errors.DBKeyNotFound == err.ErrNo() {
+			return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errorMessage, []interface{}{"ConformanceReport", systemID}, nil)
+		}
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	commonResponse := response.Response{
+		OdataType:    "#OdimConformanceReport.v1_0_0.OdimConformanceReport",
+		OdataID:      uri,
+		OdataContext: "/redfish/v1/$metadata#OdimConformanceReport.OdimConformanceReport",
+		ID:           systemID,
+		Name:         "Conformance Report",
+	}
+	commonResponse.CreateGenericResponse(response.Success)
+	commonResponse.Message = ""
+	commonResponse.MessageID = ""
+	commonResponse.Severity = ""
+	return response.RPC{
+		StatusCode:    http.StatusOK,
+		StatusMessage: response.Success,
+		Body: agresponse.ConformanceReportResponse{
+			Response:          commonResponse,
+			System:            agresponse.OdataID{OdataID: systemsURI + systemID},
+			ConformanceReport: report,
+		},
+	}
+}
+
+func getSystemID(systemURI string) string {
+	return systemURI[strings.LastIndex(systemURI, "/")+1:]
+}
@@ -0,0 +1,182 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agresponse"
+)
+
+const mockProfileSystemID = "6d4a0a66-7efa-578e-83cf-44dc68d2874e.1"
+
+func getMockConformanceInterface(t *testing.T, reports map[string]agmodel.ConformanceReport) *ExternalInterface {
+	config.SetUpMockConfig(t)
+	config.Data.InteropProfileConf.ProfilesPath = writeMockProfiles(t)
+	e := getMockExternalInterface()
+	e.GetDeviceInventory = func(deviceUUID string) (map[string]string, *errors.Error) {
+		inventory := make(map[string]string)
+		for uri, resource := range getMockProfileInventory() {
+			data, _ := json.Marshal(resource)
+			inventory[uri] = string(data)
+		}
+		// the resources of the other systems of the BMC are not validated with the system
+		inventory["/redfish/v1/Systems/"+deviceUUID+".2"] = `{"@odata.type": "#ComputerSystem.v1_0_0.ComputerSystem"}`
+		return inventory, nil
+	}
+	e.GetResource = func(table, key string) (string, *errors.Error) {
+		if key != mockProfileSystemURI {
+			return "", errors.PackError(errors.DBKeyNotFound, "error: data with key ", key, " does not exist")
+		}
+		return "{}", nil
+	}
+	e.GetAllMatchingDetails = func(table, pattern string, dbtype common.DbType) ([]string, *errors.Error) {
+		return []string{mockProfileSystemURI, mockProfileSystemURI + "/Storage/1"}, nil
+	}
+	e.SaveConformanceReport = func(report agmodel.ConformanceReport, systemID string) *errors.Error {
+		reports[systemID] = report
+		return nil
+	}
+	e.GetConformanceReportInfo = func(systemID string) (agmodel.ConformanceReport, *errors.Error) {
+		report, ok := reports[systemID]
+		if !ok {
+			return report, errors.PackError(errors.DBKeyNotFound, "error: data with key ", systemID, " does not exist")
+		}
+		return report, nil
+	}
+	e.GetAllKeysFromTable = func(table string) ([]string, error) {
+		var keys []string
+		for systemID := range reports {
+			keys = append(keys, systemID)
+		}
+		return keys, nil
+	}
+	return e
+}
+
+func TestExternalInterface_ValidateInteropProfile(t *testing.T) {
+	reports := make(map[string]agmodel.ConformanceReport)
+	e := getMockConformanceInterface(t, reports)
+	tests := []struct {
+		name           string
+		defaultProfile string
+		requestBody    string
+		wantStatusCode int32
+	}{
+		{"profile and systems of the request", "", `{"Profile": "MockServer", "Systems": [{"@odata.id": "` + mockProfileSystemURI + `/"}]}`, http.StatusOK},
+		{"default profile and all the systems", "MockServer", `{}`, http.StatusOK},
+		{"no profile", "", `{}`, http.StatusBadRequest},
+		{"unknown profile", "", `{"Profile": "Unknown"}`, http.StatusBadRequest},
+		{"profile without the required profile", "", `{"Profile": "MockBroken"}`, http.StatusInternalServerError},
+		{"unknown system", "", `{"Profile": "MockServer", "Systems": [{"@odata.id": "/redfish/v1/Systems/unknown.1"}]}`, http.StatusNotFound},
+		{"invalid property", "", `{"profile": "MockServer"}`, http.StatusBadRequest},
+		{"malformed request", "", `{"Profile":`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.Data.InteropProfileConf.DefaultProfile = tt.defaultProfile
+			resp := e.ValidateInteropProfile(context.Background(), &aggregatorproto.AggregatorRequest{RequestBody: []byte(tt.requestBody)})
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Fatalf("ValidateInteropProfile() status = %v, want %v: %v", resp.StatusCode, tt.wantStatusCode, resp.Body)
+			}
+			if resp.StatusCode != http.StatusOK {
+				return
+			}
+			want := []agresponse.ConformanceReportResult{{
+				ConformanceReport: agresponse.OdataID{OdataID: ConformanceReportsURI + "/" + mockProfileSystemID},
+				System:            agresponse.OdataID{OdataID: mockProfileSystemURI},
+				Result:            resultFail,
+			}}
+			body := resp.Body.(agresponse.ValidateInteropProfileResponse)
+			if body.Profile != "MockServer" || !reflect.DeepEqual(body.ConformanceReports, want) {
+				t.Errorf("ValidateInteropProfile() = %+v, want the report of %v", body, mockProfileSystemURI)
+			}
+		})
+	}
+	report, ok := reports[mockProfileSystemID]
+	if !ok {
+		t.Fatal("ValidateInteropProfile() did not save the conformance report")
+	}
+	if report.ValidationTime == "" || report.Result != resultFail {
+		t.Errorf("ValidateInteropProfile() saved %+v, want the validation time and the result", report)
+	}
+	for _, result := range report.Results {
+		if result.Resource == "/redfish/v1/Systems/6d4a0a66-7efa-578e-83cf-44dc68d2874e.2" {
+			t.Errorf("ValidateInteropProfile() validated the other system of the BMC: %+v", result)
+		}
+	}
+}
+
+func TestExternalInterface_ValidateConformanceOnAdd(t *testing.T) {
+	reports := make(map[string]agmodel.ConformanceReport)
+	e := getMockConformanceInterface(t, reports)
+	e.validateConformanceOnAdd(context.Background(), []string{mockProfileSystemURI})
+	if len(reports) != 0 {
+		t.Errorf("validateConformanceOnAdd() without DefaultProfile saved %v, want no report", reports)
+	}
+	config.Data.InteropProfileConf.DefaultProfile = "MockServer"
+	e.validateConformanceOnAdd(context.Background(), []string{mockProfileSystemURI})
+	if _, ok := reports[mockProfileSystemID]; !ok {
+		t.Error("validateConformanceOnAdd() did not save the conformance report of the added system")
+	}
+}
+
+func TestExternalInterface_GetConformanceReport(t *testing.T) {
+	reports := map[string]agmodel.ConformanceReport{
+		mockProfileSystemID: {Result: resultWarning, ValidationTime: "2026-10-18T10:00:00Z"},
+	}
+	e := getMockConformanceInterface(t, reports)
+	resp := e.GetConformanceReport(context.Background(), ConformanceReportsURI+"/"+mockProfileSystemID+"/")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GetConformanceReport() status = %v, want %v", resp.StatusCode, http.StatusOK)
+	}
+	body := resp.Body.(agresponse.ConformanceReportResponse)
+	if body.OdataID != ConformanceReportsURI+"/"+mockProfileSystemID || body.System.OdataID != mockProfileSystemURI ||
+		!reflect.DeepEqual(body.ConformanceReport, reports[mockProfileSystemID]) {
+		t.Errorf("GetConformanceReport() = %+v, want the stored report", body)
+	}
+	if resp := e.GetConformanceReport(context.Background(), ConformanceReportsURI+"/unknown.1"); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("GetConformanceReport() of an unknown system status = %v, want %v", resp.StatusCode, http.StatusNotFound)
+	}
+	e.GetConformanceReportInfo = func(systemID string) (agmodel.ConformanceReport, *errors.Error) {
+		return agmodel.ConformanceReport{}, errors.PackError(errors.UndefinedErrorType, "error while trying to connect to DB")
+	}
+	if resp := e.GetConformanceReport(context.Background(), ConformanceReportsURI+"/"+mockProfileSystemID); resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("GetConformanceReport() with DB error status = %v, want %v", resp.StatusCode, http.StatusInternalServerError)
+	}
+}
+
+func TestExternalInterface_GetConformanceReports(t *testing.T) {
+	reports := map[string]agmodel.ConformanceReport{mockProfileSystemID: {}}
+	e := getMockConformanceInterface(t, reports)
+	resp := e.GetConformanceReports(context.Background())
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GetConformanceReports() status = %v, want %v", resp.StatusCode, http.StatusOK)
+	}
+	body := resp.Body.(agresponse.List)
+	want := []agresponse.ListMember{{OdataID: ConformanceReportsURI + "/" + mockProfileSystemID}}
+	if body.MembersCount != 1 || !reflect.DeepEqual(body.Members, want) {
+		t.Errorf("GetConformanceReports() members = %v, want %v", body.Members, want)
+	}
+}
@@ -175,6 +175,15 @@ func (e *ExternalInterface) DeleteAggregationSource(ctx context.Context, req *ag
 		l.LogWithFields(ctx).Error(errorMessage)
 		return resp
 	}
+	if dbErr = agmodel.Delete(credentialRotationTable, req.URL, common.OnDisk); dbErr != nil && dbErr.ErrNo() != errors.DBKeyNotFound {
+		l.LogWithFields(ctx).Error("error while trying to delete the credential rotation of " + req.URL + ": " + dbErr.Error())
+	}
+	// the password of the aggregation source is shared with the deleted System or Plugin entry
+	secrets := [][]byte{aggregationSource.Password}
+	if target != nil {
+		secrets = append(secrets, target.Password)
+	}
+	e.deleteSecrets(ctx, secrets...)
 	connectionMethod.Links.AggregationSources = removeAggregationSource(connectionMethod.Links.AggregationSources, agmodel.OdataID{OdataID: req.URL})
 	dbErr = e.UpdateConnectionMethod(connectionMethod, connectionMethodOdataID)
 	if dbErr != nil {
@@ -476,6 +485,12 @@ func (e *ExternalInterface) deleteCompute(ctx context.Context, key string, index
 		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
 	}
 	e.deleteWildCardValues(ctx, key[index+1:])
+	if derr := agmodel.Delete(inventoryHistoryTable, key[index+1:], common.OnDisk); derr != nil && derr.ErrNo() != errors.DBKeyNotFound {
+		l.LogWithFields(ctx).Error("error while trying to delete the inventory history of " + key + ": " + derr.Error())
+	}
+	if derr := agmodel.Delete(conformanceReportTable, key[index+1:], common.OnDisk); derr != nil && derr.ErrNo() != errors.DBKeyNotFound {
+		l.LogWithFields(ctx).Error("error while trying to delete the conformance report of " + key + ": " + derr.Error())
+	}
 
 	for _, manager := range managersList {
 		e.EventNotification(ctx, manager, "ResourceRemoved", "ManagerCollection")
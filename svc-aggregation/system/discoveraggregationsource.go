@@ -0,0 +1,582 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agresponse"
+	"github.com/google/uuid"
+)
+
+const (
+	// DiscoverAggregationSourcesURI is the target of the OEM action scanning an address range for the Redfish services
+	DiscoverAggregationSourcesURI = "/redfish/v1/AggregationService/Actions/Oem/Odim.DiscoverAggregationSources/"
+	// ApproveDiscoveredAggregationSourcesURI is the target of the OEM action adding the discovered aggregation sources
+	ApproveDiscoveredAggregationSourcesURI = "/redfish/v1/AggregationService/Actions/Oem/Odim.ApproveDiscoveredAggregationSources/"
+	// DiscoveredAggregationSourcesURI is the URI of the collection of the discovered aggregation sources
+	DiscoveredAggregationSourcesURI = "/redfish/v1/AggregationService/Oem/Odim/DiscoveredAggregationSources"
+	// DefaultDiscoveryConcurrency is the number of addresses scanned in parallel when the request has no MaxConcurrency
+	DefaultDiscoveryConcurrency = 32
+	// DefaultDiscoveryPort is the port scanned when the request has no Port
+	DefaultDiscoveryPort = 443
+	// MaxDiscoveryAddresses is the maximum number of addresses scanned by a discovery task
+	MaxDiscoveryAddresses = 4096
+
+	discoveredAggregationSourceTable = "DiscoveredAggregationSource"
+	// anyVendor in the Vendors of a connection method matches the BMCs of any vendor
+	anyVendor = "*"
+	// discoveryRequestTimeout is the time given to a Redfish service to answer a request of the discovery
+	discoveryRequestTimeout = 10 * time.Second
+	// maxDiscoveryResponseSize is the maximum size of a response read from a Redfish service
+	maxDiscoveryResponseSize = 1 << 20
+)
+
+// NewDiscoveryClient returns the client used by the network discovery for contacting the Redfish services,
+// the certificates of the services are verified against the root CA of ODIM unless VerifyPeer is disabled in TLSConf
+func NewDiscoveryClient() *http.Client {
+	tlsConfig := &tls.Config{}
+	capool := x509.NewCertPool()
+	capool.AppendCertsFromPEM(config.Data.KeyCertConf.RootCACertificate)
+	tlsConfig.RootCAs = capool
+	config.TLSConfMutex.RLock()
+	config.Client.SetTLSConfig(tlsConfig)
+	config.TLSConfMutex.RUnlock()
+	return &http.Client{
+		Timeout: discoveryRequestTimeout,
+		Transport: &http.Transport{
+			TLSClientConfig:     tlsConfig,
+			TLSHandshakeTimeout: discoveryRequestTimeout,
+			DisableKeepAlives:   true,
+		},
+	}
+}
+
+// ParseAddressRange returns the IP addresses of the range, given as a CIDR, as a start-end pair of IP addresses or as a single IP address.
+// For the IPv4 networks, the network and the broadcast addresses are skipped.
+func ParseAddressRange(addressRange string) ([]string, error) {
+	if strings.Contains(addressRange, "/") {
+		ip, ipNet, err := net.ParseCIDR(addressRange)
+		if err != nil {
+			return nil, err
+		}
+		ones, bits := ipNet.Mask.Size()
+		hostBits := bits - ones
+		if hostBits > 12 {
+			return nil, fmt.Errorf("the network %s has more than %d addresses", addressRange, MaxDiscoveryAddresses)
+		}
+		if ip.To4() != nil {
+			ip = ipNet.IP.To4()
+		} else {
+			ip = ipNet.IP
+		}
+		count := 1 << hostBits
+		addresses := make([]string, 0, count)
+		for i := 0; i < count; i++ {
+			if ip.To4() != nil && hostBits > 1 && (i == 0 || i == count-1) {
+				ip = nextIP(ip)
+				continue
+			}
+			addresses = append(addresses, ip.String())
+			ip = nextIP(ip)
+		}
+		return addresses, nil
+	}
+	bounds := strings.Split(addressRange, "-")
+	if len(bounds) > 2 {
+		return nil, fmt.Errorf("the address range %s is not valid", addressRange)
+	}
+	start := net.ParseIP(strings.TrimSpace(bounds[0]))
+	end := start
+	if len(bounds) == 2 {
+		end = net.ParseIP(strings.TrimSpace(bounds[1]))
+	}
+	if start == nil || end == nil {
+		return nil, fmt.Errorf("the address range %s is not valid", addressRange)
+	}
+	if (start.To4() == nil) != (end.To4() == nil) {
+		return nil, fmt.Errorf("the addresses of the range %s are not of the same IP version", addressRange)
+	}
+	if start.To4() != nil {
+		start, end = start.To4(), end.To4()
+	}
+	if bytes.Compare(start, end) > 0 {
+		return nil, fmt.Errorf("the start address of the range %s is greater than the end address", addressRange)
+	}
+	var addresses []string
+	for ip := start; bytes.Compare(ip, end) <= 0; ip = nextIP(ip) {
+		if len(addresses) == MaxDiscoveryAddresses {
+			return nil, fmt.Errorf("the address range %s has more than %d addresses", addressRange, MaxDiscoveryAddresses)
+		}
+		addresses = append(addresses, ip.String())
+		if ip.Equal(end) {
+			break
+		}
+	}
+	return addresses, nil
+}
+
+// nextIP returns the IP address following ip
+func nextIP(ip net.IP) net.IP {
+	next := make(net.IP, len(ip))
+	copy(next, ip)
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			break
+		}
+	}
+	return next
+}
+
+// DiscoverAggregationSources scans the addresses of the range for the Redfish services, identifies the vendor
+// and the model of each service and matches it to a connection method. The services which are not yet aggregation
+// sources are saved as discovered aggregation sources, to be added with ApproveDiscoveredAggregationSources.
+func (e *ExternalInterface) DiscoverAggregationSources(ctx context.Context, taskID string, sessionUserName string, req *aggregatorproto.AggregatorRequest) response.RPC {
+	targetURI := DiscoverAggregationSourcesURI
+	var resp response.RPC
+	var percentComplete int32
+	var requestBody map[string]interface{}
+	json.Unmarshal(req.RequestBody, &requestBody)
+	taskRequest := l.MaskRequestBody(requestBody)
+	taskInfo := &common.TaskUpdateInfo{Context: ctx, TaskID: taskID, TargetURI: targetURI, UpdateTask: e.UpdateTask, TaskRequest: taskRequest}
+	err := e.UpdateTask(ctx, fillTaskData(taskID, targetURI, taskRequest, resp, common.Running, common.OK, percentComplete, http.MethodPost))
+	if err != nil {
+		errMsg := "error while starting the task: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+	ctx, done := common.TrackTask(ctx, taskID)
+	defer done()
+
+	var discoverRequest DiscoverAggregationSources
+	if err = json.Unmarshal(req.RequestBody, &discoverRequest); err != nil {
+		errMsg := "unable to parse the discovery request: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+	}
+	addresses, err := ParseAddressRange(discoverRequest.AddressRange)
+	if err != nil {
+		errMsg := "unable to parse the address range: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errMsg, []interface{}{discoverRequest.AddressRange, "AddressRange"}, taskInfo)
+	}
+	port := discoverRequest.Port
+	if port == 0 {
+		port = DefaultDiscoveryPort
+	}
+	concurrency := discoverRequest.MaxConcurrency
+	if concurrency <= 0 {
+		concurrency = DefaultDiscoveryConcurrency
+	}
+	connectionMethods := e.getDiscoveryConnectionMethods(ctx)
+
+	results := make(chan *agmodel.DiscoveredAggregationSource, len(addresses))
+	go func() {
+		semaphore := make(chan struct{}, concurrency)
+		var wg sync.WaitGroup
+		for _, address := range addresses {
+			select {
+			case semaphore <- struct{}{}:
+			case <-ctx.Done():
+			}
+			if ctx.Err() != nil {
+				// the task is cancelled, the remaining addresses are not scanned
+				break
+			}
+			wg.Add(1)
+			go func(address string) {
+				defer wg.Done()
+				defer func() { <-semaphore }()
+				results <- e.probeRedfishService(ctx, address, port, discoverRequest.UserName, discoverRequest.Password)
+			}(address)
+		}
+		wg.Wait()
+		close(results)
+	}()
+
+	discovered := []agresponse.OdataID{}
+	var processed, managed int
+	for discoveredSource := range results {
+		processed++
+		if progress := int32(processed * 100 / len(addresses)); progress != percentComplete && progress < 100 {
+			percentComplete = progress
+			e.UpdateTask(ctx, fillTaskData(taskID, targetURI, taskRequest, resp, common.Running, common.OK, percentComplete, http.MethodPost))
+		}
+		if discoveredSource == nil {
+			continue
+		}
+		indexList, err := e.GetString("BMCAddress", getKeyFromManagerAddress(discoveredSource.HostName))
+		if err != nil {
+			l.LogWithFields(ctx).Error("unable to check whether " + discoveredSource.HostName + " is an aggregation source: " + err.Error())
+			continue
+		}
+		if len(indexList) > 0 {
+			managed++
+			continue
+		}
+		discoveredSource.ConnectionMethod = matchConnectionMethod(discoveredSource.Vendor, connectionMethods)
+		discoveredSource.DiscoveryTask = "/redfish/v1/TaskService/Tasks/" + taskID
+		discoveredSource.DiscoveredTime = time.Now().UTC().Format(time.RFC3339)
+		discoveredSourceURI := DiscoveredAggregationSourcesURI + "/" + uuid.NewSHA1(uuid.NameSpaceURL, []byte(discoveredSource.HostName)).String()
+		if err := e.SaveDiscoveredAggregationSource(*discoveredSource, discoveredSourceURI); err != nil {
+			l.LogWithFields(ctx).Error("unable to save the discovered aggregation source " + discoveredSource.HostName + ": " + err.Error())
+			continue
+		}
+		discovered = append(discovered, agresponse.OdataID{OdataID: discoveredSourceURI})
+	}
+	if ctx.Err() != nil {
+		l.LogWithFields(ctx).Warn("discovery task " + taskID + " is cancelled")
+		return e.cancelTask(ctx, taskID, targetURI, taskRequest, percentComplete)
+	}
+
+	resp = response.RPC{
+		StatusCode:    http.StatusOK,
+		StatusMessage: response.Success,
+		Body: agresponse.DiscoverAggregationSourcesResponse{
+			Message: fmt.Sprintf("%d Redfish services are discovered in %d addresses, %d of them are already aggregation sources",
+				len(discovered)+managed, len(addresses), managed),
+			DiscoveredAggregationSources: discovered,
+		},
+	}
+	percentComplete = 100
+	e.UpdateTask(ctx, fillTaskData(taskID, targetURI, taskRequest, resp, common.Completed, common.OK, percentComplete, http.MethodPost))
+	return resp
+}
+
+// discoveryConnectionMethod is a connection method of the compute plugins along with the vendors it is matched to
+type discoveryConnectionMethod struct {
+	uri     string
+	vendors []string
+}
+
+// getDiscoveryConnectionMethods returns the connection methods of the compute plugins having Vendors in ConnectionMethodConf,
+// in the order of the configuration
+func (e *ExternalInterface) getDiscoveryConnectionMethods(ctx context.Context) []discoveryConnectionMethod {
+	connectionMethodKeys, err := e.GetAllKeysFromTable("ConnectionMethod")
+	if err != nil {
+		l.LogWithFields(ctx).Error("unable to get the connection methods: " + err.Error())
+		return nil
+	}
+	connectionMethodURIs := make(map[string]string, len(connectionMethodKeys))
+	for _, connectionMethodURI := range connectionMethodKeys {
+		connectionMethod, err := e.GetConnectionMethod(connectionMethodURI)
+		if err != nil {
+			l.LogWithFields(ctx).Error("unable to get the connection method " + connectionMethodURI + ": " + err.Error())
+			continue
+		}
+		connectionMethodURIs[connectionMethod.ConnectionMethodType+":"+connectionMethod.ConnectionMethodVariant] = connectionMethodURI
+	}
+	var connectionMethods []discoveryConnectionMethod
+	for _, connectionMethodConf := range config.Data.ConnectionMethodConf {
+		if len(connectionMethodConf.Vendors) == 0 || !strings.HasPrefix(connectionMethodConf.ConnectionMethodVariant, "Compute:") {
+			continue
+		}
+		if connectionMethodURI, present := connectionMethodURIs[connectionMethodConf.ConnectionMethodType+":"+connectionMethodConf.ConnectionMethodVariant]; present {
+			connectionMethods = append(connectionMethods, discoveryConnectionMethod{
+				uri:     connectionMethodURI,
+				vendors: connectionMethodConf.Vendors,
+			})
+		}
+	}
+	return connectionMethods
+}
+
+// matchConnectionMethod returns the first connection method listing the vendor,
+// or else the first connection method matching any vendor
+func matchConnectionMethod(vendor string, connectionMethods []discoveryConnectionMethod) *agmodel.OdataID {
+	vendor = strings.ToLower(vendor)
+	var anyVendorMatch *agmodel.OdataID
+	for _, connectionMethod := range connectionMethods {
+		for _, connectionMethodVendor := range connectionMethod.vendors {
+			if connectionMethodVendor == anyVendor {
+				if anyVendorMatch == nil {
+					anyVendorMatch = &agmodel.OdataID{OdataID: connectionMethod.uri}
+				}
+				continue
+			}
+			if vendor != "" && strings.Contains(vendor, strings.ToLower(connectionMethodVendor)) {
+				return &agmodel.OdataID{OdataID: connectionMethod.uri}
+			}
+		}
+	}
+	return anyVendorMatch
+}
+
+// discoveryServiceRoot holds the properties of the Redfish service root used by the discovery
+type discoveryServiceRoot struct {
+	RedfishVersion string
+	UUID           string
+	Vendor         string
+	Product        string
+	Managers       *agresponse.OdataID
+}
+
+// discoveryManager holds the properties of the Redfish manager used by the discovery
+type discoveryManager struct {
+	Manufacturer    string
+	Model           string
+	FirmwareVersion string
+}
+
+// probeRedfishService returns the Redfish service listening at the address and the port,
+// or nil if there is none. The managers of the service are read only if the credentials are given.
+func (e *ExternalInterface) probeRedfishService(ctx context.Context, address string, port int, userName, password string) *agmodel.DiscoveredAggregationSource {
+	serviceAddress := net.JoinHostPort(address, strconv.Itoa(port))
+	var serviceRoot discoveryServiceRoot
+	if err := e.getDiscoveryResource(ctx, serviceAddress, "/redfish/v1/", "", "", &serviceRoot); err != nil {
+		l.LogWithFields(ctx).Debug("no Redfish service found at " + serviceAddress + ": " + err.Error())
+		return nil
+	}
+	if serviceRoot.RedfishVersion == "" {
+		l.LogWithFields(ctx).Debug("no Redfish service found at " + serviceAddress + ": RedfishVersion is missing in the service root")
+		return nil
+	}
+	discoveredSource := &agmodel.DiscoveredAggregationSource{
+		HostName:        address,
+		ServiceRootUUID: serviceRoot.UUID,
+		RedfishVersion:  serviceRoot.RedfishVersion,
+		Vendor:          serviceRoot.Vendor,
+		Model:           serviceRoot.Product,
+	}
+	if port != DefaultDiscoveryPort {
+		discoveredSource.HostName = serviceAddress
+	}
+	if userName == "" || serviceRoot.Managers == nil {
+		return discoveredSource
+	}
+	manager, err := e.getDiscoveryManager(ctx, serviceAddress, serviceRoot.Managers.OdataID, userName, password)
+	if err != nil {
+		l.LogWithFields(ctx).Warn("unable to get the manager of the Redfish service at " + serviceAddress + ": " + err.Error())
+		return discoveredSource
+	}
+	if discoveredSource.Vendor == "" {
+		discoveredSource.Vendor = manager.Manufacturer
+	}
+	if discoveredSource.Model == "" {
+		discoveredSource.Model = manager.Model
+	}
+	discoveredSource.FirmwareVersion = manager.FirmwareVersion
+	return discoveredSource
+}
+
+// getDiscoveryManager returns the first manager of the managers collection of the Redfish service
+func (e *ExternalInterface) getDiscoveryManager(ctx context.Context, serviceAddress, managersURI, userName, password string) (discoveryManager, error) {
+	var manager discoveryManager
+	var managers struct {
+		Members []agresponse.OdataID
+	}
+	if err := e.getDiscoveryResource(ctx, serviceAddress, managersURI, userName, password, &managers); err != nil {
+		return manager, err
+	}
+	if len(managers.Members) == 0 {
+		return manager, fmt.Errorf("the managers collection is empty")
+	}
+	err := e.getDiscoveryResource(ctx, serviceAddress, managers.Members[0].OdataID, userName, password, &manager)
+	return manager, err
+}
+
+// getDiscoveryResource reads the resource at the URI of the Redfish service into resource,
+// the URI comes from the probed service, so it is read only when it is a Redfish resource of the same service,
+// the request is authenticated only if userName is given
+func (e *ExternalInterface) getDiscoveryResource(ctx context.Context, serviceAddress, uri, userName, password string, resource interface{}) error {
+	if !strings.HasPrefix(path.Clean(uri), "/redfish/") {
+		return fmt.Errorf("%q is not a Redfish resource", uri)
+	}
+	resourceURL := url.URL{Scheme: "https", Host: serviceAddress, Path: uri}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, resourceURL.String(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if userName != "" {
+		req.SetBasicAuth(userName, password)
+	}
+	resp, err := e.DoDiscoveryRequest(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s returned %d", uri, resp.StatusCode)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, maxDiscoveryResponseSize)).Decode(resource)
+}
+
+// ApproveDiscoveredAggregationSources adds the discovered aggregation sources of the request as aggregation sources,
+// as BulkAddAggregationSources does. The discovered aggregation sources which are added are removed.
+// They are added with the connection method in Links of the request, or with the connection method
+// matched on the discovery when the request has none.
+func (e *ExternalInterface) ApproveDiscoveredAggregationSources(ctx context.Context, taskID string, sessionUserName string, req *aggregatorproto.AggregatorRequest) response.RPC {
+	targetURI := ApproveDiscoveredAggregationSourcesURI
+	var resp response.RPC
+	var percentComplete int32
+	var requestBody map[string]interface{}
+	json.Unmarshal(req.RequestBody, &requestBody)
+	taskRequest := l.MaskRequestBody(requestBody)
+	taskInfo := &common.TaskUpdateInfo{Context: ctx, TaskID: taskID, TargetURI: targetURI, UpdateTask: e.UpdateTask, TaskRequest: taskRequest}
+	err := e.UpdateTask(ctx, fillTaskData(taskID, targetURI, taskRequest, resp, common.Running, common.OK, percentComplete, http.MethodPost))
+	if err != nil {
+		errMsg := "error while starting the task: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+	ctx, done := common.TrackTask(ctx, taskID)
+	defer done()
+
+	var approveRequest ApproveDiscoveredAggregationSources
+	if err = json.Unmarshal(req.RequestBody, &approveRequest); err != nil {
+		errMsg := "unable to parse the approve request: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+	}
+	hosts := make([]bulkAddHost, 0, len(approveRequest.DiscoveredAggregationSources))
+	for _, discoveredSourceLink := range approveRequest.DiscoveredAggregationSources {
+		discoveredSource, err := e.GetDiscoveredAggregationSourceInfo(discoveredSourceLink.OdataID)
+		if err != nil {
+			errMsg := "unable to get the discovered aggregation source: " + err.Error()
+			l.LogWithFields(ctx).Error(errMsg)
+			if errors.DBKeyNotFound == err.ErrNo() {
+				return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"DiscoveredAggregationSource", discoveredSourceLink.OdataID}, taskInfo)
+			}
+			return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+		}
+		links := getApprovedLinks(approveRequest.Links, discoveredSource)
+		if links == nil || links.ConnectionMethod == nil {
+			errMsg := "the discovered aggregation source " + discoveredSourceLink.OdataID + " is not matched to a connection method and Links has no ConnectionMethod"
+			l.LogWithFields(ctx).Error(errMsg)
+			return common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{"ConnectionMethod"}, taskInfo)
+		}
+		hosts = append(hosts, bulkAddHost{
+			BulkAggregationSourceHost: BulkAggregationSourceHost{
+				HostName: discoveredSource.HostName,
+				UserName: approveRequest.UserName,
+				Password: approveRequest.Password,
+			},
+			links:               links,
+			discoveredSourceURI: discoveredSourceLink.OdataID,
+		})
+	}
+	return e.addAggregationSourcesInBulk(ctx, taskID, targetURI, taskRequest, sessionUserName, hosts, approveRequest.MaxConcurrency)
+}
+
+// getApprovedLinks returns the links of the discovered aggregation source to be added, the connection method
+// given in the request wins over the one matched on the discovery
+func getApprovedLinks(links *Links, discoveredSource agmodel.DiscoveredAggregationSource) *Links {
+	if (links == nil || links.ConnectionMethod == nil) && discoveredSource.ConnectionMethod != nil {
+		return &Links{ConnectionMethod: &ConnectionMethod{OdataID: discoveredSource.ConnectionMethod.OdataID}}
+	}
+	return links
+}
+
+// GetDiscoveredAggregationSourceCollection returns the collection of the discovered aggregation sources
+func (e *ExternalInterface) GetDiscoveredAggregationSourceCollection(ctx context.Context) response.RPC {
+	discoveredSourceKeys, err := e.GetAllKeysFromTable(discoveredAggregationSourceTable)
+	if err != nil {
+		errorMessage := err.Error()
+		l.LogWithFields(ctx).Error("Unable to get discovered aggregation sources : " + errorMessage)
+		return common.GeneralError(http.StatusServiceUnavailable, response.CouldNotEstablishConnection, errorMessage, []interface{}{config.Data.DBConf.OnDiskHost + ":" + config.Data.DBConf.OnDiskPort}, nil)
+	}
+	var members = make([]agresponse.ListMember, 0)
+	for _, discoveredSourceKey := range discoveredSourceKeys {
+		members = append(members, agresponse.ListMember{
+			OdataID: discoveredSourceKey,
+		})
+	}
+	commonResponse := response.Response{
+		OdataType:    "#DiscoveredAggregationSourceCollection.DiscoveredAggregationSourceCollection",
+		OdataID:      DiscoveredAggregationSourcesURI,
+		OdataContext: "/redfish/v1/$metadata#DiscoveredAggregationSourceCollection.DiscoveredAggregationSourceCollection",
+		Name:         "Discovered Aggregation Sources",
+	}
+	commonResponse.CreateGenericResponse(response.Success)
+	commonResponse.Message = ""
+	commonResponse.ID = ""
+	commonResponse.MessageID = ""
+	commonResponse.Severity = ""
+	return response.RPC{
+		StatusCode:    http.StatusOK,
+		StatusMessage: response.Success,
+		Body: agresponse.List{
+			Response:     commonResponse,
+			MembersCount: len(members),
+			Members:      members,
+		},
+	}
+}
+
+// GetDiscoveredAggregationSource returns the discovered aggregation source with the given URI
+func (e *ExternalInterface) GetDiscoveredAggregationSource(ctx context.Context, reqURI string) response.RPC {
+	reqURI = strings.TrimSuffix(reqURI, "/")
+	discoveredSource, err := e.GetDiscoveredAggregationSourceInfo(reqURI)
+	if err != nil {
+		errorMessage := err.Error()
+		l.LogWithFields(ctx).Error("Unable to get discovered aggregation source : " + errorMessage)
+		if errors.DBKeyNotFound == err.ErrNo() {
+			return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errorMessage, []interface{}{"DiscoveredAggregationSource", reqURI}, nil)
+		}
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	commonResponse := response.Response{
+		OdataType:    "#DiscoveredAggregationSource.v1_0_0.DiscoveredAggregationSource",
+		OdataID:      reqURI,
+		OdataContext: "/redfish/v1/$metadata#DiscoveredAggregationSource.DiscoveredAggregationSource",
+		ID:           strings.TrimPrefix(reqURI, DiscoveredAggregationSourcesURI+"/"),
+		Name:         "Discovered-" + discoveredSource.HostName,
+	}
+	commonResponse.CreateGenericResponse(response.Success)
+	commonResponse.Message = ""
+	commonResponse.MessageID = ""
+	commonResponse.Severity = ""
+	return response.RPC{
+		StatusCode:    http.StatusOK,
+		StatusMessage: response.Success,
+		Body: agresponse.DiscoveredAggregationSourceResponse{
+			Response:        commonResponse,
+			HostName:        discoveredSource.HostName,
+			ServiceRootUUID: discoveredSource.ServiceRootUUID,
+			RedfishVersion:  discoveredSource.RedfishVersion,
+			Vendor:          discoveredSource.Vendor,
+			Model:           discoveredSource.Model,
+			FirmwareVersion: discoveredSource.FirmwareVersion,
+			DiscoveredTime:  discoveredSource.DiscoveredTime,
+			Links: agresponse.DiscoveredAggregationSourceLinks{
+				ConnectionMethod: discoveredSource.ConnectionMethod,
+				DiscoveryTask:    agmodel.OdataID{OdataID: discoveredSource.DiscoveryTask},
+			},
+		},
+	}
+}
@@ -0,0 +1,426 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agresponse"
+)
+
+const mockDiscoveredSourceURI = DiscoveredAggregationSourcesURI + "/a1b6d3b4-5c4f-5b1e-9d4b-8f1f2c8e0b11"
+
+func TestParseAddressRange(t *testing.T) {
+	tests := []struct {
+		name         string
+		addressRange string
+		want         []string
+		wantErr      bool
+	}{
+		{name: "single address", addressRange: "10.0.0.5", want: []string{"10.0.0.5"}},
+		{name: "IPv4 network", addressRange: "10.0.0.0/30", want: []string{"10.0.0.1", "10.0.0.2"}},
+		{name: "IPv4 network of two addresses", addressRange: "10.0.0.4/31", want: []string{"10.0.0.4", "10.0.0.5"}},
+		{name: "IPv4 network given by a host address", addressRange: "10.0.0.9/29", want: []string{"10.0.0.9", "10.0.0.10", "10.0.0.11", "10.0.0.12", "10.0.0.13", "10.0.0.14"}},
+		{name: "IPv6 network", addressRange: "fd00::/127", want: []string{"fd00::", "fd00::1"}},
+		{name: "range crossing an octet", addressRange: "10.0.0.254-10.0.1.1", want: []string{"10.0.0.254", "10.0.0.255", "10.0.1.0", "10.0.1.1"}},
+		{name: "range of one address", addressRange: "10.0.0.7-10.0.0.7", want: []string{"10.0.0.7"}},
+		{name: "network too large", addressRange: "10.0.0.0/16", wantErr: true},
+		{name: "range too large", addressRange: "10.0.0.0-10.0.255.255", wantErr: true},
+		{name: "reversed range", addressRange: "10.0.0.9-10.0.0.1", wantErr: true},
+		{name: "mixed IP versions", addressRange: "10.0.0.1-fd00::1", wantErr: true},
+		{name: "host name", addressRange: "bmc.odim.local", wantErr: true},
+		{name: "invalid network", addressRange: "10.0.0.0/33", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAddressRange(tt.addressRange)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAddressRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseAddressRange() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchConnectionMethod(t *testing.T) {
+	connectionMethods := []discoveryConnectionMethod{
+		{uri: "/redfish/v1/AggregationService/ConnectionMethods/generic", vendors: []string{anyVendor}},
+		{uri: "/redfish/v1/AggregationService/ConnectionMethods/dell", vendors: []string{"Dell"}},
+		{uri: "/redfish/v1/AggregationService/ConnectionMethods/lenovo", vendors: []string{"Lenovo"}},
+	}
+	tests := []struct {
+		vendor string
+		want   *agmodel.OdataID
+	}{
+		{vendor: "Dell Inc.", want: &agmodel.OdataID{OdataID: "/redfish/v1/AggregationService/ConnectionMethods/dell"}},
+		{vendor: "LENOVO", want: &agmodel.OdataID{OdataID: "/redfish/v1/AggregationService/ConnectionMethods/lenovo"}},
+		{vendor: "Contoso", want: &agmodel.OdataID{OdataID: "/redfish/v1/AggregationService/ConnectionMethods/generic"}},
+		{vendor: "", want: &agmodel.OdataID{OdataID: "/redfish/v1/AggregationService/ConnectionMethods/generic"}},
+	}
+	for _, tt := range tests {
+		if got := matchConnectionMethod(tt.vendor, connectionMethods); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("matchConnectionMethod(%q) = %v, want %v", tt.vendor, got, tt.want)
+		}
+	}
+	if got := matchConnectionMethod("Contoso", connectionMethods[1:]); got != nil {
+		t.Errorf("matchConnectionMethod() = %v, want no connection method", got)
+	}
+}
+
+// newMockRedfishService returns a fake Redfish service whose managers require the admin:password credentials
+func newMockRedfishService() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/redfish/v1/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/redfish/v1/" {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"RedfishVersion": "1.15.0",
+			"UUID":           "d4c4a1e6-8a6f-4b3e-9a0e-2f5e6b1c7d01",
+			"Managers":       map[string]string{"@odata.id": "/redfish/v1/Managers"},
+		})
+	})
+	authorized := func(w http.ResponseWriter, r *http.Request) bool {
+		if userName, password, ok := r.BasicAuth(); !ok || userName != "admin" || password != "password" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return false
+		}
+		return true
+	}
+	mux.HandleFunc("/redfish/v1/Managers", func(w http.ResponseWriter, r *http.Request) {
+		if authorized(w, r) {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"Members": []map[string]string{{"@odata.id": "/redfish/v1/Managers/1"}},
+			})
+		}
+	})
+	mux.HandleFunc("/redfish/v1/Managers/1", func(w http.ResponseWriter, r *http.Request) {
+		if authorized(w, r) {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"Manufacturer":    "Dell Inc.",
+				"Model":           "iDRAC 9",
+				"FirmwareVersion": "6.10.00.00",
+			})
+		}
+	})
+	return httptest.NewTLSServer(mux)
+}
+
+func TestExternalInterface_DiscoverAggregationSources(t *testing.T) {
+	config.SetUpMockConfig(t)
+	config.Data.ConnectionMethodConf = []config.ConnectionMethodConf{
+		{ConnectionMethodType: "Redfish", ConnectionMethodVariant: "Compute:BasicAuth:ILO_v2.0.0", Vendors: []string{"Dell"}},
+		{ConnectionMethodType: "Redfish", ConnectionMethodVariant: "Compute:BasicAuth:GRF_v2.0.0", Vendors: []string{anyVendor}},
+	}
+	service := newMockRedfishService()
+	defer service.Close()
+	_, port, _ := net.SplitHostPort(strings.TrimPrefix(service.URL, "https://"))
+
+	saved := make(map[string]agmodel.DiscoveredAggregationSource)
+	e := getMockExternalInterface()
+	e.DoDiscoveryRequest = service.Client().Do
+	e.GetAllKeysFromTable = func(table string) ([]string, error) {
+		return []string{
+			"/redfish/v1/AggregationService/ConnectionMethods/7ff3bd97-c41c-5de0-937d-85d390691b73",
+			"/redfish/v1/AggregationService/ConnectionMethods/c41cbd97-937d-1b73-c41c-1b7385d39069",
+		}, nil
+	}
+	e.GetString = func(index, match string) ([]string, error) {
+		return nil, nil
+	}
+	e.SaveDiscoveredAggregationSource = func(discoveredSource agmodel.DiscoveredAggregationSource, discoveredSourceURI string) *errors.Error {
+		saved[discoveredSourceURI] = discoveredSource
+		return nil
+	}
+
+	// 127.0.0.2 has no Redfish service, only 127.0.0.1 is discovered
+	portNumber, _ := strconv.Atoi(port)
+	tests := []struct {
+		name     string
+		request  DiscoverAggregationSources
+		wantHost agmodel.DiscoveredAggregationSource
+	}{
+		{
+			name:    "with credentials",
+			request: DiscoverAggregationSources{AddressRange: "127.0.0.1-127.0.0.2", Port: portNumber, UserName: "admin", Password: "password"},
+			wantHost: agmodel.DiscoveredAggregationSource{
+				HostName:         "127.0.0.1:" + port,
+				ServiceRootUUID:  "d4c4a1e6-8a6f-4b3e-9a0e-2f5e6b1c7d01",
+				RedfishVersion:   "1.15.0",
+				Vendor:           "Dell Inc.",
+				Model:            "iDRAC 9",
+				FirmwareVersion:  "6.10.00.00",
+				ConnectionMethod: &agmodel.OdataID{OdataID: "/redfish/v1/AggregationService/ConnectionMethods/c41cbd97-937d-1b73-c41c-1b7385d39069"},
+				DiscoveryTask:    "/redfish/v1/TaskService/Tasks/someTaskID",
+			},
+		},
+		{
+			name:    "without credentials",
+			request: DiscoverAggregationSources{AddressRange: "127.0.0.1/32", Port: portNumber},
+			wantHost: agmodel.DiscoveredAggregationSource{
+				HostName:         "127.0.0.1:" + port,
+				ServiceRootUUID:  "d4c4a1e6-8a6f-4b3e-9a0e-2f5e6b1c7d01",
+				RedfishVersion:   "1.15.0",
+				ConnectionMethod: &agmodel.OdataID{OdataID: "/redfish/v1/AggregationService/ConnectionMethods/7ff3bd97-c41c-5de0-937d-85d390691b73"},
+				DiscoveryTask:    "/redfish/v1/TaskService/Tasks/someTaskID",
+			},
+		},
+		{
+			name:    "with invalid credentials",
+			request: DiscoverAggregationSources{AddressRange: "127.0.0.1", Port: portNumber, UserName: "admin", Password: "invalid"},
+			wantHost: agmodel.DiscoveredAggregationSource{
+				HostName:         "127.0.0.1:" + port,
+				ServiceRootUUID:  "d4c4a1e6-8a6f-4b3e-9a0e-2f5e6b1c7d01",
+				RedfishVersion:   "1.15.0",
+				ConnectionMethod: &agmodel.OdataID{OdataID: "/redfish/v1/AggregationService/ConnectionMethods/7ff3bd97-c41c-5de0-937d-85d390691b73"},
+				DiscoveryTask:    "/redfish/v1/TaskService/Tasks/someTaskID",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			saved = make(map[string]agmodel.DiscoveredAggregationSource)
+			reqBody, _ := json.Marshal(tt.request)
+			resp := e.DiscoverAggregationSources(mockContext(), "someTaskID", "admin", &aggregatorproto.AggregatorRequest{RequestBody: reqBody})
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("DiscoverAggregationSources() StatusCode = %v, want %v", resp.StatusCode, http.StatusOK)
+			}
+			report, ok := resp.Body.(agresponse.DiscoverAggregationSourcesResponse)
+			if !ok || len(report.DiscoveredAggregationSources) != 1 || len(saved) != 1 {
+				t.Fatalf("DiscoverAggregationSources() Body = %v, want one discovered aggregation source", resp.Body)
+			}
+			discoveredSource, ok := saved[report.DiscoveredAggregationSources[0].OdataID]
+			if !ok {
+				t.Fatalf("DiscoverAggregationSources() did not save %v", report.DiscoveredAggregationSources[0].OdataID)
+			}
+			if discoveredSource.DiscoveredTime == "" {
+				t.Errorf("DiscoverAggregationSources() DiscoveredTime is missing")
+			}
+			discoveredSource.DiscoveredTime = ""
+			if !reflect.DeepEqual(discoveredSource, tt.wantHost) {
+				t.Errorf("DiscoverAggregationSources() saved %v, want %v", discoveredSource, tt.wantHost)
+			}
+		})
+	}
+
+	// the Redfish services which are already aggregation sources are not saved
+	saved = make(map[string]agmodel.DiscoveredAggregationSource)
+	e.GetString = func(index, match string) ([]string, error) {
+		return []string{match}, nil
+	}
+	reqBody, _ := json.Marshal(DiscoverAggregationSources{AddressRange: "127.0.0.1", Port: portNumber})
+	resp := e.DiscoverAggregationSources(mockContext(), "someTaskID", "admin", &aggregatorproto.AggregatorRequest{RequestBody: reqBody})
+	report, _ := resp.Body.(agresponse.DiscoverAggregationSourcesResponse)
+	if resp.StatusCode != http.StatusOK || len(report.DiscoveredAggregationSources) != 0 || len(saved) != 0 {
+		t.Errorf("DiscoverAggregationSources() = %v, want no discovered aggregation source", resp)
+	}
+
+	reqBody, _ = json.Marshal(DiscoverAggregationSources{AddressRange: "10.0.0.0/8"})
+	resp = e.DiscoverAggregationSources(mockContext(), "someTaskID", "admin", &aggregatorproto.AggregatorRequest{RequestBody: reqBody})
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("DiscoverAggregationSources() StatusCode = %v, want %v for a network too large", resp.StatusCode, http.StatusBadRequest)
+	}
+}
+
+func TestExternalInterface_getDiscoveryResource(t *testing.T) {
+	const serviceAddress = "10.0.0.1:443"
+	var requested []*http.Request
+	e := getMockExternalInterface()
+	e.DoDiscoveryRequest = func(req *http.Request) (*http.Response, error) {
+		requested = append(requested, req)
+		return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(`{}`))}, nil
+	}
+	tests := []struct {
+		name    string
+		uri     string
+		wantErr bool
+	}{
+		{"Redfish resource", "/redfish/v1/Managers/1", false},
+		{"user info changing the host", "@attacker.example/redfish/v1/Managers", true},
+		{"network-path reference", "//attacker.example/redfish/v1/Managers", true},
+		{"absolute URL", "https://attacker.example/redfish/v1/Managers", true},
+		{"path leaving the Redfish tree", "/redfish/../admin", true},
+		{"empty URI", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requested = nil
+			var resource map[string]interface{}
+			err := e.getDiscoveryResource(mockContext(), serviceAddress, tt.uri, "admin", "password", &resource)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getDiscoveryResource() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if len(requested) != 0 {
+					t.Errorf("getDiscoveryResource() sent the credentials to %v", requested[0].URL)
+				}
+				return
+			}
+			if len(requested) != 1 || requested[0].URL.Host != serviceAddress || requested[0].URL.Path != tt.uri {
+				t.Errorf("getDiscoveryResource() requested %v, want %v of %v", requested, tt.uri, serviceAddress)
+			}
+		})
+	}
+}
+
+func mockGetDiscoveredAggregationSourceInfo(discoveredSourceURI string) (agmodel.DiscoveredAggregationSource, *errors.Error) {
+	switch discoveredSourceURI {
+	case mockDiscoveredSourceURI:
+		return agmodel.DiscoveredAggregationSource{
+			HostName:         "10.0.0.1",
+			RedfishVersion:   "1.15.0",
+			Vendor:           "Dell Inc.",
+			ConnectionMethod: &agmodel.OdataID{OdataID: "/redfish/v1/AggregationService/ConnectionMethods/7ff3bd97-c41c-5de0-937d-85d390691b73"},
+			DiscoveryTask:    "/redfish/v1/TaskService/Tasks/someTaskID",
+			DiscoveredTime:   "2022-10-17T10:00:00Z",
+		}, nil
+	case DiscoveredAggregationSourcesURI + "/unmatched":
+		return agmodel.DiscoveredAggregationSource{HostName: "10.0.0.2", RedfishVersion: "1.15.0"}, nil
+	}
+	return agmodel.DiscoveredAggregationSource{}, errors.PackError(errors.DBKeyNotFound, "no data with the with key "+discoveredSourceURI+" found")
+}
+
+func TestExternalInterface_ApproveDiscoveredAggregationSources(t *testing.T) {
+	config.SetUpMockConfig(t)
+	e := getMockExternalInterface()
+	e.GetDiscoveredAggregationSourceInfo = mockGetDiscoveredAggregationSourceInfo
+	tests := []struct {
+		name    string
+		request ApproveDiscoveredAggregationSources
+		want    int32
+	}{
+		{
+			name: "unknown discovered aggregation source",
+			request: ApproveDiscoveredAggregationSources{
+				DiscoveredAggregationSources: []agmodel.OdataID{{OdataID: DiscoveredAggregationSourcesURI + "/unknown"}},
+				UserName:                     "admin",
+				Password:                     "password",
+			},
+			want: http.StatusNotFound,
+		},
+		{
+			name: "no connection method",
+			request: ApproveDiscoveredAggregationSources{
+				DiscoveredAggregationSources: []agmodel.OdataID{{OdataID: DiscoveredAggregationSourcesURI + "/unmatched"}},
+				UserName:                     "admin",
+				Password:                     "password",
+			},
+			want: http.StatusBadRequest,
+		},
+		{
+			// the sub tasks of the hosts can not be created, every host is reported as failed
+			name: "connection method of the request",
+			request: ApproveDiscoveredAggregationSources{
+				DiscoveredAggregationSources: []agmodel.OdataID{{OdataID: mockDiscoveredSourceURI}, {OdataID: DiscoveredAggregationSourcesURI + "/unmatched"}},
+				UserName:                     "admin",
+				Password:                     "password",
+				Links: &Links{
+					ConnectionMethod: &ConnectionMethod{OdataID: "/redfish/v1/AggregationService/ConnectionMethods/7ff3bd97-c41c-5de0-937d-85d390691b73"},
+				},
+			},
+			want: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reqBody, _ := json.Marshal(tt.request)
+			resp := e.ApproveDiscoveredAggregationSources(mockContext(), "taskWithoutChild", "admin", &aggregatorproto.AggregatorRequest{RequestBody: reqBody})
+			if resp.StatusCode != tt.want {
+				t.Fatalf("ApproveDiscoveredAggregationSources() StatusCode = %v, want %v", resp.StatusCode, tt.want)
+			}
+			if tt.want != http.StatusOK {
+				return
+			}
+			report := resp.Body.(agresponse.BulkAddAggregationSourcesResponse)
+			if len(report.Failed) != 2 || report.Failed[0].HostName != "10.0.0.1" || report.Failed[1].HostName != "10.0.0.2" {
+				t.Errorf("ApproveDiscoveredAggregationSources() report = %v, want 10.0.0.1 and 10.0.0.2 failed", report)
+			}
+		})
+	}
+}
+
+func TestGetApprovedLinks(t *testing.T) {
+	matched := "/redfish/v1/AggregationService/ConnectionMethods/7ff3bd97-c41c-5de0-937d-85d390691b73"
+	requested := "/redfish/v1/AggregationService/ConnectionMethods/c41cbd97-937d-1b73-c41c-1b7385d39069"
+	tests := []struct {
+		name             string
+		links            *Links
+		connectionMethod *agmodel.OdataID
+		want             string
+	}{
+		{"connection method of the request", &Links{ConnectionMethod: &ConnectionMethod{OdataID: requested}}, &agmodel.OdataID{OdataID: matched}, requested},
+		{"matched connection method", nil, &agmodel.OdataID{OdataID: matched}, matched},
+		{"request without connection method", &Links{}, &agmodel.OdataID{OdataID: matched}, matched},
+		{"unmatched without connection method", nil, nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			links := getApprovedLinks(tt.links, agmodel.DiscoveredAggregationSource{ConnectionMethod: tt.connectionMethod})
+			if links != nil && links.ConnectionMethod != nil {
+				got = links.ConnectionMethod.OdataID
+			}
+			if got != tt.want {
+				t.Errorf("getApprovedLinks() connection method = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExternalInterface_GetDiscoveredAggregationSource(t *testing.T) {
+	config.SetUpMockConfig(t)
+	e := getMockExternalInterface()
+	e.GetDiscoveredAggregationSourceInfo = mockGetDiscoveredAggregationSourceInfo
+	resp := e.GetDiscoveredAggregationSource(mockContext(), mockDiscoveredSourceURI)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GetDiscoveredAggregationSource() StatusCode = %v, want %v", resp.StatusCode, http.StatusOK)
+	}
+	body := resp.Body.(agresponse.DiscoveredAggregationSourceResponse)
+	if body.ID != "a1b6d3b4-5c4f-5b1e-9d4b-8f1f2c8e0b11" || body.HostName != "10.0.0.1" || body.Vendor != "Dell Inc." ||
+		body.Links.ConnectionMethod == nil || body.Links.DiscoveryTask.OdataID != "/redfish/v1/TaskService/Tasks/someTaskID" {
+		t.Errorf("GetDiscoveredAggregationSource() Body = %v, want the discovered aggregation source", body)
+	}
+	resp = e.GetDiscoveredAggregationSource(mockContext(), DiscoveredAggregationSourcesURI+"/unknown")
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("GetDiscoveredAggregationSource() StatusCode = %v, want %v", resp.StatusCode, http.StatusNotFound)
+	}
+
+	e.GetAllKeysFromTable = func(table string) ([]string, error) {
+		if table == discoveredAggregationSourceTable {
+			return []string{mockDiscoveredSourceURI}, nil
+		}
+		return nil, nil
+	}
+	resp = e.GetDiscoveredAggregationSourceCollection(mockContext())
+	collection, _ := resp.Body.(agresponse.List)
+	if resp.StatusCode != http.StatusOK || collection.MembersCount != 1 || collection.Members[0].OdataID != mockDiscoveredSourceURI {
+		t.Errorf("GetDiscoveredAggregationSourceCollection() = %v, want the discovered aggregation source", resp)
+	}
+}
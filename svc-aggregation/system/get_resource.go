@@ -16,6 +16,7 @@ import (
 	"net/http"
 	"strings"
 
+	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
 	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
@@ -105,11 +106,21 @@ func (e *ExternalInterface) GetAggregationSource(ctx context.Context, reqURI str
 	commonResponse.Message = ""
 	commonResponse.MessageID = ""
 	commonResponse.Severity = ""
-	resp.Body = agresponse.AggregationSourceResponse{
+	body := agresponse.AggregationSourceResponse{
 		Response: commonResponse,
 		HostName: aggregationSource.HostName,
 		UserName: aggregationSource.UserName,
 		Links:    aggregationSource.Links,
 	}
+	// the state of the password rotation is shown only for the BMC aggregation sources which were rotated
+	if rotation, err := e.GetCredentialRotation(reqURI); err == nil {
+		var oem dmtf.Oem = map[string]interface{}{
+			"Odim": map[string]interface{}{
+				"CredentialRotation": rotation,
+			},
+		}
+		body.Oem = &oem
+	}
+	resp.Body = body
 	return resp
 }
@@ -31,6 +31,7 @@ import (
 	"reflect"
 	"testing"
 
+	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
@@ -55,6 +56,11 @@ func mockGetAggregationSourceInfo(reqURI string) (agmodel.AggregationSource, *er
 	}
 	return aggSource, errors.PackError(errors.DBKeyNotFound, "error: while trying to fetch Aggregation Source data: no data with the with key "+reqURI+" found")
 }
+
+func mockGetCredentialRotation(reqURI string) (agmodel.CredentialRotation, *errors.Error) {
+	return agmodel.CredentialRotation{}, errors.PackError(errors.DBKeyNotFound, "error: while trying to fetch credential rotation data: no data with the with key "+reqURI+" found")
+}
+
 func TestGetAggregationSourceCollection(t *testing.T) {
 	commonResponse := response.Response{
 		OdataType:    "#AggregationSourceCollection.AggregationSourceCollection",
@@ -136,7 +142,25 @@ func TestGetAggregationSource(t *testing.T) {
 	p := &ExternalInterface{
 		GetConnectionMethod:      mockGetConnectionMethod,
 		GetAggregationSourceInfo: mockGetAggregationSourceInfo,
+		GetCredentialRotation:    mockGetCredentialRotation,
+	}
+	rotation := agmodel.CredentialRotation{Status: "Completed", StartTime: "2022-01-01T00:00:00Z", LastRotatedTime: "2022-01-01T00:00:10Z"}
+	rotatedSource := &ExternalInterface{
+		GetConnectionMethod:      mockGetConnectionMethod,
+		GetAggregationSourceInfo: mockGetAggregationSourceInfo,
+		GetCredentialRotation: func(reqURI string) (agmodel.CredentialRotation, *errors.Error) {
+			return rotation, nil
+		},
 	}
+	resp3 := resp1
+	rotatedBody := resp1.Body.(agresponse.AggregationSourceResponse)
+	var oem dmtf.Oem = map[string]interface{}{
+		"Odim": map[string]interface{}{
+			"CredentialRotation": rotation,
+		},
+	}
+	rotatedBody.Oem = &oem
+	resp3.Body = rotatedBody
 
 	type args struct {
 		reqURI string
@@ -163,6 +187,14 @@ func TestGetAggregationSource(t *testing.T) {
 			},
 			want: resp2,
 		},
+		{
+			name: "Rotated Aggregation Source",
+			p:    rotatedSource,
+			args: args{
+				reqURI: "/redfish/v1/AggregationService/AggregationSources/36474ba4-a201-46aa-badf-d8104da418e8",
+			},
+			want: resp3,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,727 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+)
+
+const (
+	requirementMandatory     = "Mandatory"
+	requirementSupported     = "Supported"
+	requirementRecommended   = "Recommended"
+	requirementIfImplemented = "IfImplemented"
+	requirementConditional   = "Conditional"
+	requirementNone          = "None"
+
+	resultPass      = "Pass"
+	resultWarning   = "Warning"
+	resultFail      = "Fail"
+	resultNotTested = "NotTested"
+
+	operationSupported    = "Supported"
+	operationNotSupported = "NotSupported"
+	operationUnknown      = "Unknown"
+)
+
+// serviceResourceTypes are the resource types of the profiles which are implemented by ODIM itself,
+// they are not part of the inventory of a BMC and are not validated
+var serviceResourceTypes = map[string]bool{
+	"ServiceRoot":              true,
+	"AccountService":           true,
+	"ManagerAccount":           true,
+	"SessionService":           true,
+	"EventService":             true,
+	"ComputerSystemCollection": true,
+	"ChassisCollection":        true,
+	"ManagerCollection":        true,
+}
+
+// requirementRanks orders the read and write requirements from the weakest to the strictest
+var requirementRanks = map[string]int{
+	requirementNone:          0,
+	requirementConditional:   0,
+	requirementIfImplemented: 1,
+	"IfPopulated":            1,
+	requirementRecommended:   2,
+	requirementSupported:     3,
+	requirementMandatory:     4,
+}
+
+// interopProfile is a Redfish Interop profile, only the requirements which can be
+// evaluated against the stored inventory of a BMC are decoded
+type interopProfile struct {
+	Name             string                         `json:"-"`
+	Version          string                         `json:"-"`
+	ProfileVersion   string                         `json:"ProfileVersion"`
+	RequiredProfiles map[string]requiredProfile     `json:"RequiredProfiles"`
+	Resources        map[string]resourceRequirement `json:"Resources"`
+}
+
+type requiredProfile struct {
+	MinVersion string `json:"MinVersion"`
+}
+
+type resourceRequirement struct {
+	MinVersion              string                         `json:"MinVersion"`
+	ReadRequirement         string                         `json:"ReadRequirement"`
+	ConditionalRequirements []conditionalRequirement       `json:"ConditionalRequirements"`
+	PropertyRequirements    map[string]propertyRequirement `json:"PropertyRequirements"`
+	ActionRequirements      map[string]actionRequirement   `json:"ActionRequirements"`
+}
+
+type propertyRequirement struct {
+	ReadRequirement         string                         `json:"ReadRequirement"`
+	WriteRequirement        string                         `json:"WriteRequirement"`
+	MinCount                *int                           `json:"MinCount"`
+	Comparison              string                         `json:"Comparison"`
+	Values                  []interface{}                  `json:"Values"`
+	ConditionalRequirements []conditionalRequirement       `json:"ConditionalRequirements"`
+	PropertyRequirements    map[string]propertyRequirement `json:"PropertyRequirements"`
+}
+
+// conditionalRequirement overrides the requirements of a resource or a property when the resource is
+// subordinate to SubordinateToResource, and the CompareProperty of the same object meets the comparison.
+// The profiles of the specification name the comparison values CompareValues, older profiles name them Values
+type conditionalRequirement struct {
+	Purpose               string        `json:"Purpose"`
+	SubordinateToResource []string      `json:"SubordinateToResource"`
+	CompareProperty       string        `json:"CompareProperty"`
+	Comparison            string        `json:"Comparison"`
+	CompareValues         []interface{} `json:"CompareValues"`
+	Values                []interface{} `json:"Values"`
+	ReadRequirement       string        `json:"ReadRequirement"`
+	WriteRequirement      string        `json:"WriteRequirement"`
+}
+
+type actionRequirement struct {
+	ReadRequirement string                          `json:"ReadRequirement"`
+	Parameters      map[string]parameterRequirement `json:"Parameters"`
+}
+
+type parameterRequirement struct {
+	ReadRequirement   string   `json:"ReadRequirement"`
+	ParameterValues   []string `json:"ParameterValues"`
+	AllowableValues   []string `json:"AllowableValues"`
+	RecommendedValues []string `json:"RecommendedValues"`
+}
+
+// profileNotFoundError is returned when no file of the profile directory has the profile
+type profileNotFoundError struct {
+	name       string
+	minVersion string
+}
+
+func (e *profileNotFoundError) Error() string {
+	if e.minVersion == "" {
+		return "profile " + e.name + " is not found"
+	}
+	return "profile " + e.name + " of version " + e.minVersion + " or later is not found"
+}
+
+// loadInteropProfiles loads the profile with the given name from the profile directory, followed by the
+// profiles it requires. The profile files are named <ProfileName>.v<Major>_<Minor>_<Patch>.json,
+// the latest version of a profile meeting MinVersion is loaded
+func loadInteropProfiles(profilesPath, name string) ([]*interopProfile, error) {
+	var profiles []*interopProfile
+	loaded := make(map[string]bool)
+	var load func(name, minVersion string) error
+	load = func(name, minVersion string) error {
+		if loaded[name] {
+			return nil
+		}
+		loaded[name] = true
+		profile, err := loadInteropProfile(profilesPath, name, minVersion)
+		if err != nil {
+			return err
+		}
+		profiles = append(profiles, profile)
+		requiredNames := make([]string, 0, len(profile.RequiredProfiles))
+		for requiredName := range profile.RequiredProfiles {
+			requiredNames = append(requiredNames, requiredName)
+		}
+		sort.Strings(requiredNames)
+		for _, requiredName := range requiredNames {
+			if err := load(requiredName, profile.RequiredProfiles[requiredName].MinVersion); err != nil {
+				return fmt.Errorf("profile %s required by %s: %v", requiredName, name, err)
+			}
+		}
+		return nil
+	}
+	if err := load(name, ""); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+func loadInteropProfile(profilesPath, name, minVersion string) (*interopProfile, error) {
+	files, err := filepath.Glob(filepath.Join(profilesPath, name+".v*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var latestFile, latestVersion string
+	for _, file := range files {
+		version := strings.Replace(strings.TrimSuffix(strings.TrimPrefix(filepath.Base(file), name+".v"), ".json"), "_", ".", -1)
+		if minVersion != "" && compareVersions(version, minVersion) < 0 {
+			continue
+		}
+		if latestFile == "" || compareVersions(version, latestVersion) > 0 {
+			latestFile, latestVersion = file, version
+		}
+	}
+	if latestFile == "" {
+		return nil, &profileNotFoundError{name: name, minVersion: minVersion}
+	}
+	data, err := ioutil.ReadFile(latestFile)
+	if err != nil {
+		return nil, err
+	}
+	var profile interopProfile
+	if err := json.Unmarshal(data, &profile); err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %v", latestFile, err)
+	}
+	// the file name is used since the ProfileName of a profile may be copied from another profile
+	profile.Name = name
+	profile.Version = latestVersion
+	if profile.ProfileVersion != "" {
+		profile.Version = profile.ProfileVersion
+	}
+	return &profile, nil
+}
+
+// compareVersions compares the versions like 1.12.0 and v1_12_0, the missing parts are taken as 0
+func compareVersions(first, second string) int {
+	split := func(version string) []string {
+		return strings.FieldsFunc(strings.TrimPrefix(version, "v"), func(r rune) bool { return r == '.' || r == '_' })
+	}
+	firstParts, secondParts := split(first), split(second)
+	for i := 0; i < len(firstParts) || i < len(secondParts); i++ {
+		var firstPart, secondPart int
+		if i < len(firstParts) {
+			firstPart, _ = strconv.Atoi(firstParts[i])
+		}
+		if i < len(secondParts) {
+			secondPart, _ = strconv.Atoi(secondParts[i])
+		}
+		if firstPart != secondPart {
+			if firstPart < secondPart {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// getResourceType returns the type and the version of a resource from its @odata.type,
+// like ComputerSystem and 1.12.0 for #ComputerSystem.v1_12_0.ComputerSystem
+func getResourceType(resource map[string]interface{}) (string, string) {
+	odataType, _ := resource["@odata.type"].(string)
+	parts := strings.Split(strings.TrimPrefix(odataType, "#"), ".")
+	switch len(parts) {
+	case 0:
+		return "", ""
+	case 1, 2:
+		return parts[0], ""
+	}
+	return parts[0], strings.Replace(strings.TrimPrefix(parts[1], "v"), "_", ".", -1)
+}
+
+// conformanceValidator evaluates the requirements of the profiles against the inventory of a system
+type conformanceValidator struct {
+	profile    string
+	inventory  map[string]map[string]interface{}
+	types      map[string]string
+	report     agmodel.ConformanceReport
+	operations map[string]bool
+}
+
+func newConformanceValidator(inventory map[string]map[string]interface{}) *conformanceValidator {
+	v := &conformanceValidator{
+		inventory:  inventory,
+		types:      make(map[string]string, len(inventory)),
+		operations: make(map[string]bool),
+		report: agmodel.ConformanceReport{
+			Profiles:   []agmodel.InteropProfile{},
+			Operations: []agmodel.OperationSupport{},
+			Results:    []agmodel.RequirementResult{},
+		},
+	}
+	for uri, resource := range inventory {
+		v.types[uri], _ = getResourceType(resource)
+	}
+	return v
+}
+
+// validate evaluates the requirements of the profiles and returns the report, the result of the
+// report is the worst result of the requirements
+func (v *conformanceValidator) validate(profiles []*interopProfile) agmodel.ConformanceReport {
+	uris := make([]string, 0, len(v.inventory))
+	for uri := range v.inventory {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+	for _, profile := range profiles {
+		v.profile = profile.Name
+		v.report.Profiles = append(v.report.Profiles, agmodel.InteropProfile{Name: profile.Name, Version: profile.Version})
+		resourceTypes := make([]string, 0, len(profile.Resources))
+		for resourceType := range profile.Resources {
+			resourceTypes = append(resourceTypes, resourceType)
+		}
+		sort.Strings(resourceTypes)
+		for _, resourceType := range resourceTypes {
+			if serviceResourceTypes[resourceType] {
+				continue
+			}
+			var instances []string
+			for _, uri := range uris {
+				if v.types[uri] == resourceType {
+					instances = append(instances, uri)
+				}
+			}
+			v.validateResourceType(resourceType, instances, profile.Resources[resourceType])
+		}
+	}
+	v.report.Result = resultPass
+	if v.report.Counts.Fail > 0 {
+		v.report.Result = resultFail
+	} else if v.report.Counts.Warning > 0 {
+		v.report.Result = resultWarning
+	}
+	return v.report
+}
+
+func (v *conformanceValidator) validateResourceType(resourceType string, instances []string, requirement resourceRequirement) {
+	readRequirement := getRequirement(requirement.ReadRequirement, requirementMandatory)
+	v.recordPresence(resourceType, "", "ReadRequirement: "+readRequirement, readRequirement, len(instances) > 0,
+		"no "+resourceType+" resource is found")
+	for _, uri := range instances {
+		resource := v.inventory[uri]
+		if requirement.MinVersion != "" {
+			_, version := getResourceType(resource)
+			switch {
+			case version == "":
+				v.record(uri, "", "MinVersion: "+requirement.MinVersion, resultNotTested, "the version of the resource is not known")
+			case compareVersions(version, requirement.MinVersion) < 0:
+				v.record(uri, "", "MinVersion: "+requirement.MinVersion, resultFail, "the version of the resource is "+version)
+			default:
+				v.record(uri, "", "MinVersion: "+requirement.MinVersion, resultPass, "")
+			}
+		}
+		v.validateProperties(uri, resource, "", resource, requirement.PropertyRequirements)
+		v.validateActions(uri, resourceType, resource, requirement.ActionRequirements)
+	}
+}
+
+// validateProperties evaluates the requirements of the properties of object, which is the resource or an object
+// property of the resource found at the JSON pointer path
+func (v *conformanceValidator) validateProperties(uri string, resource map[string]interface{}, path string,
+	object map[string]interface{}, requirements map[string]propertyRequirement) {
+	names := make([]string, 0, len(requirements))
+	for name := range requirements {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		requirement := requirements[name]
+		propertyPath := path + "/" + escapeJSONPointer(name)
+		value, present := object[name]
+		readRequirement := getRequirement(requirement.ReadRequirement, requirementMandatory)
+		writeRequirement := getRequirement(requirement.WriteRequirement, requirementNone)
+		var conditionMet bool
+		for _, condition := range requirement.ConditionalRequirements {
+			if !v.conditionMet(uri, object, condition) {
+				continue
+			}
+			// the strictest of the requirements met overrides the requirements of the property
+			if !conditionMet || requirementRanks[condition.ReadRequirement] > requirementRanks[readRequirement] {
+				readRequirement = getRequirement(condition.ReadRequirement, readRequirement)
+			}
+			if !conditionMet || requirementRanks[condition.WriteRequirement] > requirementRanks[writeRequirement] {
+				writeRequirement = getRequirement(condition.WriteRequirement, writeRequirement)
+			}
+			conditionMet = true
+		}
+		if readRequirement == requirementConditional && !conditionMet {
+			readRequirement = requirementNone
+		}
+
+		v.recordPresence(uri, propertyPath, "ReadRequirement: "+readRequirement, readRequirement, present, "the property is not found")
+		if requirementRanks[writeRequirement] > requirementRanks[requirementIfImplemented] {
+			v.validateWrite(uri, resource, propertyPath, name, present, writeRequirement)
+		}
+		if !present {
+			continue
+		}
+		if requirement.MinCount != nil {
+			count := -1
+			if members, ok := value.([]interface{}); ok {
+				count = len(members)
+			}
+			if count < *requirement.MinCount {
+				v.record(uri, propertyPath, "MinCount: "+strconv.Itoa(*requirement.MinCount), resultFail,
+					"the property has "+strconv.Itoa(count)+" members")
+			} else {
+				v.record(uri, propertyPath, "MinCount: "+strconv.Itoa(*requirement.MinCount), resultPass, "")
+			}
+		}
+		if requirement.Comparison != "" {
+			if compareValue(v.types, value, present, requirement.Comparison, requirement.Values) {
+				v.record(uri, propertyPath, "Comparison: "+requirement.Comparison, resultPass, "")
+			} else {
+				v.record(uri, propertyPath, "Comparison: "+requirement.Comparison, resultFail,
+					fmt.Sprintf("the value %v is not %s %v", value, requirement.Comparison, requirement.Values))
+			}
+		}
+		if len(requirement.PropertyRequirements) == 0 {
+			continue
+		}
+		switch nested := value.(type) {
+		case map[string]interface{}:
+			v.validateProperties(uri, resource, propertyPath, nested, requirement.PropertyRequirements)
+		case []interface{}:
+			for i, member := range nested {
+				if memberObject, ok := member.(map[string]interface{}); ok {
+					v.validateProperties(uri, resource, propertyPath+"/"+strconv.Itoa(i), memberObject, requirement.PropertyRequirements)
+				}
+			}
+		}
+	}
+}
+
+// validateWrite evaluates the write requirement of a property. The property is writable when the resource lists it
+// in @Redfish.WriteableProperties, without the annotation only a missing property is known not to be writable
+func (v *conformanceValidator) validateWrite(uri string, resource map[string]interface{}, propertyPath, name string,
+	present bool, writeRequirement string) {
+	operation := agmodel.OperationSupport{
+		Operation: "PATCH",
+		Resource:  uri,
+		Property:  propertyPath,
+		Support:   operationUnknown,
+	}
+	result := resultNotTested
+	writableProperties, annotated := resource["@Redfish.WriteableProperties"].([]interface{})
+	nested := strings.Count(propertyPath, "/") > 1
+	switch {
+	case !present:
+		operation.Support = operationNotSupported
+		operation.Message = "the property is not found"
+	case !annotated:
+		operation.Message = "the resource has no @Redfish.WriteableProperties annotation"
+	case nested:
+		operation.Message = "the writability of the nested properties is not published"
+	case containsValue(writableProperties, name):
+		operation.Support = operationSupported
+		result = resultPass
+	default:
+		operation.Support = operationNotSupported
+		operation.Message = "the property is not listed in @Redfish.WriteableProperties"
+	}
+	if operation.Support == operationNotSupported {
+		result = getUnmetResult(writeRequirement)
+	}
+	v.record(uri, propertyPath, "WriteRequirement: "+writeRequirement, result, operation.Message)
+	v.addOperation(operation)
+}
+
+// validateActions evaluates the action requirements of the resource. The allowable values of the parameters are read
+// from the @Redfish.AllowableValues annotations of the action or from its ActionInfo resource
+func (v *conformanceValidator) validateActions(uri, resourceType string, resource map[string]interface{},
+	requirements map[string]actionRequirement) {
+	names := make([]string, 0, len(requirements))
+	for name := range requirements {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	actions, _ := resource["Actions"].(map[string]interface{})
+	for _, name := range names {
+		requirement := requirements[name]
+		actionName := "#" + resourceType + "." + name
+		readRequirement := getRequirement(requirement.ReadRequirement, requirementMandatory)
+		operation := agmodel.OperationSupport{
+			Operation: actionName,
+			Resource:  uri,
+			Support:   operationNotSupported,
+		}
+		action, found := actions[actionName].(map[string]interface{})
+		v.recordPresence(uri, "/Actions/"+escapeJSONPointer(actionName), "ActionRequirement: "+readRequirement,
+			readRequirement, found, "the action is not found")
+		if !found {
+			operation.Message = "the action is not found"
+			v.addOperation(operation)
+			continue
+		}
+		operation.Support = operationSupported
+		parameterNames := make([]string, 0, len(requirement.Parameters))
+		for parameterName := range requirement.Parameters {
+			parameterNames = append(parameterNames, parameterName)
+		}
+		sort.Strings(parameterNames)
+		for _, parameterName := range parameterNames {
+			parameter := requirement.Parameters[parameterName]
+			property := "/Actions/" + escapeJSONPointer(actionName) + "/" + escapeJSONPointer(parameterName)
+			allowableValues, published := v.getAllowableValues(action, parameterName)
+			if published {
+				if operation.AllowableValues == nil {
+					operation.AllowableValues = make(map[string][]string)
+				}
+				operation.AllowableValues[parameterName] = allowableValues
+			}
+			requiredValues := append(append([]string{}, parameter.ParameterValues...), parameter.AllowableValues...)
+			parameterRequirement := getRequirement(parameter.ReadRequirement, requirementMandatory)
+			v.validateParameterValues(uri, property, "ParameterValues", requiredValues, allowableValues, published, getUnmetResult(parameterRequirement))
+			v.validateParameterValues(uri, property, "RecommendedValues", parameter.RecommendedValues, allowableValues, published, resultWarning)
+		}
+		v.addOperation(operation)
+	}
+}
+
+func (v *conformanceValidator) validateParameterValues(uri, property, requirement string, requiredValues, allowableValues []string,
+	published bool, unmetResult string) {
+	if len(requiredValues) == 0 {
+		return
+	}
+	requirement += ": " + strings.Join(requiredValues, ", ")
+	if !published {
+		v.record(uri, property, requirement, resultNotTested, "the allowable values of the parameter are not published")
+		return
+	}
+	var missingValues []string
+	for _, value := range requiredValues {
+		found := false
+		for _, allowableValue := range allowableValues {
+			if allowableValue == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missingValues = append(missingValues, value)
+		}
+	}
+	if len(missingValues) > 0 {
+		v.record(uri, property, requirement, unmetResult, "the parameter does not allow "+strings.Join(missingValues, ", "))
+		return
+	}
+	v.record(uri, property, requirement, resultPass, "")
+}
+
+func (v *conformanceValidator) getAllowableValues(action map[string]interface{}, parameterName string) ([]string, bool) {
+	if values, ok := action[parameterName+"@Redfish.AllowableValues"].([]interface{}); ok {
+		return toStrings(values), true
+	}
+	actionInfoURI, _ := action["@Redfish.ActionInfo"].(string)
+	actionInfo, ok := v.inventory[strings.TrimSuffix(actionInfoURI, "/")]
+	if !ok {
+		return nil, false
+	}
+	parameters, _ := actionInfo["Parameters"].([]interface{})
+	for _, parameter := range parameters {
+		parameterObject, _ := parameter.(map[string]interface{})
+		if parameterObject["Name"] != parameterName {
+			continue
+		}
+		if values, ok := parameterObject["AllowableValues"].([]interface{}); ok {
+			return toStrings(values), true
+		}
+	}
+	return nil, false
+}
+
+// conditionMet returns true when the resource is subordinate to the resources of the condition, and the
+// CompareProperty of object meets the comparison of the condition
+func (v *conformanceValidator) conditionMet(uri string, object map[string]interface{}, condition conditionalRequirement) bool {
+	if len(condition.SubordinateToResource) > 0 && !v.isSubordinateTo(uri, condition.SubordinateToResource) {
+		return false
+	}
+	if condition.CompareProperty == "" {
+		return true
+	}
+	values := condition.CompareValues
+	if values == nil {
+		values = condition.Values
+	}
+	comparison := condition.Comparison
+	if comparison == "" {
+		comparison = "AnyOf"
+	}
+	value, present := object[condition.CompareProperty]
+	return compareValue(v.types, value, present, comparison, values)
+}
+
+// isSubordinateTo returns true when the types of the parents of the resource, from the service root,
+// end with the given resource types
+func (v *conformanceValidator) isSubordinateTo(uri string, resourceTypes []string) bool {
+	var parentTypes []string
+	segments := strings.Split(strings.TrimPrefix(uri, "/redfish/v1/"), "/")
+	for i := 1; i < len(segments); i++ {
+		if parentType, ok := v.types["/redfish/v1/"+strings.Join(segments[:i], "/")]; ok {
+			parentTypes = append(parentTypes, parentType)
+		}
+	}
+	if len(parentTypes) < len(resourceTypes) {
+		return false
+	}
+	return reflect.DeepEqual(parentTypes[len(parentTypes)-len(resourceTypes):], resourceTypes)
+}
+
+// compareValue returns true when the value meets the comparison with the values of the profile
+func compareValue(types map[string]string, value interface{}, present bool, comparison string, values []interface{}) bool {
+	switch comparison {
+	case "Absent":
+		return !present
+	case "Present":
+		return present
+	}
+	if !present {
+		return false
+	}
+	members, isArray := value.([]interface{})
+	if !isArray {
+		members = []interface{}{value}
+	}
+	switch comparison {
+	case "Equal":
+		return len(values) > 0 && reflect.DeepEqual(value, values[0])
+	case "NotEqual":
+		return len(values) > 0 && !reflect.DeepEqual(value, values[0])
+	case "AnyOf":
+		for _, member := range members {
+			if containsValue(values, member) {
+				return true
+			}
+		}
+		return false
+	case "AllOf":
+		for _, required := range values {
+			if !containsValue(members, required) {
+				return false
+			}
+		}
+		return true
+	case "LinkToResource":
+		link, _ := value.(map[string]interface{})
+		linkURI, _ := link["@odata.id"].(string)
+		return containsValue(values, types[strings.TrimSuffix(linkURI, "/")])
+	case "GreaterThan", "GreaterThanOrEqual", "LessThan", "LessThanOrEqual":
+		number, ok := value.(float64)
+		if !ok || len(values) == 0 {
+			return false
+		}
+		limit, ok := values[0].(float64)
+		if !ok {
+			return false
+		}
+		switch comparison {
+		case "GreaterThan":
+			return number > limit
+		case "GreaterThanOrEqual":
+			return number >= limit
+		case "LessThan":
+			return number < limit
+		}
+		return number <= limit
+	}
+	return false
+}
+
+// recordPresence records the result of a read requirement of a resource, a property or an action,
+// only the Mandatory, Supported and Recommended requirements are recorded
+func (v *conformanceValidator) recordPresence(resource, property, requirementName, requirement string, present bool, message string) {
+	if requirementRanks[requirement] <= requirementRanks[requirementIfImplemented] {
+		return
+	}
+	if present {
+		v.record(resource, property, requirementName, resultPass, "")
+		return
+	}
+	v.record(resource, property, requirementName, getUnmetResult(requirement), message)
+}
+
+// record counts the result of a requirement, the requirements which are not met are added to the report
+func (v *conformanceValidator) record(resource, property, requirement, result, message string) {
+	switch result {
+	case resultPass:
+		v.report.Counts.Pass++
+		return
+	case resultWarning:
+		v.report.Counts.Warning++
+	case resultFail:
+		v.report.Counts.Fail++
+	default:
+		v.report.Counts.NotTested++
+	}
+	v.report.Results = append(v.report.Results, agmodel.RequirementResult{
+		Profile:     v.profile,
+		Resource:    resource,
+		Property:    property,
+		Requirement: requirement,
+		Result:      result,
+		Message:     message,
+	})
+}
+
+// addOperation adds the operation to the report once, when profiles require the same operation
+func (v *conformanceValidator) addOperation(operation agmodel.OperationSupport) {
+	key := operation.Operation + " " + operation.Resource + operation.Property
+	if v.operations[key] {
+		return
+	}
+	v.operations[key] = true
+	v.report.Operations = append(v.report.Operations, operation)
+}
+
+// getRequirement returns the requirement, or the default requirement when the profile has no requirement
+func getRequirement(requirement, defaultRequirement string) string {
+	if requirement == "" {
+		return defaultRequirement
+	}
+	return requirement
+}
+
+// getUnmetResult returns Fail for the Mandatory and Supported requirements which are not met, and Warning for
+// the Recommended ones
+func getUnmetResult(requirement string) string {
+	if requirementRanks[requirement] > requirementRanks[requirementRecommended] {
+		return resultFail
+	}
+	return resultWarning
+}
+
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, member := range values {
+		if reflect.DeepEqual(member, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func toStrings(values []interface{}) []string {
+	strs := make([]string, 0, len(values))
+	for _, value := range values {
+		strs = append(strs, fmt.Sprint(value))
+	}
+	return strs
+}
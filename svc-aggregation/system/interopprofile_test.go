@@ -0,0 +1,314 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+)
+
+const (
+	mockProfileSystemURI  = "/redfish/v1/Systems/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1"
+	mockProfileChassisURI = "/redfish/v1/Chassis/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1"
+	mockProfileManagerURI = "/redfish/v1/Managers/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1"
+)
+
+var mockBaseProfile = `{
+	"SchemaDefinition": "RedfishInteroperabilityProfile.v1_3_0",
+	"ProfileName": "MockBase",
+	"ProfileVersion": "1.1.0",
+	"Resources": {
+		"ServiceRoot": {"PropertyRequirements": {"Systems": {}}},
+		"Manager": {
+			"PropertyRequirements": {
+				"FirmwareVersion": {},
+				"NetworkProtocol": {"ReadRequirement": "Recommended"}
+			}
+		},
+		"EthernetInterface": {
+			"ReadRequirement": "Recommended",
+			"ConditionalRequirements": [{"SubordinateToResource": ["Manager", "EthernetInterfaceCollection"], "ReadRequirement": "Mandatory"}],
+			"PropertyRequirements": {
+				"HostName": {
+					"ReadRequirement": "IfImplemented",
+					"ConditionalRequirements": [{"SubordinateToResource": ["Manager", "EthernetInterfaceCollection"], "ReadRequirement": "Mandatory"}]
+				}
+			}
+		}
+	}
+}`
+
+var mockServerProfile = `{
+	"SchemaDefinition": "RedfishInteroperabilityProfile.v1_3_0",
+	"ProfileName": "MockServer",
+	"ProfileVersion": "1.0.0",
+	"RequiredProfiles": {"MockBase": {"MinVersion": "1.0.0"}},
+	"Resources": {
+		"ComputerSystem": {
+			"MinVersion": "1.12.0",
+			"PropertyRequirements": {
+				"AssetTag": {"WriteRequirement": "Mandatory"},
+				"SKU": {
+					"ReadRequirement": "Recommended",
+					"ConditionalRequirements": [{"CompareProperty": "PartNumber", "Comparison": "Absent", "ReadRequirement": "Mandatory"}]
+				},
+				"PartNumber": {
+					"ReadRequirement": "Recommended",
+					"ConditionalRequirements": [{"CompareProperty": "SKU", "Comparison": "Absent", "ReadRequirement": "Mandatory"}]
+				},
+				"IndicatorLED": {
+					"ReadRequirement": "Recommended",
+					"ConditionalRequirements": [{"CompareProperty": "SystemType", "Comparison": "AnyOf", "Values": ["Physical"], "ReadRequirement": "Mandatory", "WriteRequirement": "Mandatory"}]
+				},
+				"Boot": {
+					"PropertyRequirements": {
+						"BootSourceOverrideMode": {"ReadRequirement": "Recommended"},
+						"UefiTargetBootSourceOverride": {
+							"ReadRequirement": "Recommended",
+							"ConditionalRequirements": [{"CompareProperty": "BootSourceOverrideMode", "Comparison": "Equal", "Values": ["UEFI"], "ReadRequirement": "Mandatory"}]
+						}
+					}
+				},
+				"Links": {"PropertyRequirements": {"Chassis": {"MinCount": 1}}},
+				"LogServices": {"ReadRequirement": "Recommended"}
+			},
+			"ActionRequirements": {
+				"Reset": {
+					"ReadRequirement": "Mandatory",
+					"Parameters": {"ResetType": {"AllowableValues": ["ForceOff", "On", "ForceRestart"], "ReadRequirement": "Mandatory"}}
+				}
+			}
+		},
+		"Chassis": {"MinVersion": "1.13.0", "PropertyRequirements": {"ChassisType": {}}},
+		"Drive": {"ReadRequirement": "Recommended"},
+		"Power": {"ReadRequirement": "Mandatory"}
+	}
+}`
+
+func writeMockProfiles(t *testing.T) string {
+	dir := t.TempDir()
+	for name, profile := range map[string]string{
+		"MockBase.v1_0_0.json":   `{"ProfileName": "MockBase", "ProfileVersion": "1.0.0", "Resources": {}}`,
+		"MockBase.v1_1_0.json":   mockBaseProfile,
+		"MockServer.v1_0_0.json": mockServerProfile,
+		"MockCyclic.v1_0_0.json": `{"ProfileName": "MockCyclic", "RequiredProfiles": {"MockCyclic": {}}, "Resources": {}}`,
+		"MockBroken.v1_0_0.json": `{"ProfileName": "MockBroken", "RequiredProfiles": {"MockBase": {"MinVersion": "2.0.0"}}}`,
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(profile), 0644); err != nil {
+			t.Fatalf("error while writing the mock profile: %v", err)
+		}
+	}
+	return dir
+}
+
+func getMockProfileInventory() map[string]map[string]interface{} {
+	inventory := map[string]string{
+		mockProfileSystemURI: `{
+			"@odata.id": "` + mockProfileSystemURI + `",
+			"@odata.type": "#ComputerSystem.v1_12_0.ComputerSystem",
+			"@Redfish.WriteableProperties": ["AssetTag"],
+			"AssetTag": "",
+			"SKU": "867959-B21",
+			"SystemType": "Physical",
+			"IndicatorLED": "Off",
+			"Boot": {"BootSourceOverrideMode": "UEFI"},
+			"Links": {"Chassis": [{"@odata.id": "` + mockProfileChassisURI + `"}]},
+			"Actions": {
+				"#ComputerSystem.Reset": {
+					"target": "` + mockProfileSystemURI + `/Actions/ComputerSystem.Reset",
+					"ResetType@Redfish.AllowableValues": ["On", "ForceOff", "GracefulShutdown"]
+				}
+			}
+		}`,
+		mockProfileChassisURI: `{
+			"@odata.id": "` + mockProfileChassisURI + `",
+			"@odata.type": "#Chassis.v1_10_0.Chassis",
+			"ChassisType": "RackMount"
+		}`,
+		mockProfileChassisURI + "/Power": `{
+			"@odata.id": "` + mockProfileChassisURI + `/Power",
+			"@odata.type": "#Power.v1_5_2.Power"
+		}`,
+		mockProfileManagerURI: `{
+			"@odata.id": "` + mockProfileManagerURI + `",
+			"@odata.type": "#Manager.v1_10_0.Manager",
+			"FirmwareVersion": "2.44"
+		}`,
+		mockProfileManagerURI + "/EthernetInterfaces": `{
+			"@odata.id": "` + mockProfileManagerURI + `/EthernetInterfaces",
+			"@odata.type": "#EthernetInterfaceCollection.EthernetInterfaceCollection"
+		}`,
+		mockProfileManagerURI + "/EthernetInterfaces/1": `{
+			"@odata.id": "` + mockProfileManagerURI + `/EthernetInterfaces/1",
+			"@odata.type": "#EthernetInterface.v1_4_1.EthernetInterface"
+		}`,
+		mockProfileSystemURI + "/EthernetInterfaces/1": `{
+			"@odata.id": "` + mockProfileSystemURI + `/EthernetInterfaces/1",
+			"@odata.type": "#EthernetInterface.v1_4_1.EthernetInterface"
+		}`,
+	}
+	resources := make(map[string]map[string]interface{}, len(inventory))
+	for uri, data := range inventory {
+		var resource map[string]interface{}
+		json.Unmarshal([]byte(data), &resource)
+		resources[uri] = resource
+	}
+	return resources
+}
+
+func TestLoadInteropProfiles(t *testing.T) {
+	dir := writeMockProfiles(t)
+	profiles, err := loadInteropProfiles(dir, "MockServer")
+	if err != nil {
+		t.Fatalf("loadInteropProfiles() error = %v", err)
+	}
+	var loaded []agmodel.InteropProfile
+	for _, profile := range profiles {
+		loaded = append(loaded, agmodel.InteropProfile{Name: profile.Name, Version: profile.Version})
+	}
+	want := []agmodel.InteropProfile{{Name: "MockServer", Version: "1.0.0"}, {Name: "MockBase", Version: "1.1.0"}}
+	if !reflect.DeepEqual(loaded, want) {
+		t.Errorf("loadInteropProfiles() = %v, want the profile followed by the latest version of the required profile %v", loaded, want)
+	}
+	if profiles, err := loadInteropProfiles(dir, "MockCyclic"); err != nil || len(profiles) != 1 {
+		t.Errorf("loadInteropProfiles() of a profile requiring itself = %v, %v, want the profile once", profiles, err)
+	}
+	if _, err := loadInteropProfiles(dir, "Unknown"); err == nil {
+		t.Error("loadInteropProfiles() of an unknown profile succeeded, want an error")
+	} else if _, ok := err.(*profileNotFoundError); !ok {
+		t.Errorf("loadInteropProfiles() of an unknown profile error = %v, want profileNotFoundError", err)
+	}
+	if _, err := loadInteropProfiles(dir, "MockBroken"); err == nil {
+		t.Error("loadInteropProfiles() without the required version of a required profile succeeded, want an error")
+	}
+}
+
+func TestLoadInteropProfiles_ShippedProfiles(t *testing.T) {
+	for _, name := range []string{"ODIMBaselineHardwareManagement", "ODIMServerHardwareManagement", "ODIMFabricManagement"} {
+		profiles, err := loadInteropProfiles("../../odim-profiles", name)
+		if err != nil {
+			t.Errorf("loadInteropProfiles(%s) error = %v", name, err)
+			continue
+		}
+		if len(profiles[0].Resources) == 0 {
+			t.Errorf("loadInteropProfiles(%s) has no resource requirements", name)
+		}
+	}
+}
+
+func TestConformanceValidator_Validate(t *testing.T) {
+	profiles, err := loadInteropProfiles(writeMockProfiles(t), "MockServer")
+	if err != nil {
+		t.Fatalf("loadInteropProfiles() error = %v", err)
+	}
+	report := newConformanceValidator(getMockProfileInventory()).validate(profiles)
+	if report.Result != resultFail {
+		t.Errorf("validate() result = %v, want %v", report.Result, resultFail)
+	}
+	results := make(map[string]string)
+	for _, result := range report.Results {
+		results[result.Profile+" "+result.Resource+" "+result.Property+" "+result.Requirement] = result.Result
+	}
+	wantResults := map[string]string{
+		// PartNumber is absent, so SKU is mandatory and present, and PartNumber stays recommended
+		"MockServer " + mockProfileSystemURI + " /PartNumber ReadRequirement: Recommended": resultWarning,
+		// BootSourceOverrideMode is UEFI, so the UEFI target is mandatory
+		"MockServer " + mockProfileSystemURI + " /Boot/UefiTargetBootSourceOverride ReadRequirement: Mandatory": resultFail,
+		// SystemType is Physical, so the IndicatorLED must be writable
+		"MockServer " + mockProfileSystemURI + " /IndicatorLED WriteRequirement: Mandatory":                                            resultFail,
+		"MockServer " + mockProfileSystemURI + " /Actions/#ComputerSystem.Reset/ResetType ParameterValues: ForceOff, On, ForceRestart": resultFail,
+		"MockServer " + mockProfileSystemURI + " /LogServices ReadRequirement: Recommended":                                            resultWarning,
+		"MockServer " + mockProfileChassisURI + "  MinVersion: 1.13.0":                                                                 resultFail,
+		"MockServer Drive  ReadRequirement: Recommended":                                                                               resultWarning,
+		"MockBase " + mockProfileManagerURI + " /NetworkProtocol ReadRequirement: Recommended":                                         resultWarning,
+		// only the EthernetInterface of the manager has the mandatory HostName
+		"MockBase " + mockProfileManagerURI + "/EthernetInterfaces/1 /HostName ReadRequirement: Mandatory": resultFail,
+	}
+	if !reflect.DeepEqual(results, wantResults) {
+		t.Errorf("validate() results = %v, want %v", results, wantResults)
+	}
+	wantCounts := agmodel.ConformanceCounts{Pass: 18, Warning: 4, Fail: 5}
+	if report.Counts != wantCounts {
+		t.Errorf("validate() counts = %+v, want %+v", report.Counts, wantCounts)
+	}
+	wantOperations := []agmodel.OperationSupport{
+		{Operation: "PATCH", Resource: mockProfileSystemURI, Property: "/AssetTag", Support: operationSupported},
+		{Operation: "PATCH", Resource: mockProfileSystemURI, Property: "/IndicatorLED", Support: operationNotSupported,
+			Message: "the property is not listed in @Redfish.WriteableProperties"},
+		{Operation: "#ComputerSystem.Reset", Resource: mockProfileSystemURI, Support: operationSupported,
+			AllowableValues: map[string][]string{"ResetType": {"On", "ForceOff", "GracefulShutdown"}}},
+	}
+	if !reflect.DeepEqual(report.Operations, wantOperations) {
+		t.Errorf("validate() operations = %+v, want %+v", report.Operations, wantOperations)
+	}
+	wantProfiles := []agmodel.InteropProfile{{Name: "MockServer", Version: "1.0.0"}, {Name: "MockBase", Version: "1.1.0"}}
+	if !reflect.DeepEqual(report.Profiles, wantProfiles) {
+		t.Errorf("validate() profiles = %v, want %v", report.Profiles, wantProfiles)
+	}
+}
+
+func TestCompareValue(t *testing.T) {
+	types := map[string]string{mockProfileChassisURI: "Chassis"}
+	tests := []struct {
+		name       string
+		value      interface{}
+		present    bool
+		comparison string
+		values     []interface{}
+		want       bool
+	}{
+		{"absent property", nil, false, "Absent", nil, true},
+		{"present property", "On", true, "Absent", nil, false},
+		{"equal value", "UEFI", true, "Equal", []interface{}{"UEFI"}, true},
+		{"not equal value", "Legacy", true, "NotEqual", []interface{}{"UEFI"}, true},
+		{"any of the values", "Physical", true, "AnyOf", []interface{}{"Virtual", "Physical"}, true},
+		{"array with any of the values", []interface{}{"PXE", "Hdd"}, true, "AnyOf", []interface{}{"Hdd"}, true},
+		{"array without all of the values", []interface{}{"PXE"}, true, "AllOf", []interface{}{"PXE", "Hdd"}, false},
+		{"greater number", float64(2), true, "GreaterThanOrEqual", []interface{}{float64(2)}, true},
+		{"smaller number", float64(1), true, "GreaterThan", []interface{}{float64(2)}, false},
+		{"link to the resource", map[string]interface{}{"@odata.id": mockProfileChassisURI}, true, "LinkToResource", []interface{}{"Chassis"}, true},
+		{"absent property with values", nil, false, "AnyOf", []interface{}{"On"}, false},
+		{"unknown comparison", "On", true, "Unknown", []interface{}{"On"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := compareValue(types, tt.value, tt.present, tt.comparison, tt.values); got != tt.want {
+				t.Errorf("compareValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		first, second string
+		want          int
+	}{
+		{"1.12.0", "1.12.0", 0},
+		{"v1_12_0", "1.12", 0},
+		{"1.9.0", "1.12.0", -1},
+		{"1.13.0", "1.12.1", 1},
+	}
+	for _, tt := range tests {
+		if got := compareVersions(tt.first, tt.second); got != tt.want {
+			t.Errorf("compareVersions(%v, %v) = %v, want %v", tt.first, tt.second, got, tt.want)
+		}
+	}
+}
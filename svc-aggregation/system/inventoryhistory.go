@@ -0,0 +1,453 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agresponse"
+	"github.com/google/uuid"
+)
+
+// InventoryHistoryURI is the URI of the collection of the inventory histories of the systems
+const InventoryHistoryURI = "/redfish/v1/AggregationService/Oem/Odim/InventoryHistory"
+
+const (
+	inventoryHistoryTable = "InventoryHistory"
+
+	changeTypeAdded    = "Added"
+	changeTypeRemoved  = "Removed"
+	changeTypeModified = "Modified"
+)
+
+// volatileProperties are the properties which change on every read of a resource,
+// like sensor readings, the changes of them are not recorded
+var volatileProperties = map[string]bool{
+	"@odata.etag":        true,
+	"Reading":            true,
+	"ReadingCelsius":     true,
+	"ReadingRPM":         true,
+	"ReadingVolts":       true,
+	"ReadingTime":        true,
+	"PowerConsumedWatts": true,
+	"PowerMetrics":       true,
+	"LastResetTime":      true,
+	"SensorResetTime":    true,
+}
+
+// recordInventoryChanges compares the inventory of the BMC read before the rediscovery with the rediscovered
+// inventory, appends the changes to the inventory history of the system and publishes a ResourceChanged event
+// for each changed resource. Only the resources under scopeURI are compared when scopeURI is not empty
+func (e *ExternalInterface) recordInventoryChanges(ctx context.Context, deviceUUID, systemURL, scopeURI string, oldInventory map[string]string) {
+	if len(oldInventory) == 0 {
+		// the BMC is discovered for the first time or the in-memory DB lost the inventory,
+		// there is nothing to compare with
+		return
+	}
+	systemID := systemURL[strings.LastIndexByte(systemURL, '/')+1:]
+	newInventory, err := e.GetDeviceInventory(deviceUUID)
+	if err != nil {
+		l.LogWithFields(ctx).Error("unable to read the rediscovered inventory of " + systemID + ": " + err.Error())
+		return
+	}
+	changes := diffInventory(decodeInventory(ctx, oldInventory, scopeURI), decodeInventory(ctx, newInventory, scopeURI))
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	history, err := e.GetInventoryHistoryInfo(systemID)
+	if err != nil {
+		if err.ErrNo() != errors.DBKeyNotFound {
+			l.LogWithFields(ctx).Error("unable to read the inventory history of " + systemID + ": " + err.Error())
+			return
+		}
+		// the inventory is known from the first rediscovery
+		history = agmodel.InventoryHistory{StartTime: now}
+	} else if len(changes) == 0 {
+		return
+	}
+	if len(changes) > 0 {
+		history.Entries = append(history.Entries, agmodel.InventoryHistoryEntry{Timestamp: now, Changes: changes})
+		config.TLSConfMutex.RLock()
+		limit := config.Data.InventoryHistoryLimit
+		config.TLSConfMutex.RUnlock()
+		if dropped := len(history.Entries) - limit; limit > 0 && dropped > 0 {
+			// the inventory before the newest dropped entry can't be rebuilt anymore
+			history.StartTime = history.Entries[dropped-1].Timestamp
+			history.Entries = history.Entries[dropped:]
+		}
+	}
+	if err := e.SaveInventoryHistory(history, systemID); err != nil {
+		l.LogWithFields(ctx).Error("unable to save the inventory history of " + systemID + ": " + err.Error())
+		return
+	}
+	if len(changes) > 0 {
+		l.LogWithFields(ctx).Infof("recorded %d inventory changes of %s", len(changes), systemID)
+		e.publishInventoryChanges(ctx, changes)
+	}
+}
+
+// publishInventoryChanges publishes a ResourceChanged event carrying the change for each changed resource
+func (e *ExternalInterface) publishInventoryChanges(ctx context.Context, changes []agmodel.InventoryChange) {
+	eventTime := time.Now().Format(time.RFC3339)
+	var collectionTypes []string
+	events := make(map[string][]common.Event)
+	for _, change := range changes {
+		collectionType := getCollectionType(change.Resource)
+		if collectionType == "" {
+			continue
+		}
+		if _, ok := events[collectionType]; !ok {
+			collectionTypes = append(collectionTypes, collectionType)
+		}
+		// the removed resource is not sent with the event, it can be read from the inventory history
+		change.OldResource = nil
+		events[collectionType] = append(events[collectionType], common.Event{
+			EventType:         "ResourceUpdated",
+			EventID:           uuid.New().String(),
+			Severity:          "OK",
+			EventTimestamp:    eventTime,
+			Message:           "One or more resource properties have changed.",
+			MessageID:         "ResourceEvent.1.2.0.ResourceChanged",
+			OriginOfCondition: &common.Link{Oid: change.Resource},
+			Oem: map[string]interface{}{
+				"Odim": map[string]interface{}{
+					"InventoryChange": change,
+				},
+			},
+		})
+	}
+	for _, collectionType := range collectionTypes {
+		e.PublishEvents(ctx, collectionType, events[collectionType])
+	}
+}
+
+// getCollectionType returns the collection type of the events of the resource
+func getCollectionType(resourceURI string) string {
+	switch {
+	case strings.HasPrefix(resourceURI, "/redfish/v1/Systems/"):
+		return "SystemsCollection"
+	case strings.HasPrefix(resourceURI, "/redfish/v1/Chassis/"):
+		return "ChassisCollection"
+	case strings.HasPrefix(resourceURI, "/redfish/v1/Managers/"):
+		return "ManagerCollection"
+	}
+	return ""
+}
+
+// decodeInventory decodes the resources of the inventory under scopeURI
+func decodeInventory(ctx context.Context, inventory map[string]string, scopeURI string) map[string]interface{} {
+	resources := make(map[string]interface{}, len(inventory))
+	for uri, data := range inventory {
+		if scopeURI != "" && uri != scopeURI && !strings.HasPrefix(uri, scopeURI+"/") {
+			continue
+		}
+		var resource interface{}
+		if err := json.Unmarshal([]byte(data), &resource); err != nil {
+			l.LogWithFields(ctx).Warn("unable to unmarshal the resource " + uri + ": " + err.Error())
+			continue
+		}
+		resources[uri] = resource
+	}
+	return resources
+}
+
+// diffInventory returns the changes of the resources from oldInventory to newInventory, sorted by resource URI
+func diffInventory(oldInventory, newInventory map[string]interface{}) []agmodel.InventoryChange {
+	var changes []agmodel.InventoryChange
+	for _, uri := range sortedKeys(oldInventory, newInventory) {
+		oldResource, inOld := oldInventory[uri]
+		newResource, inNew := newInventory[uri]
+		switch {
+		case !inOld:
+			changes = append(changes, agmodel.InventoryChange{Resource: uri, ChangeType: changeTypeAdded})
+		case !inNew:
+			changes = append(changes, agmodel.InventoryChange{Resource: uri, ChangeType: changeTypeRemoved, OldResource: oldResource})
+		default:
+			if properties := diffProperties("", oldResource, newResource, nil); len(properties) > 0 {
+				changes = append(changes, agmodel.InventoryChange{Resource: uri, ChangeType: changeTypeModified, Properties: properties})
+			}
+		}
+	}
+	return changes
+}
+
+// diffProperties appends the changes of the properties from oldValue to newValue at the path to changes.
+// The objects are compared property by property, any other value including an array is compared as a whole
+func diffProperties(path string, oldValue, newValue interface{}, changes []agmodel.PropertyChange) []agmodel.PropertyChange {
+	oldObject, oldIsObject := oldValue.(map[string]interface{})
+	newObject, newIsObject := newValue.(map[string]interface{})
+	if !oldIsObject || !newIsObject {
+		if !reflect.DeepEqual(oldValue, newValue) {
+			changes = append(changes, agmodel.PropertyChange{Path: path, ChangeType: changeTypeModified, OldValue: oldValue, NewValue: newValue})
+		}
+		return changes
+	}
+	for _, key := range sortedKeys(oldObject, newObject) {
+		if volatileProperties[key] {
+			continue
+		}
+		propertyPath := path + "/" + escapeJSONPointer(key)
+		oldProperty, inOld := oldObject[key]
+		newProperty, inNew := newObject[key]
+		switch {
+		case !inOld:
+			changes = append(changes, agmodel.PropertyChange{Path: propertyPath, ChangeType: changeTypeAdded, NewValue: newProperty})
+		case !inNew:
+			changes = append(changes, agmodel.PropertyChange{Path: propertyPath, ChangeType: changeTypeRemoved, OldValue: oldProperty})
+		default:
+			changes = diffProperties(propertyPath, oldProperty, newProperty, changes)
+		}
+	}
+	return changes
+}
+
+// revertChanges reverts the changes in the inventory, the inventory is modified in place
+func revertChanges(inventory map[string]interface{}, changes []agmodel.InventoryChange) {
+	for _, change := range changes {
+		switch change.ChangeType {
+		case changeTypeAdded:
+			delete(inventory, change.Resource)
+		case changeTypeRemoved:
+			inventory[change.Resource] = copyValue(change.OldResource)
+		case changeTypeModified:
+			resource := inventory[change.Resource]
+			for _, property := range change.Properties {
+				resource = revertProperty(resource, property)
+			}
+			inventory[change.Resource] = resource
+		}
+	}
+}
+
+// revertProperty reverts the change of the property in the resource and returns the resource
+func revertProperty(resource interface{}, property agmodel.PropertyChange) interface{} {
+	if property.Path == "" {
+		return copyValue(property.OldValue)
+	}
+	tokens := strings.Split(strings.TrimPrefix(property.Path, "/"), "/")
+	object, ok := resource.(map[string]interface{})
+	if !ok {
+		object = make(map[string]interface{})
+		resource = object
+	}
+	for _, token := range tokens[:len(tokens)-1] {
+		token = unescapeJSONPointer(token)
+		child, ok := object[token].(map[string]interface{})
+		if !ok {
+			child = make(map[string]interface{})
+			object[token] = child
+		}
+		object = child
+	}
+	key := unescapeJSONPointer(tokens[len(tokens)-1])
+	if property.ChangeType == changeTypeAdded {
+		delete(object, key)
+	} else {
+		object[key] = copyValue(property.OldValue)
+	}
+	return resource
+}
+
+// copyValue returns a deep copy of a decoded JSON value, so that reverting the older changes
+// doesn't modify the values held by the history
+func copyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		object := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			object[key] = copyValue(child)
+		}
+		return object
+	case []interface{}:
+		array := make([]interface{}, len(v))
+		for i, child := range v {
+			array[i] = copyValue(child)
+		}
+		return array
+	}
+	return value
+}
+
+// escapeJSONPointer escapes a property name as a JSON pointer reference token
+func escapeJSONPointer(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
+
+// unescapeJSONPointer returns the property name of a JSON pointer reference token
+func unescapeJSONPointer(token string) string {
+	return strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+}
+
+// sortedKeys returns the keys of both the maps, sorted
+func sortedKeys(first, second map[string]interface{}) []string {
+	keys := make([]string, 0, len(first)+len(second))
+	for key := range first {
+		keys = append(keys, key)
+	}
+	for key := range second {
+		if _, ok := first[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// inventoryAsOf rebuilds the inventory at the given time by reverting the changes of the history entries
+// recorded after it, newest first, from the current inventory
+func inventoryAsOf(history agmodel.InventoryHistory, current map[string]interface{}, asOf time.Time) map[string]interface{} {
+	inventory := make(map[string]interface{}, len(current))
+	for uri, resource := range current {
+		inventory[uri] = resource
+	}
+	for i := len(history.Entries) - 1; i >= 0; i-- {
+		timestamp, err := time.Parse(time.RFC3339, history.Entries[i].Timestamp)
+		if err == nil && !timestamp.After(asOf) {
+			break
+		}
+		revertChanges(inventory, history.Entries[i].Changes)
+	}
+	return inventory
+}
+
+// GetInventoryHistory is the handler for getting the inventory history of a system.
+// With the AsOf query it returns the inventory of the system at the time, and with the From
+// and To queries it returns the changes of the inventory between the times
+func (e *ExternalInterface) GetInventoryHistory(ctx context.Context, reqURI string) response.RPC {
+	requestURL, parseErr := url.Parse(reqURI)
+	if parseErr != nil {
+		errorMessage := "error while trying to parse the request URI " + reqURI + ": " + parseErr.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errorMessage, nil, nil)
+	}
+	uri := strings.TrimSuffix(requestURL.Path, "/")
+	systemID := strings.TrimPrefix(uri, InventoryHistoryURI+"/")
+	query := requestURL.Query()
+	for param := range query {
+		switch param {
+		case "AsOf", "From", "To":
+		default:
+			errorMessage := "error: query parameter " + param + " is not supported"
+			l.LogWithFields(ctx).Error(errorMessage)
+			return common.GeneralError(http.StatusBadRequest, response.QueryNotSupported, errorMessage, nil, nil)
+		}
+	}
+	asOf, from, to := query.Get("AsOf"), query.Get("From"), query.Get("To")
+	if asOf != "" && (from != "" || to != "") {
+		errorMessage := "error: AsOf query can't be combined with From and To queries"
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.QueryCombinationInvalid, errorMessage, nil, nil)
+	}
+
+	history, err := e.GetInventoryHistoryInfo(systemID)
+	if err != nil {
+		errorMessage := err.Error()
+		l.LogWithFields(ctx).Error("Unable to get inventory history : " + errorMessage)
+		if errors.DBKeyNotFound == err.ErrNo() {
+			return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errorMessage, []interface{}{"InventoryHistory", systemID}, nil)
+		}
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	commonResponse := response.Response{
+		OdataType:    "#OdimInventoryHistory.v1_0_0.OdimInventoryHistory",
+		OdataID:      uri,
+		OdataContext: "/redfish/v1/$metadata#OdimInventoryHistory.OdimInventoryHistory",
+		ID:           systemID,
+		Name:         "Inventory History",
+	}
+	commonResponse.CreateGenericResponse(response.Success)
+	commonResponse.Message = ""
+	commonResponse.MessageID = ""
+	commonResponse.Severity = ""
+	resp := agresponse.InventoryHistoryResponse{
+		Response:  commonResponse,
+		StartTime: history.StartTime,
+	}
+	if asOf == "" && from == "" && to == "" {
+		resp.Entries = history.Entries
+		return response.RPC{StatusCode: http.StatusOK, StatusMessage: response.Success, Body: resp}
+	}
+
+	startTime, _ := time.Parse(time.RFC3339, history.StartTime)
+	parseTime := func(param, value, defaultValue string) (time.Time, *response.RPC) {
+		if value == "" {
+			value = defaultValue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			errorMessage := "error: " + param + " must be an RFC 3339 timestamp: " + err.Error()
+			l.LogWithFields(ctx).Error(errorMessage)
+			resp := common.GeneralError(http.StatusBadRequest, response.QueryParameterValueFormatError, errorMessage, []interface{}{value, param}, nil)
+			return t, &resp
+		}
+		if t.Before(startTime) {
+			errorMessage := "error: " + param + " is before the start of the inventory history " + history.StartTime
+			l.LogWithFields(ctx).Error(errorMessage)
+			resp := common.GeneralError(http.StatusBadRequest, response.QueryParameterOutOfRange, errorMessage, []interface{}{value, param, history.StartTime + " onwards"}, nil)
+			return t, &resp
+		}
+		return t, nil
+	}
+	var asOfTime, fromTime, toTime time.Time
+	var errResp *response.RPC
+	if asOf != "" {
+		asOfTime, errResp = parseTime("AsOf", asOf, "")
+	} else {
+		fromTime, errResp = parseTime("From", from, history.StartTime)
+		if errResp == nil {
+			toTime, errResp = parseTime("To", to, time.Now().UTC().Format(time.RFC3339))
+		}
+		if errResp == nil && toTime.Before(fromTime) {
+			errorMessage := "error: To " + to + " is before From " + from
+			l.LogWithFields(ctx).Error(errorMessage)
+			resp := common.GeneralError(http.StatusBadRequest, response.QueryParameterOutOfRange, errorMessage, []interface{}{to, "To", fromTime.Format(time.RFC3339) + " onwards"}, nil)
+			errResp = &resp
+		}
+	}
+	if errResp != nil {
+		return *errResp
+	}
+
+	deviceUUID := strings.SplitN(systemID, ".", 2)[0]
+	currentInventory, err := e.GetDeviceInventory(deviceUUID)
+	if err != nil {
+		errorMessage := "error while trying to read the inventory of " + systemID + ": " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	if asOf != "" {
+		resp.AsOf = asOfTime.UTC().Format(time.RFC3339)
+		resp.Inventory = inventoryAsOf(history, decodeInventory(ctx, currentInventory, ""), asOfTime)
+	} else {
+		resp.From = fromTime.UTC().Format(time.RFC3339)
+		resp.To = toTime.UTC().Format(time.RFC3339)
+		// the inventories are rebuilt from separate decodes since the reverts modify the resources in place
+		resp.Changes = diffInventory(inventoryAsOf(history, decodeInventory(ctx, currentInventory, ""), fromTime),
+			inventoryAsOf(history, decodeInventory(ctx, currentInventory, ""), toTime))
+	}
+	return response.RPC{StatusCode: http.StatusOK, StatusMessage: response.Success, Body: resp}
+}
@@ -0,0 +1,225 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agresponse"
+)
+
+const (
+	mockHistorySystemID  = "7a2c6100-67da-5fd6-ab82-6870d29c7279.1"
+	mockHistorySystemURI = "/redfish/v1/Systems/" + mockHistorySystemID
+	mockHistoryMemoryURI = mockHistorySystemURI + "/Memory/DIMM1"
+	mockHistoryThermal   = "/redfish/v1/Chassis/7a2c6100-67da-5fd6-ab82-6870d29c7279.1/Thermal"
+)
+
+var (
+	mockOldInventory = map[string]string{
+		mockHistorySystemURI: `{"@odata.id":"` + mockHistorySystemURI + `","BiosVersion":"U30 v2.50","Boot":{"BootOrder":["Pxe","Hdd"]},"Oem":{"a/b":1}}`,
+		mockHistoryMemoryURI: `{"@odata.id":"` + mockHistoryMemoryURI + `","CapacityMiB":32768}`,
+		mockHistoryThermal:   `{"Temperatures":[{"ReadingCelsius":40}],"Status":{"Health":"OK"}}`,
+	}
+	mockNewInventory = map[string]string{
+		mockHistorySystemURI:                   `{"@odata.id":"` + mockHistorySystemURI + `","BiosVersion":"U30 v2.60","Boot":{"BootOrder":["Hdd","Pxe"]},"Oem":{"a/b":1,"c~d":true}}`,
+		mockHistoryThermal:                     `{"Temperatures":[{"ReadingCelsius":40}],"Status":{"Health":"Warning"},"ReadingCelsius":42}`,
+		mockHistorySystemURI + "/Memory/DIMM2": `{"CapacityMiB":65536}`,
+	}
+)
+
+func TestDiffAndRevertInventory(t *testing.T) {
+	ctx := context.Background()
+	changes := diffInventory(decodeInventory(ctx, mockOldInventory, ""), decodeInventory(ctx, mockNewInventory, ""))
+	want := []agmodel.InventoryChange{
+		{Resource: mockHistoryThermal, ChangeType: changeTypeModified, Properties: []agmodel.PropertyChange{
+			{Path: "/Status/Health", ChangeType: changeTypeModified, OldValue: "OK", NewValue: "Warning"},
+		}},
+		{Resource: mockHistorySystemURI, ChangeType: changeTypeModified, Properties: []agmodel.PropertyChange{
+			{Path: "/BiosVersion", ChangeType: changeTypeModified, OldValue: "U30 v2.50", NewValue: "U30 v2.60"},
+			{Path: "/Boot/BootOrder", ChangeType: changeTypeModified, OldValue: []interface{}{"Pxe", "Hdd"}, NewValue: []interface{}{"Hdd", "Pxe"}},
+			{Path: "/Oem/c~0d", ChangeType: changeTypeAdded, NewValue: true},
+		}},
+		{Resource: mockHistoryMemoryURI, ChangeType: changeTypeRemoved, OldResource: map[string]interface{}{
+			"@odata.id": mockHistoryMemoryURI, "CapacityMiB": float64(32768),
+		}},
+		{Resource: mockHistorySystemURI + "/Memory/DIMM2", ChangeType: changeTypeAdded},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Fatalf("diffInventory() = %+v, want %+v", changes, want)
+	}
+
+	// reverting the changes rebuilds the old inventory, except for the volatile properties
+	inventory := decodeInventory(ctx, mockNewInventory, "")
+	revertChanges(inventory, changes)
+	delete(inventory[mockHistoryThermal].(map[string]interface{}), "ReadingCelsius")
+	if oldInventory := decodeInventory(ctx, mockOldInventory, ""); !reflect.DeepEqual(inventory, oldInventory) {
+		t.Errorf("revertChanges() = %v, want %v", inventory, oldInventory)
+	}
+
+	// only the resources under the scope are compared
+	changes = diffInventory(decodeInventory(ctx, mockOldInventory, mockHistorySystemURI+"/Memory"),
+		decodeInventory(ctx, mockNewInventory, mockHistorySystemURI+"/Memory"))
+	if len(changes) != 2 {
+		t.Errorf("diffInventory() of the memory = %+v, want 2 changes", changes)
+	}
+}
+
+// fakeInventoryHistoryStore holds the inventory histories and the published events
+type fakeInventoryHistoryStore struct {
+	histories map[string]agmodel.InventoryHistory
+	events    map[string][]common.Event
+}
+
+func (s *fakeInventoryHistoryStore) getInventoryHistory(systemID string) (agmodel.InventoryHistory, *errors.Error) {
+	history, ok := s.histories[systemID]
+	if !ok {
+		return history, errors.PackError(errors.DBKeyNotFound, "error: data with key ", systemID, " does not exist")
+	}
+	return history, nil
+}
+
+func (s *fakeInventoryHistoryStore) saveInventoryHistory(history agmodel.InventoryHistory, systemID string) *errors.Error {
+	s.histories[systemID] = history
+	return nil
+}
+
+func (s *fakeInventoryHistoryStore) publishEvents(ctx context.Context, collectionType string, events []common.Event) {
+	s.events[collectionType] = append(s.events[collectionType], events...)
+}
+
+func TestExternalInterface_recordInventoryChanges(t *testing.T) {
+	config.SetUpMockConfig(t)
+	store := &fakeInventoryHistoryStore{histories: map[string]agmodel.InventoryHistory{}, events: map[string][]common.Event{}}
+	e := &ExternalInterface{
+		GetDeviceInventory: func(deviceUUID string) (map[string]string, *errors.Error) {
+			return mockNewInventory, nil
+		},
+		GetInventoryHistoryInfo: store.getInventoryHistory,
+		SaveInventoryHistory:    store.saveInventoryHistory,
+		PublishEvents:           store.publishEvents,
+	}
+	ctx := mockContext()
+
+	// nothing is recorded for the first discovery
+	e.recordInventoryChanges(ctx, "7a2c6100-67da-5fd6-ab82-6870d29c7279", mockHistorySystemURI, "", map[string]string{})
+	if len(store.histories) != 0 {
+		t.Fatalf("recordInventoryChanges() recorded the first discovery: %v", store.histories)
+	}
+
+	e.recordInventoryChanges(ctx, "7a2c6100-67da-5fd6-ab82-6870d29c7279", mockHistorySystemURI, "", mockOldInventory)
+	history := store.histories[mockHistorySystemID]
+	if len(history.Entries) != 1 || len(history.Entries[0].Changes) != 4 || history.StartTime != history.Entries[0].Timestamp {
+		t.Fatalf("recordInventoryChanges() history = %+v, want an entry with 4 changes", history)
+	}
+	if len(store.events["SystemsCollection"]) != 3 || len(store.events["ChassisCollection"]) != 1 {
+		t.Fatalf("recordInventoryChanges() events = %v, want 3 system and 1 chassis events", store.events)
+	}
+	for _, event := range store.events["SystemsCollection"] {
+		change := event.Oem.(map[string]interface{})["Odim"].(map[string]interface{})["InventoryChange"].(agmodel.InventoryChange)
+		if event.MessageID != "ResourceEvent.1.2.0.ResourceChanged" || event.OriginOfCondition.Oid != change.Resource || change.OldResource != nil {
+			t.Errorf("recordInventoryChanges() published event = %+v, want ResourceChanged event of the change", event)
+		}
+	}
+
+	// the oldest entries are dropped over the history limit
+	config.Data.InventoryHistoryLimit = 2
+	for i := 0; i < 3; i++ {
+		e.recordInventoryChanges(ctx, "7a2c6100-67da-5fd6-ab82-6870d29c7279", mockHistorySystemURI, "", mockOldInventory)
+	}
+	if history = store.histories[mockHistorySystemID]; len(history.Entries) != 2 {
+		t.Errorf("recordInventoryChanges() history has %v entries, want 2", len(history.Entries))
+	}
+}
+
+func TestExternalInterface_GetInventoryHistory(t *testing.T) {
+	config.SetUpMockConfig(t)
+	ctx := context.Background()
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	changes := diffInventory(decodeInventory(ctx, mockOldInventory, ""), decodeInventory(ctx, mockNewInventory, ""))
+	store := &fakeInventoryHistoryStore{histories: map[string]agmodel.InventoryHistory{
+		mockHistorySystemID: {
+			StartTime: start.Format(time.RFC3339),
+			Entries: []agmodel.InventoryHistoryEntry{
+				{Timestamp: start.Add(2 * time.Hour).Format(time.RFC3339), Changes: changes},
+			},
+		},
+	}}
+	e := getMockExternalInterface()
+	e.GetInventoryHistoryInfo = store.getInventoryHistory
+	e.GetDeviceInventory = func(deviceUUID string) (map[string]string, *errors.Error) {
+		return mockNewInventory, nil
+	}
+	uri := InventoryHistoryURI + "/" + mockHistorySystemID
+	query := func(params ...string) string {
+		values := url.Values{}
+		for i := 0; i < len(params); i += 2 {
+			values.Set(params[i], params[i+1])
+		}
+		return uri + "?" + values.Encode()
+	}
+
+	resp := e.GetInventoryHistory(mockContext(), uri)
+	if body, _ := resp.Body.(agresponse.InventoryHistoryResponse); resp.StatusCode != http.StatusOK || len(body.Entries) != 1 {
+		t.Errorf("GetInventoryHistory() = %v, want the history entries", resp)
+	}
+
+	resp = e.GetInventoryHistory(mockContext(), query("AsOf", start.Add(time.Hour).Format(time.RFC3339)))
+	body, _ := resp.Body.(agresponse.InventoryHistoryResponse)
+	if oldInventory := decodeInventory(ctx, mockOldInventory, ""); resp.StatusCode != http.StatusOK ||
+		!reflect.DeepEqual(body.Inventory[mockHistorySystemURI], oldInventory[mockHistorySystemURI]) || body.Inventory[mockHistoryMemoryURI] == nil {
+		t.Errorf("GetInventoryHistory() with AsOf = %v, want the old inventory", resp)
+	}
+
+	resp = e.GetInventoryHistory(mockContext(), query("From", start.Format(time.RFC3339)))
+	body, _ = resp.Body.(agresponse.InventoryHistoryResponse)
+	if resp.StatusCode != http.StatusOK || len(body.Changes) != len(changes) {
+		t.Errorf("GetInventoryHistory() with From = %v, want the recorded changes", resp)
+	}
+
+	resp = e.GetInventoryHistory(mockContext(), query("From", start.Add(3*time.Hour).Format(time.RFC3339)))
+	body, _ = resp.Body.(agresponse.InventoryHistoryResponse)
+	if resp.StatusCode != http.StatusOK || len(body.Changes) != 0 {
+		t.Errorf("GetInventoryHistory() with From after the changes = %v, want no changes", resp)
+	}
+
+	for _, tt := range []struct {
+		name       string
+		reqURI     string
+		statusCode int32
+	}{
+		{name: "unknown system", reqURI: InventoryHistoryURI + "/unknown.1", statusCode: http.StatusNotFound},
+		{name: "invalid AsOf", reqURI: query("AsOf", "yesterday"), statusCode: http.StatusBadRequest},
+		{name: "AsOf before start", reqURI: query("AsOf", start.Add(-time.Hour).Format(time.RFC3339)), statusCode: http.StatusBadRequest},
+		{name: "AsOf with From", reqURI: query("AsOf", start.Format(time.RFC3339), "From", start.Format(time.RFC3339)), statusCode: http.StatusBadRequest},
+		{name: "To before From", reqURI: query("From", start.Add(time.Hour).Format(time.RFC3339), "To", start.Format(time.RFC3339)), statusCode: http.StatusBadRequest},
+		{name: "unsupported query", reqURI: query("Since", start.Format(time.RFC3339)), statusCode: http.StatusBadRequest},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if resp := e.GetInventoryHistory(mockContext(), tt.reqURI); resp.StatusCode != tt.statusCode {
+				t.Errorf("GetInventoryHistory() StatusCode = %v, want %v", resp.StatusCode, tt.statusCode)
+			}
+		})
+	}
+}
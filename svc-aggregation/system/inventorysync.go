@@ -0,0 +1,568 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agcommon"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agresponse"
+	"github.com/google/uuid"
+)
+
+const (
+	// InventorySyncURI is the URI of the policies of the scheduled inventory synchronization
+	InventorySyncURI = "/redfish/v1/AggregationService/Oem/Odim/InventorySync"
+	// DefaultInventorySyncConcurrency is the number of servers synchronized in parallel when the policies have no MaxConcurrency
+	DefaultInventorySyncConcurrency = 10
+
+	// InventorySyncActionID is the action ID of the scheduled inventory synchronization
+	InventorySyncActionID = "237"
+	// InventorySyncActionName is the action name of the scheduled inventory synchronization
+	InventorySyncActionName = "ScheduledInventorySync"
+
+	// inventorySyncCheckInterval is how often the scheduled synchronization looks for the policies due to run
+	inventorySyncCheckInterval = time.Minute
+	// maxMaintenanceWindowDuration is the longest daily maintenance window, in seconds
+	maxMaintenanceWindowDuration = 24 * 60 * 60
+	// inventorySyncLease is the lease of the replica which runs the scheduled synchronization
+	inventorySyncLease = "InventorySync"
+	// inventorySyncLeaseTTL is how long the replica runs the scheduled synchronization without renewing
+	// its lease, after which another replica takes it over
+	inventorySyncLeaseTTL = 3 * inventorySyncCheckInterval
+
+	aggregatesURI        = "/redfish/v1/AggregationService/Aggregates/"
+	connectionMethodsURI = "/redfish/v1/AggregationService/ConnectionMethods/"
+)
+
+// inventorySyncLeaseRenewInterval is how often the lease is renewed while the synchronizations run
+var inventorySyncLeaseRenewInterval = inventorySyncCheckInterval
+
+// InventorySyncRequest is the request for updating the policies of the scheduled inventory synchronization,
+// the properties which are not given are not changed
+type InventorySyncRequest struct {
+	MaxConcurrency    *int                           `json:"MaxConcurrency,omitempty"`
+	MaintenanceWindow *agmodel.MaintenanceWindow     `json:"MaintenanceWindow,omitempty"`
+	Policies          *[]agmodel.InventorySyncPolicy `json:"Policies,omitempty"`
+}
+
+// GetInventorySync returns the policies of the scheduled inventory synchronization
+func (e *ExternalInterface) GetInventorySync(ctx context.Context) response.RPC {
+	inventorySync, err := e.getInventorySyncPolicies()
+	if err != nil {
+		errorMessage := "Unable to get inventory synchronization policies: " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	return response.RPC{
+		StatusCode:    http.StatusOK,
+		StatusMessage: response.Success,
+		Body:          getInventorySyncResponse(inventorySync),
+	}
+}
+
+// UpdateInventorySync updates the policies of the scheduled inventory synchronization
+func (e *ExternalInterface) UpdateInventorySync(ctx context.Context, req *aggregatorproto.AggregatorRequest) response.RPC {
+	var updateRequest InventorySyncRequest
+	if err := json.Unmarshal(req.RequestBody, &updateRequest); err != nil {
+		errorMessage := "Unable to parse the inventory synchronization request: " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errorMessage, nil, nil)
+	}
+	invalidProperties, err := common.RequestParamsCaseValidator(req.RequestBody, updateRequest)
+	if err != nil {
+		errorMessage := "error while validating request parameters: " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	} else if invalidProperties != "" {
+		errorMessage := "error: one or more properties given in the request body are not valid, ensure properties are listed in uppercamelcase "
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errorMessage, []interface{}{invalidProperties}, nil)
+	}
+	// the maintenance window is removed with the null value
+	var requestProperties map[string]json.RawMessage
+	json.Unmarshal(req.RequestBody, &requestProperties)
+	rawWindow, windowGiven := requestProperties["MaintenanceWindow"]
+	if updateRequest.MaxConcurrency == nil && !windowGiven && updateRequest.Policies == nil {
+		errorMessage := "error: one of MaxConcurrency, MaintenanceWindow and Policies must be given"
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errorMessage, []interface{}{"MaxConcurrency MaintenanceWindow Policies"}, nil)
+	}
+
+	inventorySync, dbErr := e.getInventorySyncPolicies()
+	if dbErr != nil {
+		errorMessage := "Unable to get inventory synchronization policies: " + dbErr.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	if updateRequest.MaxConcurrency != nil {
+		if *updateRequest.MaxConcurrency <= 0 {
+			errorMessage := "error: MaxConcurrency must be greater than 0"
+			l.LogWithFields(ctx).Error(errorMessage)
+			return common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errorMessage,
+				[]interface{}{strconv.Itoa(*updateRequest.MaxConcurrency), "MaxConcurrency"}, nil)
+		}
+		inventorySync.MaxConcurrency = *updateRequest.MaxConcurrency
+	}
+	if windowGiven {
+		if string(rawWindow) == "null" {
+			inventorySync.MaintenanceWindow = nil
+		} else {
+			if resp := validateMaintenanceWindow(ctx, updateRequest.MaintenanceWindow); resp != nil {
+				return *resp
+			}
+			inventorySync.MaintenanceWindow = updateRequest.MaintenanceWindow
+		}
+	}
+	if updateRequest.Policies != nil {
+		if resp := e.validateInventorySyncPolicies(ctx, *updateRequest.Policies); resp != nil {
+			return *resp
+		}
+		inventorySync.Policies = *updateRequest.Policies
+	}
+	if dbErr := e.SaveInventorySync(inventorySync); dbErr != nil {
+		errorMessage := "Unable to save inventory synchronization policies: " + dbErr.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	return response.RPC{
+		StatusCode:    http.StatusOK,
+		StatusMessage: response.Success,
+		Body:          getInventorySyncResponse(inventorySync),
+	}
+}
+
+// getInventorySyncPolicies returns the stored policies of the scheduled inventory synchronization,
+// or the default ones when none are stored
+func (e *ExternalInterface) getInventorySyncPolicies() (agmodel.InventorySync, *errors.Error) {
+	inventorySync, err := e.GetInventorySyncInfo()
+	if err != nil {
+		if err.ErrNo() != errors.DBKeyNotFound {
+			return inventorySync, err
+		}
+		inventorySync.MaxConcurrency = DefaultInventorySyncConcurrency
+	}
+	if inventorySync.Policies == nil {
+		inventorySync.Policies = []agmodel.InventorySyncPolicy{}
+	}
+	return inventorySync, nil
+}
+
+func getInventorySyncResponse(inventorySync agmodel.InventorySync) agresponse.InventorySyncResponse {
+	commonResponse := response.Response{
+		OdataType:    "#OdimInventorySync.v1_0_0.OdimInventorySync",
+		OdataID:      InventorySyncURI,
+		OdataContext: "/redfish/v1/$metadata#OdimInventorySync.OdimInventorySync",
+		ID:           "InventorySync",
+		Name:         "Inventory Synchronization",
+	}
+	commonResponse.CreateGenericResponse(response.Success)
+	commonResponse.Message = ""
+	commonResponse.MessageID = ""
+	commonResponse.Severity = ""
+	return agresponse.InventorySyncResponse{
+		Response:          commonResponse,
+		MaxConcurrency:    inventorySync.MaxConcurrency,
+		MaintenanceWindow: inventorySync.MaintenanceWindow,
+		Policies:          inventorySync.Policies,
+	}
+}
+
+func validateMaintenanceWindow(ctx context.Context, window *agmodel.MaintenanceWindow) *response.RPC {
+	if _, err := time.Parse(time.RFC3339, window.MaintenanceWindowStartTime); err != nil {
+		errorMessage := "error: MaintenanceWindowStartTime must be in the RFC 3339 format: " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		resp := common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errorMessage,
+			[]interface{}{window.MaintenanceWindowStartTime, "MaintenanceWindowStartTime"}, nil)
+		return &resp
+	}
+	if window.MaintenanceWindowDurationInSeconds <= 0 || window.MaintenanceWindowDurationInSeconds > maxMaintenanceWindowDuration {
+		errorMessage := fmt.Sprintf("error: MaintenanceWindowDurationInSeconds must be from 1 to %d", maxMaintenanceWindowDuration)
+		l.LogWithFields(ctx).Error(errorMessage)
+		resp := common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errorMessage,
+			[]interface{}{strconv.Itoa(window.MaintenanceWindowDurationInSeconds), "MaintenanceWindowDurationInSeconds"}, nil)
+		return &resp
+	}
+	return nil
+}
+
+// validateInventorySyncPolicies checks the policies have unique names, valid schedules
+// and an existing aggregate or connection method as the scope
+func (e *ExternalInterface) validateInventorySyncPolicies(ctx context.Context, policies []agmodel.InventorySyncPolicy) *response.RPC {
+	names := make(map[string]bool, len(policies))
+	for _, policy := range policies {
+		if policy.Name == "" || policy.Schedule == "" || policy.Scope.OdataID == "" {
+			errorMessage := "error: Name, Schedule and Scope are mandatory for each policy"
+			l.LogWithFields(ctx).Error(errorMessage)
+			resp := common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errorMessage, []interface{}{"Name Schedule Scope"}, nil)
+			return &resp
+		}
+		if names[policy.Name] {
+			errorMessage := "error: policy name " + policy.Name + " is not unique"
+			l.LogWithFields(ctx).Error(errorMessage)
+			resp := common.GeneralError(http.StatusBadRequest, response.PropertyValueConflict, errorMessage, []interface{}{policy.Name, "Name"}, nil)
+			return &resp
+		}
+		names[policy.Name] = true
+		if _, err := parseCronSchedule(policy.Schedule); err != nil {
+			errorMessage := "error: " + err.Error()
+			l.LogWithFields(ctx).Error(errorMessage)
+			resp := common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errorMessage, []interface{}{policy.Schedule, "Schedule"}, nil)
+			return &resp
+		}
+		scope := strings.TrimSuffix(policy.Scope.OdataID, "/")
+		var dbErr *errors.Error
+		switch {
+		case strings.HasPrefix(scope, aggregatesURI):
+			_, dbErr = e.GetAggregateInfo(scope)
+		case strings.HasPrefix(scope, connectionMethodsURI):
+			_, dbErr = e.GetConnectionMethod(scope)
+		default:
+			errorMessage := "error: Scope of the policy " + policy.Name + " must be an aggregate or a connection method"
+			l.LogWithFields(ctx).Error(errorMessage)
+			resp := common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, errorMessage, []interface{}{scope, "Scope"}, nil)
+			return &resp
+		}
+		if dbErr != nil {
+			errorMessage := "error: Scope of the policy " + policy.Name + " is not found: " + dbErr.Error()
+			l.LogWithFields(ctx).Error(errorMessage)
+			var resp response.RPC
+			if dbErr.ErrNo() == errors.DBKeyNotFound {
+				resp = common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errorMessage, []interface{}{"Scope", scope}, nil)
+			} else {
+				resp = common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+			}
+			return &resp
+		}
+	}
+	return nil
+}
+
+// inventorySyncScheduler runs the policies of the scheduled inventory synchronization
+type inventorySyncScheduler struct {
+	e *ExternalInterface
+	// now returns the current time
+	now func() time.Time
+	// sync synchronizes the inventory of the BMC with the given deviceUUID for the policy
+	sync func(ctx context.Context, deviceUUID, policy string)
+}
+
+// PerformInventorySync synchronizes the inventory of the servers on the schedules of the inventory
+// synchronization policies. The synchronizations are started only in the maintenance window, the servers
+// which are due outside the window are synchronized when the window opens.
+// The policies are run only by the replica holding the lease of the scheduled synchronization. The last
+// run times of the policies and the pending servers are saved in the DB, so that the replica taking over
+// the lease continues from where the previous one stopped.
+func (e *ExternalInterface) PerformInventorySync() {
+	transactionID := uuid.New()
+	ctx := agcommon.CreateContext(transactionID.String(), InventorySyncActionID, InventorySyncActionName, "1", common.AggregationService, podName)
+	l.LogWithFields(ctx).Info("inventory synchronization routine started")
+	scheduler := &inventorySyncScheduler{
+		e:    e,
+		now:  func() time.Time { return time.Now().UTC() },
+		sync: e.syncServerInventory,
+	}
+	for {
+		scheduler.run(ctx)
+		time.Sleep(inventorySyncCheckInterval)
+	}
+}
+
+// run queues the servers of the policies whose schedule is due since their last run and synchronizes the queued servers
+func (s *inventorySyncScheduler) run(ctx context.Context) {
+	if !s.holdLease(ctx) {
+		return
+	}
+	inventorySync, err := s.e.getInventorySyncPolicies()
+	if err != nil {
+		l.LogWithFields(ctx).Error("unable to get the inventory synchronization policies: " + err.Error())
+		return
+	}
+	schedule, err := s.e.getInventorySyncSchedule()
+	if err != nil {
+		l.LogWithFields(ctx).Error("unable to get the inventory synchronization schedule: " + err.Error())
+		return
+	}
+	now := s.now()
+	policyNames := make(map[string]bool, len(inventorySync.Policies))
+	for _, policy := range inventorySync.Policies {
+		policyNames[policy.Name] = true
+		cronSchedule, err := parseCronSchedule(policy.Schedule)
+		if err != nil {
+			l.LogWithFields(ctx).Error("invalid schedule of the inventory synchronization policy " + policy.Name + ": " + err.Error())
+			continue
+		}
+		lastRunTime, ok := schedule.LastRunTimes[policy.Name]
+		if !ok {
+			// the policy runs first on its next scheduled time
+			schedule.LastRunTimes[policy.Name] = now
+			continue
+		}
+		if nextRunTime := cronSchedule.next(lastRunTime); nextRunTime.IsZero() || nextRunTime.After(now) {
+			continue
+		}
+		schedule.LastRunTimes[policy.Name] = now
+		deviceUUIDs := s.e.getPolicyDevices(ctx, policy.Scope.OdataID)
+		l.LogWithFields(ctx).Infof("inventory synchronization policy %s is due for %d servers", policy.Name, len(deviceUUIDs))
+		queueInventorySync(&schedule, deviceUUIDs, policy.Name)
+	}
+	for name := range schedule.LastRunTimes {
+		if !policyNames[name] {
+			delete(schedule.LastRunTimes, name)
+		}
+	}
+	if err := s.e.SaveInventorySyncSchedule(schedule); err != nil {
+		l.LogWithFields(ctx).Error("unable to save the inventory synchronization schedule: " + err.Error())
+		return
+	}
+	if len(schedule.Pending) == 0 || !inMaintenanceWindow(inventorySync.MaintenanceWindow, now) {
+		return
+	}
+	concurrency := inventorySync.MaxConcurrency
+	if concurrency <= 0 {
+		concurrency = DefaultInventorySyncConcurrency
+	}
+	s.syncPending(ctx, &schedule, concurrency, inventorySync.MaintenanceWindow)
+}
+
+// holdLease acquires the lease of the scheduled synchronization, or renews it when the replica already holds it
+func (s *inventorySyncScheduler) holdLease(ctx context.Context) bool {
+	acquired, err := s.e.AcquireLease(inventorySyncLease, inventorySyncLeaseTTL)
+	if err != nil {
+		l.LogWithFields(ctx).Error("unable to acquire the lease of the inventory synchronization: " + err.Error())
+		return false
+	}
+	return acquired
+}
+
+// getInventorySyncSchedule returns the stored progress of the scheduled inventory synchronization,
+// or an empty one when none is stored
+func (e *ExternalInterface) getInventorySyncSchedule() (agmodel.InventorySyncSchedule, *errors.Error) {
+	schedule, err := e.GetInventorySyncSchedule()
+	if err != nil && err.ErrNo() != errors.DBKeyNotFound {
+		return schedule, err
+	}
+	if schedule.LastRunTimes == nil {
+		schedule.LastRunTimes = make(map[string]time.Time)
+	}
+	return schedule, nil
+}
+
+// queueInventorySync adds the BMCs to the pending synchronizations, the BMCs already pending are not added again
+func queueInventorySync(schedule *agmodel.InventorySyncSchedule, deviceUUIDs []string, policy string) {
+	pending := make(map[string]bool, len(schedule.Pending))
+	for _, sync := range schedule.Pending {
+		pending[sync.DeviceUUID] = true
+	}
+	for _, deviceUUID := range deviceUUIDs {
+		if !pending[deviceUUID] {
+			pending[deviceUUID] = true
+			schedule.Pending = append(schedule.Pending, agmodel.PendingInventorySync{DeviceUUID: deviceUUID, Policy: policy})
+		}
+	}
+}
+
+// syncPending synchronizes the pending BMCs, at most concurrency in parallel. No synchronization is started
+// after the maintenance window closes, the remaining BMCs stay pending for the next window.
+// The lease is renewed in the background as long as synchronizations run, and again before each
+// synchronization is started and the BMC is removed from the stored pending ones. The remaining BMCs
+// are left to the replica taking over when the lease is lost.
+func (s *inventorySyncScheduler) syncPending(ctx context.Context, schedule *agmodel.InventorySyncSchedule, concurrency int, window *agmodel.MaintenanceWindow) {
+	stop := make(chan struct{})
+	defer close(stop)
+	go s.renewLease(ctx, stop)
+
+	// semaphore limits the number of servers synchronized in parallel
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for len(schedule.Pending) > 0 {
+		semaphore <- struct{}{}
+		if !inMaintenanceWindow(window, s.now()) {
+			<-semaphore
+			l.LogWithFields(ctx).Infof("maintenance window is closed, %d servers are pending for inventory synchronization", len(schedule.Pending))
+			break
+		}
+		if !s.holdLease(ctx) {
+			<-semaphore
+			l.LogWithFields(ctx).Infof("inventory synchronization is taken over by another replica, %d servers are pending", len(schedule.Pending))
+			break
+		}
+		next := schedule.Pending[0]
+		schedule.Pending = schedule.Pending[1:]
+		if err := s.e.SaveInventorySyncSchedule(*schedule); err != nil {
+			l.LogWithFields(ctx).Error("unable to save the inventory synchronization schedule: " + err.Error())
+		}
+		wg.Add(1)
+		go func(next agmodel.PendingInventorySync) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			s.sync(ctx, next.DeviceUUID, next.Policy)
+		}(next)
+	}
+	wg.Wait()
+}
+
+// renewLease renews the lease of the scheduled synchronization until stop is closed, so that it
+// doesn't expire while the loop waits for the running synchronizations to start the next one
+func (s *inventorySyncScheduler) renewLease(ctx context.Context, stop <-chan struct{}) {
+	ticker := time.NewTicker(inventorySyncLeaseRenewInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			s.holdLease(ctx)
+		}
+	}
+}
+
+// inMaintenanceWindow tells whether t is in the daily maintenance window, any time is in the window when there is none
+func inMaintenanceWindow(window *agmodel.MaintenanceWindow, t time.Time) bool {
+	if window == nil {
+		return true
+	}
+	startTime, err := time.Parse(time.RFC3339, window.MaintenanceWindowStartTime)
+	if err != nil || t.Before(startTime) {
+		return false
+	}
+	return t.Sub(startTime)%(24*time.Hour) < time.Duration(window.MaintenanceWindowDurationInSeconds)*time.Second
+}
+
+// getPolicyDevices returns the IDs of the BMCs of the systems of the aggregate,
+// or of the aggregation sources of the connection method
+func (e *ExternalInterface) getPolicyDevices(ctx context.Context, scope string) []string {
+	scope = strings.TrimSuffix(scope, "/")
+	var deviceUUIDs []string
+	found := make(map[string]bool)
+	addDevice := func(deviceUUID string) {
+		if deviceUUID != "" && !found[deviceUUID] {
+			found[deviceUUID] = true
+			deviceUUIDs = append(deviceUUIDs, deviceUUID)
+		}
+	}
+	if strings.HasPrefix(scope, aggregatesURI) {
+		aggregate, err := e.GetAggregateInfo(scope)
+		if err != nil {
+			l.LogWithFields(ctx).Error("unable to get the aggregate " + scope + ": " + err.Error())
+			return nil
+		}
+		for _, element := range aggregate.Elements {
+			systemID := strings.TrimPrefix(element.OdataID, "/redfish/v1/Systems/")
+			addDevice(strings.SplitN(systemID, ".", 2)[0])
+		}
+		return deviceUUIDs
+	}
+	connectionMethod, err := e.GetConnectionMethod(scope)
+	if err != nil {
+		l.LogWithFields(ctx).Error("unable to get the connection method " + scope + ": " + err.Error())
+		return nil
+	}
+	for _, aggregationSource := range connectionMethod.Links.AggregationSources {
+		addDevice(getTargetID(aggregationSource.OdataID))
+	}
+	return deviceUUIDs
+}
+
+// syncServerInventory refreshes the systems, chassis and managers of the BMC with the given deviceUUID
+// and records the result of the synchronization on each of its systems
+func (e *ExternalInterface) syncServerInventory(ctx context.Context, deviceUUID, policy string) {
+	systemURLs, err := e.getDeviceSystemURLs(deviceUUID)
+	if err != nil {
+		// the aggregation sources of the plugins have no systems
+		l.LogWithFields(ctx).Debug("inventory of " + deviceUUID + " is not synchronized: " + err.Error())
+		return
+	}
+	l.LogWithFields(ctx).Info("Synchronization of the inventory of the BMC with ID " + deviceUUID + " is started.")
+	syncErr := e.refreshServerInventory(ctx, deviceUUID, systemURLs)
+	syncTime := time.Now().UTC().Format(time.RFC3339)
+	for _, systemURL := range systemURLs {
+		systemID := systemURL[strings.LastIndex(systemURL, "/")+1:]
+		status, _ := e.GetInventorySyncStatus(systemID)
+		status.Policy = policy
+		status.LastSyncTime = syncTime
+		status.LastSyncError = ""
+		if syncErr != nil {
+			status.LastSyncError = syncErr.Error()
+		} else {
+			status.LastSuccessfulSyncTime = syncTime
+		}
+		if err := e.SaveInventorySyncStatus(status, systemID); err != nil {
+			l.LogWithFields(ctx).Error("unable to save the inventory synchronization status of " + systemURL + ": " + err.Error())
+		}
+	}
+	if syncErr != nil {
+		l.LogWithFields(ctx).Error("Synchronization of the inventory of the BMC with ID " + deviceUUID + " failed: " + syncErr.Error())
+		return
+	}
+	l.LogWithFields(ctx).Info("Synchronization of the inventory of the BMC with ID " + deviceUUID + " is now complete.")
+}
+
+// refreshServerInventory refreshes the systems with the given URLs and the chassis and managers of the BMC
+func (e *ExternalInterface) refreshServerInventory(ctx context.Context, deviceUUID string, systemURLs []string) error {
+	for _, systemURL := range systemURLs {
+		// check whether delete operation for the system is initiated
+		systemOperation, dbErr := agmodel.GetSystemOperationInfo(systemURL)
+		if dbErr != nil && errors.DBKeyNotFound != dbErr.ErrNo() {
+			return dbErr
+		}
+		if systemOperation.Operation == "Delete" {
+			return fmt.Errorf("%s operation is under progress for %s", systemOperation.Operation, systemURL)
+		}
+		// Add system operation info to db to block the delete request for respective system
+		systemOperation.Operation = "InventoryRediscovery"
+		if dbErr = systemOperation.AddSystemOperationInfo(systemURL); dbErr != nil {
+			return dbErr
+		}
+		defer agmodel.DeleteSystemOperationInfo(systemURL)
+	}
+	req, err := e.getDeviceRequest(ctx, deviceUUID)
+	if err != nil {
+		return err
+	}
+	resourceURIs := append([]string{}, systemURLs...)
+	for _, table := range []string{"Chassis", "Managers"} {
+		keys, dbErr := e.GetAllMatchingDetails(table, "/redfish/v1/"+table+"/"+deviceUUID+".", common.InMemory)
+		if dbErr != nil {
+			return dbErr
+		}
+		sort.Strings(keys)
+		resourceURIs = append(resourceURIs, keys...)
+	}
+	for _, resourceURI := range resourceURIs {
+		systemURL := systemURLs[0]
+		if strings.HasPrefix(resourceURI, "/redfish/v1/Systems/") {
+			systemURL = resourceURI
+		}
+		if err := e.refreshResourceInventory(ctx, req, systemURL, resourceURI); err != nil {
+			return err
+		}
+	}
+	return nil
+}
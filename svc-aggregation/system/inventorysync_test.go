@@ -0,0 +1,329 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agresponse"
+)
+
+const (
+	mockSyncAggregate  = "/redfish/v1/AggregationService/Aggregates/c14d91b5-3333-48bb-a7b7-75f74a137d48"
+	mockSyncDeviceUUID = "7a2c6100-67da-5fd6-ab82-6870d29c7279"
+)
+
+func TestExternalInterface_GetInventorySync(t *testing.T) {
+	config.SetUpMockConfig(t)
+	e := getMockExternalInterface()
+	resp := e.GetInventorySync(context.Background())
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GetInventorySync() status = %v, want %v", resp.StatusCode, http.StatusOK)
+	}
+	body := resp.Body.(agresponse.InventorySyncResponse)
+	if body.MaxConcurrency != DefaultInventorySyncConcurrency || body.MaintenanceWindow != nil || len(body.Policies) != 0 {
+		t.Errorf("GetInventorySync() without stored policies = %+v, want the defaults", body)
+	}
+	e.GetInventorySyncInfo = func() (agmodel.InventorySync, *errors.Error) {
+		return agmodel.InventorySync{}, errors.PackError(errors.UndefinedErrorType, "error while trying to connect to DB")
+	}
+	if resp := e.GetInventorySync(context.Background()); resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("GetInventorySync() with DB error status = %v, want %v", resp.StatusCode, http.StatusInternalServerError)
+	}
+}
+
+func TestExternalInterface_UpdateInventorySync(t *testing.T) {
+	config.SetUpMockConfig(t)
+	stored := agmodel.InventorySync{
+		MaxConcurrency:    5,
+		MaintenanceWindow: &agmodel.MaintenanceWindow{MaintenanceWindowStartTime: "2022-03-15T02:00:00Z", MaintenanceWindowDurationInSeconds: 3600},
+	}
+	var saved *agmodel.InventorySync
+	e := getMockExternalInterface()
+	e.GetInventorySyncInfo = func() (agmodel.InventorySync, *errors.Error) {
+		return stored, nil
+	}
+	e.SaveInventorySync = func(inventorySync agmodel.InventorySync) *errors.Error {
+		saved = &inventorySync
+		return nil
+	}
+	tests := []struct {
+		name           string
+		body           string
+		wantStatusCode int32
+	}{
+		{"update concurrency", `{"MaxConcurrency":2}`, http.StatusOK},
+		{"remove maintenance window", `{"MaintenanceWindow":null}`, http.StatusOK},
+		{"aggregate policy", `{"Policies":[{"Name":"nightly","Schedule":"30 2 * * *","Scope":{"@odata.id":"` + mockSyncAggregate + `"}}]}`, http.StatusOK},
+		{"connection method policy", `{"Policies":[{"Name":"hourly","Schedule":"@hourly","Scope":{"@odata.id":"/redfish/v1/AggregationService/ConnectionMethods/7ff3bd97-c41c-5de0-937d-85d390691b73"}}]}`, http.StatusOK},
+		{"malformed body", `{"MaxConcurrency":"2"}`, http.StatusBadRequest},
+		{"empty body", `{}`, http.StatusBadRequest},
+		{"invalid case", `{"maxConcurrency":2}`, http.StatusBadRequest},
+		{"invalid concurrency", `{"MaxConcurrency":0}`, http.StatusBadRequest},
+		{"invalid window start", `{"MaintenanceWindow":{"MaintenanceWindowStartTime":"02:00","MaintenanceWindowDurationInSeconds":3600}}`, http.StatusBadRequest},
+		{"invalid window duration", `{"MaintenanceWindow":{"MaintenanceWindowStartTime":"2022-03-15T02:00:00Z","MaintenanceWindowDurationInSeconds":90000}}`, http.StatusBadRequest},
+		{"missing schedule", `{"Policies":[{"Name":"nightly","Scope":{"@odata.id":"` + mockSyncAggregate + `"}}]}`, http.StatusBadRequest},
+		{"invalid schedule", `{"Policies":[{"Name":"nightly","Schedule":"30 25 * * *","Scope":{"@odata.id":"` + mockSyncAggregate + `"}}]}`, http.StatusBadRequest},
+		{"duplicate name", `{"Policies":[{"Name":"nightly","Schedule":"@daily","Scope":{"@odata.id":"` + mockSyncAggregate + `"}},` +
+			`{"Name":"nightly","Schedule":"@hourly","Scope":{"@odata.id":"` + mockSyncAggregate + `"}}]}`, http.StatusBadRequest},
+		{"invalid scope", `{"Policies":[{"Name":"nightly","Schedule":"@daily","Scope":{"@odata.id":"/redfish/v1/Systems"}}]}`, http.StatusBadRequest},
+		{"scope not found", `{"Policies":[{"Name":"nightly","Schedule":"@daily","Scope":{"@odata.id":"/redfish/v1/AggregationService/Aggregates/1"}}]}`, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			saved = nil
+			resp := e.UpdateInventorySync(context.Background(), &aggregatorproto.AggregatorRequest{RequestBody: []byte(tt.body)})
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Fatalf("UpdateInventorySync() status = %v, want %v: %v", resp.StatusCode, tt.wantStatusCode, resp.Body)
+			}
+			if (saved != nil) != (tt.wantStatusCode == http.StatusOK) {
+				t.Errorf("UpdateInventorySync() saved the policies = %v, want %v", saved != nil, tt.wantStatusCode == http.StatusOK)
+			}
+		})
+	}
+
+	// the properties which are not given are kept
+	e.UpdateInventorySync(context.Background(), &aggregatorproto.AggregatorRequest{RequestBody: []byte(`{"MaxConcurrency":2}`)})
+	if saved.MaxConcurrency != 2 || !reflect.DeepEqual(saved.MaintenanceWindow, stored.MaintenanceWindow) {
+		t.Errorf("UpdateInventorySync() saved %+v", saved)
+	}
+	e.UpdateInventorySync(context.Background(), &aggregatorproto.AggregatorRequest{RequestBody: []byte(`{"MaintenanceWindow":null}`)})
+	if saved.MaxConcurrency != 5 || saved.MaintenanceWindow != nil {
+		t.Errorf("UpdateInventorySync() saved %+v", saved)
+	}
+}
+
+func TestInMaintenanceWindow(t *testing.T) {
+	window := &agmodel.MaintenanceWindow{MaintenanceWindowStartTime: "2022-03-15T22:00:00Z", MaintenanceWindowDurationInSeconds: 4 * 3600}
+	tests := []struct {
+		t    time.Time
+		want bool
+	}{
+		{time.Date(2022, 3, 15, 21, 59, 0, 0, time.UTC), false},
+		{time.Date(2022, 3, 15, 22, 0, 0, 0, time.UTC), true},
+		{time.Date(2022, 3, 16, 1, 59, 0, 0, time.UTC), true},
+		{time.Date(2022, 3, 16, 2, 0, 0, 0, time.UTC), false},
+		{time.Date(2022, 3, 20, 23, 0, 0, 0, time.UTC), true},
+		{time.Date(2022, 3, 14, 23, 0, 0, 0, time.UTC), false},
+	}
+	for _, tt := range tests {
+		if got := inMaintenanceWindow(window, tt.t); got != tt.want {
+			t.Errorf("inMaintenanceWindow(%v) = %v, want %v", tt.t, got, tt.want)
+		}
+	}
+	if !inMaintenanceWindow(nil, time.Now()) {
+		t.Errorf("inMaintenanceWindow() without a window = false, want true")
+	}
+}
+
+func TestInventorySyncScheduler_run(t *testing.T) {
+	config.SetUpMockConfig(t)
+	inventorySync := agmodel.InventorySync{
+		MaxConcurrency: 2,
+		Policies: []agmodel.InventorySyncPolicy{
+			{Name: "hourly", Schedule: "@hourly", Scope: agmodel.OdataID{OdataID: mockSyncAggregate}},
+		},
+	}
+	var deviceUUIDs []string
+	var elements []agmodel.OdataID
+	for i := 0; i < 5; i++ {
+		deviceUUID := fmt.Sprintf("%s-%d", mockSyncDeviceUUID, i)
+		deviceUUIDs = append(deviceUUIDs, deviceUUID)
+		elements = append(elements, agmodel.OdataID{OdataID: "/redfish/v1/Systems/" + deviceUUID + ".1"})
+	}
+	e := getMockExternalInterface()
+	e.GetInventorySyncInfo = func() (agmodel.InventorySync, *errors.Error) {
+		return inventorySync, nil
+	}
+	e.GetAggregateInfo = func(aggregateURI string) (agmodel.Aggregate, *errors.Error) {
+		return agmodel.Aggregate{Elements: elements}, nil
+	}
+	// the schedule and the lease are shared with the other replicas
+	var schedule *agmodel.InventorySyncSchedule
+	e.GetInventorySyncSchedule = func() (agmodel.InventorySyncSchedule, *errors.Error) {
+		if schedule == nil {
+			return agmodel.InventorySyncSchedule{}, errors.PackError(errors.DBKeyNotFound, "error: data with key InventorySyncSchedule does not exist")
+		}
+		stored := agmodel.InventorySyncSchedule{LastRunTimes: make(map[string]time.Time)}
+		for name, lastRunTime := range schedule.LastRunTimes {
+			stored.LastRunTimes[name] = lastRunTime
+		}
+		stored.Pending = append(stored.Pending, schedule.Pending...)
+		return stored, nil
+	}
+	e.SaveInventorySyncSchedule = func(saved agmodel.InventorySyncSchedule) *errors.Error {
+		schedule = &saved
+		return nil
+	}
+	leaseHeld := true
+	e.AcquireLease = func(name string, ttl time.Duration) (bool, *errors.Error) {
+		return leaseHeld, nil
+	}
+
+	var lock sync.Mutex
+	var synced []string
+	running, maxRunning := 0, 0
+	now := time.Date(2022, 3, 15, 10, 30, 0, 0, time.UTC)
+	scheduler := &inventorySyncScheduler{
+		e:   e,
+		now: func() time.Time { return now },
+		sync: func(ctx context.Context, deviceUUID, policy string) {
+			lock.Lock()
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			lock.Unlock()
+			time.Sleep(10 * time.Millisecond)
+			lock.Lock()
+			running--
+			synced = append(synced, deviceUUID)
+			lock.Unlock()
+		},
+	}
+	ctx := context.Background()
+
+	// the policy first runs on its next scheduled time
+	scheduler.run(ctx)
+	if len(synced) != 0 {
+		t.Fatalf("run() synchronized %v before the schedule", synced)
+	}
+	now = now.Add(20 * time.Minute)
+	scheduler.run(ctx)
+	if len(synced) != 0 {
+		t.Fatalf("run() synchronized %v before the schedule", synced)
+	}
+	now = now.Add(10 * time.Minute)
+	scheduler.run(ctx)
+	if len(synced) != len(deviceUUIDs) || maxRunning > 2 {
+		t.Fatalf("run() synchronized %v with %d in parallel, want %v with at most 2", synced, maxRunning, deviceUUIDs)
+	}
+
+	// the servers due outside the maintenance window are synchronized when the window opens
+	synced = nil
+	inventorySync.MaintenanceWindow = &agmodel.MaintenanceWindow{MaintenanceWindowStartTime: "2022-03-15T02:00:00Z", MaintenanceWindowDurationInSeconds: 3600}
+	now = now.Add(time.Hour)
+	scheduler.run(ctx)
+	if len(synced) != 0 || len(schedule.Pending) != len(deviceUUIDs) {
+		t.Fatalf("run() outside the maintenance window synchronized %v and left %d pending", synced, len(schedule.Pending))
+	}
+	// the pending servers are not queued again
+	now = now.Add(time.Hour)
+	scheduler.run(ctx)
+	if len(schedule.Pending) != len(deviceUUIDs) {
+		t.Fatalf("run() left %d pending, want %d", len(schedule.Pending), len(deviceUUIDs))
+	}
+
+	// the replica without the lease does not synchronize, the replica taking over
+	// the lease synchronizes the servers left pending by the previous one
+	now = time.Date(2022, 3, 16, 2, 10, 0, 0, time.UTC)
+	leaseHeld = false
+	scheduler.run(ctx)
+	if len(synced) != 0 {
+		t.Fatalf("run() without the lease synchronized %v", synced)
+	}
+	leaseHeld = true
+	takeover := &inventorySyncScheduler{e: e, now: scheduler.now, sync: scheduler.sync}
+	takeover.run(ctx)
+	if len(synced) != len(deviceUUIDs) || len(schedule.Pending) != 0 {
+		t.Fatalf("run() in the maintenance window synchronized %v and left %d pending", synced, len(schedule.Pending))
+	}
+
+	// the removed policies are forgotten
+	inventorySync.Policies = nil
+	scheduler.run(ctx)
+	if len(schedule.LastRunTimes) != 0 {
+		t.Errorf("run() kept the last run times %v of the removed policies", schedule.LastRunTimes)
+	}
+}
+
+func TestInventorySyncScheduler_syncPendingRenewsLease(t *testing.T) {
+	config.SetUpMockConfig(t)
+	defer func(interval time.Duration) {
+		inventorySyncLeaseRenewInterval = interval
+	}(inventorySyncLeaseRenewInterval)
+	inventorySyncLeaseRenewInterval = 10 * time.Millisecond
+
+	e := getMockExternalInterface()
+	e.SaveInventorySyncSchedule = func(saved agmodel.InventorySyncSchedule) *errors.Error {
+		return nil
+	}
+	var renewals int32
+	e.AcquireLease = func(name string, ttl time.Duration) (bool, *errors.Error) {
+		atomic.AddInt32(&renewals, 1)
+		return true, nil
+	}
+	scheduler := &inventorySyncScheduler{
+		e:   e,
+		now: func() time.Time { return time.Now().UTC() },
+		sync: func(ctx context.Context, deviceUUID, policy string) {
+			time.Sleep(100 * time.Millisecond)
+		},
+	}
+	schedule := &agmodel.InventorySyncSchedule{
+		Pending: []agmodel.PendingInventorySync{
+			{DeviceUUID: mockSyncDeviceUUID + "-0", Policy: "hourly"},
+			{DeviceUUID: mockSyncDeviceUUID + "-1", Policy: "hourly"},
+		},
+	}
+
+	// the lease is renewed while the loop waits for the first synchronization to start the second one
+	scheduler.syncPending(context.Background(), schedule, 1, nil)
+	if got := atomic.LoadInt32(&renewals); got <= 2 {
+		t.Errorf("syncPending() renewed the lease %d times, want it renewed while the synchronizations run", got)
+	}
+}
+
+func TestExternalInterface_syncServerInventory(t *testing.T) {
+	config.SetUpMockConfig(t)
+	systemURL := "/redfish/v1/Systems/" + mockSyncDeviceUUID + ".1"
+	saved := make(map[string]agmodel.InventorySyncStatus)
+	e := getMockExternalInterface()
+	e.GetAllMatchingDetails = func(table, pattern string, dbType common.DbType) ([]string, *errors.Error) {
+		if table == "ComputerSystem" {
+			return []string{systemURL}, nil
+		}
+		return []string{}, nil
+	}
+	e.GetInventorySyncStatus = func(systemID string) (agmodel.InventorySyncStatus, *errors.Error) {
+		return agmodel.InventorySyncStatus{LastSuccessfulSyncTime: "2022-03-15T02:00:00Z"}, nil
+	}
+	e.SaveInventorySyncStatus = func(status agmodel.InventorySyncStatus, systemID string) *errors.Error {
+		saved[systemID] = status
+		return nil
+	}
+	// the inventory of the BMC can't be refreshed, the failure is recorded and the last successful synchronization is kept
+	e.syncServerInventory(context.Background(), mockSyncDeviceUUID, "nightly")
+	status, ok := saved[mockSyncDeviceUUID+".1"]
+	if !ok {
+		t.Fatalf("syncServerInventory() didn't save the synchronization status")
+	}
+	if status.Policy != "nightly" || status.LastSyncTime == "" || status.LastSyncError == "" ||
+		status.LastSuccessfulSyncTime != "2022-03-15T02:00:00Z" {
+		t.Errorf("syncServerInventory() saved the status %+v", status)
+	}
+}
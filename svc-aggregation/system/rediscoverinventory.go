@@ -55,62 +55,10 @@ func (e *ExternalInterface) RediscoverSystemInventory(ctx context.Context, devic
 		return
 	}
 
-	// Getting the device info
-	target, err := agmodel.GetTarget(deviceUUID)
+	req, err := e.getDeviceRequest(ctx, deviceUUID)
 	if err != nil {
-		genError(ctx, err.Error(), &resp, http.StatusBadRequest, errors.ResourceNotFound, map[string]string{
-			"Content-type": "application/json; charset=utf-8",
-		})
-		l.LogWithFields(ctx).Error("Unable to unmarshal data: " + err.Error())
 		return
 	}
-	decryptedPasswordByte, err := e.DecryptPassword(target.Password)
-	if err != nil {
-		genError(ctx, "error while trying to decrypt device password: "+err.Error(), &resp, http.StatusInternalServerError, errors.InternalError, map[string]string{
-			"Content-type": "application/json; charset=utf-8",
-		})
-		l.LogWithFields(ctx).Error("Unable to unmarshal data: " + err.Error())
-		return
-	}
-	target.Password = decryptedPasswordByte
-
-	// get the plugin information
-	plugin, errs := agmodel.GetPluginData(target.PluginID)
-	if errs != nil {
-		genError(ctx, errs.Error(), &resp, http.StatusBadRequest, errors.ResourceNotFound, map[string]string{
-			"Content-type": "application/json; charset=utf-8",
-		})
-		l.LogWithFields(ctx).Error(errs.Error())
-		return
-	}
-
-	var req getResourceRequest
-	req.ContactClient = e.ContactClient
-	req.GetPluginStatus = e.GetPluginStatus
-	req.Plugin = plugin
-	req.StatusPoll = true
-	req.BMCAddress = target.ManagerAddress
-	if strings.EqualFold(plugin.PreferredAuthType, "XAuthToken") {
-		var err error
-		req.HTTPMethodType = http.MethodPost
-		req.DeviceInfo = map[string]interface{}{
-			"UserName": plugin.Username,
-			"Password": string(plugin.Password),
-		}
-		req.OID = "/ODIM/v1/Sessions"
-		_, token, _, err := contactPlugin(ctx, req, "error while getting the details "+req.OID+": ")
-		if err != nil {
-			l.LogWithFields(ctx).Error(err.Error())
-			return
-		}
-		req.Token = token
-	} else {
-		req.LoginCredentials = map[string]string{
-			"UserName": plugin.Username,
-			"Password": string(plugin.Password),
-		}
-
-	}
 	// check whether delete operation for the system is initiated
 	if strings.Contains(systemURL, "/Storage") {
 		systemURL = strings.Replace(systemURL, "/Storage", "", -1)
@@ -139,10 +87,13 @@ func (e *ExternalInterface) RediscoverSystemInventory(ctx context.Context, devic
 		deleteResourceResetInfo(ctx, systemURL)
 	}()
 
+	// the inventory is read before it is removed to find the changes made by the rediscovery
+	oldInventory, dbErr := e.GetDeviceInventory(deviceUUID)
+	if dbErr != nil {
+		l.LogWithFields(ctx).Error("Unable to read the inventory of the BMC with ID " + deviceUUID + ": " + dbErr.Error())
+	}
 	deleteSubordinateResource(ctx, deviceUUID)
 
-	req.DeviceUUID = deviceUUID
-	req.DeviceInfo = target
 	req.OID = strings.Replace(systemURL, "/redfish/v1/Systems/"+deviceUUID+".", "/redfish/v1/Systems/", -1)
 	l.LogWithFields(ctx).Info("Request oid for rediscovery," + req.OID)
 	req.UpdateFlag = updateFlag
@@ -152,10 +103,12 @@ func (e *ExternalInterface) RediscoverSystemInventory(ctx context.Context, devic
 	h.InventoryData = make(map[string]interface{})
 	progress := int32(100)
 	systemsEstimatedWork := int32(75)
+	var scopeURI string
 	if strings.Contains(systemURL, "/Storage") {
-		_, progress, _ = h.getStorageInfo(ctx, progress, systemsEstimatedWork, req)
+		scopeURI = systemURL + "/Storage"
+		_, progress, err = h.getStorageInfo(ctx, progress, systemsEstimatedWork, req)
 	} else {
-		_, _, progress, _ = h.getSystemInfo(ctx, "", progress, systemsEstimatedWork, req)
+		_, _, progress, err = h.getSystemInfo(ctx, "", progress, systemsEstimatedWork, req)
 		h.InventoryData = make(map[string]interface{})
 		//rediscovering the Chassis Information
 		req.OID = "/redfish/v1/Chassis"
@@ -168,6 +121,9 @@ func (e *ExternalInterface) RediscoverSystemInventory(ctx context.Context, devic
 		progress = h.getAllRootInfo(ctx, "", progress, managerEstimatedWork, req, config.Data.AddComputeSkipResources.SkipResourceListUnderManager)
 		agmodel.SaveBMCInventory(h.InventoryData)
 	}
+	if err == nil {
+		e.recordInventoryChanges(ctx, deviceUUID, systemURL, scopeURI, oldInventory)
+	}
 
 	var responseBody = map[string]string{
 		"UUID": deviceUUID,
@@ -179,6 +135,70 @@ func (e *ExternalInterface) RediscoverSystemInventory(ctx context.Context, devic
 	l.LogWithFields(ctx).Info("Rediscovery of the BMC with ID " + deviceUUID + " is now complete.")
 }
 
+// getDeviceRequest returns the request for getting the resources of the BMC with the given deviceUUID from its plugin
+func (e *ExternalInterface) getDeviceRequest(ctx context.Context, deviceUUID string) (getResourceRequest, error) {
+	var resp response.RPC
+	var req getResourceRequest
+	// Getting the device info
+	target, err := agmodel.GetTarget(deviceUUID)
+	if err != nil {
+		genError(ctx, err.Error(), &resp, http.StatusBadRequest, errors.ResourceNotFound, map[string]string{
+			"Content-type": "application/json; charset=utf-8",
+		})
+		l.LogWithFields(ctx).Error("Unable to unmarshal data: " + err.Error())
+		return req, err
+	}
+	decryptedPasswordByte, err := e.DecryptPassword(target.Password)
+	if err != nil {
+		genError(ctx, "error while trying to decrypt device password: "+err.Error(), &resp, http.StatusInternalServerError, errors.InternalError, map[string]string{
+			"Content-type": "application/json; charset=utf-8",
+		})
+		l.LogWithFields(ctx).Error("Unable to unmarshal data: " + err.Error())
+		return req, err
+	}
+	target.Password = decryptedPasswordByte
+
+	// get the plugin information
+	plugin, errs := agmodel.GetPluginData(target.PluginID)
+	if errs != nil {
+		genError(ctx, errs.Error(), &resp, http.StatusBadRequest, errors.ResourceNotFound, map[string]string{
+			"Content-type": "application/json; charset=utf-8",
+		})
+		l.LogWithFields(ctx).Error(errs.Error())
+		return req, errs
+	}
+
+	req.ContactClient = e.ContactClient
+	req.GetPluginStatus = e.GetPluginStatus
+	req.Plugin = plugin
+	req.StatusPoll = true
+	req.BMCAddress = target.ManagerAddress
+	if strings.EqualFold(plugin.PreferredAuthType, "XAuthToken") {
+		var err error
+		req.HTTPMethodType = http.MethodPost
+		req.DeviceInfo = map[string]interface{}{
+			"UserName": plugin.Username,
+			"Password": string(plugin.Password),
+		}
+		req.OID = "/ODIM/v1/Sessions"
+		_, token, _, err := contactPlugin(ctx, req, "error while getting the details "+req.OID+": ")
+		if err != nil {
+			l.LogWithFields(ctx).Error(err.Error())
+			return req, err
+		}
+		req.Token = token
+	} else {
+		req.LoginCredentials = map[string]string{
+			"UserName": plugin.Username,
+			"Password": string(plugin.Password),
+		}
+
+	}
+	req.DeviceUUID = deviceUUID
+	req.DeviceInfo = target
+	return req, nil
+}
+
 //RediscoverResources is a function to rediscover the server inventory,
 // in the event of InMemory DB crashed and/or rebooted all of the content/inventory
 // in the Inmemory DB is gone. So to repopulate the inventory of all the added server,
@@ -369,7 +389,7 @@ func deleteSubordinateResource(ctx context.Context, deviceUUID string) {
 	for _, key := range keys {
 		resourceDetails := strings.Split(key, ":")
 		switch resourceDetails[0] {
-		case "ComputerSystem", "SystemReset", "SystemOperation", "Chassis", "Managers", "FirmwareInventory", "SoftwareInventory":
+		case "ComputerSystem", "SystemReset", "SystemOperation", "Chassis", "Managers", "FirmwareInventory", "SoftwareInventory", "InventorySyncStatus":
 			continue
 		default:
 			if err = agmodel.Delete(resourceDetails[0], resourceDetails[1], common.InMemory); err != nil {
@@ -0,0 +1,173 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"context"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agcommon"
+	"github.com/google/uuid"
+)
+
+const (
+	// SecretReencryptionActionID is the action ID of the re-encryption of the stored passwords
+	SecretReencryptionActionID = "233"
+	// SecretReencryptionActionName is the action name of the re-encryption of the stored passwords
+	SecretReencryptionActionName = "SecretReencryption"
+
+	// secretReencryptionInterval is how often the stored passwords are checked against the current key
+	secretReencryptionInterval = time.Hour
+	// secretReencryptionLease is the lease of the replica which re-encrypts the stored passwords
+	secretReencryptionLease = "SecretReencryption"
+	// secretReencryptionLeaseTTL is how long the replica re-encrypts the passwords without renewing
+	// its lease, after which another replica takes it over
+	secretReencryptionLeaseTTL = 2 * secretReencryptionInterval
+)
+
+// secretTables are the tables holding the encrypted BMC and plugin passwords
+var secretTables = []string{"System", "Plugin", "AggregationSource"}
+
+// secretHolder is an entry of the secret tables holding a secret
+type secretHolder struct {
+	table, key string
+}
+
+// PerformSecretReencryption re-encrypts the stored BMC and plugin passwords which are not protected
+// with the current key of the configured secret provider, on start and then every hour.
+// A password is re-encrypted in place, and since the passwords of the older keys stay readable
+// the services keep working while the passwords of a rotated key are re-encrypted.
+// The passwords are re-encrypted only by the replica holding the lease of the re-encryption.
+func (e *ExternalInterface) PerformSecretReencryption() {
+	transactionID := uuid.New()
+	ctx := agcommon.CreateContext(transactionID.String(), SecretReencryptionActionID, SecretReencryptionActionName, "1", common.AggregationService, podName)
+	l.LogWithFields(ctx).Info("secret re-encryption routine started")
+	for {
+		e.reencryptSecrets(ctx)
+		time.Sleep(secretReencryptionInterval)
+	}
+}
+
+// reencryptSecrets re-encrypts the passwords of all the secret tables
+// and returns the number of re-encrypted and failed passwords.
+// A password shared by the entries of the BMC or of the plugin is re-encrypted once for all of them.
+func (e *ExternalInterface) reencryptSecrets(ctx context.Context) (int, int) {
+	var reencrypted, failed int
+	if !e.holdSecretReencryptionLease(ctx) {
+		return reencrypted, failed
+	}
+	var secrets []string
+	holders := make(map[string][]secretHolder)
+	for _, table := range secretTables {
+		keys, err := e.GetAllKeysFromTable(table)
+		if err != nil {
+			l.LogWithFields(ctx).Error("unable to get the keys of " + table + " for the secret re-encryption: " + err.Error())
+			failed++
+			continue
+		}
+		for _, key := range keys {
+			password, dbErr := e.GetStoredPassword(table, key)
+			if dbErr != nil {
+				if dbErr.ErrNo() != errors.DBKeyNotFound {
+					l.LogWithFields(ctx).Error("unable to get the password of " + table + ":" + key + ": " + dbErr.Error())
+					failed++
+				}
+				// else the entry is deleted after the keys were read
+				continue
+			}
+			if len(password) == 0 {
+				continue
+			}
+			if _, ok := holders[string(password)]; !ok {
+				secrets = append(secrets, string(password))
+			}
+			holders[string(password)] = append(holders[string(password)], secretHolder{table: table, key: key})
+		}
+	}
+	for _, secret := range secrets {
+		if !e.holdSecretReencryptionLease(ctx) {
+			l.LogWithFields(ctx).Info("secret re-encryption is taken over by another replica")
+			break
+		}
+		done, errs := e.reencryptSecret(ctx, []byte(secret), holders[secret])
+		reencrypted += done
+		failed += errs
+	}
+	if failed > 0 {
+		l.LogWithFields(ctx).Warnf("re-encrypted %d passwords, failed to re-encrypt %d passwords", reencrypted, failed)
+	} else if reencrypted > 0 {
+		l.LogWithFields(ctx).Infof("re-encrypted %d passwords", reencrypted)
+	}
+	return reencrypted, failed
+}
+
+// holdSecretReencryptionLease acquires the lease of the re-encryption, or renews it when the replica already holds it
+func (e *ExternalInterface) holdSecretReencryptionLease(ctx context.Context) bool {
+	acquired, err := e.AcquireLease(secretReencryptionLease, secretReencryptionLeaseTTL)
+	if err != nil {
+		l.LogWithFields(ctx).Error("unable to acquire the lease of the secret re-encryption: " + err.Error())
+		return false
+	}
+	return acquired
+}
+
+// reencryptSecret re-encrypts the secret and replaces it in the entries holding it, and returns the number
+// of re-encrypted and failed entries. The replaced secret is deleted when all the entries hold the new one,
+// and the new secret is deleted when none of them does, as their passwords were changed in the meantime.
+func (e *ExternalInterface) reencryptSecret(ctx context.Context, secret []byte, holders []secretHolder) (int, int) {
+	newSecret, reencrypt, err := e.ReencryptPassword(secret)
+	if err != nil {
+		for _, holder := range holders {
+			l.LogWithFields(ctx).Error("unable to re-encrypt the password of " + holder.table + ":" + holder.key + ": " + err.Error())
+		}
+		return 0, len(holders)
+	}
+	if !reencrypt {
+		return 0, 0
+	}
+	var reencrypted, failed int
+	for _, holder := range holders {
+		if dbErr := e.UpdateStoredPassword(holder.table, holder.key, secret, newSecret); dbErr != nil {
+			l.LogWithFields(ctx).Error("unable to re-encrypt the password of " + holder.table + ":" + holder.key + ": " + dbErr.Error())
+			failed++
+			continue
+		}
+		reencrypted++
+	}
+	switch reencrypted {
+	case len(holders):
+		e.deleteSecrets(ctx, secret)
+	case 0:
+		e.deleteSecrets(ctx, newSecret)
+	}
+	return reencrypted, failed
+}
+
+// deleteSecrets removes the secrets, which are no longer stored, from the secret provider
+func (e *ExternalInterface) deleteSecrets(ctx context.Context, secrets ...[]byte) {
+	deleted := make(map[string]bool, len(secrets))
+	for _, secret := range secrets {
+		if len(secret) == 0 || deleted[string(secret)] {
+			continue
+		}
+		deleted[string(secret)] = true
+		if err := e.DeleteSecret(secret); err != nil {
+			l.LogWithFields(ctx).Error("unable to delete a replaced password from the secret provider: " + err.Error())
+		}
+	}
+}
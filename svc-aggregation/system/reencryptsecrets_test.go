@@ -0,0 +1,166 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+)
+
+// fakeSecretStore holds the encrypted passwords of the secret tables, keyed by table and key
+type fakeSecretStore struct {
+	passwords map[string]map[string][]byte
+	// changedKey is the key whose password is changed while it is re-encrypted
+	changedKey string
+	// reencrypted holds the passwords given for re-encryption
+	reencrypted []string
+	// deleted holds the passwords removed from the secret provider
+	deleted []string
+}
+
+func (s *fakeSecretStore) getAllKeysFromTable(table string) ([]string, error) {
+	entries, ok := s.passwords[table]
+	if !ok {
+		return nil, fmt.Errorf("error while trying to get all keys from table - %v", table)
+	}
+	var keys []string
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	// a key of a deleted entry
+	return append(keys, "deleted"), nil
+}
+
+func (s *fakeSecretStore) getStoredPassword(table, key string) ([]byte, *errors.Error) {
+	password, ok := s.passwords[table][key]
+	if !ok {
+		return nil, errors.PackError(errors.DBKeyNotFound, "error: data with key ", key, " does not exist")
+	}
+	return password, nil
+}
+
+func (s *fakeSecretStore) updateStoredPassword(table, key string, oldPassword, newPassword []byte) *errors.Error {
+	if key == s.changedKey || !bytes.Equal(s.passwords[table][key], oldPassword) {
+		return errors.PackError(errors.DBUpdateFailed, "error: password of ", table, ":", key, " is changed")
+	}
+	s.passwords[table][key] = newPassword
+	return nil
+}
+
+// reencryptPassword re-encrypts the passwords of the old key, prefixed with v1:, with the new key
+func (s *fakeSecretStore) reencryptPassword(password []byte) ([]byte, bool, error) {
+	s.reencrypted = append(s.reencrypted, string(password))
+	switch {
+	case bytes.HasPrefix(password, []byte("v2:")):
+		return nil, false, nil
+	case bytes.HasPrefix(password, []byte("v1:")):
+		return append([]byte("v2:"), password[3:]...), true, nil
+	}
+	return nil, false, fmt.Errorf("error while trying to decrypt password")
+}
+
+func (s *fakeSecretStore) deleteSecret(secret []byte) error {
+	s.deleted = append(s.deleted, string(secret))
+	return nil
+}
+
+func TestExternalInterface_reencryptSecrets(t *testing.T) {
+	store := &fakeSecretStore{
+		passwords: map[string]map[string][]byte{
+			"System": {
+				"7a2c6100-67da-5fd6-ab82-6870d29c7279": []byte("v1:system"),
+				"b2cc8a3f-e2b1-4b8c-8c6e-5b8f4e0c9b2a": []byte("v2:system"),
+				"changed":                              []byte("v1:changed"),
+			},
+			"Plugin": {
+				"GRF": []byte("v1:plugin"),
+				"ILO": []byte("invalid"),
+			},
+			"AggregationSource": {
+				// the password of the BMC is shared by its System entry and aggregation source
+				"/redfish/v1/AggregationService/AggregationSources/7a2c6100-67da-5fd6-ab82-6870d29c7279.1": []byte("v1:system"),
+				"/redfish/v1/AggregationService/AggregationSources/plugin":                                 []byte("v1:source"),
+				"/redfish/v1/AggregationService/AggregationSources/nopassword":                             nil,
+			},
+		},
+		changedKey: "changed",
+	}
+	leaseHeld := false
+	e := &ExternalInterface{
+		GetAllKeysFromTable:  store.getAllKeysFromTable,
+		GetStoredPassword:    store.getStoredPassword,
+		UpdateStoredPassword: store.updateStoredPassword,
+		ReencryptPassword:    store.reencryptPassword,
+		DeleteSecret:         store.deleteSecret,
+		AcquireLease: func(name string, ttl time.Duration) (bool, *errors.Error) {
+			return leaseHeld, nil
+		},
+	}
+
+	// the passwords are re-encrypted by the replica holding the lease
+	if reencrypted, failed := e.reencryptSecrets(context.Background()); reencrypted != 0 || failed != 0 || len(store.reencrypted) != 0 {
+		t.Fatalf("reencryptSecrets() without the lease = %v, %v, want 0, 0", reencrypted, failed)
+	}
+	leaseHeld = true
+
+	reencrypted, failed := e.reencryptSecrets(context.Background())
+	if reencrypted != 4 || failed != 2 {
+		t.Errorf("reencryptSecrets() = %v, %v, want 4, 2", reencrypted, failed)
+	}
+	// the shared password is re-encrypted once
+	sort.Strings(store.reencrypted)
+	if want := []string{"invalid", "v1:changed", "v1:plugin", "v1:source", "v1:system", "v2:system"}; !reflect.DeepEqual(store.reencrypted, want) {
+		t.Errorf("reencryptSecrets() re-encrypted %v, want %v", store.reencrypted, want)
+	}
+	if store.passwords["System"]["7a2c6100-67da-5fd6-ab82-6870d29c7279"] == nil ||
+		!bytes.Equal(store.passwords["System"]["7a2c6100-67da-5fd6-ab82-6870d29c7279"],
+			store.passwords["AggregationSource"]["/redfish/v1/AggregationService/AggregationSources/7a2c6100-67da-5fd6-ab82-6870d29c7279.1"]) {
+		t.Errorf("reencryptSecrets() stored different passwords for the System entry and the aggregation source")
+	}
+	// the replaced passwords and the password which lost the swap are deleted
+	sort.Strings(store.deleted)
+	if want := []string{"v1:plugin", "v1:source", "v1:system", "v2:changed"}; !reflect.DeepEqual(store.deleted, want) {
+		t.Errorf("reencryptSecrets() deleted %v, want %v", store.deleted, want)
+	}
+	for table, entries := range store.passwords {
+		for key, password := range entries {
+			wantPrefix := "v2:"
+			switch key {
+			case "changed":
+				wantPrefix = "v1:"
+			case "ILO":
+				wantPrefix = "invalid"
+			case "/redfish/v1/AggregationService/AggregationSources/nopassword":
+				wantPrefix = ""
+			}
+			if !strings.HasPrefix(string(password), wantPrefix) {
+				t.Errorf("password of %s:%s = %s, want prefix %s", table, key, password, wantPrefix)
+			}
+		}
+	}
+
+	// the passwords are re-encrypted only once
+	if reencrypted, _ = e.reencryptSecrets(context.Background()); reencrypted != 0 {
+		t.Errorf("reencryptSecrets() of the re-encrypted passwords = %v, want 0", reencrypted)
+	}
+}
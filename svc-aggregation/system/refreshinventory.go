@@ -0,0 +1,394 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+)
+
+// inventoryRefresh holds the state of the refresh of a resource of a BMC and its subordinate resources
+type inventoryRefresh struct {
+	e   *ExternalInterface
+	req getResourceRequest
+	// rootOID is the URI of the refreshed resource at the BMC
+	rootOID string
+	// skipList holds the names of the resources which are not refreshed under a top level resource
+	skipList []string
+	// stored holds the tables of the stored resources of the subtree, keyed by URI
+	stored map[string]string
+	// etags holds the ETags of the stored resources of the BMC, keyed by URI
+	etags map[string]string
+	// traversed holds the URIs at the BMC of the fetched resources
+	traversed map[string]bool
+	// found holds the URIs of the resources found in the subtree
+	found map[string]bool
+	// failed holds the URIs of the resources which couldn't be fetched
+	failed             []string
+	updated, unchanged int
+}
+
+// RefreshResourceInventory is the handler for refreshing the resource named in an event and its subordinate
+// resources, instead of rediscovering the whole system. The resources whose ETag is unchanged are not saved again.
+// When the refresh fails the BMC is rediscovered.
+func (e *ExternalInterface) RefreshResourceInventory(ctx context.Context, deviceUUID, resourceURI string) {
+	resourceURI = strings.TrimSuffix(resourceURI, "/")
+	l.LogWithFields(ctx).Info("Refresh of " + resourceURI + " of the BMC with ID " + deviceUUID + " is started.")
+	systemURLs, err := e.getDeviceSystemURLs(deviceUUID)
+	if err != nil {
+		l.LogWithFields(ctx).Error("Refresh of " + resourceURI + " can't be processed: " + err.Error())
+		return
+	}
+	systemURL := systemURLs[0]
+
+	// check whether delete operation for the system is initiated
+	systemOperation, dbErr := agmodel.GetSystemOperationInfo(systemURL)
+	if dbErr != nil && errors.DBKeyNotFound != dbErr.ErrNo() {
+		l.LogWithFields(ctx).Error("Refresh of " + resourceURI + " can't be processed " + dbErr.Error())
+		return
+	}
+	if systemOperation.Operation == "Delete" {
+		l.LogWithFields(ctx).Error("Refresh of " + resourceURI + " can't be processed, " +
+			systemOperation.Operation + " operation is under progress")
+		return
+	}
+	// Add system operation info to db to block the delete request for respective system
+	systemOperation.Operation = "InventoryRediscovery"
+	if dbErr = systemOperation.AddSystemOperationInfo(systemURL); dbErr != nil {
+		l.LogWithFields(ctx).Error("Refresh of " + resourceURI + " can't be processed " + dbErr.Error())
+		return
+	}
+	req, err := e.getDeviceRequest(ctx, deviceUUID)
+	if err == nil {
+		err = e.refreshResourceInventory(ctx, req, systemURL, resourceURI)
+	}
+	agmodel.DeleteSystemOperationInfo(systemURL)
+	if err != nil {
+		l.LogWithFields(ctx).Warn("Refresh of " + resourceURI + " failed, rediscovering the BMC with ID " +
+			deviceUUID + ": " + err.Error())
+		e.RediscoverSystemInventory(ctx, deviceUUID, systemURL, true)
+		return
+	}
+	l.LogWithFields(ctx).Info("Refresh of " + resourceURI + " of the BMC with ID " + deviceUUID + " is now complete.")
+}
+
+// getDeviceSystemURLs returns the sorted URIs of the computer systems of the BMC with the given deviceUUID
+func (e *ExternalInterface) getDeviceSystemURLs(deviceUUID string) ([]string, error) {
+	keys, err := e.GetAllMatchingDetails("ComputerSystem", deviceUUID, common.InMemory)
+	if err != nil {
+		return nil, err
+	}
+	var systemURLs []string
+	for _, key := range keys {
+		if strings.HasPrefix(key, "/redfish/v1/Systems/"+deviceUUID+".") {
+			systemURLs = append(systemURLs, key)
+		}
+	}
+	if len(systemURLs) == 0 {
+		return nil, fmt.Errorf("no computer system of the BMC with ID %s is found", deviceUUID)
+	}
+	sort.Strings(systemURLs)
+	return systemURLs, nil
+}
+
+// refreshResourceInventory fetches the resource with the given URI and its subordinate resources
+// from the plugin of the request, saves the changed ones and removes the stored ones which are no longer present
+func (e *ExternalInterface) refreshResourceInventory(ctx context.Context, req getResourceRequest, systemURL, resourceURI string) error {
+	deviceUUID := req.DeviceUUID
+	tokens := strings.Split(resourceURI, "/")
+	if len(tokens) < 5 || !strings.HasPrefix(resourceURI, "/redfish/v1/") || !strings.HasPrefix(tokens[4], deviceUUID+".") {
+		return fmt.Errorf("%s is not a resource of the BMC with ID %s", resourceURI, deviceUUID)
+	}
+	var skipList []string
+	switch tokens[3] {
+	case "Systems":
+		skipList = config.Data.AddComputeSkipResources.SkipResourceListUnderSystem
+	case "Chassis":
+		skipList = config.Data.AddComputeSkipResources.SkipResourceListUnderChassis
+	case "Managers":
+		skipList = config.Data.AddComputeSkipResources.SkipResourceListUnderManager
+	default:
+		return fmt.Errorf("refresh of %s is not supported", resourceURI)
+	}
+	bmcID := strings.TrimPrefix(tokens[4], deviceUUID+".")
+	tokens[4] = bmcID
+	if tokens[3] == "Systems" {
+		req.SystemID = bmcID
+	}
+	r := newInventoryRefresh(e, req, strings.Join(tokens, "/"), skipList)
+	oldInventory, err := r.refresh(ctx, resourceURI)
+	if err != nil {
+		return err
+	}
+	if r.updated == 0 {
+		return nil
+	}
+	if tokens[3] == "Systems" {
+		e.updateSystemIndex(ctx, req, systemURL)
+	}
+	e.recordInventoryChanges(ctx, deviceUUID, systemURL, resourceURI, oldInventory)
+	return nil
+}
+
+// newInventoryRefresh returns the state of the refresh of the resource with the URI rootOID at the BMC
+func newInventoryRefresh(e *ExternalInterface, req getResourceRequest, rootOID string, skipList []string) *inventoryRefresh {
+	return &inventoryRefresh{
+		e:         e,
+		req:       req,
+		rootOID:   rootOID,
+		skipList:  skipList,
+		stored:    make(map[string]string),
+		traversed: make(map[string]bool),
+		found:     make(map[string]bool),
+	}
+}
+
+// refresh refreshes the stored resources under the resourceURI, the ones which are no longer
+// present are removed. It returns the stored resources as they were before the refresh.
+func (r *inventoryRefresh) refresh(ctx context.Context, resourceURI string) (map[string]string, error) {
+	deviceUUID := r.req.DeviceUUID
+	// the stored resources are read to remove the ones which are no longer present and to find the changes
+	keys, dbErr := r.e.GetAllMatchingDetails("*", resourceURI, common.InMemory)
+	if dbErr != nil {
+		return nil, dbErr
+	}
+	oldInventory := make(map[string]string)
+	for _, key := range keys {
+		resourceDetails := strings.SplitN(key, ":", 2)
+		if len(resourceDetails) != 2 || !isSubordinateURI(resourceDetails[1], resourceURI) {
+			continue
+		}
+		switch resourceDetails[0] {
+		case "SystemReset", "SystemOperation":
+			continue
+		}
+		resource, dbErr := r.e.GetResource(resourceDetails[0], resourceDetails[1])
+		if dbErr != nil {
+			continue
+		}
+		r.stored[resourceDetails[1]] = resourceDetails[0]
+		oldInventory[resourceDetails[1]] = resource
+	}
+	if r.etags, dbErr = r.e.GetResourceETags(deviceUUID); dbErr != nil {
+		if dbErr.ErrNo() != errors.DBKeyNotFound {
+			return nil, dbErr
+		}
+		r.etags = make(map[string]string)
+	}
+
+	if err := r.refreshResource(ctx, r.rootOID); err != nil {
+		return nil, err
+	}
+	for uri, table := range r.stored {
+		if r.found[uri] || r.isFailed(uri) {
+			continue
+		}
+		if dbErr := r.e.Delete(table, uri, common.InMemory); dbErr != nil {
+			return nil, dbErr
+		}
+		delete(r.etags, uri)
+		r.updated++
+	}
+	if dbErr := r.e.SaveResourceETags(r.etags, deviceUUID); dbErr != nil {
+		l.LogWithFields(ctx).Error("unable to save the resource ETags of the BMC with ID " + deviceUUID + ": " + dbErr.Error())
+	}
+	l.LogWithFields(ctx).Infof("refreshed %s: %d resources updated, %d resources unchanged", resourceURI, r.updated, r.unchanged)
+	return oldInventory, nil
+}
+
+// refreshResource fetches the resource with the given URI at the BMC, saves it when its ETag has changed
+// and refreshes its subordinate resources. The stored resource is fetched with its ETag in If-None-Match,
+// when the BMC answers that the resource is not modified its subordinate resources are refreshed
+// with the links of the stored resource, as the ETag doesn't cover the subordinate resources.
+func (r *inventoryRefresh) refreshResource(ctx context.Context, oid string) error {
+	r.traversed[oid] = true
+	req := r.req
+	req.OID = oid
+	uri := r.keyOf(oid)
+	reqCtx := ctx
+	if _, stored := r.stored[uri]; stored && r.etags[uri] != "" {
+		reqCtx = context.WithValue(ctx, common.IfNoneMatch, r.etags[uri])
+	}
+	body, _, getResponse, err := contactPlugin(reqCtx, req, "error while trying to get the "+oid+" details: ")
+	var resourceData map[string]interface{}
+	if getResponse.StatusCode == http.StatusNotModified {
+		if resourceData, err = r.storedResource(uri); err != nil {
+			return err
+		}
+		r.found[uri] = true
+		r.unchanged++
+		return r.refreshLinks(ctx, oid, resourceData)
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, &resourceData); err != nil {
+		return fmt.Errorf("error while trying unmarshal %s: %v", oid, err)
+	}
+	r.found[uri] = true
+	_, memberFlag := resourceData["Members"]
+	table, ok := r.stored[uri]
+	if !ok {
+		table = getResourceName(oid, memberFlag)
+		if r.isTopLevel(oid) && strings.HasPrefix(oid, "/redfish/v1/Systems/") {
+			table = "ComputerSystem"
+		}
+	}
+	if memberFlag && strings.Contains(table, "VolumesCollection") {
+		addVolumeCollectionCapabilities(oid, resourceData)
+		body, _ = json.Marshal(resourceData)
+	}
+	//replacing the uuid while saving the data
+	updatedResourceData := updateResourceDataWithUUID(string(body), req.DeviceUUID)
+	etag := resourceETag(resourceData, updatedResourceData)
+	if _, stored := r.stored[uri]; stored && r.etags[uri] == etag {
+		r.unchanged++
+	} else {
+		if err := r.e.GenericSave([]byte(updatedResourceData), table, uri); err != nil {
+			return err
+		}
+		r.etags[uri] = etag
+		r.updated++
+	}
+	return r.refreshLinks(ctx, oid, resourceData)
+}
+
+// refreshLinks refreshes the subordinate resources linked in the resource with the given URI at the BMC
+func (r *inventoryRefresh) refreshLinks(ctx context.Context, oid string, resourceData map[string]interface{}) error {
+	var retrievalLinks = make(map[string]bool)
+	getLinks(resourceData, retrievalLinks, false)
+	links := make([]string, 0, len(retrievalLinks))
+	for link := range retrievalLinks {
+		links = append(links, link)
+	}
+	sort.Strings(links)
+	for _, link := range links {
+		if !r.isRetrievable(link, oid) {
+			continue
+		}
+		if err := r.refreshResource(ctx, link); err != nil {
+			// the stored resources under the link are kept since it is unknown whether they are still present
+			l.LogWithFields(ctx).Warn("unable to refresh " + link + ": " + err.Error())
+			r.failed = append(r.failed, r.keyOf(link))
+		}
+	}
+	return nil
+}
+
+// storedResource returns the stored resource with the given URI, with the links as they are at the BMC
+func (r *inventoryRefresh) storedResource(uri string) (map[string]interface{}, error) {
+	data, dbErr := r.e.GetResource(r.stored[uri], uri)
+	if dbErr != nil {
+		return nil, fmt.Errorf("error while trying to get the stored %s: %v", uri, dbErr.Error())
+	}
+	uuid := r.req.DeviceUUID
+	data = strings.NewReplacer(
+		"/redfish/v1/Systems/"+uuid+".", "/redfish/v1/Systems/",
+		"/redfish/v1/Managers/"+uuid+".", "/redfish/v1/Managers/",
+		"/redfish/v1/Chassis/"+uuid+".", "/redfish/v1/Chassis/",
+	).Replace(data)
+	var resourceData map[string]interface{}
+	if err := json.Unmarshal([]byte(data), &resourceData); err != nil {
+		return nil, fmt.Errorf("error while trying unmarshal the stored %s: %v", uri, err)
+	}
+	return resourceData, nil
+}
+
+// isRetrievable tells whether the link found in the resource with the given URI is refreshed,
+// the links are followed the same way as in the discovery of the BMC
+func (r *inventoryRefresh) isRetrievable(link, parentOID string) bool {
+	if !strings.HasPrefix(link, r.rootOID+"/") || r.traversed[link] {
+		return false
+	}
+	if !r.isTopLevel(parentOID) {
+		return checkRetrieval(link, parentOID, r.traversed)
+	}
+	for _, resourceName := range r.skipList {
+		if strings.Contains(link, resourceName) {
+			return false
+		}
+	}
+	return true
+}
+
+// isTopLevel tells whether the URI is of a computer system, chassis or manager
+func (r *inventoryRefresh) isTopLevel(oid string) bool {
+	return strings.Count(oid, "/") == 4
+}
+
+// isFailed tells whether the resource with the given URI is under a resource which couldn't be fetched
+func (r *inventoryRefresh) isFailed(uri string) bool {
+	for _, failedURI := range r.failed {
+		if isSubordinateURI(uri, failedURI) {
+			return true
+		}
+	}
+	return false
+}
+
+// keyOf returns the URI of the resource with the given URI at the BMC
+func (r *inventoryRefresh) keyOf(oid string) string {
+	if strings.Contains(oid, "/redfish/v1/Managers/") || strings.Contains(oid, "/redfish/v1/Chassis/") {
+		oid = strings.Replace(oid, "/redfish/v1/Managers/", "/redfish/v1/Managers/"+r.req.DeviceUUID+".", -1)
+		return strings.Replace(oid, "/redfish/v1/Chassis/", "/redfish/v1/Chassis/"+r.req.DeviceUUID+".", -1)
+	}
+	return keyFormation(oid, r.req.SystemID, r.req.DeviceUUID)
+}
+
+// updateSystemIndex updates the search index of the computer system with the stored inventory
+func (e *ExternalInterface) updateSystemIndex(ctx context.Context, req getResourceRequest, systemURL string) {
+	data, dbErr := e.GetResource("ComputerSystem", systemURL)
+	if dbErr != nil {
+		l.LogWithFields(ctx).Error("unable to update the search index of " + systemURL + ": " + dbErr.Error())
+		return
+	}
+	var computeSystem map[string]interface{}
+	if err := json.Unmarshal([]byte(data), &computeSystem); err != nil {
+		l.LogWithFields(ctx).Error("unable to update the search index of " + systemURL + ": " + err.Error())
+		return
+	}
+	computeSystemUUID, _ := computeSystem["UUID"].(string)
+	searchForm := createServerSearchIndex(ctx, computeSystem, systemURL, req.DeviceUUID)
+	if err := agmodel.UpdateIndex(searchForm, systemURL, computeSystemUUID, req.BMCAddress); err != nil {
+		l.LogWithFields(ctx).Error("unable to update the search index of " + systemURL + ": " + err.Error())
+	}
+}
+
+// isSubordinateURI tells whether the uri is the parentURI or a URI under it
+func isSubordinateURI(uri, parentURI string) bool {
+	return uri == parentURI || strings.HasPrefix(uri, parentURI+"/")
+}
+
+// resourceETag returns the ETag of the resource, which is the one given by the BMC
+// or else a weak ETag formed from the hash of the resource
+func resourceETag(resourceData map[string]interface{}, data string) string {
+	if etag, ok := resourceData["@odata.etag"].(string); ok && etag != "" {
+		return etag
+	}
+	sum := sha256.Sum256([]byte(data))
+	return `W/"` + hex.EncodeToString(sum[:16]) + `"`
+}
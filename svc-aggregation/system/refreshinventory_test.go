@@ -0,0 +1,216 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+)
+
+const (
+	mockRefreshDeviceUUID = "7a2c6100-67da-5fd6-ab82-6870d29c7279"
+	mockRefreshAdapters   = "/redfish/v1/Chassis/" + mockRefreshDeviceUUID + ".1/NetworkAdapters"
+)
+
+var mockRefreshPluginResponses = map[string]string{
+	"/redfish/v1/Chassis/1/NetworkAdapters": `{"@odata.id":"/redfish/v1/Chassis/1/NetworkAdapters","Members":[` +
+		`{"@odata.id":"/redfish/v1/Chassis/1/NetworkAdapters/1"},{"@odata.id":"/redfish/v1/Chassis/1/NetworkAdapters/2"},` +
+		`{"@odata.id":"/redfish/v1/Chassis/1/NetworkAdapters/4"}]}`,
+	"/redfish/v1/Chassis/1/NetworkAdapters/1": `{"@odata.id":"/redfish/v1/Chassis/1/NetworkAdapters/1","@odata.etag":"W/\"1\"","Id":"1"}`,
+	"/redfish/v1/Chassis/1/NetworkAdapters/2": `{"@odata.id":"/redfish/v1/Chassis/1/NetworkAdapters/2","Id":"2"}`,
+	"/redfish/v1/Chassis/1/NetworkAdapters/1/Ports": `{"@odata.id":"/redfish/v1/Chassis/1/NetworkAdapters/1/Ports","Members":[` +
+		`{"@odata.id":"/redfish/v1/Chassis/1/NetworkAdapters/1/Ports/1"}]}`,
+	"/redfish/v1/Chassis/1/NetworkAdapters/1/Ports/1": `{"@odata.id":"/redfish/v1/Chassis/1/NetworkAdapters/1/Ports/1","Id":"1","LinkStatus":"LinkUp"}`,
+}
+
+func mockRefreshContactClient(ctx context.Context, url, method, token string, odataID string, body interface{}, loginCredential map[string]string) (*http.Response, error) {
+	oid := strings.Replace(odataID, "/ODIM/", "/redfish/", 1)
+	data, ok := mockRefreshPluginResponses[oid]
+	if etag, _ := ctx.Value(common.IfNoneMatch).(string); ok && etag != "" && strings.Contains(data, `"@odata.etag":`+strconv.Quote(etag)) {
+		return &http.Response{
+			StatusCode: http.StatusNotModified,
+			Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+		}, nil
+	}
+	if !ok {
+		return &http.Response{
+			StatusCode: http.StatusInternalServerError,
+			Body:       ioutil.NopCloser(bytes.NewBufferString("internal error")),
+		}, nil
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(data)),
+	}, nil
+}
+
+func TestInventoryRefresh_refresh(t *testing.T) {
+	config.SetUpMockConfig(t)
+	stored := map[string]string{
+		"NetworkAdaptersCollection:" + mockRefreshAdapters: `{"Members":[]}`,
+		"NetworkAdapters:" + mockRefreshAdapters + "/1": `{"@odata.id":"` + mockRefreshAdapters + `/1","Id":"1",` +
+			`"Ports":{"@odata.id":"` + mockRefreshAdapters + `/1/Ports"}}`,
+		"Ports:" + mockRefreshAdapters + "/1/Ports/1":   `{"Id":"1"}`,
+		"NetworkAdapters:" + mockRefreshAdapters + "/3": `{"Id":"3"}`,
+		"NetworkAdapters:" + mockRefreshAdapters + "/4": `{"Id":"4"}`,
+		"Ports:" + mockRefreshAdapters + "/4/Ports/1":   `{"Id":"1"}`,
+		"SystemOperation:" + mockRefreshAdapters:        `{"Operation":"InventoryRediscovery"}`,
+	}
+	saved := make(map[string]string)
+	var deleted []string
+	var savedETags map[string]string
+
+	e := getMockExternalInterface()
+	e.GetAllMatchingDetails = func(table, pattern string, dbType common.DbType) ([]string, *errors.Error) {
+		var keys []string
+		for key := range stored {
+			keys = append(keys, key)
+		}
+		return keys, nil
+	}
+	e.GetResource = func(table, key string) (string, *errors.Error) {
+		return stored[table+":"+key], nil
+	}
+	e.GenericSave = func(data []byte, table, key string) error {
+		saved[table+":"+key] = string(data)
+		return nil
+	}
+	e.Delete = func(table, key string, dbType common.DbType) *errors.Error {
+		deleted = append(deleted, table+":"+key)
+		return nil
+	}
+	e.GetResourceETags = func(deviceUUID string) (map[string]string, *errors.Error) {
+		return map[string]string{mockRefreshAdapters + "/1": `W/"1"`, mockRefreshAdapters + "/3": `W/"3"`}, nil
+	}
+	e.SaveResourceETags = func(etags map[string]string, deviceUUID string) *errors.Error {
+		savedETags = etags
+		return nil
+	}
+	req := getResourceRequest{
+		ContactClient:  mockRefreshContactClient,
+		HTTPMethodType: http.MethodGet,
+		DeviceUUID:     mockRefreshDeviceUUID,
+		Plugin:         agmodel.Plugin{IP: "localhost", Port: "9091", PreferredAuthType: "BasicAuth"},
+	}
+
+	r := newInventoryRefresh(e, req, "/redfish/v1/Chassis/1/NetworkAdapters", config.Data.AddComputeSkipResources.SkipResourceListUnderChassis)
+	oldInventory, err := r.refresh(context.Background(), mockRefreshAdapters)
+	if err != nil {
+		t.Fatalf("refresh() error = %v", err)
+	}
+	if len(oldInventory) != 6 {
+		t.Errorf("refresh() returned %d stored resources, want 6", len(oldInventory))
+	}
+	var savedKeys []string
+	for key := range saved {
+		savedKeys = append(savedKeys, key)
+	}
+	sort.Strings(savedKeys)
+	// the adapter which is not modified isn't saved but its changed ports are, and the adapter
+	// which couldn't be fetched is kept
+	wantSaved := []string{
+		"NetworkAdapters:" + mockRefreshAdapters + "/2",
+		"NetworkAdaptersCollection:" + mockRefreshAdapters,
+		"Ports:" + mockRefreshAdapters + "/1/Ports/1",
+		"PortsCollection:" + mockRefreshAdapters + "/1/Ports",
+	}
+	if !reflect.DeepEqual(savedKeys, wantSaved) {
+		t.Errorf("saved resources = %v, want %v", savedKeys, wantSaved)
+	}
+	if want := []string{"NetworkAdapters:" + mockRefreshAdapters + "/3"}; !reflect.DeepEqual(deleted, want) {
+		t.Errorf("deleted resources = %v, want %v", deleted, want)
+	}
+	if !strings.Contains(saved["NetworkAdapters:"+mockRefreshAdapters+"/2"], mockRefreshAdapters+"/2") {
+		t.Errorf("saved resource %s isn't updated with the device UUID", saved["NetworkAdapters:"+mockRefreshAdapters+"/2"])
+	}
+	if _, ok := savedETags[mockRefreshAdapters+"/3"]; ok {
+		t.Errorf("ETag of the removed resource is still saved")
+	}
+	if savedETags[mockRefreshAdapters+"/1"] != `W/"1"` || savedETags[mockRefreshAdapters+"/2"] == "" {
+		t.Errorf("saved ETags = %v", savedETags)
+	}
+	if r.updated != 5 || r.unchanged != 1 {
+		t.Errorf("refresh() updated %d and skipped %d resources, want 5 and 1", r.updated, r.unchanged)
+	}
+
+	// a second refresh doesn't save anything
+	deleted = nil
+	e.GetResourceETags = func(deviceUUID string) (map[string]string, *errors.Error) {
+		return savedETags, nil
+	}
+	delete(stored, "NetworkAdapters:"+mockRefreshAdapters+"/3")
+	for key, data := range saved {
+		stored[key] = data
+	}
+	saved = make(map[string]string)
+	r = newInventoryRefresh(e, req, "/redfish/v1/Chassis/1/NetworkAdapters", nil)
+	if _, err := r.refresh(context.Background(), mockRefreshAdapters); err != nil {
+		t.Fatalf("refresh() error = %v", err)
+	}
+	if len(saved) != 0 || len(deleted) != 0 || r.updated != 0 {
+		t.Errorf("refresh() of the unchanged resources saved %v and deleted %v", saved, deleted)
+	}
+
+	// the refresh fails when the resource can't be fetched
+	r = newInventoryRefresh(e, req, "/redfish/v1/Chassis/1/NetworkAdapters/4", nil)
+	if _, err := r.refresh(context.Background(), mockRefreshAdapters+"/4"); err == nil {
+		t.Errorf("refresh() of the resource which can't be fetched didn't fail")
+	}
+}
+
+func TestInventoryRefresh_isRetrievable(t *testing.T) {
+	config.SetUpMockConfig(t)
+	r := newInventoryRefresh(getMockExternalInterface(), getResourceRequest{}, "/redfish/v1/Systems/1",
+		config.Data.AddComputeSkipResources.SkipResourceListUnderSystem)
+	tests := []struct {
+		link, parentOID string
+		want            bool
+	}{
+		{"/redfish/v1/Systems/1/Memory", "/redfish/v1/Systems/1", true},
+		{"/redfish/v1/Systems/1/LogServices", "/redfish/v1/Systems/1", false},
+		{"/redfish/v1/Chassis/1", "/redfish/v1/Systems/1", false},
+		{"/redfish/v1/Systems/1/Memory/1", "/redfish/v1/Systems/1/Memory", true},
+	}
+	for _, tt := range tests {
+		if got := r.isRetrievable(tt.link, tt.parentOID); got != tt.want {
+			t.Errorf("isRetrievable(%s, %s) = %v, want %v", tt.link, tt.parentOID, got, tt.want)
+		}
+	}
+}
+
+func TestResourceETag(t *testing.T) {
+	if got := resourceETag(map[string]interface{}{"@odata.etag": `W/"1"`}, "{}"); got != `W/"1"` {
+		t.Errorf("resourceETag() = %v, want the ETag of the BMC", got)
+	}
+	etag := resourceETag(map[string]interface{}{}, `{"Id":"1"}`)
+	if !strings.HasPrefix(etag, `W/"`) || etag != resourceETag(map[string]interface{}{}, `{"Id":"1"}`) {
+		t.Errorf("resourceETag() = %v, want a weak ETag formed from the data", etag)
+	}
+	if etag == resourceETag(map[string]interface{}{}, `{"Id":"2"}`) {
+		t.Errorf("resourceETag() is same for different data")
+	}
+}
@@ -98,6 +98,8 @@ func (e *ExternalInterface) Reset(ctx context.Context, taskID string, sessionUse
 		resp := common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errorMessage, []interface{}{invalidProperties}, taskInfo)
 		return resp
 	}
+	ctx, done := common.TrackTask(ctx, taskID)
+	defer done()
 	// subTaskChan is a buffered channel with buffer size equal to total number of resources.
 	// this also helps while cancelling the task. even if the reader is not available for reading
 	// the channel buffer will collect them and allows gracefull exit for already spanned goroutines.
@@ -109,6 +111,7 @@ func (e *ExternalInterface) Reset(ctx context.Context, taskID string, sessionUse
 	ctxt := context.WithValue(ctx, common.ThreadName, common.ResetAggregate)
 	ctxt = context.WithValue(ctxt, common.ThreadID, strconv.Itoa(threadID))
 	threadID++
+	writeWG.Add(len(resetRequest.TargetURIs))
 	go func() {
 
 		for i := 0; i < len(resetRequest.TargetURIs); i++ {
@@ -131,6 +134,8 @@ func (e *ExternalInterface) Reset(ctx context.Context, taskID string, sessionUse
 							cancelled = true
 						}
 					}
+				case <-ctx.Done():
+					cancelled = true
 				}
 			}
 			writeWG.Done()
@@ -139,8 +144,11 @@ func (e *ExternalInterface) Reset(ctx context.Context, taskID string, sessionUse
 
 	var tempIndex int
 	for _, resource := range resetRequest.TargetURIs {
+		if ctx.Err() != nil {
+			// the task is cancelled, the remaining targets are not reset
+			break
+		}
 		wg.Add(1)
-		writeWG.Add(1)
 		// tempIndex is for checking batch size, its increment on each iteration
 		// if its equal to batch size then reinitilise.
 		// if batch size is 0 then reset all the systems without any kind of batch and ignore the DelayBetweenBatchesInSeconds
@@ -158,12 +166,19 @@ func (e *ExternalInterface) Reset(ctx context.Context, taskID string, sessionUse
 		}
 		if tempIndex == resetRequest.BatchSize && resetRequest.BatchSize != 0 {
 			tempIndex = 0
-			time.Sleep(time.Second * time.Duration(resetRequest.DelayBetweenBatchesInSeconds))
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Second * time.Duration(resetRequest.DelayBetweenBatchesInSeconds)):
+			}
 		}
 
 	}
 	wg.Wait()
 	writeWG.Wait()
+	if ctx.Err() != nil {
+		l.LogWithFields(ctx).Warn("reset task " + taskID + " is cancelled")
+		return e.cancelTask(ctx, taskID, targetURI, string(req.RequestBody), percentComplete)
+	}
 	taskStatus := common.OK
 	if partialResultFlag {
 		taskStatus = common.Warning
@@ -210,6 +225,8 @@ func (e *ExternalInterface) aggregateSystems(ctx context.Context, requestType, u
 	} else {
 		subTaskID = strArray[len(strArray)-1]
 	}
+	ctx, done := common.TrackTask(ctx, subTaskID)
+	defer done()
 	taskInfo := &common.TaskUpdateInfo{Context: ctx, TaskID: subTaskID, TargetURI: url, UpdateTask: e.UpdateTask, TaskRequest: reqBody}
 	aggregate, err1 := agmodel.GetAggregate(url)
 	if err1 != nil {
@@ -242,6 +259,7 @@ func (e *ExternalInterface) aggregateSystems(ctx context.Context, requestType, u
 	ctxt = context.WithValue(ctxt, common.ThreadID, strconv.Itoa(threadID))
 	threadID++
 	var wg1, writeWG sync.WaitGroup
+	writeWG.Add(len(aggregate.Elements))
 	go func() {
 		for i := 0; i < len(aggregate.Elements); i++ {
 			if !cancelled { // task cancelled check to determine whether to collect status codes.
@@ -263,6 +281,8 @@ func (e *ExternalInterface) aggregateSystems(ctx context.Context, requestType, u
 							cancelled = true
 						}
 					}
+				case <-ctx.Done():
+					cancelled = true
 				}
 			}
 			writeWG.Done()
@@ -271,7 +291,6 @@ func (e *ExternalInterface) aggregateSystems(ctx context.Context, requestType, u
 
 	for _, element := range aggregate.Elements {
 		wg1.Add(1)
-		writeWG.Add(1)
 		threadID := 1
 		resetCtxt := context.WithValue(ctxt, common.ThreadName, common.ResetSystem)
 		resetCtxt = context.WithValue(resetCtxt, common.ThreadID, strconv.Itoa(threadID))
@@ -281,6 +300,12 @@ func (e *ExternalInterface) aggregateSystems(ctx context.Context, requestType, u
 
 	wg1.Wait()
 	writeWG.Wait()
+	if ctx.Err() != nil {
+		subTaskChan <- http.StatusInternalServerError
+		l.LogWithFields(ctx).Warn("reset of the aggregate " + url + " is cancelled")
+		e.cancelTask(ctx, subTaskID, url, reqBody, percentComplete)
+		return
+	}
 	subTaskChan <- int32(resp.StatusCode)
 	taskStatus := common.OK
 	if partialResultFlag {
@@ -301,6 +326,7 @@ func (e *ExternalInterface) aggregateSystems(ctx context.Context, requestType, u
 		Message: "Request completed successfully",
 	}
 	resp.Body = args.CreateGenericErrorResponse()
+	common.AddCompletedOperation(taskID, "Reset of "+url)
 	var task = fillTaskData(subTaskID, url, reqBody, resp, common.Completed, taskStatus, percentComplete, http.MethodPost)
 	err = e.UpdateTask(ctx, task)
 	if err != nil && err.Error() == common.Cancelling {
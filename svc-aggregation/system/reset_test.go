@@ -128,7 +128,8 @@ func mockContactClient(ctx context.Context, url, method, token string, odataID s
 	host := strings.Split(url, "/ODIM")[0]
 	uid := uuid.NewV4().String()
 	if url == "https://localhost:9091/ODIM/v1/Systems/1/Actions/ComputerSystem.Reset" || url == "https://localhost:9091/ODIM/v1/Systems/1/Actions/ComputerSystem.Add" ||
-		url == "https://localhost:9091/ODIM/v1/Systems/1/Actions/ComputerSystem.SetDefaultBootOrder" {
+		url == "https://localhost:9091/ODIM/v1/Systems/1/Actions/ComputerSystem.SetDefaultBootOrder" ||
+		url == "https://localhost:9091/ODIM/v1/Systems/1/Bios/Settings" {
 		body := `{"MessageId": "` + response.Success + `"}`
 		return &http.Response{
 			StatusCode: http.StatusOK,
@@ -0,0 +1,614 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agcommon"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agresponse"
+	"github.com/google/uuid"
+)
+
+const (
+	// RotateAggregationSourceCredentialsURI is the target of the OEM action rotating the passwords of the BMC aggregation sources
+	RotateAggregationSourceCredentialsURI = "/redfish/v1/AggregationService/Actions/Oem/Odim.RotateAggregationSourceCredentials/"
+	// DefaultCredentialRotationConcurrency is the number of passwords rotated in parallel when the request has no MaxConcurrency
+	DefaultCredentialRotationConcurrency = 10
+
+	// CredentialRotationActionID is the action ID of the scheduled password rotation
+	CredentialRotationActionID = "232"
+	// CredentialRotationActionName is the action name of the scheduled password rotation
+	CredentialRotationActionName = "ScheduledCredentialRotation"
+
+	// CredentialRotationInProgress is the rotation status while the password is being changed on the BMC
+	CredentialRotationInProgress = "InProgress"
+	// CredentialRotationCompleted is the rotation status when the BMC and ODIM use the new password
+	CredentialRotationCompleted = "Completed"
+	// CredentialRotationFailed is the rotation status when the password is not changed on the BMC
+	CredentialRotationFailed = "Failed"
+	// CredentialRotationRolledBack is the rotation status when the new password is not verified
+	// and the old password is set back on the BMC
+	CredentialRotationRolledBack = "RolledBack"
+	// CredentialRotationRollbackFailed is the rotation status when the old password could not be set back on the BMC,
+	// the credentials of the aggregation source must be corrected by updating the aggregation source
+	CredentialRotationRollbackFailed = "RollbackFailed"
+
+	credentialRotationTable = "CredentialRotation"
+	// credentialRotationCheckInterval is how often the scheduled rotation looks for the passwords due for rotation
+	credentialRotationCheckInterval = time.Hour
+	// credentialRotationRetryInterval is the wait before the scheduled rotation retries an unsuccessful rotation
+	credentialRotationRetryInterval = 24 * time.Hour
+	// credentialRotationLeaseTTL is how long a replica holds the rotation of an aggregation source,
+	// the lease outlives the rotation, it is only there to free the aggregation source of a crashed replica
+	credentialRotationLeaseTTL = 10 * time.Minute
+
+	passwordUpperCaseCharacters = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	passwordLowerCaseCharacters = "abcdefghijklmnopqrstuvwxyz"
+	passwordDigits              = "0123456789"
+)
+
+var (
+	// PushRotatedCredentials function pointer for sharing the rotated credentials of a BMC with its plugin
+	PushRotatedCredentials = pushRotatedCredentials
+)
+
+// RotateAggregationSourceCredentials rotates the passwords of the BMC aggregation sources of the request,
+// at most MaxConcurrency in parallel. Once all the aggregation sources are processed,
+// the task completes with the report of the succeeded and the failed rotations.
+func (e *ExternalInterface) RotateAggregationSourceCredentials(ctx context.Context, taskID string, sessionUserName string, req *aggregatorproto.AggregatorRequest) response.RPC {
+	targetURI := RotateAggregationSourceCredentialsURI
+	var resp response.RPC
+	var percentComplete int32
+	var requestBody map[string]interface{}
+	json.Unmarshal(req.RequestBody, &requestBody)
+	taskRequest := l.MaskRequestBody(requestBody)
+	taskInfo := &common.TaskUpdateInfo{Context: ctx, TaskID: taskID, TargetURI: targetURI, UpdateTask: e.UpdateTask, TaskRequest: taskRequest}
+	err := e.UpdateTask(ctx, fillTaskData(taskID, targetURI, taskRequest, resp, common.Running, common.OK, percentComplete, http.MethodPost))
+	if err != nil {
+		errMsg := "error while starting the task: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+	ctx, done := common.TrackTask(ctx, taskID)
+	defer done()
+
+	var rotateRequest RotateAggregationSourceCredentials
+	if err = json.Unmarshal(req.RequestBody, &rotateRequest); err != nil {
+		errMsg := "unable to parse the credential rotation request: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+	}
+	aggregationSourceURIs := make([]string, 0, len(rotateRequest.AggregationSources))
+	for _, aggregationSource := range rotateRequest.AggregationSources {
+		aggregationSourceURIs = append(aggregationSourceURIs, strings.TrimSuffix(aggregationSource.OdataID, "/"))
+	}
+
+	results := e.rotateCredentialsInBulk(ctx, aggregationSourceURIs, rotateRequest.MaxConcurrency, func(processed int) {
+		if processed < len(aggregationSourceURIs) {
+			percentComplete = int32(processed * 100 / len(aggregationSourceURIs))
+			e.UpdateTask(ctx, fillTaskData(taskID, targetURI, taskRequest, resp, common.Running, common.OK, percentComplete, http.MethodPost))
+		}
+	})
+	if ctx.Err() != nil {
+		l.LogWithFields(ctx).Warn("credential rotation task " + taskID + " is cancelled")
+		return e.cancelTask(ctx, taskID, targetURI, taskRequest, percentComplete)
+	}
+
+	report := getCredentialRotationReport(results)
+	taskStatus := common.OK
+	if len(report.Failed) > 0 {
+		taskStatus = common.Warning
+		l.LogWithFields(ctx).Warnf("failed to rotate the passwords of %d of %d aggregation sources", len(report.Failed), len(aggregationSourceURIs))
+		if len(report.Succeeded) == 0 {
+			taskStatus = common.Critical
+		}
+	}
+	resp = response.RPC{
+		StatusCode:    http.StatusOK,
+		StatusMessage: response.Success,
+		Body:          report,
+	}
+	percentComplete = 100
+	e.UpdateTask(ctx, fillTaskData(taskID, targetURI, taskRequest, resp, common.Completed, taskStatus, percentComplete, http.MethodPost))
+	return resp
+}
+
+// PerformCredentialRotation rotates the passwords of the BMC aggregation sources
+// which are not rotated for the configured RotationIntervalInDays, the routine
+// does nothing while RotationIntervalInDays is 0
+func (e *ExternalInterface) PerformCredentialRotation() {
+	transactionID := uuid.New()
+	ctx := agcommon.CreateContext(transactionID.String(), CredentialRotationActionID, CredentialRotationActionName, "1", common.AggregationService, podName)
+	l.LogWithFields(ctx).Info("credential rotation routine started")
+	for {
+		config.TLSConfMutex.RLock()
+		rotationConf := *config.Data.CredentialRotationConf
+		config.TLSConfMutex.RUnlock()
+		if rotationConf.RotationIntervalInDays > 0 {
+			aggregationSourceURIs := e.getCredentialsDueForRotation(ctx, time.Duration(rotationConf.RotationIntervalInDays)*24*time.Hour)
+			if len(aggregationSourceURIs) > 0 {
+				l.LogWithFields(ctx).Infof("rotating the passwords of %d aggregation sources", len(aggregationSourceURIs))
+				results := e.rotateCredentialsInBulk(ctx, aggregationSourceURIs, DefaultCredentialRotationConcurrency, func(int) {})
+				report := getCredentialRotationReport(results)
+				l.LogWithFields(ctx).Info(report.Message)
+			}
+		}
+		time.Sleep(credentialRotationCheckInterval)
+	}
+}
+
+// getCredentialsDueForRotation returns the URIs of the BMC aggregation sources which are never rotated
+// or not rotated for the rotation interval. The unsuccessful rotations are retried after credentialRotationRetryInterval.
+func (e *ExternalInterface) getCredentialsDueForRotation(ctx context.Context, rotationInterval time.Duration) []string {
+	aggregationSourceURIs, err := e.GetAllKeysFromTable("AggregationSource")
+	if err != nil {
+		l.LogWithFields(ctx).Error("unable to get the aggregation sources for the credential rotation: " + err.Error())
+		return nil
+	}
+	now := time.Now().UTC()
+	var dueURIs []string
+	for _, aggregationSourceURI := range aggregationSourceURIs {
+		if _, err := e.GetTarget(getTargetID(aggregationSourceURI)); err != nil {
+			// only the passwords of the BMC aggregation sources are rotated
+			continue
+		}
+		rotation, dbErr := e.GetCredentialRotation(aggregationSourceURI)
+		if dbErr != nil {
+			if dbErr.ErrNo() == errors.DBKeyNotFound {
+				dueURIs = append(dueURIs, aggregationSourceURI)
+			} else {
+				l.LogWithFields(ctx).Error("unable to get the credential rotation of " + aggregationSourceURI + ": " + dbErr.Error())
+			}
+			continue
+		}
+		if rotation.Status == CredentialRotationInProgress {
+			continue
+		}
+		if lastRotatedTime, err := time.Parse(time.RFC3339, rotation.LastRotatedTime); err == nil && now.Sub(lastRotatedTime) < rotationInterval {
+			continue
+		}
+		if rotation.Status != CredentialRotationCompleted {
+			if startTime, err := time.Parse(time.RFC3339, rotation.StartTime); err == nil && now.Sub(startTime) < credentialRotationRetryInterval {
+				continue
+			}
+		}
+		dueURIs = append(dueURIs, aggregationSourceURI)
+	}
+	return dueURIs
+}
+
+// credentialRotationResult is the result of rotating the password of the aggregation source at index
+type credentialRotationResult struct {
+	index  int
+	result agresponse.CredentialRotationResult
+}
+
+// rotateCredentialsInBulk rotates the passwords of the aggregation sources, at most concurrency in parallel,
+// and returns the results in the order of aggregationSourceURIs. onProgress is called with the number of the processed
+// aggregation sources after each rotation. The aggregation sources which are not processed have nil results.
+func (e *ExternalInterface) rotateCredentialsInBulk(ctx context.Context, aggregationSourceURIs []string, concurrency int, onProgress func(int)) []*agresponse.CredentialRotationResult {
+	if concurrency <= 0 {
+		concurrency = DefaultCredentialRotationConcurrency
+	}
+	// results is a buffered channel with buffer size equal to total number of aggregation sources,
+	// so the rotations can finish even if the caller stops collecting the results.
+	results := make(chan credentialRotationResult, len(aggregationSourceURIs))
+	go func() {
+		// semaphore limits the number of passwords rotated in parallel
+		semaphore := make(chan struct{}, concurrency)
+		var wg sync.WaitGroup
+		for index, aggregationSourceURI := range aggregationSourceURIs {
+			select {
+			case semaphore <- struct{}{}:
+			case <-ctx.Done():
+			}
+			if ctx.Err() != nil {
+				// the task is cancelled, the remaining passwords are not rotated
+				break
+			}
+			wg.Add(1)
+			go func(index int, aggregationSourceURI string) {
+				defer wg.Done()
+				defer func() { <-semaphore }()
+				results <- credentialRotationResult{
+					index:  index,
+					result: e.rotateCredentials(ctx, aggregationSourceURI),
+				}
+			}(index, aggregationSourceURI)
+		}
+		wg.Wait()
+		close(results)
+	}()
+
+	rotationResults := make([]*agresponse.CredentialRotationResult, len(aggregationSourceURIs))
+	var processed int
+	for result := range results {
+		rotationResult := result.result
+		rotationResults[result.index] = &rotationResult
+		processed++
+		onProgress(processed)
+	}
+	return rotationResults
+}
+
+// getCredentialRotationReport splits the results of the rotations into the succeeded and the failed rotations,
+// the aggregation sources which are not processed are skipped
+func getCredentialRotationReport(results []*agresponse.CredentialRotationResult) agresponse.RotateAggregationSourceCredentialsResponse {
+	report := agresponse.RotateAggregationSourceCredentialsResponse{
+		Succeeded: []agresponse.CredentialRotationResult{},
+		Failed:    []agresponse.CredentialRotationResult{},
+	}
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		if result.Status == CredentialRotationCompleted {
+			report.Succeeded = append(report.Succeeded, *result)
+		} else {
+			report.Failed = append(report.Failed, *result)
+		}
+	}
+	report.Message = fmt.Sprintf("%d of %d aggregation source passwords are rotated", len(report.Succeeded), len(report.Succeeded)+len(report.Failed))
+	return report
+}
+
+// getTargetID returns the key of the System table entry of the aggregation source with the given URI
+func getTargetID(aggregationSourceURI string) string {
+	aggregationSourceID := aggregationSourceURI[strings.LastIndexByte(aggregationSourceURI, '/')+1:]
+	return strings.SplitN(aggregationSourceID, ".", 2)[0]
+}
+
+// rotateCredentials sets a newly generated password on the BMC of the aggregation source and verifies the login
+// with the new password before storing it. When the new password can't be verified or stored,
+// the old password is set back on the BMC. The state of the rotation is stored along with the aggregation source.
+func (e *ExternalInterface) rotateCredentials(ctx context.Context, aggregationSourceURI string) agresponse.CredentialRotationResult {
+	result := agresponse.CredentialRotationResult{
+		AggregationSource: agresponse.OdataID{OdataID: aggregationSourceURI},
+		Status:            CredentialRotationFailed,
+	}
+	// the replicas of the service share the aggregation sources, the lease lets only one of them rotate the password
+	leaseName := credentialRotationTable + ":" + aggregationSourceURI
+	acquired, dbErr := e.AcquireLease(leaseName, credentialRotationLeaseTTL)
+	if dbErr != nil {
+		result.Message = "unable to acquire the credential rotation lease of the aggregation source: " + dbErr.Error()
+		l.LogWithFields(ctx).Error(result.Message)
+		return result
+	}
+	if !acquired {
+		result.Message = "the password of " + aggregationSourceURI + " is already being rotated"
+		return result
+	}
+	defer func() {
+		if dbErr := e.ReleaseLease(leaseName); dbErr != nil {
+			l.LogWithFields(ctx).Error("unable to release the credential rotation lease of " + aggregationSourceURI + ": " + dbErr.Error())
+		}
+	}()
+
+	aggregationSource, dbErr := e.GetAggregationSourceInfo(aggregationSourceURI)
+	if dbErr != nil {
+		result.Message = "unable to get the aggregation source: " + dbErr.Error()
+		l.LogWithFields(ctx).Error(result.Message)
+		return result
+	}
+	targetID := getTargetID(aggregationSourceURI)
+	target, err := e.GetTarget(targetID)
+	if err != nil {
+		result.Message = aggregationSourceURI + " is not a BMC aggregation source: " + err.Error()
+		l.LogWithFields(ctx).Error(result.Message)
+		return result
+	}
+	plugin, dbErr := e.GetPluginMgrAddr(target.PluginID)
+	if dbErr != nil {
+		result.Message = "unable to get the plugin " + target.PluginID + ": " + dbErr.Error()
+		l.LogWithFields(ctx).Error(result.Message)
+		return result
+	}
+	oldPassword, err := e.DecryptPassword(target.Password)
+	if err != nil {
+		result.Message = "unable to decrypt the password of the aggregation source: " + err.Error()
+		l.LogWithFields(ctx).Error(result.Message)
+		return result
+	}
+
+	rotation, dbErr := e.GetCredentialRotation(aggregationSourceURI)
+	if dbErr != nil && dbErr.ErrNo() != errors.DBKeyNotFound {
+		result.Message = "unable to get the credential rotation of the aggregation source: " + dbErr.Error()
+		l.LogWithFields(ctx).Error(result.Message)
+		return result
+	}
+	rotation.Status = CredentialRotationInProgress
+	rotation.Message = ""
+	rotation.StartTime = time.Now().UTC().Format(time.RFC3339)
+	if dbErr = e.SaveCredentialRotation(rotation, aggregationSourceURI); dbErr != nil {
+		result.Message = "unable to save the credential rotation of the aggregation source: " + dbErr.Error()
+		l.LogWithFields(ctx).Error(result.Message)
+		return result
+	}
+	// finish stores the final state of the rotation and returns the result of the rotation
+	finish := func(status, message string) agresponse.CredentialRotationResult {
+		rotation.Status = status
+		rotation.Message = message
+		if status == CredentialRotationCompleted {
+			rotation.LastRotatedTime = time.Now().UTC().Format(time.RFC3339)
+			l.LogWithFields(ctx).Info("rotated the password of " + aggregationSourceURI)
+		} else {
+			l.LogWithFields(ctx).Error("failed to rotate the password of " + aggregationSourceURI + ": " + message)
+		}
+		if dbErr := e.SaveCredentialRotation(rotation, aggregationSourceURI); dbErr != nil {
+			l.LogWithFields(ctx).Error("unable to save the credential rotation of " + aggregationSourceURI + ": " + dbErr.Error())
+		}
+		result.Status = status
+		result.Message = message
+		return result
+	}
+
+	config.TLSConfMutex.RLock()
+	rotationConf := *config.Data.CredentialRotationConf
+	config.TLSConfMutex.RUnlock()
+	newPassword, err := generateBMCPassword(rotationConf.PasswordLength, rotationConf.AllowedSpecialCharacters)
+	if err != nil {
+		return finish(CredentialRotationFailed, "unable to generate the password: "+err.Error())
+	}
+	pluginContactRequest, err := e.getPluginContactRequest(ctx, plugin)
+	if err != nil {
+		return finish(CredentialRotationFailed, err.Error())
+	}
+	accountURI, err := findBMCAccount(ctx, pluginContactRequest, target, oldPassword)
+	if err != nil {
+		return finish(CredentialRotationFailed, err.Error())
+	}
+	if err = setBMCPassword(ctx, pluginContactRequest, target, accountURI, oldPassword, []byte(newPassword)); err != nil {
+		return finish(CredentialRotationFailed, err.Error())
+	}
+
+	// the BMC has the new password from here, any failure sets the old password back
+	err = validateBMCCredentials(ctx, pluginContactRequest, target, []byte(newPassword))
+	if err == nil {
+		err = e.saveRotatedPassword(ctx, target, targetID, aggregationSource, aggregationSourceURI, []byte(newPassword))
+	}
+	if err != nil {
+		if rollbackErr := rollbackBMCPassword(ctx, pluginContactRequest, target, accountURI, []byte(newPassword), oldPassword); rollbackErr != nil {
+			return finish(CredentialRotationRollbackFailed, err.Error()+"; unable to set the old password back: "+rollbackErr.Error())
+		}
+		return finish(CredentialRotationRolledBack, err.Error())
+	}
+	// the old password is no longer stored
+	e.deleteSecrets(ctx, target.Password, aggregationSource.Password)
+	if err = PushRotatedCredentials(ctx, plugin, targetID, target, []byte(newPassword)); err != nil {
+		l.LogWithFields(ctx).Error("failed to share the rotated password of " + aggregationSourceURI + " with " + plugin.ID + " plugin: " + err.Error())
+	}
+	return finish(CredentialRotationCompleted, "")
+}
+
+// saveRotatedPassword encrypts the new password and stores it in the System entry and the aggregation source.
+// The new password is removed from the secret provider when it can't be stored.
+func (e *ExternalInterface) saveRotatedPassword(ctx context.Context, target *agmodel.Target, targetID string, aggregationSource agmodel.AggregationSource, aggregationSourceURI string, password []byte) error {
+	ciphertext, err := e.EncryptPassword(password)
+	if err != nil {
+		return fmt.Errorf("unable to encrypt the new password: %v", err)
+	}
+	saveSystem := agmodel.SaveSystem{
+		ManagerAddress: target.ManagerAddress,
+		Password:       ciphertext,
+		UserName:       target.UserName,
+		DeviceUUID:     target.DeviceUUID,
+		PluginID:       target.PluginID,
+	}
+	if dbErr := e.UpdateSystemData(saveSystem, targetID); dbErr != nil {
+		e.deleteSecrets(ctx, ciphertext)
+		return fmt.Errorf("unable to store the new password: %v", dbErr.Error())
+	}
+	aggregationSource.Password = ciphertext
+	if dbErr := e.UpdateAggregationSourceInfo(aggregationSource, aggregationSourceURI); dbErr != nil {
+		// keep the System entry in line with the old password which is set back on the BMC
+		saveSystem.Password = target.Password
+		if e.UpdateSystemData(saveSystem, targetID) == nil {
+			e.deleteSecrets(ctx, ciphertext)
+		}
+		return fmt.Errorf("unable to store the new password in the aggregation source: %v", dbErr.Error())
+	}
+	return nil
+}
+
+// getPluginContactRequest returns the request for contacting the plugin, logged in with the plugin credentials
+func (e *ExternalInterface) getPluginContactRequest(ctx context.Context, plugin agmodel.Plugin) (getResourceRequest, error) {
+	var pluginContactRequest getResourceRequest
+	pluginContactRequest.ContactClient = e.ContactClient
+	pluginContactRequest.GetPluginStatus = e.GetPluginStatus
+	pluginContactRequest.Plugin = plugin
+	pluginContactRequest.StatusPoll = true
+	if strings.EqualFold(plugin.PreferredAuthType, "XAuthToken") {
+		pluginContactRequest.HTTPMethodType = http.MethodPost
+		pluginContactRequest.DeviceInfo = map[string]interface{}{
+			"UserName": plugin.Username,
+			"Password": string(plugin.Password),
+		}
+		pluginContactRequest.OID = "/ODIM/v1/Sessions"
+		_, token, _, err := contactPlugin(ctx, pluginContactRequest, "error while logging in to plugin: ")
+		if err != nil {
+			return pluginContactRequest, err
+		}
+		pluginContactRequest.Token = token
+	} else {
+		pluginContactRequest.LoginCredentials = map[string]string{
+			"UserName": plugin.Username,
+			"Password": string(plugin.Password),
+		}
+	}
+	return pluginContactRequest, nil
+}
+
+// findBMCAccount returns the URI of the account of the BMC whose UserName is the user name of the aggregation source
+func findBMCAccount(ctx context.Context, pluginContactRequest getResourceRequest, target *agmodel.Target, password []byte) (string, error) {
+	pluginContactRequest.HTTPMethodType = http.MethodGet
+	pluginContactRequest.DeviceInfo = map[string]interface{}{
+		"ManagerAddress": target.ManagerAddress,
+		"UserName":       target.UserName,
+		"Password":       password,
+	}
+	pluginContactRequest.OID = "/redfish/v1/AccountService/Accounts"
+	body, _, _, err := contactPlugin(ctx, pluginContactRequest, "error while getting the accounts of the BMC: ")
+	if err != nil {
+		return "", err
+	}
+	var accounts agresponse.List
+	if err = json.Unmarshal(body, &accounts); err != nil {
+		return "", fmt.Errorf("unable to parse the accounts of the BMC: %v", err)
+	}
+	for _, member := range accounts.Members {
+		pluginContactRequest.OID = member.OdataID
+		body, _, _, err = contactPlugin(ctx, pluginContactRequest, "error while getting the account "+member.OdataID+" of the BMC: ")
+		if err != nil {
+			return "", err
+		}
+		var account struct {
+			UserName string `json:"UserName"`
+		}
+		if err = json.Unmarshal(body, &account); err != nil {
+			return "", fmt.Errorf("unable to parse the account %v of the BMC: %v", member.OdataID, err)
+		}
+		if account.UserName == target.UserName {
+			return member.OdataID, nil
+		}
+	}
+	return "", fmt.Errorf("no account of the BMC has the user name %v", target.UserName)
+}
+
+// setBMCPassword changes the password of the BMC account from currentPassword to newPassword
+func setBMCPassword(ctx context.Context, pluginContactRequest getResourceRequest, target *agmodel.Target, accountURI string, currentPassword, newPassword []byte) error {
+	postBody, _ := json.Marshal(map[string]string{"Password": string(newPassword)})
+	pluginContactRequest.HTTPMethodType = http.MethodPatch
+	pluginContactRequest.DeviceInfo = map[string]interface{}{
+		"ManagerAddress": target.ManagerAddress,
+		"UserName":       target.UserName,
+		"Password":       currentPassword,
+		"PostBody":       postBody,
+	}
+	pluginContactRequest.OID = accountURI
+	_, _, getResponse, err := contactPlugin(ctx, pluginContactRequest, "error while changing the password of the BMC: ")
+	if err != nil && getResponse.StatusCode != http.StatusNoContent {
+		return err
+	}
+	return nil
+}
+
+// validateBMCCredentials verifies the login to the BMC with the given password
+func validateBMCCredentials(ctx context.Context, pluginContactRequest getResourceRequest, target *agmodel.Target, password []byte) error {
+	pluginContactRequest.HTTPMethodType = http.MethodPost
+	pluginContactRequest.DeviceInfo = agmodel.SaveSystem{
+		ManagerAddress: target.ManagerAddress,
+		UserName:       target.UserName,
+		Password:       password,
+	}
+	pluginContactRequest.OID = "/ODIM/v1/validate"
+	_, _, _, err := contactPlugin(ctx, pluginContactRequest, "error while verifying the login to the BMC: ")
+	return err
+}
+
+// rollbackBMCPassword sets the old password back on the BMC and verifies the login with it
+func rollbackBMCPassword(ctx context.Context, pluginContactRequest getResourceRequest, target *agmodel.Target, accountURI string, newPassword, oldPassword []byte) error {
+	if err := setBMCPassword(ctx, pluginContactRequest, target, accountURI, newPassword, oldPassword); err != nil {
+		return err
+	}
+	return validateBMCCredentials(ctx, pluginContactRequest, target, oldPassword)
+}
+
+// pushRotatedCredentials shares the rotated password of the BMC with its plugin
+// along with the event subscription of the BMC, as the plugin replaces the device data it holds
+func pushRotatedCredentials(ctx context.Context, plugin agmodel.Plugin, deviceUUID string, target *agmodel.Target, password []byte) error {
+	evtSubsInfo := &agmodel.EventSubscriptionInfo{}
+	subsID, evtTypes, err := agcommon.GetDeviceSubscriptionDetails(target.ManagerAddress)
+	if err != nil {
+		l.LogWithFields(ctx).Error("failed to get event subscription details for " + target.ManagerAddress + ": " + err.Error())
+	} else {
+		evtSubsInfo.Location = subsID
+		evtSubsInfo.EventTypes = append(evtSubsInfo.EventTypes, evtTypes...)
+	}
+	pluginStartUpData := &agmodel.PluginStartUpData{
+		RequestType: "delta",
+		Devices: map[string]agmodel.DeviceData{
+			deviceUUID: agmodel.DeviceData{
+				Address:               target.ManagerAddress,
+				UserName:              target.UserName,
+				Password:              password,
+				Operation:             "add",
+				EventSubscriptionInfo: evtSubsInfo,
+			},
+		},
+	}
+	return PushPluginStartUpData(ctx, plugin, pluginStartUpData)
+}
+
+// generateBMCPassword generates a random password of the given length, with at least one upper case letter,
+// one lower case letter, one digit and one of the special characters
+func generateBMCPassword(length int, specialCharacters string) (string, error) {
+	characterSets := []string{passwordUpperCaseCharacters, passwordLowerCaseCharacters, passwordDigits, specialCharacters}
+	if length < len(characterSets) {
+		return "", fmt.Errorf("the password length %v is less than %v", length, len(characterSets))
+	}
+	if specialCharacters == "" {
+		return "", fmt.Errorf("no special characters are allowed in the password")
+	}
+	password := make([]byte, 0, length)
+	for _, characterSet := range characterSets {
+		character, err := randomCharacter(characterSet)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, character)
+	}
+	allCharacters := strings.Join(characterSets, "")
+	for len(password) < length {
+		character, err := randomCharacter(allCharacters)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, character)
+	}
+	// shuffle the password, so the mandatory characters are not always at the start
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j.Int64()] = password[j.Int64()], password[i]
+	}
+	return string(password), nil
+}
+
+// randomCharacter returns a random character of the given characters
+func randomCharacter(characters string) (byte, error) {
+	index, err := rand.Int(rand.Reader, big.NewInt(int64(len(characters))))
+	if err != nil {
+		return 0, fmt.Errorf("unable to generate a random number: %v", err)
+	}
+	return characters[index.Int64()], nil
+}
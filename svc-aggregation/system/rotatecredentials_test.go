@@ -0,0 +1,378 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agresponse"
+)
+
+const (
+	mockRotationSourceURI = "/redfish/v1/AggregationService/AggregationSources/7a2c6100-67da-5fd6-ab82-6870d29c7279.1"
+	mockRotationTargetID  = "7a2c6100-67da-5fd6-ab82-6870d29c7279"
+	mockRotationAccount   = "/ODIM/v1/AccountService/Accounts/2"
+	mockReplicaID         = "replica"
+)
+
+// fakeRotationBMC is a BMC behind the plugin, holding the password of the admin account
+type fakeRotationBMC struct {
+	sync.Mutex
+	password string
+	// rejectNewPassword fails the login with any password other than the initial one
+	rejectNewPassword bool
+	// failPatch fails the password change of the account
+	failPatch bool
+	// failRollback fails the password change back to the initial password
+	failRollback bool
+	initial      string
+}
+
+// fakeRotationStore holds the System entries, the aggregation sources and the credential rotations
+type fakeRotationStore struct {
+	sync.Mutex
+	systems            map[string]agmodel.SaveSystem
+	aggregationSources map[string]agmodel.AggregationSource
+	rotations          map[string]agmodel.CredentialRotation
+	// leases holds the holders of the leases by their names
+	leases map[string]string
+	// deletedSecrets holds the passwords removed from the secret provider
+	deletedSecrets []string
+	// failSourceUpdate fails storing the aggregation source
+	failSourceUpdate bool
+}
+
+func (b *fakeRotationBMC) contactClient(ctx context.Context, url, method, token string, odataID string, body interface{}, credentials map[string]string) (*http.Response, error) {
+	b.Lock()
+	defer b.Unlock()
+	var device struct {
+		UserName string `json:"UserName"`
+		Password []byte `json:"Password"`
+		PostBody []byte `json:"PostBody"`
+	}
+	data, _ := json.Marshal(body)
+	json.Unmarshal(data, &device)
+	reply := func(statusCode int, respBody string) (*http.Response, error) {
+		return &http.Response{StatusCode: statusCode, Body: ioutil.NopCloser(bytes.NewBufferString(respBody))}, nil
+	}
+	loggedIn := device.UserName == "admin" && string(device.Password) == b.password &&
+		!(b.rejectNewPassword && b.password != b.initial && odataID == "/ODIM/v1/validate")
+	if !loggedIn {
+		return reply(http.StatusUnauthorized, "")
+	}
+	switch {
+	case odataID == "/ODIM/v1/validate":
+		return reply(http.StatusOK, "{}")
+	case odataID == "/ODIM/v1/AccountService/Accounts" && method == http.MethodGet:
+		return reply(http.StatusOK, `{"Members":[{"@odata.id":"/redfish/v1/AccountService/Accounts/1"},{"@odata.id":"/redfish/v1/AccountService/Accounts/2"}]}`)
+	case odataID == "/ODIM/v1/AccountService/Accounts/1" && method == http.MethodGet:
+		return reply(http.StatusOK, `{"UserName":"operator"}`)
+	case odataID == mockRotationAccount && method == http.MethodGet:
+		return reply(http.StatusOK, `{"UserName":"admin"}`)
+	case odataID == mockRotationAccount && method == http.MethodPatch:
+		var postBody map[string]string
+		json.Unmarshal(device.PostBody, &postBody)
+		if b.failPatch || (b.failRollback && postBody["Password"] == b.initial) {
+			return reply(http.StatusBadRequest, "password change failed")
+		}
+		b.password = postBody["Password"]
+		return reply(http.StatusNoContent, "")
+	}
+	return reply(http.StatusNotFound, "")
+}
+
+func newFakeRotationStore() *fakeRotationStore {
+	return &fakeRotationStore{
+		systems: map[string]agmodel.SaveSystem{
+			mockRotationTargetID: {
+				ManagerAddress: "10.0.0.1",
+				UserName:       "admin",
+				Password:       []byte("Password@123"),
+				DeviceUUID:     mockRotationTargetID,
+				PluginID:       "GRF",
+			},
+		},
+		aggregationSources: map[string]agmodel.AggregationSource{
+			mockRotationSourceURI: {HostName: "10.0.0.1", UserName: "admin", Password: []byte("Password@123")},
+			"/redfish/v1/AggregationService/AggregationSources/plugin": {HostName: "localhost:9091", UserName: "admin"},
+		},
+		rotations: map[string]agmodel.CredentialRotation{},
+		leases:    map[string]string{},
+	}
+}
+
+func (s *fakeRotationStore) externalInterface(bmc *fakeRotationBMC) *ExternalInterface {
+	return &ExternalInterface{
+		ContactClient:   bmc.contactClient,
+		GetPluginStatus: GetPluginStatusForTesting,
+		UpdateTask:      mockUpdateTask,
+		EncryptPassword: stubDevicePassword,
+		DecryptPassword: stubDevicePassword,
+		DeleteSecret: func(secret []byte) error {
+			s.Lock()
+			defer s.Unlock()
+			s.deletedSecrets = append(s.deletedSecrets, string(secret))
+			return nil
+		},
+		GetPluginMgrAddr: func(pluginID string) (agmodel.Plugin, *errors.Error) {
+			return agmodel.Plugin{ID: pluginID, IP: "localhost", Port: "45001", Username: "admin", Password: []byte("password"), PreferredAuthType: "BasicAuth"}, nil
+		},
+		GetAllKeysFromTable: func(table string) ([]string, error) {
+			s.Lock()
+			defer s.Unlock()
+			var keys []string
+			for key := range s.aggregationSources {
+				keys = append(keys, key)
+			}
+			return keys, nil
+		},
+		GetAggregationSourceInfo: func(uri string) (agmodel.AggregationSource, *errors.Error) {
+			s.Lock()
+			defer s.Unlock()
+			if aggregationSource, ok := s.aggregationSources[uri]; ok {
+				return aggregationSource, nil
+			}
+			return agmodel.AggregationSource{}, errors.PackError(errors.DBKeyNotFound, "no data with the with key "+uri+" found")
+		},
+		GetTarget: func(targetID string) (*agmodel.Target, error) {
+			s.Lock()
+			defer s.Unlock()
+			if system, ok := s.systems[targetID]; ok {
+				return &agmodel.Target{ManagerAddress: system.ManagerAddress, Password: system.Password, UserName: system.UserName,
+					DeviceUUID: system.DeviceUUID, PluginID: system.PluginID}, nil
+			}
+			return nil, fmt.Errorf("no data with the with key %v found", targetID)
+		},
+		UpdateSystemData: func(system agmodel.SaveSystem, key string) *errors.Error {
+			s.Lock()
+			defer s.Unlock()
+			s.systems[key] = system
+			return nil
+		},
+		UpdateAggregationSourceInfo: func(aggregationSource agmodel.AggregationSource, uri string) *errors.Error {
+			s.Lock()
+			defer s.Unlock()
+			if s.failSourceUpdate {
+				return errors.PackError(errors.UndefinedErrorType, "unable to update "+uri)
+			}
+			s.aggregationSources[uri] = aggregationSource
+			return nil
+		},
+		SaveCredentialRotation: func(rotation agmodel.CredentialRotation, uri string) *errors.Error {
+			s.Lock()
+			defer s.Unlock()
+			s.rotations[uri] = rotation
+			return nil
+		},
+		GetCredentialRotation: func(uri string) (agmodel.CredentialRotation, *errors.Error) {
+			s.Lock()
+			defer s.Unlock()
+			if rotation, ok := s.rotations[uri]; ok {
+				return rotation, nil
+			}
+			return agmodel.CredentialRotation{}, errors.PackError(errors.DBKeyNotFound, "no data with the with key "+uri+" found")
+		},
+		AcquireLease: func(name string, ttl time.Duration) (bool, *errors.Error) {
+			s.Lock()
+			defer s.Unlock()
+			if holder, ok := s.leases[name]; ok && holder != mockReplicaID {
+				return false, nil
+			}
+			s.leases[name] = mockReplicaID
+			return true, nil
+		},
+		ReleaseLease: func(name string) *errors.Error {
+			s.Lock()
+			defer s.Unlock()
+			if s.leases[name] == mockReplicaID {
+				delete(s.leases, name)
+			}
+			return nil
+		},
+	}
+}
+
+func mockPushRotatedCredentials(t *testing.T) {
+	PushRotatedCredentials = func(ctx context.Context, plugin agmodel.Plugin, deviceUUID string, target *agmodel.Target, password []byte) error {
+		return nil
+	}
+	t.Cleanup(func() { PushRotatedCredentials = pushRotatedCredentials })
+}
+
+func TestGenerateBMCPassword(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		password, err := generateBMCPassword(16, "!#$")
+		if err != nil {
+			t.Fatalf("generateBMCPassword() error = %v", err)
+		}
+		if len(password) != 16 || !strings.ContainsAny(password, passwordUpperCaseCharacters) || !strings.ContainsAny(password, passwordLowerCaseCharacters) ||
+			!strings.ContainsAny(password, passwordDigits) || !strings.ContainsAny(password, "!#$") {
+			t.Fatalf("generateBMCPassword() = %v, want 16 characters with upper case, lower case, digit and special characters", password)
+		}
+		if strings.ContainsAny(password, "%*+-=?@^_") {
+			t.Fatalf("generateBMCPassword() = %v, want only the allowed special characters", password)
+		}
+	}
+	if _, err := generateBMCPassword(3, "!#$"); err == nil {
+		t.Errorf("generateBMCPassword() with length 3 error = nil, want error")
+	}
+	if _, err := generateBMCPassword(16, ""); err == nil {
+		t.Errorf("generateBMCPassword() without special characters error = nil, want error")
+	}
+}
+
+func TestExternalInterface_rotateCredentials(t *testing.T) {
+	config.SetUpMockConfig(t)
+	mockPushRotatedCredentials(t)
+	tests := []struct {
+		name          string
+		uri           string
+		bmc           *fakeRotationBMC
+		wantStatus    string
+		wantRotated   bool
+		wantBMCStored bool
+		// leasedByOtherReplica makes another replica hold the rotation of the aggregation source
+		leasedByOtherReplica bool
+		failSourceUpdate     bool
+		// wantDeleted is the password removed from the secret provider, old or new
+		wantDeleted string
+	}{
+		{name: "rotated", uri: mockRotationSourceURI, bmc: &fakeRotationBMC{}, wantStatus: CredentialRotationCompleted, wantRotated: true, wantBMCStored: true, wantDeleted: "old"},
+		{name: "new password not stored", uri: mockRotationSourceURI, bmc: &fakeRotationBMC{}, wantStatus: CredentialRotationRolledBack, wantBMCStored: true, failSourceUpdate: true, wantDeleted: "new"},
+		{name: "password change fails", uri: mockRotationSourceURI, bmc: &fakeRotationBMC{failPatch: true}, wantStatus: CredentialRotationFailed, wantBMCStored: true},
+		{name: "new password rejected", uri: mockRotationSourceURI, bmc: &fakeRotationBMC{rejectNewPassword: true}, wantStatus: CredentialRotationRolledBack, wantBMCStored: true},
+		{name: "rollback fails", uri: mockRotationSourceURI, bmc: &fakeRotationBMC{rejectNewPassword: true, failRollback: true}, wantStatus: CredentialRotationRollbackFailed},
+		{name: "plugin aggregation source", uri: "/redfish/v1/AggregationService/AggregationSources/plugin", bmc: &fakeRotationBMC{}, wantStatus: CredentialRotationFailed, wantBMCStored: true},
+		{name: "rotated by another replica", uri: mockRotationSourceURI, bmc: &fakeRotationBMC{}, wantStatus: CredentialRotationFailed, wantBMCStored: true, leasedByOtherReplica: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.bmc.password, tt.bmc.initial = "Password@123", "Password@123"
+			store := newFakeRotationStore()
+			leaseName := credentialRotationTable + ":" + tt.uri
+			if tt.leasedByOtherReplica {
+				store.leases[leaseName] = "otherReplica"
+			}
+			store.failSourceUpdate = tt.failSourceUpdate
+			e := store.externalInterface(tt.bmc)
+			got := e.rotateCredentials(mockContext(), tt.uri)
+			if got.Status != tt.wantStatus {
+				t.Fatalf("rotateCredentials() Status = %v (%v), want %v", got.Status, got.Message, tt.wantStatus)
+			}
+			storedPassword := string(store.systems[mockRotationTargetID].Password)
+			if rotated := storedPassword != "Password@123"; rotated != tt.wantRotated {
+				t.Errorf("rotateCredentials() rotated = %v, want %v", rotated, tt.wantRotated)
+			}
+			if storedPassword != string(store.aggregationSources[mockRotationSourceURI].Password) {
+				t.Errorf("rotateCredentials() stored %v in System and %v in AggregationSource", storedPassword,
+					string(store.aggregationSources[mockRotationSourceURI].Password))
+			}
+			if bmcStored := tt.bmc.password == storedPassword; bmcStored != tt.wantBMCStored {
+				t.Errorf("rotateCredentials() BMC password is the stored password = %v, want %v", bmcStored, tt.wantBMCStored)
+			}
+			switch {
+			case tt.wantDeleted == "old" && (len(store.deletedSecrets) != 1 || store.deletedSecrets[0] != "Password@123"),
+				tt.wantDeleted == "new" && (len(store.deletedSecrets) != 1 || store.deletedSecrets[0] == "Password@123"),
+				tt.wantDeleted == "" && len(store.deletedSecrets) != 0:
+				t.Errorf("rotateCredentials() deleted the secrets %v, want the %q password", store.deletedSecrets, tt.wantDeleted)
+			}
+			if holder, held := store.leases[leaseName]; held != tt.leasedByOtherReplica || (held && holder != "otherReplica") {
+				t.Errorf("rotateCredentials() left the lease held by %q", holder)
+			}
+			if tt.uri != mockRotationSourceURI {
+				return
+			}
+			if tt.leasedByOtherReplica {
+				if _, saved := store.rotations[tt.uri]; saved {
+					t.Errorf("rotateCredentials() saved the rotation of the aggregation source held by another replica")
+				}
+				return
+			}
+			rotation := store.rotations[tt.uri]
+			if rotation.Status != tt.wantStatus || (rotation.LastRotatedTime != "") != tt.wantRotated {
+				t.Errorf("rotateCredentials() saved rotation = %+v, want Status %v", rotation, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestExternalInterface_RotateAggregationSourceCredentials(t *testing.T) {
+	config.SetUpMockConfig(t)
+	mockPushRotatedCredentials(t)
+	bmc := &fakeRotationBMC{password: "Password@123", initial: "Password@123"}
+	e := newFakeRotationStore().externalInterface(bmc)
+	reqBody, _ := json.Marshal(RotateAggregationSourceCredentials{
+		AggregationSources: []agmodel.OdataID{
+			{OdataID: mockRotationSourceURI},
+			{OdataID: "/redfish/v1/AggregationService/AggregationSources/unknown"},
+		},
+	})
+	resp := e.RotateAggregationSourceCredentials(mockContext(), "someID", "admin", &aggregatorproto.AggregatorRequest{RequestBody: reqBody})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("RotateAggregationSourceCredentials() StatusCode = %v, want %v", resp.StatusCode, http.StatusOK)
+	}
+	report := resp.Body.(agresponse.RotateAggregationSourceCredentialsResponse)
+	if len(report.Succeeded) != 1 || report.Succeeded[0].AggregationSource.OdataID != mockRotationSourceURI ||
+		len(report.Failed) != 1 || report.Failed[0].Status != CredentialRotationFailed {
+		t.Errorf("RotateAggregationSourceCredentials() report = %+v, want %v rotated and unknown failed", report, mockRotationSourceURI)
+	}
+}
+
+func TestExternalInterface_getCredentialsDueForRotation(t *testing.T) {
+	now := time.Now().UTC()
+	tests := []struct {
+		name     string
+		rotation *agmodel.CredentialRotation
+		want     bool
+	}{
+		{name: "never rotated", want: true},
+		{name: "rotated recently", rotation: &agmodel.CredentialRotation{Status: CredentialRotationCompleted,
+			StartTime: now.Add(-time.Hour).Format(time.RFC3339), LastRotatedTime: now.Add(-time.Hour).Format(time.RFC3339)}},
+		{name: "rotated before the interval", rotation: &agmodel.CredentialRotation{Status: CredentialRotationCompleted,
+			StartTime: now.Add(-48 * time.Hour).Format(time.RFC3339), LastRotatedTime: now.Add(-48 * time.Hour).Format(time.RFC3339)}, want: true},
+		{name: "rotation in progress", rotation: &agmodel.CredentialRotation{Status: CredentialRotationInProgress,
+			StartTime: now.Add(-48 * time.Hour).Format(time.RFC3339)}},
+		{name: "failed recently", rotation: &agmodel.CredentialRotation{Status: CredentialRotationRolledBack,
+			StartTime: now.Add(-time.Hour).Format(time.RFC3339)}},
+		{name: "failed before the retry interval", rotation: &agmodel.CredentialRotation{Status: CredentialRotationFailed,
+			StartTime: now.Add(-25 * time.Hour).Format(time.RFC3339)}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newFakeRotationStore()
+			if tt.rotation != nil {
+				store.rotations[mockRotationSourceURI] = *tt.rotation
+			}
+			e := store.externalInterface(&fakeRotationBMC{})
+			got := e.getCredentialsDueForRotation(mockContext(), 24*time.Hour)
+			if due := len(got) == 1 && got[0] == mockRotationSourceURI; due != tt.want || len(got) > 1 {
+				t.Errorf("getCredentialsDueForRotation() = %v, want due %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronSchedule(t *testing.T) {
+	tests := []struct {
+		spec    string
+		wantErr bool
+	}{
+		{"*/15 * * * *", false},
+		{"30 2 * * 1-5", false},
+		{"0 0,12 1 */2 7", false},
+		{"@daily", false},
+		{"* * * *", true},
+		{"60 * * * *", true},
+		{"0 24 * * *", true},
+		{"0 0 0 * *", true},
+		{"0 0 * 13 *", true},
+		{"5-1 * * * *", true},
+		{"*/0 * * * *", true},
+		{"a * * * *", true},
+		{"@sometimes", true},
+	}
+	for _, tt := range tests {
+		if _, err := parseCronSchedule(tt.spec); (err != nil) != tt.wantErr {
+			t.Errorf("parseCronSchedule(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+		}
+	}
+}
+
+func TestCronSchedule_next(t *testing.T) {
+	// 2022-03-15 is a Tuesday
+	from := time.Date(2022, 3, 15, 10, 7, 30, 0, time.UTC)
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"*/15 * * * *", time.Date(2022, 3, 15, 10, 15, 0, 0, time.UTC)},
+		{"7 10 * * *", time.Date(2022, 3, 16, 10, 7, 0, 0, time.UTC)},
+		{"30 2 * * *", time.Date(2022, 3, 16, 2, 30, 0, 0, time.UTC)},
+		{"0 0 * * 0", time.Date(2022, 3, 20, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2022, 3, 20, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC)},
+		// either the day of month or the day of week matches when both are restricted
+		{"0 0 1 * 5", time.Date(2022, 3, 18, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 31 2 *", time.Time{}},
+		{"@yearly", time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		schedule, err := parseCronSchedule(tt.spec)
+		if err != nil {
+			t.Fatalf("parseCronSchedule(%q) error = %v", tt.spec, err)
+		}
+		if got := schedule.next(from); !got.Equal(tt.want) {
+			t.Errorf("next() of %q = %v, want %v", tt.spec, got, tt.want)
+		}
+	}
+}
@@ -120,6 +120,7 @@ func (e *ExternalInterface) UpdateAggregationSource(ctx context.Context, req *ag
 		return resp
 	}
 	// Update the aggregation source info
+	oldPassword := aggregationSource.Password
 	aggregationSource.HostName = updateRequest["HostName"].(string)
 	aggregationSource.UserName = updateRequest["UserName"].(string)
 	aggregationSource.Password = updateRequest["Password"].([]byte)
@@ -130,6 +131,9 @@ func (e *ExternalInterface) UpdateAggregationSource(ctx context.Context, req *ag
 		l.LogWithFields(ctx).Error(errMsg)
 		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
 	}
+	// the password is encrypted again on every update, the previous one shared
+	// with the System or the Plugin entry is no longer stored
+	e.deleteSecrets(ctx, oldPassword)
 
 	commonResponse := response.Response{
 		OdataType:    common.AggregationSourceType,
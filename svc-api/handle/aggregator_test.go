@@ -351,6 +351,212 @@ func TestAddAggregationSource(t *testing.T) {
 	test.POST("/redfish/v1/AggregationService/AggregationSources").WithHeader("X-Auth-Token", "token").WithJSON(addAggregationSourceRequest).Expect().Status(http.StatusInternalServerError)
 }
 
+func TestBulkAddAggregationSources(t *testing.T) {
+	var a AggregatorRPCs
+	a.BulkAddAggregationSourcesRPC = testAddAggregationSourceRPCCall
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/AggregationService/Actions/Oem")
+	redfishRoutes.Post("/Odim.BulkAddAggregationSources", a.BulkAddAggregationSources)
+	test := httptest.New(t, testApp)
+	bulkAddRequest := map[string]interface{}{
+		"Hosts": []interface{}{addAggregationSourceRequest},
+	}
+	test.POST("/redfish/v1/AggregationService/Actions/Oem/Odim.BulkAddAggregationSources").WithHeader("X-Auth-Token", "ValidToken").WithJSON(bulkAddRequest).Expect().Status(http.StatusAccepted)
+	test.POST("/redfish/v1/AggregationService/Actions/Oem/Odim.BulkAddAggregationSources").WithHeader("X-Auth-Token", "InvalidToken").WithJSON(bulkAddRequest).Expect().Status(http.StatusUnauthorized)
+	test.POST("/redfish/v1/AggregationService/Actions/Oem/Odim.BulkAddAggregationSources").WithHeader("X-Auth-Token", "").WithJSON(bulkAddRequest).Expect().Status(http.StatusUnauthorized)
+	test.POST("/redfish/v1/AggregationService/Actions/Oem/Odim.BulkAddAggregationSources").WithHeader("X-Auth-Token", "token").WithJSON(bulkAddRequest).Expect().Status(http.StatusInternalServerError)
+}
+
+func TestDiscoverAggregationSources(t *testing.T) {
+	var a AggregatorRPCs
+	a.DiscoverAggregationSourcesRPC = testAddAggregationSourceRPCCall
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/AggregationService/Actions/Oem")
+	redfishRoutes.Post("/Odim.DiscoverAggregationSources", a.DiscoverAggregationSources)
+	test := httptest.New(t, testApp)
+	discoverRequest := map[string]interface{}{
+		"AddressRange": "10.0.0.0/24",
+	}
+	test.POST("/redfish/v1/AggregationService/Actions/Oem/Odim.DiscoverAggregationSources").WithHeader("X-Auth-Token", "ValidToken").WithJSON(discoverRequest).Expect().Status(http.StatusAccepted)
+	test.POST("/redfish/v1/AggregationService/Actions/Oem/Odim.DiscoverAggregationSources").WithHeader("X-Auth-Token", "InvalidToken").WithJSON(discoverRequest).Expect().Status(http.StatusUnauthorized)
+	test.POST("/redfish/v1/AggregationService/Actions/Oem/Odim.DiscoverAggregationSources").WithHeader("X-Auth-Token", "").WithJSON(discoverRequest).Expect().Status(http.StatusUnauthorized)
+	test.POST("/redfish/v1/AggregationService/Actions/Oem/Odim.DiscoverAggregationSources").WithHeader("X-Auth-Token", "token").WithJSON(discoverRequest).Expect().Status(http.StatusInternalServerError)
+}
+
+func TestApproveDiscoveredAggregationSources(t *testing.T) {
+	var a AggregatorRPCs
+	a.ApproveDiscoveredAggregationSourcesRPC = testAddAggregationSourceRPCCall
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/AggregationService/Actions/Oem")
+	redfishRoutes.Post("/Odim.ApproveDiscoveredAggregationSources", a.ApproveDiscoveredAggregationSources)
+	test := httptest.New(t, testApp)
+	approveRequest := map[string]interface{}{
+		"DiscoveredAggregationSources": []interface{}{
+			map[string]string{"@odata.id": "/redfish/v1/AggregationService/Oem/Odim/DiscoveredAggregationSources/someid"},
+		},
+		"UserName": "admin",
+		"Password": "password",
+	}
+	test.POST("/redfish/v1/AggregationService/Actions/Oem/Odim.ApproveDiscoveredAggregationSources").WithHeader("X-Auth-Token", "ValidToken").WithJSON(approveRequest).Expect().Status(http.StatusAccepted)
+	test.POST("/redfish/v1/AggregationService/Actions/Oem/Odim.ApproveDiscoveredAggregationSources").WithHeader("X-Auth-Token", "InvalidToken").WithJSON(approveRequest).Expect().Status(http.StatusUnauthorized)
+	test.POST("/redfish/v1/AggregationService/Actions/Oem/Odim.ApproveDiscoveredAggregationSources").WithHeader("X-Auth-Token", "").WithJSON(approveRequest).Expect().Status(http.StatusUnauthorized)
+	test.POST("/redfish/v1/AggregationService/Actions/Oem/Odim.ApproveDiscoveredAggregationSources").WithHeader("X-Auth-Token", "token").WithJSON(approveRequest).Expect().Status(http.StatusInternalServerError)
+}
+
+func TestRotateAggregationSourceCredentials(t *testing.T) {
+	var a AggregatorRPCs
+	a.RotateAggregationSourceCredentialsRPC = testAddAggregationSourceRPCCall
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/AggregationService/Actions/Oem")
+	redfishRoutes.Post("/Odim.RotateAggregationSourceCredentials", a.RotateAggregationSourceCredentials)
+	test := httptest.New(t, testApp)
+	rotateRequest := map[string]interface{}{
+		"AggregationSources": []interface{}{
+			map[string]string{"@odata.id": "/redfish/v1/AggregationService/AggregationSources/someid"},
+		},
+	}
+	test.POST("/redfish/v1/AggregationService/Actions/Oem/Odim.RotateAggregationSourceCredentials").WithHeader("X-Auth-Token", "ValidToken").WithJSON(rotateRequest).Expect().Status(http.StatusAccepted)
+	test.POST("/redfish/v1/AggregationService/Actions/Oem/Odim.RotateAggregationSourceCredentials").WithHeader("X-Auth-Token", "InvalidToken").WithJSON(rotateRequest).Expect().Status(http.StatusUnauthorized)
+	test.POST("/redfish/v1/AggregationService/Actions/Oem/Odim.RotateAggregationSourceCredentials").WithHeader("X-Auth-Token", "").WithJSON(rotateRequest).Expect().Status(http.StatusUnauthorized)
+	test.POST("/redfish/v1/AggregationService/Actions/Oem/Odim.RotateAggregationSourceCredentials").WithHeader("X-Auth-Token", "token").WithJSON(rotateRequest).Expect().Status(http.StatusInternalServerError)
+}
+
+func TestGetAllDiscoveredAggregationSources(t *testing.T) {
+	var a AggregatorRPCs
+	a.GetAllDiscoveredAggregationSourcesRPC = testGetAllAggregationSourceRPC
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/AggregationService/Oem/Odim/DiscoveredAggregationSources")
+	redfishRoutes.Get("/", a.GetAllDiscoveredAggregationSources)
+	test := httptest.New(t, testApp)
+	test.GET(
+		"/redfish/v1/AggregationService/Oem/Odim/DiscoveredAggregationSources",
+	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK)
+	test.GET(
+		"/redfish/v1/AggregationService/Oem/Odim/DiscoveredAggregationSources",
+	).WithHeader("X-Auth-Token", "").Expect().Status(http.StatusUnauthorized)
+	test.GET(
+		"/redfish/v1/AggregationService/Oem/Odim/DiscoveredAggregationSources",
+	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusInternalServerError)
+}
+
+func TestGetDiscoveredAggregationSource(t *testing.T) {
+	var a AggregatorRPCs
+	a.GetDiscoveredAggregationSourceRPC = testGetAggregationSourceRPC
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/AggregationService/Oem/Odim/DiscoveredAggregationSources")
+	redfishRoutes.Get("/{id}", a.GetDiscoveredAggregationSource)
+	test := httptest.New(t, testApp)
+	test.GET(
+		"/redfish/v1/AggregationService/Oem/Odim/DiscoveredAggregationSources/someid",
+	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK)
+	test.GET(
+		"/redfish/v1/AggregationService/Oem/Odim/DiscoveredAggregationSources/someid",
+	).WithHeader("X-Auth-Token", "").Expect().Status(http.StatusUnauthorized)
+	test.GET(
+		"/redfish/v1/AggregationService/Oem/Odim/DiscoveredAggregationSources/someid",
+	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusInternalServerError)
+}
+
+func TestGetInventoryHistory(t *testing.T) {
+	var a AggregatorRPCs
+	a.GetInventoryHistoryRPC = testGetAggregationSourceRPC
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/AggregationService/Oem/Odim/InventoryHistory")
+	redfishRoutes.Get("/{id}", a.GetInventoryHistory)
+	test := httptest.New(t, testApp)
+	test.GET(
+		"/redfish/v1/AggregationService/Oem/Odim/InventoryHistory/someid",
+	).WithQuery("AsOf", "2026-10-17T10:00:00Z").WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK)
+	test.GET(
+		"/redfish/v1/AggregationService/Oem/Odim/InventoryHistory/someid",
+	).WithHeader("X-Auth-Token", "").Expect().Status(http.StatusUnauthorized)
+	test.GET(
+		"/redfish/v1/AggregationService/Oem/Odim/InventoryHistory/someid",
+	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusInternalServerError)
+}
+
+func TestGetInventorySync(t *testing.T) {
+	var a AggregatorRPCs
+	a.GetInventorySyncRPC = testGetAggregationSourceRPC
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/AggregationService/Oem/Odim")
+	redfishRoutes.Get("/InventorySync", a.GetInventorySync)
+	test := httptest.New(t, testApp)
+	test.GET(
+		"/redfish/v1/AggregationService/Oem/Odim/InventorySync",
+	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK)
+	test.GET(
+		"/redfish/v1/AggregationService/Oem/Odim/InventorySync",
+	).WithHeader("X-Auth-Token", "").Expect().Status(http.StatusUnauthorized)
+	test.GET(
+		"/redfish/v1/AggregationService/Oem/Odim/InventorySync",
+	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusInternalServerError)
+}
+
+func TestUpdateInventorySync(t *testing.T) {
+	var a AggregatorRPCs
+	a.UpdateInventorySyncRPC = testUpdateAggregationSourceRPCCall
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/AggregationService/Oem/Odim")
+	redfishRoutes.Patch("/InventorySync", a.UpdateInventorySync)
+	test := httptest.New(t, testApp)
+	request := map[string]interface{}{"MaxConcurrency": 5}
+	test.PATCH("/redfish/v1/AggregationService/Oem/Odim/InventorySync").WithHeader("X-Auth-Token", "ValidToken").WithJSON(request).Expect().Status(http.StatusOK)
+	test.PATCH("/redfish/v1/AggregationService/Oem/Odim/InventorySync").WithHeader("X-Auth-Token", "").WithJSON(request).Expect().Status(http.StatusUnauthorized)
+	test.PATCH("/redfish/v1/AggregationService/Oem/Odim/InventorySync").WithHeader("X-Auth-Token", "token").WithJSON(request).Expect().Status(http.StatusInternalServerError)
+	test.PATCH("/redfish/v1/AggregationService/Oem/Odim/InventorySync").WithHeader("X-Auth-Token", "ValidToken").WithBytes([]byte(`{"MaxConcurrency":`)).Expect().Status(http.StatusBadRequest)
+}
+
+func TestValidateInteropProfile(t *testing.T) {
+	var a AggregatorRPCs
+	a.ValidateInteropProfileRPC = testUpdateAggregationSourceRPCCall
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/AggregationService/Actions/Oem")
+	redfishRoutes.Post("/Odim.ValidateInteropProfile", a.ValidateInteropProfile)
+	test := httptest.New(t, testApp)
+	request := map[string]interface{}{"Profile": "ODIMServerHardwareManagement"}
+	test.POST("/redfish/v1/AggregationService/Actions/Oem/Odim.ValidateInteropProfile").WithHeader("X-Auth-Token", "ValidToken").WithJSON(request).Expect().Status(http.StatusOK)
+	test.POST("/redfish/v1/AggregationService/Actions/Oem/Odim.ValidateInteropProfile").WithHeader("X-Auth-Token", "").WithJSON(request).Expect().Status(http.StatusUnauthorized)
+	test.POST("/redfish/v1/AggregationService/Actions/Oem/Odim.ValidateInteropProfile").WithHeader("X-Auth-Token", "token").WithJSON(request).Expect().Status(http.StatusInternalServerError)
+	test.POST("/redfish/v1/AggregationService/Actions/Oem/Odim.ValidateInteropProfile").WithHeader("X-Auth-Token", "ValidToken").WithBytes([]byte(`{"Profile":`)).Expect().Status(http.StatusBadRequest)
+}
+
+func TestGetConformanceReports(t *testing.T) {
+	var a AggregatorRPCs
+	a.GetConformanceReportsRPC = testGetAllAggregationSourceRPC
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/AggregationService/Oem/Odim/ConformanceReports")
+	redfishRoutes.Get("/", a.GetConformanceReports)
+	test := httptest.New(t, testApp)
+	test.GET(
+		"/redfish/v1/AggregationService/Oem/Odim/ConformanceReports",
+	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK)
+	test.GET(
+		"/redfish/v1/AggregationService/Oem/Odim/ConformanceReports",
+	).WithHeader("X-Auth-Token", "").Expect().Status(http.StatusUnauthorized)
+	test.GET(
+		"/redfish/v1/AggregationService/Oem/Odim/ConformanceReports",
+	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusInternalServerError)
+}
+
+func TestGetConformanceReport(t *testing.T) {
+	var a AggregatorRPCs
+	a.GetConformanceReportRPC = testGetAggregationSourceRPC
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/AggregationService/Oem/Odim/ConformanceReports")
+	redfishRoutes.Get("/{id}", a.GetConformanceReport)
+	test := httptest.New(t, testApp)
+	test.GET(
+		"/redfish/v1/AggregationService/Oem/Odim/ConformanceReports/someid",
+	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK)
+	test.GET(
+		"/redfish/v1/AggregationService/Oem/Odim/ConformanceReports/someid",
+	).WithHeader("X-Auth-Token", "").Expect().Status(http.StatusUnauthorized)
+	test.GET(
+		"/redfish/v1/AggregationService/Oem/Odim/ConformanceReports/someid",
+	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusInternalServerError)
+}
+
 func TestGetAllAggregationSource(t *testing.T) {
 	var a AggregatorRPCs
 	a.GetAllAggregationSourceRPC = testGetAllAggregationSourceRPC
@@ -674,6 +880,71 @@ func TestSetDefaultBootOrderAggregateElements(t *testing.T) {
 	).WithHeader("X-Auth-Token", "token").WithJSON(aggregateRequest).Expect().Status(http.StatusInternalServerError)
 }
 
+func TestApplySettingsAggregateElements(t *testing.T) {
+	var a AggregatorRPCs
+	a.ApplySettingsAggregateElementsRPC = testGetAggregateRPCCall
+	var aggregateRequest = map[string]interface{}{
+		"Attributes": map[string]interface{}{"ProcTurboMode": "Enabled"},
+		"Boot":       map[string]interface{}{"BootOrder": []string{"Pxe", "Hdd"}},
+	}
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/AggregationService/Aggregates/{id}/Actions/Aggregate.ApplySettings")
+	redfishRoutes.Post("/", a.ApplySettingsAggregateElements)
+	test := httptest.New(t, testApp)
+	// test with valid token
+	test.POST(
+		"/redfish/v1/AggregationService/Aggregates/7ff3bd97-c41c-5de0-937d-85d390691b73/Actions/Aggregate.ApplySettings",
+	).WithHeader("X-Auth-Token", "ValidToken").WithJSON(aggregateRequest).Expect().Status(http.StatusOK)
+
+	// test with Invalid token
+	test.POST(
+		"/redfish/v1/AggregationService/Aggregates/7ff3bd97-c41c-5de0-937d-85d390691b73/Actions/Aggregate.ApplySettings",
+	).WithHeader("X-Auth-Token", "InvalidToken").WithJSON(aggregateRequest).Expect().Status(http.StatusUnauthorized)
+
+	// test without token
+	test.POST(
+		"/redfish/v1/AggregationService/Aggregates/7ff3bd97-c41c-5de0-937d-85d390691b73/Actions/Aggregate.ApplySettings",
+	).WithHeader("X-Auth-Token", "").WithJSON(aggregateRequest).Expect().Status(http.StatusUnauthorized)
+
+	// test without request body
+	test.POST(
+		"/redfish/v1/AggregationService/Aggregates/7ff3bd97-c41c-5de0-937d-85d390691b73/Actions/Aggregate.ApplySettings",
+	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusBadRequest)
+
+	// test for RPC Error
+	test.POST(
+		"/redfish/v1/AggregationService/Aggregates/7ff3bd97-c41c-5de0-937d-85d390691b73/Actions/Aggregate.ApplySettings",
+	).WithHeader("X-Auth-Token", "token").WithJSON(aggregateRequest).Expect().Status(http.StatusInternalServerError)
+}
+
+func TestGetAggregateSettingsDrift(t *testing.T) {
+	var a AggregatorRPCs
+	a.GetAggregateSettingsDriftRPC = testGetAggregateRPCCall
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/AggregationService/Aggregates/{id}/Oem/Odim/SettingsDrift")
+	redfishRoutes.Get("/", a.GetAggregateSettingsDrift)
+	test := httptest.New(t, testApp)
+	// test with valid token
+	test.GET(
+		"/redfish/v1/AggregationService/Aggregates/7ff3bd97-c41c-5de0-937d-85d390691b73/Oem/Odim/SettingsDrift",
+	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK)
+
+	// test with Invalid token
+	test.GET(
+		"/redfish/v1/AggregationService/Aggregates/7ff3bd97-c41c-5de0-937d-85d390691b73/Oem/Odim/SettingsDrift",
+	).WithHeader("X-Auth-Token", "InvalidToken").Expect().Status(http.StatusUnauthorized)
+
+	// test without token
+	test.GET(
+		"/redfish/v1/AggregationService/Aggregates/7ff3bd97-c41c-5de0-937d-85d390691b73/Oem/Odim/SettingsDrift",
+	).WithHeader("X-Auth-Token", "").Expect().Status(http.StatusUnauthorized)
+
+	// test for RPC Error
+	test.GET(
+		"/redfish/v1/AggregationService/Aggregates/7ff3bd97-c41c-5de0-937d-85d390691b73/Oem/Odim/SettingsDrift",
+	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusInternalServerError)
+}
+
 func TestGetAllConnectionMethods(t *testing.T) {
 	var a AggregatorRPCs
 	a.GetAllConnectionMethodsRPC = testGetAggregateRPCCall
@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"path"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
@@ -98,6 +99,10 @@ func (chassis *ChassisRPCs) GetChassisCollection(ctx iris.Context) {
 		ctx.JSON(&response.Body)
 		return
 	}
+	params := getCollectionQuery(ctx, true)
+	if params == nil {
+		return
+	}
 	resp, err := chassis.GetChassisCollectionRPC(ctxt,req)
 	if err != nil {
 		errorMessage := " RPC error:" + err.Error()
@@ -108,11 +113,22 @@ func (chassis *ChassisRPCs) GetChassisCollection(ctx iris.Context) {
 		ctx.JSON(&response.Body)
 		return
 	}
+	body := applyCollectionQuery(ctx, params, resp.StatusCode, resp.Body, func(odataID string) (map[string]interface{}, error) {
+		member, err := chassis.GetChassisRPC(ctxt, chassisproto.GetChassisRequest{
+			SessionToken: req.SessionToken,
+			RequestParam: path.Base(odataID),
+			URL:          odataID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return decodeMember(member.StatusCode, member.Body)
+	})
 
 	ctx.ResponseWriter().Header().Set("Allow", "GET, POST")
 	common.SetResponseHeader(ctx, resp.Header)
 	ctx.StatusCode(int(resp.StatusCode))
-	ctx.Write(resp.Body)
+	ctx.Write(body)
 }
 
 // GetChassisResource defines the GetChassisResource iris handler.
@@ -19,6 +19,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"path"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
@@ -35,6 +36,8 @@ type EventsRPCs struct {
 	GetEventSubscriptionRPC            func(context.Context, eventsproto.EventRequest) (*eventsproto.EventSubResponse, error)
 	DeleteEventSubscriptionRPC         func(context.Context, eventsproto.EventRequest) (*eventsproto.EventSubResponse, error)
 	GetEventSubscriptionsCollectionRPC func(context.Context, eventsproto.EventRequest) (*eventsproto.EventSubResponse, error)
+	ResumeEventSubscriptionRPC         func(context.Context, eventsproto.EventRequest) (*eventsproto.EventSubResponse, error)
+	GetUndeliveredEventsCollectionRPC  func(context.Context, eventsproto.EventRequest) (*eventsproto.EventSubResponse, error)
 }
 
 // GetEventService is the handler to get the Event Service details.
@@ -238,6 +241,10 @@ func (e *EventsRPCs) GetEventSubscriptionsCollection(ctx iris.Context) {
 		return
 	}
 
+	params := getCollectionQuery(ctx, false)
+	if params == nil {
+		return
+	}
 	resp, err := e.GetEventSubscriptionsCollectionRPC(ctxt, req)
 	if err != nil {
 		l.LogWithFields(ctxt).Error(err.Error())
@@ -247,9 +254,92 @@ func (e *EventsRPCs) GetEventSubscriptionsCollection(ctx iris.Context) {
 		ctx.JSON(&response.Body)
 		return
 	}
+	body := applyCollectionQuery(ctx, params, resp.StatusCode, resp.Body, func(odataID string) (map[string]interface{}, error) {
+		member, err := e.GetEventSubscriptionRPC(ctxt, eventsproto.EventRequest{
+			SessionToken:        req.SessionToken,
+			EventSubscriptionID: path.Base(odataID),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return decodeMember(member.StatusCode, member.Body)
+	})
 
 	ctx.ResponseWriter().Header().Set("Allow", "GET, POST")
 	common.SetResponseHeader(ctx, resp.Header)
 	ctx.StatusCode(int(resp.StatusCode))
+	ctx.Write(body)
+}
+
+// ResumeEventSubscription is the handler to resume the event subscription
+// suspended or disabled as per its DeliveryRetryPolicy
+func (e *EventsRPCs) ResumeEventSubscription(ctx iris.Context) {
+	defer ctx.Next()
+	ctxt := ctx.Request().Context()
+	var req eventsproto.EventRequest
+	req.EventSubscriptionID = ctx.Params().Get("id")
+	req.SessionToken = ctx.Request().Header.Get("X-Auth-Token")
+
+	if req.SessionToken == "" {
+		errorMessage := "no X-Auth-Token found in request header"
+		response := common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errorMessage, nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusUnauthorized)
+		ctx.JSON(&response.Body)
+		return
+	}
+
+	resp, err := e.ResumeEventSubscriptionRPC(ctxt, req)
+	if err != nil {
+		l.LogWithFields(ctxt).Error(err.Error())
+		response := common.GeneralError(http.StatusInternalServerError, response.InternalError, err.Error(), nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusInternalServerError)
+		ctx.JSON(&response.Body)
+		return
+	}
+	ctx.ResponseWriter().Header().Set("Allow", "POST")
+	common.SetResponseHeader(ctx, resp.Header)
+	ctx.StatusCode(int(resp.StatusCode))
 	ctx.Write(resp.Body)
 }
+
+// GetUndeliveredEventsCollection is the handler to get the events which
+// couldn't be delivered to the destination of the event subscription
+func (e *EventsRPCs) GetUndeliveredEventsCollection(ctx iris.Context) {
+	defer ctx.Next()
+	ctxt := ctx.Request().Context()
+	var req eventsproto.EventRequest
+	req.EventSubscriptionID = ctx.Params().Get("id")
+	req.SessionToken = ctx.Request().Header.Get("X-Auth-Token")
+
+	if req.SessionToken == "" {
+		errorMessage := "no X-Auth-Token found in request header"
+		response := common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errorMessage, nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusUnauthorized)
+		ctx.JSON(&response.Body)
+		return
+	}
+
+	params := getCollectionQuery(ctx, false)
+	if params == nil {
+		return
+	}
+	resp, err := e.GetUndeliveredEventsCollectionRPC(ctxt, req)
+	if err != nil {
+		l.LogWithFields(ctxt).Error(err.Error())
+		response := common.GeneralError(http.StatusInternalServerError, response.InternalError, err.Error(), nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusInternalServerError)
+		ctx.JSON(&response.Body)
+		return
+	}
+	// the undelivered events are embedded in the collection
+	body := applyCollectionQuery(ctx, params, resp.StatusCode, resp.Body, inlineMemberFetcher(resp.Body))
+
+	ctx.ResponseWriter().Header().Set("Allow", "GET")
+	common.SetResponseHeader(ctx, resp.Header)
+	ctx.StatusCode(int(resp.StatusCode))
+	ctx.Write(body)
+}
@@ -313,3 +313,76 @@ func TestGetEventSubscriptionsCollectionRPC(t *testing.T) {
 		"/redfish/v1/EventService/Subscriptions",
 	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusInternalServerError)
 }
+
+func TestResumeEventSubscriptionRPC(t *testing.T) {
+	var s EventsRPCs
+	s.ResumeEventSubscriptionRPC = mockGetEventSubscriptionRPC
+
+	mockApp := iris.New()
+	redfishRoutes := mockApp.Party("/redfish/v1")
+	redfishRoutes.Post("/EventService/Subscriptions/{id}/Actions/EventDestination.ResumeSubscription", s.ResumeEventSubscription)
+	e := httptest.New(t, mockApp)
+	e.POST(
+		"/redfish/v1/EventService/Subscriptions/1A/Actions/EventDestination.ResumeSubscription",
+	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK)
+
+	// test with invalid token
+	e.POST(
+		"/redfish/v1/EventService/Subscriptions/1A/Actions/EventDestination.ResumeSubscription",
+	).WithHeader("X-Auth-Token", "InValidToken").Expect().Status(http.StatusUnauthorized)
+
+	// test without token
+	e.POST(
+		"/redfish/v1/EventService/Subscriptions/1A/Actions/EventDestination.ResumeSubscription",
+	).WithHeader("X-Auth-Token", "").Expect().Status(http.StatusUnauthorized)
+
+	// test for RPC Error
+	e.POST(
+		"/redfish/v1/EventService/Subscriptions/1A/Actions/EventDestination.ResumeSubscription",
+	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusInternalServerError)
+}
+
+func mockGetUndeliveredEventsCollectionRPC(ctx context.Context, req eventsproto.EventRequest) (*eventsproto.EventSubResponse, error) {
+	if req.SessionToken != "ValidToken" {
+		return mockGetEventSubscriptionRPC(ctx, req)
+	}
+	return &eventsproto.EventSubResponse{
+		StatusCode: http.StatusOK,
+		Body: []byte(`{"@odata.id":"/redfish/v1/EventService/Subscriptions/1A/UndeliveredEvents","Members@odata.count":2,"Members":[` +
+			`{"@odata.id":"/redfish/v1/EventService/Subscriptions/1A/UndeliveredEvents#/Members/0","Id":"event1","Timestamp":"2022-01-01T00:00:00Z","Event":"event"},` +
+			`{"@odata.id":"/redfish/v1/EventService/Subscriptions/1A/UndeliveredEvents#/Members/1","Id":"event2","Timestamp":"2022-01-01T00:00:01Z","Event":"event"}]}`),
+	}, nil
+}
+
+func TestGetUndeliveredEventsCollectionRPC(t *testing.T) {
+	var s EventsRPCs
+	s.GetUndeliveredEventsCollectionRPC = mockGetUndeliveredEventsCollectionRPC
+
+	mockApp := iris.New()
+	redfishRoutes := mockApp.Party("/redfish/v1")
+	redfishRoutes.Get("/EventService/Subscriptions/{id}/UndeliveredEvents", s.GetUndeliveredEventsCollection)
+	e := httptest.New(t, mockApp)
+	e.GET(
+		"/redfish/v1/EventService/Subscriptions/1A/UndeliveredEvents",
+	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK).JSON().Object().Value("Members").Array().Length().Equal(2)
+
+	// test with $filter on the embedded members
+	e.GET(
+		"/redfish/v1/EventService/Subscriptions/1A/UndeliveredEvents",
+	).WithQuery("$filter", "Id eq 'event2'").WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK).JSON().Object().Value("Members").Array().Length().Equal(1)
+
+	// test with invalid token
+	e.GET(
+		"/redfish/v1/EventService/Subscriptions/1A/UndeliveredEvents",
+	).WithHeader("X-Auth-Token", "InValidToken").Expect().Status(http.StatusUnauthorized)
+
+	// test without token
+	e.GET(
+		"/redfish/v1/EventService/Subscriptions/1A/UndeliveredEvents",
+	).WithHeader("X-Auth-Token", "").Expect().Status(http.StatusUnauthorized)
+
+	// test for RPC Error
+	e.GET(
+		"/redfish/v1/EventService/Subscriptions/1A/UndeliveredEvents",
+	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusInternalServerError)
+}
@@ -41,7 +41,7 @@ func (f *FabricRPCs) GetFabricCollection(ctx iris.Context) {
 	ctxt := ctx.Request().Context()
 	req := fabricsproto.FabricRequest{
 		SessionToken: ctx.Request().Header.Get("X-Auth-Token"),
-		URL:          ctx.Request().RequestURI,
+		URL:          ctx.Request().URL.Path,
 	}
 
 	if req.SessionToken == "" {
@@ -53,6 +53,10 @@ func (f *FabricRPCs) GetFabricCollection(ctx iris.Context) {
 		return
 	}
 
+	params := getCollectionQuery(ctx, false)
+	if params == nil {
+		return
+	}
 	resp, err := f.GetFabricResourceRPC(ctxt, req)
 	if err != nil && resp == nil {
 		errorMessage := "RPC error: " + err.Error()
@@ -63,12 +67,22 @@ func (f *FabricRPCs) GetFabricCollection(ctx iris.Context) {
 		ctx.JSON(&response.Body)
 		return
 	}
+	body := applyCollectionQuery(ctx, params, resp.StatusCode, resp.Body, func(odataID string) (map[string]interface{}, error) {
+		member, err := f.GetFabricResourceRPC(ctxt, fabricsproto.FabricRequest{
+			SessionToken: req.SessionToken,
+			URL:          odataID,
+		})
+		if err != nil && member == nil {
+			return nil, err
+		}
+		return decodeMember(member.StatusCode, member.Body)
+	})
 	resp.Header = map[string]string{
 		"Allow": `"GET"`,
 	}
 	common.SetResponseHeader(ctx, resp.Header)
 	ctx.StatusCode(int(resp.StatusCode))
-	ctx.Write(resp.Body)
+	ctx.Write(body)
 }
 
 // GetFabric defines the GetFabric iris handler.
@@ -25,6 +25,7 @@ import (
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/lib-utilities/query"
 	errResponse "github.com/ODIM-Project/ODIM/lib-utilities/response"
 	srv "github.com/ODIM-Project/ODIM/lib-utilities/services"
 	"github.com/ODIM-Project/ODIM/svc-api/models"
@@ -70,6 +71,18 @@ func getService(microServices []string, uuid string) models.ServiceRoot {
 				OdataID: "/redfish/v1/SessionService/Sessions"},
 		},
 		Registries: &models.Service{OdataID: "/redfish/v1/Registries"},
+		ProtocolFeaturesSupported: &models.PFSupported{
+			FilterQuery:  true,
+			SelectQuery:  true,
+			TopSkipQuery: true,
+			ExpandQuery: &models.ExpandQuery{
+				ExpandAll: true,
+				Levels:    true,
+				Links:     true,
+				NoLinks:   true,
+				MaxLevels: query.MaxExpandLevels,
+			},
+		},
 	}
 	// To discover the services we need registry
 	//Get Service options to retrive the Registry from it.
@@ -1288,6 +1301,7 @@ func EvtMethodNotAllowed(ctx iris.Context) {
 	defer ctx.Next()
 	url := ctx.Request().URL
 	path := url.Path
+	id := ctx.Params().Get("id")
 
 	// Extend switch case, when each path, requires different handling
 	switch path {
@@ -1297,6 +1311,12 @@ func EvtMethodNotAllowed(ctx iris.Context) {
 		ctx.ResponseWriter().Header().Set("Allow", "")
 	case "/redfish/v1/EventService/Actions/EventService.SubmitTestEvent":
 		ctx.ResponseWriter().Header().Set("Allow", "POST")
+	case "/redfish/v1/EventService/SSE":
+		ctx.ResponseWriter().Header().Set("Allow", "GET")
+	case "/redfish/v1/EventService/Subscriptions/" + id + "/Actions/EventDestination.ResumeSubscription":
+		ctx.ResponseWriter().Header().Set("Allow", "POST")
+	case "/redfish/v1/EventService/Subscriptions/" + id + "/UndeliveredEvents":
+		ctx.ResponseWriter().Header().Set("Allow", "GET")
 	}
 	fillMethodNotAllowedErrorResponse(ctx)
 }
@@ -1314,6 +1334,28 @@ func AggMethodNotAllowed(ctx iris.Context) {
 		ctx.ResponseWriter().Header().Set("Allow", "POST")
 	case "/redfish/v1/AggregationService/Actions/AggregationService.Reset":
 		ctx.ResponseWriter().Header().Set("Allow", "POST")
+	case "/redfish/v1/AggregationService/Actions/Oem/Odim.BulkAddAggregationSources":
+		ctx.ResponseWriter().Header().Set("Allow", "POST")
+	case "/redfish/v1/AggregationService/Actions/Oem/Odim.DiscoverAggregationSources":
+		ctx.ResponseWriter().Header().Set("Allow", "POST")
+	case "/redfish/v1/AggregationService/Actions/Oem/Odim.ApproveDiscoveredAggregationSources":
+		ctx.ResponseWriter().Header().Set("Allow", "POST")
+	case "/redfish/v1/AggregationService/Actions/Oem/Odim.RotateAggregationSourceCredentials":
+		ctx.ResponseWriter().Header().Set("Allow", "POST")
+	case "/redfish/v1/AggregationService/Actions/Oem/Odim.ValidateInteropProfile":
+		ctx.ResponseWriter().Header().Set("Allow", "POST")
+	case "/redfish/v1/AggregationService/Oem/Odim/DiscoveredAggregationSources":
+		ctx.ResponseWriter().Header().Set("Allow", "GET")
+	case "/redfish/v1/AggregationService/Oem/Odim/DiscoveredAggregationSources/" + id:
+		ctx.ResponseWriter().Header().Set("Allow", "GET")
+	case "/redfish/v1/AggregationService/Oem/Odim/InventoryHistory/" + id:
+		ctx.ResponseWriter().Header().Set("Allow", "GET")
+	case "/redfish/v1/AggregationService/Oem/Odim/InventorySync":
+		ctx.ResponseWriter().Header().Set("Allow", "GET, PATCH")
+	case "/redfish/v1/AggregationService/Oem/Odim/ConformanceReports":
+		ctx.ResponseWriter().Header().Set("Allow", "GET")
+	case "/redfish/v1/AggregationService/Oem/Odim/ConformanceReports/" + id:
+		ctx.ResponseWriter().Header().Set("Allow", "GET")
 	case "/redfish/v1/AggregationService/AggregationSources":
 		ctx.ResponseWriter().Header().Set("Allow", "GET, POST")
 	case "/redfish/v1/AggregationService/AggregationSources/" + id:
@@ -1355,6 +1397,10 @@ func AggregateMethodNotAllowed(ctx iris.Context) {
 		ctx.ResponseWriter().Header().Set("Allow", "POST")
 	case "/redfish/v1/AggregationService/Aggregates/" + aggregateID + "Actions/Aggregate.SetDefaultBootOrder/":
 		ctx.ResponseWriter().Header().Set("Allow", "POST")
+	case "/redfish/v1/AggregationService/Aggregates/" + aggregateID + "/Actions/Aggregate.ApplySettings/":
+		ctx.ResponseWriter().Header().Set("Allow", "POST")
+	case "/redfish/v1/AggregationService/Aggregates/" + aggregateID + "/Oem/Odim/SettingsDrift":
+		ctx.ResponseWriter().Header().Set("Allow", "GET")
 	}
 	fillMethodNotAllowedErrorResponse(ctx)
 }
@@ -1373,9 +1419,9 @@ func RoleMethodNotAllowed(ctx iris.Context) {
 	path := url.Path
 	id := ctx.Params().Get("id")
 	switch path {
-	case "/redfish/v1/Roles":
+	case "/redfish/v1/AccountService/Roles":
 		ctx.ResponseWriter().Header().Set("Allow", "GET, POST")
-	case "/redfish/v1/Roles/" + id:
+	case "/redfish/v1/AccountService/Roles/" + id:
 		ctx.ResponseWriter().Header().Set("Allow", "GET, PATCH, DELETE")
 	}
 	fillMethodNotAllowedErrorResponse(ctx)
@@ -19,6 +19,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"path"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
@@ -46,6 +47,7 @@ func (mgr *ManagersRPCs) GetManagersCollection(ctx iris.Context) {
 	ctxt := ctx.Request().Context()
 	req := managersproto.ManagerRequest{
 		SessionToken: ctx.Request().Header.Get("X-Auth-Token"),
+		URL:          ctx.Request().RequestURI,
 	}
 	if req.SessionToken == "" {
 		errorMessage := "error: no X-Auth-Token found in request header"
@@ -55,6 +57,10 @@ func (mgr *ManagersRPCs) GetManagersCollection(ctx iris.Context) {
 		ctx.JSON(&response.Body)
 		return
 	}
+	params := getCollectionQuery(ctx, true)
+	if params == nil {
+		return
+	}
 	resp, err := mgr.GetManagersCollectionRPC(ctxt, req)
 	if err != nil {
 		errorMessage := "error:  RPC error:" + err.Error()
@@ -65,10 +71,21 @@ func (mgr *ManagersRPCs) GetManagersCollection(ctx iris.Context) {
 		ctx.JSON(&response.Body)
 		return
 	}
+	body := applyCollectionQuery(ctx, params, resp.StatusCode, resp.Body, func(odataID string) (map[string]interface{}, error) {
+		member, err := mgr.GetManagersRPC(ctxt, managersproto.ManagerRequest{
+			SessionToken: req.SessionToken,
+			ManagerID:    path.Base(odataID),
+			URL:          odataID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return decodeMember(member.StatusCode, member.Body)
+	})
 	ctx.ResponseWriter().Header().Set("Allow", "GET")
 	common.SetResponseHeader(ctx, resp.Header)
 	ctx.StatusCode(int(resp.StatusCode))
-	ctx.Write(resp.Body)
+	ctx.Write(body)
 }
 
 // GetManager fetches computer managers details
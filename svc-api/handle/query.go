@@ -0,0 +1,108 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package handle ...
+package handle
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/lib-utilities/query"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	iris "github.com/kataras/iris/v12"
+)
+
+// getCollectionQuery parses the query parameters of a collection request.
+// expandSupported tells whether the service serving the collection handles
+// $expand. In case of invalid query parameters the error response is written
+// and nil is returned, the handler is expected to return.
+func getCollectionQuery(ctx iris.Context, expandSupported bool) *query.Params {
+	params, err := query.Parse(ctx.Request().URL.Query())
+	if err == nil && params.Expand != nil && !expandSupported {
+		err = &query.Error{
+			StatusMessage: response.QueryNotSupported,
+			ErrorMessage:  "error: " + query.ExpandParam + " is not supported on " + ctx.Request().URL.Path,
+		}
+	}
+	if err != nil {
+		l.LogWithFields(ctx.Request().Context()).Error(err.Error())
+		resp := err.Response()
+		common.SetResponseHeader(ctx, resp.Header)
+		ctx.StatusCode(int(resp.StatusCode))
+		ctx.JSON(&resp.Body)
+		return nil
+	}
+	return params
+}
+
+// applyCollectionQuery applies the query parameters on the collection returned
+// by the service. fetch is used to get the members for evaluating $filter.
+// The body is returned as is if the collection couldn't be read successfully.
+func applyCollectionQuery(ctx iris.Context, params *query.Params, statusCode int32, body []byte, fetch query.MemberFetcher) []byte {
+	if params == nil || params.IsEmpty() || statusCode != http.StatusOK {
+		return body
+	}
+	var collection map[string]interface{}
+	if err := json.Unmarshal(body, &collection); err != nil {
+		l.LogWithFields(ctx.Request().Context()).Error("error while applying query on the collection: " + err.Error())
+		return body
+	}
+	collection = params.ApplyToCollection(collection, ctx.Request().URL.Path, fetch)
+	data, err := json.Marshal(collection)
+	if err != nil {
+		l.LogWithFields(ctx.Request().Context()).Error("error while applying query on the collection: " + err.Error())
+		return body
+	}
+	return data
+}
+
+// decodeMember returns the collection member read using the RPC. An error is
+// returned if the service failed to return the member.
+func decodeMember(statusCode int32, body []byte) (map[string]interface{}, error) {
+	if statusCode != http.StatusOK {
+		return nil, fmt.Errorf("member request failed with status %d", statusCode)
+	}
+	var member map[string]interface{}
+	if err := json.Unmarshal(body, &member); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// inlineMemberFetcher returns the fetcher for the collections which embed
+// their members instead of linking them, the members are looked up by their
+// @odata.id in the collection body
+func inlineMemberFetcher(body []byte) query.MemberFetcher {
+	// the members are read upfront as the fetcher is called concurrently
+	var collection struct {
+		Members []map[string]interface{} `json:"Members"`
+	}
+	json.Unmarshal(body, &collection)
+	members := make(map[string]map[string]interface{}, len(collection.Members))
+	for _, member := range collection.Members {
+		odataID, _ := member["@odata.id"].(string)
+		members[odataID] = member
+	}
+	return func(odataID string) (map[string]interface{}, error) {
+		member, ok := members[odataID]
+		if !ok {
+			return nil, fmt.Errorf("member %v not found in the collection", odataID)
+		}
+		return member, nil
+	}
+}
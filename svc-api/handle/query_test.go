@@ -0,0 +1,109 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package handle
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	chassisproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/chassis"
+	"github.com/kataras/iris/v12"
+	"github.com/kataras/iris/v12/httptest"
+)
+
+func mockGetChassisCollectionForQuery(context.Context, chassisproto.GetChassisRequest) (*chassisproto.GetChassisResponse, error) {
+	body, _ := json.Marshal(map[string]interface{}{
+		"@odata.id": "/redfish/v1/Chassis",
+		"Name":      "Chassis Collection",
+		"Members": []map[string]string{
+			{"@odata.id": "/redfish/v1/Chassis/1"},
+			{"@odata.id": "/redfish/v1/Chassis/2"},
+			{"@odata.id": "/redfish/v1/Chassis/3"},
+		},
+		"Members@odata.count": 3,
+	})
+	return &chassisproto.GetChassisResponse{
+		StatusCode: http.StatusOK,
+		Body:       body,
+	}, nil
+}
+
+func mockGetChassisForQuery(ctx context.Context, req chassisproto.GetChassisRequest) (*chassisproto.GetChassisResponse, error) {
+	chassisType := "RackMount"
+	if req.RequestParam == "2" {
+		chassisType = "Enclosure"
+	}
+	body, _ := json.Marshal(map[string]interface{}{
+		"@odata.id":   req.URL,
+		"Id":          req.RequestParam,
+		"ChassisType": chassisType,
+	})
+	return &chassisproto.GetChassisResponse{
+		StatusCode: http.StatusOK,
+		Body:       body,
+	}, nil
+}
+
+func TestGetChassisCollectionWithQuery(t *testing.T) {
+	var cha ChassisRPCs
+	cha.GetChassisCollectionRPC = mockGetChassisCollectionForQuery
+	cha.GetChassisRPC = mockGetChassisForQuery
+	mockApp := iris.New()
+	redfishRoutes := mockApp.Party("/redfish/v1/Chassis")
+	redfishRoutes.Get("/", cha.GetChassisCollection)
+
+	e := httptest.New(t, mockApp)
+	// pagination
+	resp := e.GET("/redfish/v1/Chassis").WithQuery("$top", "1").WithQuery("$skip", "1").
+		WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusOK).JSON().Object()
+	resp.Value("Members@odata.count").Number().Equal(3)
+	resp.Value("Members").Array().Length().Equal(1)
+	resp.Value("Members").Array().Element(0).Object().Value("@odata.id").Equal("/redfish/v1/Chassis/2")
+	resp.Value("Members@odata.nextLink").String().Equal("/redfish/v1/Chassis?$skip=2&$top=1")
+
+	// filter
+	resp = e.GET("/redfish/v1/Chassis").WithQuery("$filter", "ChassisType eq 'RackMount'").
+		WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusOK).JSON().Object()
+	resp.Value("Members@odata.count").Number().Equal(2)
+	resp.Value("Members").Array().Element(1).Object().Value("@odata.id").Equal("/redfish/v1/Chassis/3")
+	resp.NotContainsKey("Members@odata.nextLink")
+
+	// select
+	resp = e.GET("/redfish/v1/Chassis").WithQuery("$select", "Members").
+		WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusOK).JSON().Object()
+	resp.NotContainsKey("Name")
+	resp.ContainsKey("@odata.id")
+}
+
+func TestGetChassisCollectionWithInvalidQuery(t *testing.T) {
+	var cha ChassisRPCs
+	cha.GetChassisCollectionRPC = mockGetChassisCollectionForQuery
+	cha.GetChassisRPC = mockGetChassisForQuery
+	mockApp := iris.New()
+	redfishRoutes := mockApp.Party("/redfish/v1/Chassis")
+	redfishRoutes.Get("/", cha.GetChassisCollection)
+
+	e := httptest.New(t, mockApp)
+	e.GET("/redfish/v1/Chassis").WithQuery("$filter", "ChassisType eq RackMount").
+		WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusBadRequest)
+	e.GET("/redfish/v1/Chassis").WithQuery("$top", "-1").
+		WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusBadRequest)
+	e.GET("/redfish/v1/Chassis").WithQuery("$skip", "one").
+		WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusBadRequest)
+	e.GET("/redfish/v1/Chassis").WithQuery("$levels", "2").
+		WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusBadRequest)
+}
@@ -29,12 +29,62 @@ import (
 
 // RoleRPCs defines all the RPC methods in role
 type RoleRPCs struct {
+	CreateRoleRPC  func(context.Context,roleproto.RoleRequest) (*roleproto.RoleResponse, error)
 	GetAllRolesRPC func(context.Context,roleproto.GetRoleRequest) (*roleproto.RoleResponse, error)
 	GetRoleRPC     func(context.Context,roleproto.GetRoleRequest) (*roleproto.RoleResponse, error)
 	UpdateRoleRPC  func(context.Context,roleproto.UpdateRoleRequest) (*roleproto.RoleResponse, error)
 	DeleteRoleRPC  func(context.Context,roleproto.DeleteRoleRequest) (*roleproto.RoleResponse, error)
 }
 
+// CreateRole defines the CreateRole iris handler.
+// The method extract the session token, and necessary
+// request parameters and creates the RPC request.
+// After the RPC call the method will feed the response to the iris
+// and gives out a proper response.
+func (r *RoleRPCs) CreateRole(ctx iris.Context) {
+	defer ctx.Next()
+	ctxt := ctx.Request().Context()
+	var req roleproto.RoleRequest
+
+	//Read Body from Request
+	var roleReq interface{}
+	err := ctx.ReadJSON(&roleReq)
+	if err != nil {
+		l.LogWithFields(ctxt).Error("Error while trying to collect data from request: " + err.Error())
+		errorMessage := "error while trying to get JSON body from the role create request body: " + err.Error()
+		response := common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errorMessage, nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusBadRequest)
+		ctx.JSON(response.Body)
+		return
+	}
+
+	req.SessionToken = ctx.Request().Header.Get("X-Auth-Token")
+	if req.SessionToken == "" {
+		errorMessage := "error: no X-Auth-Token found in request header"
+		response := common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errorMessage, nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusUnauthorized)
+		ctx.JSON(&response.Body)
+		return
+	}
+	req.RequestBody, _ = json.Marshal(&roleReq)
+	resp, err := r.CreateRoleRPC(ctxt,req)
+	if err != nil {
+		errorMessage := "RPC error:" + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		response := common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusInternalServerError)
+		ctx.JSON(&response.Body)
+		return
+	}
+
+	common.SetResponseHeader(ctx, resp.Header)
+	ctx.StatusCode(int(resp.StatusCode))
+	ctx.Write(resp.Body)
+}
+
 // GetAllRoles defines the GetAllRoles iris handler.
 // The method extract the session token and creates the RPC request.
 // After the RPC call the method will feed the response to the iris
@@ -63,7 +113,7 @@ func (r *RoleRPCs) GetAllRoles(ctx iris.Context) {
 		return
 	}
 
-	ctx.ResponseWriter().Header().Set("Allow", "GET")
+	ctx.ResponseWriter().Header().Set("Allow", "GET, POST")
 	common.SetResponseHeader(ctx, resp.Header)
 	ctx.StatusCode(int(resp.StatusCode))
 	ctx.Write(resp.Body)
@@ -68,8 +68,50 @@ func mockDeleteRoleRPC(context.Context, roleproto.DeleteRoleRequest) (*roleproto
 	}, nil
 }
 
+func TestRoleRPCs_CreateRole(t *testing.T) {
+	var r RoleRPCs
+	r.CreateRoleRPC = mockCreateRoleRPC
+	body := map[string]interface{}{
+		"RoleId":             "someRole",
+		"AssignedPrivileges": []string{"Login"},
+	}
+
+	mockApp := iris.New()
+	redfishRoutes := mockApp.Party("/redfish/v1")
+	redfishRoutes.Post("/AccountService/Roles", r.CreateRole)
+
+	e := httptest.New(t, mockApp)
+	e.POST(
+		"/redfish/v1/AccountService/Roles",
+	).WithHeader("X-Auth-Token", "token").WithJSON(body).Expect().Status(http.StatusCreated).Headers().Equal(header)
+	e.POST(
+		"/redfish/v1/AccountService/Roles",
+	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusBadRequest)
+	e.POST(
+		"/redfish/v1/AccountService/Roles",
+	).WithHeader("X-Auth-Token", "").WithJSON(body).Expect().Status(http.StatusUnauthorized)
+}
+
+func TestRoleRPCs_CreateRoleWithRPCError(t *testing.T) {
+	var r RoleRPCs
+	r.CreateRoleRPC = mockCreateRoleRPCWithRPCError
+	body := map[string]interface{}{
+		"RoleId":             "someRole",
+		"AssignedPrivileges": []string{"Login"},
+	}
+
+	mockApp := iris.New()
+	redfishRoutes := mockApp.Party("/redfish/v1")
+	redfishRoutes.Post("/AccountService/Roles", r.CreateRole)
+
+	e := httptest.New(t, mockApp)
+	e.POST(
+		"/redfish/v1/AccountService/Roles",
+	).WithHeader("X-Auth-Token", "token").WithJSON(body).Expect().Status(http.StatusInternalServerError)
+}
+
 func TestRoleRPCs_GetAllRoles(t *testing.T) {
-	header["Allow"] = []string{"GET"}
+	header["Allow"] = []string{"GET, POST"}
 	defer delete(header, "Allow")
 	var r RoleRPCs
 	r.GetAllRolesRPC = mockGetAllRolesRPC
@@ -0,0 +1,163 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package handle ...
+package handle
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/lib-utilities/query"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-api/sse"
+	iris "github.com/kataras/iris/v12"
+)
+
+// ServerSentEvents defines Auth which helps with authorization and the
+// broker from which the events are streamed to the clients
+type ServerSentEvents struct {
+	Auth   func(string, []string, []string) (response.RPC, error)
+	Broker *sse.Broker
+}
+
+// GetEventStream streams the events to the client as Server-Sent Events.
+// The events can be filtered with $filter on EventType, MessageId,
+// OriginResource, RegistryPrefix and ResourceType. A client reconnecting
+// with Last-Event-ID receives the retained events published after it.
+// The session is validated again on every keep-alive, the stream is closed
+// once the session is no more valid.
+func (s *ServerSentEvents) GetEventStream(ctx iris.Context) {
+	defer ctx.Next()
+	ctxt := ctx.Request().Context()
+	sessionToken := ctx.Request().Header.Get("X-Auth-Token")
+	if sessionToken == "" {
+		errorMessage := "error: no X-Auth-Token found in request header"
+		resp := common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errorMessage, nil, nil)
+		common.SetResponseHeader(ctx, resp.Header)
+		ctx.StatusCode(http.StatusUnauthorized)
+		ctx.JSON(&resp.Body)
+		return
+	}
+	authResp, err := s.Auth(sessionToken, []string{common.PrivilegeLogin}, []string{})
+	if authResp.StatusCode != http.StatusOK {
+		errMsg := "error while trying to authenticate session"
+		if err != nil {
+			errMsg = errMsg + ": " + err.Error()
+		}
+		l.LogWithFields(ctxt).Error(errMsg)
+		common.SetResponseHeader(ctx, authResp.Header)
+		ctx.StatusCode(int(authResp.StatusCode))
+		ctx.JSON(&authResp.Body)
+		return
+	}
+	filter, qerr := getEventStreamFilter(ctx.Request().URL.Query())
+	if qerr != nil {
+		l.LogWithFields(ctxt).Error(qerr.Error())
+		resp := qerr.Response()
+		common.SetResponseHeader(ctx, resp.Header)
+		ctx.StatusCode(int(resp.StatusCode))
+		ctx.JSON(&resp.Body)
+		return
+	}
+
+	client, replay := s.Broker.Subscribe(filter, ctx.GetHeader("Last-Event-ID"))
+	defer s.Broker.Unsubscribe(client)
+	l.LogWithFields(ctxt).Info("SSE client connected")
+
+	w := ctx.ResponseWriter()
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	ctx.StatusCode(http.StatusOK)
+	for _, event := range replay {
+		if err := writeEvent(w, event); err != nil {
+			return
+		}
+	}
+	w.Flush()
+
+	keepAlive := time.NewTicker(time.Duration(config.Data.EventConf.SSEKeepAliveIntervalSeconds) * time.Second)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case event, ok := <-client.Events():
+			if !ok {
+				l.LogWithFields(ctxt).Warn("SSE client is disconnected for not keeping up with the events")
+				return
+			}
+			err = writeEvent(w, event)
+		case <-keepAlive.C:
+			if authResp, _ := s.Auth(sessionToken, []string{common.PrivilegeLogin}, []string{}); authResp.StatusCode != http.StatusOK {
+				l.LogWithFields(ctxt).Info("SSE stream is closed as the session is no more valid")
+				return
+			}
+			_, err = io.WriteString(w, ": keep-alive\n\n")
+		}
+		if err != nil {
+			l.LogWithFields(ctxt).Info("SSE client disconnected: " + err.Error())
+			return
+		}
+		w.Flush()
+	}
+}
+
+// writeEvent writes the event in the SSE format
+func writeEvent(w io.Writer, event sse.Event) error {
+	_, err := fmt.Fprintf(w, "id: %s\ndata: %s\n\n", event.ID, event.Data)
+	return err
+}
+
+// getEventStreamFilter returns the $filter given for the event stream, only
+// the properties supported for SSE are accepted in the filter. Other query
+// parameters starting with $ are reported as not supported.
+func getEventStreamFilter(values url.Values) (query.Expression, *query.Error) {
+	for key := range values {
+		if strings.HasPrefix(key, "$") && key != query.Filter {
+			return nil, &query.Error{
+				StatusMessage: response.QueryNotSupported,
+				ErrorMessage:  "error: " + key + " is not supported on the event stream",
+			}
+		}
+	}
+	value := values.Get(query.Filter)
+	if value == "" {
+		return nil, nil
+	}
+	filter, err := query.ParseFilter(value)
+	if err != nil {
+		return nil, &query.Error{
+			StatusMessage: response.QueryParameterValueFormatError,
+			ErrorMessage:  "error: invalid " + query.Filter + ": " + err.Error(),
+			MessageArgs:   []interface{}{value, query.Filter},
+		}
+	}
+	for _, property := range query.FilterProperties(filter) {
+		if !sse.FilterProperties[property] {
+			return nil, &query.Error{
+				StatusMessage: response.QueryParameterValueFormatError,
+				ErrorMessage:  "error: " + property + " is not supported in " + query.Filter + " of the event stream",
+				MessageArgs:   []interface{}{value, query.Filter},
+			}
+		}
+	}
+	return filter, nil
+}
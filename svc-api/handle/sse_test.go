@@ -0,0 +1,72 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package handle
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-api/sse"
+	"github.com/kataras/iris/v12"
+	"github.com/kataras/iris/v12/httptest"
+)
+
+func TestGetEventStreamFilter(t *testing.T) {
+	tests := []struct {
+		name       string
+		values     url.Values
+		wantFilter bool
+		wantErr    string
+	}{
+		{"no filter", url.Values{}, false, ""},
+		{"supported properties", url.Values{"$filter": {"EventType eq 'Alert' and ResourceType eq 'ComputerSystem'"}}, true, ""},
+		{"unsupported property", url.Values{"$filter": {"Severity eq 'Critical'"}}, false, response.QueryParameterValueFormatError},
+		{"invalid filter", url.Values{"$filter": {"EventType eq"}}, false, response.QueryParameterValueFormatError},
+		{"unsupported query", url.Values{"$top": {"1"}}, false, response.QueryNotSupported},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := getEventStreamFilter(tt.values)
+			if (filter != nil) != tt.wantFilter {
+				t.Errorf("getEventStreamFilter() filter = %v, want filter %v", filter, tt.wantFilter)
+			}
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("getEventStreamFilter() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.StatusMessage != tt.wantErr) {
+				t.Errorf("getEventStreamFilter() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetEventStream(t *testing.T) {
+	config.SetUpMockConfig(t)
+	s := ServerSentEvents{
+		Auth:   authMock,
+		Broker: sse.NewBroker(10),
+	}
+	router := iris.New()
+	redfishRoutes := router.Party("/redfish/v1/EventService")
+	redfishRoutes.Get("/SSE", s.GetEventStream)
+	test := httptest.New(t, router)
+	test.GET("/redfish/v1/EventService/SSE").Expect().Status(http.StatusUnauthorized)
+	test.GET("/redfish/v1/EventService/SSE").WithHeader("X-Auth-Token", "invalidToken").Expect().Status(http.StatusUnauthorized)
+	test.GET("/redfish/v1/EventService/SSE").WithHeader("X-Auth-Token", "validToken").WithQuery("$top", "1").Expect().Status(http.StatusBadRequest)
+	test.GET("/redfish/v1/EventService/SSE").WithHeader("X-Auth-Token", "validToken").WithQuery("$filter", "Severity eq 'OK'").Expect().Status(http.StatusBadRequest)
+}
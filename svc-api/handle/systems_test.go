@@ -635,3 +635,35 @@ func TestDeleteVolume(t *testing.T) {
 		"/redfish/v1/Systems/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1/Storage/ArrayControllers-0/Volumes/2",
 	).WithJSON(map[string]string{"Sample": "Body"}).WithHeader("X-Auth-Token", "TokenRPC").Expect().Status(http.StatusInternalServerError)
 }
+
+func mockGetHealthSummary(ctx context.Context, req systemsproto.GetSystemsRequest) (*systemsproto.SystemsResponse, error) {
+	if req.SessionToken == "TokenRPC" {
+		return &systemsproto.SystemsResponse{}, errors.New("Unable to RPC Call")
+	}
+	if req.SessionToken != "ValidToken" {
+		return &systemsproto.SystemsResponse{
+			StatusCode:    http.StatusUnauthorized,
+			StatusMessage: "Unauthorized",
+			Body:          []byte(`{"Response":"Unauthorized"}`),
+		}, nil
+	}
+	return &systemsproto.SystemsResponse{
+		StatusCode:    http.StatusOK,
+		StatusMessage: "Success",
+		Body:          []byte(`{"HealthRollup":"OK"}`),
+	}, nil
+}
+
+func TestGetHealthSummary(t *testing.T) {
+	var sys SystemRPCs
+	sys.GetHealthSummaryRPC = mockGetHealthSummary
+	mockApp := iris.New()
+	redfishRoutes := mockApp.Party("/redfish/v1/Systems")
+	redfishRoutes.Get("/Oem/Odim/HealthSummary", sys.GetHealthSummary)
+
+	e := httptest.New(t, mockApp)
+	e.GET("/redfish/v1/Systems/Oem/Odim/HealthSummary").WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK).JSON().Object().Value("HealthRollup").Equal("OK")
+	e.GET("/redfish/v1/Systems/Oem/Odim/HealthSummary").WithHeader("X-Auth-Token", "InvalidToken").Expect().Status(http.StatusUnauthorized)
+	e.GET("/redfish/v1/Systems/Oem/Odim/HealthSummary").Expect().Status(http.StatusUnauthorized)
+	e.GET("/redfish/v1/Systems/Oem/Odim/HealthSummary").WithHeader("X-Auth-Token", "TokenRPC").Expect().Status(http.StatusInternalServerError)
+}
@@ -18,6 +18,7 @@ package handle
 import (
 	"context"
 	"net/http"
+	"path"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
@@ -202,6 +203,10 @@ func (task *TaskRPCs) TaskCollection(ctx iris.Context) {
 		common.SetResponseHeader(ctx, nil)
 		return
 	}
+	params := getCollectionQuery(ctx, false)
+	if params == nil {
+		return
+	}
 	response, err := task.TaskCollectionRPC(ctxt, req)
 	common.SetResponseHeader(ctx, response.Header)
 
@@ -213,11 +218,21 @@ func (task *TaskRPCs) TaskCollection(ctx iris.Context) {
 		ctx.JSON(&response.Body)
 		return
 	}
+	body := applyCollectionQuery(ctx, params, response.StatusCode, response.Body, func(odataID string) (map[string]interface{}, error) {
+		member, err := task.GetTaskRPC(ctxt, &taskproto.GetTaskRequest{
+			TaskID:       path.Base(odataID),
+			SessionToken: req.SessionToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return decodeMember(member.StatusCode, member.Body)
+	})
 
 	ctx.ResponseWriter().Header().Set("Allow", "GET")
 	common.SetResponseHeader(ctx, response.Header)
 	ctx.StatusCode(int(response.StatusCode))
-	ctx.Write(response.Body)
+	ctx.Write(body)
 
 	return
 }
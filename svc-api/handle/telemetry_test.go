@@ -82,7 +82,7 @@ func TestGetMetricDefinitionCollection(t *testing.T) {
 }
 
 func TestGetMetricReportDefinitionCollection(t *testing.T) {
-	header["Allow"] = []string{"GET"}
+	header["Allow"] = []string{"GET, POST"}
 	defer delete(header, "Allow")
 	var a TelemetryRPCs
 	a.GetMetricReportDefinitionCollectionRPC = testTelemetryService
@@ -232,3 +232,120 @@ func TestUpdateTrigger(t *testing.T) {
 		"/redfish/v1/TelemetryService/Triggers/1",
 	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusInternalServerError)
 }
+
+func TestCreateMetricReportDefinition(t *testing.T) {
+	var a TelemetryRPCs
+	a.CreateMetricReportDefinitionRPC = testTelemetryService
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/TelemetryService")
+	redfishRoutes.Post("/MetricReportDefinitions", a.CreateMetricReportDefinition)
+	test := httptest.New(t, testApp)
+	body := map[string]interface{}{
+		"Id":                         "PowerMetrics",
+		"MetricReportDefinitionType": "OnRequest",
+		"MetricProperties":           []string{"/redfish/v1/Chassis/uuid.1/Power#/PowerControl/0/PowerConsumedWatts"},
+	}
+	test.POST(
+		"/redfish/v1/TelemetryService/MetricReportDefinitions",
+	).WithHeader("X-Auth-Token", "ValidToken").WithJSON(body).Expect().Status(http.StatusOK)
+	test.POST(
+		"/redfish/v1/TelemetryService/MetricReportDefinitions",
+	).WithHeader("X-Auth-Token", "").WithJSON(body).Expect().Status(http.StatusUnauthorized)
+	test.POST(
+		"/redfish/v1/TelemetryService/MetricReportDefinitions",
+	).WithHeader("X-Auth-Token", "token").WithJSON(body).Expect().Status(http.StatusInternalServerError)
+	test.POST(
+		"/redfish/v1/TelemetryService/MetricReportDefinitions",
+	).WithHeader("X-Auth-Token", "ValidToken").WithBytes([]byte(`{"Id":`)).Expect().Status(http.StatusBadRequest)
+}
+
+func TestDeleteMetricReportDefinition(t *testing.T) {
+	var a TelemetryRPCs
+	a.DeleteMetricReportDefinitionRPC = testTelemetryService
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/TelemetryService")
+	redfishRoutes.Delete("/MetricReportDefinitions/{id}", a.DeleteMetricReportDefinition)
+	test := httptest.New(t, testApp)
+	test.DELETE(
+		"/redfish/v1/TelemetryService/MetricReportDefinitions/1",
+	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK)
+	test.DELETE(
+		"/redfish/v1/TelemetryService/MetricReportDefinitions/1",
+	).WithHeader("X-Auth-Token", "").Expect().Status(http.StatusUnauthorized)
+	test.DELETE(
+		"/redfish/v1/TelemetryService/MetricReportDefinitions/1",
+	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusInternalServerError)
+}
+
+func TestCreateTrigger(t *testing.T) {
+	var a TelemetryRPCs
+	a.CreateTriggerRPC = testTelemetryService
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/TelemetryService")
+	redfishRoutes.Post("/Triggers", a.CreateTrigger)
+	test := httptest.New(t, testApp)
+	body := map[string]interface{}{
+		"Id":         "PowerTrigger",
+		"MetricType": "Numeric",
+	}
+	test.POST(
+		"/redfish/v1/TelemetryService/Triggers",
+	).WithHeader("X-Auth-Token", "ValidToken").WithJSON(body).Expect().Status(http.StatusOK)
+	test.POST(
+		"/redfish/v1/TelemetryService/Triggers",
+	).WithHeader("X-Auth-Token", "").WithJSON(body).Expect().Status(http.StatusUnauthorized)
+	test.POST(
+		"/redfish/v1/TelemetryService/Triggers",
+	).WithHeader("X-Auth-Token", "token").WithJSON(body).Expect().Status(http.StatusInternalServerError)
+	test.POST(
+		"/redfish/v1/TelemetryService/Triggers",
+	).WithHeader("X-Auth-Token", "ValidToken").WithBytes([]byte(`{"Id":`)).Expect().Status(http.StatusBadRequest)
+}
+
+func TestDeleteTrigger(t *testing.T) {
+	var a TelemetryRPCs
+	a.DeleteTriggerRPC = testTelemetryService
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/TelemetryService")
+	redfishRoutes.Delete("/Triggers/{id}", a.DeleteTrigger)
+	test := httptest.New(t, testApp)
+	test.DELETE(
+		"/redfish/v1/TelemetryService/Triggers/1",
+	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK)
+	test.DELETE(
+		"/redfish/v1/TelemetryService/Triggers/1",
+	).WithHeader("X-Auth-Token", "").Expect().Status(http.StatusUnauthorized)
+	test.DELETE(
+		"/redfish/v1/TelemetryService/Triggers/1",
+	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusInternalServerError)
+}
+
+func TestGetOpenMetrics(t *testing.T) {
+	var a TelemetryRPCs
+	a.GetOpenMetricsRPC = func(ctx context.Context, req teleproto.TelemetryRequest) (*teleproto.TelemetryResponse, error) {
+		if req.SessionToken == "ValidToken" {
+			return &teleproto.TelemetryResponse{
+				StatusCode:    200,
+				StatusMessage: "Success",
+				Header:        map[string]string{"Content-Type": "application/openmetrics-text; version=1.0.0; charset=utf-8"},
+				Body:          []byte("# EOF\n"),
+			}, nil
+		}
+		return testTelemetryService(ctx, req)
+	}
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/TelemetryService")
+	redfishRoutes.Get("/OpenMetrics", a.GetOpenMetrics)
+	test := httptest.New(t, testApp)
+	resp := test.GET(
+		"/redfish/v1/TelemetryService/OpenMetrics",
+	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK)
+	resp.Header("Content-Type").Equal("application/openmetrics-text; version=1.0.0; charset=utf-8")
+	resp.Body().Equal("# EOF\n")
+	test.GET(
+		"/redfish/v1/TelemetryService/OpenMetrics",
+	).WithHeader("X-Auth-Token", "").Expect().Status(http.StatusUnauthorized)
+	test.GET(
+		"/redfish/v1/TelemetryService/OpenMetrics",
+	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusInternalServerError)
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/ODIM-Project/ODIM/svc-api/apicommon"
 	"github.com/ODIM-Project/ODIM/svc-api/router"
 	"github.com/ODIM-Project/ODIM/svc-api/rpc"
+	"github.com/ODIM-Project/ODIM/svc-api/sse"
 	iris "github.com/kataras/iris/v12"
 )
 
@@ -82,8 +83,13 @@ func main() {
 		r = r.WithContext(ctx)
 		basicAuth := r.Header.Get("Authorization")
 		var basicAuthToken string
+		// the client certificate is used only when the request has no other credentials
+		var clientCertificate []byte
+		if basicAuth == "" && r.Header.Get("X-Auth-Token") == "" {
+			clientCertificate = getClientCertificate(r)
+		}
 
-		if basicAuth != "" {
+		if basicAuth != "" || clientCertificate != nil {
 			var urlNoBasicAuth = []string{"/redfish/v1", "/redfish/v1/SessionService"}
 			var authRequired bool
 			authRequired = true
@@ -95,49 +101,57 @@ func main() {
 				}
 			}
 			if authRequired {
-				var username, password string
-				yes := strings.Contains(basicAuth, "Basic")
-				if yes {
-					spl := strings.Split(basicAuth, " ")
-					if len(spl) != 2 {
+				var req sessionproto.SessionCreateRequest
+				if clientCertificate != nil {
+					// the client certificate is mapped to the account by the account session service
+					req.ClientCertificate = clientCertificate
+				} else if strings.HasPrefix(basicAuth, "Bearer ") {
+					// the bearer token is validated by the account session service, which creates a session for the token
+					req.BearerToken = strings.TrimSpace(strings.TrimPrefix(basicAuth, "Bearer "))
+				} else {
+					var username, password string
+					yes := strings.Contains(basicAuth, "Basic")
+					if yes {
+						spl := strings.Split(basicAuth, " ")
+						if len(spl) != 2 {
+							errorMessage := "Invalid basic auth provided"
+							logs.LogWithFields(ctx).Error(errorMessage)
+							invalidAuthResp(errorMessage, w)
+							return
+						}
+						data, err := base64.StdEncoding.DecodeString(spl[1])
+						if err != nil {
+							errorMessage := "Decoding the authorization failed: " + err.Error()
+							logs.LogWithFields(ctx).Error(err.Error())
+							invalidAuthResp(errorMessage, w)
+							return
+						}
+						userCred := strings.SplitN(string(data), ":", 2)
+						if len(userCred) < 2 {
+							errorMessage := "Invalid basic auth provided"
+							logs.LogWithFields(ctx).Error(errorMessage)
+							invalidAuthResp(errorMessage, w)
+							return
+						}
+						username = userCred[0]
+						password = userCred[1]
+					} else {
 						errorMessage := "Invalid basic auth provided"
 						logs.LogWithFields(ctx).Error(errorMessage)
 						invalidAuthResp(errorMessage, w)
 						return
 					}
-					data, err := base64.StdEncoding.DecodeString(spl[1])
-					if err != nil {
-						errorMessage := "Decoding the authorization failed: " + err.Error()
-						logs.LogWithFields(ctx).Error(err.Error())
-						invalidAuthResp(errorMessage, w)
-						return
-					}
-					userCred := strings.SplitN(string(data), ":", 2)
-					if len(userCred) < 2 {
-						errorMessage := "Invalid basic auth provided"
-						logs.LogWithFields(ctx).Error(errorMessage)
-						invalidAuthResp(errorMessage, w)
-						return
+
+					//Converting the request into a map
+					sessionReq := map[string]interface{}{
+						"UserName": username,
+						"Password": password,
 					}
-					username = userCred[0]
-					password = userCred[1]
-				} else {
-					errorMessage := "Invalid basic auth provided"
-					logs.LogWithFields(ctx).Error(errorMessage)
-					invalidAuthResp(errorMessage, w)
-					return
-				}
+					//Marshalling input to get bytes since session create request accepts bytes
+					sessionReqData, _ := json.Marshal(sessionReq)
 
-				//Converting the request into a map
-				sessionReq := map[string]interface{}{
-					"UserName": username,
-					"Password": password,
+					req.RequestBody = sessionReqData
 				}
-				//Marshalling input to get bytes since session create request accepts bytes
-				sessionReqData, err := json.Marshal(sessionReq)
-
-				var req sessionproto.SessionCreateRequest
-				req.RequestBody = sessionReqData
 				resp, err := rpc.DoSessionCreationRequest(ctx, req)
 				if err != nil && resp == nil {
 					errorMessage := "error: something went wrong with the RPC calls: " + err.Error()
@@ -201,6 +215,11 @@ func main() {
 	if err != nil {
 		logs.Log.Fatal("service initialization failed: " + err.Error())
 	}
+	if clientCertificateConf := config.Data.AuthConf.ClientCertificate; clientCertificateConf != nil && clientCertificateConf.Enabled {
+		if err = clientCertificateConf.SetTLSConfig(apiServer.TLSConfig); err != nil {
+			logs.Log.Fatal("service initialization failed: " + err.Error())
+		}
+	}
 
 	apicommon.ConfigFilePath = os.Getenv("CONFIG_FILE_PATH")
 	if apicommon.ConfigFilePath == "" {
@@ -211,9 +230,25 @@ func main() {
 	// TrackConfigFileChanges monitors the odim config changes using fsnotfiy
 	go apicommon.TrackConfigFileChanges(errChan)
 
+	// subscribing to the events to be streamed to the SSE clients
+	go sse.Consume()
+
 	router.Run(iris.Server(apiServer))
 }
 
+// getClientCertificate returns the client certificate verified during the TLS handshake,
+// or nil if client certificate authentication is disabled or the client has not sent a certificate
+func getClientCertificate(r *http.Request) []byte {
+	conf := config.Data.AuthConf.ClientCertificate
+	if conf == nil || !conf.Enabled || r.TLS == nil {
+		return nil
+	}
+	if len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return r.TLS.VerifiedChains[0][0].Raw
+}
+
 // invalidAuthResp function is used to generate an invalid credentials response
 func invalidAuthResp(errMsg string, w http.ResponseWriter) {
 	common.SetCommonHeaders(w)
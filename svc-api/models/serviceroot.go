@@ -66,10 +66,11 @@ type ServiceRoot struct {
 //PFSupported struct definition
 type PFSupported struct {
 	ExcerptQuery    bool         `json:"ExcerptQuery"`
-	ExpandQuery     *ExpandQuery `json:"ExpandQuery:omitempty"`
+	ExpandQuery     *ExpandQuery `json:"ExpandQuery,omitempty"`
 	FilterQuery     bool         `json:"FilterQuery"`
 	OnlyMemberQuery bool         `json:"OnlyMemberQuery"`
 	SelectQuery     bool         `json:"SelectQuery"`
+	TopSkipQuery    bool         `json:"TopSkipQuery"`
 }
 
 //ExpandQuery struct definition
@@ -36,6 +36,7 @@ import (
 	"github.com/ODIM-Project/ODIM/svc-api/middleware"
 	"github.com/ODIM-Project/ODIM/svc-api/ratelimiter"
 	"github.com/ODIM-Project/ODIM/svc-api/rpc"
+	"github.com/ODIM-Project/ODIM/svc-api/sse"
 	"github.com/kataras/iris/v12"
 )
 
@@ -45,6 +46,7 @@ var cs handle.CompositionServiceRPCs
 // Router method to register API handlers.
 func Router() *iris.Application {
 	r := handle.RoleRPCs{
+		CreateRoleRPC:  rpc.CreateRole,
 		GetAllRolesRPC: rpc.GetAllRoles,
 		GetRoleRPC:     rpc.GetRole,
 		UpdateRoleRPC:  rpc.UpdateRole,
@@ -63,6 +65,18 @@ func Router() *iris.Application {
 		ResetRPC:                                rpc.DoResetRequest,
 		SetDefaultBootOrderRPC:                  rpc.DoSetDefaultBootOrderRequest,
 		AddAggregationSourceRPC:                 rpc.DoAddAggregationSource,
+		BulkAddAggregationSourcesRPC:            rpc.DoBulkAddAggregationSources,
+		DiscoverAggregationSourcesRPC:           rpc.DoDiscoverAggregationSources,
+		ApproveDiscoveredAggregationSourcesRPC:  rpc.DoApproveDiscoveredAggregationSources,
+		RotateAggregationSourceCredentialsRPC:   rpc.DoRotateAggregationSourceCredentials,
+		GetAllDiscoveredAggregationSourcesRPC:   rpc.DoGetAllDiscoveredAggregationSources,
+		GetDiscoveredAggregationSourceRPC:       rpc.DoGetDiscoveredAggregationSource,
+		GetInventoryHistoryRPC:                  rpc.DoGetInventoryHistory,
+		GetInventorySyncRPC:                     rpc.DoGetInventorySync,
+		UpdateInventorySyncRPC:                  rpc.DoUpdateInventorySync,
+		ValidateInteropProfileRPC:               rpc.DoValidateInteropProfile,
+		GetConformanceReportsRPC:                rpc.DoGetConformanceReports,
+		GetConformanceReportRPC:                 rpc.DoGetConformanceReport,
 		GetAllAggregationSourceRPC:              rpc.DoGetAllAggregationSource,
 		GetAggregationSourceRPC:                 rpc.DoGetAggregationSource,
 		UpdateAggregationSourceRPC:              rpc.DoUpdateAggregationSource,
@@ -75,6 +89,8 @@ func Router() *iris.Application {
 		RemoveElementsFromAggregateRPC:          rpc.DoRemoveElementsFromAggregate,
 		ResetAggregateElementsRPC:               rpc.DoResetAggregateElements,
 		SetDefaultBootOrderAggregateElementsRPC: rpc.DoSetDefaultBootOrderAggregateElements,
+		ApplySettingsAggregateElementsRPC:       rpc.DoApplySettingsAggregateElements,
+		GetAggregateSettingsDriftRPC:            rpc.DoGetAggregateSettingsDrift,
 		GetAllConnectionMethodsRPC:              rpc.DoGetAllConnectionMethods,
 		GetConnectionMethodRPC:                  rpc.DoGetConnectionMethod,
 		GetResetActionInfoServiceRPC:            rpc.DoGetResetActionInfoService,
@@ -109,6 +125,7 @@ func Router() *iris.Application {
 		ChangeBootOrderSettingsRPC: rpc.ChangeBootOrderSettings,
 		CreateVolumeRPC:            rpc.CreateVolume,
 		DeleteVolumeRPC:            rpc.DeleteVolume,
+		GetHealthSummaryRPC:        rpc.GetHealthSummary,
 	}
 
 	cha := handle.ChassisRPCs{
@@ -127,6 +144,8 @@ func Router() *iris.Application {
 		GetEventSubscriptionRPC:            rpc.DoGetEventSubscription,
 		DeleteEventSubscriptionRPC:         rpc.DoDeleteEventSubscription,
 		GetEventSubscriptionsCollectionRPC: rpc.DoGetEventSubscriptionsCollection,
+		ResumeEventSubscriptionRPC:         rpc.DoResumeEventSubscription,
+		GetUndeliveredEventsCollectionRPC:  rpc.DoGetUndeliveredEventsCollection,
 	}
 
 	fab := handle.FabricRPCs{
@@ -168,6 +187,11 @@ func Router() *iris.Application {
 		GetMetricReportRPC:                     rpc.DoGetMetricReport,
 		GetTriggerRPC:                          rpc.DoGetTrigger,
 		UpdateTriggerRPC:                       rpc.DoUpdateTrigger,
+		CreateMetricReportDefinitionRPC:        rpc.DoCreateMetricReportDefinition,
+		DeleteMetricReportDefinitionRPC:        rpc.DoDeleteMetricReportDefinition,
+		CreateTriggerRPC:                       rpc.DoCreateTrigger,
+		DeleteTriggerRPC:                       rpc.DoDeleteTrigger,
+		GetOpenMetricsRPC:                      rpc.DoGetOpenMetrics,
 	}
 
 	for _, service := range config.Data.EnabledServices {
@@ -204,6 +228,10 @@ func Router() *iris.Application {
 	registryFile := handle.Registry{
 		Auth: srv.IsAuthorized,
 	}
+	eventStream := handle.ServerSentEvents{
+		Auth:   srv.IsAuthorized,
+		Broker: sse.GetBroker(),
+	}
 	logService := l.Logging{
 		GetUserDetails: loggingService.GetUserDetails,
 	}
@@ -342,6 +370,7 @@ func Router() *iris.Application {
 	role.SetRegisterRule(iris.RouteSkip)
 	role.Get("/", r.GetAllRoles)
 	role.Get("/{id}", r.GetRole)
+	role.Post("/", r.CreateRole)
 	role.Patch("/{id}", r.UpdateRole)
 	role.Delete("/{id}", r.DeleteRole)
 	role.Any("/", handle.RoleMethodNotAllowed)
@@ -364,6 +393,8 @@ func Router() *iris.Application {
 	systems := v1.Party("/Systems", middleware.SessionDelMiddleware)
 	systems.SetRegisterRule(iris.RouteSkip)
 	systems.Get("/", system.GetSystemsCollection)
+	systems.Get("/Oem/Odim/HealthSummary", system.GetHealthSummary)
+	systems.Any("/Oem/Odim/HealthSummary", handle.SystemsMethodNotAllowed)
 	systems.Get("/{id}", system.GetSystem)
 	systems.Get("/{id}/Processors", system.GetSystemResource)
 	systems.Get("/{id}/Processors/{rid}", system.GetSystemResource)
@@ -464,8 +495,39 @@ func Router() *iris.Application {
 	aggregation.Any("/Actions/AggregationService.Reset/", handle.AggMethodNotAllowed)
 	aggregation.Post("/Actions/AggregationService.SetDefaultBootOrder/", pc.SetDefaultBootOrder)
 	aggregation.Any("/Actions/AggregationService.SetDefaultBootOrder/", handle.AggMethodNotAllowed)
+	aggregation.Post("/Actions/Oem/Odim.BulkAddAggregationSources/", pc.BulkAddAggregationSources)
+	aggregation.Any("/Actions/Oem/Odim.BulkAddAggregationSources/", handle.AggMethodNotAllowed)
+	aggregation.Post("/Actions/Oem/Odim.DiscoverAggregationSources/", pc.DiscoverAggregationSources)
+	aggregation.Any("/Actions/Oem/Odim.DiscoverAggregationSources/", handle.AggMethodNotAllowed)
+	aggregation.Post("/Actions/Oem/Odim.ApproveDiscoveredAggregationSources/", pc.ApproveDiscoveredAggregationSources)
+	aggregation.Any("/Actions/Oem/Odim.ApproveDiscoveredAggregationSources/", handle.AggMethodNotAllowed)
+	aggregation.Post("/Actions/Oem/Odim.RotateAggregationSourceCredentials/", pc.RotateAggregationSourceCredentials)
+	aggregation.Any("/Actions/Oem/Odim.RotateAggregationSourceCredentials/", handle.AggMethodNotAllowed)
+	aggregation.Post("/Actions/Oem/Odim.ValidateInteropProfile/", pc.ValidateInteropProfile)
+	aggregation.Any("/Actions/Oem/Odim.ValidateInteropProfile/", handle.AggMethodNotAllowed)
 	aggregation.Any("/", handle.AggMethodNotAllowed)
 
+	discoveredAggregationSource := aggregation.Party("/Oem/Odim/DiscoveredAggregationSources", middleware.SessionDelMiddleware)
+	discoveredAggregationSource.Get("/", pc.GetAllDiscoveredAggregationSources)
+	discoveredAggregationSource.Any("/", handle.AggMethodNotAllowed)
+	discoveredAggregationSource.Get("/{id}", pc.GetDiscoveredAggregationSource)
+	discoveredAggregationSource.Any("/{id}", handle.AggMethodNotAllowed)
+
+	inventoryHistory := aggregation.Party("/Oem/Odim/InventoryHistory", middleware.SessionDelMiddleware)
+	inventoryHistory.Get("/{id}", pc.GetInventoryHistory)
+	inventoryHistory.Any("/{id}", handle.AggMethodNotAllowed)
+
+	inventorySync := aggregation.Party("/Oem/Odim/InventorySync", middleware.SessionDelMiddleware)
+	inventorySync.Get("/", pc.GetInventorySync)
+	inventorySync.Patch("/", pc.UpdateInventorySync)
+	inventorySync.Any("/", handle.AggMethodNotAllowed)
+
+	conformanceReports := aggregation.Party("/Oem/Odim/ConformanceReports", middleware.SessionDelMiddleware)
+	conformanceReports.Get("/", pc.GetConformanceReports)
+	conformanceReports.Any("/", handle.AggMethodNotAllowed)
+	conformanceReports.Get("/{id}", pc.GetConformanceReport)
+	conformanceReports.Any("/{id}", handle.AggMethodNotAllowed)
+
 	aggregationSource := aggregation.Party("/AggregationSources", middleware.SessionDelMiddleware)
 	aggregationSource.Post("/", pc.AddAggregationSource)
 	aggregationSource.Get("/", pc.GetAllAggregationSource)
@@ -496,6 +558,10 @@ func Router() *iris.Application {
 	aggregates.Any("/{id}/Actions/Aggregate.Reset/", handle.AggregateMethodNotAllowed)
 	aggregates.Post("/{id}/Actions/Aggregate.SetDefaultBootOrder/", pc.SetDefaultBootOrderAggregateElements)
 	aggregates.Any("/{id}/Actions/Aggregate.SetDefaultBootOrder/", handle.AggregateMethodNotAllowed)
+	aggregates.Post("/{id}/Actions/Aggregate.ApplySettings/", pc.ApplySettingsAggregateElements)
+	aggregates.Any("/{id}/Actions/Aggregate.ApplySettings/", handle.AggregateMethodNotAllowed)
+	aggregates.Get("/{id}/Oem/Odim/SettingsDrift", pc.GetAggregateSettingsDrift)
+	aggregates.Any("/{id}/Oem/Odim/SettingsDrift", handle.AggregateMethodNotAllowed)
 
 	chassis := v1.Party("/Chassis", middleware.SessionDelMiddleware)
 	chassis.SetRegisterRule(iris.RouteSkip)
@@ -573,13 +639,19 @@ func Router() *iris.Application {
 	events.Get("/", evt.GetEventService)
 	events.Get("/Subscriptions", evt.GetEventSubscriptionsCollection)
 	events.Get("/Subscriptions/{id}", evt.GetEventSubscription)
+	events.Get("/Subscriptions/{id}/UndeliveredEvents", evt.GetUndeliveredEventsCollection)
+	events.Get("/SSE", eventStream.GetEventStream)
 	events.Post("/Subscriptions", evt.CreateEventSubscription)
 	events.Post("/Actions/EventService.SubmitTestEvent", evt.SubmitTestEvent)
+	events.Post("/Subscriptions/{id}/Actions/EventDestination.ResumeSubscription", evt.ResumeEventSubscription)
 	events.Delete("/Subscriptions/{id}", evt.DeleteEventSubscription)
 	events.Any("/", handle.EvtMethodNotAllowed)
 	events.Any("/Actions", handle.EvtMethodNotAllowed)
 	events.Any("/Actions/EventService.SubmitTestEvent", handle.EvtMethodNotAllowed)
 	events.Any("/Subscriptions", handle.EvtMethodNotAllowed)
+	events.Any("/Subscriptions/{id}/Actions/EventDestination.ResumeSubscription", handle.EvtMethodNotAllowed)
+	events.Any("/Subscriptions/{id}/UndeliveredEvents", handle.EvtMethodNotAllowed)
+	events.Any("/SSE", handle.EvtMethodNotAllowed)
 
 	fabrics := v1.Party("/Fabrics", middleware.SessionDelMiddleware)
 	fabrics.SetRegisterRule(iris.RouteSkip)
@@ -703,7 +775,12 @@ func Router() *iris.Application {
 	telemetryService.Get("/MetricReportDefinitions/{id}", telemetry.GetMetricReportDefinition)
 	telemetryService.Get("/MetricReports/{id}", telemetry.GetMetricReport)
 	telemetryService.Get("/Triggers/{id}", telemetry.GetTrigger)
+	telemetryService.Post("/MetricReportDefinitions", telemetry.CreateMetricReportDefinition)
+	telemetryService.Delete("/MetricReportDefinitions/{id}", telemetry.DeleteMetricReportDefinition)
+	telemetryService.Post("/Triggers", telemetry.CreateTrigger)
 	telemetryService.Patch("/Triggers/{id}", telemetry.UpdateTrigger)
+	telemetryService.Delete("/Triggers/{id}", telemetry.DeleteTrigger)
+	telemetryService.Get("/OpenMetrics", telemetry.GetOpenMetrics)
 	telemetryService.Any("/MetricDefinitions", handle.MethodNotAllowed)
 	telemetryService.Any("/MetricReportDefinitions", handle.MethodNotAllowed)
 	telemetryService.Any("/MetricReports", handle.MethodNotAllowed)
@@ -712,6 +789,7 @@ func Router() *iris.Application {
 	telemetryService.Any("/MetricReportDefinitions/{id}", handle.MethodNotAllowed)
 	telemetryService.Any("/MetricReports/{id}", handle.MethodNotAllowed)
 	telemetryService.Any("/Triggers/{id}", handle.MethodNotAllowed)
+	telemetryService.Any("/OpenMetrics", handle.MethodNotAllowed)
 
 	licenseService := v1.Party("/LicenseService", middleware.SessionDelMiddleware)
 	licenseService.SetRegisterRule(iris.RouteSkip)
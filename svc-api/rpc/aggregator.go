@@ -104,6 +104,234 @@ func DoAddAggregationSource(ctx context.Context, req aggregatorproto.AggregatorR
 	return resp, err
 }
 
+// DoBulkAddAggregationSources defines the RPC call function for
+// the BulkAddAggregationSources from aggregator micro service
+func DoBulkAddAggregationSources(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Aggregator)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	aggregator := NewAggregatorClientFunc(conn)
+
+	resp, err := aggregator.BulkAddAggregationSources(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
+// DoDiscoverAggregationSources defines the RPC call function for
+// the DiscoverAggregationSources from aggregator micro service
+func DoDiscoverAggregationSources(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Aggregator)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	aggregator := NewAggregatorClientFunc(conn)
+
+	resp, err := aggregator.DiscoverAggregationSources(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
+// DoApproveDiscoveredAggregationSources defines the RPC call function for
+// the ApproveDiscoveredAggregationSources from aggregator micro service
+func DoApproveDiscoveredAggregationSources(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Aggregator)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	aggregator := NewAggregatorClientFunc(conn)
+
+	resp, err := aggregator.ApproveDiscoveredAggregationSources(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
+// DoRotateAggregationSourceCredentials defines the RPC call function for
+// the RotateAggregationSourceCredentials from aggregator micro service
+func DoRotateAggregationSourceCredentials(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Aggregator)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	aggregator := NewAggregatorClientFunc(conn)
+
+	resp, err := aggregator.RotateAggregationSourceCredentials(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
+// DoGetAllDiscoveredAggregationSources defines the RPC call function for
+// the GetAllDiscoveredAggregationSources from aggregator micro service
+func DoGetAllDiscoveredAggregationSources(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Aggregator)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	aggregator := NewAggregatorClientFunc(conn)
+
+	resp, err := aggregator.GetAllDiscoveredAggregationSources(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
+// DoGetDiscoveredAggregationSource defines the RPC call function for
+// the GetDiscoveredAggregationSource from aggregator micro service
+func DoGetDiscoveredAggregationSource(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Aggregator)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	aggregator := NewAggregatorClientFunc(conn)
+
+	resp, err := aggregator.GetDiscoveredAggregationSource(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
+// DoGetInventoryHistory defines the RPC call function for
+// the GetInventoryHistory from aggregator micro service
+func DoGetInventoryHistory(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Aggregator)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	aggregator := NewAggregatorClientFunc(conn)
+
+	resp, err := aggregator.GetInventoryHistory(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
+// DoGetInventorySync defines the RPC call function for
+// the GetInventorySync from aggregator micro service
+func DoGetInventorySync(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Aggregator)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	aggregator := NewAggregatorClientFunc(conn)
+
+	resp, err := aggregator.GetInventorySync(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
+// DoUpdateInventorySync defines the RPC call function for
+// the UpdateInventorySync from aggregator micro service
+func DoUpdateInventorySync(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Aggregator)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	aggregator := NewAggregatorClientFunc(conn)
+
+	resp, err := aggregator.UpdateInventorySync(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
+// DoValidateInteropProfile defines the RPC call function for
+// the ValidateInteropProfile from aggregator micro service
+func DoValidateInteropProfile(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Aggregator)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	aggregator := NewAggregatorClientFunc(conn)
+
+	resp, err := aggregator.ValidateInteropProfile(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
+// DoGetConformanceReports defines the RPC call function for
+// the GetConformanceReports from aggregator micro service
+func DoGetConformanceReports(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Aggregator)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	aggregator := NewAggregatorClientFunc(conn)
+
+	resp, err := aggregator.GetConformanceReports(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
+// DoGetConformanceReport defines the RPC call function for
+// the GetConformanceReport from aggregator micro service
+func DoGetConformanceReport(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Aggregator)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	aggregator := NewAggregatorClientFunc(conn)
+
+	resp, err := aggregator.GetConformanceReport(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
 // DoGetAllAggregationSource defines the RPC call function for
 // the GetAllAggregationSource from aggregator micro service
 func DoGetAllAggregationSource(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
@@ -332,6 +560,44 @@ func DoSetDefaultBootOrderAggregateElements(ctx context.Context, req aggregatorp
 	return resp, err
 }
 
+// DoApplySettingsAggregateElements defines the RPC call function for
+// the apply settings elements of an aggregate from aggregator micro service
+func DoApplySettingsAggregateElements(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Aggregator)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	aggregator := NewAggregatorClientFunc(conn)
+
+	resp, err := aggregator.ApplySettingsElementsOfAggregate(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
+// DoGetAggregateSettingsDrift defines the RPC call function for
+// the get settings drift of an aggregate from aggregator micro service
+func DoGetAggregateSettingsDrift(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Aggregator)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	aggregator := NewAggregatorClientFunc(conn)
+
+	resp, err := aggregator.GetAggregateSettingsDrift(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
 // DoGetAllConnectionMethods defines the RPC call function for
 // the get connection method collection from aggregator micro service
 func DoGetAllConnectionMethods(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
@@ -218,6 +218,546 @@ func TestDoAddAggregationSource(t *testing.T) {
 	}
 }
 
+func TestDoBulkAddAggregationSources(t *testing.T) {
+	type args struct {
+		req aggregatorproto.AggregatorRequest
+	}
+	tests := []struct {
+		name                    string
+		args                    args
+		ClientFunc              func(clientName string) (*grpc.ClientConn, error)
+		NewAggregatorClientFunc func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient
+		want                    *aggregatorproto.AggregatorResponse
+		wantErr                 bool
+	}{
+		{
+			name:                    "Client func error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return nil },
+			want:                    nil,
+			wantErr:                 true,
+		},
+		{
+			name:                    "BulkAddAggregationSources error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return fakeStruct{} },
+			want:                    nil,
+			wantErr:                 true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewAggregatorClientFunc = tt.NewAggregatorClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DoBulkAddAggregationSources(context.Background(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoBulkAddAggregationSources() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DoBulkAddAggregationSources() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoDiscoverAggregationSources(t *testing.T) {
+	type args struct {
+		req aggregatorproto.AggregatorRequest
+	}
+	tests := []struct {
+		name                    string
+		args                    args
+		ClientFunc              func(clientName string) (*grpc.ClientConn, error)
+		NewAggregatorClientFunc func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient
+		want                    *aggregatorproto.AggregatorResponse
+		wantErr                 bool
+	}{
+		{
+			name:                    "Client func error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return nil },
+			want:                    nil,
+			wantErr:                 true,
+		},
+		{
+			name:                    "DiscoverAggregationSources error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return fakeStruct{} },
+			want:                    nil,
+			wantErr:                 true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewAggregatorClientFunc = tt.NewAggregatorClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DoDiscoverAggregationSources(context.Background(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoDiscoverAggregationSources() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DoDiscoverAggregationSources() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoApproveDiscoveredAggregationSources(t *testing.T) {
+	type args struct {
+		req aggregatorproto.AggregatorRequest
+	}
+	tests := []struct {
+		name                    string
+		args                    args
+		ClientFunc              func(clientName string) (*grpc.ClientConn, error)
+		NewAggregatorClientFunc func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient
+		want                    *aggregatorproto.AggregatorResponse
+		wantErr                 bool
+	}{
+		{
+			name:                    "Client func error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return nil },
+			want:                    nil,
+			wantErr:                 true,
+		},
+		{
+			name:                    "ApproveDiscoveredAggregationSources error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return fakeStruct{} },
+			want:                    nil,
+			wantErr:                 true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewAggregatorClientFunc = tt.NewAggregatorClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DoApproveDiscoveredAggregationSources(context.Background(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoApproveDiscoveredAggregationSources() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DoApproveDiscoveredAggregationSources() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoRotateAggregationSourceCredentials(t *testing.T) {
+	type args struct {
+		req aggregatorproto.AggregatorRequest
+	}
+	tests := []struct {
+		name                    string
+		args                    args
+		ClientFunc              func(clientName string) (*grpc.ClientConn, error)
+		NewAggregatorClientFunc func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient
+		want                    *aggregatorproto.AggregatorResponse
+		wantErr                 bool
+	}{
+		{
+			name:                    "Client func error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return nil },
+			want:                    nil,
+			wantErr:                 true,
+		},
+		{
+			name:                    "RotateAggregationSourceCredentials error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return fakeStruct{} },
+			want:                    nil,
+			wantErr:                 true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewAggregatorClientFunc = tt.NewAggregatorClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DoRotateAggregationSourceCredentials(context.Background(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoRotateAggregationSourceCredentials() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DoRotateAggregationSourceCredentials() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoGetAllDiscoveredAggregationSources(t *testing.T) {
+	type args struct {
+		req aggregatorproto.AggregatorRequest
+	}
+	tests := []struct {
+		name                    string
+		args                    args
+		ClientFunc              func(clientName string) (*grpc.ClientConn, error)
+		NewAggregatorClientFunc func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient
+		want                    *aggregatorproto.AggregatorResponse
+		wantErr                 bool
+	}{
+		{
+			name:                    "Client func error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return nil },
+			want:                    nil,
+			wantErr:                 true,
+		},
+		{
+			name:                    "GetAllDiscoveredAggregationSources error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return fakeStruct{} },
+			want:                    nil,
+			wantErr:                 true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewAggregatorClientFunc = tt.NewAggregatorClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DoGetAllDiscoveredAggregationSources(context.Background(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoGetAllDiscoveredAggregationSources() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DoGetAllDiscoveredAggregationSources() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoGetDiscoveredAggregationSource(t *testing.T) {
+	type args struct {
+		req aggregatorproto.AggregatorRequest
+	}
+	tests := []struct {
+		name                    string
+		args                    args
+		ClientFunc              func(clientName string) (*grpc.ClientConn, error)
+		NewAggregatorClientFunc func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient
+		want                    *aggregatorproto.AggregatorResponse
+		wantErr                 bool
+	}{
+		{
+			name:                    "Client func error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return nil },
+			want:                    nil,
+			wantErr:                 true,
+		},
+		{
+			name:                    "GetDiscoveredAggregationSource error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return fakeStruct{} },
+			want:                    nil,
+			wantErr:                 true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewAggregatorClientFunc = tt.NewAggregatorClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DoGetDiscoveredAggregationSource(context.Background(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoGetDiscoveredAggregationSource() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DoGetDiscoveredAggregationSource() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoGetInventoryHistory(t *testing.T) {
+	type args struct {
+		req aggregatorproto.AggregatorRequest
+	}
+	tests := []struct {
+		name                    string
+		args                    args
+		ClientFunc              func(clientName string) (*grpc.ClientConn, error)
+		NewAggregatorClientFunc func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient
+		want                    *aggregatorproto.AggregatorResponse
+		wantErr                 bool
+	}{
+		{
+			name:                    "Client func error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return nil },
+			want:                    nil,
+			wantErr:                 true,
+		},
+		{
+			name:                    "GetInventoryHistory error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return fakeStruct{} },
+			want:                    nil,
+			wantErr:                 true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewAggregatorClientFunc = tt.NewAggregatorClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DoGetInventoryHistory(context.Background(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoGetInventoryHistory() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DoGetInventoryHistory() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoGetInventorySync(t *testing.T) {
+	type args struct {
+		req aggregatorproto.AggregatorRequest
+	}
+	tests := []struct {
+		name                    string
+		args                    args
+		ClientFunc              func(clientName string) (*grpc.ClientConn, error)
+		NewAggregatorClientFunc func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient
+		want                    *aggregatorproto.AggregatorResponse
+		wantErr                 bool
+	}{
+		{
+			name:                    "Client func error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return nil },
+			want:                    nil,
+			wantErr:                 true,
+		},
+		{
+			name:                    "GetInventorySync error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return fakeStruct{} },
+			want:                    nil,
+			wantErr:                 true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewAggregatorClientFunc = tt.NewAggregatorClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DoGetInventorySync(context.Background(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoGetInventorySync() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DoGetInventorySync() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoUpdateInventorySync(t *testing.T) {
+	type args struct {
+		req aggregatorproto.AggregatorRequest
+	}
+	tests := []struct {
+		name                    string
+		args                    args
+		ClientFunc              func(clientName string) (*grpc.ClientConn, error)
+		NewAggregatorClientFunc func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient
+		want                    *aggregatorproto.AggregatorResponse
+		wantErr                 bool
+	}{
+		{
+			name:                    "Client func error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return nil },
+			want:                    nil,
+			wantErr:                 true,
+		},
+		{
+			name:                    "UpdateInventorySync error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return fakeStruct{} },
+			want:                    nil,
+			wantErr:                 true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewAggregatorClientFunc = tt.NewAggregatorClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DoUpdateInventorySync(context.Background(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoUpdateInventorySync() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DoUpdateInventorySync() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoValidateInteropProfile(t *testing.T) {
+	type args struct {
+		req aggregatorproto.AggregatorRequest
+	}
+	tests := []struct {
+		name                    string
+		args                    args
+		ClientFunc              func(clientName string) (*grpc.ClientConn, error)
+		NewAggregatorClientFunc func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient
+		want                    *aggregatorproto.AggregatorResponse
+		wantErr                 bool
+	}{
+		{
+			name:                    "Client func error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return nil },
+			want:                    nil,
+			wantErr:                 true,
+		},
+		{
+			name:                    "ValidateInteropProfile error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return fakeStruct{} },
+			want:                    nil,
+			wantErr:                 true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewAggregatorClientFunc = tt.NewAggregatorClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DoValidateInteropProfile(context.Background(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoValidateInteropProfile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DoValidateInteropProfile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoGetConformanceReports(t *testing.T) {
+	type args struct {
+		req aggregatorproto.AggregatorRequest
+	}
+	tests := []struct {
+		name                    string
+		args                    args
+		ClientFunc              func(clientName string) (*grpc.ClientConn, error)
+		NewAggregatorClientFunc func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient
+		want                    *aggregatorproto.AggregatorResponse
+		wantErr                 bool
+	}{
+		{
+			name:                    "Client func error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return nil },
+			want:                    nil,
+			wantErr:                 true,
+		},
+		{
+			name:                    "GetConformanceReports error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return fakeStruct{} },
+			want:                    nil,
+			wantErr:                 true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewAggregatorClientFunc = tt.NewAggregatorClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DoGetConformanceReports(context.Background(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoGetConformanceReports() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DoGetConformanceReports() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoGetConformanceReport(t *testing.T) {
+	type args struct {
+		req aggregatorproto.AggregatorRequest
+	}
+	tests := []struct {
+		name                    string
+		args                    args
+		ClientFunc              func(clientName string) (*grpc.ClientConn, error)
+		NewAggregatorClientFunc func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient
+		want                    *aggregatorproto.AggregatorResponse
+		wantErr                 bool
+	}{
+		{
+			name:                    "Client func error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return nil },
+			want:                    nil,
+			wantErr:                 true,
+		},
+		{
+			name:                    "GetConformanceReport error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return fakeStruct{} },
+			want:                    nil,
+			wantErr:                 true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewAggregatorClientFunc = tt.NewAggregatorClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DoGetConformanceReport(context.Background(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoGetConformanceReport() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DoGetConformanceReport() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestDoGetAllAggregationSource(t *testing.T) {
 	type args struct {
 		req aggregatorproto.AggregatorRequest
@@ -758,6 +1298,96 @@ func TestDoSetDefaultBootOrderAggregateElements(t *testing.T) {
 	}
 }
 
+func TestDoApplySettingsAggregateElements(t *testing.T) {
+	type args struct {
+		req aggregatorproto.AggregatorRequest
+	}
+	tests := []struct {
+		name                    string
+		args                    args
+		ClientFunc              func(clientName string) (*grpc.ClientConn, error)
+		NewAggregatorClientFunc func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient
+		want                    *aggregatorproto.AggregatorResponse
+		wantErr                 bool
+	}{
+		{
+			name:                    "Client func error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return nil },
+			want:                    nil,
+			wantErr:                 true,
+		},
+		{
+			name:                    "ApplySettingsAggregateElements error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return fakeStruct{} },
+			want:                    nil,
+			wantErr:                 true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewAggregatorClientFunc = tt.NewAggregatorClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DoApplySettingsAggregateElements(context.Background(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoApplySettingsAggregateElements() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DoApplySettingsAggregateElements() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoGetAggregateSettingsDrift(t *testing.T) {
+	type args struct {
+		req aggregatorproto.AggregatorRequest
+	}
+	tests := []struct {
+		name                    string
+		args                    args
+		ClientFunc              func(clientName string) (*grpc.ClientConn, error)
+		NewAggregatorClientFunc func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient
+		want                    *aggregatorproto.AggregatorResponse
+		wantErr                 bool
+	}{
+		{
+			name:                    "Client func error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return nil },
+			want:                    nil,
+			wantErr:                 true,
+		},
+		{
+			name:                    "GetAggregateSettingsDrift error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return fakeStruct{} },
+			want:                    nil,
+			wantErr:                 true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewAggregatorClientFunc = tt.NewAggregatorClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DoGetAggregateSettingsDrift(context.Background(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoGetAggregateSettingsDrift() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DoGetAggregateSettingsDrift() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestDoGetAllConnectionMethods(t *testing.T) {
 	type args struct {
 		req aggregatorproto.AggregatorRequest
@@ -141,3 +141,41 @@ func DoGetEventSubscriptionsCollection(ctx context.Context, req eventsproto.Even
 	defer conn.Close()
 	return resp, err
 }
+
+// DoResumeEventSubscription defines the RPC call function for
+// the ResumeEventSubscription from events micro service
+func DoResumeEventSubscription(ctx context.Context, req eventsproto.EventRequest) (*eventsproto.EventSubResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Events)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	events := NewEventsClientFunc(conn)
+
+	resp, err := events.ResumeEventSubscription(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
+// DoGetUndeliveredEventsCollection defines the RPC call function for
+// the GetUndeliveredEventsCollection from events micro service
+func DoGetUndeliveredEventsCollection(ctx context.Context, req eventsproto.EventRequest) (*eventsproto.EventSubResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Events)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	events := NewEventsClientFunc(conn)
+
+	resp, err := events.GetUndeliveredEventsCollection(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
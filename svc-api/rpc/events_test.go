@@ -307,3 +307,93 @@ func TestDoGetEventSubscriptionsCollection(t *testing.T) {
 		})
 	}
 }
+
+func TestDoResumeEventSubscription(t *testing.T) {
+	type args struct {
+		req eventsproto.EventRequest
+	}
+	tests := []struct {
+		name                string
+		args                args
+		ClientFunc          func(clientName string) (*grpc.ClientConn, error)
+		NewEventsClientFunc func(cc *grpc.ClientConn) eventsproto.EventsClient
+		want                *eventsproto.EventSubResponse
+		wantErr             bool
+	}{
+		{
+			name:                "Client func error",
+			args:                args{},
+			ClientFunc:          func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewEventsClientFunc: func(cc *grpc.ClientConn) eventsproto.EventsClient { return nil },
+			want:                nil,
+			wantErr:             true,
+		},
+		{
+			name:                "ResumeEventSubscription error",
+			args:                args{},
+			ClientFunc:          func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewEventsClientFunc: func(cc *grpc.ClientConn) eventsproto.EventsClient { return fakeStruct{} },
+			want:                nil,
+			wantErr:             true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewEventsClientFunc = tt.NewEventsClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DoResumeEventSubscription(context.Background(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoResumeEventSubscription() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DoResumeEventSubscription() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoGetUndeliveredEventsCollection(t *testing.T) {
+	type args struct {
+		req eventsproto.EventRequest
+	}
+	tests := []struct {
+		name                string
+		args                args
+		ClientFunc          func(clientName string) (*grpc.ClientConn, error)
+		NewEventsClientFunc func(cc *grpc.ClientConn) eventsproto.EventsClient
+		want                *eventsproto.EventSubResponse
+		wantErr             bool
+	}{
+		{
+			name:                "Client func error",
+			args:                args{},
+			ClientFunc:          func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewEventsClientFunc: func(cc *grpc.ClientConn) eventsproto.EventsClient { return nil },
+			want:                nil,
+			wantErr:             true,
+		},
+		{
+			name:                "GetUndeliveredEventsCollection error",
+			args:                args{},
+			ClientFunc:          func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewEventsClientFunc: func(cc *grpc.ClientConn) eventsproto.EventsClient { return fakeStruct{} },
+			want:                nil,
+			wantErr:             true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewEventsClientFunc = tt.NewEventsClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DoGetUndeliveredEventsCollection(context.Background(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoGetUndeliveredEventsCollection() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DoGetUndeliveredEventsCollection() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -66,6 +66,10 @@ func (fakeStruct) RediscoverSystemInventory(ctx context.Context, in *aggregatorp
 	return nil, errors.New("fakeError")
 }
 
+func (fakeStruct) RefreshResourceInventory(ctx context.Context, in *aggregatorproto.RefreshResourceInventoryRequest, opts ...grpc.CallOption) (*aggregatorproto.RefreshResourceInventoryResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
 func (fakeStruct) UpdateSystemState(ctx context.Context, in *aggregatorproto.UpdateSystemStateRequest, opts ...grpc.CallOption) (*aggregatorproto.UpdateSystemStateResponse, error) {
 	return nil, errors.New("fakeError")
 }
@@ -75,6 +79,66 @@ func (fakeStruct) AddAggregationSource(ctx context.Context, in *aggregatorproto.
 	return nil, errors.New("fakeError")
 }
 
+func (fakeStruct) BulkAddAggregationSources(ctx context.Context, in *aggregatorproto.AggregatorRequest, opts ...grpc.CallOption) (*aggregatorproto.AggregatorResponse, error) {
+
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) DiscoverAggregationSources(ctx context.Context, in *aggregatorproto.AggregatorRequest, opts ...grpc.CallOption) (*aggregatorproto.AggregatorResponse, error) {
+
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) ApproveDiscoveredAggregationSources(ctx context.Context, in *aggregatorproto.AggregatorRequest, opts ...grpc.CallOption) (*aggregatorproto.AggregatorResponse, error) {
+
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) RotateAggregationSourceCredentials(ctx context.Context, in *aggregatorproto.AggregatorRequest, opts ...grpc.CallOption) (*aggregatorproto.AggregatorResponse, error) {
+
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) GetAllDiscoveredAggregationSources(ctx context.Context, in *aggregatorproto.AggregatorRequest, opts ...grpc.CallOption) (*aggregatorproto.AggregatorResponse, error) {
+
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) GetDiscoveredAggregationSource(ctx context.Context, in *aggregatorproto.AggregatorRequest, opts ...grpc.CallOption) (*aggregatorproto.AggregatorResponse, error) {
+
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) GetInventoryHistory(ctx context.Context, in *aggregatorproto.AggregatorRequest, opts ...grpc.CallOption) (*aggregatorproto.AggregatorResponse, error) {
+
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) GetInventorySync(ctx context.Context, in *aggregatorproto.AggregatorRequest, opts ...grpc.CallOption) (*aggregatorproto.AggregatorResponse, error) {
+
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) UpdateInventorySync(ctx context.Context, in *aggregatorproto.AggregatorRequest, opts ...grpc.CallOption) (*aggregatorproto.AggregatorResponse, error) {
+
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) ValidateInteropProfile(ctx context.Context, in *aggregatorproto.AggregatorRequest, opts ...grpc.CallOption) (*aggregatorproto.AggregatorResponse, error) {
+
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) GetConformanceReports(ctx context.Context, in *aggregatorproto.AggregatorRequest, opts ...grpc.CallOption) (*aggregatorproto.AggregatorResponse, error) {
+
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) GetConformanceReport(ctx context.Context, in *aggregatorproto.AggregatorRequest, opts ...grpc.CallOption) (*aggregatorproto.AggregatorResponse, error) {
+
+	return nil, errors.New("fakeError")
+}
+
 func (fakeStruct) GetAllAggregationSource(ctx context.Context, in *aggregatorproto.AggregatorRequest, opts ...grpc.CallOption) (*aggregatorproto.AggregatorResponse, error) {
 
 	return nil, errors.New("fakeError")
@@ -135,6 +199,16 @@ func (fakeStruct) SetDefaultBootOrderElementsOfAggregate(ctx context.Context, in
 	return nil, errors.New("fakeError")
 }
 
+func (fakeStruct) ApplySettingsElementsOfAggregate(ctx context.Context, in *aggregatorproto.AggregatorRequest, opts ...grpc.CallOption) (*aggregatorproto.AggregatorResponse, error) {
+
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) GetAggregateSettingsDrift(ctx context.Context, in *aggregatorproto.AggregatorRequest, opts ...grpc.CallOption) (*aggregatorproto.AggregatorResponse, error) {
+
+	return nil, errors.New("fakeError")
+}
+
 func (fakeStruct) GetAllConnectionMethods(ctx context.Context, in *aggregatorproto.AggregatorRequest, opts ...grpc.CallOption) (*aggregatorproto.AggregatorResponse, error) {
 
 	return nil, errors.New("fakeError")
@@ -233,6 +307,14 @@ func (fakeStruct) GetEventSubscriptionsCollection(ctx context.Context, in *event
 	return nil, errors.New("fakeError")
 }
 
+func (fakeStruct) ResumeEventSubscription(ctx context.Context, in *eventsproto.EventRequest, opts ...grpc.CallOption) (*eventsproto.EventSubResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) GetUndeliveredEventsCollection(ctx context.Context, in *eventsproto.EventRequest, opts ...grpc.CallOption) (*eventsproto.EventSubResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
 func (fakeStruct) SubsribeEMB(ctx context.Context, in *eventsproto.SubscribeEMBRequest, opts ...grpc.CallOption) (*eventsproto.SubscribeEMBResponse, error) {
 	return nil, errors.New("fakeError")
 }
@@ -386,6 +468,10 @@ func (fakeStruct2) DeleteVolume(ctx context.Context, in *systemsproto.VolumeRequ
 	return nil, errors.New("fakeError")
 }
 
+func (fakeStruct2) GetHealthSummary(ctx context.Context, in *systemsproto.GetSystemsRequest, opts ...grpc.CallOption) (*systemsproto.SystemsResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
 //-----------------------------------------TASK------------------------------------------
 
 func (fakeStruct) DeleteTask(ctx context.Context, in *taskproto.GetTaskRequest, opts ...grpc.CallOption) (*taskproto.TaskResponse, error) {
@@ -470,6 +556,26 @@ func (fakeStruct) UpdateTrigger(ctx context.Context, in *teleproto.TelemetryRequ
 	return nil, errors.New("fakeError")
 }
 
+func (fakeStruct) CreateMetricReportDefinition(ctx context.Context, in *teleproto.TelemetryRequest, opts ...grpc.CallOption) (*teleproto.TelemetryResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) DeleteMetricReportDefinition(ctx context.Context, in *teleproto.TelemetryRequest, opts ...grpc.CallOption) (*teleproto.TelemetryResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) CreateTrigger(ctx context.Context, in *teleproto.TelemetryRequest, opts ...grpc.CallOption) (*teleproto.TelemetryResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) DeleteTrigger(ctx context.Context, in *teleproto.TelemetryRequest, opts ...grpc.CallOption) (*teleproto.TelemetryResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) GetOpenMetrics(ctx context.Context, in *teleproto.TelemetryRequest, opts ...grpc.CallOption) (*teleproto.TelemetryResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
 //--------------------------------------------UPDATE----------------------------------------
 
 func (fakeStruct) GetUpdateService(ctx context.Context, in *updateproto.UpdateRequest, opts ...grpc.CallOption) (*updateproto.UpdateResponse, error) {
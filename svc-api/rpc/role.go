@@ -28,6 +28,24 @@ var (
 	NewRolesClientFunc = roleproto.NewRolesClient
 )
 
+// CreateRole defines the RPC call function for
+// the CreateRole from account-session micro service
+func CreateRole(ctx context.Context, req roleproto.RoleRequest) (*roleproto.RoleResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.AccountSession)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	asService := NewRolesClientFunc(conn)
+	resp, err := asService.CreateRole(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
 // GetRole defines the RPC call function for
 // the GetRole from account-session micro service
 func GetRole(ctx context.Context, req roleproto.GetRoleRequest) (*roleproto.RoleResponse, error) {
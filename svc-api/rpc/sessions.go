@@ -34,7 +34,8 @@ var (
 // DoSessionCreationRequest will do the rpc calls for the auth
 func DoSessionCreationRequest(ctx context.Context, req sessionproto.SessionCreateRequest) (*sessionproto.SessionCreateResponse, error) {
 	ctx = common.CreateMetadata(ctx)
-	if config.Data.SessionLimitCountPerUser > 0 {
+	// the user of a bearer token or a client certificate is known only after the account session service validates it
+	if config.Data.SessionLimitCountPerUser > 0 && req.BearerToken == "" && len(req.ClientCertificate) == 0 {
 		request := make(map[string]interface{})
 		err := json.Unmarshal(req.RequestBody, &request)
 		if err != nil {
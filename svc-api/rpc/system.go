@@ -180,3 +180,20 @@ func DeleteVolume(ctx context.Context, req systemsproto.VolumeRequest) (*systems
 	defer conn.Close()
 	return resp, nil
 }
+
+// GetHealthSummary will do the rpc call to get the fleet health summary
+func GetHealthSummary(ctx context.Context, req systemsproto.GetSystemsRequest) (*systemsproto.SystemsResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Systems)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	asService := NewSystemsClientFunc(conn)
+	resp, err := asService.GetHealthSummary(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, nil
+}
@@ -451,3 +451,39 @@ func TestDeleteVolume(t *testing.T) {
 		})
 	}
 }
+
+func TestGetHealthSummary(t *testing.T) {
+	tests := []struct {
+		name                 string
+		ClientFunc           func(clientName string) (*grpc.ClientConn, error)
+		NewSystemsClientFunc func(cc *grpc.ClientConn) systemsproto.SystemsClient
+		wantErr              bool
+	}{
+		{
+			name:                 "Client func error",
+			ClientFunc:           func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewSystemsClientFunc: func(cc *grpc.ClientConn) systemsproto.SystemsClient { return nil },
+			wantErr:              true,
+		},
+		{
+			name:                 "GetHealthSummary error",
+			ClientFunc:           func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewSystemsClientFunc: func(cc *grpc.ClientConn) systemsproto.SystemsClient { return fakeStruct2{} },
+			wantErr:              true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewSystemsClientFunc = tt.NewSystemsClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetHealthSummary(context.Background(), systemsproto.GetSystemsRequest{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetHealthSummary() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != nil {
+				t.Errorf("GetHealthSummary() = %v, want nil", got)
+			}
+		})
+	}
+}
@@ -215,3 +215,98 @@ func DoUpdateTrigger(ctx context.Context, req teleproto.TelemetryRequest) (*tele
 	defer conn.Close()
 	return resp, err
 }
+
+// DoCreateMetricReportDefinition defines the RPC call function for
+// the CreateMetricReportDefinition from telemetry micro service
+func DoCreateMetricReportDefinition(ctx context.Context, req teleproto.TelemetryRequest) (*teleproto.TelemetryResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Telemetry)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	telemetry := NewTelemetryClientFunc(conn)
+
+	resp, err := telemetry.CreateMetricReportDefinition(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
+// DoDeleteMetricReportDefinition defines the RPC call function for
+// the DeleteMetricReportDefinition from telemetry micro service
+func DoDeleteMetricReportDefinition(ctx context.Context, req teleproto.TelemetryRequest) (*teleproto.TelemetryResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Telemetry)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	telemetry := NewTelemetryClientFunc(conn)
+
+	resp, err := telemetry.DeleteMetricReportDefinition(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
+// DoCreateTrigger defines the RPC call function for
+// the CreateTrigger from telemetry micro service
+func DoCreateTrigger(ctx context.Context, req teleproto.TelemetryRequest) (*teleproto.TelemetryResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Telemetry)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	telemetry := NewTelemetryClientFunc(conn)
+
+	resp, err := telemetry.CreateTrigger(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
+// DoDeleteTrigger defines the RPC call function for
+// the DeleteTrigger from telemetry micro service
+func DoDeleteTrigger(ctx context.Context, req teleproto.TelemetryRequest) (*teleproto.TelemetryResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Telemetry)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	telemetry := NewTelemetryClientFunc(conn)
+
+	resp, err := telemetry.DeleteTrigger(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
+// DoGetOpenMetrics defines the RPC call function for
+// the GetOpenMetrics from telemetry micro service
+func DoGetOpenMetrics(ctx context.Context, req teleproto.TelemetryRequest) (*teleproto.TelemetryResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Telemetry)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	telemetry := NewTelemetryClientFunc(conn)
+
+	resp, err := telemetry.GetOpenMetrics(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
@@ -487,3 +487,228 @@ func TestDoUpdateTrigger(t *testing.T) {
 		})
 	}
 }
+
+func TestDoCreateMetricReportDefinition(t *testing.T) {
+	type args struct {
+		req teleproto.TelemetryRequest
+	}
+	tests := []struct {
+		name                   string
+		args                   args
+		ClientFunc             func(clientName string) (*grpc.ClientConn, error)
+		NewTelemetryClientFunc func(cc *grpc.ClientConn) teleproto.TelemetryClient
+		want                   *teleproto.TelemetryResponse
+		wantErr                bool
+	}{
+		{
+			name:                   "Client func error",
+			args:                   args{},
+			ClientFunc:             func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewTelemetryClientFunc: func(cc *grpc.ClientConn) teleproto.TelemetryClient { return nil },
+			want:                   nil,
+			wantErr:                true,
+		},
+		{
+			name:                   "DoCreateMetricReportDefinition error",
+			args:                   args{},
+			ClientFunc:             func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewTelemetryClientFunc: func(cc *grpc.ClientConn) teleproto.TelemetryClient { return fakeStruct{} },
+			want:                   nil,
+			wantErr:                true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewTelemetryClientFunc = tt.NewTelemetryClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DoCreateMetricReportDefinition(context.Background(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoCreateMetricReportDefinition() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DoCreateMetricReportDefinition() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoDeleteMetricReportDefinition(t *testing.T) {
+	type args struct {
+		req teleproto.TelemetryRequest
+	}
+	tests := []struct {
+		name                   string
+		args                   args
+		ClientFunc             func(clientName string) (*grpc.ClientConn, error)
+		NewTelemetryClientFunc func(cc *grpc.ClientConn) teleproto.TelemetryClient
+		want                   *teleproto.TelemetryResponse
+		wantErr                bool
+	}{
+		{
+			name:                   "Client func error",
+			args:                   args{},
+			ClientFunc:             func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewTelemetryClientFunc: func(cc *grpc.ClientConn) teleproto.TelemetryClient { return nil },
+			want:                   nil,
+			wantErr:                true,
+		},
+		{
+			name:                   "DoDeleteMetricReportDefinition error",
+			args:                   args{},
+			ClientFunc:             func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewTelemetryClientFunc: func(cc *grpc.ClientConn) teleproto.TelemetryClient { return fakeStruct{} },
+			want:                   nil,
+			wantErr:                true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewTelemetryClientFunc = tt.NewTelemetryClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DoDeleteMetricReportDefinition(context.Background(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoDeleteMetricReportDefinition() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DoDeleteMetricReportDefinition() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoCreateTrigger(t *testing.T) {
+	type args struct {
+		req teleproto.TelemetryRequest
+	}
+	tests := []struct {
+		name                   string
+		args                   args
+		ClientFunc             func(clientName string) (*grpc.ClientConn, error)
+		NewTelemetryClientFunc func(cc *grpc.ClientConn) teleproto.TelemetryClient
+		want                   *teleproto.TelemetryResponse
+		wantErr                bool
+	}{
+		{
+			name:                   "Client func error",
+			args:                   args{},
+			ClientFunc:             func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewTelemetryClientFunc: func(cc *grpc.ClientConn) teleproto.TelemetryClient { return nil },
+			want:                   nil,
+			wantErr:                true,
+		},
+		{
+			name:                   "DoCreateTrigger error",
+			args:                   args{},
+			ClientFunc:             func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewTelemetryClientFunc: func(cc *grpc.ClientConn) teleproto.TelemetryClient { return fakeStruct{} },
+			want:                   nil,
+			wantErr:                true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewTelemetryClientFunc = tt.NewTelemetryClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DoCreateTrigger(context.Background(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoCreateTrigger() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DoCreateTrigger() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoDeleteTrigger(t *testing.T) {
+	type args struct {
+		req teleproto.TelemetryRequest
+	}
+	tests := []struct {
+		name                   string
+		args                   args
+		ClientFunc             func(clientName string) (*grpc.ClientConn, error)
+		NewTelemetryClientFunc func(cc *grpc.ClientConn) teleproto.TelemetryClient
+		want                   *teleproto.TelemetryResponse
+		wantErr                bool
+	}{
+		{
+			name:                   "Client func error",
+			args:                   args{},
+			ClientFunc:             func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewTelemetryClientFunc: func(cc *grpc.ClientConn) teleproto.TelemetryClient { return nil },
+			want:                   nil,
+			wantErr:                true,
+		},
+		{
+			name:                   "DoDeleteTrigger error",
+			args:                   args{},
+			ClientFunc:             func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewTelemetryClientFunc: func(cc *grpc.ClientConn) teleproto.TelemetryClient { return fakeStruct{} },
+			want:                   nil,
+			wantErr:                true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewTelemetryClientFunc = tt.NewTelemetryClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DoDeleteTrigger(context.Background(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoDeleteTrigger() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DoDeleteTrigger() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoGetOpenMetrics(t *testing.T) {
+	type args struct {
+		req teleproto.TelemetryRequest
+	}
+	tests := []struct {
+		name                   string
+		args                   args
+		ClientFunc             func(clientName string) (*grpc.ClientConn, error)
+		NewTelemetryClientFunc func(cc *grpc.ClientConn) teleproto.TelemetryClient
+		want                   *teleproto.TelemetryResponse
+		wantErr                bool
+	}{
+		{
+			name:                   "Client func error",
+			args:                   args{},
+			ClientFunc:             func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewTelemetryClientFunc: func(cc *grpc.ClientConn) teleproto.TelemetryClient { return nil },
+			want:                   nil,
+			wantErr:                true,
+		},
+		{
+			name:                   "DoGetOpenMetrics error",
+			args:                   args{},
+			ClientFunc:             func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewTelemetryClientFunc: func(cc *grpc.ClientConn) teleproto.TelemetryClient { return fakeStruct{} },
+			want:                   nil,
+			wantErr:                true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewTelemetryClientFunc = tt.NewTelemetryClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DoGetOpenMetrics(context.Background(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoGetOpenMetrics() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DoGetOpenMetrics() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
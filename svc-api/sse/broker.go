@@ -0,0 +1,206 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package sse streams the events received from the event service to the
+// clients connected to the ServerSentEventUri
+package sse
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/query"
+)
+
+// clientQueueSize is the number of events which can be pending for a client,
+// a client which doesn't keep up is disconnected and has to resume the stream
+// with Last-Event-ID
+const clientQueueSize = 100
+
+// FilterProperties are the properties supported in $filter of the SSE stream
+var FilterProperties = map[string]bool{
+	"EventType":      true,
+	"MessageId":      true,
+	"OriginResource": true,
+	"RegistryPrefix": true,
+	"ResourceType":   true,
+}
+
+// Event is an event message to be written to the SSE stream
+type Event struct {
+	ID   string
+	Data []byte
+}
+
+// eventPayload is the Event resource sent on the SSE stream
+type eventPayload struct {
+	OdataType string         `json:"@odata.type"`
+	ID        string         `json:"Id"`
+	Name      string         `json:"Name"`
+	Context   string         `json:"@odata.context"`
+	Events    []common.Event `json:"Events"`
+}
+
+type record struct {
+	id      uint64
+	message common.MessageData
+}
+
+// Client is a connected SSE client
+type Client struct {
+	filter query.Expression
+	events chan Event
+}
+
+// Events returns the channel on which the events for the client are sent.
+// The channel is closed when the client is dropped for not keeping up with
+// the events.
+func (c *Client) Events() <-chan Event {
+	return c.events
+}
+
+// Broker retains the recent event messages and fans them out to the clients
+type Broker struct {
+	mu      sync.Mutex
+	size    int
+	records []record
+	clients map[*Client]bool
+}
+
+// NewBroker returns a broker retaining the last size event messages for
+// resuming the streams
+func NewBroker(size int) *Broker {
+	return &Broker{
+		size:    size,
+		clients: make(map[*Client]bool),
+	}
+}
+
+// Publish retains the message with the event ID and sends it to the clients
+// whose filter matches any of the events in it. The event IDs are the offsets
+// of the messages in the SSE event queue, they are the same on all the
+// replicas of the service and keep increasing across their restarts, so a
+// client resumes the stream with Last-Event-ID on any replica.
+func (b *Broker) Publish(id uint64, message common.MessageData) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	r := record{id: id, message: message}
+	b.records = append(b.records, r)
+	if len(b.records) > b.size {
+		b.records = b.records[len(b.records)-b.size:]
+	}
+	for client := range b.clients {
+		event, ok := r.eventFor(client.filter)
+		if !ok {
+			continue
+		}
+		select {
+		case client.events <- event:
+		default:
+			delete(b.clients, client)
+			close(client.events)
+		}
+	}
+}
+
+// Subscribe registers a client for the events matching the filter, filter
+// can be nil. When lastEventID is given, the retained events published after
+// it are returned for replaying before the events received on the client.
+func (b *Broker) Subscribe(filter query.Expression, lastEventID string) (*Client, []Event) {
+	client := &Client{
+		filter: filter,
+		events: make(chan Event, clientQueueSize),
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var replay []Event
+	if id, err := strconv.ParseUint(lastEventID, 10, 64); err == nil {
+		for _, r := range b.records {
+			if r.id <= id {
+				continue
+			}
+			if event, ok := r.eventFor(filter); ok {
+				replay = append(replay, event)
+			}
+		}
+	}
+	b.clients[client] = true
+	return client, replay
+}
+
+// Unsubscribe removes the client from the broker
+func (b *Broker) Unsubscribe(client *Client) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.clients[client] {
+		delete(b.clients, client)
+		close(client.events)
+	}
+}
+
+// eventFor returns the event to be sent to a client with the filter, holding
+// only the events of the message matched by the filter
+func (r record) eventFor(filter query.Expression) (Event, bool) {
+	payload := eventPayload{
+		OdataType: r.message.OdataType,
+		ID:        strconv.FormatUint(r.id, 10),
+		Name:      r.message.Name,
+		Context:   r.message.Context,
+	}
+	for _, event := range r.message.Events {
+		if filter == nil || filter.Evaluate(eventProperties(event)) {
+			payload.Events = append(payload.Events, event)
+		}
+	}
+	if len(payload.Events) == 0 {
+		return Event{}, false
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return Event{}, false
+	}
+	return Event{ID: payload.ID, Data: data}, true
+}
+
+// eventProperties returns the properties of the event on which $filter is
+// evaluated
+func eventProperties(event common.Event) map[string]interface{} {
+	properties := map[string]interface{}{
+		"EventType":      event.EventType,
+		"MessageId":      event.MessageID,
+		"RegistryPrefix": strings.Split(event.MessageID, ".")[0],
+	}
+	if event.OriginOfCondition == nil {
+		return properties
+	}
+	origin := strings.TrimSuffix(event.OriginOfCondition.Oid, "/")
+	properties["OriginResource"] = origin
+	// resource type is identified from the collection the resource belongs
+	// to, same as for the event subscriptions
+	segments := strings.Split(origin, "/")
+	if len(segments) > 2 {
+		collection := segments[len(segments)-2]
+		var resourceTypes []interface{}
+		for resourceType, name := range common.ResourceTypes {
+			if strings.Contains(collection, name) {
+				resourceTypes = append(resourceTypes, resourceType)
+			}
+		}
+		properties["ResourceType"] = resourceTypes
+	}
+	return properties
+}
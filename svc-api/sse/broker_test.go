@@ -0,0 +1,235 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package sse
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	dc "github.com/ODIM-Project/ODIM/lib-messagebus/datacommunicator"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/query"
+)
+
+func testMessage(events ...common.Event) common.MessageData {
+	return common.MessageData{
+		OdataType: common.EventType,
+		Name:      "Event",
+		Context:   "/redfish/v1/$metadata#Event.Event",
+		Events:    events,
+	}
+}
+
+func testEvent(eventType, messageID, origin string) common.Event {
+	return common.Event{
+		EventType:         eventType,
+		MessageID:         messageID,
+		OriginOfCondition: &common.Link{Oid: origin},
+	}
+}
+
+func decodeEvents(t *testing.T, event Event) eventPayload {
+	var payload eventPayload
+	if err := json.Unmarshal(event.Data, &payload); err != nil {
+		t.Fatal(err)
+	}
+	if payload.ID != event.ID {
+		t.Errorf("Id = %v, want the event ID %v", payload.ID, event.ID)
+	}
+	return payload
+}
+
+func parseFilter(t *testing.T, filter string) query.Expression {
+	expr, err := query.ParseFilter(filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return expr
+}
+
+func TestBrokerFilter(t *testing.T) {
+	powerOff := testEvent("Alert", "Alert.1.0.ServerPoweredOff", "/redfish/v1/Systems/uuid.1")
+	processor := testEvent("StatusChange", "ResourceEvent.1.2.0.ResourceChanged", "/redfish/v1/Systems/uuid.1/Processors/1/")
+	chassis := testEvent("ResourceAdded", "ResourceEvent.1.2.0.ResourceAdded", "/redfish/v1/Chassis/uuid.1")
+	tests := []struct {
+		filter string
+		want   []string
+	}{
+		{"EventType eq 'Alert'", []string{powerOff.MessageID}},
+		{"MessageId eq 'ResourceEvent.1.2.0.ResourceAdded'", []string{chassis.MessageID}},
+		{"RegistryPrefix eq 'ResourceEvent'", []string{processor.MessageID, chassis.MessageID}},
+		{"OriginResource eq '/redfish/v1/Systems/uuid.1/Processors/1'", []string{processor.MessageID}},
+		{"ResourceType eq 'ComputerSystem' or ResourceType eq 'Chassis'", []string{powerOff.MessageID, chassis.MessageID}},
+		{"ResourceType eq 'Processor' and not EventType eq 'Alert'", []string{processor.MessageID}},
+		{"EventType eq 'ResourceRemoved'", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			b := NewBroker(10)
+			client, _ := b.Subscribe(parseFilter(t, tt.filter), "")
+			b.Publish(1, testMessage(powerOff, processor, chassis))
+			b.Unsubscribe(client)
+			var got []string
+			for event := range client.Events() {
+				for _, e := range decodeEvents(t, event).Events {
+					got = append(got, e.MessageID)
+				}
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got events %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("got events %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestBrokerReplay(t *testing.T) {
+	b := NewBroker(3)
+	var ids []string
+	client, _ := b.Subscribe(nil, "")
+	for i := 0; i < 5; i++ {
+		eventType := "Alert"
+		if i%2 == 1 {
+			eventType = "StatusChange"
+		}
+		b.Publish(uint64(i+1), testMessage(testEvent(eventType, "Alert.1.0.Test", "/redfish/v1/Systems/uuid.1")))
+		ids = append(ids, (<-client.Events()).ID)
+	}
+	b.Unsubscribe(client)
+
+	tests := []struct {
+		name        string
+		filter      string
+		lastEventID string
+		want        []string
+	}{
+		{"no Last-Event-ID", "", "", nil},
+		{"invalid Last-Event-ID", "", "abc", nil},
+		{"latest event", "", ids[4], nil},
+		{"retained event", "", ids[2], ids[3:]},
+		{"event not retained anymore", "", ids[0], ids[2:]},
+		{"filtered replay", "EventType eq 'Alert'", ids[0], []string{ids[2], ids[4]}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var filter query.Expression
+			if tt.filter != "" {
+				filter = parseFilter(t, tt.filter)
+			}
+			client, replay := b.Subscribe(filter, tt.lastEventID)
+			defer b.Unsubscribe(client)
+			var got []string
+			for _, event := range replay {
+				got = append(got, event.ID)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("replayed %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("replayed %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestBrokerDropsSlowClient(t *testing.T) {
+	b := NewBroker(10)
+	client, _ := b.Subscribe(nil, "")
+	for i := 0; i <= clientQueueSize; i++ {
+		b.Publish(uint64(i+1), testMessage(testEvent("Alert", "Alert.1.0.Test", "/redfish/v1/Systems/uuid.1")))
+	}
+	received := 0
+	for range client.Events() {
+		received++
+	}
+	if received != clientQueueSize {
+		t.Errorf("received %d events, want %d before the client is dropped", received, clientQueueSize)
+	}
+	// unsubscribing a dropped client is a no-op
+	b.Unsubscribe(client)
+}
+
+func TestPublish(t *testing.T) {
+	b := NewBroker(10)
+	client, _ := b.Subscribe(nil, "")
+	defer b.Unsubscribe(client)
+	var data interface{}
+	raw, _ := json.Marshal(testMessage(testEvent("Alert", "Alert.1.0.Test", "/redfish/v1/Systems/uuid.1")))
+	json.Unmarshal(raw, &data)
+	publish(b, 7, data)
+	// messages without events are ignored
+	publish(b, 8, map[string]interface{}{"Name": "Event"})
+	publish(b, 9, data)
+	first, second := <-client.Events(), <-client.Events()
+	if first.ID != "7" || second.ID != "9" {
+		t.Errorf("event IDs %v, %v, want the offsets of the messages", first.ID, second.ID)
+	}
+	if events := decodeEvents(t, first).Events; len(events) != 1 || events[0].MessageID != "Alert.1.0.Test" {
+		t.Errorf("Events = %v", events)
+	}
+}
+
+func TestConsume(t *testing.T) {
+	config.SetUpMockConfig(t)
+	config.Data.MessageBusConf.MessageBusType = dc.INMEMORY
+	config.Data.MessageBusConf.OdimSSEEventQueue = "SSE-EVENTS-TEST"
+
+	// each replica of the service has its own broker
+	replicas := []*Broker{NewBroker(10), NewBroker(10)}
+	var clients []*Client
+	for _, b := range replicas {
+		go consume(b)
+		client, _ := b.Subscribe(nil, "")
+		defer b.Unsubscribe(client)
+		clients = append(clients, client)
+	}
+	time.Sleep(200 * time.Millisecond)
+
+	k, err := dc.Communicator(dc.INMEMORY, "", config.Data.MessageBusConf.OdimSSEEventQueue)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := k.Distribute(testMessage(testEvent("Alert", "Alert.1.0.Test", "/redfish/v1/Systems/uuid.1"))); err != nil {
+			t.Fatalf("error: %v", err)
+		}
+	}
+
+	var ids [][]string
+	for _, client := range clients {
+		var received []string
+		for i := 0; i < 3; i++ {
+			select {
+			case event := <-client.Events():
+				received = append(received, event.ID)
+			case <-time.After(5 * time.Second):
+				t.Fatalf("received %d of 3 events on a replica", i)
+			}
+		}
+		ids = append(ids, received)
+	}
+	if !reflect.DeepEqual(ids[0], ids[1]) {
+		t.Errorf("event IDs %v and %v, want the same IDs on the replicas", ids[0], ids[1])
+	}
+}
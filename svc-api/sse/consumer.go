@@ -0,0 +1,85 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package sse
+
+import (
+	"encoding/json"
+	"sync"
+
+	dc "github.com/ODIM-Project/ODIM/lib-messagebus/datacommunicator"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+)
+
+var (
+	broker     *Broker
+	brokerOnce sync.Once
+)
+
+// GetBroker returns the broker of the service, created on the first call
+// with the configured SSEEventBufferSize
+func GetBroker() *Broker {
+	brokerOnce.Do(func() {
+		broker = NewBroker(config.Data.EventConf.SSEEventBufferSize)
+	})
+	return broker
+}
+
+// Consume subscribes to the SSE event queue to which the event service
+// publishes the events, and hands them over to the broker of the service.
+// Every replica of the service receives all the events, so the clients get
+// the same stream whichever replica they are connected to.
+func Consume() {
+	consume(GetBroker())
+}
+
+// consume hands over the events of the SSE event queue to the broker
+func consume(b *Broker) {
+	config.TLSConfMutex.RLock()
+	messageBusType := config.Data.MessageBusConf.MessageBusType
+	messageBusConfigFilePath := config.Data.MessageBusConf.MessageBusConfigFilePath
+	topicName := config.Data.MessageBusConf.OdimSSEEventQueue
+	config.TLSConfMutex.RUnlock()
+	k, err := dc.Communicator(messageBusType, messageBusConfigFilePath, topicName)
+	if err != nil {
+		l.Log.Error("unable to connect to " + messageBusType + " for the SSE events: " + err.Error())
+		return
+	}
+	if err := k.Subscribe(func(offset uint64, data interface{}) {
+		publish(b, offset, data)
+	}); err != nil {
+		l.Log.Error("unable to subscribe to " + topicName + ": " + err.Error())
+	}
+}
+
+// publish decodes the event message read from the message bus and publishes
+// it to the broker, the offset of the message in the queue is its event ID
+func publish(b *Broker, offset uint64, data interface{}) {
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		l.Log.Error("error while reading the SSE event: " + err.Error())
+		return
+	}
+	var message common.MessageData
+	if err := json.Unmarshal(bytes, &message); err != nil {
+		l.Log.Error("error while reading the SSE event: " + err.Error())
+		return
+	}
+	if len(message.Events) == 0 {
+		return
+	}
+	b.Publish(offset, message)
+}
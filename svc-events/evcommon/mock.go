@@ -178,6 +178,16 @@ func MockCreateChildTask(ctx context.Context, sessionID, taskid string) (string,
 	return "123456", nil
 }
 
+// MockPublishSSEEvent is for mocking up of publishing the events for SSE clients
+func MockPublishSSEEvent(message common.MessageData) error {
+	return nil
+}
+
+// MockPublishHealthEvent is for mocking up of publishing the events for the health rollup
+func MockPublishHealthEvent(message common.MessageData) error {
+	return nil
+}
+
 // MockUpdateTask is for mocking up of update task
 func MockUpdateTask(context context.Context, task common.TaskData) error {
 	return nil
@@ -526,6 +536,25 @@ func MockGetEvtSubscriptions(searchKey string) ([]evmodel.Subscription, error) {
 				SubordinateResources: true,
 			},
 		}
+	case "61de0110-c35a-4859-984c-072d6c5a32e1", "https://localhost:1234/Destination":
+		subarr = []evmodel.Subscription{
+			{
+				UserName:             "admin",
+				SubscriptionID:       "61de0110-c35a-4859-984c-072d6c5a32e1",
+				Destination:          "https://localhost:1234/Destination",
+				Name:                 "Subscription",
+				Location:             "/ODIM/v1/Subscriptions/61",
+				Context:              "context",
+				EventTypes:           []string{"Alert"},
+				MessageIds:           []string{},
+				ResourceTypes:        []string{},
+				OriginResources:      []string{"/redfish/v1/Systems/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1"},
+				Hosts:                []string{"100.100.100.100"},
+				SubordinateResources: true,
+				DeliveryRetryPolicy:  evmodel.SuspendRetries,
+				State:                evmodel.SubscriptionSuspended,
+			},
+		}
 	default:
 		return subarr, fmt.Errorf("No data found for the key")
 	}
@@ -674,3 +703,14 @@ func MockDeleteUndeliveredEventsFlag(destination string) error {
 func MockDeleteUndeliveredEvents(destination string) error {
 	return nil
 }
+
+// MockGetSubscriptionUndeliveredEvents is for mocking up of getting undelivered events of a subscription
+func MockGetSubscriptionUndeliveredEvents(subscriptionID string) ([]evmodel.UndeliveredEvent, error) {
+	if subscriptionID == "61de0110-c35a-4859-984c-072d6c5a32e1" {
+		return []evmodel.UndeliveredEvent{
+			{ID: "event1", SubscriptionID: subscriptionID, Timestamp: "2022-01-01T00:00:00Z", Event: `{"Events":[{"EventType":"Alert"}]}`},
+			{ID: "event2", SubscriptionID: subscriptionID, Timestamp: "2022-01-01T00:00:01Z", Event: "event"},
+		}, nil
+	}
+	return []evmodel.UndeliveredEvent{}, nil
+}
@@ -45,11 +45,13 @@ var (
 
 // External struct to inject the contact external function into the handlers
 type External struct {
-	ContactClient   func(context.Context, string, string, string, string, interface{}, map[string]string) (*http.Response, error)
-	Auth            func(string, []string, []string) (response.RPC, error)
-	CreateTask      func(context.Context, string) (string, error)
-	UpdateTask      func(context.Context, common.TaskData) error
-	CreateChildTask func(context.Context, string, string) (string, error)
+	ContactClient      func(context.Context, string, string, string, string, interface{}, map[string]string) (*http.Response, error)
+	Auth               func(string, []string, []string) (response.RPC, error)
+	CreateTask         func(context.Context, string) (string, error)
+	UpdateTask         func(context.Context, common.TaskData) error
+	CreateChildTask    func(context.Context, string, string) (string, error)
+	PublishSSEEvent    func(common.MessageData) error
+	PublishHealthEvent func(common.MessageData) error
 }
 
 // DB struct to inject the contact DB function into the handlers
@@ -72,6 +74,7 @@ type DB struct {
 	SaveDeviceSubscription           func(evmodel.DeviceSubscription) error
 	GetUndeliveredEvents             func(string) (string, error)
 	DeleteUndeliveredEvents          func(string) error
+	GetSubscriptionUndeliveredEvents  func(string) ([]evmodel.UndeliveredEvent, error)
 	GetUndeliveredEventsFlag         func(string) (bool, error)
 	SetUndeliveredEventsFlag         func(string) error
 	DeleteUndeliveredEventsFlag      func(string) error
@@ -244,6 +247,16 @@ func validateFields(request *evmodel.RequestBody) (int32, string, []interface{},
 		}
 	}
 
+	if len(request.MetricReportDefinitions) > 0 && request.EventFormatType != evmodel.MetricReportFormatType {
+		return http.StatusBadRequest, errResponse.PropertyValueConflict, []interface{}{"MetricReportDefinitions", "EventFormatType"}, fmt.Errorf("MetricReportDefinitions is supported only for MetricReport EventFormatType")
+	}
+	for _, definition := range request.MetricReportDefinitions {
+		id := strings.TrimPrefix(strings.TrimSuffix(definition.OdataID, "/"), evmodel.MetricReportDefinitionsURI)
+		if id == "" || strings.Contains(id, "/") || !strings.HasPrefix(definition.OdataID, evmodel.MetricReportDefinitionsURI) {
+			return http.StatusBadRequest, errResponse.PropertyValueFormatError, []interface{}{definition.OdataID, "MetricReportDefinitions"}, fmt.Errorf("Invalid MetricReportDefinition %v", definition.OdataID)
+		}
+	}
+
 	if request.SubscriptionType == "" {
 		request.SubscriptionType = evmodel.SubscriptionType
 	} else if request.SubscriptionType == "SSE" || request.SubscriptionType == "SNMPTrap" || request.SubscriptionType == "SNMPInform" {
@@ -256,11 +269,12 @@ func validateFields(request *evmodel.RequestBody) (int32, string, []interface{},
 		request.Context = evmodel.Context
 	}
 
+	validDeliveryRetryPolicies := map[string]bool{evmodel.RetryForever: true, evmodel.SuspendRetries: true, evmodel.TerminateAfterRetries: true}
 	if request.DeliveryRetryPolicy == "" {
 		request.DeliveryRetryPolicy = evmodel.DeliveryRetryPolicy
-	} else if request.DeliveryRetryPolicy == "TerminateAfterRetries" || request.DeliveryRetryPolicy == "SuspendRetries" || request.DeliveryRetryPolicy == "RetryForeverWithBackoff" {
+	} else if request.DeliveryRetryPolicy == "RetryForeverWithBackoff" {
 		return http.StatusBadRequest, errResponse.PropertyValueNotInList, []interface{}{request.DeliveryRetryPolicy, "DeliveryRetryPolicy"}, fmt.Errorf("Unsupported DeliveryRetryPolicy")
-	} else if request.DeliveryRetryPolicy != evmodel.DeliveryRetryPolicy {
+	} else if !validDeliveryRetryPolicies[request.DeliveryRetryPolicy] {
 		return http.StatusBadRequest, errResponse.PropertyValueNotInList, []interface{}{request.DeliveryRetryPolicy, "DeliveryRetryPolicy"}, fmt.Errorf("Invalid DeliveryRetryPolicy")
 	}
 
@@ -436,6 +450,9 @@ func (e *ExternalInterfaces) checkCollection(origin string) ([]string, string, b
 		return collection, "FabricsCollection", true, "", false, err
 	case "/redfish/v1/TaskService/Tasks":
 		return []string{}, "TasksCollection", true, "", false, nil
+	case "/redfish/v1/AccountService/Accounts":
+		// the security alerts of the user accounts are raised by the account session service
+		return []string{}, "AccountsCollection", true, "", false, nil
 	}
 	if strings.Contains(origin, "/AggregationService/Aggregates/") {
 		aggregateCollection, err := e.GetAggregateData(origin)
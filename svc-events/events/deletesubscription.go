@@ -230,6 +230,7 @@ func (e *ExternalInterfaces) DeleteEventSubscriptionsDetails(req *eventsproto.Ev
 			evcommon.GenErrorResponse(errorMessage, response.ResourceNotFound, http.StatusBadRequest, msgArgs, &resp)
 			return resp
 		}
+		e.removeUndeliveredEvents(evtSubscription.SubscriptionID)
 	}
 
 	commonResponse := response.Response{
@@ -342,6 +343,7 @@ func isCollectionOriginResourceURI(origin string) bool {
 		"/redfish/v1/Fabrics",
 		"/redfish/v1/Managers",
 		"/redfish/v1/TaskService/Tasks",
+		"/redfish/v1/AccountService/Accounts",
 	}
 
 	front := 0
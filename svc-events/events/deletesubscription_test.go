@@ -230,6 +230,11 @@ func TestIsCollectionOriginResourceURI(t *testing.T) {
 			arg:  "/redfish/v1/Fabrics/",
 			want: true,
 		},
+		{
+			name: "Positive: Accounts collection",
+			arg:  "/redfish/v1/AccountService/Accounts/",
+			want: true,
+		},
 		{
 			name: "Negative: Empty string",
 			arg:  "",
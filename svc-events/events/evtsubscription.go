@@ -158,6 +158,7 @@ func (e *ExternalInterfaces) CreateEventSubscription(taskID string, sessionUserN
 			"/redfish/v1/Fabrics",
 			"/redfish/v1/Managers",
 			"/redfish/v1/TaskService/Tasks",
+			"/redfish/v1/AccountService/Accounts",
 		}
 		originResourcesCount = len(originResources)
 	}
@@ -281,6 +282,10 @@ func (e *ExternalInterfaces) CreateEventSubscription(taskID string, sessionUserN
 			OriginResources:      successfulSubscriptionList,
 			Hosts:                hosts,
 			DeliveryRetryPolicy:  postRequest.DeliveryRetryPolicy,
+			State:                evmodel.SubscriptionEnabled,
+		}
+		for _, definition := range postRequest.MetricReportDefinitions {
+			evtSubscription.MetricReportDefinitions = append(evtSubscription.MetricReportDefinitions, strings.TrimSuffix(definition.OdataID, "/"))
 		}
 
 		if err = e.SaveEventSubscription(evtSubscription); err != nil {
@@ -79,11 +79,11 @@ func TestCreateEventSubscriptionForAgregate(t *testing.T) {
 			{OdataID: "/redfish/v1/AggregationService/Aggregates/11081de0-4859-984c-c35a-6c50732d72da"},
 			{OdataID: "/redfish/v1/AggregationService/Aggregates/11081de0-4859-984c-c35a-6c50732d72da2"},
 		},
-		"DeliveryRetryPolicy": "TerminateAfterRetries",
+		"DeliveryRetryPolicy": "RetryForeverWithBackoff",
 	}
 	postBody, _ = json.Marshal(&SubscriptionReq)
 
-	// Invalid Delivery type
+	// Unsupported Delivery type
 	req = &eventsproto.EventSubRequest{
 		SessionToken: "token",
 		PostBody:     postBody,
@@ -339,6 +339,37 @@ func TestNegativeCasesCreateEventSubscription(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, int(resp.StatusCode), "Status Code should be StatusBadRequest")
 	SubscriptionReq["EventFormatType"] = "Event"
 
+	// if MetricReportDefinitions is given for Event EventFormatType
+	SubscriptionReq["MetricReportDefinitions"] = []evmodel.OdataIDLink{
+		{OdataID: "/redfish/v1/TelemetryService/MetricReportDefinitions/CPUUtil"},
+	}
+	postBody, _ = json.Marshal(&SubscriptionReq)
+
+	req = &eventsproto.EventSubRequest{
+		SessionToken: "token",
+		PostBody:     postBody,
+	}
+	resp = p.CreateEventSubscription(taskID, sessionUserName, req)
+	assert.Equal(t, http.StatusBadRequest, int(resp.StatusCode), "Status Code should be StatusBadRequest")
+
+	// if MetricReportDefinitions is invalid
+	SubscriptionReq["EventFormatType"] = "MetricReport"
+	SubscriptionReq["EventTypes"] = []string{"MetricReport"}
+	SubscriptionReq["MetricReportDefinitions"] = []evmodel.OdataIDLink{
+		{OdataID: "/redfish/v1/TelemetryService/MetricReports/CPUUtil"},
+	}
+	postBody, _ = json.Marshal(&SubscriptionReq)
+
+	req = &eventsproto.EventSubRequest{
+		SessionToken: "token",
+		PostBody:     postBody,
+	}
+	resp = p.CreateEventSubscription(taskID, sessionUserName, req)
+	assert.Equal(t, http.StatusBadRequest, int(resp.StatusCode), "Status Code should be StatusBadRequest")
+	delete(SubscriptionReq, "MetricReportDefinitions")
+	SubscriptionReq["EventFormatType"] = "Event"
+	SubscriptionReq["EventTypes"] = []string{"Alert"}
+
 	// if SubscriptionType is Unsupported
 	SubscriptionReq["SubscriptionType"] = "SSE"
 	postBody, _ = json.Marshal(&SubscriptionReq)
@@ -82,16 +82,26 @@ func (e *ExternalInterfaces) GetEventSubscriptionsDetails(req *eventsproto.Event
 		}
 
 		subscriptions = &evresponse.SubscriptionResponse{
-			Response:            commonResponse,
-			Destination:         evtSubscription.Destination,
-			Protocol:            evtSubscription.Protocol,
-			Context:             evtSubscription.Context,
-			EventTypes:          evtSubscription.EventTypes,
-			SubscriptionType:    evtSubscription.SubscriptionType,
-			MessageIds:          evtSubscription.MessageIds,
-			ResourceTypes:       evtSubscription.ResourceTypes,
-			OriginResources:     updateOriginResourceswithOdataID(evtSubscription.OriginResources),
-			DeliveryRetryPolicy: evtSubscription.DeliveryRetryPolicy,
+			Response:                commonResponse,
+			Destination:             evtSubscription.Destination,
+			Protocol:                evtSubscription.Protocol,
+			Context:                 evtSubscription.Context,
+			EventTypes:              evtSubscription.EventTypes,
+			SubscriptionType:        evtSubscription.SubscriptionType,
+			MessageIds:              evtSubscription.MessageIds,
+			ResourceTypes:           evtSubscription.ResourceTypes,
+			OriginResources:         updateOriginResourceswithOdataID(evtSubscription.OriginResources),
+			DeliveryRetryPolicy:     evtSubscription.DeliveryRetryPolicy,
+			EventFormatType:         evtSubscription.EventFormatType,
+			MetricReportDefinitions: updateOriginResourceswithOdataID(evtSubscription.MetricReportDefinitions),
+			Status: &evresponse.SubscriptionStatus{
+				State: subscriptionState(evtSubscription),
+			},
+			Actions: &evresponse.SubscriptionActions{
+				ResumeSubscription: evresponse.ActionTarget{
+					Target: commonResponse.OdataID + "/Actions/EventDestination.ResumeSubscription",
+				},
+			},
 		}
 	}
 	resp.Body = subscriptions
@@ -100,6 +110,18 @@ func (e *ExternalInterfaces) GetEventSubscriptionsDetails(req *eventsproto.Event
 	return resp
 }
 
+// subscriptionState returns the Redfish state of the subscription, a suspended
+// subscription is reported as StandbyOffline
+func subscriptionState(subscription evmodel.Subscription) string {
+	switch subscription.State {
+	case evmodel.SubscriptionSuspended:
+		return "StandbyOffline"
+	case evmodel.SubscriptionDisabled:
+		return evmodel.SubscriptionDisabled
+	}
+	return evmodel.SubscriptionEnabled
+}
+
 // GetEventSubscriptionsCollection collects all subscription details
 func (e *ExternalInterfaces) GetEventSubscriptionsCollection(req *eventsproto.EventRequest) response.RPC {
 	var resp response.RPC
@@ -38,10 +38,12 @@ import (
 func getMockMethods() ExternalInterfaces {
 	return ExternalInterfaces{
 		External: External{
-			ContactClient:   evcommon.MockContactClient,
-			Auth:            evcommon.MockIsAuthorized,
-			CreateChildTask: evcommon.MockCreateChildTask,
-			UpdateTask:      evcommon.MockUpdateTask,
+			ContactClient:      evcommon.MockContactClient,
+			Auth:               evcommon.MockIsAuthorized,
+			CreateChildTask:    evcommon.MockCreateChildTask,
+			UpdateTask:         evcommon.MockUpdateTask,
+			PublishSSEEvent:    evcommon.MockPublishSSEEvent,
+			PublishHealthEvent: evcommon.MockPublishHealthEvent,
 		},
 		DB: DB{
 			GetSessionUserName:               evcommon.MockGetSessionUserName,
@@ -65,6 +67,7 @@ func getMockMethods() ExternalInterfaces {
 			SetUndeliveredEventsFlag:         evcommon.MockSetUndeliveredEventsFlag,
 			DeleteUndeliveredEventsFlag:      evcommon.MockDeleteUndeliveredEventsFlag,
 			DeleteUndeliveredEvents:          evcommon.MockDeleteUndeliveredEvents,
+			GetSubscriptionUndeliveredEvents:  evcommon.MockGetSubscriptionUndeliveredEvents,
 			GetAggregateData:                 evcommon.MockGetAggregateDatacData,
 			SaveAggregateSubscription:        evcommon.MockSaveAggregateSubscription,
 			GetAggregateHosts:                evcommon.MockGetAggregateHosts,
@@ -25,10 +25,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
 	"net"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
@@ -47,6 +47,18 @@ var (
 	SendEventFunc = sendEvent
 	//ServiceDiscoveryFunc func pointer for calling the files
 	ServiceDiscoveryFunc = services.ODIMService.Client
+	// maxDeliveryRetryInterval is the longest interval of the retries with backoff
+	// of the subscriptions with RetryForever DeliveryRetryPolicy
+	maxDeliveryRetryInterval = 10 * time.Minute
+	// refreshDelay is how long the refresh of a resource waits for the other events changing it,
+	// the events received meanwhile are served by the same refresh
+	refreshDelay = 10 * time.Second
+	// refreshInventoryFunc refreshes the resource of the device in the inventory
+	refreshInventoryFunc = refreshResourceInventory
+
+	// pendingRefreshes are the resources of the devices waiting for their refresh
+	pendingRefreshes     = make(map[string]bool)
+	pendingRefreshesLock sync.Mutex
 )
 
 // addFabric will add the new fabric resource to db when an event is ResourceAdded and
@@ -100,7 +112,7 @@ func (e *ExternalInterfaces) PublishEventsToDestination(data interface{}) bool {
 	}
 
 	if event.EventType == "MetricReport" {
-		return e.publishMetricReport(requestData)
+		return e.publishMetricReport(requestData, host)
 	}
 
 	var flag bool
@@ -113,6 +125,11 @@ func (e *ExternalInterfaces) PublishEventsToDestination(data interface{}) bool {
 	}
 
 	e.addFabric(rawMessage, host)
+	// the events of the aggregator for the systems and chassis added or removed are handed over
+	// for the health rollup before looking for the subscriptions, as there might be none for them
+	if event.Internal {
+		go e.publishHealthEvent(rawMessage)
+	}
 	searchKey := evcommon.GetSearchKey(host, evmodel.DeviceSubscriptionIndex)
 
 	deviceSubscription, err := e.GetDeviceSubscriptions(searchKey)
@@ -126,6 +143,11 @@ func (e *ExternalInterfaces) PublishEventsToDestination(data interface{}) bool {
 		return false
 	}
 	message, deviceUUID = formatEvent(rawMessage, deviceSubscription.OriginResources[0], host)
+	// the events of the devices are handed over for the health rollup whether they
+	// are subscribed or not
+	if !event.Internal {
+		go e.publishHealthEvent(message)
+	}
 	searchKey = evcommon.GetSearchKey(host, evmodel.SubscriptionIndex)
 	subscriptions, err := e.GetEvtSubscriptions(searchKey)
 	if err != nil {
@@ -146,6 +168,9 @@ func (e *ExternalInterfaces) PublishEventsToDestination(data interface{}) bool {
 	}
 	eventUniqueID := uuid.NewV4().String()
 	eventMap := make(map[string][]common.Event)
+	// the subscriptions of the destination, as they can be in different states
+	subscriptionMap := make(map[string][]evmodel.Subscription)
+	var sseEvents []common.Event
 	for index, inEvent := range message.Events {
 		if inEvent.OriginOfCondition == nil || len(inEvent.OriginOfCondition.Oid) < 1 {
 			l.Log.Info("event not forwarded as Originofcondition is empty in incoming event: ", requestData)
@@ -169,12 +194,22 @@ func (e *ExternalInterfaces) PublishEventsToDestination(data interface{}) bool {
 			l.Log.Info("event not forwarded as resource type of originofcondition not supported in incoming event: ", requestData)
 			continue
 		}
+		sseEvents = append(sseEvents, inEvent)
 		collectionSubscriptions := e.getCollectionSubscriptionInfoForOID(inEvent.OriginOfCondition.Oid, host)
 		subscriptions = append(subscriptions, collectionSubscriptions...)
+		// the event is added once to a destination having more than one subscription
+		forwarded := make(map[string]bool)
+		addEvent := func(sub evmodel.Subscription) {
+			if !forwarded[sub.Destination] {
+				forwarded[sub.Destination] = true
+				eventMap[sub.Destination] = append(eventMap[sub.Destination], inEvent)
+			}
+			subscriptionMap[sub.Destination] = appendSubscription(subscriptionMap[sub.Destination], sub)
+			flag = true
+		}
 		for _, sub := range aggregateSubscriptionList {
 			if filterEventsToBeForwarded(sub, inEvent, deviceSubscription.OriginResources) {
-				eventMap[sub.Destination] = append(eventMap[sub.Destination], inEvent)
-				flag = true
+				addEvent(sub)
 			}
 		}
 		for _, sub := range subscriptions {
@@ -185,8 +220,7 @@ func (e *ExternalInterfaces) PublishEventsToDestination(data interface{}) bool {
 				// check if hostip present in the hosts slice to make sure that it doesn't filter with the destination ip
 				if isHostPresentInEventForward(sub.Hosts, host) {
 					if filterEventsToBeForwarded(sub, inEvent, deviceSubscription.OriginResources) {
-						eventMap[sub.Destination] = append(eventMap[sub.Destination], inEvent)
-						flag = true
+						addEvent(sub)
 					}
 				} else {
 					l.Log.Info("event not forwarded : No subscription for the incoming event's originofcondition")
@@ -196,24 +230,26 @@ func (e *ExternalInterfaces) PublishEventsToDestination(data interface{}) bool {
 			}
 		}
 		if strings.EqualFold("Alert", inEvent.EventType) {
-			if strings.Contains(inEvent.MessageID, "ServerPostDiscoveryComplete") || strings.Contains(inEvent.MessageID, "ServerPostComplete") {
-				go rediscoverSystemInventory(deviceUUID, inEvent.OriginOfCondition.Oid)
-				flag = true
-			}
 			if strings.Contains(inEvent.MessageID, "ServerPoweredOn") || strings.Contains(inEvent.MessageID, "ServerPoweredOff") {
 				go updateSystemPowerState(deviceUUID, rawMessage.Events[index].OriginOfCondition.Oid, inEvent.MessageID)
 				flag = true
 			}
-		} else if strings.EqualFold("ResourceAdded", message.Events[0].EventType) || strings.EqualFold("ResourceRemoved", message.Events[0].EventType) {
-			if strings.Contains(message.Events[0].OriginOfCondition.Oid, "Volumes") {
-				s := strings.Split(message.Events[0].OriginOfCondition.Oid, "/")
-				storageURI := fmt.Sprintf("/%s/%s/%s/%s/%s/", s[1], s[2], s[3], s[4], s[5])
-				go rediscoverSystemInventory(deviceUUID, storageURI)
+		}
+		// events published by the aggregator for the changes in the inventory are not refreshed again
+		if deviceUUID != "" && !event.Internal {
+			if resourceURI := getRefreshResourceURI(inEvent); resourceURI != "" {
+				scheduleRefresh(deviceUUID, resourceURI)
 				flag = true
 			}
 		}
 	}
 
+	if len(sseEvents) > 0 {
+		sseMessage := message
+		sseMessage.Events = sseEvents
+		go e.publishSSEEvent(sseMessage)
+	}
+
 	for key, value := range eventMap {
 		message.Events = value
 		data, err := json.Marshal(message)
@@ -221,21 +257,103 @@ func (e *ExternalInterfaces) PublishEventsToDestination(data interface{}) bool {
 			l.Log.Error("unable to converts event into bytes: ", err.Error())
 			continue
 		}
-		go e.postEvent(key, eventUniqueID, data)
+		go e.postEventToSubscribers(key, subscriptionMap[key], eventUniqueID, data)
 	}
 	return flag
 }
 
-func (e *ExternalInterfaces) publishMetricReport(requestData string) bool {
-	eventUniqueID := uuid.NewV4().String()
-	subscriptions, err := e.GetEvtSubscriptions("MetricReport")
+// appendSubscription adds the subscription to the subscriptions unless it is already one of them
+func appendSubscription(subscriptions []evmodel.Subscription, sub evmodel.Subscription) []evmodel.Subscription {
+	for _, subscription := range subscriptions {
+		if subscription.SubscriptionID == sub.SubscriptionID {
+			return subscriptions
+		}
+	}
+	return append(subscriptions, sub)
+}
+
+// publishSSEEvent hands over the events to the API service for streaming
+// them to the SSE clients, which apply their own filters
+func (e *ExternalInterfaces) publishSSEEvent(message common.MessageData) {
+	if e.PublishSSEEvent == nil {
+		return
+	}
+	if err := e.PublishSSEEvent(message); err != nil {
+		l.Log.Error("failed to publish the event for SSE clients: ", err.Error())
+	}
+}
+
+// publishHealthEvent hands over the events to the systems service, which
+// keeps the health rollup of the systems and chassis up to date with them
+func (e *ExternalInterfaces) publishHealthEvent(message common.MessageData) {
+	if e.PublishHealthEvent == nil {
+		return
+	}
+	if err := e.PublishHealthEvent(message); err != nil {
+		l.Log.Error("failed to publish the event for the health rollup: ", err.Error())
+	}
+}
+
+// metricReport has the properties of the metric report used to find the
+// subscriptions to which the report is delivered
+type metricReport struct {
+	ID                     string       `json:"Id"`
+	MetricReportDefinition *common.Link `json:"MetricReportDefinition"`
+}
+
+// definition returns the URI of the metric report definition of the report,
+// reports without the link are generated by the definition with the same Id
+func (r metricReport) definition() string {
+	if r.MetricReportDefinition != nil && r.MetricReportDefinition.Oid != "" {
+		return strings.TrimSuffix(r.MetricReportDefinition.Oid, "/")
+	}
+	if r.ID == "" {
+		return ""
+	}
+	return evmodel.MetricReportDefinitionsURI + r.ID
+}
+
+// publishMetricReport delivers the metric report received from the host to
+// the subscriptions with MetricReport EventFormatType. The report is delivered
+// to a subscription only when the subscription has no MetricReportDefinitions
+// or the definition of the report is one of them.
+func (e *ExternalInterfaces) publishMetricReport(requestData, host string) bool {
+	// plugins forward the metric report as a JSON string
+	reportData := requestData
+	var data string
+	if err := json.Unmarshal([]byte(requestData), &data); err == nil {
+		reportData = data
+	}
+	var report metricReport
+	if err := json.Unmarshal([]byte(reportData), &report); err != nil {
+		l.Log.Error("failed to unmarshal the incoming metric report: ", reportData, " with the error: ", err.Error())
+		return false
+	}
+	definition := report.definition()
+
+	subscriptions, err := e.GetEvtSubscriptions(evmodel.MetricReportFormatType)
 	if err != nil {
+		l.Log.Info("no subscriptions found for the metric report: ", err.Error())
 		return false
 	}
+	eventUniqueID := uuid.NewV4().String()
+	var flag bool
 	for _, sub := range subscriptions {
-		go e.postEvent(sub.Destination, eventUniqueID, []byte(requestData))
+		// pattern search matches the subscriptions having MetricReport in
+		// any of the properties
+		if sub.EventFormatType != evmodel.MetricReportFormatType || sub.Destination == "" {
+			continue
+		}
+		if !isHostPresentInEventForward(sub.Hosts, host) {
+			continue
+		}
+		if len(sub.MetricReportDefinitions) > 0 && (definition == "" || !isStringPresentInSlice(sub.MetricReportDefinitions, definition, "metric report definition")) {
+			continue
+		}
+		go e.postEventToSubscribers(sub.Destination, []evmodel.Subscription{sub}, eventUniqueID, []byte(reportData))
+		flag = true
 	}
-	return true
+	return flag
 }
 
 func filterEventsToBeForwarded(subscription evmodel.Subscription, event common.Event, originResources []string) bool {
@@ -338,25 +456,71 @@ func isStringPresentInSlice(slice []string, str, message string) bool {
 	return false
 }
 
-// postEvent will post the event to destination
-func (e *ExternalInterfaces) postEvent(destination, eventUniqueID string, event []byte) {
+// postEventToSubscribers posts the event once to the destination shared by the
+// subscriptions, as per their states. The event is saved for each suspended
+// subscription for delivering it once the subscription is resumed, and it is
+// dropped for the disabled subscriptions.
+func (e *ExternalInterfaces) postEventToSubscribers(destination string, subscriptions []evmodel.Subscription, eventUniqueID string, event []byte) {
+	var enabled []evmodel.Subscription
+	for _, sub := range subscriptions {
+		switch sub.State {
+		case evmodel.SubscriptionSuspended:
+			l.Log.Info("event is saved as the subscription " + sub.SubscriptionID + " is suspended")
+			e.saveUndeliveredEvent(sub.SubscriptionID, eventUniqueID, event)
+		case evmodel.SubscriptionDisabled:
+			l.Log.Info("event not forwarded as the subscription " + sub.SubscriptionID + " is disabled")
+		default:
+			enabled = append(enabled, sub)
+		}
+	}
+	if len(enabled) > 0 {
+		e.postEvent(destination, enabled, eventUniqueID, event)
+	}
+}
+
+// postEvent will post the event to destination, the event is saved for each of the
+// enabled subscriptions of the destination when it couldn't be delivered
+func (e *ExternalInterfaces) postEvent(destination string, subscriptions []evmodel.Subscription, eventUniqueID string, event []byte) {
 	resp, err := SendEventFunc(destination, event)
 	if err == nil {
 		resp.Body.Close()
 		l.Log.Info("Event is successfully forwarded")
-		// check any undelivered events are present in db for the destination and publish those
-		go e.checkUndeliveredEvents(destination)
+		// check any undelivered events are present in db for the subscriptions and publish those
+		for _, sub := range subscriptions {
+			go e.checkUndeliveredEvents(sub)
+		}
 		return
 	}
-	undeliveredEventID := destination + ":" + eventUniqueID
-	serr := e.SaveUndeliveredEvents(undeliveredEventID, event)
-	if serr != nil {
-		l.Log.Error("error while saving undelivered event: ", serr.Error())
+	for _, sub := range subscriptions {
+		e.saveUndeliveredEvent(sub.SubscriptionID, eventUniqueID, event)
 	}
-	go e.reAttemptEvents(destination, undeliveredEventID, event)
+	go e.reAttemptEvents(destination, subscriptions, eventUniqueID, event)
 
 }
 
+// saveUndeliveredEvent saves the event which couldn't be delivered to the
+// destination of the subscription and returns its key. The oldest events of
+// the subscription are removed when there are more than UndeliveredEventsLimit events.
+func (e *ExternalInterfaces) saveUndeliveredEvent(subscriptionID, eventUniqueID string, event []byte) string {
+	undeliveredEventID := subscriptionID + ":" + eventUniqueID
+	if err := e.SaveUndeliveredEvents(undeliveredEventID, event); err != nil {
+		l.Log.Error("error while saving undelivered event: ", err.Error())
+		return undeliveredEventID
+	}
+	undeliveredEvents, err := e.GetSubscriptionUndeliveredEvents(subscriptionID)
+	if err != nil {
+		l.Log.Error("error while getting undelivered events: ", err.Error())
+		return undeliveredEventID
+	}
+	for i := 0; i < len(undeliveredEvents)-config.Data.EventConf.UndeliveredEventsLimit; i++ {
+		l.Log.Warn("undelivered events limit reached for subscription " + subscriptionID + ", removing the oldest event " + undeliveredEvents[i].ID)
+		if err := e.DeleteUndeliveredEvents(subscriptionID + ":" + undeliveredEvents[i].ID); err != nil {
+			l.Log.Error("error while deleting undelivered events: ", err.Error())
+		}
+	}
+	return undeliveredEventID
+}
+
 func sendEvent(destination string, event []byte) (*http.Response, error) {
 	httpConf := &config.HTTPConfig{
 		CACertificate: &config.Data.KeyCertConf.RootCACertificate,
@@ -378,45 +542,168 @@ func sendEvent(destination string, event []byte) (*http.Response, error) {
 	return httpClient.Do(req)
 }
 
-func (e *ExternalInterfaces) reAttemptEvents(destination, undeliveredEventID string, event []byte) {
-	var resp *http.Response
-	var err error
-	count := config.Data.EventConf.DeliveryRetryAttempts
-	for i := 0; i < count; i++ {
+// reAttemptEvents retries posting the undelivered event to the destination of
+// the subscriptions. Once DeliveryRetryAttempts are exhausted the subscriptions
+// are updated as per their DeliveryRetryPolicy, and the retries go on with
+// backoff for the subscriptions with RetryForever. The retries stop when the
+// event is delivered, or when none of the subscriptions are enabled and
+// waiting for the event any more.
+func (e *ExternalInterfaces) reAttemptEvents(destination string, subscriptions []evmodel.Subscription, eventUniqueID string, event []byte) {
+	interval := time.Second * time.Duration(config.Data.EventConf.DeliveryRetryIntervalSeconds)
+	var exhausted bool
+	for attempt := 1; ; attempt++ {
 		l.Log.Info("Retry event forwarding on destination: ", destination)
-		time.Sleep(time.Second * time.Duration(config.Data.EventConf.DeliveryRetryIntervalSeconds))
+		time.Sleep(interval)
 		// if undelivered event already published then ignore retrying
-		eventString, err := e.GetUndeliveredEvents(undeliveredEventID)
-		if err != nil || len(eventString) < 1 {
+		if subscriptions = e.pendingSubscriptions(subscriptions, eventUniqueID); len(subscriptions) == 0 {
 			l.Log.Info("Event is forwarded to destination")
 			return
 		}
-		resp, err = SendEventFunc(destination, event)
+		resp, err := SendEventFunc(destination, event)
 		if err == nil {
 			resp.Body.Close()
 			l.Log.Info("Event is successfully forwarded")
-			// if event is delivered then delete the same which is saved in 1st attempt
-			err = e.DeleteUndeliveredEvents(undeliveredEventID)
-			if err != nil {
-				l.Log.Error("error while deleting undelivered events: ", err.Error())
+			for _, sub := range subscriptions {
+				// if event is delivered then delete the same which is saved in 1st attempt
+				if err := e.DeleteUndeliveredEvents(sub.SubscriptionID + ":" + eventUniqueID); err != nil {
+					l.Log.Error("error while deleting undelivered events: ", err.Error())
+				}
+				// check any undelivered events are present in db for the subscription and publish those
+				go e.checkUndeliveredEvents(sub)
 			}
-			// check any undelivered events are present in db for the destination and publish those
-			go e.checkUndeliveredEvents(destination)
 			return
 		}
+		l.Log.Error("error while make https call to send the event: ", err.Error())
+		if attempt < config.Data.EventConf.DeliveryRetryAttempts {
+			continue
+		}
+		if !exhausted {
+			exhausted = true
+			subscriptions = e.applyDeliveryRetryPolicy(destination, subscriptions)
+		}
+		if interval *= 2; interval > maxDeliveryRetryInterval {
+			interval = maxDeliveryRetryInterval
+		}
+	}
+}
 
+// pendingSubscriptions returns the subscriptions which are still enabled and
+// waiting for the undelivered event
+func (e *ExternalInterfaces) pendingSubscriptions(subscriptions []evmodel.Subscription, eventUniqueID string) []evmodel.Subscription {
+	var pending []evmodel.Subscription
+	for _, sub := range subscriptions {
+		eventString, err := e.GetUndeliveredEvents(sub.SubscriptionID + ":" + eventUniqueID)
+		if err != nil || len(eventString) < 1 {
+			continue
+		}
+		current, err := e.currentSubscription(sub.SubscriptionID)
+		if err != nil {
+			l.Log.Error("error while getting subscription " + sub.SubscriptionID + ": " + err.Error())
+			continue
+		}
+		if current != nil && current.IsEnabled() {
+			pending = append(pending, *current)
+		}
 	}
+	return pending
+}
+
+// currentSubscription returns the subscription with the ID as it is saved,
+// or nil if it is deleted
+func (e *ExternalInterfaces) currentSubscription(subscriptionID string) (*evmodel.Subscription, error) {
+	subscriptions, err := e.GetEvtSubscriptions(subscriptionID)
 	if err != nil {
-		l.Log.Error("error while make https call to send the event: ", err.Error())
+		return nil, err
+	}
+	// pattern search matches the subscriptions having the ID in any of the properties
+	for _, sub := range subscriptions {
+		if sub.SubscriptionID == subscriptionID {
+			return &sub, nil
+		}
+	}
+	return nil, nil
+}
+
+// applyDeliveryRetryPolicy suspends or disables the subscriptions of the
+// destination as per their DeliveryRetryPolicy once the delivery retries of an
+// event are exhausted, and returns the subscriptions with RetryForever which
+// are left enabled for retrying the delivery with backoff.
+func (e *ExternalInterfaces) applyDeliveryRetryPolicy(destination string, subscriptions []evmodel.Subscription) []evmodel.Subscription {
+	var retryForever []evmodel.Subscription
+	for _, sub := range subscriptions {
+		switch sub.DeliveryRetryPolicy {
+		case evmodel.SuspendRetries:
+			sub.State = evmodel.SubscriptionSuspended
+		case evmodel.TerminateAfterRetries:
+			sub.State = evmodel.SubscriptionDisabled
+		default:
+			retryForever = append(retryForever, sub)
+			continue
+		}
+		if err := e.UpdateEventSubscription(sub); err != nil {
+			l.Log.Error("error while updating state of subscription " + sub.SubscriptionID + ": " + err.Error())
+			continue
+		}
+		l.Log.Warn("event delivery retries exhausted for " + destination + ", subscription " + sub.SubscriptionID + " is " + sub.State)
+	}
+	return retryForever
+}
+
+// getRefreshResourceURI returns the URI of the resource to be refreshed in the inventory for the event,
+// which is the OriginOfCondition for a server restart or a change of a resource and the collection
+// of the resource for an addition or a removal of a resource. It is empty when the event doesn't
+// change the inventory.
+func getRefreshResourceURI(event common.Event) string {
+	origin := strings.TrimSuffix(event.OriginOfCondition.Oid, "/")
+	var resourceURI string
+	switch {
+	case strings.EqualFold("Alert", event.EventType) &&
+		(strings.Contains(event.MessageID, "ServerPostDiscoveryComplete") || strings.Contains(event.MessageID, "ServerPostComplete")):
+		resourceURI = origin
+	case strings.EqualFold("ResourceAdded", event.EventType) || strings.EqualFold("ResourceRemoved", event.EventType):
+		s := strings.Split(origin, "/")
+		if strings.Contains(origin, "Volumes") && len(s) > 6 {
+			// the capacity of the storage changes with the volumes
+			resourceURI = strings.Join(s[:7], "/")
+		} else {
+			resourceURI = origin[:strings.LastIndex(origin, "/")+1]
+		}
+	case strings.EqualFold("ResourceUpdated", event.EventType) || strings.Contains(event.MessageID, "ResourceChanged"):
+		resourceURI = origin
+	}
+	resourceURI = strings.TrimSuffix(resourceURI, "/")
+	s := strings.Split(resourceURI, "/")
+	if len(s) < 5 || s[1] != "redfish" || s[2] != "v1" {
+		return ""
 	}
+	switch s[3] {
+	case "Systems", "Chassis", "Managers":
+		return resourceURI
+	}
+	return ""
 }
 
-// rediscoverSystemInventory will be triggered when ever the System Restart or Power On
-// event is detected it will create a rpc for aggregation which will delete all system inventory //
-// and rediscover all of them
-func rediscoverSystemInventory(systemID, systemURL string) {
-	systemURL = strings.TrimSuffix(systemURL, "/")
+// scheduleRefresh refreshes the resource of the device after refreshDelay, unless its refresh is
+// already pending. The bursts of events sent by the BMCs for a change result in a single refresh.
+func scheduleRefresh(deviceUUID, resourceURI string) {
+	key := deviceUUID + ":" + resourceURI
+	pendingRefreshesLock.Lock()
+	defer pendingRefreshesLock.Unlock()
+	if pendingRefreshes[key] {
+		return
+	}
+	pendingRefreshes[key] = true
+	time.AfterFunc(refreshDelay, func() {
+		pendingRefreshesLock.Lock()
+		delete(pendingRefreshes, key)
+		pendingRefreshesLock.Unlock()
+		refreshInventoryFunc(deviceUUID, resourceURI)
+	})
+}
 
+// refreshResourceInventory will be triggered when ever an event changing the inventory is detected,
+// it will create a rpc for aggregation which will refresh the resource and its subordinate resources
+func refreshResourceInventory(deviceUUID, resourceURI string) {
 	conn, err := ServiceDiscoveryFunc(services.Aggregator)
 	if err != nil {
 		l.Log.Error("failed to get client connection object for aggregator service")
@@ -425,16 +712,15 @@ func rediscoverSystemInventory(systemID, systemURL string) {
 	defer conn.Close()
 	aggregator := aggregatorproto.NewAggregatorClient(conn)
 
-	_, err = aggregator.RediscoverSystemInventory(context.TODO(), &aggregatorproto.RediscoverSystemInventoryRequest{
-		SystemID:  systemID,
-		SystemURL: systemURL,
+	_, err = aggregator.RefreshResourceInventory(context.TODO(), &aggregatorproto.RefreshResourceInventoryRequest{
+		DeviceUUID:  deviceUUID,
+		ResourceURI: resourceURI,
 	})
 	if err != nil {
-		l.Log.Info("Error while rediscoverSystemInventory")
+		l.Log.Error("error while refreshing the inventory of " + resourceURI + ": " + err.Error())
 		return
 	}
-	l.Log.Info("rediscovery of system and chassis started.")
-
+	l.Log.Info("refresh of " + resourceURI + " started.")
 }
 
 func (e *ExternalInterfaces) addFabricRPCCall(origin, address string) {
@@ -548,48 +834,51 @@ func callPluginStartUp(event common.Events) {
 	l.Log.Info("successfully sent plugin startup data to " + event.IP)
 }
 
-func (e *ExternalInterfaces) checkUndeliveredEvents(destination string) {
+// checkUndeliveredEvents posts the undelivered events of the enabled subscription,
+// unless another instance is already posting them
+func (e *ExternalInterfaces) checkUndeliveredEvents(sub evmodel.Subscription) {
 	// first check any of the instance have already picked up for publishing
-	// undelivered events for the destination
-	flag, _ := e.GetUndeliveredEventsFlag(destination)
+	// undelivered events for the subscription
+	flag, _ := e.GetUndeliveredEventsFlag(sub.SubscriptionID)
 	if !flag {
 		// if flag is false then set the flag true, so other instance shouldnt have to read the undelivered events and publish
-		err := e.SetUndeliveredEventsFlag(destination)
+		err := e.SetUndeliveredEventsFlag(sub.SubscriptionID)
 		if err != nil {
 			l.Log.Error("error while setting undelivered events flag: ", err.Error())
 		}
-		destData, _ := e.GetAllMatchingDetails(evmodel.UndeliveredEvents, destination, common.OnDisk)
-		for _, dest := range destData {
-			event, err := e.GetUndeliveredEvents(dest)
-			if err != nil {
-				l.Log.Error("error while getting undelivered events: ", err.Error())
-				continue
-			}
-			event = strings.Replace(event, "\\", "", -1)
-			event = strings.TrimPrefix(event, "\"")
-			event = strings.TrimSuffix(event, "\"")
-			resp, err := SendEventFunc(destination, []byte(event))
-			if resp != nil {
-				defer resp.Body.Close()
-			}
-			if err != nil {
-				l.Log.Error("error while make https call to send the event: ", err.Error())
-				continue
-			}
-			l.Log.Info("Event is successfully forwarded")
-			err = e.DeleteUndeliveredEvents(dest)
-			if err != nil {
-				l.Log.Error("error while deleting undelivered events: ", err.Error())
-			}
-		}
+		e.postUndeliveredEvents(sub)
 		// handle logic if inter connection fails
-		derr := e.DeleteUndeliveredEventsFlag(destination)
+		derr := e.DeleteUndeliveredEventsFlag(sub.SubscriptionID)
 		if derr != nil {
 			l.Log.Error("error while deleting undelivered events flag: ", derr.Error())
 		}
 	}
 }
 
+// postUndeliveredEvents posts the undelivered events of the subscription to its
+// destination in the order in which they were saved. Posting is stopped at the
+// first failure to retain the order for the next attempt.
+func (e *ExternalInterfaces) postUndeliveredEvents(sub evmodel.Subscription) {
+	undeliveredEvents, err := e.GetSubscriptionUndeliveredEvents(sub.SubscriptionID)
+	if err != nil {
+		l.Log.Error("error while getting undelivered events: ", err.Error())
+		return
+	}
+	for _, event := range undeliveredEvents {
+		resp, err := SendEventFunc(sub.Destination, []byte(event.Event))
+		if err != nil {
+			l.Log.Error("error while make https call to send the event: ", err.Error())
+			return
+		}
+		resp.Body.Close()
+		l.Log.Info("Event is successfully forwarded")
+		err = e.DeleteUndeliveredEvents(sub.SubscriptionID + ":" + event.ID)
+		if err != nil {
+			l.Log.Error("error while deleting undelivered events: ", err.Error())
+		}
+	}
+}
+
 func (e *ExternalInterfaces) getCollectionSubscriptionInfoForOID(oid, host string) []evmodel.Subscription {
 	var key string
 	if strings.Contains(oid, "Systems") && host != "SystemsCollection" {
@@ -25,7 +25,9 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"sort"
 	"testing"
+	"time"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
@@ -83,6 +85,112 @@ func TestPublishEventsToDestiantion(t *testing.T) {
 	}
 }
 
+func TestPublishEventsToDestiantionForSSE(t *testing.T) {
+	config.SetUpMockConfig(t)
+	message := common.MessageData{
+		OdataType: "#Event",
+		Events: []common.Event{
+			{
+				EventType: "Alert",
+				EventID:   "123",
+				MessageID: "IndicatorChanged",
+				OriginOfCondition: &common.Link{
+					Oid: "/redfish/v1/Systems/1",
+				},
+			},
+			{
+				EventType: "Alert",
+				EventID:   "124",
+				MessageID: "IndicatorChanged",
+			},
+		},
+	}
+	data, _ := json.Marshal(message)
+	published := make(chan common.MessageData, 1)
+	pc := getMockMethods()
+	pc.PublishSSEEvent = func(message common.MessageData) error {
+		published <- message
+		return nil
+	}
+	pc.PublishEventsToDestination(common.Events{IP: "100.100.100.100", Request: data})
+	select {
+	case sseMessage := <-published:
+		// events without OriginOfCondition are not published
+		assert.Equal(t, 1, len(sseMessage.Events))
+		assert.Equal(t, "123", sseMessage.Events[0].EventID)
+	case <-time.After(5 * time.Second):
+		t.Error("event is not published for the SSE clients")
+	}
+}
+
+func TestPublishEventsToDestiantionForHealth(t *testing.T) {
+	config.SetUpMockConfig(t)
+	message := common.MessageData{
+		OdataType: "#Event",
+		Events: []common.Event{
+			{
+				EventType: "ResourceEvent",
+				EventID:   "123",
+				MessageID: "ResourceEvent.1.0.3.ResourceStatusChangedCritical",
+				OriginOfCondition: &common.Link{
+					Oid: "/redfish/v1/Systems/1",
+				},
+			},
+		},
+	}
+	data, _ := json.Marshal(message)
+	published := make(chan common.MessageData, 1)
+	pc := getMockMethods()
+	pc.PublishHealthEvent = func(message common.MessageData) error {
+		published <- message
+		return nil
+	}
+	pc.PublishEventsToDestination(common.Events{IP: "100.100.100.100", Request: data})
+	select {
+	case healthMessage := <-published:
+		assert.Equal(t, 1, len(healthMessage.Events))
+		assert.Equal(t, "123", healthMessage.Events[0].EventID)
+	case <-time.After(5 * time.Second):
+		t.Error("event is not published for the health rollup")
+	}
+}
+
+func TestPublishEventsToDestiantionForHealthWithoutSubscription(t *testing.T) {
+	config.SetUpMockConfig(t)
+	message := common.MessageData{
+		OdataType: "#Event",
+		Events: []common.Event{
+			{
+				EventType: "ResourceAdded",
+				EventID:   "123",
+				MessageID: "ResourceEvent.1.0.3.ResourceAdded",
+				OriginOfCondition: &common.Link{
+					Oid: "/redfish/v1/Systems/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1",
+				},
+			},
+		},
+	}
+	data, _ := json.Marshal(message)
+	published := make(chan common.MessageData, 1)
+	pc := getMockMethods()
+	pc.PublishHealthEvent = func(message common.MessageData) error {
+		published <- message
+		return nil
+	}
+	// the event of the aggregator is published even when no one subscribed to the collection
+	pc.DB.GetDeviceSubscriptions = func(hostIP string) (*evmodel.DeviceSubscription, error) {
+		return nil, &errors.Error{}
+	}
+	pc.PublishEventsToDestination(common.Events{IP: "SystemsCollection", Request: data, Internal: true})
+	select {
+	case healthMessage := <-published:
+		assert.Equal(t, 1, len(healthMessage.Events))
+		assert.Equal(t, "/redfish/v1/Systems/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1", healthMessage.Events[0].OriginOfCondition.Oid)
+	case <-time.After(5 * time.Second):
+		t.Error("event of the aggregator is not published for the health rollup")
+	}
+}
+
 func TestPublishEventsWithEmptyOriginOfCondition(t *testing.T) {
 	common.SetUpMockConfig()
 	message := common.MessageData{
@@ -192,7 +300,8 @@ func TestExternalInterfaces_getCollectionSubscriptionInfoForOID(t *testing.T) {
 
 func TestExternalInterfaces_checkUndeliveredEvents(t *testing.T) {
 	pc := getMockMethods()
-	pc.checkUndeliveredEvents("dummy")
+	sub := evmodel.Subscription{SubscriptionID: "dummy", Destination: "dummy"}
+	pc.checkUndeliveredEvents(sub)
 	pc.DB.GetUndeliveredEventsFlag = func(s string) (bool, error) { return false, nil }
 	pc.DB.GetAllMatchingDetails = func(s1, s2 string, dt common.DbType) ([]string, *errors.Error) {
 		return []string{"/dummydestination"}, nil
@@ -200,13 +309,13 @@ func TestExternalInterfaces_checkUndeliveredEvents(t *testing.T) {
 	SendEventFunc = func(destination string, event []byte) (*http.Response, error) {
 		return &http.Response{Body: ioutil.NopCloser(bytes.NewBufferString("Dummy"))}, &errors.Error{}
 	}
-	pc.checkUndeliveredEvents("dummy")
+	pc.checkUndeliveredEvents(sub)
 	SendEventFunc = func(destination string, event []byte) (*http.Response, error) {
 		return &http.Response{Body: ioutil.NopCloser(bytes.NewBufferString("Dummy"))}, nil
 	}
-	pc.checkUndeliveredEvents("dummy")
+	pc.checkUndeliveredEvents(sub)
 	pc.DB.GetUndeliveredEvents = func(s string) (string, error) { return "", &errors.Error{} }
-	pc.checkUndeliveredEvents("dummy")
+	pc.checkUndeliveredEvents(sub)
 
 	SendEventFunc = func(destination string, event []byte) (*http.Response, error) {
 		return sendEvent(destination, event)
@@ -216,7 +325,7 @@ func TestExternalInterfaces_checkUndeliveredEvents(t *testing.T) {
 
 func Test_callPluginStartUp(t *testing.T) {
 	pc := getMockMethods()
-	pc.publishMetricReport("")
+	pc.publishMetricReport("", "")
 	pc.DB.GetEvtSubscriptions = func(s string) ([]evmodel.Subscription, error) {
 		return []evmodel.Subscription{{
 			UserName:       "admin",
@@ -224,7 +333,7 @@ func Test_callPluginStartUp(t *testing.T) {
 			Destination:    "dummy",
 		}}, nil
 	}
-	pc.publishMetricReport("")
+	pc.publishMetricReport("", "")
 	JSONUnmarshal = func(data []byte, v interface{}) error {
 		return &errors.Error{}
 	}
@@ -245,7 +354,7 @@ func Test_callPluginStartUp(t *testing.T) {
 	pc.removeFabricRPCCall("Fabric", "test")
 	pc.addFabricRPCCall("Zones", "test")
 	pc.addFabricRPCCall("Fabric", "test")
-	rediscoverSystemInventory("3bd1f589-117a-4cf9-89f2-da44ee8e012b.1", "/redfish/v1/UpdateService/FirmwareInentory/valid.1")
+	refreshResourceInventory("3bd1f589-117a-4cf9-89f2-da44ee8e012b", "/redfish/v1/Systems/3bd1f589-117a-4cf9-89f2-da44ee8e012b.1")
 
 	callPluginStartUp(common.Events{})
 }
@@ -253,14 +362,52 @@ func Test_callPluginStartUp(t *testing.T) {
 func TestExternalInterfaces_reAttemptEvents(t *testing.T) {
 	config.SetUpMockConfig(t)
 	pc := getMockMethods()
-	pc.reAttemptEvents("test", "dummy", []byte{})
+	subs := []evmodel.Subscription{{SubscriptionID: "81de0110-c35a-4859-984c-072d6c5a32d7", Destination: "test"}}
+	pc.reAttemptEvents("test", subs, "dummy", []byte{})
 
 	pc.DB.GetUndeliveredEvents = func(s string) (string, error) { return "test", nil }
 	SendEventFunc = func(destination string, event []byte) (*http.Response, error) {
 		return &http.Response{Body: ioutil.NopCloser(bytes.NewBufferString("Dummy"))}, nil
 	}
 	pc.DB.DeleteUndeliveredEvents = func(s string) error { return &errors.Error{} }
-	pc.reAttemptEvents("test", "dummy", []byte{})
+	pc.reAttemptEvents("test", subs, "dummy", []byte{})
+}
+
+func TestExternalInterfaces_reAttemptEventsRetryForever(t *testing.T) {
+	config.SetUpMockConfig(t)
+	pc := getMockMethods()
+	subscriptions := map[string]evmodel.Subscription{
+		"1": {SubscriptionID: "1", Destination: "https://localhost:1234/Destination", DeliveryRetryPolicy: evmodel.RetryForever},
+		"2": {SubscriptionID: "2", Destination: "https://localhost:1234/Destination", DeliveryRetryPolicy: evmodel.SuspendRetries},
+	}
+	pc.DB.GetEvtSubscriptions = func(s string) ([]evmodel.Subscription, error) {
+		return []evmodel.Subscription{subscriptions[s]}, nil
+	}
+	pc.DB.UpdateEventSubscription = func(s evmodel.Subscription) error {
+		subscriptions[s.SubscriptionID] = s
+		return nil
+	}
+	pc.DB.GetUndeliveredEvents = func(s string) (string, error) { return "event", nil }
+	var deleted []string
+	pc.DB.DeleteUndeliveredEvents = func(key string) error {
+		deleted = append(deleted, key)
+		return nil
+	}
+	// the delivery succeeds only after the retry attempts are exhausted
+	var attempts int
+	SendEventFunc = func(destination string, event []byte) (*http.Response, error) {
+		if attempts++; attempts <= config.Data.EventConf.DeliveryRetryAttempts {
+			return nil, &errors.Error{}
+		}
+		return &http.Response{Body: ioutil.NopCloser(bytes.NewBufferString("Dummy"))}, nil
+	}
+	defer func() { SendEventFunc = sendEvent }()
+
+	pc.reAttemptEvents("https://localhost:1234/Destination", []evmodel.Subscription{subscriptions["1"], subscriptions["2"]}, "event", []byte{})
+	assert.Equal(t, config.Data.EventConf.DeliveryRetryAttempts+1, attempts, "delivery should be retried till it succeeds")
+	assert.Equal(t, evmodel.SubscriptionSuspended, subscriptions["2"].State, "subscription with SuspendRetries should be suspended")
+	assert.True(t, subscriptions["1"].IsEnabled(), "subscription with RetryForever should be enabled")
+	assert.Equal(t, []string{"1:event"}, deleted, "delivered event should be deleted for the subscription with RetryForever only")
 }
 
 func TestExternalInterfaces_postEvent(t *testing.T) {
@@ -272,13 +419,13 @@ func TestExternalInterfaces_postEvent(t *testing.T) {
 		return &http.Response{Body: ioutil.NopCloser(bytes.NewBufferString("Dummy"))}, nil
 	}
 
-	pc.postEvent("dumy", "dummy", []byte{})
+	pc.postEvent("dumy", []evmodel.Subscription{{SubscriptionID: "dummy", Destination: "dumy"}}, "dummy", []byte{})
 	pc.DB.SaveUndeliveredEvents = func(s string, b []byte) error { return &errors.Error{} }
 	SendEventFunc = func(destination string, event []byte) (*http.Response, error) {
 		return &http.Response{Body: ioutil.NopCloser(bytes.NewBufferString("Dummy"))}, &errors.Error{}
 	}
 
-	pc.postEvent("dumy", "dummy", []byte{})
+	pc.postEvent("dumy", []evmodel.Subscription{{SubscriptionID: "dummy", Destination: "dumy"}}, "dummy", []byte{})
 
 	isStringPresentInSlice([]string{"data1"}, "", "data2")
 	isStringPresentInSlice([]string{"data1"}, "data1", "data2")
@@ -304,3 +451,264 @@ func Test_isResourceTypeSubscribed(t *testing.T) {
 	assert.Equal(t, true, status, "There shoud be no error ")
 
 }
+
+func TestExternalInterfaces_postEventToSubscribers(t *testing.T) {
+	config.SetUpMockConfig(t)
+	pc := getMockMethods()
+	var saved []string
+	pc.DB.SaveUndeliveredEvents = func(key string, event []byte) error {
+		saved = append(saved, key)
+		return nil
+	}
+	pc.DB.GetUndeliveredEventsFlag = func(s string) (bool, error) { return false, nil }
+	replayed := make(chan string, 5)
+	pc.DB.SetUndeliveredEventsFlag = func(subscriptionID string) error {
+		replayed <- subscriptionID
+		return nil
+	}
+	var sent int
+	SendEventFunc = func(destination string, event []byte) (*http.Response, error) {
+		sent++
+		return &http.Response{Body: ioutil.NopCloser(bytes.NewBufferString("Dummy"))}, nil
+	}
+	defer func() { SendEventFunc = sendEvent }()
+
+	destination := "https://localhost:1234/Destination"
+	subs := []evmodel.Subscription{
+		{SubscriptionID: "1", Destination: destination},
+		{SubscriptionID: "2", Destination: destination, State: evmodel.SubscriptionSuspended},
+		{SubscriptionID: "3", Destination: destination, State: evmodel.SubscriptionDisabled},
+		{SubscriptionID: "4", Destination: destination},
+	}
+	pc.postEventToSubscribers(destination, subs, "event", []byte{})
+	assert.Equal(t, 1, sent, "event should be posted once to the destination of the enabled subscriptions")
+	assert.Equal(t, []string{"2:event"}, saved, "event should be saved for the suspended subscription only")
+
+	// the undelivered events of the suspended subscription are retained
+	var replayedIDs []string
+	for i := 0; i < 2; i++ {
+		select {
+		case id := <-replayed:
+			replayedIDs = append(replayedIDs, id)
+		case <-time.After(5 * time.Second):
+			t.Fatal("undelivered events of the enabled subscriptions are not posted")
+		}
+	}
+	sort.Strings(replayedIDs)
+	assert.Equal(t, []string{"1", "4"}, replayedIDs, "undelivered events of the enabled subscriptions only should be posted")
+
+	saved = nil
+	pc.postEventToSubscribers(destination, subs[1:3], "event", []byte{})
+	assert.Equal(t, 1, sent, "event shouldn't be posted when no subscription is enabled")
+	assert.Equal(t, []string{"2:event"}, saved, "event should be saved for suspended subscription")
+}
+
+func TestPublishEventsToDestiantionWithSharedDestination(t *testing.T) {
+	config.SetUpMockConfig(t)
+	message := common.MessageData{
+		OdataType: "#Event",
+		Events: []common.Event{
+			{
+				EventType: "Alert",
+				EventID:   "123",
+				MessageID: "IndicatorChanged",
+				OriginOfCondition: &common.Link{
+					Oid: "/redfish/v1/Systems/1",
+				},
+			},
+		},
+	}
+	data, _ := json.Marshal(message)
+	pc := getMockMethods()
+	// the subscriptions of the destination are in different states
+	pc.DB.GetEvtSubscriptions = func(s string) ([]evmodel.Subscription, error) {
+		return []evmodel.Subscription{
+			{SubscriptionID: "1", Destination: "https://localhost:1234/Destination", Hosts: []string{"100.100.100.100"}, State: evmodel.SubscriptionSuspended},
+			{SubscriptionID: "2", Destination: "https://localhost:1234/Destination", Hosts: []string{"100.100.100.100"}},
+		}, nil
+	}
+	saved := make(chan string, 5)
+	pc.DB.SaveUndeliveredEvents = func(key string, event []byte) error {
+		saved <- key
+		return nil
+	}
+	sent := make(chan string, 5)
+	SendEventFunc = func(destination string, event []byte) (*http.Response, error) {
+		// only the incoming event posted to the shared destination is counted
+		if destination == "https://localhost:1234/Destination" && bytes.Contains(event, []byte(`"EventId":"123"`)) {
+			sent <- destination
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(bytes.NewBufferString("Dummy"))}, nil
+	}
+	defer func() { SendEventFunc = sendEvent }()
+
+	assert.True(t, pc.PublishEventsToDestination(common.Events{IP: "100.100.100.100", Request: data}))
+	for _, ch := range []chan string{sent, saved} {
+		select {
+		case <-ch:
+		case <-time.After(5 * time.Second):
+			t.Fatal("event is not handled as per the state of each subscription of the destination")
+		}
+	}
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, 0, len(sent), "event should be posted once for the enabled subscription")
+	assert.Equal(t, 0, len(saved), "event should be saved once for the suspended subscription")
+}
+
+func TestExternalInterfaces_saveUndeliveredEvent(t *testing.T) {
+	config.SetUpMockConfig(t)
+	pc := getMockMethods()
+	var deleted []string
+	pc.DB.DeleteUndeliveredEvents = func(key string) error {
+		deleted = append(deleted, key)
+		return nil
+	}
+	key := pc.saveUndeliveredEvent("61de0110-c35a-4859-984c-072d6c5a32e1", "event3", []byte{})
+	assert.Equal(t, "61de0110-c35a-4859-984c-072d6c5a32e1:event3", key)
+	assert.Equal(t, 0, len(deleted), "events within the limit shouldn't be deleted")
+
+	config.Data.EventConf.UndeliveredEventsLimit = 1
+	pc.saveUndeliveredEvent("61de0110-c35a-4859-984c-072d6c5a32e1", "event3", []byte{})
+	assert.Equal(t, []string{"61de0110-c35a-4859-984c-072d6c5a32e1:event1"}, deleted, "oldest event should be deleted")
+}
+
+func TestExternalInterfaces_applyDeliveryRetryPolicy(t *testing.T) {
+	config.SetUpMockConfig(t)
+	pc := getMockMethods()
+	subscriptions := []evmodel.Subscription{
+		{SubscriptionID: "1", Destination: "https://localhost:1234/Destination", DeliveryRetryPolicy: evmodel.RetryForever},
+		{SubscriptionID: "2", Destination: "https://localhost:1234/Destination", DeliveryRetryPolicy: evmodel.SuspendRetries},
+		{SubscriptionID: "3", Destination: "https://localhost:1234/Destination", DeliveryRetryPolicy: evmodel.TerminateAfterRetries},
+	}
+	updated := map[string]string{}
+	pc.DB.UpdateEventSubscription = func(s evmodel.Subscription) error {
+		updated[s.SubscriptionID] = s.State
+		return nil
+	}
+	retryForever := pc.applyDeliveryRetryPolicy("https://localhost:1234/Destination", subscriptions)
+	assert.Equal(t, map[string]string{"2": evmodel.SubscriptionSuspended, "3": evmodel.SubscriptionDisabled}, updated)
+	if assert.Equal(t, 1, len(retryForever), "subscription with RetryForever should be retried") {
+		assert.Equal(t, "1", retryForever[0].SubscriptionID)
+	}
+}
+
+func TestExternalInterfaces_publishMetricReport(t *testing.T) {
+	config.SetUpMockConfig(t)
+	pc := getMockMethods()
+	pc.DB.GetEvtSubscriptions = func(s string) ([]evmodel.Subscription, error) {
+		return []evmodel.Subscription{
+			{SubscriptionID: "1", Destination: "https://localhost:1234/all", EventFormatType: evmodel.MetricReportFormatType},
+			{SubscriptionID: "2", Destination: "https://localhost:1234/cpu", EventFormatType: evmodel.MetricReportFormatType,
+				MetricReportDefinitions: []string{"/redfish/v1/TelemetryService/MetricReportDefinitions/CPUUtil"}},
+			{SubscriptionID: "3", Destination: "https://localhost:1234/power", EventFormatType: evmodel.MetricReportFormatType,
+				MetricReportDefinitions: []string{"/redfish/v1/TelemetryService/MetricReportDefinitions/PowerMetrics"}},
+			{SubscriptionID: "4", Destination: "https://localhost:1234/event", EventFormatType: "Event", EventTypes: []string{"MetricReport"}},
+			{SubscriptionID: "5", Destination: "https://localhost:1234/otherhost", EventFormatType: evmodel.MetricReportFormatType, Hosts: []string{"10.10.10.10"}},
+		}, nil
+	}
+	destinations := make(chan string, 5)
+	SendEventFunc = func(destination string, event []byte) (*http.Response, error) {
+		var report map[string]interface{}
+		assert.Nil(t, json.Unmarshal(event, &report), "metric report should be posted as JSON object")
+		destinations <- destination
+		return &http.Response{Body: ioutil.NopCloser(bytes.NewBufferString("Dummy"))}, nil
+	}
+	defer func() { SendEventFunc = sendEvent }()
+
+	// plugins forward the metric report as JSON string
+	report, _ := json.Marshal(`{"@odata.type":"#MetricReport.v1_4_2.MetricReport","Id":"CPUUtil","MetricReportDefinition":{"@odata.id":"/redfish/v1/TelemetryService/MetricReportDefinitions/CPUUtil"},"MetricValues":[]}`)
+	assert.True(t, pc.publishMetricReport(string(report), "100.100.100.100"), "metric report should be published")
+
+	var received []string
+	for i := 0; i < 2; i++ {
+		select {
+		case destination := <-destinations:
+			received = append(received, destination)
+		case <-time.After(time.Second * 5):
+			t.Fatal("metric report not delivered")
+		}
+	}
+	assert.ElementsMatch(t, []string{"https://localhost:1234/all", "https://localhost:1234/cpu"}, received)
+	select {
+	case destination := <-destinations:
+		t.Errorf("metric report shouldn't be delivered to %v", destination)
+	case <-time.After(time.Millisecond * 100):
+	}
+
+	// report without the definition link is matched with the definition having its Id
+	assert.Equal(t, "/redfish/v1/TelemetryService/MetricReportDefinitions/PowerMetrics", metricReport{ID: "PowerMetrics"}.definition())
+	assert.False(t, pc.publishMetricReport("invalid", "100.100.100.100"), "invalid metric report shouldn't be published")
+}
+
+func Test_getRefreshResourceURI(t *testing.T) {
+	systemURI := "/redfish/v1/Systems/3bd1f589-117a-4cf9-89f2-da44ee8e012b.1"
+	tests := []struct {
+		name      string
+		eventType string
+		messageID string
+		origin    string
+		want      string
+	}{
+		{"server restart", "Alert", "iLOEvents.2.1.ServerPostComplete", systemURI + "/", systemURI},
+		{"power state change", "Alert", "iLOEvents.2.1.ServerPoweredOn", systemURI, ""},
+		{"resource added", "ResourceAdded", "ResourceEvent.1.0.ResourceCreated", systemURI + "/Memory/proc1dimm1", systemURI + "/Memory"},
+		{"volume removed", "ResourceRemoved", "ResourceEvent.1.0.ResourceRemoved", systemURI + "/Storage/1/Volumes/2", systemURI + "/Storage/1"},
+		{"resource changed", "Alert", "ResourceEvent.1.0.ResourceChanged", systemURI + "/Processors/1", systemURI + "/Processors/1"},
+		{"resource updated", "ResourceUpdated", "", "/redfish/v1/Chassis/3bd1f589-117a-4cf9-89f2-da44ee8e012b.1/Power", "/redfish/v1/Chassis/3bd1f589-117a-4cf9-89f2-da44ee8e012b.1/Power"},
+		{"system added", "ResourceAdded", "ResourceEvent.1.0.ResourceCreated", systemURI, ""},
+		{"fabric resource", "ResourceAdded", "ResourceEvent.1.0.ResourceCreated", "/redfish/v1/Fabrics/1/Zones/1", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := common.Event{
+				EventType:         tt.eventType,
+				MessageID:         tt.messageID,
+				OriginOfCondition: &common.Link{Oid: tt.origin},
+			}
+			assert.Equal(t, tt.want, getRefreshResourceURI(event))
+		})
+	}
+}
+
+func Test_scheduleRefresh(t *testing.T) {
+	defer func(delay time.Duration) {
+		refreshDelay = delay
+		refreshInventoryFunc = refreshResourceInventory
+	}(refreshDelay)
+	refreshDelay = 50 * time.Millisecond
+	refreshed := make(chan string, 10)
+	refreshInventoryFunc = func(deviceUUID, resourceURI string) {
+		refreshed <- resourceURI
+	}
+	deviceUUID := "3bd1f589-117a-4cf9-89f2-da44ee8e012b"
+	systemURI := "/redfish/v1/Systems/" + deviceUUID + ".1"
+
+	// the burst of events for a resource results in a single refresh
+	for i := 0; i < 3; i++ {
+		scheduleRefresh(deviceUUID, systemURI+"/Processors/1")
+	}
+	scheduleRefresh(deviceUUID, systemURI+"/Memory")
+	var got []string
+	timeout := time.After(time.Second)
+	for len(got) < 2 {
+		select {
+		case resourceURI := <-refreshed:
+			got = append(got, resourceURI)
+		case <-timeout:
+			t.Fatalf("scheduleRefresh() refreshed %v, want both resources refreshed", got)
+		}
+	}
+	sort.Strings(got)
+	assert.Equal(t, []string{systemURI + "/Memory", systemURI + "/Processors/1"}, got)
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, 0, len(refreshed), "resource should be refreshed once for the burst of events")
+
+	// the events received after the refresh are refreshed again
+	scheduleRefresh(deviceUUID, systemURI+"/Processors/1")
+	select {
+	case resourceURI := <-refreshed:
+		assert.Equal(t, systemURI+"/Processors/1", resourceURI)
+	case <-time.After(time.Second):
+		t.Error("scheduleRefresh() did not refresh the resource changed again")
+	}
+}
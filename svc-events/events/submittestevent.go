@@ -33,6 +33,7 @@ import (
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
 	eventsproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/events"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-events/evmodel"
 )
 
 var (
@@ -110,7 +111,7 @@ func (e *ExternalInterfaces) SubmitTestEvent(req *eventsproto.EventSubRequest) r
 			if sub.Destination != "" {
 				if filterEventsToBeForwarded(sub, message.Events[0], []string{origin}) {
 					l.Log.Info("Destination: " + sub.Destination)
-					go e.postEvent(sub.Destination, eventUniqueID, messageBytes)
+					go e.postEventToSubscribers(sub.Destination, []evmodel.Subscription{sub}, eventUniqueID, messageBytes)
 				}
 			}
 		}
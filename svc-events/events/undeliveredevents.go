@@ -0,0 +1,166 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package events have the functionality of
+// - Create Event Subscription
+// - Delete Event Subscription
+// - Get Event Subscription
+// - Post Event Subscription to destination
+// - Post TestEvent (SubmitTestEvent)
+// and corresponding unit test cases
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	eventsproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/events"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-events/evmodel"
+	"github.com/ODIM-Project/ODIM/svc-events/evresponse"
+)
+
+// ResumeEventSubscription enables the subscription suspended or disabled as
+// per its DeliveryRetryPolicy and posts the undelivered events retained for it
+func (e *ExternalInterfaces) ResumeEventSubscription(req *eventsproto.EventRequest) response.RPC {
+	authResp, err := e.Auth(req.SessionToken, []string{common.PrivilegeConfigureComponents}, []string{})
+	if authResp.StatusCode != http.StatusOK {
+		errMsg := fmt.Sprintf("error while trying to authenticate session: status code: %v, status message: %v", authResp.StatusCode, authResp.StatusMessage)
+		if err != nil {
+			errMsg = errMsg + ": " + err.Error()
+		}
+		l.Log.Error(errMsg)
+		return authResp
+	}
+	subscription, resp := e.getSubscription(req.EventSubscriptionID)
+	if subscription == nil {
+		return resp
+	}
+	if !subscription.IsEnabled() {
+		subscription.State = evmodel.SubscriptionEnabled
+		if err := e.UpdateEventSubscription(*subscription); err != nil {
+			errorMessage := "error while updating state of subscription " + subscription.SubscriptionID + ": " + err.Error()
+			l.Log.Error(errorMessage)
+			return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+		}
+		l.Log.Info("subscription " + subscription.SubscriptionID + " is resumed")
+	}
+	// undelivered events are posted in the background as the destination
+	// might take a while to receive all of them
+	go e.checkUndeliveredEvents(*subscription)
+
+	resp.StatusCode = http.StatusOK
+	resp.StatusMessage = response.Success
+	resp.Body = response.ErrorClass{
+		Code:    resp.StatusMessage,
+		Message: "Request completed successfully.",
+	}
+	return resp
+}
+
+// GetUndeliveredEventsCollection returns the events retained for the
+// subscription which couldn't be delivered to its destination, the oldest
+// event first
+func (e *ExternalInterfaces) GetUndeliveredEventsCollection(req *eventsproto.EventRequest) response.RPC {
+	authResp, err := e.Auth(req.SessionToken, []string{common.PrivilegeConfigureComponents}, []string{})
+	if authResp.StatusCode != http.StatusOK {
+		errMsg := fmt.Sprintf("error while trying to authenticate session: status code: %v, status message: %v", authResp.StatusCode, authResp.StatusMessage)
+		if err != nil {
+			errMsg = errMsg + ": " + err.Error()
+		}
+		l.Log.Error(errMsg)
+		return authResp
+	}
+	subscription, resp := e.getSubscription(req.EventSubscriptionID)
+	if subscription == nil {
+		return resp
+	}
+	undeliveredEvents, err := e.GetSubscriptionUndeliveredEvents(subscription.SubscriptionID)
+	if err != nil {
+		errorMessage := "error while getting undelivered events of subscription " + subscription.SubscriptionID + ": " + err.Error()
+		l.Log.Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	collectionURI := "/redfish/v1/EventService/Subscriptions/" + subscription.SubscriptionID + "/UndeliveredEvents"
+	members := []evresponse.UndeliveredEventMember{}
+	for i, event := range undeliveredEvents {
+		members = append(members, evresponse.UndeliveredEventMember{
+			OdataID:   collectionURI + "#/Members/" + strconv.Itoa(i),
+			ID:        event.ID,
+			Timestamp: event.Timestamp,
+			Event:     rawEvent(event.Event),
+		})
+	}
+	resp.Body = evresponse.UndeliveredEventsResponse{
+		OdataID:      collectionURI,
+		Name:         "Undelivered Events",
+		Description:  "Events which couldn't be delivered to " + subscription.Destination,
+		MembersCount: len(members),
+		Members:      members,
+	}
+	resp.StatusCode = http.StatusOK
+	resp.StatusMessage = response.Success
+	return resp
+}
+
+// removeUndeliveredEvents removes the undelivered events retained for the
+// deleted subscription
+func (e *ExternalInterfaces) removeUndeliveredEvents(subscriptionID string) {
+	undeliveredEvents, err := e.GetSubscriptionUndeliveredEvents(subscriptionID)
+	if err != nil {
+		l.Log.Error("error while getting undelivered events: ", err.Error())
+		return
+	}
+	for _, event := range undeliveredEvents {
+		if err := e.DeleteUndeliveredEvents(subscriptionID + ":" + event.ID); err != nil {
+			l.Log.Error("error while deleting undelivered events: ", err.Error())
+		}
+	}
+}
+
+// getSubscription returns the subscription with the ID, the error response is
+// returned when the subscription couldn't be found
+func (e *ExternalInterfaces) getSubscription(subscriptionID string) (*evmodel.Subscription, response.RPC) {
+	subscriptions, err := e.GetEvtSubscriptions(subscriptionID)
+	if err != nil && !strings.Contains(err.Error(), "No data found for the key") {
+		errorMessage := "error while getting subscription " + subscriptionID + ": " + err.Error()
+		l.Log.Error(errorMessage)
+		return nil, common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	// Since we are searching subscription id with pattern search
+	// we need to match the subscripton id
+	for _, subscription := range subscriptions {
+		if subscription.SubscriptionID == subscriptionID {
+			return &subscription, response.RPC{}
+		}
+	}
+	errorMessage := "Subscription details not found for subscription id: " + subscriptionID
+	l.Log.Error(errorMessage)
+	return nil, common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errorMessage, []interface{}{"EventSubscription", subscriptionID}, nil)
+}
+
+// rawEvent returns the undelivered event to be embedded in the response,
+// the event is returned as string if it is not a valid JSON
+func rawEvent(event string) json.RawMessage {
+	if json.Valid([]byte(event)) {
+		return json.RawMessage(event)
+	}
+	data, _ := json.Marshal(event)
+	return data
+}
@@ -0,0 +1,134 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package events have the functionality of
+// - Create Event Subscription
+// - Delete Event Subscription
+// - Get Event Subscription
+// - Post Event Subscription to destination
+// and corresponding unit test cases
+package events
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	eventsproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/events"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-events/evmodel"
+	"github.com/ODIM-Project/ODIM/svc-events/evresponse"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResumeEventSubscription(t *testing.T) {
+	config.SetUpMockConfig(t)
+	p := getMockMethods()
+	var updated []evmodel.Subscription
+	p.DB.UpdateEventSubscription = func(s evmodel.Subscription) error {
+		updated = append(updated, s)
+		return nil
+	}
+	SendEventFunc = func(destination string, event []byte) (*http.Response, error) {
+		return &http.Response{Body: ioutil.NopCloser(bytes.NewBufferString("Dummy"))}, nil
+	}
+	defer func() { SendEventFunc = sendEvent }()
+
+	req := &eventsproto.EventRequest{
+		SessionToken:        "validToken",
+		EventSubscriptionID: "61de0110-c35a-4859-984c-072d6c5a32e1",
+	}
+	resp := p.ResumeEventSubscription(req)
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "Status Code should be StatusOK")
+	if assert.Equal(t, 1, len(updated), "suspended subscription should be updated") {
+		assert.Equal(t, evmodel.SubscriptionEnabled, updated[0].State, "subscription should be enabled")
+	}
+
+	// subscription which is already enabled is not updated
+	updated = nil
+	req.EventSubscriptionID = "81de0110-c35a-4859-984c-072d6c5a32d7"
+	resp = p.ResumeEventSubscription(req)
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "Status Code should be StatusOK")
+	assert.Equal(t, 0, len(updated), "enabled subscription shouldn't be updated")
+
+	req.EventSubscriptionID = "invalid"
+	resp = p.ResumeEventSubscription(req)
+	assert.Equal(t, http.StatusNotFound, int(resp.StatusCode), "Status Code should be StatusNotFound")
+
+	req.SessionToken = "InValidToken"
+	resp = p.ResumeEventSubscription(req)
+	assert.Equal(t, http.StatusUnauthorized, int(resp.StatusCode), "Status Code should be StatusUnauthorized")
+
+	req.SessionToken = "validToken"
+	req.EventSubscriptionID = "61de0110-c35a-4859-984c-072d6c5a32e1"
+	p.DB.UpdateEventSubscription = func(s evmodel.Subscription) error {
+		return errors.New("DB error")
+	}
+	resp = p.ResumeEventSubscription(req)
+	assert.Equal(t, http.StatusInternalServerError, int(resp.StatusCode), "Status Code should be StatusInternalServerError")
+}
+
+func TestGetUndeliveredEventsCollection(t *testing.T) {
+	config.SetUpMockConfig(t)
+	p := getMockMethods()
+	req := &eventsproto.EventRequest{
+		SessionToken:        "validToken",
+		EventSubscriptionID: "61de0110-c35a-4859-984c-072d6c5a32e1",
+	}
+	resp := p.GetUndeliveredEventsCollection(req)
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "Status Code should be StatusOK")
+	collection := resp.Body.(evresponse.UndeliveredEventsResponse)
+	assert.Equal(t, "/redfish/v1/EventService/Subscriptions/61de0110-c35a-4859-984c-072d6c5a32e1/UndeliveredEvents", collection.OdataID)
+	assert.Equal(t, 2, collection.MembersCount, "there should be two undelivered events")
+	assert.Equal(t, "event1", collection.Members[0].ID)
+	assert.Equal(t, `{"Events":[{"EventType":"Alert"}]}`, string(collection.Members[0].Event), "valid JSON event should be embedded as is")
+	assert.Equal(t, `"event"`, string(collection.Members[1].Event), "invalid JSON event should be embedded as string")
+
+	// subscription without undelivered events
+	req.EventSubscriptionID = "81de0110-c35a-4859-984c-072d6c5a32d7"
+	resp = p.GetUndeliveredEventsCollection(req)
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "Status Code should be StatusOK")
+	assert.Equal(t, 0, resp.Body.(evresponse.UndeliveredEventsResponse).MembersCount, "there should be no undelivered events")
+
+	req.EventSubscriptionID = "invalid"
+	resp = p.GetUndeliveredEventsCollection(req)
+	assert.Equal(t, http.StatusNotFound, int(resp.StatusCode), "Status Code should be StatusNotFound")
+
+	req.SessionToken = "InValidToken"
+	resp = p.GetUndeliveredEventsCollection(req)
+	assert.Equal(t, http.StatusUnauthorized, int(resp.StatusCode), "Status Code should be StatusUnauthorized")
+
+	req.SessionToken = "validToken"
+	req.EventSubscriptionID = "61de0110-c35a-4859-984c-072d6c5a32e1"
+	p.DB.GetSubscriptionUndeliveredEvents = func(s string) ([]evmodel.UndeliveredEvent, error) {
+		return nil, errors.New("DB error")
+	}
+	resp = p.GetUndeliveredEventsCollection(req)
+	assert.Equal(t, http.StatusInternalServerError, int(resp.StatusCode), "Status Code should be StatusInternalServerError")
+	assert.Equal(t, response.InternalError, resp.StatusMessage)
+}
+
+func TestRemoveUndeliveredEvents(t *testing.T) {
+	p := getMockMethods()
+	var deleted []string
+	p.DB.DeleteUndeliveredEvents = func(key string) error {
+		deleted = append(deleted, key)
+		return nil
+	}
+	p.removeUndeliveredEvents("61de0110-c35a-4859-984c-072d6c5a32e1")
+	assert.Equal(t, []string{"61de0110-c35a-4859-984c-072d6c5a32e1:event1", "61de0110-c35a-4859-984c-072d6c5a32e1:event2"}, deleted)
+}
@@ -0,0 +1,91 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package evmessagebus publishes the events handled by the event service
+// to the message bus
+package evmessagebus
+
+import (
+	"fmt"
+	"sync"
+
+	dc "github.com/ODIM-Project/ODIM/lib-messagebus/datacommunicator"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+)
+
+var (
+	// communicators holds the connections to the event queues by their topics,
+	// they are opened on the first event and shared by all the events
+	communicators     = make(map[string]dc.MQBus)
+	communicatorsLock sync.Mutex
+)
+
+// PublishSSEEvent publishes the events received from the devices to the SSE
+// event queue, from where the API service streams them to the clients
+// connected to the ServerSentEventUri
+func PublishSSEEvent(message common.MessageData) error {
+	config.TLSConfMutex.RLock()
+	topicName := config.Data.MessageBusConf.OdimSSEEventQueue
+	config.TLSConfMutex.RUnlock()
+	return publish(topicName, message)
+}
+
+// PublishHealthEvent publishes the events received from the devices to the
+// health event queue, from where the systems service updates the health
+// rollup of the systems and chassis
+func PublishHealthEvent(message common.MessageData) error {
+	config.TLSConfMutex.RLock()
+	topicName := config.Data.MessageBusConf.OdimHealthEventQueue
+	config.TLSConfMutex.RUnlock()
+	return publish(topicName, message)
+}
+
+// publish distributes the message to the topic, the connection is dropped
+// when the message can't be published so that the next event reconnects
+func publish(topicName string, message common.MessageData) error {
+	k, err := getCommunicator(topicName)
+	if err != nil {
+		return err
+	}
+	if err := k.Distribute(message); err != nil {
+		communicatorsLock.Lock()
+		if communicators[topicName] == k {
+			delete(communicators, topicName)
+			k.Close()
+		}
+		communicatorsLock.Unlock()
+		return fmt.Errorf("unable to publish the event to %s: %s", topicName, err.Error())
+	}
+	return nil
+}
+
+// getCommunicator returns the connection to the topic, opened on the first call
+func getCommunicator(topicName string) (dc.MQBus, error) {
+	communicatorsLock.Lock()
+	defer communicatorsLock.Unlock()
+	if k, ok := communicators[topicName]; ok {
+		return k, nil
+	}
+	config.TLSConfMutex.RLock()
+	messageBusType := config.Data.MessageBusConf.MessageBusType
+	messageBusConfigFilePath := config.Data.MessageBusConf.MessageBusConfigFilePath
+	config.TLSConfMutex.RUnlock()
+	k, err := dc.Communicator(messageBusType, messageBusConfigFilePath, topicName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to %s: %s", messageBusType, err.Error())
+	}
+	communicators[topicName] = k
+	return k, nil
+}
@@ -18,7 +18,9 @@ package evmodel
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
@@ -26,9 +28,17 @@ import (
 )
 
 const (
-	// EventFormatType is set to Event (MetricReport is not supporting now)
+	// EventFormatType is set to Event incase if its empty
 	EventFormatType = "Event"
 
+	// MetricReportFormatType is the EventFormatType of the subscriptions to
+	// which the metric reports are delivered
+	MetricReportFormatType = "MetricReport"
+
+	// MetricReportDefinitionsURI is the URI of the MetricReportDefinitions
+	// collection which can be used to filter the metric reports
+	MetricReportDefinitionsURI = "/redfish/v1/TelemetryService/MetricReportDefinitions/"
+
 	// SubscriptionType is set to RedfishEvent (make it as array of SubscritpionType)
 	SubscriptionType = "RedfishEvent"
 
@@ -51,7 +61,26 @@ const (
 	// ReadInProgres holds table for ReadInProgres
 	ReadInProgres = "ReadInProgres"
 	// DeliveryRetryPolicy is set to default value incase if its empty
-	DeliveryRetryPolicy = "RetryForever"
+	DeliveryRetryPolicy = RetryForever
+
+	// RetryForever keeps the subscription enabled when the retries of an event
+	// are exhausted, the event is delivered once the destination is reachable
+	RetryForever = "RetryForever"
+	// SuspendRetries suspends the subscription when the retries of an event
+	// are exhausted, the events are retained till the subscription is resumed
+	SuspendRetries = "SuspendRetries"
+	// TerminateAfterRetries disables the subscription when the retries of an
+	// event are exhausted
+	TerminateAfterRetries = "TerminateAfterRetries"
+
+	// SubscriptionEnabled is the state of a subscription to which the events are delivered
+	SubscriptionEnabled = "Enabled"
+	// SubscriptionSuspended is the state of a subscription for which the events
+	// are retained without delivering
+	SubscriptionSuspended = "Suspended"
+	// SubscriptionDisabled is the state of a subscription to which the events
+	// are not delivered anymore
+	SubscriptionDisabled = "Disabled"
 
 	// AggregateSubscriptionIndex is a index name which required for indexing
 	// subscription of device
@@ -82,6 +111,9 @@ type RequestBody struct {
 	SubordinateResources bool          `json:"SubordinateResources"`
 	OriginResources      []OdataIDLink `json:"OriginResources"`
 	DeliveryRetryPolicy  string        `json:"DeliveryRetryPolicy"`
+	// MetricReportDefinitions filters the metric reports delivered to
+	// the subscriptions with MetricReport EventFormatType
+	MetricReportDefinitions []OdataIDLink `json:"MetricReportDefinitions,omitempty"`
 }
 
 //Subscription is a model to store the subscription details
@@ -110,6 +142,24 @@ type Subscription struct {
 	ExcludeMessageIds       []string `json:"ExcludeMessageIds,omitempty"`
 	ExcludeRegistryPrefixes []string `json:"ExcludeRegistryPrefixes,omitempty"`
 	DeliveryRetryPolicy     string   `json:"DeliveryRetryPolicy"`
+	// State of the subscription, subscriptions saved without it are enabled
+	State string `json:"State,omitempty"`
+	// To store the metric report definitions of which the reports are delivered,
+	// all the reports are delivered when it's empty
+	MetricReportDefinitions []string `json:"MetricReportDefinitions,omitempty"`
+}
+
+// IsEnabled tells whether the events are delivered to the subscription
+func (s Subscription) IsEnabled() bool {
+	return s.State == "" || s.State == SubscriptionEnabled
+}
+
+// UndeliveredEvent is an event which couldn't be delivered to the destination of the subscription
+type UndeliveredEvent struct {
+	ID             string `json:"-"`
+	SubscriptionID string `json:"-"`
+	Timestamp      string `json:"Timestamp"`
+	Event          string `json:"Event"`
 }
 
 //DeviceSubscription is a model to store the subscription details of a device
@@ -519,7 +569,11 @@ func SaveUndeliveredEvents(key string, event []byte) error {
 		l.Log.Error("While trying to get DB Connection : " + err.Error())
 		return fmt.Errorf("error while trying to connecting to DB: %v", err.Error())
 	}
-	if err = connPool.AddResourceData(UndeliveredEvents, key, string(event)); err != nil {
+	undeliveredEvent := UndeliveredEvent{
+		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+		Event:     string(event),
+	}
+	if err = connPool.AddResourceData(UndeliveredEvents, key, undeliveredEvent); err != nil {
 		l.Log.Error(" while trying to add Undelivered Events to DB: " + err.Error())
 		return fmt.Errorf("error while trying to add Undelivered Events to DB: %v", err.Error())
 	}
@@ -538,7 +592,59 @@ func GetUndeliveredEvents(destination string) (string, error) {
 		return "", fmt.Errorf("error: while trying to fetch details: %v", err.Error())
 	}
 
-	return eventData, nil
+	return decodeUndeliveredEvent(eventData).Event, nil
+}
+
+// GetSubscriptionUndeliveredEvents returns the undelivered events saved for
+// the subscription, the oldest event first
+func GetSubscriptionUndeliveredEvents(subscriptionID string) ([]UndeliveredEvent, error) {
+	conn, err := GetDbConnection(common.OnDisk)
+	if err != nil {
+		return nil, fmt.Errorf("error: while trying to create connection with DB: %v", err.Error())
+	}
+	prefix := subscriptionID + ":"
+	keys, err := conn.GetAllMatchingDetails(UndeliveredEvents, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("error: while trying to fetch undelivered events of %v: %v", subscriptionID, err.Error())
+	}
+	var events []UndeliveredEvent
+	for _, key := range keys {
+		// pattern search matches the keys having the prefix as substring as well
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		eventData, err := conn.Read(UndeliveredEvents, key)
+		if err != nil {
+			// the event is delivered or removed meanwhile
+			continue
+		}
+		event := decodeUndeliveredEvent(eventData)
+		event.ID = strings.TrimPrefix(key, prefix)
+		event.SubscriptionID = subscriptionID
+		events = append(events, event)
+	}
+	// RFC3339Nano timestamps are not of fixed width, so they are compared
+	// as time instead of string
+	sort.SliceStable(events, func(i, j int) bool {
+		ti, _ := time.Parse(time.RFC3339Nano, events[i].Timestamp)
+		tj, _ := time.Parse(time.RFC3339Nano, events[j].Timestamp)
+		return ti.Before(tj)
+	})
+	return events, nil
+}
+
+// decodeUndeliveredEvent decodes the undelivered event read from the DB, the
+// events saved before the timestamp was recorded are saved as plain string
+func decodeUndeliveredEvent(eventData string) UndeliveredEvent {
+	var event UndeliveredEvent
+	if err := json.Unmarshal([]byte(eventData), &event); err == nil && event.Event != "" {
+		return event
+	}
+	var data string
+	if err := json.Unmarshal([]byte(eventData), &data); err == nil {
+		return UndeliveredEvent{Event: data}
+	}
+	return UndeliveredEvent{Event: eventData}
 }
 
 // DeleteUndeliveredEvents deletes the undelivered events for the destination
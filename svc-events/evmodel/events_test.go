@@ -745,6 +745,29 @@ func TestDeleteUndeliveredEvents(t *testing.T) {
 	assert.NotNil(t, err, "error should not be nil")
 }
 
+func TestGetSubscriptionUndeliveredEvents(t *testing.T) {
+	common.SetUpMockConfig()
+	defer func() {
+		err := common.TruncateDB(common.OnDisk)
+		if err != nil {
+			t.Fatalf("error: %v", err)
+		}
+	}()
+	for _, key := range []string{"subscription:event1", "subscription:event2", "othersubscription:event3"} {
+		if cerr := SaveUndeliveredEvents(key, []byte(`event`)); cerr != nil {
+			t.Errorf("Error while making save undelivered events : %v\n", cerr.Error())
+		}
+	}
+
+	events, err := GetSubscriptionUndeliveredEvents("subscription")
+	assert.Nil(t, err, "error should be nil")
+	if assert.Equal(t, 2, len(events), "there should be two undelivered events") {
+		assert.Equal(t, "event1", events[0].ID, "oldest event should be first")
+		assert.Equal(t, "event2", events[1].ID, "latest event should be last")
+		assert.Equal(t, "event", events[0].Event, "there should be event data")
+	}
+}
+
 func TestSetUndeliveredEventsFlag(t *testing.T) {
 	common.SetUpMockConfig()
 	defer func() {
@@ -17,6 +17,7 @@
 package evresponse
 
 import (
+	"encoding/json"
 	"strings"
 	"sync"
 
@@ -26,17 +27,55 @@ import (
 // SubscriptionResponse is used to return response to end user
 type SubscriptionResponse struct {
 	response.Response
-	Destination             string       `json:"Destination,omitempty"`
-	Context                 string       `json:"Context,omitempty"`
-	Protocol                string       `json:"Protocol,omitempty"`
-	EventTypes              []string     `json:"EventTypes,omitempty"`
-	SubscriptionType        string       `json:"SubscriptionType,omitempty"`
-	MessageIds              []string     `json:"MessageIds,omitempty"`
-	ResourceTypes           []string     `json:"ResourceTypes,omitempty"`
-	OriginResources         []ListMember `json:"OriginResources,omitempty"`
-	ExcludeMessageIds       []string     `json:"ExcludeMessageIds,omitempty"`
-	ExcludeRegistryPrefixes []string     `json:"ExcludeRegistryPrefixes,omitempty"`
-	DeliveryRetryPolicy     string       `json:"DeliveryRetryPolicy,omitempty"`
+	Destination             string               `json:"Destination,omitempty"`
+	Context                 string               `json:"Context,omitempty"`
+	Protocol                string               `json:"Protocol,omitempty"`
+	EventTypes              []string             `json:"EventTypes,omitempty"`
+	SubscriptionType        string               `json:"SubscriptionType,omitempty"`
+	MessageIds              []string             `json:"MessageIds,omitempty"`
+	ResourceTypes           []string             `json:"ResourceTypes,omitempty"`
+	OriginResources         []ListMember         `json:"OriginResources,omitempty"`
+	ExcludeMessageIds       []string             `json:"ExcludeMessageIds,omitempty"`
+	ExcludeRegistryPrefixes []string             `json:"ExcludeRegistryPrefixes,omitempty"`
+	DeliveryRetryPolicy     string               `json:"DeliveryRetryPolicy,omitempty"`
+	EventFormatType         string               `json:"EventFormatType,omitempty"`
+	MetricReportDefinitions []ListMember         `json:"MetricReportDefinitions,omitempty"`
+	Status                  *SubscriptionStatus  `json:"Status,omitempty"`
+	Actions                 *SubscriptionActions `json:"Actions,omitempty"`
+}
+
+// SubscriptionStatus is the status of the subscription
+type SubscriptionStatus struct {
+	State string `json:"State"`
+}
+
+// SubscriptionActions are the actions supported on a subscription
+type SubscriptionActions struct {
+	ResumeSubscription ActionTarget `json:"#EventDestination.ResumeSubscription"`
+}
+
+// ActionTarget contains the target URI of an action
+type ActionTarget struct {
+	Target string `json:"target"`
+}
+
+// UndeliveredEventsResponse is the collection of the events which couldn't be
+// delivered to the destination of a subscription
+type UndeliveredEventsResponse struct {
+	OdataID      string                   `json:"@odata.id"`
+	Name         string                   `json:"Name"`
+	Description  string                   `json:"Description,omitempty"`
+	MembersCount int                      `json:"Members@odata.count"`
+	Members      []UndeliveredEventMember `json:"Members"`
+}
+
+// UndeliveredEventMember is an event which couldn't be delivered, Timestamp
+// is the time at which the delivery failed first
+type UndeliveredEventMember struct {
+	OdataID   string          `json:"@odata.id"`
+	ID        string          `json:"Id"`
+	Timestamp string          `json:"Timestamp"`
+	Event     json.RawMessage `json:"Event"`
 }
 
 // ListResponse define list for odimra
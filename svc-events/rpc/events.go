@@ -31,6 +31,7 @@ import (
 	"github.com/ODIM-Project/ODIM/lib-utilities/services"
 	"github.com/ODIM-Project/ODIM/svc-events/evcommon"
 	"github.com/ODIM-Project/ODIM/svc-events/events"
+	"github.com/ODIM-Project/ODIM/svc-events/evmessagebus"
 	"github.com/ODIM-Project/ODIM/svc-events/evmodel"
 	"github.com/ODIM-Project/ODIM/svc-events/evresponse"
 )
@@ -49,11 +50,13 @@ var (
 func GetPluginContactInitializer() *Events {
 	connector := &events.ExternalInterfaces{
 		External: events.External{
-			ContactClient:   pmbhandle.ContactPlugin,
-			Auth:            services.IsAuthorized,
-			CreateTask:      services.CreateTask,
-			UpdateTask:      events.UpdateTaskData,
-			CreateChildTask: services.CreateChildTask,
+			ContactClient:      pmbhandle.ContactPlugin,
+			Auth:               services.IsAuthorized,
+			CreateTask:         services.CreateTask,
+			UpdateTask:         events.UpdateTaskData,
+			CreateChildTask:    services.CreateChildTask,
+			PublishSSEEvent:    evmessagebus.PublishSSEEvent,
+			PublishHealthEvent: evmessagebus.PublishHealthEvent,
 		},
 		DB: events.DB{
 			GetSessionUserName:               services.GetSessionUserName,
@@ -77,6 +80,7 @@ func GetPluginContactInitializer() *Events {
 			SetUndeliveredEventsFlag:         evmodel.SetUndeliveredEventsFlag,
 			DeleteUndeliveredEventsFlag:      evmodel.DeleteUndeliveredEventsFlag,
 			DeleteUndeliveredEvents:          evmodel.DeleteUndeliveredEvents,
+			GetSubscriptionUndeliveredEvents:  evmodel.GetSubscriptionUndeliveredEvents,
 			GetAggregateData:                 evmodel.GetAggregateData,
 			SaveAggregateSubscription:        evmodel.SaveAggregateSubscription,
 			GetAggregateHosts:                evmodel.GetAggregateHosts,
@@ -164,23 +168,20 @@ func (e *Events) GetEventService(ctx context.Context, req *eventsproto.EventSubR
 			"ResourceAdded",
 			"ResourceRemoved",
 			"Alert"},
-		RegistryPrefixes: []string{},
-		ResourceTypes:    resourceTypes,
-		//		ServerSentEventURI:"/redfish/v1/EventService/SSE",
-		ServiceEnabled: isServiceEnabled,
-		/*
-			SSEFilterPropertiesSupported: &evresponse.SSEFilterPropertiesSupported{
-				EventFormatType:        true,
-				EventType:              true,
-				MessageID:              true,
-				MetricReportDefinition: false,
-				OriginResource:         true,
-				RegistryPrefix:         false,
-				ResourceType:           true,
-				SubordinateResources:   true,
-			},
-		*/
-
+		RegistryPrefixes:   []string{},
+		ResourceTypes:      resourceTypes,
+		ServerSentEventURI: "/redfish/v1/EventService/SSE",
+		ServiceEnabled:     isServiceEnabled,
+		SSEFilterPropertiesSupported: &evresponse.SSEFilterPropertiesSupported{
+			EventFormatType:        false,
+			EventType:              true,
+			MessageID:              true,
+			MetricReportDefinition: false,
+			OriginResource:         true,
+			RegistryPrefix:         true,
+			ResourceType:           true,
+			SubordinateResources:   false,
+		},
 		Status: evresponse.Status{
 			Health:       "OK",
 			HealthRollup: "OK",
@@ -353,6 +354,41 @@ func (e *Events) DeleteEventSubscription(ctx context.Context, req *eventsproto.E
 	return &resp, nil
 }
 
+// ResumeEventSubscription defines the operations which handles the RPC request response
+// for the resume event subscription RPC call to events micro service.
+// The functionality is to enable the suspended subscription and post its undelivered events.
+func (e *Events) ResumeEventSubscription(ctx context.Context, req *eventsproto.EventRequest) (*eventsproto.EventSubResponse, error) {
+	data := e.Connector.ResumeEventSubscription(req)
+	return generateEventSubResponse(data, "resume event subscription"), nil
+}
+
+// GetUndeliveredEventsCollection defines the operations which handles the RPC request response
+// for the get undelivered events RPC call to events micro service.
+// The functionality is to get the events which couldn't be delivered to the subscription.
+func (e *Events) GetUndeliveredEventsCollection(ctx context.Context, req *eventsproto.EventRequest) (*eventsproto.EventSubResponse, error) {
+	data := e.Connector.GetUndeliveredEventsCollection(req)
+	return generateEventSubResponse(data, "get undelivered events"), nil
+}
+
+// generateEventSubResponse converts the response of the operation to the RPC response
+func generateEventSubResponse(data response.RPC, operation string) *eventsproto.EventSubResponse {
+	var resp eventsproto.EventSubResponse
+	var err error
+	resp.Body, err = JSONMarshal(data.Body)
+	if err != nil {
+		errorMessage := "error while trying marshal the response body for " + operation + ": " + err.Error()
+		resp.StatusCode = http.StatusInternalServerError
+		resp.StatusMessage = response.InternalError
+		resp.Body, _ = json.Marshal(common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil).Body)
+		l.Log.Error(errorMessage)
+		return &resp
+	}
+	resp.StatusCode = data.StatusCode
+	resp.StatusMessage = data.StatusMessage
+	resp.Header = data.Header
+	return &resp
+}
+
 //CreateDefaultEventSubscription defines the operations which handles the RPC request response
 // after computer system restarts ,This will  triggered from   aggregation service whenever a computer system is added
 func (e *Events) CreateDefaultEventSubscription(ctx context.Context, req *eventsproto.DefaultEventSubRequest) (*eventsproto.DefaultEventSubResponse, error) {
@@ -57,6 +57,11 @@ func getMockPluginContactInitializer() *Events {
 			GetAllMatchingDetails:            evcommon.MockGetAllMatchingDetails,
 			SaveDeviceSubscription:           evcommon.MockSaveDeviceSubscription,
 			SaveUndeliveredEvents:            evcommon.MockSaveUndeliveredEvents,
+			GetUndeliveredEventsFlag:         evcommon.MockGetUndeliveredEventsFlag,
+			SetUndeliveredEventsFlag:         evcommon.MockSetUndeliveredEventsFlag,
+			DeleteUndeliveredEventsFlag:      evcommon.MockDeleteUndeliveredEventsFlag,
+			DeleteUndeliveredEvents:          evcommon.MockDeleteUndeliveredEvents,
+			GetSubscriptionUndeliveredEvents:  evcommon.MockGetSubscriptionUndeliveredEvents,
 		},
 	}
 	return &Events{
@@ -328,3 +333,41 @@ func TestEvents_RemoveEventSubscriptionsRPC(t *testing.T) {
 	assert.NotNil(t, "There should be an error ", err)
 	GetPluginContactInitializer()
 }
+
+func TestResumeEventSubscription(t *testing.T) {
+	config.SetUpMockConfig(t)
+	var ctx context.Context
+	events := getMockPluginContactInitializer()
+	req := &eventsproto.EventRequest{
+		SessionToken:        "validToken",
+		EventSubscriptionID: "81de0110-c35a-4859-984c-072d6c5a32d7",
+	}
+	resp, err := events.ResumeEventSubscription(ctx, req)
+	assert.Nil(t, err, "There should be no error")
+	assert.Equal(t, int(resp.StatusCode), http.StatusOK, "Status code should be StatusOK.")
+
+	req.EventSubscriptionID = "81de0110"
+	resp, _ = events.ResumeEventSubscription(ctx, req)
+	assert.Equal(t, int(resp.StatusCode), http.StatusNotFound, "Status code should be StatusNotFound.")
+}
+
+func TestGetUndeliveredEventsCollection(t *testing.T) {
+	config.SetUpMockConfig(t)
+	var ctx context.Context
+	events := getMockPluginContactInitializer()
+	req := &eventsproto.EventRequest{
+		SessionToken:        "validToken",
+		EventSubscriptionID: "61de0110-c35a-4859-984c-072d6c5a32e1",
+	}
+	resp, err := events.GetUndeliveredEventsCollection(ctx, req)
+	assert.Nil(t, err, "There should be no error")
+	assert.Equal(t, int(resp.StatusCode), http.StatusOK, "Status code should be StatusOK.")
+
+	var collection evresponse.UndeliveredEventsResponse
+	json.Unmarshal(resp.Body, &collection)
+	assert.Equal(t, 2, collection.MembersCount, "There should be two undelivered events")
+
+	req.EventSubscriptionID = "81de0110"
+	resp, _ = events.GetUndeliveredEventsCollection(ctx, req)
+	assert.Equal(t, int(resp.StatusCode), http.StatusNotFound, "Status code should be StatusNotFound.")
+}
@@ -0,0 +1,74 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package managers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/lib-utilities/query"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+)
+
+// ExpandResponse expands the hyperlinks in the response body as requested
+// with $expand. The expanded resources are read from the BMC inventory saved
+// in the in-memory DB during the discovery.
+func (e *ExternalInterface) ExpandResponse(ctx context.Context, expand *query.Expand, resp response.RPC) response.RPC {
+	if expand == nil || resp.StatusCode != http.StatusOK {
+		return resp
+	}
+	data, err := json.Marshal(resp.Body)
+	if err != nil {
+		l.LogWithFields(ctx).Error("error while reading the response to be expanded: " + err.Error())
+		return resp
+	}
+	var body map[string]interface{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		l.LogWithFields(ctx).Error("error while reading the response to be expanded: " + err.Error())
+		return resp
+	}
+	expanded, exhausted := expand.Apply(body, e.getInventoryResource, query.MaxExpandedResources)
+	if exhausted {
+		l.LogWithFields(ctx).Warnf("expanded the maximum of %d resources, rest of the hyperlinks are not expanded", query.MaxExpandedResources)
+	}
+	l.LogWithFields(ctx).Debugf("expanded %d resources in the response", expanded)
+	resp.Body = body
+	return resp
+}
+
+// getInventoryResource reads the resource from the inventory, looking up the
+// tables in which the resource could have been saved
+func (e *ExternalInterface) getInventoryResource(oid string) (map[string]interface{}, error) {
+	for _, table := range common.GetInventoryTableNames(oid) {
+		data, err := e.DB.GetResource(table, oid)
+		if err != nil {
+			if err.ErrNo() == errors.DBKeyNotFound {
+				continue
+			}
+			return nil, fmt.Errorf("error while reading %s: %s", oid, err.Error())
+		}
+		var resource map[string]interface{}
+		if err := json.Unmarshal([]byte(data), &resource); err != nil {
+			return nil, err
+		}
+		return resource, nil
+	}
+	return nil, fmt.Errorf("%s is not found in the inventory", oid)
+}
@@ -0,0 +1,65 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package managers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	"github.com/ODIM-Project/ODIM/lib-utilities/query"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+)
+
+func TestExternalInterface_ExpandResponse(t *testing.T) {
+	e := mockGetExternalInterface()
+	e.DB.GetResource = func(table, key string) (string, *errors.Error) {
+		if table == "EthernetInterfacesCollection" && key == "/redfish/v1/Managers/uuid.1/EthernetInterfaces" {
+			return `{"@odata.id": "/redfish/v1/Managers/uuid.1/EthernetInterfaces", "Members": []}`, nil
+		}
+		return "", errors.PackError(errors.DBKeyNotFound, "not found")
+	}
+	manager := func() response.RPC {
+		return response.RPC{
+			StatusCode: http.StatusOK,
+			Body: map[string]interface{}{
+				"@odata.id":          "/redfish/v1/Managers/uuid.1",
+				"EthernetInterfaces": map[string]interface{}{"@odata.id": "/redfish/v1/Managers/uuid.1/EthernetInterfaces"},
+				"LogServices":        map[string]interface{}{"@odata.id": "/redfish/v1/Managers/uuid.1/LogServices"},
+			},
+		}
+	}
+	ctx := context.Background()
+
+	resp := e.ExpandResponse(ctx, nil, manager())
+	if _, ok := resp.Body.(map[string]interface{})["EthernetInterfaces"].(map[string]interface{})["Members"]; ok {
+		t.Error("ExpandResponse() expanded the response without $expand")
+	}
+
+	resp = e.ExpandResponse(ctx, &query.Expand{Type: query.ExpandSubordinate, Levels: 1}, manager())
+	body := resp.Body.(map[string]interface{})
+	if _, ok := body["EthernetInterfaces"].(map[string]interface{})["Members"]; !ok {
+		t.Errorf("EthernetInterfaces is not expanded: %v", body)
+	}
+	if len(body["LogServices"].(map[string]interface{})) != 1 {
+		t.Errorf("LogServices = %v, want the hyperlink", body["LogServices"])
+	}
+
+	failed := response.RPC{StatusCode: http.StatusNotFound, Body: "error"}
+	if resp = e.ExpandResponse(ctx, &query.Expand{Type: query.ExpandAll, Levels: 1}, failed); resp.Body != "error" {
+		t.Errorf("ExpandResponse() = %v, want the response as is", resp.Body)
+	}
+}
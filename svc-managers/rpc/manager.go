@@ -23,6 +23,7 @@ import (
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
 	managersproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/managers"
+	"github.com/ODIM-Project/ODIM/lib-utilities/query"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
 	"github.com/ODIM-Project/ODIM/svc-managers/managers"
 )
@@ -58,7 +59,13 @@ func (m *Managers) GetManagersCollection(ctx context.Context, req *managersproto
 		resp.Header = authResp.Header
 		return &resp, nil
 	}
+	uri, expand, qerr := query.SplitExpand(req.URL)
+	if qerr != nil {
+		return queryErrorResponse(ctx, qerr), nil
+	}
+	req.URL = uri
 	data, _ := m.EI.GetManagersCollection(ctx, req)
+	data = m.EI.ExpandResponse(ctx, expand, data)
 	resp.Header = data.Header
 	resp.StatusCode = data.StatusCode
 	resp.StatusMessage = data.StatusMessage
@@ -90,7 +97,13 @@ func (m *Managers) GetManager(ctx context.Context, req *managersproto.ManagerReq
 		resp.Header = authResp.Header
 		return &resp, nil
 	}
+	uri, expand, qerr := query.SplitExpand(req.URL)
+	if qerr != nil {
+		return queryErrorResponse(ctx, qerr), nil
+	}
+	req.URL = uri
 	data := m.EI.GetManagers(ctx, req)
+	data = m.EI.ExpandResponse(ctx, expand, data)
 	resp.Header = data.Header
 	resp.StatusCode = data.StatusCode
 	resp.StatusMessage = data.StatusMessage
@@ -121,7 +134,13 @@ func (m *Managers) GetManagersResource(ctx context.Context, req *managersproto.M
 		resp.Header = authResp.Header
 		return &resp, nil
 	}
+	uri, expand, qerr := query.SplitExpand(req.URL)
+	if qerr != nil {
+		return queryErrorResponse(ctx, qerr), nil
+	}
+	req.URL = uri
 	data := m.EI.GetManagersResource(ctx, req)
+	data = m.EI.ExpandResponse(ctx, expand, data)
 	resp.Header = data.Header
 	resp.StatusCode = data.StatusCode
 	resp.StatusMessage = data.StatusMessage
@@ -190,6 +209,18 @@ func (m *Managers) VirtualMediaEject(ctx context.Context, req *managersproto.Man
 	return resp, nil
 }
 
+// queryErrorResponse returns the response for the invalid query parameters
+func queryErrorResponse(ctx context.Context, qerr *query.Error) *managersproto.ManagerResponse {
+	l.LogWithFields(ctx).Error(qerr.Error())
+	data := qerr.Response()
+	return &managersproto.ManagerResponse{
+		StatusCode:    data.StatusCode,
+		StatusMessage: data.StatusMessage,
+		Header:        data.Header,
+		Body:          generateResponse(ctx, data.Body),
+	}
+}
+
 func generateResponse(ctx context.Context, input interface{}) []byte {
 	bytes, err := json.Marshal(input)
 	if err != nil {
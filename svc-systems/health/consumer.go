@@ -0,0 +1,64 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package health
+
+import (
+	"context"
+	"encoding/json"
+
+	dc "github.com/ODIM-Project/ODIM/lib-messagebus/datacommunicator"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+)
+
+// Run seeds the health rollup and then subscribes to the health event queue
+// to which the event service publishes the events, for keeping it up to date
+func (t *Tracker) Run() {
+	ctx := context.WithValue(context.Background(), common.ThreadName, common.SystemService)
+	t.Seed(ctx)
+
+	config.TLSConfMutex.RLock()
+	messageBusType := config.Data.MessageBusConf.MessageBusType
+	messageBusConfigFilePath := config.Data.MessageBusConf.MessageBusConfigFilePath
+	topicName := config.Data.MessageBusConf.OdimHealthEventQueue
+	config.TLSConfMutex.RUnlock()
+	k, err := dc.Communicator(messageBusType, messageBusConfigFilePath, topicName)
+	if err != nil {
+		l.Log.Error("unable to connect to " + messageBusType + " for the health events: " + err.Error())
+		return
+	}
+	if err := k.Accept(func(data interface{}) {
+		t.consume(ctx, data)
+	}); err != nil {
+		l.Log.Error("unable to subscribe to " + topicName + ": " + err.Error())
+	}
+}
+
+// consume decodes the event message read from the message bus and updates
+// the health rollup with it
+func (t *Tracker) consume(ctx context.Context, data interface{}) {
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		l.Log.Error("error while reading the health event: " + err.Error())
+		return
+	}
+	var message common.MessageData
+	if err := json.Unmarshal(bytes, &message); err != nil {
+		l.Log.Error("error while reading the health event: " + err.Error())
+		return
+	}
+	t.HandleEvents(ctx, message)
+}
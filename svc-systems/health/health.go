@@ -0,0 +1,412 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package health keeps the health rollup of the systems and chassis up to
+// date with the events received from the devices, and builds the fleet
+// health summary from it
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-rest-client/pmbhandle"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/svc-systems/plugin"
+	"github.com/ODIM-Project/ODIM/svc-systems/scommon"
+	"github.com/ODIM-Project/ODIM/svc-systems/smodel"
+)
+
+const (
+	// SummaryURI is the URI of the fleet health summary
+	SummaryURI = "/redfish/v1/Systems/Oem/Odim/HealthSummary"
+
+	systemsPrefix = "/redfish/v1/Systems/"
+	chassisPrefix = "/redfish/v1/Chassis/"
+
+	healthOK       = "OK"
+	healthWarning  = "Warning"
+	healthCritical = "Critical"
+	unknown        = "Unknown"
+)
+
+// maxSwapAttempts is the number of times the health of a resource is read
+// again when another replica changed it while it was being saved
+const maxSwapAttempts = 5
+
+// resourceTables are the inventory tables of the tracked resources, which
+// are also used as their resource types
+var resourceTables = []string{"ComputerSystem", "Chassis"}
+
+// Tracker keeps the health of the systems and chassis, the number of them
+// in each health and power state, and the resources which reported a Warning
+// or Critical health
+type Tracker struct {
+	ContactClient         func(context.Context, string, string, string, string, interface{}, map[string]string) (*http.Response, error)
+	DevicePassword        func([]byte) ([]byte, error)
+	GetPluginStatus       func(context.Context, smodel.Plugin) bool
+	GetResourceFromDevice func(context.Context, scommon.ResourceInfoRequest, bool) (string, error)
+	PluginClientFactory   plugin.ClientFactory
+	DB                    DB
+}
+
+// DB holds the database functions used by the Tracker
+type DB struct {
+	GetAllKeysFromTable     func(string) ([]string, error)
+	GetResource             func(context.Context, string, string) (string, *errors.Error)
+	GetResourceHealth       func(string) (smodel.ResourceHealth, *errors.Error)
+	SwapResourceHealth      func(string, *smodel.ResourceHealth, *smodel.ResourceHealth, []string, []string) (bool, *errors.Error)
+	GetHealthCount          func(string) (int, *errors.Error)
+	SaveUnhealthyResource   func(smodel.UnhealthyResource) *errors.Error
+	DeleteUnhealthyResource func(string) *errors.Error
+	GetUnhealthyResources   func() ([]smodel.UnhealthyResource, *errors.Error)
+	GetAggregates           func() (map[string]smodel.Aggregate, *errors.Error)
+	AcquireHealthSeed       func() (bool, *errors.Error)
+}
+
+// NewTracker returns the Tracker, which reads the unmanaged racks using the
+// plugin clients created by pcf
+func NewTracker(pcf plugin.ClientFactory) *Tracker {
+	return &Tracker{
+		ContactClient:         pmbhandle.ContactPlugin,
+		DevicePassword:        common.DecryptWithPrivateKey,
+		GetPluginStatus:       scommon.GetPluginStatus,
+		GetResourceFromDevice: scommon.GetResourceInfoFromDevice,
+		PluginClientFactory:   pcf,
+		DB: DB{
+			GetAllKeysFromTable:     smodel.GetAllKeysFromTable,
+			GetResource:             smodel.GetResource,
+			GetResourceHealth:       smodel.GetResourceHealth,
+			SwapResourceHealth:      smodel.SwapResourceHealth,
+			GetHealthCount:          smodel.GetHealthCount,
+			SaveUnhealthyResource:   smodel.SaveUnhealthyResource,
+			DeleteUnhealthyResource: smodel.DeleteUnhealthyResource,
+			GetUnhealthyResources:   smodel.GetUnhealthyResources,
+			GetAggregates:           smodel.GetAggregates,
+			AcquireHealthSeed:       smodel.AcquireHealthSeed,
+		},
+	}
+}
+
+// resourceStatus has the properties of a system or chassis which are tracked
+type resourceStatus struct {
+	Status struct {
+		Health       string `json:"Health"`
+		HealthRollup string `json:"HealthRollup"`
+	} `json:"Status"`
+	PowerState string `json:"PowerState"`
+}
+
+// Seed builds the health rollup from the systems and chassis already present
+// in the database. Only the first instance of the service seeds it, the
+// others rely on the events for keeping it up to date.
+func (t *Tracker) Seed(ctx context.Context) {
+	acquired, err := t.DB.AcquireHealthSeed()
+	if err != nil {
+		l.LogWithFields(ctx).Error("unable to seed the health rollup: " + err.Error())
+		return
+	}
+	if !acquired {
+		return
+	}
+	for _, table := range resourceTables {
+		keys, err := t.DB.GetAllKeysFromTable(table)
+		if err != nil {
+			l.LogWithFields(ctx).Error("unable to seed the health rollup: " + err.Error())
+			continue
+		}
+		for _, key := range keys {
+			data, err := t.DB.GetResource(ctx, table, key)
+			if err != nil {
+				l.LogWithFields(ctx).Warn("unable to read " + key + " for seeding the health rollup: " + err.Error())
+				continue
+			}
+			t.update(ctx, key, getResourceHealth(table, data))
+		}
+	}
+}
+
+// HandleEvents updates the health rollup with the events received from the
+// devices
+func (t *Tracker) HandleEvents(ctx context.Context, message common.MessageData) {
+	for _, event := range message.Events {
+		if event.OriginOfCondition == nil || event.OriginOfCondition.Oid == "" {
+			continue
+		}
+		t.handleEvent(ctx, event)
+	}
+}
+
+func (t *Tracker) handleEvent(ctx context.Context, event common.Event) {
+	origin := strings.TrimSuffix(event.OriginOfCondition.Oid, "/")
+	table, uri := getTopLevelResource(origin)
+	if uri == "" {
+		return
+	}
+	if origin == uri && (event.EventType == "ResourceRemoved" || strings.HasSuffix(event.MessageID, "ResourceRemoved")) {
+		t.remove(ctx, uri)
+		return
+	}
+	health := getEventHealth(event.MessageID)
+	if origin != uri && health != "" {
+		t.updateComponent(ctx, origin, health, event)
+	}
+	t.refresh(ctx, table, uri, health)
+}
+
+// refresh reads the current status of the system or chassis from the device.
+// The health reported by the event is used when the device can't be reached.
+func (t *Tracker) refresh(ctx context.Context, table, uri, eventHealth string) {
+	data, err := t.getResourceFromDevice(ctx, table, uri)
+	if err == nil {
+		t.update(ctx, uri, getResourceHealth(table, data))
+		return
+	}
+	if _, dbErr := t.DB.GetResource(ctx, table, uri); dbErr != nil && dbErr.ErrNo() == errors.DBKeyNotFound {
+		// the resource is no longer part of the inventory
+		t.remove(ctx, uri)
+		return
+	}
+	l.LogWithFields(ctx).Warn("unable to read the status of " + uri + ": " + err.Error())
+	if eventHealth == "" {
+		return
+	}
+	record, dbErr := t.DB.GetResourceHealth(uri)
+	if dbErr != nil {
+		record = smodel.ResourceHealth{ResourceType: table, PowerState: unknown}
+	}
+	record.Health = eventHealth
+	t.update(ctx, uri, record)
+}
+
+func (t *Tracker) getResourceFromDevice(ctx context.Context, table, uri string) (string, error) {
+	requestData := strings.SplitN(uri[strings.LastIndex(uri, "/")+1:], ".", 2)
+	if len(requestData) <= 1 {
+		return "", errors.PackError(errors.UndefinedErrorType, "error: SystemUUID not found in "+uri)
+	}
+	req := scommon.ResourceInfoRequest{
+		URL:             uri,
+		UUID:            requestData[0],
+		SystemID:        requestData[1],
+		ContactClient:   t.ContactClient,
+		DevicePassword:  t.DevicePassword,
+		GetPluginStatus: t.GetPluginStatus,
+		ResourceName:    table,
+	}
+	// the event service refreshes the inventory, the status is only read here
+	return t.GetResourceFromDevice(ctx, req, false)
+}
+
+// update saves the health of the system or chassis, and moves it between the
+// counters of the health and power states when they changed
+func (t *Tracker) update(ctx context.Context, uri string, record smodel.ResourceHealth) {
+	old, exists, ok := t.swap(ctx, uri, &record)
+	if !ok {
+		return
+	}
+	if exists && old.Health == record.Health {
+		return
+	}
+	if isUnhealthy(record.Health) {
+		t.saveUnhealthyResource(ctx, smodel.UnhealthyResource{
+			OdataID: uri,
+			Health:  record.Health,
+			Since:   time.Now().UTC().Format(time.RFC3339),
+		})
+		return
+	}
+	if exists && isUnhealthy(old.Health) {
+		t.deleteUnhealthyResource(ctx, uri)
+	}
+}
+
+// updateComponent tracks the health of a component of the system or chassis
+// reported by the event
+func (t *Tracker) updateComponent(ctx context.Context, uri, health string, event common.Event) {
+	if !isUnhealthy(health) {
+		t.deleteUnhealthyResource(ctx, uri)
+		return
+	}
+	since := event.EventTimestamp
+	if since == "" {
+		since = time.Now().UTC().Format(time.RFC3339)
+	}
+	t.saveUnhealthyResource(ctx, smodel.UnhealthyResource{
+		OdataID: uri,
+		Health:  health,
+		Message: event.Message,
+		Since:   since,
+	})
+}
+
+// remove stops tracking the system or chassis and its components
+func (t *Tracker) remove(ctx context.Context, uri string) {
+	t.swap(ctx, uri, nil)
+	resources, err := t.DB.GetUnhealthyResources()
+	if err != nil {
+		l.LogWithFields(ctx).Error("unable to read the unhealthy resources: " + err.Error())
+		return
+	}
+	for _, resource := range resources {
+		if isSubordinate(resource.OdataID, uri) {
+			t.deleteUnhealthyResource(ctx, resource.OdataID)
+		}
+	}
+}
+
+// swap replaces the tracked health of the system or chassis with record, or
+// deletes it when record is nil, and moves it between the counters in the
+// same step. The health is read again when another replica changed it in
+// the meantime. It returns the health replaced, whether there was one, and
+// whether the tracked health was changed.
+func (t *Tracker) swap(ctx context.Context, uri string, record *smodel.ResourceHealth) (smodel.ResourceHealth, bool, bool) {
+	for attempt := 0; attempt < maxSwapAttempts; attempt++ {
+		old, err := t.DB.GetResourceHealth(uri)
+		if err != nil && err.ErrNo() != errors.DBKeyNotFound {
+			l.LogWithFields(ctx).Error("unable to read the health of " + uri + ": " + err.Error())
+			return old, false, false
+		}
+		exists := err == nil
+		if !exists && record == nil || exists && record != nil && old == *record {
+			return old, exists, false
+		}
+		var oldRecord *smodel.ResourceHealth
+		var decrKeys, incrKeys []string
+		if exists {
+			oldRecord = &old
+			decrKeys = getCountKeys(old)
+		}
+		if record != nil {
+			incrKeys = getCountKeys(*record)
+		}
+		swapped, err := t.DB.SwapResourceHealth(uri, oldRecord, record, decrKeys, incrKeys)
+		if err != nil {
+			l.LogWithFields(ctx).Error("unable to save the health of " + uri + ": " + err.Error())
+			return old, exists, false
+		}
+		if swapped {
+			return old, exists, true
+		}
+	}
+	l.LogWithFields(ctx).Warn("unable to save the health of " + uri + ": it kept changing while being saved")
+	return smodel.ResourceHealth{}, false, false
+}
+
+// getCountKeys returns the counters of the health and power state of record
+func getCountKeys(record smodel.ResourceHealth) []string {
+	return []string{
+		getCountKey(record.ResourceType, "Health", record.Health),
+		getCountKey(record.ResourceType, "PowerState", record.PowerState),
+	}
+}
+
+func (t *Tracker) saveUnhealthyResource(ctx context.Context, resource smodel.UnhealthyResource) {
+	if err := t.DB.SaveUnhealthyResource(resource); err != nil {
+		l.LogWithFields(ctx).Error("unable to save the unhealthy resource " + resource.OdataID + ": " + err.Error())
+	}
+}
+
+func (t *Tracker) deleteUnhealthyResource(ctx context.Context, uri string) {
+	if err := t.DB.DeleteUnhealthyResource(uri); err != nil && err.ErrNo() != errors.DBKeyNotFound {
+		l.LogWithFields(ctx).Error("unable to delete the unhealthy resource " + uri + ": " + err.Error())
+	}
+}
+
+// getTopLevelResource returns the inventory table and the URI of the system
+// or chassis to which the resource belongs
+func getTopLevelResource(uri string) (string, string) {
+	for i, prefix := range []string{systemsPrefix, chassisPrefix} {
+		if !strings.HasPrefix(uri, prefix) {
+			continue
+		}
+		id := strings.SplitN(strings.TrimPrefix(uri, prefix), "/", 2)[0]
+		if id == "" {
+			return "", ""
+		}
+		return resourceTables[i], prefix + id
+	}
+	return "", ""
+}
+
+// getResourceHealth returns the tracked health of the system or chassis read
+// from its JSON representation. The worse of the health of the resource and
+// of its subordinate resources is tracked.
+func getResourceHealth(table, data string) smodel.ResourceHealth {
+	var status resourceStatus
+	json.Unmarshal([]byte(data), &status)
+	record := smodel.ResourceHealth{
+		ResourceType: table,
+		Health:       worst(status.Status.Health, status.Status.HealthRollup),
+		PowerState:   status.PowerState,
+	}
+	if record.Health == "" {
+		record.Health = unknown
+	}
+	if record.PowerState == "" {
+		record.PowerState = unknown
+	}
+	return record
+}
+
+// getEventHealth returns the health reported by the ResourceStatusChanged
+// events of the ResourceEvent registry
+func getEventHealth(messageID string) string {
+	for _, health := range []string{healthOK, healthWarning, healthCritical} {
+		if strings.HasSuffix(messageID, "ResourceStatusChanged"+health) {
+			return health
+		}
+	}
+	return ""
+}
+
+func getCountKey(resourceType, property, value string) string {
+	return resourceType + ":" + property + ":" + value
+}
+
+func isUnhealthy(health string) bool {
+	return health == healthWarning || health == healthCritical
+}
+
+func isSubordinate(uri, parent string) bool {
+	return uri == parent || strings.HasPrefix(uri, parent+"/")
+}
+
+// severity orders the health values, Unknown being the least severe
+func severity(health string) int {
+	switch health {
+	case healthCritical:
+		return 3
+	case healthWarning:
+		return 2
+	case healthOK:
+		return 1
+	}
+	return 0
+}
+
+// worst returns the most severe of the health values
+func worst(health ...string) string {
+	var result string
+	for _, h := range health {
+		if severity(h) > severity(result) {
+			result = h
+		}
+	}
+	return result
+}
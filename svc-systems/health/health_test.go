@@ -0,0 +1,349 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-systems/plugin"
+	"github.com/ODIM-Project/ODIM/svc-systems/scommon"
+	"github.com/ODIM-Project/ODIM/svc-systems/smodel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	systemURI  = "/redfish/v1/Systems/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1"
+	chassisURI = "/redfish/v1/Chassis/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1"
+)
+
+// mockDB keeps the tracked health in maps in place of the database
+type mockDB struct {
+	inventory map[string]map[string]string
+	health    map[string]smodel.ResourceHealth
+	counts    map[string]int
+	unhealthy map[string]smodel.UnhealthyResource
+	seeded    bool
+}
+
+func newMockTracker(devices map[string]string) (*Tracker, *mockDB) {
+	db := &mockDB{
+		inventory: map[string]map[string]string{
+			"ComputerSystem": {systemURI: `{"Status":{"Health":"OK","HealthRollup":"OK"},"PowerState":"On"}`},
+			"Chassis":        {chassisURI: `{"Status":{"Health":"OK"}}`},
+		},
+		health:    map[string]smodel.ResourceHealth{},
+		counts:    map[string]int{},
+		unhealthy: map[string]smodel.UnhealthyResource{},
+	}
+	t := &Tracker{
+		GetResourceFromDevice: func(ctx context.Context, req scommon.ResourceInfoRequest, saveRequired bool) (string, error) {
+			if data, ok := devices[req.URL]; ok {
+				return data, nil
+			}
+			return "", fmt.Errorf("device is not reachable")
+		},
+		DB: DB{
+			GetAllKeysFromTable: func(table string) ([]string, error) {
+				var keys []string
+				for key := range db.inventory[table] {
+					keys = append(keys, key)
+				}
+				return keys, nil
+			},
+			GetResource: func(ctx context.Context, table, key string) (string, *errors.Error) {
+				if data, ok := db.inventory[table][key]; ok {
+					return data, nil
+				}
+				return "", errors.PackError(errors.DBKeyNotFound, "not found")
+			},
+			GetResourceHealth: func(uri string) (smodel.ResourceHealth, *errors.Error) {
+				if health, ok := db.health[uri]; ok {
+					return health, nil
+				}
+				return smodel.ResourceHealth{}, errors.PackError(errors.DBKeyNotFound, "not found")
+			},
+			SwapResourceHealth: func(uri string, old, new *smodel.ResourceHealth, decrKeys, incrKeys []string) (bool, *errors.Error) {
+				current, exists := db.health[uri]
+				if exists != (old != nil) || exists && current != *old {
+					return false, nil
+				}
+				if new == nil {
+					delete(db.health, uri)
+				} else {
+					db.health[uri] = *new
+				}
+				for _, key := range decrKeys {
+					db.counts[key]--
+				}
+				for _, key := range incrKeys {
+					db.counts[key]++
+				}
+				return true, nil
+			},
+			GetHealthCount: func(key string) (int, *errors.Error) {
+				return db.counts[key], nil
+			},
+			SaveUnhealthyResource: func(resource smodel.UnhealthyResource) *errors.Error {
+				db.unhealthy[resource.OdataID] = resource
+				return nil
+			},
+			DeleteUnhealthyResource: func(uri string) *errors.Error {
+				if _, ok := db.unhealthy[uri]; !ok {
+					return errors.PackError(errors.DBKeyNotFound, "not found")
+				}
+				delete(db.unhealthy, uri)
+				return nil
+			},
+			GetUnhealthyResources: func() ([]smodel.UnhealthyResource, *errors.Error) {
+				var resources []smodel.UnhealthyResource
+				for _, resource := range db.unhealthy {
+					resources = append(resources, resource)
+				}
+				return resources, nil
+			},
+			GetAggregates: func() (map[string]smodel.Aggregate, *errors.Error) {
+				var aggregate smodel.Aggregate
+				aggregate.Elements = append(aggregate.Elements, struct {
+					OdataID string `json:"@odata.id"`
+				}{OdataID: systemURI})
+				return map[string]smodel.Aggregate{"/redfish/v1/AggregationService/Aggregates/1": aggregate}, nil
+			},
+			AcquireHealthSeed: func() (bool, *errors.Error) {
+				acquired := !db.seeded
+				db.seeded = true
+				return acquired, nil
+			},
+		},
+	}
+	return t, db
+}
+
+func statusEvent(origin, messageID string) common.MessageData {
+	return common.MessageData{
+		Events: []common.Event{
+			{
+				EventType:         "ResourceEvent",
+				MessageID:         messageID,
+				Message:           "The health of the resource changed",
+				EventTimestamp:    "2022-01-01T00:00:00Z",
+				OriginOfCondition: &common.Link{Oid: origin},
+			},
+		},
+	}
+}
+
+func TestSeed(t *testing.T) {
+	tracker, db := newMockTracker(nil)
+	tracker.Seed(context.Background())
+	assert.Equal(t, smodel.ResourceHealth{ResourceType: "ComputerSystem", Health: "OK", PowerState: "On"}, db.health[systemURI])
+	assert.Equal(t, smodel.ResourceHealth{ResourceType: "Chassis", Health: "OK", PowerState: "Unknown"}, db.health[chassisURI])
+	assert.Equal(t, 1, db.counts["ComputerSystem:Health:OK"])
+	assert.Equal(t, 1, db.counts["ComputerSystem:PowerState:On"])
+	assert.Equal(t, 1, db.counts["Chassis:Health:OK"])
+
+	// the health rollup is seeded only once
+	db.inventory["ComputerSystem"][systemURI+"0"] = `{"Status":{"Health":"OK"}}`
+	tracker.Seed(context.Background())
+	assert.Equal(t, 1, db.counts["ComputerSystem:Health:OK"])
+}
+
+func TestHandleEvents(t *testing.T) {
+	devices := map[string]string{
+		systemURI: `{"Status":{"Health":"OK","HealthRollup":"Critical"},"PowerState":"On"}`,
+	}
+	tracker, db := newMockTracker(devices)
+	ctx := context.Background()
+	tracker.Seed(ctx)
+
+	component := systemURI + "/Processors/1"
+	tracker.HandleEvents(ctx, statusEvent(component, "ResourceEvent.1.0.3.ResourceStatusChangedCritical"))
+	assert.Equal(t, "Critical", db.health[systemURI].Health)
+	assert.Equal(t, 0, db.counts["ComputerSystem:Health:OK"])
+	assert.Equal(t, 1, db.counts["ComputerSystem:Health:Critical"])
+	assert.Equal(t, 1, db.counts["ComputerSystem:PowerState:On"])
+	assert.Equal(t, "Critical", db.unhealthy[component].Health)
+	assert.Equal(t, "2022-01-01T00:00:00Z", db.unhealthy[component].Since)
+	assert.Equal(t, "Critical", db.unhealthy[systemURI].Health)
+
+	// the same event doesn't count the system again
+	tracker.HandleEvents(ctx, statusEvent(component, "ResourceEvent.1.0.3.ResourceStatusChangedCritical"))
+	assert.Equal(t, 1, db.counts["ComputerSystem:Health:Critical"])
+
+	devices[systemURI] = `{"Status":{"Health":"OK","HealthRollup":"OK"},"PowerState":"Off"}`
+	tracker.HandleEvents(ctx, statusEvent(component, "ResourceEvent.1.0.3.ResourceStatusChangedOK"))
+	assert.Equal(t, 1, db.counts["ComputerSystem:Health:OK"])
+	assert.Equal(t, 0, db.counts["ComputerSystem:Health:Critical"])
+	assert.Equal(t, 0, db.counts["ComputerSystem:PowerState:On"])
+	assert.Equal(t, 1, db.counts["ComputerSystem:PowerState:Off"])
+	assert.Empty(t, db.unhealthy)
+}
+
+func TestHandleEventsWhenDeviceIsNotReachable(t *testing.T) {
+	tracker, db := newMockTracker(nil)
+	ctx := context.Background()
+	tracker.Seed(ctx)
+
+	tracker.HandleEvents(ctx, statusEvent(chassisURI, "ResourceEvent.1.0.3.ResourceStatusChangedWarning"))
+	assert.Equal(t, "Warning", db.health[chassisURI].Health)
+	assert.Equal(t, 0, db.counts["Chassis:Health:OK"])
+	assert.Equal(t, 1, db.counts["Chassis:Health:Warning"])
+	assert.Equal(t, "Warning", db.unhealthy[chassisURI].Health)
+
+	// the events without health don't change the tracked health
+	tracker.HandleEvents(ctx, statusEvent(chassisURI+"/Thermal", "Alert.1.0.TemperatureAboveThreshold"))
+	assert.Equal(t, 1, db.counts["Chassis:Health:Warning"])
+
+	// the resources removed from the inventory are no longer tracked
+	delete(db.inventory["Chassis"], chassisURI)
+	tracker.HandleEvents(ctx, statusEvent(chassisURI+"/Power", "ResourceEvent.1.0.3.ResourceChanged"))
+	assert.NotContains(t, db.health, chassisURI)
+	assert.Equal(t, 0, db.counts["Chassis:Health:Warning"])
+	assert.Empty(t, db.unhealthy)
+}
+
+func TestHandleEventsWhenHealthChangesMeanwhile(t *testing.T) {
+	devices := map[string]string{
+		systemURI: `{"Status":{"Health":"Warning"},"PowerState":"On"}`,
+	}
+	tracker, db := newMockTracker(devices)
+	ctx := context.Background()
+	tracker.Seed(ctx)
+
+	// another replica tracks the system as Critical before the swap
+	swap := tracker.DB.SwapResourceHealth
+	tracker.DB.SwapResourceHealth = func(uri string, old, new *smodel.ResourceHealth, decrKeys, incrKeys []string) (bool, *errors.Error) {
+		tracker.DB.SwapResourceHealth = swap
+		critical := smodel.ResourceHealth{ResourceType: "ComputerSystem", Health: "Critical", PowerState: "On"}
+		swap(uri, old, &critical, decrKeys, []string{"ComputerSystem:Health:Critical", "ComputerSystem:PowerState:On"})
+		return swap(uri, old, new, decrKeys, incrKeys)
+	}
+	tracker.HandleEvents(ctx, statusEvent(systemURI, "ResourceEvent.1.0.3.ResourceStatusChangedWarning"))
+	assert.Equal(t, "Warning", db.health[systemURI].Health)
+	assert.Equal(t, 0, db.counts["ComputerSystem:Health:OK"])
+	assert.Equal(t, 0, db.counts["ComputerSystem:Health:Critical"])
+	assert.Equal(t, 1, db.counts["ComputerSystem:Health:Warning"])
+	assert.Equal(t, 1, db.counts["ComputerSystem:PowerState:On"])
+}
+func TestHandleEventsForRemovedResource(t *testing.T) {
+	tracker, db := newMockTracker(nil)
+	ctx := context.Background()
+	tracker.Seed(ctx)
+	tracker.HandleEvents(ctx, statusEvent(systemURI+"/Memory/1", "ResourceEvent.1.0.3.ResourceStatusChangedCritical"))
+	assert.Contains(t, db.unhealthy, systemURI+"/Memory/1")
+
+	message := statusEvent(systemURI, "ResourceEvent.1.0.3.ResourceRemoved")
+	message.Events[0].EventType = "ResourceRemoved"
+	tracker.HandleEvents(ctx, message)
+	assert.NotContains(t, db.health, systemURI)
+	assert.Equal(t, 0, db.counts["ComputerSystem:Health:Critical"])
+	assert.Equal(t, 0, db.counts["ComputerSystem:Health:OK"])
+	assert.Equal(t, 0, db.counts["ComputerSystem:PowerState:On"])
+	assert.Empty(t, db.unhealthy)
+}
+
+func TestGetHealthSummary(t *testing.T) {
+	devices := map[string]string{
+		systemURI: `{"Status":{"Health":"OK","HealthRollup":"Critical"},"PowerState":"On"}`,
+	}
+	tracker, _ := newMockTracker(devices)
+	ctx := context.Background()
+	tracker.Seed(ctx)
+	tracker.HandleEvents(ctx, statusEvent(systemURI+"/Processors/1", "ResourceEvent.1.0.3.ResourceStatusChangedCritical"))
+
+	rack := "/redfish/v1/Chassis/1be678f0-86dd-58ac-ac38-16bf0f6dafee"
+	rackGroup := "/redfish/v1/Chassis/f4e24c1c-dd2f-5a17-91b7-71620eb070df"
+	collection := new(plugin.ClientMock)
+	collection.On("Get", "/redfish/v1/Chassis", mock.Anything).Return(response.RPC{
+		StatusCode: http.StatusOK,
+		Body:       []byte(`{"Members":[{"@odata.id":"` + rack + `"},{"@odata.id":"` + rackGroup + `"}]}`),
+	})
+	chassis := new(plugin.ClientMock)
+	chassis.On("Get", rack, mock.Anything).Return(response.RPC{
+		StatusCode: http.StatusOK,
+		Body:       []byte(`{"ChassisType":"Rack","Links":{"Contains":[{"@odata.id":"` + chassisURI + `"}]}}`),
+	})
+	chassis.On("Get", rackGroup, mock.Anything).Return(response.RPC{
+		StatusCode: http.StatusOK,
+		Body:       []byte(`{"ChassisType":"RackGroup","Links":{"Contains":[{"@odata.id":"` + rack + `"}]}}`),
+	})
+	calls := 0
+	tracker.PluginClientFactory = func(name string) (plugin.Client, *errors.Error) {
+		calls++
+		if calls == 1 {
+			return collection, nil
+		}
+		return chassis, nil
+	}
+
+	resp := tracker.GetHealthSummary(ctx)
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode))
+	summary := resp.Body.(Summary)
+	assert.Equal(t, SummaryURI, summary.OdataID)
+	assert.Equal(t, "Critical", summary.HealthRollup)
+	assert.Equal(t, 1, summary.Systems.Count)
+	assert.Equal(t, 1, summary.Systems.Health["Critical"])
+	assert.Equal(t, 1, summary.Systems.PowerState["On"])
+	assert.Equal(t, 1, summary.Chassis.Health["OK"])
+	assert.Equal(t, 1, summary.Chassis.PowerState["Unknown"])
+	assert.Equal(t, []Rollup{{OdataID: "/redfish/v1/AggregationService/Aggregates/1", HealthRollup: "Critical"}}, summary.Aggregates)
+	assert.Equal(t, []Rollup{
+		{OdataID: rack, ChassisType: "Rack", HealthRollup: "OK"},
+		{OdataID: rackGroup, ChassisType: "RackGroup", HealthRollup: "OK"},
+	}, summary.Racks)
+	if assert.Equal(t, 2, len(summary.CriticalComponents)) {
+		assert.Equal(t, systemURI, summary.CriticalComponents[0].OdataID)
+		assert.Equal(t, systemURI+"/Processors/1", summary.CriticalComponents[1].OdataID)
+	}
+
+	// the rollup of the racks follows the chassis they contain
+	tracker.HandleEvents(ctx, statusEvent(chassisURI, "ResourceEvent.1.0.3.ResourceStatusChangedWarning"))
+	calls = 0
+	summary = tracker.GetHealthSummary(ctx).Body.(Summary)
+	assert.Equal(t, "Warning", summary.Racks[0].HealthRollup)
+	assert.Equal(t, "Warning", summary.Racks[1].HealthRollup)
+}
+
+func TestGetTopLevelResource(t *testing.T) {
+	tests := []struct {
+		uri   string
+		table string
+		want  string
+	}{
+		{systemURI, "ComputerSystem", systemURI},
+		{systemURI + "/Storage/1/Drives/0", "ComputerSystem", systemURI},
+		{chassisURI + "/Thermal", "Chassis", chassisURI},
+		{"/redfish/v1/Managers/1", "", ""},
+		{"/redfish/v1/Systems/", "", ""},
+	}
+	for _, tt := range tests {
+		table, uri := getTopLevelResource(tt.uri)
+		assert.Equal(t, tt.table, table, tt.uri)
+		assert.Equal(t, tt.want, uri, tt.uri)
+	}
+}
+
+func TestWorst(t *testing.T) {
+	assert.Equal(t, "", worst())
+	assert.Equal(t, "OK", worst("", "OK"))
+	assert.Equal(t, "Warning", worst("OK", "Warning", "Unknown"))
+	assert.Equal(t, "Critical", worst("Critical", "Warning"))
+}
@@ -0,0 +1,262 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-systems/plugin"
+	"github.com/ODIM-Project/ODIM/svc-systems/smodel"
+	"github.com/ODIM-Project/ODIM/svc-systems/sresponse"
+)
+
+var (
+	healthStates = []string{healthOK, healthWarning, healthCritical, unknown}
+	powerStates  = []string{"On", "Off", "PoweringOn", "PoweringOff", "Paused", unknown}
+)
+
+// Summary is the fleet health summary
+type Summary struct {
+	OdataContext       string                     `json:"@odata.context"`
+	OdataID            string                     `json:"@odata.id"`
+	OdataType          string                     `json:"@odata.type"`
+	ID                 string                     `json:"Id"`
+	Name               string                     `json:"Name"`
+	Description        string                     `json:"Description"`
+	HealthRollup       string                     `json:"HealthRollup"`
+	Systems            ResourceCounts             `json:"Systems"`
+	Chassis            ResourceCounts             `json:"Chassis"`
+	Aggregates         []Rollup                   `json:"Aggregates"`
+	Racks              []Rollup                   `json:"Racks"`
+	CriticalComponents []smodel.UnhealthyResource `json:"CriticalComponents"`
+}
+
+// ResourceCounts has the number of resources in each health and power state
+type ResourceCounts struct {
+	Count      int            `json:"Count"`
+	Health     map[string]int `json:"Health"`
+	PowerState map[string]int `json:"PowerState"`
+}
+
+// Rollup is the health rollup of an aggregate or an unmanaged rack
+type Rollup struct {
+	OdataID      string `json:"@odata.id"`
+	ChassisType  string `json:"ChassisType,omitempty"`
+	HealthRollup string `json:"HealthRollup"`
+}
+
+// rackChassis has the properties of the RackGroup and Rack chassis of the
+// unmanaged racks plugin which are used for the rollup
+type rackChassis struct {
+	OdataID     string `json:"@odata.id"`
+	ChassisType string `json:"ChassisType"`
+	Links       struct {
+		Contains []dmtf.Link `json:"Contains"`
+	} `json:"Links"`
+}
+
+// GetHealthSummary returns the fleet health summary built from the tracked
+// health, without reading the inventory of the systems and chassis
+func (t *Tracker) GetHealthSummary(ctx context.Context) response.RPC {
+	unhealthy, err := t.DB.GetUnhealthyResources()
+	if err != nil {
+		errorMessage := "error while trying to read the unhealthy resources: " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	sort.Slice(unhealthy, func(i, j int) bool { return unhealthy[i].OdataID < unhealthy[j].OdataID })
+
+	summary := Summary{
+		OdataContext:       "/redfish/v1/$metadata#OdimHealthSummary.OdimHealthSummary",
+		OdataID:            SummaryURI,
+		OdataType:          "#OdimHealthSummary.v1_0_0.OdimHealthSummary",
+		ID:                 "HealthSummary",
+		Name:               "Fleet Health Summary",
+		Description:        "Health and power state of the systems and chassis managed by ODIM",
+		Aggregates:         []Rollup{},
+		Racks:              []Rollup{},
+		CriticalComponents: []smodel.UnhealthyResource{},
+	}
+	for _, resource := range unhealthy {
+		if resource.Health == healthCritical {
+			summary.CriticalComponents = append(summary.CriticalComponents, resource)
+		}
+	}
+	if summary.Systems, err = t.getResourceCounts("ComputerSystem"); err != nil {
+		errorMessage := "error while trying to read the health counts: " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	if summary.Chassis, err = t.getResourceCounts("Chassis"); err != nil {
+		errorMessage := "error while trying to read the health counts: " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	summary.HealthRollup = getCountsRollup(summary.Systems, summary.Chassis)
+
+	aggregates, err := t.DB.GetAggregates()
+	if err != nil {
+		l.LogWithFields(ctx).Warn("unable to read the aggregates for the health summary: " + err.Error())
+	}
+	for uri, aggregate := range aggregates {
+		elements := make([]string, 0, len(aggregate.Elements))
+		for _, element := range aggregate.Elements {
+			elements = append(elements, element.OdataID)
+		}
+		summary.Aggregates = append(summary.Aggregates, Rollup{
+			OdataID:      uri,
+			HealthRollup: getElementsRollup(elements, unhealthy),
+		})
+	}
+	sort.Slice(summary.Aggregates, func(i, j int) bool { return summary.Aggregates[i].OdataID < summary.Aggregates[j].OdataID })
+	summary.Racks = t.getRackRollups(ctx, unhealthy)
+
+	return response.RPC{
+		StatusCode:    http.StatusOK,
+		StatusMessage: response.Success,
+		Header: map[string]string{
+			"Link": "</redfish/v1/SchemaStore/en/OdimHealthSummary.json>; rel=describedby",
+		},
+		Body: summary,
+	}
+}
+
+func (t *Tracker) getResourceCounts(resourceType string) (ResourceCounts, *errors.Error) {
+	counts := ResourceCounts{
+		Health:     make(map[string]int, len(healthStates)),
+		PowerState: make(map[string]int, len(powerStates)),
+	}
+	for _, health := range healthStates {
+		count, err := t.DB.GetHealthCount(getCountKey(resourceType, "Health", health))
+		if err != nil {
+			return counts, err
+		}
+		counts.Health[health] = count
+		counts.Count += count
+	}
+	for _, powerState := range powerStates {
+		count, err := t.DB.GetHealthCount(getCountKey(resourceType, "PowerState", powerState))
+		if err != nil {
+			return counts, err
+		}
+		counts.PowerState[powerState] = count
+	}
+	return counts, nil
+}
+
+// getRackRollups returns the health rollup of the RackGroup and Rack chassis
+// of the unmanaged racks plugin, which is the worst health of the chassis they
+// contain
+func (t *Tracker) getRackRollups(ctx context.Context, unhealthy []smodel.UnhealthyResource) []Rollup {
+	rollups := []Rollup{}
+	if t.PluginClientFactory == nil {
+		return rollups
+	}
+	pc, err := t.PluginClientFactory("URP*")
+	if err != nil {
+		l.LogWithFields(ctx).Warn("unable to read the unmanaged racks for the health summary: " + err.Error())
+		return rollups
+	}
+	resp := pc.Get(ctx, "/redfish/v1/Chassis", plugin.AggregateResults)
+	if resp.StatusCode != http.StatusOK {
+		return rollups
+	}
+	var collection sresponse.Collection
+	if err := json.Unmarshal(resp.Body.([]byte), &collection); err != nil {
+		l.LogWithFields(ctx).Warn("unable to read the unmanaged racks for the health summary: " + err.Error())
+		return rollups
+	}
+	racks := make(map[string]rackChassis, len(collection.Members))
+	for _, member := range collection.Members {
+		// the collector of a client is set by the first call, so each call uses a new client
+		pc, err := t.PluginClientFactory("URP*")
+		if err != nil {
+			continue
+		}
+		resp := pc.Get(ctx, member.Oid)
+		if resp.StatusCode != http.StatusOK {
+			continue
+		}
+		var rack rackChassis
+		if err := json.Unmarshal(resp.Body.([]byte), &rack); err != nil {
+			continue
+		}
+		if rack.ChassisType == "RackGroup" || rack.ChassisType == "Rack" {
+			racks[member.Oid] = rack
+		}
+	}
+	for uri, rack := range racks {
+		rollups = append(rollups, Rollup{
+			OdataID:      uri,
+			ChassisType:  rack.ChassisType,
+			HealthRollup: getRackRollup(uri, racks, unhealthy, map[string]bool{}),
+		})
+	}
+	sort.Slice(rollups, func(i, j int) bool { return rollups[i].OdataID < rollups[j].OdataID })
+	return rollups
+}
+
+// getRackRollup returns the health rollup of the rack, following the racks
+// contained by a RackGroup
+func getRackRollup(uri string, racks map[string]rackChassis, unhealthy []smodel.UnhealthyResource, visited map[string]bool) string {
+	visited[uri] = true
+	var chassis []string
+	health := healthOK
+	for _, link := range racks[uri].Links.Contains {
+		if _, ok := racks[link.Oid]; ok {
+			if !visited[link.Oid] {
+				health = worst(health, getRackRollup(link.Oid, racks, unhealthy, visited))
+			}
+			continue
+		}
+		chassis = append(chassis, link.Oid)
+	}
+	return worst(health, getElementsRollup(chassis, unhealthy))
+}
+
+// getElementsRollup returns the worst health reported by the elements or
+// their components
+func getElementsRollup(elements []string, unhealthy []smodel.UnhealthyResource) string {
+	health := healthOK
+	for _, element := range elements {
+		for _, resource := range unhealthy {
+			if isSubordinate(resource.OdataID, element) {
+				health = worst(health, resource.Health)
+			}
+		}
+	}
+	return health
+}
+
+// getCountsRollup returns the worst health among the counted resources
+func getCountsRollup(counts ...ResourceCounts) string {
+	health := healthOK
+	for _, c := range counts {
+		for _, state := range []string{healthWarning, healthCritical} {
+			if c.Health[state] > 0 {
+				health = worst(health, state)
+			}
+		}
+	}
+	return health
+}
@@ -27,6 +27,7 @@ import (
 	systemsproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/systems"
 	"github.com/ODIM-Project/ODIM/lib-utilities/services"
 	"github.com/ODIM-Project/ODIM/svc-systems/chassis"
+	"github.com/ODIM-Project/ODIM/svc-systems/health"
 	"github.com/ODIM-Project/ODIM/svc-systems/plugin"
 	"github.com/ODIM-Project/ODIM/svc-systems/rpc"
 	"github.com/ODIM-Project/ODIM/svc-systems/scommon"
@@ -110,10 +111,12 @@ func registerHandler() {
 	systemRPC.CreateTask = services.CreateTask
 	systemRPC.UpdateTask = systems.UpdateTaskData
 
+	pcf := plugin.NewClientFactory(config.Data.URLTranslation)
 	systemRPC.EI = systems.GetExternalInterface()
+	systemRPC.HealthTracker = health.NewTracker(pcf)
 	systemsproto.RegisterSystemsServer(services.ODIMService.Server(), systemRPC)
+	go systemRPC.HealthTracker.Run()
 
-	pcf := plugin.NewClientFactory(config.Data.URLTranslation)
 	chassisRPC := rpc.NewChassisRPC(
 		services.IsAuthorized,
 		chassis.NewCreateHandler(pcf),
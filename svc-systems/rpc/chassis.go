@@ -26,6 +26,7 @@ import (
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
 	chassisproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/chassis"
+	"github.com/ODIM-Project/ODIM/lib-utilities/query"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
 	"github.com/ODIM-Project/ODIM/svc-systems/chassis"
 	"github.com/ODIM-Project/ODIM/svc-systems/scommon"
@@ -147,13 +148,19 @@ func (cha *ChassisRPC) GetChassisResource(ctx context.Context, req *chassisproto
 		rewrite(ctx, authResp, &resp)
 		return &resp, nil
 	}
+	uri, expand, qerr := query.SplitExpand(req.URL)
+	if qerr != nil {
+		rewrite(ctx, qerr.Response(), &resp)
+		return &resp, nil
+	}
+	req.URL = uri
 	var pc = chassis.PluginContact{
 		ContactClient:   pmbhandle.ContactPlugin,
 		DecryptPassword: common.DecryptWithPrivateKey,
 		GetPluginStatus: scommon.GetPluginStatus,
 	}
 	data, _ := pc.GetChassisResource(ctx, req)
-	rewrite(ctx, data, &resp)
+	rewrite(ctx, expandResponse(ctx, expand, data), &resp)
 	l.LogWithFields(ctx).Debugf("outgoing response for get chassisResource : %s", string(resp.Body))
 	return &resp, nil
 }
@@ -168,7 +175,11 @@ func (cha *ChassisRPC) GetChassisCollection(ctx context.Context, req *chassispro
 	l.LogWithFields(ctx).Debugf("incoming GetChassisCollection request with %s", req.URL)
 	var resp chassisproto.GetChassisResponse
 	r := auth(ctx, cha.IsAuthorizedRPC, req.SessionToken, []string{common.PrivilegeLogin}, func() response.RPC {
-		return cha.GetCollectionHandler.Handle(ctx)
+		_, expand, qerr := query.SplitExpand(req.URL)
+		if qerr != nil {
+			return qerr.Response()
+		}
+		return expandResponse(ctx, expand, cha.GetCollectionHandler.Handle(ctx))
 	})
 	rewrite(ctx, r, &resp)
 	l.LogWithFields(ctx).Debugf("outgoing response Get ChassisCollection : %s", string(resp.Body))
@@ -187,7 +198,12 @@ func (cha *ChassisRPC) GetChassisInfo(ctx context.Context, req *chassisproto.Get
 	l.LogWithFields(ctx).Debugf("incoming GetChassisInfo request with %s", req.URL)
 	var resp chassisproto.GetChassisResponse
 	r := auth(ctx, cha.IsAuthorizedRPC, req.SessionToken, []string{common.PrivilegeLogin}, func() response.RPC {
-		return cha.GetHandler.Handle(ctx, req)
+		uri, expand, qerr := query.SplitExpand(req.URL)
+		if qerr != nil {
+			return qerr.Response()
+		}
+		req.URL = uri
+		return expandResponse(ctx, expand, cha.GetHandler.Handle(ctx, req))
 	})
 
 	rewrite(ctx, r, &resp)
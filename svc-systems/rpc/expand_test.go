@@ -0,0 +1,79 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	"github.com/ODIM-Project/ODIM/lib-utilities/query"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-systems/smodel"
+)
+
+func mockGetInventoryResource(ctx context.Context, table, key string) (string, *errors.Error) {
+	switch {
+	case table == "ProcessorsCollection" && key == "/redfish/v1/Systems/uuid.1/Processors":
+		return `{"@odata.id": "/redfish/v1/Systems/uuid.1/Processors", "Members": [{"@odata.id": "/redfish/v1/Systems/uuid.1/Processors/1"}]}`, nil
+	case table == "Processors" && key == "/redfish/v1/Systems/uuid.1/Processors/1":
+		return `{"@odata.id": "/redfish/v1/Systems/uuid.1/Processors/1", "Id": "1"}`, nil
+	case key == "/redfish/v1/Systems/uuid.1/Storage":
+		return "", errors.PackError(errors.UndefinedErrorType, "DB error")
+	}
+	return "", errors.PackError(errors.DBKeyNotFound, "not found")
+}
+
+func TestExpandResponse(t *testing.T) {
+	GetInventoryResourceFunc = mockGetInventoryResource
+	defer func() {
+		GetInventoryResourceFunc = smodel.GetResource
+	}()
+	system := func() response.RPC {
+		return response.RPC{
+			StatusCode: http.StatusOK,
+			Body: map[string]interface{}{
+				"@odata.id":  "/redfish/v1/Systems/uuid.1",
+				"Processors": map[string]string{"@odata.id": "/redfish/v1/Systems/uuid.1/Processors"},
+				"Storage":    map[string]string{"@odata.id": "/redfish/v1/Systems/uuid.1/Storage"},
+			},
+		}
+	}
+	ctx := context.Background()
+
+	resp := expandResponse(ctx, &query.Expand{Type: query.ExpandSubordinate, Levels: 2}, system())
+	body := resp.Body.(map[string]interface{})
+	processors, ok := body["Processors"].(map[string]interface{})
+	if !ok || processors["Members"] == nil {
+		t.Fatalf("Processors is not expanded: %v", body)
+	}
+	if member := processors["Members"].([]interface{})[0].(map[string]interface{}); member["Id"] != "1" {
+		t.Errorf("Processors/1 is not expanded with $levels=2: %v", member)
+	}
+	if len(body["Storage"].(map[string]interface{})) != 1 {
+		t.Errorf("Storage = %v, want the hyperlink", body["Storage"])
+	}
+
+	resp = expandResponse(ctx, nil, system())
+	if _, ok := resp.Body.(map[string]interface{})["Processors"].(map[string]string); !ok {
+		t.Error("expandResponse() changed the response without $expand")
+	}
+
+	failed := response.RPC{StatusCode: http.StatusNotFound, Body: "error"}
+	if resp = expandResponse(ctx, &query.Expand{Type: query.ExpandAll, Levels: 1}, failed); resp.Body != "error" {
+		t.Errorf("expandResponse() = %v, want the response as is", resp.Body)
+	}
+}
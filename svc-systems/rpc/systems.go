@@ -25,7 +25,9 @@ import (
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
 	systemsproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/systems"
+	"github.com/ODIM-Project/ODIM/lib-utilities/query"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-systems/health"
 	"github.com/ODIM-Project/ODIM/svc-systems/scommon"
 	"github.com/ODIM-Project/ODIM/svc-systems/systems"
 )
@@ -37,6 +39,7 @@ type Systems struct {
 	CreateTask         func(ctx context.Context, sessionUserName string) (string, error)
 	UpdateTask         func(ctx context.Context, task common.TaskData) error
 	EI                 *systems.ExternalInterface
+	HealthTracker      *health.Tracker
 }
 
 // GetSystemResource defines the operations which handles the RPC request response
@@ -60,13 +63,19 @@ func (s *Systems) GetSystemResource(ctx context.Context, req *systemsproto.GetSy
 		fillSystemProtoResponse(ctx, &resp, authResp)
 		return &resp, nil
 	}
+	uri, expand, qerr := query.SplitExpand(req.URL)
+	if qerr != nil {
+		fillSystemProtoResponse(ctx, &resp, qerr.Response())
+		return &resp, nil
+	}
+	req.URL = uri
 	var pc = systems.PluginContact{
 		ContactClient:   pmbhandle.ContactPlugin,
 		DevicePassword:  common.DecryptWithPrivateKey,
 		GetPluginStatus: scommon.GetPluginStatus,
 	}
 	data := pc.GetSystemResource(ctx, req)
-	fillSystemProtoResponse(ctx, &resp, data)
+	fillSystemProtoResponse(ctx, &resp, expandResponse(ctx, expand, data))
 	return &resp, nil
 }
 
@@ -90,8 +99,14 @@ func (s *Systems) GetSystemsCollection(ctx context.Context, req *systemsproto.Ge
 		fillSystemProtoResponse(ctx, &resp, authResp)
 		return &resp, nil
 	}
+	uri, expand, qerr := query.SplitExpand(req.URL)
+	if qerr != nil {
+		fillSystemProtoResponse(ctx, &resp, qerr.Response())
+		return &resp, nil
+	}
+	req.URL = uri
 	data := systems.GetSystemsCollection(ctx, req)
-	fillSystemProtoResponse(ctx, &resp, data)
+	fillSystemProtoResponse(ctx, &resp, expandResponse(ctx, expand, data))
 	l.LogWithFields(ctx).Debugf("outgoing response for Get SystemsCollection : %s", string(resp.Body))
 	return &resp, nil
 }
@@ -116,13 +131,19 @@ func (s *Systems) GetSystems(ctx context.Context, req *systemsproto.GetSystemsRe
 		fillSystemProtoResponse(ctx, &resp, authResp)
 		return &resp, nil
 	}
+	uri, expand, qerr := query.SplitExpand(req.URL)
+	if qerr != nil {
+		fillSystemProtoResponse(ctx, &resp, qerr.Response())
+		return &resp, nil
+	}
+	req.URL = uri
 	var pc = systems.PluginContact{
 		ContactClient:   pmbhandle.ContactPlugin,
 		DevicePassword:  common.DecryptWithPrivateKey,
 		GetPluginStatus: scommon.GetPluginStatus,
 	}
 	data := pc.GetSystems(ctx, req)
-	fillSystemProtoResponse(ctx, &resp, data)
+	fillSystemProtoResponse(ctx, &resp, expandResponse(ctx, expand, data))
 	l.LogWithFields(ctx).Debugf("outgoing response for GetSystems : %s", string(resp.Body))
 	return &resp, nil
 }
@@ -333,6 +354,30 @@ func (s *Systems) DeleteVolume(ctx context.Context, req *systemsproto.VolumeRequ
 	return &resp, nil
 }
 
+// GetHealthSummary defines the operations which handles the RPC request response
+// for the fleet health summary of systems micro service.
+// The summary is built from the health rollup kept up to date with the events,
+// and the function uses IsAuthorized of util-lib to validate the session
+// which is present in the request.
+func (s *Systems) GetHealthSummary(ctx context.Context, req *systemsproto.GetSystemsRequest) (*systemsproto.SystemsResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.SystemService, podName)
+	l.LogWithFields(ctx).Debugf("incoming GetHealthSummary request with %s", req.URL)
+	var resp systemsproto.SystemsResponse
+	authResp, err := s.IsAuthorizedRPC(req.SessionToken, []string{common.PrivilegeLogin}, []string{})
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
+		}
+		fillSystemProtoResponse(ctx, &resp, authResp)
+		return &resp, nil
+	}
+	data := s.HealthTracker.GetHealthSummary(ctx)
+	fillSystemProtoResponse(ctx, &resp, data)
+	l.LogWithFields(ctx).Debugf("outgoing response GetHealthSummary: %s", string(resp.Body))
+	return &resp, nil
+}
+
 func fillSystemProtoResponse(ctx context.Context, resp *systemsproto.SystemsResponse, data response.RPC) {
 	resp.StatusCode = data.StatusCode
 	resp.StatusMessage = data.StatusMessage
@@ -27,6 +27,7 @@ import (
 	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
 	systemsproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/systems"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-systems/health"
 	"github.com/ODIM-Project/ODIM/svc-systems/smodel"
 	"github.com/ODIM-Project/ODIM/svc-systems/systems"
 )
@@ -761,3 +762,41 @@ func mockUpdateTask(ctx context.Context, task common.TaskData) error {
 	}
 	return nil
 }
+
+func TestSystems_GetHealthSummary(t *testing.T) {
+	config.SetUpMockConfig(t)
+	sys := new(Systems)
+	sys.IsAuthorizedRPC = mockIsAuthorized
+	sys.HealthTracker = &health.Tracker{
+		DB: health.DB{
+			GetUnhealthyResources: func() ([]smodel.UnhealthyResource, *errors.Error) { return nil, nil },
+			GetHealthCount:        func(key string) (int, *errors.Error) { return 0, nil },
+			GetAggregates:         func() (map[string]smodel.Aggregate, *errors.Error) { return nil, nil },
+		},
+	}
+	tests := []struct {
+		name           string
+		sessionToken   string
+		wantStatusCode int32
+	}{
+		{
+			name:           "Request with valid token",
+			sessionToken:   "validToken",
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "Request with invalid token",
+			sessionToken:   "invalidToken",
+			wantStatusCode: http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &systemsproto.GetSystemsRequest{SessionToken: tt.sessionToken, URL: health.SummaryURI}
+			resp, _ := sys.GetHealthSummary(context.Background(), req)
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Errorf("GetHealthSummary() got = %v, want %v", resp.StatusCode, tt.wantStatusCode)
+			}
+		})
+	}
+}
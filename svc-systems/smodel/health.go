@@ -0,0 +1,201 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package smodel
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+)
+
+const (
+	resourceHealthTable    = "ResourceHealth"
+	healthCountTable       = "HealthCount"
+	unhealthyResourceTable = "UnhealthyResource"
+)
+
+// ResourceHealth is the health of a system or chassis tracked for the
+// fleet health summary
+type ResourceHealth struct {
+	ResourceType string
+	Health       string
+	PowerState   string
+}
+
+// UnhealthyResource is a system, chassis or one of their components which
+// reported a Warning or Critical health
+type UnhealthyResource struct {
+	OdataID string `json:"@odata.id"`
+	Health  string
+	Message string `json:"Message,omitempty"`
+	Since   string
+}
+
+// Aggregate is the model of the aggregate stored by the aggregation service
+type Aggregate struct {
+	Elements []struct {
+		OdataID string `json:"@odata.id"`
+	} `json:"Elements"`
+}
+
+// GetResourceHealth fetches the tracked health of the system or chassis
+func GetResourceHealth(uri string) (ResourceHealth, *errors.Error) {
+	var health ResourceHealth
+	conn, err := GetDBConnectionFunc(common.InMemory)
+	if err != nil {
+		return health, err
+	}
+	data, err := conn.Read(resourceHealthTable, uri)
+	if err != nil {
+		return health, errors.PackError(err.ErrNo(), "error while trying to fetch resource health: ", err.Error())
+	}
+	if err := json.Unmarshal([]byte(data), &health); err != nil {
+		return health, errors.PackError(errors.JSONUnmarshalFailed, err)
+	}
+	return health, nil
+}
+
+// SwapResourceHealth replaces the tracked health of the system or chassis
+// and moves it between the health counts in one step. A nil old health is
+// expected to be absent, a nil new health deletes it. It returns false when
+// the tracked health was changed meanwhile, nothing is changed then.
+func SwapResourceHealth(uri string, old, new *ResourceHealth, decrKeys, incrKeys []string) (bool, *errors.Error) {
+	conn, err := GetDBConnectionFunc(common.InMemory)
+	if err != nil {
+		return false, err
+	}
+	var oldData, newData interface{}
+	if old != nil {
+		oldData = old
+	}
+	if new != nil {
+		newData = new
+	}
+	swapped, err := conn.SwapAndCount(resourceHealthTable, uri, oldData, newData, healthCountTable, decrKeys, incrKeys)
+	if err != nil {
+		return false, errors.PackError(err.ErrNo(), "error while trying to save resource health: ", err.Error())
+	}
+	return swapped, nil
+}
+
+// GetHealthCount fetches the number of resources counted under the key
+func GetHealthCount(key string) (int, *errors.Error) {
+	conn, err := GetDBConnectionFunc(common.InMemory)
+	if err != nil {
+		return 0, err
+	}
+	data, err := conn.Read(healthCountTable, key)
+	if err != nil {
+		if err.ErrNo() == errors.DBKeyNotFound {
+			return 0, nil
+		}
+		return 0, err
+	}
+	count, cerr := strconv.Atoi(data)
+	if cerr != nil {
+		return 0, errors.PackError(errors.UndefinedErrorType, "error while trying to read health count: ", cerr)
+	}
+	return count, nil
+}
+
+// SaveUnhealthyResource saves the resource which reported a Warning or Critical health
+func SaveUnhealthyResource(resource UnhealthyResource) *errors.Error {
+	conn, err := GetDBConnectionFunc(common.InMemory)
+	if err != nil {
+		return err
+	}
+	if err := conn.AddResourceData(unhealthyResourceTable, resource.OdataID, resource); err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to save unhealthy resource: ", err.Error())
+	}
+	return nil
+}
+
+// DeleteUnhealthyResource deletes the resource which no longer reports
+// a Warning or Critical health
+func DeleteUnhealthyResource(uri string) *errors.Error {
+	conn, err := GetDBConnectionFunc(common.InMemory)
+	if err != nil {
+		return err
+	}
+	return conn.Delete(unhealthyResourceTable, uri)
+}
+
+// GetUnhealthyResources fetches all the resources which reported a Warning
+// or Critical health
+func GetUnhealthyResources() ([]UnhealthyResource, *errors.Error) {
+	conn, err := GetDBConnectionFunc(common.InMemory)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := conn.GetAllDetails(unhealthyResourceTable)
+	if err != nil {
+		return nil, err
+	}
+	resources := make([]UnhealthyResource, 0, len(keys))
+	for _, key := range keys {
+		data, err := conn.Read(unhealthyResourceTable, key)
+		if err != nil {
+			// the resource recovered after the keys were listed
+			continue
+		}
+		var resource UnhealthyResource
+		if err := json.Unmarshal([]byte(data), &resource); err != nil {
+			return nil, errors.PackError(errors.JSONUnmarshalFailed, err)
+		}
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
+
+// GetAggregates fetches all the aggregates by their URI
+func GetAggregates() (map[string]Aggregate, *errors.Error) {
+	conn, err := GetDBConnectionFunc(common.OnDisk)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := conn.GetAllDetails("Aggregate")
+	if err != nil {
+		return nil, err
+	}
+	aggregates := make(map[string]Aggregate, len(keys))
+	for _, key := range keys {
+		data, err := conn.Read("Aggregate", key)
+		if err != nil {
+			continue
+		}
+		var aggregate Aggregate
+		if err := json.Unmarshal([]byte(data), &aggregate); err != nil {
+			return nil, errors.PackError(errors.JSONUnmarshalFailed, err)
+		}
+		aggregates[key] = aggregate
+	}
+	return aggregates, nil
+}
+
+// AcquireHealthSeed returns true only to the first caller, which seeds the
+// health rollup from the inventory already present in the database
+func AcquireHealthSeed() (bool, *errors.Error) {
+	conn, err := GetDBConnectionFunc(common.InMemory)
+	if err != nil {
+		return false, err
+	}
+	count, err := conn.Incr("HealthSeed", "Acquired")
+	if err != nil {
+		return false, err
+	}
+	return count == 1, nil
+}
@@ -370,6 +370,24 @@ func GetSystemResetInfo(ctx context.Context, systemURI string) (map[string]strin
 	return resetInfo, nil
 }
 
+// GetInventorySyncStatus fetches the status of the scheduled inventory synchronization of the system
+func GetInventorySyncStatus(ctx context.Context, systemID string) (map[string]interface{}, *errors.Error) {
+	l.LogWithFields(ctx).Debugf("incoming GetInventorySyncStatus request for system: %s", systemID)
+	var status map[string]interface{}
+	conn, err := GetDBConnectionFunc(common.InMemory)
+	if err != nil {
+		return status, err
+	}
+	data, err := conn.Read("InventorySyncStatus", systemID)
+	if err != nil {
+		return status, errors.PackError(err.ErrNo(), "error while trying to fetch inventory synchronization status: ", err.Error())
+	}
+	if err := json.Unmarshal([]byte(data), &status); err != nil {
+		return status, errors.PackError(errors.JSONUnmarshalFailed, err)
+	}
+	return status, nil
+}
+
 // DeleteVolume will delete the volume from InMemory
 func DeleteVolume(ctx context.Context, key string) *errors.Error {
 	l.LogWithFields(ctx).Debugf("incoming DeleteVolume request for key: %s", key)
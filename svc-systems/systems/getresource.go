@@ -51,6 +51,8 @@ var (
 	GetDeviceLoadInfoFunc = getDeviceLoadInfo
 	// GetStringFunc function pointer for the smodel.GetString
 	GetStringFunc = smodel.GetString
+	// GetInventorySyncStatusFunc function pointer for the smodel.GetInventorySyncStatus
+	GetInventorySyncStatusFunc = smodel.GetInventorySyncStatus
 )
 
 func setRegexFlag(ctx context.Context, val string) bool {
@@ -811,6 +813,7 @@ func (p *PluginContact) GetSystems(ctx context.Context, req *systemsproto.GetSys
 	data = strings.Replace(data, `"Id":"`, `"Id":"`+uuid+`.`, -1)
 	var resource map[string]interface{}
 	json.Unmarshal([]byte(data), &resource)
+	addInventorySyncStatus(ctx, resource, req.RequestParam)
 	resp.Body = resource
 	resp.StatusCode = http.StatusOK
 	resp.StatusMessage = response.Success
@@ -818,6 +821,30 @@ func (p *PluginContact) GetSystems(ctx context.Context, req *systemsproto.GetSys
 	return resp
 }
 
+// addInventorySyncStatus adds the status of the scheduled inventory synchronization of the system
+// to the Oem.Odim property of the system, the system is left as is when it was never synchronized
+func addInventorySyncStatus(ctx context.Context, resource map[string]interface{}, systemID string) {
+	status, err := GetInventorySyncStatusFunc(ctx, systemID)
+	if err != nil {
+		l.LogWithFields(ctx).Debug("inventory synchronization status of " + systemID + " is not added: " + err.Error())
+		return
+	}
+	if resource == nil {
+		return
+	}
+	oem, _ := resource["Oem"].(map[string]interface{})
+	if oem == nil {
+		oem = make(map[string]interface{})
+		resource["Oem"] = oem
+	}
+	odim, _ := oem["Odim"].(map[string]interface{})
+	if odim == nil {
+		odim = make(map[string]interface{})
+		oem["Odim"] = odim
+	}
+	odim["InventorySync"] = status
+}
+
 // getStringData supports the eq and ne only for  expression
 func getStringData(key, match, expr string, regexFlag bool) ([]string, error) {
 	if expr == "eq" {
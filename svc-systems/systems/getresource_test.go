@@ -1113,3 +1113,27 @@ func Test_getStringData(t *testing.T) {
 	data, _ = getStringData("", "", "lt", false)
 	assert.True(t, true, data)
 }
+
+func TestAddInventorySyncStatus(t *testing.T) {
+	ctx := mockContext()
+	defer func() {
+		GetInventorySyncStatusFunc = smodel.GetInventorySyncStatus
+	}()
+	status := map[string]interface{}{"Policy": "nightly", "LastSyncTime": "2022-03-15T02:00:00Z", "LastSuccessfulSyncTime": "2022-03-15T02:00:00Z"}
+	GetInventorySyncStatusFunc = func(ctx context.Context, systemID string) (map[string]interface{}, *errors.Error) {
+		return status, nil
+	}
+	resource := map[string]interface{}{"Id": "6d4a0a66-7efa-578e-83cf-44dc68d2874e.1", "Oem": map[string]interface{}{"Hpe": map[string]interface{}{}}}
+	addInventorySyncStatus(ctx, resource, "6d4a0a66-7efa-578e-83cf-44dc68d2874e.1")
+	oem := resource["Oem"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{}, oem["Hpe"], "OEM property of the BMC should be kept")
+	assert.Equal(t, map[string]interface{}{"InventorySync": status}, oem["Odim"], "synchronization status should be added")
+
+	// the system which was never synchronized is left as is
+	GetInventorySyncStatusFunc = func(ctx context.Context, systemID string) (map[string]interface{}, *errors.Error) {
+		return nil, errors.PackError(errors.DBKeyNotFound, "no data with the with key ", systemID, " found")
+	}
+	resource = map[string]interface{}{"Id": "6d4a0a66-7efa-578e-83cf-44dc68d2874e.1"}
+	addInventorySyncStatus(ctx, resource, "6d4a0a66-7efa-578e-83cf-44dc68d2874e.1")
+	assert.Equal(t, map[string]interface{}{"Id": "6d4a0a66-7efa-578e-83cf-44dc68d2874e.1"}, resource, "system should be unchanged")
+}
@@ -44,6 +44,14 @@ const (
 
 var podName = os.Getenv("POD_NAME")
 
+var (
+	// taskCancelPollInterval is the interval at which the state of the task being cancelled is checked
+	taskCancelPollInterval = 5 * time.Second
+	// taskCancelTimeout is the time given to the service running the task to stop it,
+	// the task is marked as Cancelled by the task service after this
+	taskCancelTimeout = 10 * time.Minute
+)
+
 // TasksRPC used to register handler used as rpc call
 // AuthenticationRPC is used to authorize user and privileges
 // GetTaskStatusModel get task status
@@ -60,6 +68,7 @@ type TasksRPC struct {
 	PersistTaskModel                 func(ctx context.Context, t *tmodel.Task, db common.DbType) error
 	ValidateTaskUserNameModel        func(ctx context.Context, userName string) error
 	PublishToMessageBus              func(ctx context.Context, taskURI string, taskEvenMessageID string, eventType string, taskMessage string)
+	PublishCancelTask                func(ctx context.Context, data common.CancelTaskData)
 }
 
 //TaskCollectionData ....
@@ -335,7 +344,7 @@ func (ts *TasksRPC) taskCancelCallBack(ctx context.Context, taskID string) error
 		l.LogWithFields(ctx).Error("error getting task status : " + err.Error())
 		return nil
 	}
-	if task.TaskState == common.Completed || task.TaskState == common.Exception || task.TaskState == common.Pending {
+	if isTaskFinished(task.TaskState) {
 		// check if this task has any child tasks, if so delete them.
 		for _, subTaskID := range task.ChildTaskIDs {
 			subTask, err := ts.GetTaskStatusModel(ctx, subTaskID, common.InMemory)
@@ -350,7 +359,8 @@ func (ts *TasksRPC) taskCancelCallBack(ctx context.Context, taskID string) error
 	}
 	threadID := ctx.Value(tcommon.IterationCount).(*int)
 	newCtx := context.WithValue(ctx, common.ThreadName, common.AsyncTaskDelete)
-	for _, subTaskID := range task.ChildTaskIDs {
+	cancelTask := common.CancelTaskData{TaskID: taskID}
+	for _, subTaskID := range ts.getSubTaskIDs(ctx, task) {
 		subTask, err := ts.GetTaskStatusModel(ctx, subTaskID, common.InMemory)
 		if err != nil {
 			l.LogWithFields(ctx).Error("error getting task status : " + err.Error())
@@ -359,34 +369,57 @@ func (ts *TasksRPC) taskCancelCallBack(ctx context.Context, taskID string) error
 		// Just changing the TaskState to Cancelling state,
 		// After this the thread associated with this task, it can be in any service can see this change and
 		// mark the taskstate to Cancelled exits.
-		if subTask.TaskState == common.Completed || subTask.TaskState == common.Exception || subTask.TaskState == common.Pending {
-			ts.DeleteTaskFromDBModel(ctx, subTask)
-		} else if subTask.TaskState != common.Cancelling {
+		if isTaskFinished(subTask.TaskState) {
+			continue
+		}
+		cancelTask.SubTaskIDs = append(cancelTask.SubTaskIDs, subTaskID)
+		if subTask.TaskState != common.Cancelling {
 			subTask.TaskState = common.Cancelling
 			ts.UpdateTaskQueue(subTask)
 			newCtx = context.WithValue(newCtx, common.ThreadID, strconv.Itoa(*threadID))
-			go ts.asyncTaskDelete(newCtx, subTaskID)
+			go ts.asyncTaskCancel(newCtx, subTaskID)
 			*threadID++
 		}
 	}
-	// Delete the parent task
+	// Cancel the parent task
 	if task.TaskState != common.Cancelling {
 		task.TaskState = common.Cancelling
 		ts.UpdateTaskQueue(task)
 		newCtx = context.WithValue(newCtx, common.ThreadID, strconv.Itoa(*threadID))
-		go ts.asyncTaskDelete(newCtx, taskID)
+		go ts.asyncTaskCancel(newCtx, taskID)
 		*threadID++
 	}
-
+	// signal the service running the task to stop the operations of the task and the sub tasks
+	ts.PublishCancelTask(ctx, cancelTask)
 	return nil
 }
 
-func (ts *TasksRPC) asyncTaskDelete(ctx context.Context, taskID string) {
-	//Polling for the taskstate.
-	//If the taskstate becomes Cancelled, then this means the thread associated with this task exited succefully,
-	//so go ahead delete the task from the db
+// isTaskFinished checks if the task is not running and can be deleted
+func isTaskFinished(taskState string) bool {
+	return taskState == common.Completed || taskState == common.Exception ||
+		taskState == common.Pending || taskState == common.Cancelled
+}
+
+// getSubTaskIDs returns the IDs of the child tasks of the task and of their child tasks recursively
+func (ts *TasksRPC) getSubTaskIDs(ctx context.Context, task *tmodel.Task) []string {
+	var subTaskIDs []string
+	for _, subTaskID := range task.ChildTaskIDs {
+		subTaskIDs = append(subTaskIDs, subTaskID)
+		subTask, err := ts.GetTaskStatusModel(ctx, subTaskID, common.InMemory)
+		if err != nil {
+			continue
+		}
+		subTaskIDs = append(subTaskIDs, ts.getSubTaskIDs(ctx, subTask)...)
+	}
+	return subTaskIDs
+}
 
-	// Get the task
+func (ts *TasksRPC) asyncTaskCancel(ctx context.Context, taskID string) {
+	//Polling for the taskstate.
+	//If the taskstate becomes Cancelled, then this means the thread associated with this task exited succefully
+	//and recorded the operations completed before the cancellation, the task is kept so that it can be viewed.
+	//If the service running the task doesn't stop it in time, the task is marked Cancelled here.
+	deadline := time.Now().Add(taskCancelTimeout)
 	for {
 		task, err := ts.GetTaskStatusModel(ctx, taskID, common.InMemory)
 		if err != nil {
@@ -394,16 +427,17 @@ func (ts *TasksRPC) asyncTaskDelete(ctx context.Context, taskID string) {
 			return
 		}
 		if task.TaskState == common.Cancelled {
-			err = ts.DeleteTaskFromDBModel(ctx, task)
-			if err != nil {
-				l.LogWithFields(ctx).Error("error unable to delete the task from db: " + err.Error())
-				return
+			return
+		}
+		if time.Now().After(deadline) {
+			l.LogWithFields(ctx).Warn("task " + taskID + " was not stopped by the service running it, marking it as cancelled")
+			if err := ts.updateTaskUtil(ctx, taskID, common.Cancelled, common.Warning, task.PercentComplete, nil, time.Now()); err != nil {
+				l.LogWithFields(ctx).Error("error unable to mark the task as cancelled: " + err.Error())
 			}
-			break
+			return
 		}
-		time.Sleep(5000 * time.Millisecond)
+		time.Sleep(taskCancelPollInterval)
 	}
-	return
 }
 
 //GetSubTasks is an API end point to get all available tasks
@@ -1017,6 +1051,10 @@ func (ts *TasksRPC) updateTaskUtil(ctx context.Context, taskID string, taskState
 		task.PercentComplete = percentComplete
 		if payLoad != nil {
 			task.StatusCode = payLoad.StatusCode
+			if len(payLoad.ResponseBody) > 0 {
+				task.TaskResponse = payLoad.ResponseBody
+				task.Messages = append(task.Messages, getResponseMessages(payLoad.ResponseBody)...)
+			}
 		}
 		task.EndTime = endTime
 		// Constuct the appropriate messageID for task status change nitification
@@ -1146,3 +1184,25 @@ func (ts *TasksRPC) updateTaskUtil(ctx context.Context, taskID string, taskState
 	}
 	return err
 }
+
+// getResponseMessages returns the messages in the @Message.ExtendedInfo of the response body of the task
+func getResponseMessages(responseBody []byte) []*tmodel.Message {
+	var body response.CommonError
+	if err := json.Unmarshal(responseBody, &body); err != nil {
+		return nil
+	}
+	var messages []*tmodel.Message
+	for _, info := range body.Error.MessageExtendedInfo {
+		message := &tmodel.Message{
+			MessageID:  info.MessageID,
+			Message:    info.Message,
+			Severity:   info.Severity,
+			Resolution: info.Resolution,
+		}
+		for _, arg := range info.MessageArgs {
+			message.MessageArgs = append(message.MessageArgs, fmt.Sprintf("%v", arg))
+		}
+		messages = append(messages, message)
+	}
+	return messages
+}
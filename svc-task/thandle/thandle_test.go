@@ -15,6 +15,7 @@ package thandle
 
 import (
 	"context"
+	"encoding/json"
 	"encoding/base64"
 	"fmt"
 	"net/http"
@@ -91,6 +92,9 @@ func mockDeleteTaskFromDBModel(ctx context.Context, task *tmodel.Task) error {
 func mockUpdateTaskStatusModel(task *tmodel.Task) {
 }
 
+func mockPublishCancelTask(ctx context.Context, data common.CancelTaskData) {
+}
+
 func mockPublishToMessageBus(ctx context.Context, taskURI, taskEvenMessageID, eventType, taskMessage string) {
 
 }
@@ -1602,6 +1606,7 @@ func TestTasksRPC_taskCancelCallBack(t *testing.T) {
 				GetTaskStatusModel:    mockGetTaskStatusModel,
 				UpdateTaskQueue:       mockUpdateTaskStatusModel,
 				DeleteTaskFromDBModel: mockDeleteTaskFromDBModel,
+				PublishCancelTask:     mockPublishCancelTask,
 			},
 			args: args{
 				taskID: "validTaskID",
@@ -1614,6 +1619,7 @@ func TestTasksRPC_taskCancelCallBack(t *testing.T) {
 				GetTaskStatusModel:    mockGetTaskStatusModel,
 				UpdateTaskQueue:       mockUpdateTaskStatusModel,
 				DeleteTaskFromDBModel: mockDeleteTaskFromDBModel,
+				PublishCancelTask:     mockPublishCancelTask,
 			},
 			args: args{
 				taskID: "CompletedTaskID",
@@ -1626,6 +1632,7 @@ func TestTasksRPC_taskCancelCallBack(t *testing.T) {
 				GetTaskStatusModel:    mockGetTaskStatusModel,
 				UpdateTaskQueue:       mockUpdateTaskStatusModel,
 				DeleteTaskFromDBModel: mockDeleteTaskFromDBModel,
+				PublishCancelTask:     mockPublishCancelTask,
 			},
 			args: args{
 				taskID: "RunningTaskID",
@@ -1638,6 +1645,7 @@ func TestTasksRPC_taskCancelCallBack(t *testing.T) {
 				GetTaskStatusModel:    mockGetTaskStatusModel,
 				UpdateTaskQueue:       mockUpdateTaskStatusModel,
 				DeleteTaskFromDBModel: mockDeleteTaskFromDBModel,
+				PublishCancelTask:     mockPublishCancelTask,
 			},
 			args: args{
 				taskID: "InvalidTaskID",
@@ -1668,3 +1676,15 @@ func mockContext() context.Context {
 	ctx = context.WithValue(ctx, common.ProcessName, "xyz")
 	return ctx
 }
+
+func Test_getResponseMessages(t *testing.T) {
+	body, _ := json.Marshal(common.CancelledTaskResponse("task1", []string{"/redfish/v1/Systems/uuid.1"}).Body)
+	messages := getResponseMessages(body)
+	if len(messages) != 1 || messages[0].MessageID != common.TaskEventType+".TaskCancelled" ||
+		!reflect.DeepEqual(messages[0].MessageArgs, []string{"task1"}) {
+		t.Errorf("getResponseMessages() = %v", messages)
+	}
+	if messages := getResponseMessages([]byte("invalid")); messages != nil {
+		t.Errorf("getResponseMessages() = %v, want nil", messages)
+	}
+}
@@ -56,8 +56,9 @@ func Publish(ctx context.Context, taskURI, messageID, eventType, taskMessage str
 	}
 	data, _ := json.Marshal(messageData)
 	var mbevent = common.Events{
-		IP:      "TasksCollection",
-		Request: data,
+		IP:       "TasksCollection",
+		Request:  data,
+		Internal: true,
 	}
 
 	if err := k.Distribute(mbevent); err != nil {
@@ -65,3 +66,23 @@ func Publish(ctx context.Context, taskURI, messageID, eventType, taskMessage str
 		return
 	}
 }
+
+// PublishCancelTask publishes the CancelTask control message to the control message queues
+// of the services running the tasks, the service running the task stops its operations
+func PublishCancelTask(ctx context.Context, data common.CancelTaskData) {
+	ctrlMsg := common.ControlMessageData{
+		MessageType: common.CancelTask,
+		Data:        data,
+	}
+	for _, service := range common.TaskCancellationServices {
+		topicName := common.TaskControlMessageQueue(service)
+		k, err := dc.Communicator(config.Data.MessageBusConf.MessageBusType, config.Data.MessageBusConf.MessageBusConfigFilePath, topicName)
+		if err != nil {
+			l.LogWithFields(ctx).Error("Unable to connect to " + config.Data.MessageBusConf.MessageBusType + " " + err.Error())
+			return
+		}
+		if err := k.Distribute(ctrlMsg); err != nil {
+			l.LogWithFields(ctx).Error("TaskID:" + data.TaskID + " : unable to publish the task cancellation to " + topicName + ": " + err.Error())
+		}
+	}
+}
@@ -14,6 +14,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 
@@ -24,6 +25,7 @@ import (
 	"github.com/ODIM-Project/ODIM/lib-utilities/services"
 	"github.com/ODIM-Project/ODIM/svc-telemetry/rpc"
 	"github.com/ODIM-Project/ODIM/svc-telemetry/tcommon"
+	"github.com/ODIM-Project/ODIM/svc-telemetry/telemetry"
 
 	"github.com/sirupsen/logrus"
 )
@@ -73,6 +75,8 @@ func main() {
 	go tcommon.TrackConfigFileChanges(errChan)
 
 	registerHandlers(errChan)
+	// collect the metrics of the MetricReportDefinitions created in ODIM, shared with the other replicas
+	go manageMetricReportCollectors(podName)
 	// Run server
 	if err := services.ODIMService.Run(); err != nil {
 		log.Error(err)
@@ -89,3 +93,11 @@ func registerHandlers(errChan chan error) {
 	tele := rpc.GetTele()
 	teleproto.RegisterTelemetryServer(services.ODIMService.Server(), tele)
 }
+
+func manageMetricReportCollectors(podName string) {
+	ctx := context.Background()
+	ctx = context.WithValue(ctx, common.ProcessName, podName)
+	ctx = context.WithValue(ctx, common.ThreadName, common.CollectMetricReport)
+	ctx = context.WithValue(ctx, common.ThreadID, common.DefaultThreadID)
+	telemetry.GetExternalInterface().ManageMetricReportCollectors(ctx)
+}
@@ -187,3 +187,93 @@ func (a *Telemetry) UpdateTrigger(ctx context.Context, req *teleproto.TelemetryR
 	fillProtoResponse(ctx, resp, a.connector.UpdateTrigger(req))
 	return resp, nil
 }
+
+// CreateMetricReportDefinition is an rpc handler which is invoked during POST on MetricReportDefinition Collection
+func (a *Telemetry) CreateMetricReportDefinition(ctx context.Context, req *teleproto.TelemetryRequest) (*teleproto.TelemetryResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.TelemetryService, podName)
+	resp := &teleproto.TelemetryResponse{}
+	authResp, err := a.connector.External.Auth(req.SessionToken, []string{common.PrivilegeConfigureComponents}, []string{})
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
+		}
+		fillProtoResponse(ctx, resp, authResp)
+		return resp, nil
+	}
+	fillProtoResponse(ctx, resp, a.connector.CreateMetricReportDefinition(ctx, req))
+	return resp, nil
+}
+
+// DeleteMetricReportDefinition is an rpc handler which is invoked during DELETE on MetricReportDefinition
+func (a *Telemetry) DeleteMetricReportDefinition(ctx context.Context, req *teleproto.TelemetryRequest) (*teleproto.TelemetryResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.TelemetryService, podName)
+	resp := &teleproto.TelemetryResponse{}
+	authResp, err := a.connector.External.Auth(req.SessionToken, []string{common.PrivilegeConfigureComponents}, []string{})
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
+		}
+		fillProtoResponse(ctx, resp, authResp)
+		return resp, nil
+	}
+	fillProtoResponse(ctx, resp, a.connector.DeleteMetricReportDefinition(ctx, req))
+	return resp, nil
+}
+
+// CreateTrigger is an rpc handler which is invoked during POST on Triggers Collection
+func (a *Telemetry) CreateTrigger(ctx context.Context, req *teleproto.TelemetryRequest) (*teleproto.TelemetryResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.TelemetryService, podName)
+	resp := &teleproto.TelemetryResponse{}
+	authResp, err := a.connector.External.Auth(req.SessionToken, []string{common.PrivilegeConfigureComponents}, []string{})
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
+		}
+		fillProtoResponse(ctx, resp, authResp)
+		return resp, nil
+	}
+	fillProtoResponse(ctx, resp, a.connector.CreateTrigger(ctx, req))
+	return resp, nil
+}
+
+// DeleteTrigger is an rpc handler which is invoked during DELETE on Trigger
+func (a *Telemetry) DeleteTrigger(ctx context.Context, req *teleproto.TelemetryRequest) (*teleproto.TelemetryResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.TelemetryService, podName)
+	resp := &teleproto.TelemetryResponse{}
+	authResp, err := a.connector.External.Auth(req.SessionToken, []string{common.PrivilegeConfigureComponents}, []string{})
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
+		}
+		fillProtoResponse(ctx, resp, authResp)
+		return resp, nil
+	}
+	fillProtoResponse(ctx, resp, a.connector.DeleteTrigger(ctx, req))
+	return resp, nil
+}
+
+// GetOpenMetrics is an rpc handler which is invoked during GET on OpenMetrics, the telemetry
+// is exported in the OpenMetrics text exposition format and is not JSON encoded
+func (a *Telemetry) GetOpenMetrics(ctx context.Context, req *teleproto.TelemetryRequest) (*teleproto.TelemetryResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.TelemetryService, podName)
+	resp := &teleproto.TelemetryResponse{}
+	authResp, err := a.connector.External.Auth(req.SessionToken, []string{common.PrivilegeLogin}, []string{})
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
+		}
+		fillProtoResponse(ctx, resp, authResp)
+		return resp, nil
+	}
+	data := a.connector.GetOpenMetrics(ctx, req)
+	fillProtoResponse(ctx, resp, data)
+	if body, ok := data.Body.(string); ok {
+		resp.Body = []byte(body)
+	}
+	return resp, nil
+}
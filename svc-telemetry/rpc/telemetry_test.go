@@ -404,3 +404,88 @@ func TestTelemetry_UpdateTrigger(t *testing.T) {
 		})
 	}
 }
+
+func TestTelemetry_CreateAndDeleteResources(t *testing.T) {
+	telemetry := new(Telemetry)
+	telemetry.connector = tm.MockGetExternalInterface()
+	type rpcHandler func(context.Context, *teleproto.TelemetryRequest) (*teleproto.TelemetryResponse, error)
+	tests := []struct {
+		name    string
+		handler rpcHandler
+		req     *teleproto.TelemetryRequest
+		want    int
+	}{
+		{
+			name:    "Create MetricReportDefinition with invalid token",
+			handler: telemetry.CreateMetricReportDefinition,
+			req:     &teleproto.TelemetryRequest{SessionToken: "InvalidToken"},
+			want:    http.StatusUnauthorized,
+		},
+		{
+			name:    "Create MetricReportDefinition with malformed body",
+			handler: telemetry.CreateMetricReportDefinition,
+			req:     &teleproto.TelemetryRequest{SessionToken: "validToken", RequestBody: []byte(`{"Id":`)},
+			want:    http.StatusBadRequest,
+		},
+		{
+			name:    "Delete MetricReportDefinition with invalid token",
+			handler: telemetry.DeleteMetricReportDefinition,
+			req:     &teleproto.TelemetryRequest{SessionToken: "InvalidToken"},
+			want:    http.StatusUnauthorized,
+		},
+		{
+			name:    "Delete unknown MetricReportDefinition",
+			handler: telemetry.DeleteMetricReportDefinition,
+			req:     &teleproto.TelemetryRequest{SessionToken: "validToken", URL: "/redfish/v1/TelemetryService/MetricReportDefinitions/NotFound"},
+			want:    http.StatusNotFound,
+		},
+		{
+			name:    "Create Trigger with invalid token",
+			handler: telemetry.CreateTrigger,
+			req:     &teleproto.TelemetryRequest{SessionToken: "InvalidToken"},
+			want:    http.StatusUnauthorized,
+		},
+		{
+			name:    "Create Trigger with malformed body",
+			handler: telemetry.CreateTrigger,
+			req:     &teleproto.TelemetryRequest{SessionToken: "validToken", RequestBody: []byte(`{"Id":`)},
+			want:    http.StatusBadRequest,
+		},
+		{
+			name:    "Delete Trigger with invalid token",
+			handler: telemetry.DeleteTrigger,
+			req:     &teleproto.TelemetryRequest{SessionToken: "InvalidToken"},
+			want:    http.StatusUnauthorized,
+		},
+		{
+			name:    "Delete unknown Trigger",
+			handler: telemetry.DeleteTrigger,
+			req:     &teleproto.TelemetryRequest{SessionToken: "validToken", URL: "/redfish/v1/TelemetryService/Triggers/NotFound"},
+			want:    http.StatusNotFound,
+		},
+		{
+			name:    "Get OpenMetrics with invalid token",
+			handler: telemetry.GetOpenMetrics,
+			req:     &teleproto.TelemetryRequest{SessionToken: "InvalidToken"},
+			want:    http.StatusUnauthorized,
+		},
+		{
+			name:    "Get OpenMetrics",
+			handler: telemetry.GetOpenMetrics,
+			req:     &teleproto.TelemetryRequest{SessionToken: "validToken", URL: "/redfish/v1/TelemetryService/OpenMetrics"},
+			want:    http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.handler(context.Background(), tt.req)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if int(got.StatusCode) != tt.want {
+				t.Errorf("status code = %v, want %v", got.StatusCode, tt.want)
+			}
+		})
+	}
+}
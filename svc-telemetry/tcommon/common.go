@@ -63,6 +63,7 @@ type ResourceInfoRequest struct {
 	GetPluginData       func(string) (tmodel.Plugin, *errors.Error)
 	GetResource         func(string, string, common.DbType) (string, *errors.Error)
 	GenericSave         func(context.Context, []byte, string, string) error
+	GetTarget           func(string) (*tmodel.Target, *errors.Error)
 }
 
 var (
@@ -154,6 +155,66 @@ func getResourceInfo(ctx context.Context, pluginID string, metricReportData *dmt
 	return
 }
 
+// GetResourceFromDevice will contact the plugin of the device to which the resource
+// belongs to and gets the resource, the URL has the system, chassis or manager ID
+// in the ODIM format {uuid}.{id}
+func GetResourceFromDevice(ctx context.Context, req ResourceInfoRequest) ([]byte, error) {
+	parts := strings.Split(req.URL, "/")
+	if len(parts) < 5 || !strings.Contains(parts[4], ".") {
+		return nil, fmt.Errorf("invalid resource URI %s", req.URL)
+	}
+	resourceID := parts[4]
+	deviceUUID := resourceID[:strings.Index(resourceID, ".")]
+	target, gerr := req.GetTarget(deviceUUID)
+	if gerr != nil {
+		return nil, fmt.Errorf("error while trying to get the target %s: %s", deviceUUID, gerr.Error())
+	}
+	plugin, gerr := req.GetPluginData(target.PluginID)
+	if gerr != nil {
+		return nil, fmt.Errorf("error while trying to get the plugin %s: %s", target.PluginID, gerr.Error())
+	}
+	var contactRequest PluginContactRequest
+
+	contactRequest.ContactClient = req.ContactClient
+	contactRequest.Plugin = plugin
+	contactRequest.GetPluginStatus = req.GetPluginStatus
+	if strings.EqualFold(plugin.PreferredAuthType, "XAuthToken") {
+		contactRequest.HTTPMethodType = http.MethodPost
+		contactRequest.DeviceInfo = map[string]interface{}{
+			"Username": plugin.Username,
+			"Password": string(plugin.Password),
+		}
+		contactRequest.OID = "/ODIM/v1/Sessions"
+		_, token, _, err := ContactPlugin(ctx, contactRequest, "error while getting the details "+contactRequest.OID+": ")
+		if err != nil {
+			return nil, err
+		}
+		contactRequest.Token = token
+	} else {
+		contactRequest.BasicAuth = map[string]string{
+			"UserName": plugin.Username,
+			"Password": string(plugin.Password),
+		}
+	}
+	password, err := req.DevicePassword(target.Password)
+	if err != nil {
+		return nil, fmt.Errorf("error while trying to decrypt device password: %s", err.Error())
+	}
+	contactRequest.DeviceInfo = map[string]interface{}{
+		"ManagerAddress": target.ManagerAddress,
+		"UserName":       target.UserName,
+		"Password":       password,
+	}
+	// replace the {uuid}.{id} with the id of the resource in the device
+	contactRequest.OID = strings.Replace(req.URL, resourceID, resourceID[len(deviceUUID)+1:], 1)
+	contactRequest.HTTPMethodType = http.MethodGet
+	body, _, _, err := ContactPlugin(ctx, contactRequest, "error while getting the details "+contactRequest.OID+": ")
+	if err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
 // ContactPlugin is commons which handles the request and response of Contact Plugin usage
 func ContactPlugin(ctx context.Context, req PluginContactRequest, errorMessage string) ([]byte, string, ResponseStatus, error) {
 	var resp ResponseStatus
@@ -80,6 +80,12 @@ func mockContactClient(ctx context.Context, url, method, token string, odataID s
 			StatusCode: http.StatusUnauthorized,
 			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
 		}, nil
+	} else if url == "https://localhost:9091/ODIM/v1/Chassis/1/Power" {
+		body := `{"@odata.id":"/redfish/v1/Chassis/1/Power","PowerControl":[{"PowerConsumedWatts":245}]}`
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		}, nil
 	} else if url == "https://localhost:9091/ODIM/v1/TelemetryService/MetricReports/CPUUtilCustom1" {
 		body := `{"@odata.id": "/ODIM/v1/TelemetryService/MetricReports/CPUUtilCustom1"}`
 		return &http.Response{
@@ -183,6 +189,44 @@ func TestGetResourceInfoFromDevice(t *testing.T) {
 	assert.Nil(t, err, "There should be no error getting data")
 }
 
+func mockGetTarget(uuid string) (*tmodel.Target, *errors.Error) {
+	if uuid != "6d4a0a66-7efa-578e-83cf-44dc68d2874e" {
+		return nil, errors.PackError(errors.DBKeyNotFound, "no data with the with key "+uuid+" found")
+	}
+	return &tmodel.Target{
+		ManagerAddress: "10.0.0.1",
+		Password:       []byte("password"),
+		UserName:       "admin",
+		DeviceUUID:     uuid,
+		PluginID:       "GRF",
+	}, nil
+}
+
+func TestGetResourceFromDevice(t *testing.T) {
+	config.SetUpMockConfig(t)
+
+	var req = ResourceInfoRequest{
+		URL:             "/redfish/v1/Chassis/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1/Power",
+		ContactClient:   mockContactClient,
+		DevicePassword:  stubDevicePassword,
+		GetPluginData:   mockGetPluginData,
+		GetPluginStatus: mockPluginStatus,
+		GetTarget:       mockGetTarget,
+	}
+	ctx := mockContext()
+	data, err := GetResourceFromDevice(ctx, req)
+	assert.Nil(t, err, "There should be no error getting data")
+	assert.Contains(t, string(data), "PowerConsumedWatts", "The resource should be fetched from the BMC")
+
+	req.URL = "/redfish/v1/Chassis/7e4a0a66-7efa-578e-83cf-44dc68d2874e.1/Power"
+	_, err = GetResourceFromDevice(ctx, req)
+	assert.NotNil(t, err, "There should be an error for the unknown BMC")
+
+	req.URL = "/redfish/v1/Chassis"
+	_, err = GetResourceFromDevice(ctx, req)
+	assert.NotNil(t, err, "There should be an error for the invalid resource URI")
+}
+
 func TestContactPlugin(t *testing.T) {
 	config.SetUpMockConfig(t)
 	plugin := tmodel.Plugin{}
@@ -0,0 +1,47 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package tcommon
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// durationPattern is the Redfish duration format, which is the subset of
+// ISO 8601 duration with days, hours, minutes and seconds, ex: P1DT2H3M4.5S
+var durationPattern = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// ParseDuration converts the Redfish duration string to time.Duration
+func ParseDuration(duration string) (time.Duration, error) {
+	match := durationPattern.FindStringSubmatch(duration)
+	if match == nil || duration == "P" || duration == "PT" {
+		return 0, fmt.Errorf("invalid duration %s", duration)
+	}
+	var result time.Duration
+	units := []time.Duration{24 * time.Hour, time.Hour, time.Minute, time.Second}
+	for i, unit := range units {
+		if match[i+1] == "" {
+			continue
+		}
+		value, err := strconv.ParseFloat(match[i+1], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %s: %v", duration, err)
+		}
+		result += time.Duration(value * float64(unit))
+	}
+	return result, nil
+}
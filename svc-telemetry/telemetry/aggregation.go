@@ -0,0 +1,761 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	teleproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/telemetry"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-telemetry/tcommon"
+)
+
+var (
+	// metricSamplingInterval is the interval at which the metric properties of the
+	// MetricReportDefinitions created in ODIM are collected from the BMCs
+	metricSamplingInterval = 30 * time.Second
+	// metricReportCollectorsInterval is the interval at which each replica of the service renews
+	// the leases of the MetricReportDefinitions it collects and takes over the ones without a collector
+	metricReportCollectorsInterval = 30 * time.Second
+
+	collectors = metricReportCollectors{collectors: make(map[string]*metricReportCollector)}
+)
+
+const (
+	// collectorStateTable holds the collected samples and the last report of the
+	// MetricReportDefinitions created in ODIM, the collector of a definition is resumed
+	// from it by the replica taking over the definition
+	collectorStateTable = "MetricReportCollectorState"
+	// collectorLeaseTTL is how long a replica owns a MetricReportDefinition without renewing
+	// its lease, after which another replica takes over the collection of its metric properties
+	collectorLeaseTTL = 90 * time.Second
+)
+
+// metricReportCollectors holds the running collectors of the MetricReportDefinitions
+// created in ODIM, against the URI of the MetricReportDefinition
+type metricReportCollectors struct {
+	lock       sync.RWMutex
+	collectors map[string]*metricReportCollector
+}
+
+// metricSample is a value of a metric property collected from the BMC
+type metricSample struct {
+	value     string
+	timestamp time.Time
+}
+
+// collectorState is the state of a collector saved in the DB
+type collectorState struct {
+	Samples  map[string][]collectorStateSample `json:"Samples"`
+	Report   *dmtf.MetricReports               `json:"Report,omitempty"`
+	Sequence int                               `json:"Sequence"`
+}
+
+// collectorStateSample is a metricSample saved in the DB
+type collectorStateSample struct {
+	Value     string    `json:"Value"`
+	Timestamp time.Time `json:"Timestamp"`
+}
+
+// collectorMetric is a metric of the MetricReportDefinition, with the
+// wildcards in its metric properties substituted
+type collectorMetric struct {
+	id         string
+	function   string
+	duration   time.Duration
+	properties []string
+}
+
+// metricReportCollector collects the metric properties of a MetricReportDefinition
+// created in ODIM and generates its MetricReport
+type metricReportCollector struct {
+	lock       sync.Mutex
+	definition dmtf.MetricReportDefinitions
+	recurrence time.Duration
+	metrics    []collectorMetric
+	properties []string
+	retention  map[string]time.Duration
+	samples    map[string][]metricSample
+	triggers   map[string]dmtf.Triggers
+	report     *dmtf.MetricReports
+	sequence   int
+	stop       chan struct{}
+}
+
+func (m *metricReportCollectors) add(c *metricReportCollector) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if existing, ok := m.collectors[c.definition.ODataID]; ok {
+		close(existing.stop)
+	}
+	m.collectors[c.definition.ODataID] = c
+}
+
+func (m *metricReportCollectors) get(definitionURI string) (*metricReportCollector, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	c, ok := m.collectors[definitionURI]
+	return c, ok
+}
+
+func (m *metricReportCollectors) remove(definitionURI string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if c, ok := m.collectors[definitionURI]; ok {
+		close(c.stop)
+		delete(m.collectors, definitionURI)
+	}
+}
+
+func (m *metricReportCollectors) all() []*metricReportCollector {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	var result []*metricReportCollector
+	for _, c := range m.collectors {
+		result = append(result, c)
+	}
+	return result
+}
+
+func newMetricReportCollector(definition dmtf.MetricReportDefinitions) *metricReportCollector {
+	c := &metricReportCollector{
+		definition: definition,
+		retention:  make(map[string]time.Duration),
+		samples:    make(map[string][]metricSample),
+		triggers:   make(map[string]dmtf.Triggers),
+		stop:       make(chan struct{}),
+	}
+	if definition.MetricReportDefinitionType == "Periodic" {
+		c.recurrence, _ = tcommon.ParseDuration(definition.Schedule.RecurrenceInterval)
+	}
+	if len(definition.MetricProperties) > 0 {
+		c.metrics = append(c.metrics, collectorMetric{
+			properties: expandWildcards(definition.MetricProperties, definition.Wildcards),
+		})
+	}
+	for _, metric := range definition.Metrics {
+		collectorMetric := collectorMetric{
+			id:         metric.MetricID,
+			function:   metric.CollectionFunction,
+			properties: expandWildcards(metric.MetricProperties, definition.Wildcards),
+		}
+		if metric.CollectionDuration != "" {
+			collectorMetric.duration, _ = tcommon.ParseDuration(metric.CollectionDuration)
+		}
+		c.metrics = append(c.metrics, collectorMetric)
+	}
+	for _, metric := range c.metrics {
+		for _, property := range metric.properties {
+			retention, ok := c.retention[property]
+			if !ok {
+				c.properties = append(c.properties, property)
+			}
+			if metric.duration > retention {
+				retention = metric.duration
+			}
+			c.retention[property] = retention
+		}
+	}
+	sort.Strings(c.properties)
+	return c
+}
+
+// ManageMetricReportCollectors collects the metric properties of the MetricReportDefinitions
+// created in ODIM, which are saved in the DB. Each definition is collected by only one of the
+// replicas of the service, the one holding its lease. The replicas renew the leases of their
+// definitions at metricReportCollectorsInterval and take over the definitions whose lease has
+// expired, as when the replica collecting them went down.
+func (e *ExternalInterface) ManageMetricReportCollectors(ctx context.Context) {
+	for {
+		e.reconcileMetricReportCollectors(ctx)
+		time.Sleep(metricReportCollectorsInterval)
+	}
+}
+
+// reconcileMetricReportCollectors runs the collectors of the MetricReportDefinitions whose lease
+// is held by the replica and stops the others, and links the Triggers saved in the DB to them.
+// The copies of the definitions and the Triggers in the InMemory DB are restored if they are lost.
+func (e *ExternalInterface) reconcileMetricReportCollectors(ctx context.Context) {
+	definitions, err := e.getAggregatedDefinitions(ctx)
+	if err != nil {
+		l.LogWithFields(ctx).Error("error while trying to get the MetricReportDefinitions: " + err.Error())
+		return
+	}
+	for definitionURI, definition := range definitions {
+		e.restoreInMemoryCopy(ctx, "MetricReportDefinitions", "MetricReportDefinitionsCollection", metricReportDefinitionsURI, definition.ODataID, definition)
+		owned, lerr := e.DB.AcquireLease(collectorLeaseName(definitionURI), collectorLeaseTTL)
+		if lerr != nil {
+			l.LogWithFields(ctx).Error("error while trying to acquire the lease of the MetricReportDefinition " + definitionURI + ": " + lerr.Error())
+		}
+		_, running := collectors.get(definitionURI)
+		switch {
+		case owned && !running:
+			c := newMetricReportCollector(definition)
+			e.restoreCollectorState(ctx, c)
+			e.startMetricReportCollector(ctx, c)
+		case !owned && running:
+			// another replica has taken over the definition
+			collectors.remove(definitionURI)
+		}
+	}
+	for _, c := range collectors.all() {
+		if _, ok := definitions[c.definition.ODataID]; !ok {
+			// the definition is deleted through another replica
+			collectors.remove(c.definition.ODataID)
+		}
+	}
+
+	keys, err := e.DB.GetAllKeysFromTable(aggregatedTriggersTable, common.OnDisk)
+	if err != nil {
+		l.LogWithFields(ctx).Error("error while trying to get the Triggers: " + err.Error())
+		return
+	}
+	var triggers []dmtf.Triggers
+	for _, key := range keys {
+		data, gerr := e.DB.GetResource(aggregatedTriggersTable, key, common.OnDisk)
+		if gerr != nil {
+			l.LogWithFields(ctx).Error("error while trying to get the Trigger " + key + ": " + gerr.Error())
+			continue
+		}
+		var trigger dmtf.Triggers
+		if err := json.Unmarshal([]byte(data), &trigger); err != nil {
+			l.LogWithFields(ctx).Error("error while trying to unmarshal the Trigger " + key + ": " + err.Error())
+			continue
+		}
+		e.restoreInMemoryCopy(ctx, "Triggers", "TriggersCollection", triggersURI, trigger.ODataID, trigger)
+		triggers = append(triggers, trigger)
+	}
+	setTriggers(triggers)
+}
+
+// getAggregatedDefinitions returns the MetricReportDefinitions created in ODIM against their URIs
+func (e *ExternalInterface) getAggregatedDefinitions(ctx context.Context) (map[string]dmtf.MetricReportDefinitions, error) {
+	keys, err := e.DB.GetAllKeysFromTable(aggregatedDefinitionsTable, common.OnDisk)
+	if err != nil {
+		return nil, err
+	}
+	definitions := make(map[string]dmtf.MetricReportDefinitions)
+	for _, key := range keys {
+		definition, gerr := e.getAggregatedDefinition(key)
+		if gerr != nil {
+			l.LogWithFields(ctx).Error("error while trying to get the MetricReportDefinition " + key + ": " + gerr.Error())
+			continue
+		}
+		definitions[key] = definition
+	}
+	return definitions, nil
+}
+
+// getAggregatedDefinition returns the MetricReportDefinition created in ODIM
+func (e *ExternalInterface) getAggregatedDefinition(definitionURI string) (dmtf.MetricReportDefinitions, *errors.Error) {
+	var definition dmtf.MetricReportDefinitions
+	data, gerr := e.DB.GetResource(aggregatedDefinitionsTable, definitionURI, common.OnDisk)
+	if gerr != nil {
+		return definition, gerr
+	}
+	if err := json.Unmarshal([]byte(data), &definition); err != nil {
+		return definition, errors.PackError(errors.UndefinedErrorType, "error while trying to unmarshal the MetricReportDefinition: ", err)
+	}
+	return definition, nil
+}
+
+// restoreInMemoryCopy saves the resource created in ODIM in the InMemory DB and adds it
+// to its collection, when it is lost as the InMemory DB got restarted
+func (e *ExternalInterface) restoreInMemoryCopy(ctx context.Context, table, collectionTable, collectionURI, uri string, resource interface{}) {
+	_, gerr := e.DB.GetResource(table, uri, common.InMemory)
+	if gerr == nil || gerr.ErrNo() != errors.DBKeyNotFound {
+		return
+	}
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return
+	}
+	if err := e.External.GenericSave(ctx, data, table, uri); err != nil {
+		l.LogWithFields(ctx).Error("error while trying to restore " + uri + ": " + err.Error())
+		return
+	}
+	if err := e.addCollectionMember(ctx, collectionTable, collectionURI, uri); err != nil {
+		l.LogWithFields(ctx).Error("error while trying to restore " + uri + " in its collection: " + err.Error())
+	}
+}
+
+// takeOverMetricReportDefinition starts collecting the metric properties of the MetricReportDefinition
+// if the replica gets its lease, else the replica holding the lease collects them
+func (e *ExternalInterface) takeOverMetricReportDefinition(ctx context.Context, definition dmtf.MetricReportDefinitions) {
+	owned, err := e.DB.AcquireLease(collectorLeaseName(definition.ODataID), collectorLeaseTTL)
+	if err != nil {
+		l.LogWithFields(ctx).Error("error while trying to acquire the lease of the MetricReportDefinition " + definition.ODataID + ": " + err.Error())
+		return
+	}
+	if owned {
+		e.startMetricReportCollector(ctx, newMetricReportCollector(definition))
+	}
+}
+
+// collectorLeaseName is the name of the lease of the MetricReportDefinition
+func collectorLeaseName(definitionURI string) string {
+	return aggregatedDefinitionsTable + ":" + definitionURI
+}
+
+// saveCollectorState saves the samples and the report of the collector run by the replica
+func (e *ExternalInterface) saveCollectorState(ctx context.Context, c *metricReportCollector) {
+	if running, ok := collectors.get(c.definition.ODataID); !ok || running != c {
+		return
+	}
+	c.lock.Lock()
+	state := collectorState{
+		Samples:  make(map[string][]collectorStateSample, len(c.samples)),
+		Report:   c.report,
+		Sequence: c.sequence,
+	}
+	for property, samples := range c.samples {
+		for _, sample := range samples {
+			state.Samples[property] = append(state.Samples[property], collectorStateSample{Value: sample.value, Timestamp: sample.timestamp})
+		}
+	}
+	data, err := json.Marshal(state)
+	c.lock.Unlock()
+	if err != nil {
+		l.LogWithFields(ctx).Error("error while trying to marshal the state of the MetricReportDefinition " + c.definition.ODataID + ": " + err.Error())
+		return
+	}
+	if err := e.DB.SaveResource(collectorStateTable, c.definition.ODataID, data, common.InMemory); err != nil {
+		l.LogWithFields(ctx).Error("error while trying to save the state of the MetricReportDefinition " + c.definition.ODataID + ": " + err.Error())
+	}
+}
+
+// restoreCollectorState loads the samples and the report of the collector saved by the replica
+// which collected the metric properties of the MetricReportDefinition before
+func (e *ExternalInterface) restoreCollectorState(ctx context.Context, c *metricReportCollector) {
+	data, gerr := e.DB.GetResource(collectorStateTable, c.definition.ODataID, common.InMemory)
+	if gerr != nil {
+		if gerr.ErrNo() != errors.DBKeyNotFound {
+			l.LogWithFields(ctx).Error("error while trying to get the state of the MetricReportDefinition " + c.definition.ODataID + ": " + gerr.Error())
+		}
+		return
+	}
+	var state collectorState
+	if err := json.Unmarshal([]byte(data), &state); err != nil {
+		l.LogWithFields(ctx).Error("error while trying to unmarshal the state of the MetricReportDefinition " + c.definition.ODataID + ": " + err.Error())
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for property, samples := range state.Samples {
+		if _, ok := c.retention[property]; !ok {
+			continue
+		}
+		for _, sample := range samples {
+			c.samples[property] = append(c.samples[property], metricSample{value: sample.Value, timestamp: sample.Timestamp})
+		}
+	}
+	c.report = state.Report
+	c.sequence = state.Sequence
+}
+
+func (e *ExternalInterface) startMetricReportCollector(ctx context.Context, c *metricReportCollector) {
+	ctx = context.WithValue(common.CreateNewRequestContext(ctx), common.ThreadName, common.CollectMetricReport)
+	collectors.add(c)
+	go e.runMetricReportCollector(ctx, c)
+}
+
+// runMetricReportCollector collects the metric properties at the sampling interval
+// and generates the MetricReport as per the MetricReportDefinitionType, till the
+// MetricReportDefinition is deleted
+func (e *ExternalInterface) runMetricReportCollector(ctx context.Context, c *metricReportCollector) {
+	samplingInterval := metricSamplingInterval
+	if c.recurrence > 0 && c.recurrence < samplingInterval {
+		samplingInterval = c.recurrence
+	}
+	sampling := time.NewTicker(samplingInterval)
+	defer sampling.Stop()
+	var recurrence <-chan time.Time
+	if c.recurrence > 0 {
+		ticker := time.NewTicker(c.recurrence)
+		defer ticker.Stop()
+		recurrence = ticker.C
+	}
+
+	e.collectMetrics(ctx, c, time.Now())
+	for {
+		select {
+		case <-c.stop:
+			return
+		case now := <-sampling.C:
+			e.collectMetrics(ctx, c, now)
+		case now := <-recurrence:
+			e.generateMetricReport(ctx, c, now)
+		}
+	}
+}
+
+// collectMetrics collects the metric properties from the BMCs, the MetricReport is generated
+// when the values are changed for OnChange definitions and when the Triggers are met
+func (e *ExternalInterface) collectMetrics(ctx context.Context, c *metricReportCollector, now time.Time) {
+	values := e.getMetricValues(ctx, c.properties)
+
+	c.lock.Lock()
+	var changed, triggered bool
+	for property, value := range values {
+		samples := c.samples[property]
+		if len(samples) == 0 {
+			changed = true
+		} else if previous := samples[len(samples)-1].value; previous != value {
+			changed = true
+			triggered = triggered || c.isTriggered(property, previous, value)
+		}
+		c.samples[property] = append(samples, metricSample{value: value, timestamp: now})
+	}
+	c.pruneSamples(now)
+	c.lock.Unlock()
+
+	if (changed && c.definition.MetricReportDefinitionType == "OnChange") || triggered {
+		e.generateMetricReport(ctx, c, now)
+	}
+	e.saveCollectorState(ctx, c)
+}
+
+// getMetricValues gets the values of the metric properties from the BMCs,
+// each resource is fetched only once for all of its metric properties
+func (e *ExternalInterface) getMetricValues(ctx context.Context, properties []string) map[string]string {
+	resources := make(map[string][]string)
+	for _, property := range properties {
+		resourceURI := property[:strings.Index(property, "#")]
+		resources[resourceURI] = append(resources[resourceURI], property)
+	}
+
+	values := make(map[string]string)
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	for resourceURI, resourceProperties := range resources {
+		wg.Add(1)
+		go func(resourceURI string, resourceProperties []string) {
+			defer wg.Done()
+			body, err := tcommon.GetResourceFromDevice(ctx, tcommon.ResourceInfoRequest{
+				URL:             resourceURI,
+				ContactClient:   e.External.ContactClient,
+				DevicePassword:  e.External.DevicePassword,
+				GetPluginStatus: e.External.GetPluginStatus,
+				GetPluginData:   e.External.GetPluginData,
+				GetTarget:       e.External.GetTarget,
+			})
+			if err != nil {
+				l.LogWithFields(ctx).Warn("unable to collect the metric properties of " + resourceURI + ": " + err.Error())
+				return
+			}
+			var resource interface{}
+			if err := json.Unmarshal(body, &resource); err != nil {
+				l.LogWithFields(ctx).Warn("unable to unmarshal the resource " + resourceURI + ": " + err.Error())
+				return
+			}
+			lock.Lock()
+			defer lock.Unlock()
+			for _, property := range resourceProperties {
+				if value, ok := getPropertyValue(resource, property[strings.Index(property, "#")+1:]); ok {
+					values[property] = value
+				}
+			}
+		}(resourceURI, resourceProperties)
+	}
+	wg.Wait()
+	return values
+}
+
+// getPropertyValue gets the value of the property pointed by the JSON pointer in the resource
+func getPropertyValue(resource interface{}, pointer string) (string, bool) {
+	unescape := strings.NewReplacer("~1", "/", "~0", "~")
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		token = unescape.Replace(token)
+		switch node := resource.(type) {
+		case map[string]interface{}:
+			value, ok := node[token]
+			if !ok {
+				return "", false
+			}
+			resource = value
+		case []interface{}:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(node) {
+				return "", false
+			}
+			resource = node[index]
+		default:
+			return "", false
+		}
+	}
+	switch value := resource.(type) {
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64), true
+	case string:
+		return value, true
+	case bool:
+		return strconv.FormatBool(value), true
+	}
+	return "", false
+}
+
+// pruneSamples removes the samples which are older than the CollectionDuration of
+// the metric properties, the latest sample of each property is always retained
+func (c *metricReportCollector) pruneSamples(now time.Time) {
+	for property, samples := range c.samples {
+		since := now.Add(-c.retention[property])
+		index := 0
+		for index < len(samples)-1 && samples[index].timestamp.Before(since) {
+			index++
+		}
+		c.samples[property] = samples[index:]
+	}
+}
+
+// isTriggered checks whether any of the Triggers linked to the MetricReportDefinition
+// is met by the change in the value of the metric property
+func (c *metricReportCollector) isTriggered(property, previous, current string) bool {
+	previousValue, err := strconv.ParseFloat(previous, 64)
+	if err != nil {
+		return false
+	}
+	currentValue, err := strconv.ParseFloat(current, 64)
+	if err != nil {
+		return false
+	}
+	for _, trigger := range c.triggers {
+		if len(trigger.MetricProperties) > 0 && !isStringPresent(expandWildcards(trigger.MetricProperties, trigger.Wildcards), property) {
+			continue
+		}
+		thresholds := trigger.NumericThresholds
+		for _, threshold := range []dmtf.Threshold{thresholds.UpperCritical, thresholds.UpperWarning, thresholds.LowerWarning, thresholds.LowerCritical} {
+			if isThresholdCrossed(threshold, previousValue, currentValue) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isThresholdCrossed(threshold dmtf.Threshold, previous, current float64) bool {
+	reading := float64(threshold.Reading)
+	increasing := previous < reading && current >= reading
+	decreasing := previous > reading && current <= reading
+	switch threshold.Activation {
+	case "Increasing":
+		return increasing
+	case "Decreasing":
+		return decreasing
+	case "Either":
+		return increasing || decreasing
+	}
+	return false
+}
+
+func isStringPresent(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// generateMetricReport generates the MetricReport from the collected samples,
+// and performs the ReportActions of the MetricReportDefinition with it
+func (e *ExternalInterface) generateMetricReport(ctx context.Context, c *metricReportCollector, now time.Time) dmtf.MetricReports {
+	c.lock.Lock()
+	report := c.buildReport(now)
+	c.lock.Unlock()
+	e.saveCollectorState(ctx, c)
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		l.LogWithFields(ctx).Error("error while trying to marshal the MetricReport " + report.ODataID + ": " + err.Error())
+		return report
+	}
+	for _, action := range c.definition.ReportActions {
+		switch action {
+		case "LogToMetricReportsCollection":
+			if _, gerr := e.DB.GetResource(aggregatedDefinitionsTable, c.definition.ODataID, common.OnDisk); gerr != nil {
+				// the MetricReport is removed along with its definition
+				continue
+			}
+			if err := e.External.GenericSave(ctx, data, "MetricReports", report.ODataID); err != nil {
+				l.LogWithFields(ctx).Error("error while trying to save the MetricReport " + report.ODataID + ": " + err.Error())
+				continue
+			}
+			if err := e.addCollectionMember(ctx, "MetricReportsCollection", metricReportsURI, report.ODataID); err != nil {
+				l.LogWithFields(ctx).Error("error while trying to update the MetricReport collection: " + err.Error())
+			}
+		case "RedfishEvent":
+			e.External.PublishMetricReport(ctx, data)
+		}
+	}
+	return report
+}
+
+// buildReport computes the values of the metrics with the CollectionFunction applied
+// over the samples collected in the CollectionDuration
+func (c *metricReportCollector) buildReport(now time.Time) dmtf.MetricReports {
+	var values []dmtf.MetricValue
+	for _, metric := range c.metrics {
+		for _, property := range metric.properties {
+			samples := c.samples[property]
+			if len(samples) == 0 {
+				continue
+			}
+			latest := samples[len(samples)-1]
+			value := latest.value
+			if metric.function != "" {
+				var ok bool
+				if value, ok = aggregateSamples(metric.function, samples, now.Add(-metric.duration)); !ok {
+					continue
+				}
+			}
+			values = append(values, dmtf.MetricValue{
+				MetricID:       metric.id,
+				MetricProperty: property,
+				MetricValue:    value,
+				Timestamp:      latest.timestamp.UTC().Format(time.RFC3339),
+			})
+		}
+	}
+
+	switch c.definition.ReportUpdates {
+	case "AppendWrapsWhenFull", "AppendStopsWhenFull":
+		if c.report != nil {
+			values = append(c.report.MetricValues, values...)
+		}
+		if limit := c.definition.AppendLimit; len(values) > limit {
+			if c.definition.ReportUpdates == "AppendWrapsWhenFull" {
+				values = values[len(values)-limit:]
+			} else {
+				values = values[:limit]
+			}
+		}
+	}
+
+	c.sequence++
+	c.report = &dmtf.MetricReports{
+		ODataID:                c.definition.MetricReport.ODataID,
+		ODataType:              metricReportType,
+		ODataContext:           "/redfish/v1/$metadata#MetricReport.MetricReport",
+		ID:                     c.definition.ID,
+		Name:                   c.definition.Name,
+		MetricReportDefinition: dmtf.Oid{ODataID: c.definition.ODataID},
+		MetricValues:           values,
+		ReportSequence:         strconv.Itoa(c.sequence),
+		Timestamp:              now.UTC().Format(time.RFC3339),
+	}
+	return *c.report
+}
+
+// aggregateSamples applies the CollectionFunction on the numeric samples collected since the given time
+func aggregateSamples(function string, samples []metricSample, since time.Time) (string, bool) {
+	var result float64
+	var count int
+	for _, sample := range samples {
+		if sample.timestamp.Before(since) {
+			continue
+		}
+		value, err := strconv.ParseFloat(sample.value, 64)
+		if err != nil {
+			continue
+		}
+		switch {
+		case count == 0:
+			result = value
+		case function == "Maximum" && value > result, function == "Minimum" && value < result:
+			result = value
+		case function == "Average", function == "Summation":
+			result += value
+		}
+		count++
+	}
+	if count == 0 {
+		return "", false
+	}
+	if function == "Average" {
+		result /= float64(count)
+	}
+	return strconv.FormatFloat(result, 'f', -1, 64), true
+}
+
+// getAggregatedMetricReport gets the MetricReport generated by the telemetry service,
+// the report of the OnRequest MetricReportDefinition is generated on the request
+func (e *ExternalInterface) getAggregatedMetricReport(ctx context.Context, c *metricReportCollector, req *teleproto.TelemetryRequest) response.RPC {
+	var resp response.RPC
+	if c.definition.MetricReportDefinitionType == "OnRequest" {
+		resp.Body = e.generateMetricReport(ctx, c, time.Now())
+		resp.StatusCode = http.StatusOK
+		resp.StatusMessage = response.Success
+		return resp
+	}
+	data, gerr := e.DB.GetResource("MetricReports", req.URL, common.InMemory)
+	if gerr != nil {
+		l.LogWithFields(ctx).Warn("Unable to get MetricReport details : " + gerr.Error())
+		return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, gerr.Error(), []interface{}{"MetricReport", req.URL}, nil)
+	}
+	var resource map[string]interface{}
+	json.Unmarshal([]byte(data), &resource)
+	resp.Body = resource
+	resp.StatusCode = http.StatusOK
+	resp.StatusMessage = response.Success
+	return resp
+}
+
+// attachTrigger links the Trigger to the collectors of its MetricReportDefinitions
+func attachTrigger(trigger dmtf.Triggers) {
+	for _, definition := range trigger.Links.MetricReportDefinitions {
+		if c, ok := collectors.get(definition.ODataID); ok {
+			c.lock.Lock()
+			c.triggers[trigger.ODataID] = trigger
+			c.lock.Unlock()
+		}
+	}
+}
+
+// detachTrigger removes the Trigger from all the collectors
+func detachTrigger(triggerURI string) {
+	for _, c := range collectors.all() {
+		c.lock.Lock()
+		delete(c.triggers, triggerURI)
+		c.lock.Unlock()
+	}
+}
+
+// setTriggers links the Triggers to the collectors of their MetricReportDefinitions,
+// replacing the Triggers linked to the collectors before
+func setTriggers(triggers []dmtf.Triggers) {
+	for _, c := range collectors.all() {
+		c.lock.Lock()
+		c.triggers = make(map[string]dmtf.Triggers)
+		for _, trigger := range triggers {
+			for _, definition := range trigger.Links.MetricReportDefinitions {
+				if definition.ODataID == c.definition.ODataID {
+					c.triggers[trigger.ODataID] = trigger
+				}
+			}
+		}
+		c.lock.Unlock()
+	}
+}
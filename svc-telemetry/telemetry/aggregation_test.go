@@ -0,0 +1,360 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	teleproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/telemetry"
+)
+
+func mockSamples(now time.Time, values ...string) []metricSample {
+	var samples []metricSample
+	for i, value := range values {
+		samples = append(samples, metricSample{
+			value:     value,
+			timestamp: now.Add(time.Duration(i-len(values)+1) * time.Minute),
+		})
+	}
+	return samples
+}
+
+func Test_aggregateSamples(t *testing.T) {
+	now := time.Now()
+	samples := mockSamples(now, "40", "10", "NaN-value", "30", "20")
+	tests := []struct {
+		function string
+		since    time.Time
+		want     string
+		wantOk   bool
+	}{
+		{function: "Average", since: now.Add(-time.Hour), want: "25", wantOk: true},
+		{function: "Maximum", since: now.Add(-time.Hour), want: "40", wantOk: true},
+		{function: "Minimum", since: now.Add(-time.Hour), want: "10", wantOk: true},
+		{function: "Summation", since: now.Add(-time.Hour), want: "100", wantOk: true},
+		{function: "Maximum", since: now.Add(-90 * time.Second), want: "30", wantOk: true},
+		{function: "Average", since: now.Add(time.Minute), wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.function, func(t *testing.T) {
+			got, ok := aggregateSamples(tt.function, samples, tt.since)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("aggregateSamples() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func Test_getPropertyValue(t *testing.T) {
+	var resource interface{}
+	json.Unmarshal([]byte(`{"PowerControl":[{"PowerConsumedWatts":245.5,"Status":{"State":"Enabled"}}],"a/b":true}`), &resource)
+	tests := []struct {
+		pointer string
+		want    string
+		wantOk  bool
+	}{
+		{pointer: "/PowerControl/0/PowerConsumedWatts", want: "245.5", wantOk: true},
+		{pointer: "/PowerControl/0/Status/State", want: "Enabled", wantOk: true},
+		{pointer: "/a~1b", want: "true", wantOk: true},
+		{pointer: "/PowerControl/1/PowerConsumedWatts", wantOk: false},
+		{pointer: "/PowerControl/0/Status", wantOk: false},
+		{pointer: "/Voltages", wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pointer, func(t *testing.T) {
+			got, ok := getPropertyValue(resource, tt.pointer)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("getPropertyValue() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func Test_isThresholdCrossed(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold dmtf.Threshold
+		previous  float64
+		current   float64
+		want      bool
+	}{
+		{name: "Increasing crossed", threshold: dmtf.Threshold{Activation: "Increasing", Reading: 90}, previous: 80, current: 95, want: true},
+		{name: "Increasing not crossed", threshold: dmtf.Threshold{Activation: "Increasing", Reading: 90}, previous: 95, current: 80},
+		{name: "Decreasing crossed", threshold: dmtf.Threshold{Activation: "Decreasing", Reading: 10}, previous: 20, current: 5, want: true},
+		{name: "Either crossed", threshold: dmtf.Threshold{Activation: "Either", Reading: 50}, previous: 60, current: 40, want: true},
+		{name: "No activation", threshold: dmtf.Threshold{Reading: 50}, previous: 40, current: 60},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isThresholdCrossed(tt.threshold, tt.previous, tt.current); got != tt.want {
+				t.Errorf("isThresholdCrossed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_metricReportCollector_buildReport(t *testing.T) {
+	property := "/redfish/v1/Chassis/{ChassisID}/Power#/PowerControl/0/PowerConsumedWatts"
+	c := newMetricReportCollector(dmtf.MetricReportDefinitions{
+		ODataID:                    metricReportDefinitionsURI + "/PowerMetrics",
+		ID:                         "PowerMetrics",
+		MetricReportDefinitionType: "OnRequest",
+		MetricReport:               dmtf.Oid{ODataID: metricReportsURI + "/PowerMetrics"},
+		ReportUpdates:              "AppendWrapsWhenFull",
+		AppendLimit:                3,
+		Wildcards:                  []dmtf.WildCard{{Name: "ChassisID", Values: []string{"uuid.1", "uuid2.1"}}},
+		Metrics: []dmtf.Metric{{
+			MetricID:           "MaxConsumedWatts",
+			CollectionFunction: "Maximum",
+			CollectionDuration: "PT10M",
+			MetricProperties:   []string{property},
+		}},
+	})
+	if len(c.properties) != 2 {
+		t.Fatalf("newMetricReportCollector() properties = %v, want 2 properties", c.properties)
+	}
+	now := time.Now()
+	c.samples[c.properties[0]] = mockSamples(now, "100", "300", "200")
+	c.samples[c.properties[1]] = mockSamples(now, "50")
+
+	report := c.buildReport(now)
+	if len(report.MetricValues) != 2 || report.MetricValues[0].MetricValue != "300" || report.ReportSequence != "1" {
+		t.Errorf("buildReport() = %v, want the maximum of the samples", report)
+	}
+	report = c.buildReport(now)
+	if len(report.MetricValues) != 3 || report.ReportSequence != "2" {
+		t.Errorf("buildReport() = %v, want the metric values wrapped at the AppendLimit", report)
+	}
+}
+
+func TestExternalInterface_GetMetricReportOfAggregatedDefinition(t *testing.T) {
+	ctx := mockContext()
+	for _, definitionType := range []string{"OnRequest", "OnChange"} {
+		c := newMetricReportCollector(dmtf.MetricReportDefinitions{
+			ODataID:                    metricReportDefinitionsURI + "/" + definitionType,
+			ID:                         definitionType,
+			MetricReportDefinitionType: definitionType,
+			MetricReport:               dmtf.Oid{ODataID: metricReportsURI + "/" + definitionType},
+			ReportActions:              []string{"LogToMetricReportsCollection", "RedfishEvent"},
+			MetricProperties:           []string{"/redfish/v1/Chassis/uuid.1/Power#/PowerControl/0/PowerConsumedWatts"},
+		})
+		collectors.add(c)
+	}
+	defer collectors.remove(metricReportDefinitionsURI + "/OnRequest")
+	defer collectors.remove(metricReportDefinitionsURI + "/OnChange")
+
+	tests := []struct {
+		name string
+		url  string
+		want int
+	}{
+		{name: "OnRequest report", url: metricReportsURI + "/OnRequest", want: http.StatusOK},
+		{name: "OnChange report", url: metricReportsURI + "/OnChange", want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := MockGetExternalInterface()
+			got := e.GetMetricReport(ctx, &teleproto.TelemetryRequest{URL: tt.url})
+			if int(got.StatusCode) != tt.want {
+				t.Errorf("ExternalInterface.GetMetricReport() = %v, want %v", got.StatusCode, tt.want)
+			}
+		})
+	}
+}
+
+// fakeAggregationStore keeps the resources of the OnDisk and InMemory DBs and
+// the leases held by the replica under test
+type fakeAggregationStore struct {
+	lock      sync.Mutex
+	resources map[common.DbType]map[string]map[string]string
+	leases    map[string]bool
+}
+
+func newFakeAggregationStore() *fakeAggregationStore {
+	return &fakeAggregationStore{
+		resources: map[common.DbType]map[string]map[string]string{common.OnDisk: {}, common.InMemory: {}},
+		leases:    make(map[string]bool),
+	}
+}
+
+func (f *fakeAggregationStore) save(table, key string, body []byte, dbType common.DbType) *errors.Error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.resources[dbType][table] == nil {
+		f.resources[dbType][table] = make(map[string]string)
+	}
+	f.resources[dbType][table][key] = string(body)
+	return nil
+}
+
+func (f *fakeAggregationStore) get(table, key string, dbType common.DbType) (string, *errors.Error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	data, ok := f.resources[dbType][table][key]
+	if !ok {
+		return "", errors.PackError(errors.DBKeyNotFound, "no data with the with key "+key+" found")
+	}
+	return data, nil
+}
+
+func (f *fakeAggregationStore) keys(table string, dbType common.DbType) ([]string, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	var keys []string
+	for key := range f.resources[dbType][table] {
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (f *fakeAggregationStore) delete(table, key string, dbType common.DbType) *errors.Error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	delete(f.resources[dbType][table], key)
+	return nil
+}
+
+func (f *fakeAggregationStore) externalInterface() *ExternalInterface {
+	e := MockGetExternalInterface()
+	e.External.GenericSave = func(ctx context.Context, body []byte, table, key string) error {
+		f.save(table, key, body, common.InMemory)
+		return nil
+	}
+	e.DB = DB{
+		GetAllKeysFromTable: f.keys,
+		GetResource:         f.get,
+		SaveResource:        f.save,
+		DeleteResource:      f.delete,
+		AcquireLease: func(name string, ttl time.Duration) (bool, *errors.Error) {
+			f.lock.Lock()
+			defer f.lock.Unlock()
+			return f.leases[name], nil
+		},
+		ReleaseLease: func(name string) *errors.Error {
+			return nil
+		},
+	}
+	return e
+}
+
+func TestExternalInterface_reconcileMetricReportCollectors(t *testing.T) {
+	config.SetUpMockConfig(t)
+	ctx := mockContext()
+	store := newFakeAggregationStore()
+	e := store.externalInterface()
+
+	definitionURI := metricReportDefinitionsURI + "/Reconciled"
+	definition, _ := json.Marshal(dmtf.MetricReportDefinitions{
+		ODataID:                    definitionURI,
+		ID:                         "Reconciled",
+		MetricReportDefinitionType: "OnRequest",
+		MetricReport:               dmtf.Oid{ODataID: metricReportsURI + "/Reconciled"},
+		MetricProperties:           []string{"/redfish/v1/Chassis/uuid.1/Power#/PowerControl/0/PowerConsumedWatts"},
+	})
+	trigger, _ := json.Marshal(dmtf.Triggers{
+		ODataID: triggersURI + "/Reconciled",
+		Links:   dmtf.TriggerLinks{MetricReportDefinitions: []dmtf.Oid{{ODataID: definitionURI}}},
+	})
+	store.save(aggregatedDefinitionsTable, definitionURI, definition, common.OnDisk)
+	store.save(aggregatedTriggersTable, triggersURI+"/Reconciled", trigger, common.OnDisk)
+	defer collectors.remove(definitionURI)
+
+	// the lease is held by another replica
+	e.reconcileMetricReportCollectors(ctx)
+	if _, ok := collectors.get(definitionURI); ok {
+		t.Errorf("the definition is collected without holding its lease")
+	}
+	if _, err := store.get("MetricReportDefinitions", definitionURI, common.InMemory); err != nil {
+		t.Errorf("the InMemory copy of the definition is not restored: %v", err)
+	}
+	if _, err := store.get("Triggers", triggersURI+"/Reconciled", common.InMemory); err != nil {
+		t.Errorf("the InMemory copy of the Trigger is not restored: %v", err)
+	}
+
+	// the lease has expired and is taken over
+	store.leases[collectorLeaseName(definitionURI)] = true
+	e.reconcileMetricReportCollectors(ctx)
+	c, ok := collectors.get(definitionURI)
+	if !ok {
+		t.Fatalf("the definition is not collected after taking over its lease")
+	}
+	c.lock.Lock()
+	_, attached := c.triggers[triggersURI+"/Reconciled"]
+	c.lock.Unlock()
+	if !attached {
+		t.Errorf("the Trigger is not linked to the collector")
+	}
+
+	// the renewal succeeds, the running collector is kept
+	e.reconcileMetricReportCollectors(ctx)
+	if running, _ := collectors.get(definitionURI); running != c {
+		t.Errorf("the running collector is replaced while holding the lease")
+	}
+
+	// the lease is lost
+	store.leases[collectorLeaseName(definitionURI)] = false
+	e.reconcileMetricReportCollectors(ctx)
+	if _, ok := collectors.get(definitionURI); ok {
+		t.Errorf("the definition is collected after losing its lease")
+	}
+
+	// the definition is deleted through another replica
+	store.leases[collectorLeaseName(definitionURI)] = true
+	e.reconcileMetricReportCollectors(ctx)
+	store.delete(aggregatedDefinitionsTable, definitionURI, common.OnDisk)
+	e.reconcileMetricReportCollectors(ctx)
+	if _, ok := collectors.get(definitionURI); ok {
+		t.Errorf("the definition is collected after it is deleted")
+	}
+}
+
+func TestExternalInterface_restoreCollectorState(t *testing.T) {
+	config.SetUpMockConfig(t)
+	ctx := mockContext()
+	store := newFakeAggregationStore()
+	e := store.externalInterface()
+	definition := dmtf.MetricReportDefinitions{
+		ODataID:                    metricReportDefinitionsURI + "/Restored",
+		ID:                         "Restored",
+		MetricReportDefinitionType: "OnRequest",
+		MetricReport:               dmtf.Oid{ODataID: metricReportsURI + "/Restored"},
+		MetricProperties:           []string{"/redfish/v1/Chassis/uuid.1/Power#/PowerControl/0/PowerConsumedWatts"},
+	}
+	property := definition.MetricProperties[0]
+
+	previous := newMetricReportCollector(definition)
+	previous.samples[property] = mockSamples(time.Now(), "10", "20")
+	collectors.add(previous)
+	defer collectors.remove(definition.ODataID)
+	e.generateMetricReport(ctx, previous, time.Now())
+
+	c := newMetricReportCollector(definition)
+	e.restoreCollectorState(ctx, c)
+	if len(c.samples[property]) != 2 || c.samples[property][1].value != "20" {
+		t.Errorf("the samples are not restored, got %v", c.samples[property])
+	}
+	if c.sequence != 1 || c.report == nil {
+		t.Errorf("the report is not restored, got sequence %d", c.sequence)
+	}
+}
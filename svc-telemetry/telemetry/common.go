@@ -17,6 +17,7 @@ package telemetry
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/ODIM-Project/ODIM/lib-rest-client/pmbhandle"
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
@@ -24,6 +25,7 @@ import (
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
 	"github.com/ODIM-Project/ODIM/lib-utilities/services"
 	"github.com/ODIM-Project/ODIM/svc-telemetry/tcommon"
+	"github.com/ODIM-Project/ODIM/svc-telemetry/tmessagebus"
 	"github.com/ODIM-Project/ODIM/svc-telemetry/tmodel"
 )
 
@@ -35,15 +37,16 @@ type ExternalInterface struct {
 
 // External struct holds the function pointers all outboud services
 type External struct {
-	ContactClient      func(context.Context, string, string, string, string, interface{}, map[string]string) (*http.Response, error)
-	Auth               func(string, []string, []string) (response.RPC, error)
-	DevicePassword     func([]byte) ([]byte, error)
-	GetPluginData      func(string) (tmodel.Plugin, *errors.Error)
-	ContactPlugin      func(context.Context, tcommon.PluginContactRequest, string) ([]byte, string, tcommon.ResponseStatus, error)
-	GetTarget          func(string) (*tmodel.Target, *errors.Error)
-	GetSessionUserName func(string) (string, error)
-	GenericSave        func(context.Context, []byte, string, string) error
-	GetPluginStatus    func(context.Context, tmodel.Plugin) bool
+	ContactClient       func(context.Context, string, string, string, string, interface{}, map[string]string) (*http.Response, error)
+	Auth                func(string, []string, []string) (response.RPC, error)
+	DevicePassword      func([]byte) ([]byte, error)
+	GetPluginData       func(string) (tmodel.Plugin, *errors.Error)
+	ContactPlugin       func(context.Context, tcommon.PluginContactRequest, string) ([]byte, string, tcommon.ResponseStatus, error)
+	GetTarget           func(string) (*tmodel.Target, *errors.Error)
+	GetSessionUserName  func(string) (string, error)
+	GenericSave         func(context.Context, []byte, string, string) error
+	GetPluginStatus     func(context.Context, tmodel.Plugin) bool
+	PublishMetricReport func(context.Context, []byte)
 }
 
 type responseStatus struct {
@@ -56,25 +59,34 @@ type responseStatus struct {
 type DB struct {
 	GetAllKeysFromTable func(string, common.DbType) ([]string, error)
 	GetResource         func(string, string, common.DbType) (string, *errors.Error)
+	SaveResource        func(string, string, []byte, common.DbType) *errors.Error
+	DeleteResource      func(string, string, common.DbType) *errors.Error
+	AcquireLease        func(string, time.Duration) (bool, *errors.Error)
+	ReleaseLease        func(string) *errors.Error
 }
 
 // GetExternalInterface retrieves all the external connections update package functions uses
 func GetExternalInterface() *ExternalInterface {
 	return &ExternalInterface{
 		External: External{
-			ContactClient:      pmbhandle.ContactPlugin,
-			Auth:               services.IsAuthorized,
-			DevicePassword:     common.DecryptWithPrivateKey,
-			GetPluginData:      tmodel.GetPluginData,
-			ContactPlugin:      tcommon.ContactPlugin,
-			GetTarget:          tmodel.GetTarget,
-			GetSessionUserName: services.GetSessionUserName,
-			GenericSave:        tmodel.GenericSave,
-			GetPluginStatus:    tcommon.GetPluginStatus,
+			ContactClient:       pmbhandle.ContactPlugin,
+			Auth:                services.IsAuthorized,
+			DevicePassword:      common.DecryptWithPrivateKey,
+			GetPluginData:       tmodel.GetPluginData,
+			ContactPlugin:       tcommon.ContactPlugin,
+			GetTarget:           tmodel.GetTarget,
+			GetSessionUserName:  services.GetSessionUserName,
+			GenericSave:         tmodel.GenericSave,
+			GetPluginStatus:     tcommon.GetPluginStatus,
+			PublishMetricReport: tmessagebus.PublishMetricReport,
 		},
 		DB: DB{
 			GetAllKeysFromTable: tmodel.GetAllKeysFromTable,
 			GetResource:         tmodel.GetResource,
+			SaveResource:        tmodel.SaveResource,
+			DeleteResource:      tmodel.DeleteResource,
+			AcquireLease:        common.AcquireLease,
+			ReleaseLease:        common.ReleaseLease,
 		},
 	}
 }
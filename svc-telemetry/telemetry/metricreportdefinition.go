@@ -0,0 +1,375 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+
+	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	teleproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/telemetry"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-telemetry/tcommon"
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	metricReportDefinitionsURI = "/redfish/v1/TelemetryService/MetricReportDefinitions"
+	metricReportsURI           = "/redfish/v1/TelemetryService/MetricReports"
+	triggersURI                = "/redfish/v1/TelemetryService/Triggers"
+
+	metricReportDefinitionType = "#MetricReportDefinition.v1_4_2.MetricReportDefinition"
+	metricReportType           = "#MetricReport.v1_4_2.MetricReport"
+	triggersType               = "#Triggers.v1_2_0.Triggers"
+
+	// aggregatedDefinitionsTable holds the MetricReportDefinitions created in ODIM,
+	// the reports of which are generated by the telemetry service, in the OnDisk DB
+	aggregatedDefinitionsTable = "AggregatedMetricReportDefinitions"
+	// aggregatedTriggersTable holds the Triggers created in ODIM, in the OnDisk DB
+	aggregatedTriggersTable = "AggregatedTriggers"
+)
+
+// collectionNames holds the schema names of the collections saved in the tables
+var collectionNames = map[string]string{
+	"MetricReportDefinitionsCollection": "MetricReportDefinitionCollection",
+	"MetricReportsCollection":           "MetricReportCollection",
+	"TriggersCollection":                "TriggersCollection",
+}
+
+// wildcardPattern matches the wildcards used in the MetricProperties, ex: {SystemID}
+var wildcardPattern = regexp.MustCompile(`{([^{}]+)}`)
+
+// CreateMetricReportDefinition creates a MetricReportDefinition in ODIM, the metric properties
+// of which are collected from the BMCs and the report is generated by the telemetry service
+func (e *ExternalInterface) CreateMetricReportDefinition(ctx context.Context, req *teleproto.TelemetryRequest) response.RPC {
+	var resp response.RPC
+	var definition dmtf.MetricReportDefinitions
+	if err := json.Unmarshal(req.RequestBody, &definition); err != nil {
+		errorMessage := "error while trying to unmarshal the request body: " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errorMessage, nil, nil)
+	}
+
+	// Validating the request JSON properties for case sensitive
+	invalidProperties, err := common.RequestParamsCaseValidator(req.RequestBody, definition)
+	if err != nil {
+		errorMessage := "error while validating request parameters: " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	} else if invalidProperties != "" {
+		errorMessage := "error: one or more properties given in the request body are not valid, ensure properties are listed in uppercamelcase "
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errorMessage, []interface{}{invalidProperties}, nil)
+	}
+
+	statusCode, statusMessage, messageArgs, err := validateMetricReportDefinition(&definition)
+	if err != nil {
+		errorMessage := "error: request payload validation failed: " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(statusCode, statusMessage, errorMessage, messageArgs, nil)
+	}
+
+	definition.ODataID = metricReportDefinitionsURI + "/" + definition.ID
+	definition.ODataType = metricReportDefinitionType
+	definition.MetricReport = dmtf.Oid{ODataID: metricReportsURI + "/" + definition.ID}
+	definition.MetricReportDefinitionEnabled = true
+	definition.Status = dmtf.Status{State: "Enabled", Health: "OK"}
+
+	if _, gerr := e.DB.GetResource("MetricReportDefinitions", definition.ODataID, common.InMemory); gerr == nil {
+		errorMessage := "error: MetricReportDefinition " + definition.ODataID + " already exists"
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusConflict, response.ResourceAlreadyExists, errorMessage, []interface{}{"MetricReportDefinition", "Id", definition.ID}, nil)
+	}
+
+	data, err := json.Marshal(definition)
+	if err != nil {
+		errorMessage := "error while trying to marshal the MetricReportDefinition: " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	if err := e.DB.SaveResource(aggregatedDefinitionsTable, definition.ODataID, data, common.OnDisk); err != nil {
+		errorMessage := "error while trying to save the MetricReportDefinition: " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	if err := e.External.GenericSave(ctx, data, "MetricReportDefinitions", definition.ODataID); err != nil {
+		errorMessage := "error while trying to save the MetricReportDefinition: " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	if err := e.addCollectionMember(ctx, "MetricReportDefinitionsCollection", metricReportDefinitionsURI, definition.ODataID); err != nil {
+		l.LogWithFields(ctx).Error("error while trying to update the MetricReportDefinition collection: " + err.Error())
+	}
+
+	e.takeOverMetricReportDefinition(ctx, definition)
+
+	resp.StatusCode = http.StatusCreated
+	resp.StatusMessage = response.Created
+	resp.Header = map[string]string{
+		"Location": definition.ODataID,
+	}
+	resp.Body = definition
+	return resp
+}
+
+// DeleteMetricReportDefinition deletes the MetricReportDefinition created in ODIM
+// along with the MetricReport generated for it
+func (e *ExternalInterface) DeleteMetricReportDefinition(ctx context.Context, req *teleproto.TelemetryRequest) response.RPC {
+	var resp response.RPC
+	if _, gerr := e.DB.GetResource(aggregatedDefinitionsTable, req.URL, common.OnDisk); gerr != nil {
+		errorMessage := gerr.Error()
+		if errors.DBKeyNotFound != gerr.ErrNo() {
+			l.LogWithFields(ctx).Error("Unable to get MetricReportDefinition details : " + errorMessage)
+			return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+		}
+		if _, gerr := e.DB.GetResource("MetricReportDefinitions", req.URL, common.InMemory); gerr == nil {
+			errorMessage = "error: MetricReportDefinition " + req.URL + " is defined by the BMC and can't be deleted"
+			l.LogWithFields(ctx).Error(errorMessage)
+			return common.GeneralError(http.StatusMethodNotAllowed, response.ActionNotSupported, errorMessage, []interface{}{http.MethodDelete}, nil)
+		}
+		l.LogWithFields(ctx).Warn("Unable to get MetricReportDefinition details : " + errorMessage)
+		return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errorMessage, []interface{}{"MetricReportDefinition", req.URL}, nil)
+	}
+
+	collectors.remove(req.URL)
+	if err := e.DB.ReleaseLease(collectorLeaseName(req.URL)); err != nil {
+		l.LogWithFields(ctx).Error("error while trying to release the lease of the MetricReportDefinition " + req.URL + ": " + err.Error())
+	}
+	reportURI := metricReportsURI + "/" + path.Base(req.URL)
+	for _, resource := range []struct {
+		table, key string
+		dbType     common.DbType
+	}{
+		{aggregatedDefinitionsTable, req.URL, common.OnDisk},
+		{"MetricReportDefinitions", req.URL, common.InMemory},
+		{"MetricReports", reportURI, common.InMemory},
+		{collectorStateTable, req.URL, common.InMemory},
+	} {
+		if derr := e.DB.DeleteResource(resource.table, resource.key, resource.dbType); derr != nil && errors.DBKeyNotFound != derr.ErrNo() {
+			errorMessage := "error while trying to delete the MetricReportDefinition: " + derr.Error()
+			l.LogWithFields(ctx).Error(errorMessage)
+			return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+		}
+	}
+	if err := e.removeCollectionMember(ctx, "MetricReportDefinitionsCollection", metricReportDefinitionsURI, req.URL); err != nil {
+		l.LogWithFields(ctx).Error("error while trying to update the MetricReportDefinition collection: " + err.Error())
+	}
+	if err := e.removeCollectionMember(ctx, "MetricReportsCollection", metricReportsURI, reportURI); err != nil {
+		l.LogWithFields(ctx).Error("error while trying to update the MetricReport collection: " + err.Error())
+	}
+
+	resp.StatusCode = http.StatusNoContent
+	resp.StatusMessage = response.Success
+	return resp
+}
+
+// validateMetricReportDefinition validates the MetricReportDefinition in the request
+// and fills the default values of the optional properties
+func validateMetricReportDefinition(definition *dmtf.MetricReportDefinitions) (int32, string, []interface{}, error) {
+	if definition.ID == "" {
+		definition.ID = uuid.NewV4().String()
+	} else if strings.Contains(definition.ID, "/") {
+		return http.StatusBadRequest, response.PropertyValueFormatError, []interface{}{definition.ID, "Id"}, fmt.Errorf("invalid Id %s", definition.ID)
+	}
+	if definition.Name == "" {
+		definition.Name = definition.ID
+	}
+
+	switch definition.MetricReportDefinitionType {
+	case "":
+		return http.StatusBadRequest, response.PropertyMissing, []interface{}{"MetricReportDefinitionType"}, fmt.Errorf("MetricReportDefinitionType is missing")
+	case "Periodic":
+		if definition.Schedule.RecurrenceInterval == "" {
+			return http.StatusBadRequest, response.PropertyMissing, []interface{}{"Schedule/RecurrenceInterval"}, fmt.Errorf("RecurrenceInterval is mandatory for Periodic MetricReportDefinition")
+		}
+		if interval, err := tcommon.ParseDuration(definition.Schedule.RecurrenceInterval); err != nil || interval <= 0 {
+			return http.StatusBadRequest, response.PropertyValueFormatError, []interface{}{definition.Schedule.RecurrenceInterval, "RecurrenceInterval"}, fmt.Errorf("invalid RecurrenceInterval %s", definition.Schedule.RecurrenceInterval)
+		}
+	case "OnChange", "OnRequest":
+	default:
+		return http.StatusBadRequest, response.PropertyValueNotInList, []interface{}{definition.MetricReportDefinitionType, "MetricReportDefinitionType"}, fmt.Errorf("invalid MetricReportDefinitionType %s", definition.MetricReportDefinitionType)
+	}
+
+	if len(definition.Metrics) == 0 && len(definition.MetricProperties) == 0 {
+		return http.StatusBadRequest, response.PropertyMissing, []interface{}{"Metrics"}, fmt.Errorf("Metrics is missing")
+	}
+	for _, metric := range definition.Metrics {
+		if len(metric.MetricProperties) == 0 {
+			return http.StatusBadRequest, response.PropertyMissing, []interface{}{"Metrics/MetricProperties"}, fmt.Errorf("MetricProperties is missing for the metric %s", metric.MetricID)
+		}
+		switch metric.CollectionFunction {
+		case "":
+		case "Average", "Maximum", "Minimum", "Summation":
+			if metric.CollectionDuration == "" {
+				return http.StatusBadRequest, response.PropertyMissing, []interface{}{"Metrics/CollectionDuration"}, fmt.Errorf("CollectionDuration is mandatory when CollectionFunction is %s", metric.CollectionFunction)
+			}
+		default:
+			return http.StatusBadRequest, response.PropertyValueNotInList, []interface{}{metric.CollectionFunction, "CollectionFunction"}, fmt.Errorf("invalid CollectionFunction %s", metric.CollectionFunction)
+		}
+		if metric.CollectionDuration != "" {
+			if _, err := tcommon.ParseDuration(metric.CollectionDuration); err != nil {
+				return http.StatusBadRequest, response.PropertyValueFormatError, []interface{}{metric.CollectionDuration, "CollectionDuration"}, err
+			}
+		}
+	}
+	statusCode, statusMessage, messageArgs, err := validateMetricProperties(definition.MetricProperties, definition.Wildcards)
+	if err != nil {
+		return statusCode, statusMessage, messageArgs, err
+	}
+	for _, metric := range definition.Metrics {
+		statusCode, statusMessage, messageArgs, err := validateMetricProperties(metric.MetricProperties, definition.Wildcards)
+		if err != nil {
+			return statusCode, statusMessage, messageArgs, err
+		}
+	}
+
+	if len(definition.ReportActions) == 0 {
+		definition.ReportActions = []string{"LogToMetricReportsCollection"}
+	}
+	for _, action := range definition.ReportActions {
+		if action != "LogToMetricReportsCollection" && action != "RedfishEvent" {
+			return http.StatusBadRequest, response.PropertyValueNotInList, []interface{}{action, "ReportActions"}, fmt.Errorf("invalid ReportAction %s", action)
+		}
+	}
+
+	switch definition.ReportUpdates {
+	case "":
+		definition.ReportUpdates = "Overwrite"
+	case "Overwrite":
+	case "AppendWrapsWhenFull", "AppendStopsWhenFull":
+		if definition.AppendLimit <= 0 {
+			return http.StatusBadRequest, response.PropertyMissing, []interface{}{"AppendLimit"}, fmt.Errorf("AppendLimit is mandatory when ReportUpdates is %s", definition.ReportUpdates)
+		}
+	default:
+		return http.StatusBadRequest, response.PropertyValueNotInList, []interface{}{definition.ReportUpdates, "ReportUpdates"}, fmt.Errorf("invalid ReportUpdates %s", definition.ReportUpdates)
+	}
+	return http.StatusOK, common.OK, nil, nil
+}
+
+// validateMetricProperties validates the format of the metric properties, each of
+// which is a resource URI followed by the JSON pointer of the property in it.
+// The wildcards used in the properties must be defined in the Wildcards
+func validateMetricProperties(properties []string, wildcards []dmtf.WildCard) (int32, string, []interface{}, error) {
+	for _, property := range properties {
+		if !strings.HasPrefix(property, "/redfish/v1/") || !strings.Contains(property, "#/") {
+			return http.StatusBadRequest, response.PropertyValueFormatError, []interface{}{property, "MetricProperties"}, fmt.Errorf("invalid metric property %s", property)
+		}
+		for _, match := range wildcardPattern.FindAllStringSubmatch(property, -1) {
+			if values := getWildcardValues(wildcards, match[1]); len(values) == 0 {
+				return http.StatusBadRequest, response.PropertyValueConflict, []interface{}{"MetricProperties", "Wildcards"}, fmt.Errorf("wildcard %s used in %s is not defined", match[1], property)
+			}
+		}
+	}
+	return http.StatusOK, common.OK, nil, nil
+}
+
+func getWildcardValues(wildcards []dmtf.WildCard, name string) []string {
+	for _, wildcard := range wildcards {
+		if wildcard.Name == name {
+			return wildcard.Values
+		}
+	}
+	return nil
+}
+
+// expandWildcards substitutes the wildcards in the metric properties with
+// each of their values, and returns the resulting metric properties
+func expandWildcards(properties []string, wildcards []dmtf.WildCard) []string {
+	var result []string
+	for _, property := range properties {
+		match := wildcardPattern.FindStringSubmatch(property)
+		if match == nil {
+			result = append(result, property)
+			continue
+		}
+		var substituted []string
+		for _, value := range getWildcardValues(wildcards, match[1]) {
+			substituted = append(substituted, strings.Replace(property, match[0], value, -1))
+		}
+		result = append(result, expandWildcards(substituted, wildcards)...)
+	}
+	return result
+}
+
+// addCollectionMember adds the member to the collection saved in the table
+func (e *ExternalInterface) addCollectionMember(ctx context.Context, table, collectionURI, member string) error {
+	collection, err := e.getCollection(table, collectionURI)
+	if err != nil {
+		return err
+	}
+	for _, link := range collection.Members {
+		if link.Oid == member {
+			return nil
+		}
+	}
+	collection.Members = append(collection.Members, &dmtf.Link{Oid: member})
+	return e.saveCollection(ctx, table, collection)
+}
+
+// removeCollectionMember removes the member from the collection saved in the table
+func (e *ExternalInterface) removeCollectionMember(ctx context.Context, table, collectionURI, member string) error {
+	collection, err := e.getCollection(table, collectionURI)
+	if err != nil {
+		return err
+	}
+	members := []*dmtf.Link{}
+	for _, link := range collection.Members {
+		if link.Oid != member {
+			members = append(members, link)
+		}
+	}
+	collection.Members = members
+	return e.saveCollection(ctx, table, collection)
+}
+
+func (e *ExternalInterface) getCollection(table, collectionURI string) (dmtf.Collection, error) {
+	collection := dmtf.Collection{
+		ODataID: collectionURI,
+		Members: []*dmtf.Link{},
+	}
+	data, gerr := e.DB.GetResource(table, collectionURI, common.InMemory)
+	if gerr != nil {
+		if errors.DBKeyNotFound != gerr.ErrNo() {
+			return collection, gerr
+		}
+		name := collectionNames[table]
+		collection.ODataContext = "/redfish/v1/$metadata#" + name + "." + name
+		collection.ODataType = "#" + name + "." + name
+		collection.Description = name + " view"
+		collection.Name = name
+		return collection, nil
+	}
+	if err := json.Unmarshal([]byte(data), &collection); err != nil {
+		return collection, err
+	}
+	return collection, nil
+}
+
+func (e *ExternalInterface) saveCollection(ctx context.Context, table string, collection dmtf.Collection) error {
+	collection.MembersCount = len(collection.Members)
+	data, err := json.Marshal(collection)
+	if err != nil {
+		return err
+	}
+	return e.External.GenericSave(ctx, data, table, collection.ODataID)
+}
@@ -0,0 +1,263 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	teleproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/telemetry"
+)
+
+func TestExternalInterface_CreateMetricReportDefinition(t *testing.T) {
+	ctx := mockContext()
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{
+			name: "Success",
+			body: `{"Id":"NotFound","MetricReportDefinitionType":"OnRequest","Wildcards":[{"Name":"SystemID","Values":["6d4a0a66-7efa-578e-83cf-44dc68d2874e.1"]}],"Metrics":[{"MetricId":"AverageConsumedWatts","CollectionFunction":"Average","CollectionDuration":"PT10M","MetricProperties":["/redfish/v1/Chassis/{SystemID}/Power#/PowerControl/0/PowerConsumedWatts"]}]}`,
+			want: http.StatusCreated,
+		},
+		{
+			name: "Malformed JSON",
+			body: `{"Id":`,
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "Invalid property case",
+			body: `{"id":"NotFound","MetricReportDefinitionType":"OnRequest","MetricProperties":["/redfish/v1/Chassis/1/Power#/PowerControl/0/PowerConsumedWatts"]}`,
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "Invalid definition",
+			body: `{"Id":"NotFound","MetricReportDefinitionType":"Periodic","MetricProperties":["/redfish/v1/Chassis/1/Power#/PowerControl/0/PowerConsumedWatts"]}`,
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "Already exists",
+			body: `{"Id":"CPUUtilCustom1","MetricReportDefinitionType":"OnRequest","MetricProperties":["/redfish/v1/Chassis/1/Power#/PowerControl/0/PowerConsumedWatts"]}`,
+			want: http.StatusConflict,
+		},
+		{
+			name: "Save failure",
+			body: `{"Id":"SaveError","MetricReportDefinitionType":"OnRequest","MetricProperties":["/redfish/v1/Chassis/1/Power#/PowerControl/0/PowerConsumedWatts"]}`,
+			want: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := MockGetExternalInterface()
+			got := e.CreateMetricReportDefinition(ctx, &teleproto.TelemetryRequest{
+				URL:         metricReportDefinitionsURI,
+				RequestBody: []byte(tt.body),
+			})
+			if int(got.StatusCode) != tt.want {
+				t.Errorf("ExternalInterface.CreateMetricReportDefinition() = %v, want %v", got.StatusCode, tt.want)
+			}
+		})
+	}
+	collectors.remove(metricReportDefinitionsURI + "/NotFound")
+}
+
+func TestExternalInterface_DeleteMetricReportDefinition(t *testing.T) {
+	ctx := mockContext()
+	tests := []struct {
+		name string
+		url  string
+		want int
+	}{
+		{
+			name: "Success",
+			url:  metricReportDefinitionsURI + "/Aggregated1",
+			want: http.StatusNoContent,
+		},
+		{
+			name: "Defined by the BMC",
+			url:  metricReportDefinitionsURI + "/CPUUtilCustom1",
+			want: http.StatusMethodNotAllowed,
+		},
+		{
+			name: "Not found",
+			url:  metricReportDefinitionsURI + "/NotFound",
+			want: http.StatusNotFound,
+		},
+		{
+			name: "DB error",
+			url:  "error",
+			want: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := MockGetExternalInterface()
+			got := e.DeleteMetricReportDefinition(ctx, &teleproto.TelemetryRequest{URL: tt.url})
+			if int(got.StatusCode) != tt.want {
+				t.Errorf("ExternalInterface.DeleteMetricReportDefinition() = %v, want %v", got.StatusCode, tt.want)
+			}
+		})
+	}
+}
+
+func Test_validateMetricReportDefinition(t *testing.T) {
+	property := "/redfish/v1/Systems/{SystemID}/Processors/1#/ProcessorSummary/Metrics/AverageFrequencyMHz"
+	wildcards := []dmtf.WildCard{{Name: "SystemID", Values: []string{"uuid.1"}}}
+	tests := []struct {
+		name       string
+		definition dmtf.MetricReportDefinitions
+		wantErr    bool
+	}{
+		{
+			name: "Periodic definition",
+			definition: dmtf.MetricReportDefinitions{
+				MetricReportDefinitionType: "Periodic",
+				Schedule:                   dmtf.Schedule{RecurrenceInterval: "PT1M"},
+				MetricProperties:           []string{property},
+				Wildcards:                  wildcards,
+			},
+		},
+		{
+			name: "Invalid Id",
+			definition: dmtf.MetricReportDefinitions{
+				ID:                         "CPU/Util",
+				MetricReportDefinitionType: "OnChange",
+				MetricProperties:           []string{property},
+				Wildcards:                  wildcards,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Missing MetricReportDefinitionType",
+			definition: dmtf.MetricReportDefinitions{
+				MetricProperties: []string{property},
+				Wildcards:        wildcards,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Periodic definition without Schedule",
+			definition: dmtf.MetricReportDefinitions{
+				MetricReportDefinitionType: "Periodic",
+				MetricProperties:           []string{property},
+				Wildcards:                  wildcards,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Missing metrics",
+			definition: dmtf.MetricReportDefinitions{
+				MetricReportDefinitionType: "OnChange",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Undefined wildcard",
+			definition: dmtf.MetricReportDefinitions{
+				MetricReportDefinitionType: "OnChange",
+				MetricProperties:           []string{property},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Invalid metric property",
+			definition: dmtf.MetricReportDefinitions{
+				MetricReportDefinitionType: "OnChange",
+				MetricProperties:           []string{"/redfish/v1/Systems/1/Processors/1"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Invalid CollectionFunction",
+			definition: dmtf.MetricReportDefinitions{
+				MetricReportDefinitionType: "OnRequest",
+				Metrics: []dmtf.Metric{{
+					MetricID:           "Frequency",
+					CollectionFunction: "Median",
+					CollectionDuration: "PT5M",
+					MetricProperties:   []string{property},
+				}},
+				Wildcards: wildcards,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Missing CollectionDuration",
+			definition: dmtf.MetricReportDefinitions{
+				MetricReportDefinitionType: "OnRequest",
+				Metrics: []dmtf.Metric{{
+					MetricID:           "Frequency",
+					CollectionFunction: "Maximum",
+					MetricProperties:   []string{property},
+				}},
+				Wildcards: wildcards,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Invalid ReportActions",
+			definition: dmtf.MetricReportDefinitions{
+				MetricReportDefinitionType: "OnChange",
+				MetricProperties:           []string{property},
+				Wildcards:                  wildcards,
+				ReportActions:              []string{"SendEmail"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Append without AppendLimit",
+			definition: dmtf.MetricReportDefinitions{
+				MetricReportDefinitionType: "OnChange",
+				MetricProperties:           []string{property},
+				Wildcards:                  wildcards,
+				ReportUpdates:              "AppendWrapsWhenFull",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, _, err := validateMetricReportDefinition(&tt.definition)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateMetricReportDefinition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_expandWildcards(t *testing.T) {
+	properties := []string{"/redfish/v1/Chassis/{ChassisID}/Power#/PowerSupplies/{PSU}/PowerOutputWatts"}
+	wildcards := []dmtf.WildCard{
+		{Name: "ChassisID", Values: []string{"uuid.1", "uuid2.1"}},
+		{Name: "PSU", Values: []string{"0", "1"}},
+	}
+	want := []string{
+		"/redfish/v1/Chassis/uuid.1/Power#/PowerSupplies/0/PowerOutputWatts",
+		"/redfish/v1/Chassis/uuid.1/Power#/PowerSupplies/1/PowerOutputWatts",
+		"/redfish/v1/Chassis/uuid2.1/Power#/PowerSupplies/0/PowerOutputWatts",
+		"/redfish/v1/Chassis/uuid2.1/Power#/PowerSupplies/1/PowerOutputWatts",
+	}
+	if got := expandWildcards(properties, wildcards); !reflect.DeepEqual(got, want) {
+		t.Errorf("expandWildcards() = %v, want %v", got, want)
+	}
+}
+
+func mockContext() context.Context {
+	return context.Background()
+}
@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
@@ -52,9 +54,71 @@ func MockGetResource(table, key string, dbType common.DbType) (string, *errors.E
 	if key == "error" {
 		return "", &errors.Error{}
 	}
+	if strings.HasSuffix(key, "/NotFound") || strings.HasSuffix(key, "/SaveError") || table == collectorStateTable {
+		return "", errors.PackError(errors.DBKeyNotFound, "no data with the with key "+key+" found")
+	}
+	if table == aggregatedDefinitionsTable || table == aggregatedTriggersTable {
+		if strings.HasSuffix(key, "/Aggregated1") {
+			return "body", nil
+		}
+		return "", errors.PackError(errors.DBKeyNotFound, "no data with the with key "+key+" found")
+	}
 	return "body", nil
 }
 
+func MockDeleteResource(table, key string, dbType common.DbType) *errors.Error {
+	if strings.HasSuffix(key, "/DeleteError") {
+		return errors.PackError(errors.UndefinedErrorType, "unable to delete "+key)
+	}
+	return nil
+}
+
+func MockSaveResource(table, key string, body []byte, dbType common.DbType) *errors.Error {
+	if strings.HasSuffix(key, "/SaveError") {
+		return errors.PackError(errors.UndefinedErrorType, "unable to save "+key)
+	}
+	return nil
+}
+
+func MockAcquireLease(name string, ttl time.Duration) (bool, *errors.Error) {
+	return true, nil
+}
+
+func MockReleaseLease(name string) *errors.Error {
+	return nil
+}
+
+func MockGenericSave(ctx context.Context, body []byte, table string, key string) error {
+	if strings.HasSuffix(key, "/SaveError") {
+		return fmt.Errorf("unable to save %s", key)
+	}
+	return nil
+}
+
+func MockGetTarget(uuid string) (*tmodel.Target, *errors.Error) {
+	if uuid != "6d4a0a66-7efa-578e-83cf-44dc68d2874e" {
+		return nil, errors.PackError(errors.DBKeyNotFound, "no data with the with key "+uuid+" found")
+	}
+	return &tmodel.Target{
+		ManagerAddress: "10.0.0.1",
+		Password:       []byte("password"),
+		UserName:       "admin",
+		DeviceUUID:     uuid,
+		PluginID:       "GRF",
+	}, nil
+}
+
+func MockDevicePassword(password []byte) ([]byte, error) {
+	return password, nil
+}
+
+func MockGetPluginStatus(ctx context.Context, plugin tmodel.Plugin) bool {
+	return false
+}
+
+func MockPublishMetricReport(ctx context.Context, report []byte) {
+}
+
 func MockGetAllKeysFromTable(table string, dbType common.DbType) ([]string, error) {
 	if table == "Plugin" {
 		return []string{"ILO", "GRF"}, nil
@@ -88,13 +152,22 @@ func MockGetPluginData(pluginID string) (tmodel.Plugin, *errors.Error) {
 func MockGetExternalInterface() *ExternalInterface {
 	return &ExternalInterface{
 		External: External{
-			Auth:          MockIsAuthorized,
-			ContactClient: MockContactClient,
-			GetPluginData: MockGetPluginData,
+			Auth:                MockIsAuthorized,
+			ContactClient:       MockContactClient,
+			GetPluginData:       MockGetPluginData,
+			GetTarget:           MockGetTarget,
+			DevicePassword:      MockDevicePassword,
+			GenericSave:         MockGenericSave,
+			PublishMetricReport: MockPublishMetricReport,
+			GetPluginStatus:     MockGetPluginStatus,
 		},
 		DB: DB{
 			GetAllKeysFromTable: MockGetAllKeysFromTable,
 			GetResource:         MockGetResource,
+			SaveResource:        MockSaveResource,
+			DeleteResource:      MockDeleteResource,
+			AcquireLease:        MockAcquireLease,
+			ReleaseLease:        MockReleaseLease,
 		},
 	}
 }
@@ -0,0 +1,317 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	teleproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/telemetry"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+)
+
+// OpenMetricsContentType is the content type of the OpenMetrics text exposition format
+const OpenMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+// openMetricFamilies holds the help text of the metric families exported by the telemetry service
+var openMetricFamilies = map[string]string{
+	"odim_metric_report_value":               "Numeric value of the metric in the MetricReport.",
+	"odim_chassis_scrape_success":            "Whether the readings of the chassis are found in the inventory of the BMC.",
+	"odim_chassis_temperature_celsius":       "Temperature reading of the chassis Thermal sensor in degree Celsius.",
+	"odim_chassis_fan_reading":               "Reading of the chassis Thermal fan in the units given by the units label.",
+	"odim_chassis_power_consumed_watts":      "Power consumed by the chassis PowerControl in Watts.",
+	"odim_chassis_power_supply_output_watts": "Last output power of the chassis power supply in Watts.",
+	"odim_chassis_voltage_volts":             "Voltage reading of the chassis Power sensor in Volts.",
+	"odim_chassis_sensor_reading":            "Reading of the chassis Sensor in the units given by the units label.",
+}
+
+var systemUUIDPattern = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
+
+// openMetricSample is a single sample of the metric family
+type openMetricSample struct {
+	labels map[string]string
+	value  float64
+}
+
+// openMetrics collects the samples of the metric families, it's safe for concurrent use
+type openMetrics struct {
+	lock     sync.Mutex
+	families map[string][]openMetricSample
+}
+
+func (m *openMetrics) add(family string, labels map[string]string, value float64) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.families[family] = append(m.families[family], openMetricSample{labels: labels, value: value})
+}
+
+// String renders the metric families in the OpenMetrics text exposition format
+func (m *openMetrics) String() string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	var names []string
+	for name := range m.families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString("# TYPE " + name + " gauge\n")
+		b.WriteString("# HELP " + name + " " + openMetricFamilies[name] + "\n")
+		var lines []string
+		for _, sample := range m.families[name] {
+			lines = append(lines, name+formatOpenMetricLabels(sample.labels)+" "+strconv.FormatFloat(sample.value, 'f', -1, 64))
+		}
+		sort.Strings(lines)
+		for _, line := range lines {
+			b.WriteString(line + "\n")
+		}
+	}
+	b.WriteString("# EOF\n")
+	return b.String()
+}
+
+func formatOpenMetricLabels(labels map[string]string) string {
+	var names []string
+	for name, value := range labels {
+		if value != "" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	sort.Strings(names)
+	escape := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	var pairs []string
+	for _, name := range names {
+		pairs = append(pairs, name+`="`+escape.Replace(labels[name])+`"`)
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// GetOpenMetrics exports the MetricReports and the Thermal, Power and Sensors readings
+// of the chassis as gauges in the OpenMetrics text exposition format. The scrape is built
+// from the stored MetricReports and the inventory collected from the BMCs, so that the
+// scrapes don't reach the BMCs.
+func (e *ExternalInterface) GetOpenMetrics(ctx context.Context, req *teleproto.TelemetryRequest) response.RPC {
+	var resp response.RPC
+	metrics := &openMetrics{families: make(map[string][]openMetricSample)}
+
+	reports, err := e.getCollectionMembers("MetricReportsCollection", metricReportsURI)
+	if err != nil {
+		l.LogWithFields(ctx).Warn("unable to get the MetricReports: " + err.Error())
+	}
+	for _, reportURI := range reports {
+		e.addMetricReportMetrics(ctx, metrics, reportURI)
+	}
+
+	chassisList, err := e.DB.GetAllKeysFromTable("Chassis", common.InMemory)
+	if err != nil {
+		l.LogWithFields(ctx).Warn("unable to get the Chassis: " + err.Error())
+	}
+	for _, chassisURI := range chassisList {
+		// only the chassis of the BMCs added to ODIM carry the system UUID in the ID
+		if !systemUUIDPattern.MatchString(chassisURI) {
+			continue
+		}
+		e.addChassisMetrics(ctx, metrics, chassisURI)
+	}
+
+	resp.StatusCode = http.StatusOK
+	resp.StatusMessage = response.Success
+	resp.Header = map[string]string{
+		"Content-Type": OpenMetricsContentType,
+	}
+	resp.Body = metrics.String()
+	return resp
+}
+
+// getCollectionMembers gets the URIs of the members of the collection saved in the table
+func (e *ExternalInterface) getCollectionMembers(table, collectionURI string) ([]string, error) {
+	collection, err := e.getCollection(table, collectionURI)
+	if err != nil {
+		return nil, err
+	}
+	var members []string
+	for _, member := range collection.Members {
+		members = append(members, member.Oid)
+	}
+	return members, nil
+}
+
+// addMetricReportMetrics adds the numeric metric values of the MetricReport. The reports of
+// the MetricReportDefinitions created in ODIM are built from the samples saved by the replica
+// collecting them, the other reports are taken from the stored MetricReports.
+func (e *ExternalInterface) addMetricReportMetrics(ctx context.Context, metrics *openMetrics, reportURI string) {
+	var report dmtf.MetricReports
+	if definition, gerr := e.getAggregatedDefinition(metricReportDefinitionsURI + "/" + path.Base(reportURI)); gerr == nil {
+		c := newMetricReportCollector(definition)
+		e.restoreCollectorState(ctx, c)
+		if definition.MetricReportDefinitionType == "OnRequest" || c.report == nil {
+			c.buildReport(time.Now())
+		}
+		report = *c.report
+	} else {
+		data, gerr := e.DB.GetResource("MetricReports", reportURI, common.InMemory)
+		if gerr != nil {
+			l.LogWithFields(ctx).Debug("unable to get the MetricReport " + reportURI + ": " + gerr.Error())
+			return
+		}
+		if err := json.Unmarshal([]byte(data), &report); err != nil {
+			l.LogWithFields(ctx).Warn("unable to unmarshal the MetricReport " + reportURI + ": " + err.Error())
+			return
+		}
+	}
+
+	for _, metricValue := range report.MetricValues {
+		value, err := strconv.ParseFloat(metricValue.MetricValue, 64)
+		if err != nil {
+			continue
+		}
+		metricID := metricValue.MetricID
+		if metricID == "" {
+			metricID = metricValue.MetricDefinition.ODataID[strings.LastIndex(metricValue.MetricDefinition.ODataID, "/")+1:]
+		}
+		metrics.add("odim_metric_report_value", map[string]string{
+			"metric_report":   report.ID,
+			"metric_id":       metricID,
+			"metric_property": metricValue.MetricProperty,
+			"system_uuid":     systemUUIDPattern.FindString(metricValue.MetricProperty),
+		}, value)
+	}
+}
+
+// addChassisMetrics adds the Thermal, Power and Sensors readings of the chassis
+// saved in the inventory of the BMC
+func (e *ExternalInterface) addChassisMetrics(ctx context.Context, metrics *openMetrics, chassisURI string) {
+	chassisID := chassisURI[strings.LastIndex(chassisURI, "/")+1:]
+	labels := map[string]string{
+		"system_uuid": systemUUIDPattern.FindString(chassisID),
+		"chassis":     chassisID,
+	}
+	if chassis, ok := e.getInventoryResource(ctx, "Chassis", chassisURI); ok {
+		labels["chassis_name"] = getString(chassis, "Name")
+	}
+
+	var success float64
+	if thermal, ok := e.getInventoryResource(ctx, "Thermal", chassisURI+"/Thermal"); ok {
+		success = 1
+		for _, temperature := range getObjects(thermal, "Temperatures") {
+			addReading(metrics, "odim_chassis_temperature_celsius", labels, temperature, "ReadingCelsius", map[string]string{
+				"physical_context": getString(temperature, "PhysicalContext"),
+			})
+		}
+		for _, fan := range getObjects(thermal, "Fans") {
+			addReading(metrics, "odim_chassis_fan_reading", labels, fan, "Reading", map[string]string{
+				"units": getString(fan, "ReadingUnits"),
+			})
+		}
+	}
+	if power, ok := e.getInventoryResource(ctx, "Power", chassisURI+"/Power"); ok {
+		success = 1
+		for _, powerControl := range getObjects(power, "PowerControl") {
+			addReading(metrics, "odim_chassis_power_consumed_watts", labels, powerControl, "PowerConsumedWatts", nil)
+		}
+		for _, powerSupply := range getObjects(power, "PowerSupplies") {
+			addReading(metrics, "odim_chassis_power_supply_output_watts", labels, powerSupply, "LastPowerOutputWatts", nil)
+		}
+		for _, voltage := range getObjects(power, "Voltages") {
+			addReading(metrics, "odim_chassis_voltage_volts", labels, voltage, "ReadingVolts", nil)
+		}
+	}
+	if sensors, ok := e.getInventoryResource(ctx, "SensorsCollection", chassisURI+"/Sensors"); ok {
+		success = 1
+		for _, member := range getObjects(sensors, "Members") {
+			sensorURI := getString(member, "@odata.id")
+			if sensorURI == "" {
+				continue
+			}
+			if sensor, ok := e.getInventoryResource(ctx, "Sensors", sensorURI); ok {
+				addReading(metrics, "odim_chassis_sensor_reading", labels, sensor, "Reading", map[string]string{
+					"reading_type": getString(sensor, "ReadingType"),
+					"units":        getString(sensor, "ReadingUnits"),
+				})
+			}
+		}
+	}
+	metrics.add("odim_chassis_scrape_success", labels, success)
+}
+
+// getInventoryResource gets the resource saved in the table by the inventory of the BMC
+func (e *ExternalInterface) getInventoryResource(ctx context.Context, table, uri string) (map[string]interface{}, bool) {
+	data, gerr := e.DB.GetResource(table, uri, common.InMemory)
+	if gerr != nil {
+		l.LogWithFields(ctx).Debug("unable to get " + uri + ": " + gerr.Error())
+		return nil, false
+	}
+	var resource map[string]interface{}
+	if err := json.Unmarshal([]byte(data), &resource); err != nil {
+		l.LogWithFields(ctx).Debug("unable to unmarshal " + uri + ": " + err.Error())
+		return nil, false
+	}
+	return resource, true
+}
+
+// addReading adds the numeric reading of the sensor, the sensor label is the Name of the sensor
+func addReading(metrics *openMetrics, family string, chassisLabels map[string]string, sensor map[string]interface{}, property string, extraLabels map[string]string) {
+	value, ok := sensor[property].(float64)
+	if !ok {
+		return
+	}
+	labels := map[string]string{}
+	for name, value := range chassisLabels {
+		labels[name] = value
+	}
+	for name, value := range extraLabels {
+		labels[name] = value
+	}
+	labels["sensor"] = getString(sensor, "Name")
+	if labels["sensor"] == "" {
+		labels["sensor"] = getString(sensor, "MemberId")
+	}
+	if labels["sensor"] == "" {
+		labels["sensor"] = getString(sensor, "Id")
+	}
+	metrics.add(family, labels, value)
+}
+
+func getObjects(resource map[string]interface{}, property string) []map[string]interface{} {
+	list, _ := resource[property].([]interface{})
+	var objects []map[string]interface{}
+	for _, item := range list {
+		if object, ok := item.(map[string]interface{}); ok {
+			objects = append(objects, object)
+		}
+	}
+	return objects
+}
+
+func getString(resource map[string]interface{}, property string) string {
+	value, _ := resource[property].(string)
+	return value
+}
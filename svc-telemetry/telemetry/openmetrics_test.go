@@ -0,0 +1,135 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	teleproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/telemetry"
+)
+
+func Test_openMetrics_String(t *testing.T) {
+	metrics := &openMetrics{families: make(map[string][]openMetricSample)}
+	chassis := map[string]string{"system_uuid": "6d4a0a66-7efa-578e-83cf-44dc68d2874e", "chassis": "1", "chassis_name": `Rack "A"`}
+	addReading(metrics, "odim_chassis_temperature_celsius", chassis, map[string]interface{}{"Name": "CPU 1", "ReadingCelsius": 40.5}, "ReadingCelsius", nil)
+	addReading(metrics, "odim_chassis_fan_reading", chassis, map[string]interface{}{"MemberId": "0", "Reading": 2000.0}, "Reading", map[string]string{"units": "RPM"})
+	addReading(metrics, "odim_chassis_fan_reading", chassis, map[string]interface{}{"Name": "Fan 2", "Reading": nil}, "Reading", nil)
+	metrics.add("odim_chassis_scrape_success", chassis, 1)
+
+	want := `# TYPE odim_chassis_fan_reading gauge
+# HELP odim_chassis_fan_reading ` + openMetricFamilies["odim_chassis_fan_reading"] + `
+odim_chassis_fan_reading{chassis="1",chassis_name="Rack \"A\"",sensor="0",system_uuid="6d4a0a66-7efa-578e-83cf-44dc68d2874e",units="RPM"} 2000
+# TYPE odim_chassis_scrape_success gauge
+# HELP odim_chassis_scrape_success ` + openMetricFamilies["odim_chassis_scrape_success"] + `
+odim_chassis_scrape_success{chassis="1",chassis_name="Rack \"A\"",system_uuid="6d4a0a66-7efa-578e-83cf-44dc68d2874e"} 1
+# TYPE odim_chassis_temperature_celsius gauge
+# HELP odim_chassis_temperature_celsius ` + openMetricFamilies["odim_chassis_temperature_celsius"] + `
+odim_chassis_temperature_celsius{chassis="1",chassis_name="Rack \"A\"",sensor="CPU 1",system_uuid="6d4a0a66-7efa-578e-83cf-44dc68d2874e"} 40.5
+# EOF
+`
+	if got := metrics.String(); got != want {
+		t.Errorf("openMetrics.String() = %v, want %v", got, want)
+	}
+}
+
+func TestExternalInterface_GetOpenMetrics(t *testing.T) {
+	e := MockGetExternalInterface()
+	got := e.GetOpenMetrics(mockContext(), &teleproto.TelemetryRequest{URL: "/redfish/v1/TelemetryService/OpenMetrics"})
+	if got.StatusCode != http.StatusOK {
+		t.Errorf("ExternalInterface.GetOpenMetrics() = %v, want %v", got.StatusCode, http.StatusOK)
+	}
+	if got.Header["Content-Type"] != OpenMetricsContentType {
+		t.Errorf("Content-Type = %v, want %v", got.Header["Content-Type"], OpenMetricsContentType)
+	}
+	if body, _ := got.Body.(string); !strings.HasSuffix(body, "# EOF\n") {
+		t.Errorf("body %q is not terminated by # EOF", body)
+	}
+}
+
+func TestExternalInterface_GetOpenMetrics_storedData(t *testing.T) {
+	config.SetUpMockConfig(t)
+	store := newFakeAggregationStore()
+	e := store.externalInterface()
+	e.External.ContactClient = func(ctx context.Context, url, method, token string, odataID string, body interface{}, loginCredential map[string]string) (*http.Response, error) {
+		t.Errorf("GetOpenMetrics() contacted %s", url)
+		return nil, fmt.Errorf("no BMC is reachable")
+	}
+	save := func(table, key string, resource interface{}, dbType common.DbType) {
+		data, _ := json.Marshal(resource)
+		store.save(table, key, data, dbType)
+	}
+
+	chassisURI := "/redfish/v1/Chassis/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1"
+	save("Chassis", chassisURI, map[string]interface{}{"Name": "Rack"}, common.InMemory)
+	save("Thermal", chassisURI+"/Thermal", map[string]interface{}{
+		"Temperatures": []interface{}{map[string]interface{}{"Name": "CPU 1", "ReadingCelsius": 40}},
+	}, common.InMemory)
+	save("SensorsCollection", chassisURI+"/Sensors", map[string]interface{}{
+		"Members": []interface{}{map[string]interface{}{"@odata.id": chassisURI + "/Sensors/Inlet"}},
+	}, common.InMemory)
+	save("Sensors", chassisURI+"/Sensors/Inlet", map[string]interface{}{"Id": "Inlet", "Reading": 22, "ReadingType": "Temperature"}, common.InMemory)
+	save("Chassis", "/redfish/v1/Chassis/6d4a0a66-7efa-578e-83cf-44dc68d2874f.1", map[string]interface{}{"Name": "Empty"}, common.InMemory)
+
+	// the report of the BMC is stored, the report of the OnRequest definition is built
+	// from the samples saved by the replica collecting it
+	property := chassisURI + "/Power#/PowerControl/0/PowerConsumedWatts"
+	definition := dmtf.MetricReportDefinitions{
+		ODataID:                    metricReportDefinitionsURI + "/PowerMetrics",
+		ID:                         "PowerMetrics",
+		MetricReportDefinitionType: "OnRequest",
+		MetricReport:               dmtf.Oid{ODataID: metricReportsURI + "/PowerMetrics"},
+		MetricProperties:           []string{property},
+	}
+	save(aggregatedDefinitionsTable, definition.ODataID, definition, common.OnDisk)
+	save(collectorStateTable, definition.ODataID, collectorState{
+		Samples: map[string][]collectorStateSample{property: {{Value: "180", Timestamp: time.Now()}}},
+	}, common.InMemory)
+	save("MetricReports", metricReportsURI+"/CPUUtil", dmtf.MetricReports{
+		ID:           "CPUUtil",
+		MetricValues: []dmtf.MetricValue{{MetricID: "CPUUtil", MetricValue: "12"}},
+	}, common.InMemory)
+	save("MetricReportsCollection", metricReportsURI, dmtf.Collection{Members: []*dmtf.Link{
+		{Oid: metricReportsURI + "/CPUUtil"},
+		{Oid: metricReportsURI + "/PowerMetrics"},
+		{Oid: metricReportsURI + "/NotStored"},
+	}}, common.InMemory)
+
+	got := e.GetOpenMetrics(mockContext(), &teleproto.TelemetryRequest{URL: "/redfish/v1/TelemetryService/OpenMetrics"})
+	body, _ := got.Body.(string)
+	for _, want := range []string{
+		`odim_chassis_temperature_celsius{chassis="6d4a0a66-7efa-578e-83cf-44dc68d2874e.1",chassis_name="Rack",sensor="CPU 1",system_uuid="6d4a0a66-7efa-578e-83cf-44dc68d2874e"} 40`,
+		`odim_chassis_sensor_reading{chassis="6d4a0a66-7efa-578e-83cf-44dc68d2874e.1",chassis_name="Rack",reading_type="Temperature",sensor="Inlet",system_uuid="6d4a0a66-7efa-578e-83cf-44dc68d2874e"} 22`,
+		`odim_chassis_scrape_success{chassis="6d4a0a66-7efa-578e-83cf-44dc68d2874e.1",chassis_name="Rack",system_uuid="6d4a0a66-7efa-578e-83cf-44dc68d2874e"} 1`,
+		`odim_chassis_scrape_success{chassis="6d4a0a66-7efa-578e-83cf-44dc68d2874f.1",chassis_name="Empty",system_uuid="6d4a0a66-7efa-578e-83cf-44dc68d2874f"} 0`,
+		`odim_metric_report_value{metric_id="CPUUtil",metric_report="CPUUtil"} 12`,
+		`odim_metric_report_value{metric_property="` + property + `",metric_report="PowerMetrics",system_uuid="6d4a0a66-7efa-578e-83cf-44dc68d2874e"} 180`,
+	} {
+		if !strings.Contains(body, want+"\n") {
+			t.Errorf("GetOpenMetrics() body = %v, want the sample %v", body, want)
+		}
+	}
+	if strings.Contains(body, "NotStored") {
+		t.Errorf("GetOpenMetrics() body = %v, want no sample of the MetricReport which is not stored", body)
+	}
+}
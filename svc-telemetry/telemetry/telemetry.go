@@ -22,6 +22,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"path"
 
 	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
@@ -233,6 +234,18 @@ func (e *ExternalInterface) GetMetricReportDefinition(ctx context.Context, req *
 // GetMetricReport is for to get metric report from southbound resource
 func (e *ExternalInterface) GetMetricReport(ctx context.Context, req *teleproto.TelemetryRequest) response.RPC {
 	var resp response.RPC
+	// the reports of the MetricReportDefinitions created in ODIM are generated by the telemetry service
+	definitionURI := metricReportDefinitionsURI + "/" + path.Base(req.URL)
+	if collector, ok := collectors.get(definitionURI); ok {
+		return e.getAggregatedMetricReport(ctx, collector, req)
+	}
+	if definition, gerr := e.getAggregatedDefinition(definitionURI); gerr == nil {
+		// the definition is collected by another replica, the report of the OnRequest
+		// definition is generated from the samples saved by that replica
+		collector := newMetricReportCollector(definition)
+		e.restoreCollectorState(ctx, collector)
+		return e.getAggregatedMetricReport(ctx, collector, req)
+	}
 	var getDeviceInfoRequest = tcommon.ResourceInfoRequest{
 		URL:                 req.URL,
 		ContactClient:       e.External.ContactClient,
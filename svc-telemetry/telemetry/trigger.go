@@ -0,0 +1,196 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	teleproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/telemetry"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	uuid "github.com/satori/go.uuid"
+)
+
+// CreateTrigger creates a Trigger in ODIM, which generates the MetricReports of the linked
+// MetricReportDefinitions when the numeric thresholds are crossed by the metric properties
+func (e *ExternalInterface) CreateTrigger(ctx context.Context, req *teleproto.TelemetryRequest) response.RPC {
+	var resp response.RPC
+	var trigger dmtf.Triggers
+	if err := json.Unmarshal(req.RequestBody, &trigger); err != nil {
+		errorMessage := "error while trying to unmarshal the request body: " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errorMessage, nil, nil)
+	}
+
+	// Validating the request JSON properties for case sensitive
+	invalidProperties, err := common.RequestParamsCaseValidator(req.RequestBody, trigger)
+	if err != nil {
+		errorMessage := "error while validating request parameters: " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	} else if invalidProperties != "" {
+		errorMessage := "error: one or more properties given in the request body are not valid, ensure properties are listed in uppercamelcase "
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errorMessage, []interface{}{invalidProperties}, nil)
+	}
+
+	statusCode, statusMessage, messageArgs, err := e.validateTrigger(&trigger)
+	if err != nil {
+		errorMessage := "error: request payload validation failed: " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(statusCode, statusMessage, errorMessage, messageArgs, nil)
+	}
+
+	trigger.ODataID = triggersURI + "/" + trigger.ID
+	trigger.ODataType = triggersType
+	trigger.Status = dmtf.Status{State: "Enabled", Health: "OK"}
+	trigger.Links.MetricReportDefinitionsCount = len(trigger.Links.MetricReportDefinitions)
+
+	if _, gerr := e.DB.GetResource("Triggers", trigger.ODataID, common.InMemory); gerr == nil {
+		errorMessage := "error: Trigger " + trigger.ODataID + " already exists"
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusConflict, response.ResourceAlreadyExists, errorMessage, []interface{}{"Triggers", "Id", trigger.ID}, nil)
+	}
+
+	data, err := json.Marshal(trigger)
+	if err != nil {
+		errorMessage := "error while trying to marshal the Trigger: " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	if err := e.DB.SaveResource(aggregatedTriggersTable, trigger.ODataID, data, common.OnDisk); err != nil {
+		errorMessage := "error while trying to save the Trigger: " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	if err := e.External.GenericSave(ctx, data, "Triggers", trigger.ODataID); err != nil {
+		errorMessage := "error while trying to save the Trigger: " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	if err := e.addCollectionMember(ctx, "TriggersCollection", triggersURI, trigger.ODataID); err != nil {
+		l.LogWithFields(ctx).Error("error while trying to update the Triggers collection: " + err.Error())
+	}
+
+	attachTrigger(trigger)
+
+	resp.StatusCode = http.StatusCreated
+	resp.StatusMessage = response.Created
+	resp.Header = map[string]string{
+		"Location": trigger.ODataID,
+	}
+	resp.Body = trigger
+	return resp
+}
+
+// DeleteTrigger deletes the Trigger created in ODIM
+func (e *ExternalInterface) DeleteTrigger(ctx context.Context, req *teleproto.TelemetryRequest) response.RPC {
+	var resp response.RPC
+	if _, gerr := e.DB.GetResource(aggregatedTriggersTable, req.URL, common.OnDisk); gerr != nil {
+		errorMessage := gerr.Error()
+		if errors.DBKeyNotFound != gerr.ErrNo() {
+			l.LogWithFields(ctx).Error("Unable to get Triggers details : " + errorMessage)
+			return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+		}
+		if _, gerr := e.DB.GetResource("Triggers", req.URL, common.InMemory); gerr == nil {
+			errorMessage = "error: Trigger " + req.URL + " is defined by the BMC and can't be deleted"
+			l.LogWithFields(ctx).Error(errorMessage)
+			return common.GeneralError(http.StatusMethodNotAllowed, response.ActionNotSupported, errorMessage, []interface{}{http.MethodDelete}, nil)
+		}
+		l.LogWithFields(ctx).Warn("Unable to get Triggers details : " + errorMessage)
+		return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errorMessage, []interface{}{"Triggers", req.URL}, nil)
+	}
+
+	detachTrigger(req.URL)
+	for table, dbType := range map[string]common.DbType{
+		aggregatedTriggersTable: common.OnDisk,
+		"Triggers":              common.InMemory,
+	} {
+		if derr := e.DB.DeleteResource(table, req.URL, dbType); derr != nil && errors.DBKeyNotFound != derr.ErrNo() {
+			errorMessage := "error while trying to delete the Trigger: " + derr.Error()
+			l.LogWithFields(ctx).Error(errorMessage)
+			return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+		}
+	}
+	if err := e.removeCollectionMember(ctx, "TriggersCollection", triggersURI, req.URL); err != nil {
+		l.LogWithFields(ctx).Error("error while trying to update the Triggers collection: " + err.Error())
+	}
+
+	resp.StatusCode = http.StatusNoContent
+	resp.StatusMessage = response.Success
+	return resp
+}
+
+// validateTrigger validates the Trigger in the request, only the numeric Triggers
+// which generate the reports of the MetricReportDefinitions created in ODIM are supported
+func (e *ExternalInterface) validateTrigger(trigger *dmtf.Triggers) (int32, string, []interface{}, error) {
+	if trigger.ID == "" {
+		trigger.ID = uuid.NewV4().String()
+	} else if strings.Contains(trigger.ID, "/") {
+		return http.StatusBadRequest, response.PropertyValueFormatError, []interface{}{trigger.ID, "Id"}, fmt.Errorf("invalid Id %s", trigger.ID)
+	}
+	if trigger.Name == "" {
+		trigger.Name = trigger.ID
+	}
+
+	if trigger.MetricType != "Numeric" {
+		return http.StatusBadRequest, response.PropertyValueNotInList, []interface{}{trigger.MetricType, "MetricType"}, fmt.Errorf("unsupported MetricType %s", trigger.MetricType)
+	}
+	var thresholdCount int
+	thresholds := trigger.NumericThresholds
+	for _, threshold := range []dmtf.Threshold{thresholds.UpperCritical, thresholds.UpperWarning, thresholds.LowerWarning, thresholds.LowerCritical} {
+		switch threshold.Activation {
+		case "":
+			continue
+		case "Increasing", "Decreasing", "Either":
+			thresholdCount++
+		default:
+			return http.StatusBadRequest, response.PropertyValueNotInList, []interface{}{threshold.Activation, "Activation"}, fmt.Errorf("invalid Activation %s", threshold.Activation)
+		}
+	}
+	if thresholdCount == 0 {
+		return http.StatusBadRequest, response.PropertyMissing, []interface{}{"NumericThresholds"}, fmt.Errorf("NumericThresholds is missing")
+	}
+
+	if len(trigger.TriggerActions) == 0 {
+		trigger.TriggerActions = []string{"RedfishMetricReport"}
+	}
+	for _, action := range trigger.TriggerActions {
+		if action != "RedfishMetricReport" {
+			return http.StatusBadRequest, response.PropertyValueNotInList, []interface{}{action, "TriggerActions"}, fmt.Errorf("unsupported TriggerAction %s", action)
+		}
+	}
+
+	if statusCode, statusMessage, messageArgs, err := validateMetricProperties(trigger.MetricProperties, trigger.Wildcards); err != nil {
+		return statusCode, statusMessage, messageArgs, err
+	}
+
+	if len(trigger.Links.MetricReportDefinitions) == 0 {
+		return http.StatusBadRequest, response.PropertyMissing, []interface{}{"Links/MetricReportDefinitions"}, fmt.Errorf("MetricReportDefinitions is missing")
+	}
+	for _, definition := range trigger.Links.MetricReportDefinitions {
+		if _, gerr := e.DB.GetResource(aggregatedDefinitionsTable, definition.ODataID, common.OnDisk); gerr != nil {
+			return http.StatusBadRequest, response.ResourceNotFound, []interface{}{"MetricReportDefinition", definition.ODataID}, fmt.Errorf("MetricReportDefinition %s is not created in ODIM", definition.ODataID)
+		}
+	}
+	return http.StatusOK, common.OK, nil, nil
+}
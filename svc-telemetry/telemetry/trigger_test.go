@@ -0,0 +1,133 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package telemetry
+
+import (
+	"net/http"
+	"testing"
+
+	teleproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/telemetry"
+)
+
+func TestExternalInterface_CreateTrigger(t *testing.T) {
+	ctx := mockContext()
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{
+			name: "Success",
+			body: `{"Id":"NotFound","MetricType":"Numeric","NumericThresholds":{"UpperCritical":{"Reading":90,"Activation":"Increasing"}},"MetricProperties":["/redfish/v1/Systems/uuid.1#/ProcessorSummary/Metrics/CPUUtil"],"Links":{"MetricReportDefinitions":[{"@odata.id":"/redfish/v1/TelemetryService/MetricReportDefinitions/Aggregated1"}]}}`,
+			want: http.StatusCreated,
+		},
+		{
+			name: "Malformed JSON",
+			body: `{"Id":`,
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "Invalid property case",
+			body: `{"id":"NotFound","MetricType":"Numeric"}`,
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "Discrete trigger",
+			body: `{"Id":"NotFound","MetricType":"Discrete","Links":{"MetricReportDefinitions":[{"@odata.id":"/redfish/v1/TelemetryService/MetricReportDefinitions/Aggregated1"}]}}`,
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "Missing thresholds",
+			body: `{"Id":"NotFound","MetricType":"Numeric","Links":{"MetricReportDefinitions":[{"@odata.id":"/redfish/v1/TelemetryService/MetricReportDefinitions/Aggregated1"}]}}`,
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "Invalid Activation",
+			body: `{"Id":"NotFound","MetricType":"Numeric","NumericThresholds":{"UpperCritical":{"Reading":90,"Activation":"Always"}},"Links":{"MetricReportDefinitions":[{"@odata.id":"/redfish/v1/TelemetryService/MetricReportDefinitions/Aggregated1"}]}}`,
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "Unsupported TriggerActions",
+			body: `{"Id":"NotFound","MetricType":"Numeric","NumericThresholds":{"UpperCritical":{"Reading":90,"Activation":"Increasing"}},"TriggerActions":["LogToLogService"],"Links":{"MetricReportDefinitions":[{"@odata.id":"/redfish/v1/TelemetryService/MetricReportDefinitions/Aggregated1"}]}}`,
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "MetricReportDefinition not created in ODIM",
+			body: `{"Id":"NotFound","MetricType":"Numeric","NumericThresholds":{"UpperCritical":{"Reading":90,"Activation":"Increasing"}},"Links":{"MetricReportDefinitions":[{"@odata.id":"/redfish/v1/TelemetryService/MetricReportDefinitions/CPUUtilCustom1"}]}}`,
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "Already exists",
+			body: `{"Id":"CPUUtilTrigger","MetricType":"Numeric","NumericThresholds":{"UpperCritical":{"Reading":90,"Activation":"Increasing"}},"Links":{"MetricReportDefinitions":[{"@odata.id":"/redfish/v1/TelemetryService/MetricReportDefinitions/Aggregated1"}]}}`,
+			want: http.StatusConflict,
+		},
+		{
+			name: "Save failure",
+			body: `{"Id":"SaveError","MetricType":"Numeric","NumericThresholds":{"UpperCritical":{"Reading":90,"Activation":"Increasing"}},"Links":{"MetricReportDefinitions":[{"@odata.id":"/redfish/v1/TelemetryService/MetricReportDefinitions/Aggregated1"}]}}`,
+			want: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := MockGetExternalInterface()
+			got := e.CreateTrigger(ctx, &teleproto.TelemetryRequest{
+				URL:         triggersURI,
+				RequestBody: []byte(tt.body),
+			})
+			if int(got.StatusCode) != tt.want {
+				t.Errorf("ExternalInterface.CreateTrigger() = %v, want %v", got.StatusCode, tt.want)
+			}
+		})
+	}
+}
+
+func TestExternalInterface_DeleteTrigger(t *testing.T) {
+	ctx := mockContext()
+	tests := []struct {
+		name string
+		url  string
+		want int
+	}{
+		{
+			name: "Success",
+			url:  triggersURI + "/Aggregated1",
+			want: http.StatusNoContent,
+		},
+		{
+			name: "Defined by the BMC",
+			url:  triggersURI + "/CPUUtilTrigger",
+			want: http.StatusMethodNotAllowed,
+		},
+		{
+			name: "Not found",
+			url:  triggersURI + "/NotFound",
+			want: http.StatusNotFound,
+		},
+		{
+			name: "DB error",
+			url:  "error",
+			want: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := MockGetExternalInterface()
+			got := e.DeleteTrigger(ctx, &teleproto.TelemetryRequest{URL: tt.url})
+			if int(got.StatusCode) != tt.want {
+				t.Errorf("ExternalInterface.DeleteTrigger() = %v, want %v", got.StatusCode, tt.want)
+			}
+		})
+	}
+}
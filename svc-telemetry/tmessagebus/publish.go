@@ -0,0 +1,47 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package tmessagebus ...
+package tmessagebus
+
+import (
+	"context"
+
+	dc "github.com/ODIM-Project/ODIM/lib-messagebus/datacommunicator"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+)
+
+// PublishMetricReport will takes the metric report generated by the telemetry service
+// and publishes it to message bus, for the event service to deliver it to the subscribers
+func PublishMetricReport(ctx context.Context, report []byte) {
+	topicName := config.Data.MessageBusConf.OdimControlMessageQueue
+	k, err := dc.Communicator(config.Data.MessageBusConf.MessageBusType, config.Data.MessageBusConf.MessageBusConfigFilePath, topicName)
+	if err != nil {
+		l.LogWithFields(ctx).Error("Unable to connect to " + config.Data.MessageBusConf.MessageBusType + " " + err.Error())
+		return
+	}
+
+	var mbevent = common.Events{
+		IP:        "TelemetryService",
+		Request:   report,
+		EventType: "MetricReport",
+		Internal:  true,
+	}
+	if err := k.Distribute(mbevent); err != nil {
+		l.LogWithFields(ctx).Error("unable to publish the metric report to message bus: " + err.Error())
+		return
+	}
+}
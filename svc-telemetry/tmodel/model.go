@@ -53,7 +53,7 @@ var (
 
 // GetResource fetches a resource from database using table and key
 func GetResource(Table, key string, dbtype common.DbType) (string, *errors.Error) {
-	conn, err := GetDBConnectionFunc(common.InMemory)
+	conn, err := GetDBConnectionFunc(dbtype)
 	if err != nil {
 		return "", err
 	}
@@ -139,3 +139,27 @@ func GenericSave(ctx context.Context, body []byte, table string, key string) err
 	}
 	return nil
 }
+
+// SaveResource will save the resource data into the database, replacing the existing data
+func SaveResource(table, key string, body []byte, dbtype common.DbType) *errors.Error {
+	conn, err := GetDBConnectionFunc(dbtype)
+	if err != nil {
+		return err
+	}
+	if err = conn.AddResourceData(table, key, string(body)); err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to save resource: ", err.Error())
+	}
+	return nil
+}
+
+// DeleteResource will delete the resource data from the database
+func DeleteResource(table, key string, dbtype common.DbType) *errors.Error {
+	conn, err := GetDBConnectionFunc(dbtype)
+	if err != nil {
+		return err
+	}
+	if err = conn.Delete(table, key); err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to delete resource: ", err.Error())
+	}
+	return nil
+}
@@ -277,6 +277,55 @@ func TestGenericSave(t *testing.T) {
 
 }
 
+func TestDeleteResource(t *testing.T) {
+	config.SetUpMockConfig(t)
+	defer func() {
+		common.TruncateDB(common.InMemory)
+	}()
+	mockData(t, common.InMemory, "someTable", "someKey", "someData")
+
+	err := DeleteResource("someTable", "someKey", common.InMemory)
+	assert.Nil(t, err, "There should be no error")
+
+	err = DeleteResource("someTable", "someKey", common.InMemory)
+	assert.NotNil(t, err, "There should be an error for the deleted key")
+
+	GetDBConnectionFunc = func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+		return nil, &errors.Error{}
+	}
+	err = DeleteResource("someTable", "someKey", common.InMemory)
+	assert.NotNil(t, err, "There should be an error")
+
+	GetDBConnectionFunc = func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+		return common.GetDBConnection(dbFlag)
+	}
+}
+
+func TestSaveResource(t *testing.T) {
+	config.SetUpMockConfig(t)
+	defer func() {
+		common.TruncateDB(common.OnDisk)
+	}()
+
+	err := SaveResource("someTable", "someKey", []byte(`{"Id":"1"}`), common.OnDisk)
+	assert.Nil(t, err, "There should be no error")
+	err = SaveResource("someTable", "someKey", []byte(`{"Id":"2"}`), common.OnDisk)
+	assert.Nil(t, err, "There should be no error while replacing the data")
+	data, gerr := GetResource("someTable", "someKey", common.OnDisk)
+	assert.Nil(t, gerr, "There should be no error")
+	assert.Equal(t, `{"Id":"2"}`, data, "The saved data should be replaced")
+
+	GetDBConnectionFunc = func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+		return nil, &errors.Error{}
+	}
+	err = SaveResource("someTable", "someKey", []byte(`{"Id":"1"}`), common.OnDisk)
+	assert.NotNil(t, err, "There should be an error")
+
+	GetDBConnectionFunc = func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+		return common.GetDBConnection(dbFlag)
+	}
+}
+
 func mockContext() context.Context {
 	ctx := context.Background()
 	ctx = context.WithValue(ctx, common.TransactionID, "xyz")
@@ -24,6 +24,7 @@ import (
 	"github.com/ODIM-Project/ODIM/lib-utilities/services"
 	"github.com/ODIM-Project/ODIM/svc-update/rpc"
 	"github.com/ODIM-Project/ODIM/svc-update/ucommon"
+	"github.com/ODIM-Project/ODIM/svc-update/umessagebus"
 	"github.com/sirupsen/logrus"
 )
 
@@ -72,6 +73,8 @@ func main() {
 	go ucommon.TrackConfigFileChanges(errChan)
 
 	registerHandlers(errChan)
+	// subscribe to the control messages for stopping the cancelled tasks
+	go umessagebus.SubscribeCtrlMsgQueue(common.TaskControlMessageQueue(common.UpdateService))
 	// Run server
 	if err := services.ODIMService.Run(); err != nil {
 		log.Error(err)
@@ -151,6 +151,8 @@ func TaskData(ctx context.Context, taskData common.TaskData) error {
 	if err != nil && (err.Error() == common.Cancelling) {
 		// We cant do anything here as the task has done it work completely, we cant reverse it.
 		//Unless if we can do opposite/reverse action for delete server which is add server.
+		cancelled := common.CancelledTaskResponse(taskData.TaskID, common.CompletedOperations(taskData.TaskID))
+		payLoad.ResponseBody, _ = json.Marshal(cancelled.Body)
 		ServicesUpdateTaskFunc(ctx, taskData.TaskID, common.Cancelled, taskData.TaskStatus, taskData.PercentComplete, payLoad, time.Now())
 		if taskData.PercentComplete == 0 {
 			return fmt.Errorf("error while starting the task: %v", err)
@@ -160,6 +162,15 @@ func TaskData(ctx context.Context, taskData common.TaskData) error {
 	return nil
 }
 
+// cancelTask records the task as Cancelled along with the operations of the task completed before the cancellation
+func (e *ExternalInterface) cancelTask(ctx context.Context, taskID, targetURI, request string, percentComplete int32) {
+	resp := common.CancelledTaskResponse(taskID, common.CompletedOperations(taskID))
+	task := fillTaskData(taskID, targetURI, request, resp, common.Cancelled, common.Warning, percentComplete, http.MethodPost)
+	if err := e.External.UpdateTask(ctx, task); err != nil {
+		l.LogWithFields(ctx).Warn("unable to update the cancelled task " + taskID + ": " + err.Error())
+	}
+}
+
 func fillTaskData(taskID, targetURI, request string, resp response.RPC, taskState string, taskStatus string, percentComplete int32, httpMethod string) common.TaskData {
 	return common.TaskData{
 		TaskID:          taskID,
@@ -192,7 +203,14 @@ func (e *ExternalInterface) monitorPluginTask(ctx context.Context, subTaskChanne
 			e.External.UpdateTask(ctx, updatetask)
 			return monitorTaskData.getResponse, err
 		}
-		time.Sleep(time.Second * 5)
+		// stop polling the plugin task once the task is cancelled
+		select {
+		case <-ctx.Done():
+			subTaskChannel <- http.StatusInternalServerError
+			e.cancelTask(ctx, monitorTaskData.subTaskID, monitorTaskData.serverURI, monitorTaskData.updateRequestBody, task.PercentComplete)
+			return monitorTaskData.getResponse, ctx.Err()
+		case <-time.After(time.Second * 5):
+		}
 		monitorTaskData.pluginRequest.OID = monitorTaskData.location
 		monitorTaskData.pluginRequest.HTTPMethodType = http.MethodGet
 		monitorTaskData.respBody, _, monitorTaskData.getResponse, err = e.External.ContactPlugin(ctx, monitorTaskData.pluginRequest, "error while performing simple update action: ")
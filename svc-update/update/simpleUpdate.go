@@ -53,6 +53,10 @@ func (e *ExternalInterface) SimpleUpdate(ctx context.Context, taskID string, ses
 
 	taskInfo := &common.TaskUpdateInfo{Context: ctx, TaskID: taskID, TargetURI: targetURI, UpdateTask: e.External.UpdateTask, TaskRequest: string(req.RequestBody)}
 
+	// the sub tasks are tracked with the context of the task to be cancelled along with it
+	ctx, done := common.TrackTask(ctx, taskID)
+	defer done()
+
 	var updateRequest SimpleUpdateRequest
 	err := json.Unmarshal(req.RequestBody, &updateRequest)
 	if err != nil {
@@ -109,6 +113,10 @@ func (e *ExternalInterface) SimpleUpdate(ctx context.Context, taskID string, ses
 	resp.StatusCode = http.StatusOK
 	for i := 0; i < len(targetList); i++ {
 		select {
+		case <-ctx.Done():
+			l.LogWithFields(ctx).Info("SimpleUpdate task " + taskID + " is cancelled")
+			e.cancelTask(ctx, taskID, targetURI, string(req.RequestBody), percentComplete)
+			return common.CancelledTaskResponse(taskID, common.CompletedOperations(taskID))
 		case statusCode := <-subTaskChannel:
 			if statusCode != http.StatusOK {
 				partialResultFlag = true
@@ -117,7 +125,7 @@ func (e *ExternalInterface) SimpleUpdate(ctx context.Context, taskID string, ses
 				}
 			}
 			if i < len(targetList)-1 {
-				percentComplete := int32(((i + 1) / len(targetList)) * 100)
+				percentComplete = int32(((i + 1) / len(targetList)) * 100)
 				var task = fillTaskData(taskID, targetURI, string(req.RequestBody), resp, common.Running, common.OK, percentComplete, http.MethodPost)
 				err := e.External.UpdateTask(ctx, task)
 				if err != nil && err.Error() == common.Cancelling {
@@ -189,6 +197,10 @@ func (e *ExternalInterface) sendRequest(ctx context.Context, uuid, taskID, serve
 
 	taskInfo := &common.TaskUpdateInfo{Context: ctx, TaskID: subTaskID, TargetURI: serverURI, UpdateTask: e.External.UpdateTask, TaskRequest: updateRequestBody}
 
+	parentTaskID := taskID
+	ctx, done := common.TrackTask(ctx, subTaskID)
+	defer done()
+
 	var percentComplete int32
 	target, gerr := e.External.GetTarget(uuid)
 	if gerr != nil {
@@ -237,6 +249,11 @@ func (e *ExternalInterface) sendRequest(ctx context.Context, uuid, taskID, serve
 	contactRequest.ContactClient = e.External.ContactClient
 	contactRequest.Plugin = plugin
 
+	if ctx.Err() != nil {
+		subTaskChannel <- http.StatusInternalServerError
+		e.cancelTask(ctx, subTaskID, serverURI, updateRequestBody, percentComplete)
+		return
+	}
 	if StringsEqualFoldFunc(plugin.PreferredAuthType, "XAuthToken") {
 		var err error
 		contactRequest.HTTPMethodType = http.MethodPost
@@ -263,6 +280,11 @@ func (e *ExternalInterface) sendRequest(ctx context.Context, uuid, taskID, serve
 
 	}
 
+	if ctx.Err() != nil {
+		subTaskChannel <- http.StatusInternalServerError
+		e.cancelTask(ctx, subTaskID, serverURI, updateRequestBody, percentComplete)
+		return
+	}
 	target.PostBody = []byte(updateRequestBody)
 	contactRequest.DeviceInfo = target
 	contactRequest.OID = "/ODIM/v1/UpdateService/Actions/UpdateService.SimpleUpdate"
@@ -275,6 +297,7 @@ func (e *ExternalInterface) sendRequest(ctx context.Context, uuid, taskID, serve
 		common.GeneralError(getResponse.StatusCode, getResponse.StatusMessage, errMsg, getResponse.MsgArgs, taskInfo)
 		return
 	}
+	common.AddCompletedOperation(subTaskID, "SimpleUpdate request sent to "+serverURI)
 	if getResponse.StatusCode == http.StatusAccepted {
 		getResponse, err = e.monitorPluginTask(ctx, subTaskChannel, &monitorTaskRequest{
 			subTaskID:         subTaskID,
@@ -295,6 +318,7 @@ func (e *ExternalInterface) sendRequest(ctx context.Context, uuid, taskID, serve
 	resp.StatusCode = http.StatusOK
 	percentComplete = 100
 
+	common.AddCompletedOperation(parentTaskID, "SimpleUpdate of "+serverURI)
 	subTaskChannel <- int32(getResponse.StatusCode)
 	var task = fillTaskData(subTaskID, serverURI, updateRequestBody, resp, common.Completed, common.OK, percentComplete, http.MethodPost)
 	err = e.External.UpdateTask(ctx, task)